@@ -10,6 +10,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/router"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
 	"github.com/agentregistry-dev/agentregistry/internal/version"
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
@@ -65,8 +66,9 @@ func generateSpec(apiVersion string) *huma.OpenAPI {
 
 	// Register all routes. Service and metrics are nil because they are only
 	// captured in handler closures and invoked at request time, not during
-	// route registration.
-	router.RegisterRoutes(api, cfg, nil, nil, nil, nil)
+	// route registration. The job manager is always configured by the server,
+	// so its endpoints are part of the spec.
+	router.RegisterRoutes(api, cfg, nil, nil, nil, &router.RouteOptions{JobManager: jobs.NewManager()})
 
 	return api.OpenAPI()
 }
@@ -21,11 +21,13 @@ var AddPromptCmd = &cobra.Command{
 
 Prompts can be added from the registry using --registry-prompt-name.
 If --registry-url is not provided, the current registry URL is used.
+Use --arg to set the values the prompt template is rendered with.
 
 Examples:
   arctl agent add-prompt my-prompt --registry-prompt-name cool-prompt
   arctl agent add-prompt my-prompt --registry-prompt-name cool-prompt --registry-prompt-version 1.0.0
   arctl agent add-prompt my-prompt --registry-prompt-name cool-prompt --registry-url https://registry.example.com
+  arctl agent add-prompt reviewer --registry-prompt-name code-review --arg language=Go --arg strict=true
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: runAddPrompt,
@@ -36,13 +38,16 @@ var (
 	promptRegistryURL           string
 	promptRegistryPromptName    string
 	promptRegistryPromptVersion string
+	promptArguments             []string
 )
 
 func init() {
 	AddPromptCmd.Flags().StringVar(&promptProjectDir, "project-dir", ".", "Project directory")
 	AddPromptCmd.Flags().StringVar(&promptRegistryURL, "registry-url", "", "Registry URL (defaults to the currently configured registry)")
 	AddPromptCmd.Flags().StringVar(&promptRegistryPromptName, "registry-prompt-name", "", "Prompt name in the registry")
-	AddPromptCmd.Flags().StringVar(&promptRegistryPromptVersion, "registry-prompt-version", "latest", "Prompt version to pull from the registry (defaults to latest)")
+	AddPromptCmd.Flags().StringVar(&promptRegistryPromptVersion, "registry-prompt-version", "latest", "Prompt version or semver range (e.g. ^1.2) to pull from the registry (defaults to latest)")
+
+	AddPromptCmd.Flags().StringArrayVar(&promptArguments, "arg", nil, "Prompt argument value (NAME=VALUE), may be repeated")
 
 	_ = AddPromptCmd.MarkFlagRequired("registry-prompt-name")
 }
@@ -70,7 +75,10 @@ func addPromptCmd(name string) error {
 		fmt.Printf("Loaded manifest for agent '%s' from %s\n", manifest.Name, resolvedDir)
 	}
 
-	ref := buildPromptRef(name)
+	ref, err := buildPromptRef(name)
+	if err != nil {
+		return err
+	}
 	if err := checkDuplicatePrompt(manifest, ref.Name); err != nil {
 		return err
 	}
@@ -87,19 +95,32 @@ func addPromptCmd(name string) error {
 	return nil
 }
 
-func buildPromptRef(name string) models.PromptRef {
+func buildPromptRef(name string) (models.PromptRef, error) {
 	// Default to the current registry URL if not explicitly provided
 	url := promptRegistryURL
 	if url == "" {
 		url = agentutils.GetDefaultRegistryURL()
 	}
 
+	var arguments map[string]string
+	for _, arg := range promptArguments {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return models.PromptRef{}, fmt.Errorf("invalid --arg %q (expected NAME=VALUE)", arg)
+		}
+		if arguments == nil {
+			arguments = make(map[string]string)
+		}
+		arguments[key] = value
+	}
+
 	return models.PromptRef{
 		Name:                  name,
 		RegistryURL:           url,
 		RegistryPromptName:    promptRegistryPromptName,
 		RegistryPromptVersion: promptRegistryPromptVersion,
-	}
+		Arguments:             arguments,
+	}, nil
 }
 
 func checkDuplicatePrompt(manifest *models.AgentManifest, name string) error {
@@ -47,7 +47,7 @@ func init() {
 	AddSkillCmd.Flags().StringVar(&skillImage, "image", "", "Docker image containing the skill")
 	AddSkillCmd.Flags().StringVar(&skillRegistryURL, "registry-url", "", "Registry URL (defaults to the currently configured registry)")
 	AddSkillCmd.Flags().StringVar(&skillRegistrySkillName, "registry-skill-name", "", "Skill name in the registry")
-	AddSkillCmd.Flags().StringVar(&skillRegistrySkillVersion, "registry-skill-version", "", "Skill version to pull from the registry (defaults to latest)")
+	AddSkillCmd.Flags().StringVar(&skillRegistrySkillVersion, "registry-skill-version", "", "Skill version or semver range (e.g. ^1.2) to pull from the registry (defaults to latest)")
 }
 
 func runAddSkill(cmd *cobra.Command, args []string) error {
@@ -35,6 +35,8 @@ func init() {
 	AgentCmd.AddCommand(AddMcpCmd)
 	AgentCmd.AddCommand(PublishCmd)
 	AgentCmd.AddCommand(DeleteCmd)
+	AgentCmd.AddCommand(DeprecateCmd)
+	AgentCmd.AddCommand(UndeprecateCmd)
 	AgentCmd.AddCommand(ListCmd)
 	AgentCmd.AddCommand(ShowCmd)
 }
@@ -18,7 +18,11 @@ var BuildCmd = &cobra.Command{
 	Long: `Build Docker images for an agent project created with the init command.
 
 This command looks for agent.yaml in the specified directory, regenerates template artifacts,
-and invokes docker build (plus optional push) for both the agent and any command-type MCP servers.`,
+and invokes docker build (plus optional push) for both the agent and any command-type MCP servers.
+
+Registry MCP servers, skills and prompts are locked to concrete versions in agent.lock.
+Versions already in agent.lock are kept until the refs in agent.yaml change; delete
+agent.lock to pick up newer versions that match the same ranges.`,
 	Args:    cobra.ExactArgs(1),
 	RunE:    runBuild,
 	Example: `arctl agent build ./my-agent`,
@@ -51,6 +55,10 @@ func runBuild(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load agent.yaml: %w", err)
 	}
+	manifest, err = lockProject(projectDir, manifest)
+	if err != nil {
+		return err
+	}
 
 	if err := project.RegenerateMcpTools(projectDir, manifest, verbose); err != nil {
 		return fmt.Errorf("failed to regenerate mcp_tools.py: %w", err)
@@ -0,0 +1,12 @@
+package agent
+
+import (
+	"github.com/agentregistry-dev/agentregistry/internal/cli/common"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+)
+
+// DeprecateCmd and UndeprecateCmd set the status of agent versions.
+var DeprecateCmd, UndeprecateCmd = common.NewDeprecateCommands("agent",
+	func() *client.Client { return apiClient },
+	(*client.Client).SetAgentStatus,
+)
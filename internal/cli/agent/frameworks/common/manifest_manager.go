@@ -18,6 +18,10 @@ var envVarPattern = regexp.MustCompile(`\$\{[^}]+\}`)
 
 const ManifestFileName = "agent.yaml"
 
+// LockFileName is the file next to agent.yaml that pins the versions of the
+// agent's registry MCP servers, skills and prompts.
+const LockFileName = "agent.lock"
+
 // AgentManifestValidator validates agent manifests.
 type AgentManifestValidator struct{}
 
@@ -148,6 +152,26 @@ func (m *Manager) Save(man *models.AgentManifest) error {
 	return m.Manager.Save(man)
 }
 
+// LockManager wraps the generic manifest manager for agent.lock files.
+type LockManager struct {
+	*manifest.Manager[*models.AgentLock]
+}
+
+// NewLockManager creates a new agent.lock manager.
+func NewLockManager(projectRoot string) *LockManager {
+	return &LockManager{
+		Manager: manifest.NewManager[*models.AgentLock](projectRoot, LockFileName, nil),
+	}
+}
+
+// LoadIfExists returns the lock file, or nil when the project has none.
+func (m *LockManager) LoadIfExists() (*models.AgentLock, error) {
+	if !m.Exists() {
+		return nil, nil
+	}
+	return m.Load()
+}
+
 // NewProjectManifest creates a new AgentManifest with the given values.
 func NewProjectManifest(agentName, language, framework, modelProvider, modelName, description string, mcpServers []models.McpServerType) *models.AgentManifest {
 	return &models.AgentManifest{
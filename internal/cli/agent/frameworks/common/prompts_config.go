@@ -7,24 +7,16 @@ import (
 	"path/filepath"
 
 	"github.com/agentregistry-dev/agentregistry/internal/utils"
-	"github.com/agentregistry-dev/agentregistry/pkg/models"
 )
 
 // PythonPrompt represents the JSON structure written to prompts.json for the Python agent.
-// Each prompt is a named text blob (the instruction content).
+// Each prompt is a named text blob (the instruction content, rendered with the
+// arguments of its manifest reference).
 type PythonPrompt struct {
 	Name    string `json:"name"`
 	Content string `json:"content"`
 }
 
-// PythonPromptFromResponse extracts the text content from a PromptJSON.
-func PythonPromptFromResponse(name string, prompt *models.PromptJSON) PythonPrompt {
-	return PythonPrompt{
-		Name:    name,
-		Content: prompt.Content,
-	}
-}
-
 // ComputePromptsConfigPath returns the config directory and file path for prompts.
 // If BaseDir or AgentName is empty, both returned paths are empty.
 func ComputePromptsConfigPath(target *MCPConfigTarget) (configDir string, configPath string) {
@@ -0,0 +1,37 @@
+package agent
+
+import (
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
+	agentutils "github.com/agentregistry-dev/agentregistry/internal/cli/agent/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// lockProject resolves the registry references of a project's manifest,
+// keeping the versions already in its agent.lock where they still apply, writes
+// the lock back and returns the manifest pinned to it.
+func lockProject(projectDir string, manifest *models.AgentManifest) (*models.AgentManifest, error) {
+	lockMgr := common.NewLockManager(projectDir)
+	existing, err := lockMgr.LoadIfExists()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", common.LockFileName, err)
+	}
+
+	lock, err := agentutils.ResolveAgentLock(manifest, existing, verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock registry versions: %w", err)
+	}
+
+	// Projects without registry references don't need a lock file.
+	if existing != nil || len(lock.McpServers)+len(lock.Skills)+len(lock.Prompts) > 0 {
+		if err := lockMgr.Save(lock); err != nil {
+			return nil, err
+		}
+		if verbose {
+			fmt.Printf("[lock-resolver] Wrote %s\n", lockMgr.Path())
+		}
+	}
+
+	return manifest.Pinned(lock), nil
+}
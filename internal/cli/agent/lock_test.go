@@ -0,0 +1,117 @@
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func newLockTestRegistry(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/v0/skills/pdf/versions":
+			var skills []models.SkillResponse
+			for _, version := range []string{"1.1.0", "1.2.0", "1.3.1", "2.0.0"} {
+				skills = append(skills, models.SkillResponse{Skill: models.SkillJSON{Name: "pdf", Version: version}})
+			}
+			_ = json.NewEncoder(w).Encode(models.SkillListResponse{Skills: skills})
+		case "/v0/prompts/system/versions/latest":
+			_ = json.NewEncoder(w).Encode(models.PromptResponse{Prompt: models.PromptJSON{Name: "system", Version: "0.7.0"}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLockProject_WritesLockAndKeepsLockedVersions(t *testing.T) {
+	var requests []string
+	server := newLockTestRegistry(t, &requests)
+	dir := t.TempDir()
+	manifest := baseManifest()
+	manifest.Skills = []models.SkillRef{{Name: "pdf", RegistryURL: server.URL, RegistrySkillName: "pdf", RegistrySkillVersion: "^1.2"}}
+	manifest.Prompts = []models.PromptRef{{Name: "system", RegistryURL: server.URL, RegistryPromptName: "system"}}
+
+	pinned, err := lockProject(dir, manifest)
+	if err != nil {
+		t.Fatalf("lockProject() error: %v", err)
+	}
+	if got := pinned.Skills[0].RegistrySkillVersion; got != "1.3.1" {
+		t.Errorf("pinned skill version = %q, want 1.3.1", got)
+	}
+	if got := pinned.Prompts[0].RegistryPromptVersion; got != "0.7.0" {
+		t.Errorf("pinned prompt version = %q, want 0.7.0", got)
+	}
+
+	lock, err := common.NewLockManager(dir).Load()
+	if err != nil {
+		t.Fatalf("load agent.lock: %v", err)
+	}
+	if err := lock.CheckCurrent(manifest); err != nil {
+		t.Fatalf("written lock is not current: %v", err)
+	}
+
+	requests = nil
+	if _, err := lockProject(dir, manifest); err != nil {
+		t.Fatalf("lockProject() second run error: %v", err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("locked versions were resolved again: %v", requests)
+	}
+}
+
+func TestLockProject_ReportsConflictingRefs(t *testing.T) {
+	var requests []string
+	server := newLockTestRegistry(t, &requests)
+	manifest := baseManifest()
+	manifest.Skills = []models.SkillRef{
+		{Name: "pdf", RegistryURL: server.URL, RegistrySkillName: "pdf", RegistrySkillVersion: "~1.1"},
+		{Name: "pdf-next", RegistryURL: server.URL, RegistrySkillName: "pdf", RegistrySkillVersion: "^2"},
+	}
+
+	_, err := lockProject(t.TempDir(), manifest)
+	if err == nil || !strings.Contains(err.Error(), "skill pdf (^2, ~1.1)") || !strings.Contains(err.Error(), "conflict") {
+		t.Fatalf("lockProject() error = %v, want a conflict for skill pdf", err)
+	}
+}
+
+func TestBuildAgentJSONFromManifest_RejectsStaleLock(t *testing.T) {
+	dir := t.TempDir()
+	manifest := baseManifest()
+	manifest.Skills = []models.SkillRef{{Name: "pdf", RegistrySkillName: "pdf", RegistrySkillVersion: "^1.3"}}
+	writeTestManifest(t, dir, manifest)
+	lockMgr := common.NewLockManager(dir)
+	if err := lockMgr.Save(&models.AgentLock{
+		Skills: []models.LockedVersion{{Name: "pdf", Constraints: []string{"^1.2"}, Version: "1.2.0"}},
+	}); err != nil {
+		t.Fatalf("save agent.lock: %v", err)
+	}
+
+	if _, err := buildAgentJSONFromManifest(common.NewManifestManager(dir)); err == nil || !strings.Contains(err.Error(), "out of date") {
+		t.Fatalf("buildAgentJSONFromManifest() error = %v, want stale lock error", err)
+	}
+
+	if err := lockMgr.Save(&models.AgentLock{
+		Skills: []models.LockedVersion{{Name: "pdf", Constraints: []string{"^1.3"}, Version: "1.3.1"}},
+	}); err != nil {
+		t.Fatalf("save agent.lock: %v", err)
+	}
+	agentJSON, err := buildAgentJSONFromManifest(common.NewManifestManager(dir))
+	if err != nil {
+		t.Fatalf("buildAgentJSONFromManifest() error: %v", err)
+	}
+	if agentJSON.Lock == nil || agentJSON.Lock.Skills[0].Version != "1.3.1" {
+		t.Errorf("published lock = %+v, want skill pdf locked to 1.3.1", agentJSON.Lock)
+	}
+	if agentJSON.Skills[0].RegistrySkillVersion != "^1.3" {
+		t.Errorf("published skill version = %q, want the range from agent.yaml", agentJSON.Skills[0].RegistrySkillVersion)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	clicommon "github.com/agentregistry-dev/agentregistry/internal/cli/common"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
 	"github.com/agentregistry-dev/agentregistry/pkg/validators"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/spf13/cobra"
@@ -21,6 +22,7 @@ var (
 	dryRunFlag     bool
 	overwriteFlag  bool
 	publishDesc    string
+	signingFlags   clicommon.SigningFlags
 )
 
 var PublishCmd = &cobra.Command{
@@ -69,6 +71,7 @@ func init() {
 	PublishCmd.Flags().StringVar(&publishDesc, "description", "", "Agent description (when not using agent.yaml)")
 	PublishCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show what would be done without actually doing it")
 	PublishCmd.Flags().BoolVar(&overwriteFlag, "overwrite", false, "Overwrite if the version is already published")
+	signingFlags.AddFlags(PublishCmd)
 }
 
 func runPublish(cmd *cobra.Command, args []string) error {
@@ -120,10 +123,23 @@ func buildAgentJSONFromManifest(mgr *common.Manager) (*models.AgentJSON, error)
 	publishManifest := *manifest
 	publishManifest.TelemetryEndpoint = ""
 
+	// Publish the versions the project was built with. Without a lock, the
+	// registry resolves version ranges when the agent is deployed.
+	lock, err := common.NewLockManager(filepath.Dir(mgr.Path())).LoadIfExists()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", common.LockFileName, err)
+	}
+	if lock != nil {
+		if err := lock.CheckCurrent(manifest); err != nil {
+			return nil, fmt.Errorf("%s is out of date with agent.yaml (%w); run 'arctl agent build' to update it", common.LockFileName, err)
+		}
+	}
+
 	agentJSON := &models.AgentJSON{
 		AgentManifest: publishManifest,
 		Version:       version,
 		Status:        "active",
+		Lock:          lock,
 	}
 
 	if gitRepository != "" {
@@ -184,13 +200,19 @@ func checkAndHandleExistingAgent(agentName, version string) error {
 
 // publishToRegistry handles the actual publish or dry-run output.
 func publishToRegistry(agentJSON *models.AgentJSON) error {
+	sig, err := signingFlags.Sign(signing.AgentPayload(agentJSON))
+	if err != nil {
+		return err
+	}
+	agentJSON.Signature = sig
+
 	if dryRunFlag {
 		j, _ := json.MarshalIndent(agentJSON, "", "  ")
 		printer.PrintInfo(fmt.Sprintf("[DRY RUN] Would publish agent:\n%s", string(j)))
 		return nil
 	}
 
-	_, err := apiClient.CreateAgent(agentJSON)
+	_, err = apiClient.CreateAgent(agentJSON)
 	if err != nil {
 		return fmt.Errorf("failed to publish to registry: %w", err)
 	}
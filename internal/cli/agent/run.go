@@ -74,9 +74,13 @@ func runRun(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to resolve agent %q: %w", target, err)
 	}
-	manifest := agentModel.Agent.AgentManifest
+	lock, err := agentutils.ResolveAgentLock(&agentModel.Agent.AgentManifest, agentModel.Agent.Lock, verbose)
+	if err != nil {
+		return fmt.Errorf("failed to lock registry versions for agent %q: %w", target, err)
+	}
+	manifest := agentModel.Agent.AgentManifest.Pinned(lock)
 	version := agentModel.Agent.Version
-	return runFromManifest(cmd.Context(), &manifest, version, nil, envMap)
+	return runFromManifest(cmd.Context(), manifest, version, nil, envMap)
 }
 
 // runFromDirectory runs an agent from a local project directory. It resolves
@@ -88,6 +92,10 @@ func runFromDirectory(ctx context.Context, projectDir string, envMap map[string]
 	if err != nil {
 		return fmt.Errorf("failed to load agent.yaml: %w", err)
 	}
+	manifest, err = lockProject(projectDir, manifest)
+	if err != nil {
+		return err
+	}
 
 	resolvedSkills, err := resolveSkillsForRuntime(manifest)
 	if err != nil {
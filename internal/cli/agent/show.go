@@ -53,6 +53,9 @@ func runShow(cmd *cobra.Command, args []string) error {
 	t.AddRow("Model Provider", printer.EmptyValueOrDefault(agent.Agent.ModelProvider, "<none>"))
 	t.AddRow("Model Name", printer.EmptyValueOrDefault(agent.Agent.ModelName, "<none>"))
 	t.AddRow("Status", agent.Meta.Official.Status)
+	if agent.Meta.Official.Successor != "" {
+		t.AddRow("Successor", agent.Meta.Official.Successor)
+	}
 	t.AddRow("Website", printer.EmptyValueOrDefault(agent.Agent.WebsiteURL, "<none>"))
 
 	if !agent.Meta.Official.PublishedAt.IsZero() {
@@ -12,12 +12,18 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common/gitutil"
 	arclient "github.com/agentregistry-dev/agentregistry/internal/client"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
 )
 
 type resolvedSkillRef struct {
 	name    string
 	image   string // Docker/OCI image ref (mutually exclusive with repoURL)
 	repoURL string // Git repository URL (mutually exclusive with image)
+
+	// Registry-hosted bundle digest and the client of the registry that
+	// serves it (mutually exclusive with image and repoURL)
+	bundleDigest string
+	bundleClient *arclient.Client
 }
 
 func resolveSkillsForRuntime(manifest *models.AgentManifest) ([]resolvedSkillRef, error) {
@@ -40,10 +46,11 @@ func resolveSkillsForRuntime(manifest *models.AgentManifest) ([]resolvedSkillRef
 	return resolved, nil
 }
 
-// resolveSkillSource resolves a SkillRef to either a Docker image or a GitHub
-// repository URL. When the skill is fetched from the registry, Docker/OCI
-// packages are preferred; if none are available, the skill's GitHub repository
-// is used as a fallback.
+// resolveSkillSource resolves a SkillRef to a registry-hosted bundle, a Docker
+// image or a GitHub repository URL. When the skill is fetched from the
+// registry, a bundle hosted by that registry is preferred since it needs
+// neither Docker nor Git, then Docker/OCI packages, and the skill's GitHub
+// repository is used as a fallback.
 func resolveSkillSource(skill models.SkillRef) (resolvedSkillRef, error) {
 	image := strings.TrimSpace(skill.Image)
 	registrySkillName := strings.TrimSpace(skill.RegistrySkillName)
@@ -65,7 +72,11 @@ func resolveSkillSource(skill models.SkillRef) (resolvedSkillRef, error) {
 		version = "latest"
 	}
 
-	skillResp, err := fetchSkillFromRegistry(skill.RegistryURL, registrySkillName, version)
+	client, err := skillRegistryClient(skill.RegistryURL)
+	if err != nil {
+		return resolvedSkillRef{}, err
+	}
+	skillResp, err := fetchSkillFromRegistry(client, skill.RegistryURL, registrySkillName, version)
 	if err != nil {
 		return resolvedSkillRef{}, err
 	}
@@ -73,6 +84,10 @@ func resolveSkillSource(skill models.SkillRef) (resolvedSkillRef, error) {
 		return resolvedSkillRef{}, fmt.Errorf("skill not found: %s (version %s)", registrySkillName, version)
 	}
 
+	if digest := extractSkillBundleDigest(skillResp); digest != "" {
+		return resolvedSkillRef{name: skill.Name, bundleDigest: digest, bundleClient: client}, nil
+	}
+
 	// Prefer Docker/OCI image if available.
 	imageRef, err := extractSkillImageRef(skillResp)
 	if err == nil {
@@ -99,36 +114,57 @@ func extractSkillRepoURL(skillResp *models.SkillResponse) (string, error) {
 	return "", fmt.Errorf("no git repository found")
 }
 
-func fetchSkillFromRegistry(registryURL, skillName, version string) (*models.SkillResponse, error) {
-	// Use the default configured API client when registry URL is omitted.
+// extractSkillBundleDigest returns the digest of the registry-hosted bundle of
+// a skill, or "" when it has none.
+func extractSkillBundleDigest(skillResp *models.SkillResponse) string {
+	if skillResp == nil {
+		return ""
+	}
+	for _, pkg := range skillResp.Skill.Packages {
+		if pkg.RegistryType == models.SkillPackageTypeRegistry && strings.TrimSpace(pkg.Identifier) != "" {
+			return strings.TrimSpace(pkg.Identifier)
+		}
+	}
+	return ""
+}
+
+// skillRegistryClient returns the client for the registry a skill is fetched
+// from, the default configured API client when registry URL is omitted.
+func skillRegistryClient(registryURL string) (*arclient.Client, error) {
 	if strings.TrimSpace(registryURL) == "" {
 		if apiClient == nil {
 			return nil, fmt.Errorf("API client not initialized")
 		}
-		if strings.EqualFold(version, "latest") {
-			return apiClient.GetSkillByName(skillName)
-		}
-		return apiClient.GetSkillByNameAndVersion(skillName, version)
+		return apiClient, nil
 	}
 
 	baseURL, err := normalizeSkillRegistryURL(registryURL)
 	if err != nil {
 		return nil, err
 	}
-
 	// TODO: DI the client.
-	client := arclient.NewClient(baseURL, "")
+	return arclient.NewClient(baseURL, ""), nil
+}
+
+func fetchSkillFromRegistry(client *arclient.Client, registryURL, skillName, version string) (*models.SkillResponse, error) {
+	if strings.TrimSpace(registryURL) == "" {
+		if strings.EqualFold(version, "latest") {
+			return client.GetSkillByName(skillName)
+		}
+		return client.GetSkillByNameAndVersion(skillName, version)
+	}
+
 	if strings.EqualFold(version, "latest") {
 		resp, err := client.GetSkillByName(skillName)
 		if err != nil {
-			return nil, fmt.Errorf("fetch skill %q from %s: %w", skillName, baseURL, err)
+			return nil, fmt.Errorf("fetch skill %q from %s: %w", skillName, client.BaseURL, err)
 		}
 		return resp, nil
 	}
 
 	resp, err := client.GetSkillByNameAndVersion(skillName, version)
 	if err != nil {
-		return nil, fmt.Errorf("fetch skill %q version %q from %s: %w", skillName, version, baseURL, err)
+		return nil, fmt.Errorf("fetch skill %q version %q from %s: %w", skillName, version, client.BaseURL, err)
 	}
 	return resp, nil
 }
@@ -189,6 +225,10 @@ func materializeSkillsForRuntime(skills []resolvedSkillRef, skillsDir string, ve
 
 		targetDir := filepath.Join(skillsDir, dirName)
 		switch {
+		case skill.bundleDigest != "":
+			if err := extractSkillBundle(skill.bundleClient, skill.bundleDigest, targetDir); err != nil {
+				return fmt.Errorf("materialize skill %q from bundle %s: %w", skill.name, skill.bundleDigest, err)
+			}
 		case skill.image != "":
 			if err := extractSkillImage(skill.image, targetDir, verbose); err != nil {
 				return fmt.Errorf("materialize skill %q from image %q: %w", skill.name, skill.image, err)
@@ -198,12 +238,23 @@ func materializeSkillsForRuntime(skills []resolvedSkillRef, skillsDir string, ve
 				return fmt.Errorf("materialize skill %q from repo %q: %w", skill.name, skill.repoURL, err)
 			}
 		default:
-			return fmt.Errorf("skill %q has no image or repository URL", skill.name)
+			return fmt.Errorf("skill %q has no bundle, image or repository URL", skill.name)
 		}
 	}
 	return nil
 }
 
+func extractSkillBundle(client *arclient.Client, digest, targetDir string) error {
+	if client == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	data, err := client.GetSkillBundle(digest)
+	if err != nil {
+		return err
+	}
+	return skillbundle.Unpack(data, targetDir)
+}
+
 func sanitizeSkillDirName(name string) string {
 	out := strings.TrimSpace(strings.ToLower(name))
 	replacer := strings.NewReplacer(
@@ -206,3 +206,23 @@ func TestExtractSkillRepoURL(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractSkillBundleDigest(t *testing.T) {
+	digest := "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	resp := &models.SkillResponse{
+		Skill: models.SkillJSON{
+			Packages: []models.SkillPackageInfo{
+				{RegistryType: "docker", Identifier: "docker.io/org/skill:1.0.0"},
+				{RegistryType: models.SkillPackageTypeRegistry, Identifier: digest},
+			},
+		},
+	}
+	if got := extractSkillBundleDigest(resp); got != digest {
+		t.Fatalf("extractSkillBundleDigest() = %q, want %q", got, digest)
+	}
+
+	resp.Skill.Packages = resp.Skill.Packages[:1]
+	if got := extractSkillBundleDigest(resp); got != "" {
+		t.Fatalf("extractSkillBundleDigest() = %q, want empty", got)
+	}
+}
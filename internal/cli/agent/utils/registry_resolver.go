@@ -9,6 +9,7 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
 	"github.com/agentregistry-dev/agentregistry/internal/registry"
+	versionpkg "github.com/agentregistry-dev/agentregistry/internal/version"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -236,26 +237,23 @@ func ResolveManifestPrompts(manifest *models.AgentManifest, verbose bool) ([]com
 			clients[registryURL] = apiClient
 		}
 
-		var promptResp *models.PromptResponse
-		var err error
-		if promptVersion != "" {
-			promptResp, err = apiClient.GetPromptByNameAndVersion(promptName, promptVersion)
-		} else {
-			promptResp, err = apiClient.GetPromptByName(promptName)
+		if promptVersion == "" {
+			promptVersion = "latest"
 		}
+		rendered, err := apiClient.RenderPrompt(promptName, promptVersion, ref.Arguments)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch prompt %q from registry: %w", promptName, err)
+			return nil, fmt.Errorf("failed to render prompt %q from registry: %w", promptName, err)
 		}
-		if promptResp == nil {
+		if rendered == nil {
 			return nil, fmt.Errorf("prompt %q not found in registry at %s", promptName, registryURL)
 		}
 
 		if verbose {
 			fmt.Printf("[prompt-resolver] [%d] Successfully resolved prompt %q (version=%q, content length=%d)\n",
-				i, ref.Name, promptResp.Prompt.Version, len(promptResp.Prompt.Content))
+				i, ref.Name, rendered.Version, len(rendered.Content))
 		}
 
-		resolved = append(resolved, common.PythonPromptFromResponse(ref.Name, &promptResp.Prompt))
+		resolved = append(resolved, common.PythonPrompt{Name: ref.Name, Content: rendered.Content})
 	}
 
 	if verbose {
@@ -265,6 +263,104 @@ func ResolveManifestPrompts(manifest *models.AgentManifest, verbose bool) ([]com
 	return resolved, nil
 }
 
+// ResolveAgentLock pins every registry MCP server, skill and prompt of the
+// manifest to a concrete version. Version refs may be exact versions, semver
+// ranges such as ^1.2 or ~0.4, or empty for latest. Entries of existing that
+// still match the manifest are kept, so a project keeps the versions it was
+// locked to until its agent.yaml changes. Refs to the same resource must agree
+// on a single version; conflicts are reported rather than resolved.
+func ResolveAgentLock(manifest *models.AgentManifest, existing *models.AgentLock, verbose bool) (*models.AgentLock, error) {
+	return existing.Complete(manifest, func(kind models.RegistryRefKind, ref models.RegistryRef) (string, error) {
+		registryURL := ref.RegistryURL
+		if registryURL == "" {
+			registryURL = defaultRegistryURL
+		}
+		if verbose {
+			fmt.Printf("[lock-resolver] Resolving %s %q (constraints=%v registryURL=%q)\n", kind, ref.Name, ref.Constraints, registryURL)
+		}
+
+		version, err := versionpkg.Select(ref.Constraints, func() ([]string, error) {
+			return fetchRegistryVersions(kind, registryURL, ref.Name)
+		})
+		if err != nil {
+			return "", err
+		}
+		if version == "" {
+			if version, err = fetchLatestRegistryVersion(kind, registryURL, ref.Name); err != nil {
+				return "", err
+			}
+		}
+
+		if verbose {
+			fmt.Printf("[lock-resolver] Locked %s %q to version %q\n", kind, ref.Name, version)
+		}
+		return version, nil
+	})
+}
+
+// fetchRegistryVersions lists the published versions of a registry resource.
+func fetchRegistryVersions(kind models.RegistryRefKind, registryURL, name string) ([]string, error) {
+	var versions []string
+	switch kind {
+	case models.RegistryRefMCPServer:
+		servers, err := registry.NewClient().FetchServerVersions(registryURL, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch versions of server %q: %w", name, err)
+		}
+		for _, server := range servers {
+			versions = append(versions, server.Server.Version)
+		}
+	case models.RegistryRefSkill:
+		skills, err := client.NewClient(registryURL, "").GetSkillVersions(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch versions of skill %q: %w", name, err)
+		}
+		for _, skill := range skills {
+			versions = append(versions, skill.Skill.Version)
+		}
+	case models.RegistryRefPrompt:
+		prompts, err := client.NewClient(registryURL, "").GetPromptVersions(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch versions of prompt %q: %w", name, err)
+		}
+		for _, prompt := range prompts {
+			versions = append(versions, prompt.Prompt.Version)
+		}
+	}
+	return versions, nil
+}
+
+// fetchLatestRegistryVersion returns the version the registry marks as latest.
+func fetchLatestRegistryVersion(kind models.RegistryRefKind, registryURL, name string) (string, error) {
+	switch kind {
+	case models.RegistryRefMCPServer:
+		server, err := registry.NewClient().FetchServer(registryURL, name, versionpkg.Latest)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch server %q from registry: %w", name, err)
+		}
+		return server.Server.Version, nil
+	case models.RegistryRefSkill:
+		skill, err := client.NewClient(registryURL, "").GetSkillByName(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch skill %q from registry: %w", name, err)
+		}
+		if skill == nil {
+			return "", fmt.Errorf("skill %q not found in registry at %s", name, registryURL)
+		}
+		return skill.Skill.Version, nil
+	case models.RegistryRefPrompt:
+		prompt, err := client.NewClient(registryURL, "").GetPromptByName(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch prompt %q from registry: %w", name, err)
+		}
+		if prompt == nil {
+			return "", fmt.Errorf("prompt %q not found in registry at %s", name, registryURL)
+		}
+		return prompt.Prompt.Version, nil
+	}
+	return "", fmt.Errorf("unknown registry reference kind %q", kind)
+}
+
 // parseManifestEnvVars parses environment variables from the manifest's Env field.
 // The Env field is a []string in "KEY=VALUE" format.
 func parseManifestEnvVars(envSlice []string) map[string]string {
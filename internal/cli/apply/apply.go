@@ -0,0 +1,213 @@
+// Package apply implements `arctl apply` and `arctl diff`, which converge the
+// registry's deployments on a declarative YAML manifest.
+package apply
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	cliCommon "github.com/agentregistry-dev/agentregistry/internal/cli/common"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var apiClient *client.Client
+
+func SetAPIClient(c *client.Client) {
+	apiClient = c
+}
+
+var ApplyCmd = &cobra.Command{
+	Use:   "apply -f <file>",
+	Short: "Converge deployments on a manifest",
+	Long: `Create, update and (with --prune) remove deployments so that they match a
+YAML manifest. Each entry is identified by its provider, type and name; only
+managed deployments on the providers the manifest names are considered.
+
+Env values are never printed. Versions left at 'latest' are resolved to the
+newest published version before comparing. Deployments get exactly the
+declared env, so agents need their model API key in env or secretEnv. The
+registry does not return env values, so a changed value of an env key the
+deployment already sets is only applied along with another change; declare
+values that change in secretEnv.
+
+Example manifest:
+  deployments:
+    - name: io.github.acme/weather
+      type: mcp
+      version: 1.2.0
+      provider: local
+      env:
+        LOG_LEVEL: info
+      secretEnv:
+        WEATHER_API_KEY: weather-api-key
+
+Example:
+  arctl apply -f env.yaml
+  arctl apply -f env.yaml --prune
+  arctl apply -f env.yaml --dry-run`,
+	Args:          cobra.NoArgs,
+	RunE:          runApply,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+var DiffCmd = &cobra.Command{
+	Use:   "diff -f <file>",
+	Short: "Show what apply would change",
+	Long: `Compare a deployment manifest with the live deployments and print the changes
+'arctl apply' would make, without making them.
+
+Example:
+  arctl diff -f env.yaml
+  arctl diff -f env.yaml --prune`,
+	Args:          cobra.NoArgs,
+	RunE:          runDiff,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{ApplyCmd, DiffCmd} {
+		cmd.Flags().StringP("filename", "f", "", "Manifest file to read ('-' for stdin)")
+		cmd.Flags().Bool("prune", false, "Remove deployments on the manifest's providers that the manifest does not declare")
+		_ = cmd.MarkFlagRequired("filename")
+	}
+	ApplyCmd.Flags().Bool("dry-run", false, "Print the changes without applying them")
+	ApplyCmd.Flags().Bool("wait", true, "Wait for created and updated deployments to become ready")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	plan, err := loadPlan(cmd)
+	if err != nil {
+		return err
+	}
+	plan.Print(os.Stdout)
+	return nil
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	plan, err := loadPlan(cmd)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	wait, _ := cmd.Flags().GetBool("wait")
+
+	plan.Print(os.Stdout)
+	if dryRun || plan.Empty() {
+		return nil
+	}
+
+	// Keep going after a failure so one broken entry does not block the rest.
+	var errs []error
+	for _, change := range plan.Changes {
+		if err := applyChange(change, wait); err != nil {
+			errs = append(errs, err)
+			fmt.Printf("✗ %v\n", err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d changes failed: %w", len(errs), len(plan.Changes), errors.Join(errs...))
+	}
+	fmt.Println("Deployments match the manifest.")
+	return nil
+}
+
+// loadPlan reads the manifest named by the command's flags and plans it
+// against the live deployments.
+func loadPlan(cmd *cobra.Command) (*Plan, error) {
+	if apiClient == nil {
+		return nil, fmt.Errorf("API client not initialized")
+	}
+	path, _ := cmd.Flags().GetString("filename")
+	prune, _ := cmd.Flags().GetBool("prune")
+
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := resolveVersions(manifest); err != nil {
+		return nil, err
+	}
+	live, err := apiClient.GetDeployedServers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	return BuildPlan(manifest, live, prune)
+}
+
+// resolveVersions replaces 'latest' with the newest published version so that
+// a deployment pinned by an earlier apply is updated when a new version ships.
+func resolveVersions(manifest *Manifest) error {
+	for i := range manifest.Deployments {
+		spec := &manifest.Deployments[i]
+		if spec.Version != latestVersion {
+			continue
+		}
+		switch spec.Type {
+		case "mcp":
+			server, err := apiClient.GetServerByNameAndVersion(spec.Name, latestVersion)
+			if err != nil {
+				return err
+			}
+			if server == nil {
+				return fmt.Errorf("server not found: %s", spec.Name)
+			}
+			spec.Version = server.Server.Version
+		case "agent":
+			agent, err := apiClient.GetAgentByNameAndVersion(spec.Name, latestVersion)
+			if err != nil {
+				return err
+			}
+			if agent == nil {
+				return fmt.Errorf("agent not found: %s", spec.Name)
+			}
+			spec.Version = agent.Agent.Version
+		}
+	}
+	return nil
+}
+
+func applyChange(change Change, wait bool) error {
+	var deployment *models.Deployment
+	var err error
+	switch change.Action {
+	case ActionCreate:
+		spec := change.Spec
+		deployment, err = apiClient.CreateDeployment(&client.DeploymentRequest{
+			ServerName:     spec.Name,
+			Version:        spec.Version,
+			Env:            spec.desiredEnv(),
+			SecretRefs:     spec.SecretEnv,
+			ProviderConfig: spec.ProviderConfig,
+			PreferRemote:   spec.PreferRemote,
+			ResourceType:   spec.Type,
+			ProviderID:     spec.Provider,
+		})
+		if err != nil {
+			return fmt.Errorf("create %s: %w", describeSpec(spec), err)
+		}
+	case ActionUpdate:
+		deployment, err = apiClient.UpdateDeployment(change.Current.ID, change.Update)
+		if err != nil {
+			return fmt.Errorf("update %s: %w", describeDeployment(change.Current), err)
+		}
+	case ActionDelete:
+		if err := apiClient.RemoveDeploymentByID(change.Current.ID); err != nil {
+			return fmt.Errorf("delete %s: %w", describeDeployment(change.Current), err)
+		}
+		fmt.Printf("✓ deleted %s\n", describeDeployment(change.Current))
+		return nil
+	}
+
+	if wait && deployment.ProviderID != defaultProviderID {
+		if err := cliCommon.WaitForDeploymentReady(apiClient, deployment.ID); err != nil {
+			return fmt.Errorf("%s %s: %w", change.Action, describeDeployment(deployment), err)
+		}
+	}
+	fmt.Printf("✓ %sd %s\n", change.Action, describeDeployment(deployment))
+	return nil
+}
@@ -0,0 +1,156 @@
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultProviderID = "local"
+	latestVersion     = "latest"
+)
+
+// Manifest declares the deployments that should exist on one or more providers.
+//
+// Example:
+//
+//	deployments:
+//	  - name: io.github.acme/weather
+//	    type: mcp
+//	    version: 1.2.0
+//	    provider: kubernetes-default
+//	    namespace: tools
+//	    env:
+//	      LOG_LEVEL: info
+//	    secretEnv:
+//	      WEATHER_API_KEY: weather-api-key
+type Manifest struct {
+	Deployments []DeploymentSpec `yaml:"deployments"`
+}
+
+// DeploymentSpec is the desired state of one deployment. A deployment is
+// identified by its provider, type and name.
+type DeploymentSpec struct {
+	Name    string `yaml:"name"`
+	Type    string `yaml:"type"`
+	Version string `yaml:"version,omitempty"`
+	// Provider is the provider ID to deploy to; defaults to local.
+	Provider  string            `yaml:"provider,omitempty"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Env       map[string]string `yaml:"env,omitempty"`
+	// SecretEnv maps environment variables to the stored secrets they are read from.
+	SecretEnv    map[string]string `yaml:"secretEnv,omitempty"`
+	PreferRemote bool              `yaml:"preferRemote,omitempty"`
+	// ProviderConfig holds provider-specific settings. Only the keys listed
+	// here are compared against the live deployment.
+	ProviderConfig map[string]any `yaml:"providerConfig,omitempty"`
+}
+
+// key identifies the deployment a spec describes.
+func (s *DeploymentSpec) key() deploymentKey {
+	return deploymentKey{provider: s.Provider, resourceType: s.Type, name: s.Name}
+}
+
+// desiredEnv returns the plain environment the deployment should run with,
+// including the namespace the same way `arctl deployments create` passes it.
+func (s *DeploymentSpec) desiredEnv() map[string]string {
+	env := make(map[string]string, len(s.Env)+1)
+	for key, value := range s.Env {
+		env[key] = value
+	}
+	if s.Namespace != "" {
+		env["KAGENT_NAMESPACE"] = s.Namespace
+	}
+	return env
+}
+
+// LoadManifest reads a manifest from path, or from stdin when path is "-".
+func LoadManifest(path string) (*Manifest, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	manifest, err := ParseManifest(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// ParseManifest decodes and validates a manifest and fills in defaults.
+// Unknown fields are rejected so that typos do not silently drop settings.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&manifest); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	seen := map[deploymentKey]bool{}
+	for i := range manifest.Deployments {
+		spec := &manifest.Deployments[i]
+		spec.Name = strings.TrimSpace(spec.Name)
+		spec.Type = strings.ToLower(strings.TrimSpace(spec.Type))
+		spec.Version = strings.TrimSpace(spec.Version)
+		spec.Provider = strings.TrimSpace(spec.Provider)
+		if spec.Version == "" {
+			spec.Version = latestVersion
+		}
+		if spec.Provider == "" {
+			spec.Provider = defaultProviderID
+		}
+
+		if spec.Name == "" {
+			return nil, fmt.Errorf("deployments[%d]: name is required", i)
+		}
+		if spec.Type != "mcp" && spec.Type != "agent" {
+			return nil, fmt.Errorf("deployments[%d] (%s): type must be 'mcp' or 'agent'", i, spec.Name)
+		}
+		for key := range spec.SecretEnv {
+			if _, ok := spec.desiredEnv()[key]; ok {
+				return nil, fmt.Errorf("deployments[%d] (%s): %s is set in both env and secretEnv", i, spec.Name, key)
+			}
+		}
+		if seen[spec.key()] {
+			return nil, fmt.Errorf("deployments[%d]: %s %s is declared more than once for provider %s", i, spec.Type, spec.Name, spec.Provider)
+		}
+		seen[spec.key()] = true
+
+		// Provider config is compared with values decoded from the API, so give
+		// it the same JSON types (e.g. float64 numbers, map[string]any objects).
+		if spec.ProviderConfig != nil {
+			normalized, err := normalizeJSON(spec.ProviderConfig)
+			if err != nil {
+				return nil, fmt.Errorf("deployments[%d] (%s): providerConfig: %w", i, spec.Name, err)
+			}
+			spec.ProviderConfig = normalized
+		}
+	}
+	return &manifest, nil
+}
+
+func normalizeJSON(value map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
@@ -0,0 +1,251 @@
+package apply
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+type deploymentKey struct {
+	provider     string
+	resourceType string
+	name         string
+}
+
+func liveKey(d *models.Deployment) deploymentKey {
+	return deploymentKey{provider: d.ProviderID, resourceType: d.ResourceType, name: d.ServerName}
+}
+
+// Action is what apply does to converge one deployment.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is one step of a plan.
+type Change struct {
+	Action Action
+	// Spec is the desired state; nil for deletes.
+	Spec *DeploymentSpec
+	// Current is the live deployment; nil for creates.
+	Current *models.Deployment
+	// Update holds the fields an update changes.
+	Update *models.DeploymentUpdate
+	// Details describe what differs, without env values.
+	Details []string
+}
+
+// Plan is the set of changes that converges live deployments on a manifest.
+type Plan struct {
+	Changes   []Change
+	Unchanged int
+	// Unmanaged lists live deployments on the manifest's providers that the
+	// manifest does not declare. They are only removed with prune.
+	Unmanaged []*models.Deployment
+}
+
+// Empty reports whether applying the plan would change nothing.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// BuildPlan compares a manifest with the live deployments. Only managed
+// deployments on providers the manifest names are considered, so a manifest
+// for one environment never touches another. Spec versions should already be
+// resolved; a spec left at "latest" matches any deployed version. A declared
+// resource with more than one live deployment is an error, since apply cannot
+// tell which of them the manifest describes.
+func BuildPlan(manifest *Manifest, live []*models.Deployment, prune bool) (*Plan, error) {
+	providers := map[string]bool{}
+	for i := range manifest.Deployments {
+		providers[manifest.Deployments[i].Provider] = true
+	}
+	byKey := map[deploymentKey][]*models.Deployment{}
+	for _, d := range live {
+		if d.Origin == "discovered" || !providers[d.ProviderID] {
+			continue
+		}
+		byKey[liveKey(d)] = append(byKey[liveKey(d)], d)
+	}
+
+	plan := &Plan{}
+	for i := range manifest.Deployments {
+		spec := &manifest.Deployments[i]
+		matches := byKey[spec.key()]
+		delete(byKey, spec.key())
+		if len(matches) == 0 {
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Spec: spec})
+			continue
+		}
+		if len(matches) > 1 {
+			ids := make([]string, 0, len(matches))
+			for _, d := range matches {
+				ids = append(ids, truncateID(d.ID))
+			}
+			slices.Sort(ids)
+			return nil, fmt.Errorf("%s has %d live deployments (%s); remove all but one before applying",
+				describeSpec(spec), len(matches), strings.Join(ids, ", "))
+		}
+		current := matches[0]
+
+		update, details := diffDeployment(spec, current)
+		if update == nil && current.Status == models.DeploymentStatusFailed {
+			// An update with no changes rolls the deployment out again.
+			update, details = &models.DeploymentUpdate{}, []string{"redeploy failed deployment"}
+		}
+		if update == nil {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{
+			Action:  ActionUpdate,
+			Spec:    spec,
+			Current: current,
+			Update:  update,
+			Details: details,
+		})
+	}
+	for _, remaining := range byKey {
+		plan.Unmanaged = append(plan.Unmanaged, remaining...)
+	}
+	slices.SortFunc(plan.Unmanaged, func(a, b *models.Deployment) int {
+		return cmp.Or(
+			cmp.Compare(a.ProviderID, b.ProviderID),
+			cmp.Compare(a.ResourceType, b.ResourceType),
+			cmp.Compare(a.ServerName, b.ServerName),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+
+	if prune {
+		for _, d := range plan.Unmanaged {
+			plan.Changes = append(plan.Changes, Change{Action: ActionDelete, Current: d})
+		}
+		plan.Unmanaged = nil
+	}
+	return plan, nil
+}
+
+// diffDeployment returns the update that brings current to spec, or nil when
+// they already match.
+func diffDeployment(spec *DeploymentSpec, current *models.Deployment) (*models.DeploymentUpdate, []string) {
+	update := &models.DeploymentUpdate{}
+	var details []string
+
+	if spec.Version != latestVersion && spec.Version != current.Version {
+		version := spec.Version
+		update.Version = &version
+		details = append(details, fmt.Sprintf("version %s -> %s", current.Version, spec.Version))
+	}
+	if env := spec.desiredEnv(); !maps.EqualFunc(env, current.Env, envValueMatches) {
+		update.Env = env
+		details = append(details, "env "+describeKeyChanges(current.Env, env))
+	}
+	if secretRefs := maps.Clone(spec.SecretEnv); !maps.Equal(secretRefs, current.SecretRefs) {
+		if secretRefs == nil {
+			secretRefs = map[string]string{}
+		}
+		update.SecretRefs = secretRefs
+		details = append(details, "secretEnv "+describeKeyChanges(current.SecretRefs, secretRefs))
+	}
+	if spec.PreferRemote != current.PreferRemote {
+		preferRemote := spec.PreferRemote
+		update.PreferRemote = &preferRemote
+		details = append(details, fmt.Sprintf("preferRemote %t -> %t", current.PreferRemote, spec.PreferRemote))
+	}
+
+	var changedConfig []string
+	for _, key := range slices.Sorted(maps.Keys(spec.ProviderConfig)) {
+		if !reflect.DeepEqual(spec.ProviderConfig[key], current.ProviderConfig[key]) {
+			changedConfig = append(changedConfig, key)
+		}
+	}
+	if len(changedConfig) > 0 {
+		// Keys the manifest does not mention are kept, since adapters record
+		// their own settings there.
+		providerConfig := maps.Clone(current.ProviderConfig)
+		if providerConfig == nil {
+			providerConfig = models.JSONObject{}
+		}
+		maps.Copy(providerConfig, spec.ProviderConfig)
+		update.ProviderConfig = providerConfig
+		details = append(details, "providerConfig ~"+strings.Join(changedConfig, " ~"))
+	}
+
+	if len(details) == 0 {
+		return nil, nil
+	}
+	return update, details
+}
+
+// envValueMatches compares a desired env value with the registry's. The
+// registry redacts env values, so a redacted value matches any desired value.
+func envValueMatches(desired, current string) bool {
+	return current == desired || current == models.RedactedEnvValue
+}
+
+// describeKeyChanges lists added (+), changed (~) and removed (-) keys.
+func describeKeyChanges(before, after map[string]string) string {
+	var changes []string
+	for _, key := range slices.Sorted(maps.Keys(after)) {
+		previous, ok := before[key]
+		switch {
+		case !ok:
+			changes = append(changes, "+"+key)
+		case !envValueMatches(after[key], previous):
+			changes = append(changes, "~"+key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(before)) {
+		if _, ok := after[key]; !ok {
+			changes = append(changes, "-"+key)
+		}
+	}
+	return strings.Join(changes, " ")
+}
+
+// Print writes the plan in a diff-like form followed by a summary line.
+func (p *Plan) Print(w io.Writer) {
+	counts := map[Action]int{}
+	for _, change := range p.Changes {
+		counts[change.Action]++
+		switch change.Action {
+		case ActionCreate:
+			fmt.Fprintf(w, "+ create %s\n", describeSpec(change.Spec))
+		case ActionUpdate:
+			fmt.Fprintf(w, "~ update %s: %s\n", describeDeployment(change.Current), strings.Join(change.Details, "; "))
+		case ActionDelete:
+			fmt.Fprintf(w, "- delete %s\n", describeDeployment(change.Current))
+		}
+	}
+	for _, d := range p.Unmanaged {
+		fmt.Fprintf(w, "  not in manifest: %s (use --prune to remove)\n", describeDeployment(d))
+	}
+	fmt.Fprintf(w, "Plan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete], p.Unchanged)
+}
+
+func describeSpec(spec *DeploymentSpec) string {
+	return fmt.Sprintf("%s %s@%s on %s", spec.Type, spec.Name, spec.Version, spec.Provider)
+}
+
+func describeDeployment(d *models.Deployment) string {
+	return fmt.Sprintf("%s %s@%s on %s [%s]", d.ResourceType, d.ServerName, d.Version, d.ProviderID, truncateID(d.ID))
+}
+
+// truncateID shows the first 8 characters of a deployment ID for display.
+func truncateID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
@@ -0,0 +1,198 @@
+package apply
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+const testManifest = `
+deployments:
+  - name: io.test/weather
+    type: mcp
+    version: 1.0.0
+    env:
+      LOG_LEVEL: info
+  - name: io.test/fetch
+    type: MCP
+    version: 2.0.0
+    env:
+      LOG_LEVEL: debug
+    secretEnv:
+      API_KEY: fetch-api-key
+  - name: io.test/planner
+    type: agent
+    version: 0.3.0
+    provider: kubernetes-default
+    namespace: agents
+    providerConfig:
+      replicas: 2
+`
+
+func TestParseManifest_AppliesDefaultsAndValidates(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	fetch := manifest.Deployments[1]
+	if fetch.Type != "mcp" || fetch.Provider != "local" {
+		t.Fatalf("fetch spec = %+v, want type mcp on provider local", fetch)
+	}
+	if got := manifest.Deployments[2].desiredEnv()["KAGENT_NAMESPACE"]; got != "agents" {
+		t.Fatalf("KAGENT_NAMESPACE = %q, want agents", got)
+	}
+
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "missing name", yaml: "deployments:\n  - type: mcp\n", wantErr: "name is required"},
+		{name: "bad type", yaml: "deployments:\n  - name: a\n    type: skill\n", wantErr: "type must be"},
+		{name: "unknown field", yaml: "deployments:\n  - name: a\n    type: mcp\n    envs: {}\n", wantErr: "envs"},
+		{name: "duplicate", yaml: "deployments:\n  - name: a\n    type: mcp\n  - name: a\n    type: mcp\n", wantErr: "more than once"},
+		{name: "env and secret", yaml: "deployments:\n  - name: a\n    type: mcp\n    env: {K: v}\n    secretEnv: {K: s}\n", wantErr: "both env and secretEnv"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseManifest([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseManifest() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildPlan_ConvergesOnManifest(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	live := []*models.Deployment{
+		// Matches the manifest; the registry redacts env values.
+		{ID: "dep-weather", ServerName: "io.test/weather", Version: "1.0.0", ResourceType: "mcp", ProviderID: "local",
+			Origin: "managed", Env: map[string]string{"LOG_LEVEL": models.RedactedEnvValue}},
+		// Version and env drifted; the secret is missing.
+		{ID: "dep-fetch", ServerName: "io.test/fetch", Version: "1.0.0", ResourceType: "mcp", ProviderID: "local",
+			Origin: "managed", Env: map[string]string{"LOG_LEVEL": "info", "DEBUG": "1"}},
+		// Not declared.
+		{ID: "dep-orphan", ServerName: "io.test/orphan", Version: "1.0.0", ResourceType: "mcp", ProviderID: "local", Origin: "managed"},
+		// Discovered deployments and other providers are out of scope.
+		{ID: "dep-discovered", ServerName: "io.test/other", Version: "1.0.0", ResourceType: "mcp", ProviderID: "local", Origin: "discovered"},
+		{ID: "dep-elsewhere", ServerName: "io.test/other", Version: "1.0.0", ResourceType: "mcp", ProviderID: "staging", Origin: "managed"},
+	}
+
+	plan, err := BuildPlan(manifest, live, false)
+	if err != nil {
+		t.Fatalf("BuildPlan() error = %v", err)
+	}
+	if plan.Unchanged != 1 {
+		t.Fatalf("Unchanged = %d, want 1", plan.Unchanged)
+	}
+	if len(plan.Changes) != 2 {
+		t.Fatalf("Changes = %+v, want an update and a create", plan.Changes)
+	}
+	update := plan.Changes[0]
+	if update.Action != ActionUpdate || update.Current.ID != "dep-fetch" {
+		t.Fatalf("first change = %+v, want update of dep-fetch", update)
+	}
+	if *update.Update.Version != "2.0.0" || update.Update.Env["LOG_LEVEL"] != "debug" || update.Update.SecretRefs["API_KEY"] != "fetch-api-key" {
+		t.Fatalf("update = %+v", update.Update)
+	}
+	if update.Update.PreferRemote != nil || update.Update.ProviderConfig != nil {
+		t.Fatalf("update changes unchanged fields: %+v", update.Update)
+	}
+	if got := strings.Join(update.Details, "; "); got != "version 1.0.0 -> 2.0.0; env ~LOG_LEVEL -DEBUG; secretEnv +API_KEY" {
+		t.Fatalf("details = %q", got)
+	}
+	if create := plan.Changes[1]; create.Action != ActionCreate || create.Spec.Name != "io.test/planner" {
+		t.Fatalf("second change = %+v, want create of io.test/planner", create)
+	}
+	if len(plan.Unmanaged) != 1 || plan.Unmanaged[0].ID != "dep-orphan" {
+		t.Fatalf("Unmanaged = %+v, want dep-orphan", plan.Unmanaged)
+	}
+
+	pruned, err := BuildPlan(manifest, live, true)
+	if err != nil {
+		t.Fatalf("BuildPlan() error = %v", err)
+	}
+	last := pruned.Changes[len(pruned.Changes)-1]
+	if last.Action != ActionDelete || last.Current.ID != "dep-orphan" || len(pruned.Unmanaged) != 0 {
+		t.Fatalf("pruned plan = %+v, want dep-orphan deleted", pruned)
+	}
+
+	var out bytes.Buffer
+	pruned.Print(&out)
+	if !strings.Contains(out.String(), "Plan: 1 to create, 1 to update, 1 to delete, 1 unchanged.") {
+		t.Fatalf("Print() = %q", out.String())
+	}
+	if strings.Contains(out.String(), "debug") {
+		t.Fatalf("Print() leaks env values: %q", out.String())
+	}
+}
+
+func TestBuildPlan_ComparesOnlyDeclaredProviderConfig(t *testing.T) {
+	manifest, err := ParseManifest([]byte("deployments:\n  - name: a\n    type: agent\n    version: 1.0.0\n    providerConfig:\n      replicas: 2\n"))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	current := &models.Deployment{ID: "dep-a", ServerName: "a", Version: "1.0.0", ResourceType: "agent", ProviderID: "local",
+		Origin: "managed", ProviderConfig: models.JSONObject{"replicas": float64(2), "image": "adapter-owned"}}
+
+	if plan, err := BuildPlan(manifest, []*models.Deployment{current}, false); err != nil || !plan.Empty() {
+		t.Fatalf("BuildPlan() = %+v, %v; want no changes", plan, err)
+	}
+
+	current.ProviderConfig["replicas"] = float64(1)
+	plan, err := BuildPlan(manifest, []*models.Deployment{current}, false)
+	if err != nil {
+		t.Fatalf("BuildPlan() error = %v", err)
+	}
+	if len(plan.Changes) != 1 {
+		t.Fatalf("plan = %+v, want one update", plan.Changes)
+	}
+	got := plan.Changes[0].Update.ProviderConfig
+	if got["replicas"] != float64(2) || got["image"] != "adapter-owned" {
+		t.Fatalf("provider config = %v, want replicas updated and image kept", got)
+	}
+}
+
+func TestBuildPlan_RedeploysFailedDeployments(t *testing.T) {
+	manifest, err := ParseManifest([]byte("deployments:\n  - name: a\n    type: mcp\n    version: 1.0.0\n"))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	current := &models.Deployment{ID: "dep-a", ServerName: "a", Version: "1.0.0", ResourceType: "mcp", ProviderID: "local",
+		Origin: "managed", Status: models.DeploymentStatusFailed}
+
+	plan, err := BuildPlan(manifest, []*models.Deployment{current}, false)
+	if err != nil {
+		t.Fatalf("BuildPlan() error = %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Unchanged != 0 {
+		t.Fatalf("plan = %+v, want one redeploy", plan)
+	}
+	change := plan.Changes[0]
+	if change.Action != ActionUpdate || change.Current.ID != "dep-a" || !reflect.DeepEqual(change.Update, &models.DeploymentUpdate{}) {
+		t.Fatalf("change = %+v, want an empty update of dep-a", change)
+	}
+}
+
+func TestBuildPlan_RejectsDuplicateLiveDeployments(t *testing.T) {
+	manifest, err := ParseManifest([]byte("deployments:\n  - name: a\n    type: mcp\n    version: 1.0.0\n"))
+	if err != nil {
+		t.Fatalf("ParseManifest() error = %v", err)
+	}
+	live := []*models.Deployment{
+		{ID: "dep-a1", ServerName: "a", Version: "1.0.0", ResourceType: "mcp", ProviderID: "local", Origin: "managed"},
+		{ID: "dep-a2", ServerName: "a", Version: "0.9.0", ResourceType: "mcp", ProviderID: "local", Origin: "managed"},
+	}
+
+	_, err = BuildPlan(manifest, live, true)
+	if err == nil || !strings.Contains(err.Error(), "dep-a1, dep-a2") {
+		t.Fatalf("BuildPlan() error = %v, want both deployments named", err)
+	}
+}
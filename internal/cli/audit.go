@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditPrincipal    string
+	auditAction       string
+	auditResourceType string
+	auditResource     string
+	auditRequestID    string
+	auditSince        string
+	auditUntil        string
+	auditLimit        int
+	auditCursor       string
+	auditOutputFormat string
+)
+
+// AuditCmd lists entries from the registry's audit log of mutating operations.
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the audit log of changes made to the registry",
+	Long: `Show who published, edited, deprecated, deleted or deployed what, newest first.
+--since and --until accept RFC3339 timestamps or durations relative to now (e.g. 24h).`,
+	Example: `  arctl audit --since 24h
+  arctl audit --principal octocat --action delete
+  arctl audit --resource-type server --resource io.github.acme/weather -o json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAudit()
+	},
+}
+
+func init() {
+	AuditCmd.Flags().StringVar(&auditPrincipal, "principal", "", "Only show changes made by this principal")
+	AuditCmd.Flags().StringVar(&auditAction, "action", "", "Only show this action (publish, edit, set-status, delete, create, deploy, undeploy, cancel)")
+	AuditCmd.Flags().StringVar(&auditResourceType, "resource-type", "", "Only show this resource type (server, agent, skill, prompt, provider, deployment, mirror)")
+	AuditCmd.Flags().StringVar(&auditResource, "resource", "", "Only show changes to the resource with this name")
+	AuditCmd.Flags().StringVar(&auditRequestID, "request-id", "", "Only show changes made by the request with this X-Request-ID")
+	AuditCmd.Flags().StringVar(&auditSince, "since", "", "Only show changes at or after this time (RFC3339 or duration such as 24h)")
+	AuditCmd.Flags().StringVar(&auditUntil, "until", "", "Only show changes before this time (RFC3339 or duration such as 1h)")
+	AuditCmd.Flags().IntVarP(&auditLimit, "limit", "l", 50, "Maximum number of entries to return")
+	AuditCmd.Flags().StringVar(&auditCursor, "cursor", "", "Cursor from a previous listing to fetch the next page")
+	AuditCmd.Flags().StringVarP(&auditOutputFormat, "output", "o", "table", "Output format (table, json)")
+}
+
+func runAudit() error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	since, err := auditTime("since", auditSince)
+	if err != nil {
+		return err
+	}
+	until, err := auditTime("until", auditUntil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := apiClient.ListAuditEntries(client.AuditOptions{
+		Principal:    auditPrincipal,
+		Action:       auditAction,
+		ResourceType: auditResourceType,
+		ResourceName: auditResource,
+		RequestID:    auditRequestID,
+		Since:        since,
+		Until:        until,
+		Cursor:       auditCursor,
+		Limit:        auditLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if auditOutputFormat == "json" {
+		p := printer.New(printer.OutputTypeJSON, false)
+		if err := p.PrintJSON(resp); err != nil {
+			return fmt.Errorf("failed to output JSON: %w", err)
+		}
+		return nil
+	}
+
+	if len(resp.Entries) == 0 {
+		fmt.Println("No audit entries found")
+		return nil
+	}
+	printAuditTable(resp.Entries)
+	if resp.Metadata.NextCursor != "" {
+		fmt.Printf("\nMore entries available. Use --cursor %s to fetch the next page.\n", resp.Metadata.NextCursor)
+	}
+	return nil
+}
+
+// auditTime converts a --since/--until value to RFC3339. Durations are taken
+// as relative to now.
+func auditTime(flag, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d).UTC().Format(time.RFC3339), nil
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return "", fmt.Errorf("invalid --%s %q: expected RFC3339 timestamp or duration", flag, value)
+	}
+	return value, nil
+}
+
+func printAuditTable(entries []models.AuditEntry) {
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Time", "Principal", "Method", "Action", "Type", "Resource", "Version", "Request ID")
+
+	for _, e := range entries {
+		t.AddRow(
+			printer.FormatTimestamp(e.Timestamp.UTC()),
+			printer.TruncateString(e.Principal, 30),
+			e.AuthMethod,
+			e.Action,
+			e.ResourceType,
+			printer.TruncateString(e.ResourceName, 40),
+			printer.EmptyValueOrDefault(e.ResourceVersion, "-"),
+			printer.EmptyValueOrDefault(e.RequestID, "-"),
+		)
+	}
+
+	if err := t.Render(); err != nil {
+		printer.PrintError(fmt.Sprintf("failed to render table: %v", err))
+	}
+}
@@ -0,0 +1,99 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+// NewDeprecateCommands returns the deprecate and undeprecate commands for a
+// kind of artifact, such as "agent". getClient returns the API client when a
+// command runs, since it is set up after the commands are created, and
+// setStatus is the client method that sets the status of that kind.
+func NewDeprecateCommands[T any](
+	kind string,
+	getClient func() *client.Client,
+	setStatus func(c *client.Client, name, version, status, successor string) (T, error),
+) (deprecate, undeprecate *cobra.Command) {
+	title := strings.ToUpper(kind[:1]) + kind[1:]
+	var deprecateVersion, deprecateSuccessor, undeprecateVersion string
+
+	deprecate = &cobra.Command{
+		Use:   fmt.Sprintf("deprecate <%s-name>", kind),
+		Short: fmt.Sprintf("Deprecate %s %s version in the registry", article(kind), kind),
+		Long: fmt.Sprintf(`Mark %s %s version as deprecated. Deprecated versions stay available,
+but clients show that they should no longer be used. Use --successor to point
+users at the version that replaces it.
+
+Examples:
+  arctl %[2]s deprecate my-%[2]s --version 1.0.0
+  arctl %[2]s deprecate my-%[2]s --version 1.0.0 --successor 2.0.0`, article(kind), kind),
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			c := getClient()
+			if c == nil {
+				return fmt.Errorf("API client not initialized")
+			}
+
+			if _, err := setStatus(c, name, deprecateVersion, "deprecated", deprecateSuccessor); err != nil {
+				return fmt.Errorf("failed to deprecate %s: %w", kind, err)
+			}
+
+			if deprecateSuccessor != "" {
+				printer.PrintSuccess(fmt.Sprintf("%s '%s' version %s deprecated in favor of %s", title, name, deprecateVersion, deprecateSuccessor))
+			} else {
+				printer.PrintSuccess(fmt.Sprintf("%s '%s' version %s deprecated", title, name, deprecateVersion))
+			}
+			return nil
+		},
+	}
+	deprecate.Flags().StringVar(&deprecateVersion, "version", "", "Specify the version to deprecate (required)")
+	deprecate.Flags().StringVar(&deprecateSuccessor, "successor", "", "Version that replaces the deprecated one")
+	_ = deprecate.MarkFlagRequired("version")
+
+	undeprecate = &cobra.Command{
+		Use:   fmt.Sprintf("undeprecate <%s-name>", kind),
+		Short: fmt.Sprintf("Mark a deprecated %s version as active again", kind),
+		Long: fmt.Sprintf(`Mark a deprecated %[1]s version as active again and clear its successor.
+
+Examples:
+  arctl %[1]s undeprecate my-%[1]s --version 1.0.0`, kind),
+		Args:          cobra.ExactArgs(1),
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			c := getClient()
+			if c == nil {
+				return fmt.Errorf("API client not initialized")
+			}
+
+			if _, err := setStatus(c, name, undeprecateVersion, "active", ""); err != nil {
+				return fmt.Errorf("failed to undeprecate %s: %w", kind, err)
+			}
+
+			printer.PrintSuccess(fmt.Sprintf("%s '%s' version %s is active again", title, name, undeprecateVersion))
+			return nil
+		},
+	}
+	undeprecate.Flags().StringVar(&undeprecateVersion, "version", "", "Specify the version to undeprecate (required)")
+	_ = undeprecate.MarkFlagRequired("version")
+
+	return deprecate, undeprecate
+}
+
+// article returns the indefinite article for a kind of artifact.
+func article(kind string) string {
+	if strings.ContainsRune("aeiou", rune(kind[0])) {
+		return "an"
+	}
+	return "a"
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+	"github.com/spf13/cobra"
+)
+
+// SigningFlags are the publish flags that sign the artifacts of a version.
+type SigningFlags struct {
+	KeyPath  string
+	KeyID    string
+	CertPath string
+}
+
+// AddFlags registers the signing flags on a publish command.
+func (f *SigningFlags) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.KeyPath, "signing-key", "", "PEM private key to sign the published artifacts with")
+	cmd.Flags().StringVar(&f.KeyID, "signing-key-id", "", "ID of the signing key in the registry's trusted keys (key-based signing)")
+	cmd.Flags().StringVar(&f.CertPath, "signing-cert", "", "PEM certificate for the signing key, followed by any intermediates (keyless signing)")
+}
+
+// Sign signs payload and returns the signature to attach, or nil when no
+// signing key was given.
+func (f *SigningFlags) Sign(payload signing.Payload) (*models.ArtifactSignature, error) {
+	if f.KeyPath == "" {
+		if f.KeyID != "" || f.CertPath != "" {
+			return nil, fmt.Errorf("--signing-key is required with --signing-key-id or --signing-cert")
+		}
+		return nil, nil
+	}
+	if (f.KeyID == "") == (f.CertPath == "") {
+		return nil, fmt.Errorf("--signing-key needs exactly one of --signing-key-id or --signing-cert")
+	}
+
+	data, err := os.ReadFile(f.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	key, err := signing.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key %s: %w", f.KeyPath, err)
+	}
+	sig, err := signing.Sign(payload.Bytes(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign %s %s: %w", payload.Kind, payload.Name, err)
+	}
+
+	signature := &models.ArtifactSignature{Signature: sig, KeyID: f.KeyID}
+	if f.CertPath != "" {
+		cert, err := os.ReadFile(f.CertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing certificate: %w", err)
+		}
+		signature.Certificate = string(cert)
+	}
+	return signature, nil
+}
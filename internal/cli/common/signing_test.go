@@ -0,0 +1,60 @@
+package common
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+)
+
+func TestSigningFlagsSign(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "signing.key")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	payload := signing.SkillPayload(&models.SkillJSON{
+		Name:     "pdf-tools",
+		Version:  "1.0.0",
+		Packages: []models.SkillPackageInfo{{RegistryType: "docker", Identifier: "ghcr.io/org/pdf-tools:1.0.0"}},
+	})
+
+	unsigned, err := (&SigningFlags{}).Sign(payload)
+	if err != nil || unsigned != nil {
+		t.Fatalf("Sign() without a key = %v, %v; want nil, nil", unsigned, err)
+	}
+	if _, err := (&SigningFlags{KeyPath: keyPath}).Sign(payload); err == nil {
+		t.Error("Sign() without --signing-key-id or --signing-cert succeeded")
+	}
+	if _, err := (&SigningFlags{KeyID: "release"}).Sign(payload); err == nil {
+		t.Error("Sign() with --signing-key-id but no key succeeded")
+	}
+
+	sig, err := (&SigningFlags{KeyPath: keyPath, KeyID: "release"}).Sign(payload)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	policy, err := signing.NewTrustPolicy(map[string]crypto.PublicKey{"release": &key.PublicKey}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := policy.Verify(payload.Bytes(), sig); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"maps"
 	"os"
+	"regexp"
+	"slices"
 	"strings"
 
 	cliCommon "github.com/agentregistry-dev/agentregistry/internal/cli/common"
 	cliUtils "github.com/agentregistry-dev/agentregistry/internal/cli/utils"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +23,8 @@ var CreateCmd = &cobra.Command{
 Example:
   arctl deployments create my-agent --type agent --version latest
   arctl deployments create my-mcp-server --type mcp --version 1.2.3
-  arctl deployments create my-agent --type agent --provider-id kubernetes-default`,
+  arctl deployments create my-agent --type agent --provider-id kubernetes-default
+  arctl deployments create my-mcp-server --type mcp --secret-env GITHUB_TOKEN=github-token`,
 	Args:          cobra.ExactArgs(1),
 	RunE:          runCreate,
 	SilenceUsage:  true,
@@ -37,6 +41,7 @@ func init() {
 	CreateCmd.Flags().StringArrayP("env", "e", []string{}, "Environment variables to set (KEY=VALUE)")
 	CreateCmd.Flags().StringArrayP("arg", "a", []string{}, "Runtime arguments for MCP servers (KEY=VALUE)")
 	CreateCmd.Flags().StringArray("header", []string{}, "HTTP headers for remote MCP servers (KEY=VALUE)")
+	CreateCmd.Flags().StringArray("secret-env", []string{}, "Environment variables read from stored secrets (KEY=SECRET_NAME)")
 
 	_ = CreateCmd.MarkFlagRequired("type")
 }
@@ -64,6 +69,7 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	envFlags, _ := cmd.Flags().GetStringArray("env")
 	argFlags, _ := cmd.Flags().GetStringArray("arg")
 	headerFlags, _ := cmd.Flags().GetStringArray("header")
+	secretEnvFlags, _ := cmd.Flags().GetStringArray("secret-env")
 
 	resourceType = strings.ToLower(resourceType)
 	if resourceType != "agent" && resourceType != "mcp" {
@@ -81,6 +87,10 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	secretRefs, err := cliUtils.ParseEnvFlags(secretEnvFlags)
+	if err != nil {
+		return err
+	}
 
 	// Parse --arg flags (MCP-specific, prefixed with ARG_)
 	for _, arg := range argFlags {
@@ -106,14 +116,14 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	switch resourceType {
 	case "agent":
-		return createAgentDeployment(name, version, envMap, providerID, namespace, wait)
+		return createAgentDeployment(name, version, envMap, secretRefs, providerID, namespace, wait)
 	case "mcp":
-		return createMCPDeployment(name, version, envMap, providerID, namespace, preferRemote, wait)
+		return createMCPDeployment(name, version, envMap, secretRefs, providerID, namespace, preferRemote, wait)
 	}
 	return nil
 }
 
-func createAgentDeployment(name, version string, envMap map[string]string, providerID, namespace string, wait bool) error {
+func createAgentDeployment(name, version string, envMap, secretRefs map[string]string, providerID, namespace string, wait bool) error {
 	agentModel, err := apiClient.GetAgentByNameAndVersion(name, version)
 	if err != nil {
 		return fmt.Errorf("failed to fetch agent %q: %w", name, err)
@@ -124,17 +134,46 @@ func createAgentDeployment(name, version string, envMap map[string]string, provi
 
 	manifest := &agentModel.Agent.AgentManifest
 
-	if err := validateAPIKey(manifest.ModelProvider, envMap); err != nil {
+	// An API key provided as a secret satisfies the check and must not also be
+	// copied from the local environment, since a key cannot be set both ways.
+	provided := maps.Clone(envMap)
+	maps.Copy(provided, secretRefs)
+	if err := validateAPIKey(manifest.ModelProvider, provided); err != nil {
 		return err
 	}
 
 	config := buildAgentDeployConfig(manifest, envMap)
+	for key := range secretRefs {
+		delete(config, key)
+	}
+	// The provider API key is a credential: it is stored as a secret and
+	// referenced, never sent as a plain env value.
+	if envVar, value := providerAPIKey(manifest, envMap); value != "" {
+		if _, ok := secretRefs[envVar]; !ok {
+			secretName, err := storeProviderAPIKey(name, envVar, value)
+			if err != nil {
+				return err
+			}
+			if secretRefs == nil {
+				secretRefs = map[string]string{}
+			}
+			secretRefs[envVar] = secretName
+		}
+	}
 	if namespace != "" {
 		config["KAGENT_NAMESPACE"] = namespace
 	}
+	req := &client.DeploymentRequest{
+		ServerName:   name,
+		Version:      version,
+		Env:          config,
+		SecretRefs:   secretRefs,
+		ResourceType: "agent",
+		ProviderID:   providerID,
+	}
 
 	if providerID == "local" {
-		deployment, err := apiClient.DeployAgent(name, version, config, providerID)
+		deployment, err := apiClient.CreateDeployment(req)
 		if err != nil {
 			return fmt.Errorf("failed to deploy agent: %w", err)
 		}
@@ -142,7 +181,7 @@ func createAgentDeployment(name, version string, envMap map[string]string, provi
 		return nil
 	}
 
-	deployment, err := apiClient.DeployAgent(name, version, config, providerID)
+	deployment, err := apiClient.CreateDeployment(req)
 	if err != nil {
 		return fmt.Errorf("failed to deploy agent: %w", err)
 	}
@@ -162,9 +201,17 @@ func createAgentDeployment(name, version string, envMap map[string]string, provi
 	return nil
 }
 
-func createMCPDeployment(name, version string, envMap map[string]string, providerID, namespace string, preferRemote bool, wait bool) error {
+func createMCPDeployment(name, version string, envMap, secretRefs map[string]string, providerID, namespace string, preferRemote bool, wait bool) error {
 	fmt.Println("\nDeploying server...")
-	deployment, err := apiClient.DeployServer(name, version, envMap, preferRemote, providerID)
+	deployment, err := apiClient.CreateDeployment(&client.DeploymentRequest{
+		ServerName:   name,
+		Version:      version,
+		Env:          envMap,
+		SecretRefs:   secretRefs,
+		PreferRemote: preferRemote,
+		ResourceType: "mcp",
+		ProviderID:   providerID,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to deploy server: %w", err)
 	}
@@ -183,6 +230,9 @@ func createMCPDeployment(name, version string, envMap map[string]string, provide
 	if len(envMap) > 0 {
 		fmt.Printf("Deployment Env: %d setting(s)\n", len(envMap))
 	}
+	if len(secretRefs) > 0 {
+		fmt.Printf("Deployment Secrets: %d setting(s)\n", len(secretRefs))
+	}
 	if providerID == "local" {
 		fmt.Printf("\nServer deployment recorded. The registry will reconcile containers automatically.\n")
 		fmt.Printf("Agent Gateway endpoint: http://localhost:%s/mcp\n", cliCommon.DefaultAgentGatewayPort)
@@ -206,17 +256,15 @@ func validateAPIKey(modelProvider string, extraEnv map[string]string) error {
 	return nil
 }
 
-// buildAgentDeployConfig creates the configuration map with all necessary environment variables.
+// buildAgentDeployConfig creates the configuration map with the plain environment
+// variables of an agent deployment. The provider API key is left out; it is
+// sent as a secret reference instead.
 func buildAgentDeployConfig(manifest *models.AgentManifest, envOverrides map[string]string) map[string]string {
 	config := make(map[string]string)
 	maps.Copy(config, envOverrides)
 
 	if envVar, ok := providerAPIKeys[strings.ToLower(manifest.ModelProvider)]; ok && envVar != "" {
-		if _, exists := config[envVar]; !exists {
-			if value := os.Getenv(envVar); value != "" {
-				config[envVar] = value
-			}
-		}
+		delete(config, envVar)
 	}
 
 	if manifest.TelemetryEndpoint != "" {
@@ -225,3 +273,47 @@ func buildAgentDeployConfig(manifest *models.AgentManifest, envOverrides map[str
 
 	return config
 }
+
+// providerAPIKey returns the API key env var of the agent's model provider and
+// its value, taken from the --env flags or else the local environment. The
+// value is empty when the provider needs no key or none is set.
+func providerAPIKey(manifest *models.AgentManifest, envOverrides map[string]string) (string, string) {
+	envVar, ok := providerAPIKeys[strings.ToLower(manifest.ModelProvider)]
+	if !ok || envVar == "" {
+		return "", ""
+	}
+	if value := envOverrides[envVar]; value != "" {
+		return envVar, value
+	}
+	return envVar, os.Getenv(envVar)
+}
+
+var invalidSecretNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// providerAPIKeySecretName names the secret holding an agent's provider API
+// key, e.g. com.example-my-agent-openai-api-key.
+func providerAPIKeySecretName(agentName, envVar string) string {
+	name := strings.Trim(invalidSecretNameChars.ReplaceAllString(agentName, "-"), "-._")
+	return name + "-" + strings.ToLower(strings.ReplaceAll(envVar, "_", "-"))
+}
+
+// storeProviderAPIKey stores the provider API key of an agent as a secret,
+// replacing the value of an existing one, and returns the secret's name.
+func storeProviderAPIKey(agentName, envVar, value string) (string, error) {
+	secretName := providerAPIKeySecretName(agentName, envVar)
+	existing, err := apiClient.ListSecrets()
+	if err != nil {
+		return "", fmt.Errorf("failed to store %s as a secret: %w", envVar, err)
+	}
+	description := fmt.Sprintf("%s for agent %s", envVar, agentName)
+	if slices.ContainsFunc(existing, func(secret models.Secret) bool { return secret.Name == secretName }) {
+		_, err = apiClient.UpdateSecret(secretName, value, description)
+	} else {
+		_, err = apiClient.CreateSecret(&models.SecretInput{Name: secretName, Value: value, Description: description})
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to store %s as a secret: %w", envVar, err)
+	}
+	fmt.Printf("Stored %s as secret '%s'\n", envVar, secretName)
+	return secretName, nil
+}
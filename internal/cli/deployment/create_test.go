@@ -98,25 +98,17 @@ func TestBuildAgentDeployConfig_WithEnvOverrides(t *testing.T) {
 				ModelProvider: "gemini",
 			},
 			envOverrides: map[string]string{"GOOGLE_API_KEY": "from-flag", "CUSTOM_VAR": "custom-val"},
-			wantKeys:     map[string]string{"GOOGLE_API_KEY": "from-flag", "CUSTOM_VAR": "custom-val"},
-		},
-		{
-			name: "env override takes precedence over os env",
-			manifest: &models.AgentManifest{
-				ModelProvider: "openai",
-			},
-			osEnv:        map[string]string{"OPENAI_API_KEY": "from-os"},
-			envOverrides: map[string]string{"OPENAI_API_KEY": "from-flag"},
-			wantKeys:     map[string]string{"OPENAI_API_KEY": "from-flag"},
+			wantKeys:     map[string]string{"CUSTOM_VAR": "custom-val"},
+			wantAbsent:   []string{"GOOGLE_API_KEY"},
 		},
 		{
-			name: "os env used when no override",
+			name: "os env api key is not copied",
 			manifest: &models.AgentManifest{
 				ModelProvider: "openai",
 			},
 			osEnv:        map[string]string{"OPENAI_API_KEY": "from-os"},
 			envOverrides: map[string]string{},
-			wantKeys:     map[string]string{"OPENAI_API_KEY": "from-os"},
+			wantAbsent:   []string{"OPENAI_API_KEY"},
 		},
 		{
 			name: "telemetry endpoint included",
@@ -125,7 +117,7 @@ func TestBuildAgentDeployConfig_WithEnvOverrides(t *testing.T) {
 				TelemetryEndpoint: "http://otel:4317",
 			},
 			envOverrides: map[string]string{"OPENAI_API_KEY": "key"},
-			wantKeys:     map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://otel:4317", "OPENAI_API_KEY": "key"},
+			wantKeys:     map[string]string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://otel:4317"},
 		},
 		{
 			name: "empty overrides with no os env",
@@ -168,3 +160,24 @@ func TestBuildAgentDeployConfig_WithEnvOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderAPIKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "from-os")
+	manifest := &models.AgentManifest{ModelProvider: "openai"}
+
+	if envVar, value := providerAPIKey(manifest, map[string]string{"OPENAI_API_KEY": "from-flag"}); envVar != "OPENAI_API_KEY" || value != "from-flag" {
+		t.Errorf("providerAPIKey() = %q, %q, want the --env value", envVar, value)
+	}
+	if _, value := providerAPIKey(manifest, nil); value != "from-os" {
+		t.Errorf("providerAPIKey() value = %q, want the local environment value", value)
+	}
+	if envVar, value := providerAPIKey(&models.AgentManifest{ModelProvider: "ollama"}, nil); envVar != "" || value != "" {
+		t.Errorf("providerAPIKey() = %q, %q, want none for a provider without a key", envVar, value)
+	}
+}
+
+func TestProviderAPIKeySecretName(t *testing.T) {
+	if got := providerAPIKeySecretName("com.example/my-agent", "OPENAI_API_KEY"); got != "com.example-my-agent-openai-api-key" {
+		t.Errorf("providerAPIKeySecretName() = %q", got)
+	}
+}
@@ -20,6 +20,10 @@ var DeploymentCmd = &cobra.Command{
 	Example: `arctl deployments list
 arctl deployments create my-agent --type agent
 arctl deployments create my-mcp-server --type mcp
+arctl deployments update <deployment-id> --version 1.3.0
+arctl deployments rollback <deployment-id> --to 2
+arctl deployments render my-agent --type agent --provider-id kubernetes-default --format helm
+arctl deployments logs <deployment-id> --follow
 arctl deployments delete <deployment-id>`,
 }
 
@@ -27,5 +31,9 @@ func init() {
 	DeploymentCmd.AddCommand(CreateCmd)
 	DeploymentCmd.AddCommand(ListCmd)
 	DeploymentCmd.AddCommand(ShowCmd)
+	DeploymentCmd.AddCommand(UpdateCmd)
+	DeploymentCmd.AddCommand(RollbackCmd)
+	DeploymentCmd.AddCommand(RenderCmd)
+	DeploymentCmd.AddCommand(LogsCmd)
 	DeploymentCmd.AddCommand(DeleteCmd)
 }
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	"github.com/spf13/cobra"
 )
@@ -28,7 +29,7 @@ Example:
 
 func init() {
 	ListCmd.Flags().String("type", "", "Filter by resource type (agent or mcp)")
-	ListCmd.Flags().String("status", "", "Filter by deployment status (deploying, deployed, failed, cancelled, discovered)")
+	ListCmd.Flags().String("status", "", "Filter by deployment status (deploying, deployed, failed, cancelled, discovered, drifted, missing, unhealthy)")
 	ListCmd.Flags().String("provider", "", "Filter by provider ID")
 	ListCmd.Flags().StringP("output", "o", "table", "Output format (table, json)")
 }
@@ -123,6 +124,31 @@ func printDeploymentsTable(deployments []*client.DeploymentResponse) {
 	if err := t.Render(); err != nil {
 		printer.PrintError(fmt.Sprintf("failed to render table: %v", err))
 	}
+
+	// Explain why reconciled deployments no longer match their platform.
+	var drifted []*client.DeploymentResponse
+	for _, d := range deployments {
+		if isDriftStatus(d.Status) && d.Error != "" {
+			drifted = append(drifted, d)
+		}
+	}
+	if len(drifted) > 0 {
+		fmt.Println()
+		for _, d := range drifted {
+			fmt.Printf("%s %s: %s\n", truncateID(d.ID), d.Status, d.Error)
+		}
+	}
+}
+
+// isDriftStatus reports whether the reconciler found the deployment's workload
+// out of line with its desired state.
+func isDriftStatus(status string) bool {
+	switch status {
+	case models.DeploymentStatusDrifted, models.DeploymentStatusMissing, models.DeploymentStatusUnhealthy:
+		return true
+	default:
+		return false
+	}
 }
 
 // effectiveStatus returns "discovered" when the deployment was discovered,
@@ -0,0 +1,120 @@
+package deployment
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var LogsCmd = &cobra.Command{
+	Use:   "logs <deployment-id>",
+	Short: "Show logs of a deployment",
+	Long: `Show logs of a deployment.
+
+Example:
+  arctl deployments logs eb2d8231
+  arctl deployments logs eb2d8231 --tail 100
+  arctl deployments logs eb2d8231 --since 10m --follow`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runLogs,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	LogsCmd.Flags().BoolP("follow", "f", false, "Follow log output")
+	LogsCmd.Flags().Int64("tail", 0, "Number of lines to show from the end of the logs (0 shows all)")
+	LogsCmd.Flags().String("since", "", "Show logs since an RFC3339 timestamp or a relative duration (e.g. 10m)")
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	follow, _ := cmd.Flags().GetBool("follow")
+	tail, _ := cmd.Flags().GetInt64("tail")
+	since, _ := cmd.Flags().GetString("since")
+	if tail < 0 {
+		return fmt.Errorf("--tail must not be negative")
+	}
+
+	fullID, err := resolveDeploymentID(args[0])
+	if err != nil {
+		return err
+	}
+
+	if follow {
+		return followLogs(cmd, fullID, tail, since)
+	}
+
+	resp, err := apiClient.GetDeploymentLogs(fullID, tail, since)
+	if err != nil {
+		return err
+	}
+	for _, line := range resp.Logs {
+		fmt.Fprintln(cmd.OutOrStdout(), line)
+	}
+	return nil
+}
+
+func followLogs(cmd *cobra.Command, deploymentID string, tail int64, since string) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	httpReq, err := apiClient.NewDeploymentLogsStreamRequest(ctx, deploymentID, tail, since)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := apiClient.SSEClient().Do(httpReq)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("failed to connect to API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("API error (%d): %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	out := cmd.OutOrStdout()
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+
+		var event client.DeploymentLogEvent
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(data, " ")), &event); err != nil {
+			continue
+		}
+
+		switch event.Type {
+		case "log":
+			fmt.Fprintln(out, event.Line)
+		case "error":
+			return fmt.Errorf("log stream failed: %s", event.Error)
+		case "completed":
+			return nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("connection error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package deployment
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cliUtils "github.com/agentregistry-dev/agentregistry/internal/cli/utils"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var RenderCmd = &cobra.Command{
+	Use:   "render <name>",
+	Short: "Render deployment manifests without deploying",
+	Long: `Render the manifests a provider would apply for an agent or MCP server, without
+deploying it, so they can be committed and applied by a GitOps tool.
+
+Kubernetes providers render plain YAML, a Helm chart or a kustomization. The
+local provider renders its docker compose and agent gateway files. Objects keep
+the namespace they are rendered for.
+
+Secret values are never rendered: variables passed with --secret-env are listed
+with empty values for you to fill in. Unlike create, model API keys are not read
+from your environment; pass them with --secret-env.
+
+Example:
+  arctl deployments render my-agent --type agent --provider-id kubernetes-default
+  arctl deployments render my-agent --type agent --provider-id kubernetes-default --format helm --output-dir ./charts/my-agent
+  arctl deployments render my-mcp-server --type mcp --format kustomize --provider-id kubernetes-default --output-dir ./deploy`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runRender,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	RenderCmd.Flags().String("type", "", "Resource type to render (agent or mcp)")
+	RenderCmd.Flags().String("version", "latest", "Version to render")
+	RenderCmd.Flags().String("provider-id", "", "Deployment target provider ID (defaults to local when omitted)")
+	RenderCmd.Flags().String("namespace", "", "Kubernetes namespace to render for")
+	RenderCmd.Flags().String("format", models.DeploymentRenderFormatYAML, "Output format (yaml, helm or kustomize)")
+	RenderCmd.Flags().String("output-dir", "", "Directory to write the rendered files to (prints to stdout when omitted)")
+	RenderCmd.Flags().Bool("prefer-remote", false, "Prefer using a remote source when available")
+	RenderCmd.Flags().StringArrayP("env", "e", []string{}, "Environment variables to set (KEY=VALUE)")
+	RenderCmd.Flags().StringArrayP("arg", "a", []string{}, "Runtime arguments for MCP servers (KEY=VALUE)")
+	RenderCmd.Flags().StringArray("header", []string{}, "HTTP headers for remote MCP servers (KEY=VALUE)")
+	RenderCmd.Flags().StringArray("secret-env", []string{}, "Environment variables read from stored secrets (KEY=SECRET_NAME)")
+
+	_ = RenderCmd.MarkFlagRequired("type")
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	name := args[0]
+	resourceType, _ := cmd.Flags().GetString("type")
+	version, _ := cmd.Flags().GetString("version")
+	providerID, _ := cmd.Flags().GetString("provider-id")
+	namespace, _ := cmd.Flags().GetString("namespace")
+	format, _ := cmd.Flags().GetString("format")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	preferRemote, _ := cmd.Flags().GetBool("prefer-remote")
+	envFlags, _ := cmd.Flags().GetStringArray("env")
+	argFlags, _ := cmd.Flags().GetStringArray("arg")
+	headerFlags, _ := cmd.Flags().GetStringArray("header")
+	secretEnvFlags, _ := cmd.Flags().GetStringArray("secret-env")
+
+	resourceType = strings.ToLower(resourceType)
+	if resourceType != "agent" && resourceType != "mcp" {
+		return fmt.Errorf("invalid --type %q: must be 'agent' or 'mcp'", resourceType)
+	}
+	format = strings.ToLower(format)
+	switch format {
+	case models.DeploymentRenderFormatYAML, models.DeploymentRenderFormatHelm, models.DeploymentRenderFormatKustomize:
+	default:
+		return fmt.Errorf("invalid --format %q: must be 'yaml', 'helm' or 'kustomize'", format)
+	}
+
+	if version == "" {
+		version = "latest"
+	}
+	if providerID == "" {
+		providerID = "local"
+	}
+
+	envMap, err := cliUtils.ParseEnvFlags(envFlags)
+	if err != nil {
+		return err
+	}
+	secretRefs, err := cliUtils.ParseEnvFlags(secretEnvFlags)
+	if err != nil {
+		return err
+	}
+	for _, arg := range argFlags {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid arg format (expected KEY=VALUE): %s", arg)
+		}
+		envMap["ARG_"+parts[0]] = parts[1]
+	}
+	for _, header := range headerFlags {
+		parts := strings.SplitN(header, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid header format (expected KEY=VALUE): %s", header)
+		}
+		envMap["HEADER_"+parts[0]] = parts[1]
+	}
+	if namespace != "" {
+		envMap["KAGENT_NAMESPACE"] = namespace
+	}
+
+	if resourceType == "agent" {
+		agentModel, err := apiClient.GetAgentByNameAndVersion(name, version)
+		if err != nil {
+			return fmt.Errorf("failed to fetch agent %q: %w", name, err)
+		}
+		if agentModel == nil {
+			return fmt.Errorf("agent not found: %s (version %s)", name, version)
+		}
+		if endpoint := agentModel.Agent.TelemetryEndpoint; endpoint != "" {
+			envMap["OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"] = endpoint
+		}
+	}
+
+	render, err := apiClient.RenderDeployment(&client.DeploymentRenderRequest{
+		DeploymentRequest: client.DeploymentRequest{
+			ServerName:   name,
+			Version:      version,
+			Env:          envMap,
+			SecretRefs:   secretRefs,
+			PreferRemote: preferRemote,
+			ResourceType: resourceType,
+			ProviderID:   providerID,
+		},
+		Format: format,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to render deployment: %w", err)
+	}
+
+	if outputDir == "" {
+		return printRenderedFiles(os.Stdout, render.Files)
+	}
+	if err := writeRenderedFiles(outputDir, render.Files); err != nil {
+		return err
+	}
+	fmt.Printf("Rendered %d file(s) for %s to %s\n", len(render.Files), render.Platform, outputDir)
+	return nil
+}
+
+// printRenderedFiles writes a single file as is and several files as one
+// stream, each headed by its path.
+func printRenderedFiles(w io.Writer, files []models.RenderedFile) error {
+	if len(files) == 1 {
+		_, err := io.WriteString(w, files[0].Content)
+		return err
+	}
+	for i, file := range files {
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "# Source: %s\n%s", file.Path, file.Content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRenderedFiles writes files under dir. Paths must stay inside dir.
+func writeRenderedFiles(dir string, files []models.RenderedFile) error {
+	for _, file := range files {
+		if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+			return fmt.Errorf("rendered file path %q is outside the output directory", file.Path)
+		}
+		path := filepath.Join(dir, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("create directory for %s: %w", file.Path, err)
+		}
+		if err := os.WriteFile(path, []byte(file.Content), 0644); err != nil {
+			return fmt.Errorf("write %s: %w", file.Path, err)
+		}
+	}
+	return nil
+}
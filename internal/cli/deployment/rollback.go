@@ -0,0 +1,75 @@
+package deployment
+
+import (
+	"fmt"
+
+	cliCommon "github.com/agentregistry-dev/agentregistry/internal/cli/common"
+	"github.com/spf13/cobra"
+)
+
+var RollbackCmd = &cobra.Command{
+	Use:   "rollback <deployment-id>",
+	Short: "Roll a deployment back to a previous revision",
+	Long: `Re-apply the version, env, secret references and provider config recorded in a
+previous revision of a deployment. The rollback goes through the same platform
+adapter as an update and is recorded as a new revision. If it fails, the current
+configuration is restored automatically.
+
+Example:
+  arctl deployments rollback abc12345 --to 3`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runRollback,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	RollbackCmd.Flags().Int("to", 0, "Revision to roll back to")
+	RollbackCmd.Flags().Bool("wait", true, "Wait for the deployment to become ready before returning")
+	_ = RollbackCmd.MarkFlagRequired("to")
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	revision, _ := cmd.Flags().GetInt("to")
+	wait, _ := cmd.Flags().GetBool("wait")
+	if revision < 1 {
+		return fmt.Errorf("--to must be a revision number of 1 or more")
+	}
+
+	fullID, err := resolveDeploymentID(args[0])
+	if err != nil {
+		return err
+	}
+	current, err := apiClient.GetDeploymentByID(fullID)
+	if err != nil {
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if current == nil {
+		return fmt.Errorf("deployment not found: %s", args[0])
+	}
+
+	deployment, err := apiClient.RollbackDeployment(fullID, revision)
+	if err != nil {
+		return fmt.Errorf("failed to roll back deployment: %w", err)
+	}
+
+	if deployment.ProviderID != "local" && wait {
+		fmt.Printf("Waiting for '%s' to become ready...\n", deployment.ServerName)
+		if err := cliCommon.WaitForDeploymentReady(apiClient, deployment.ID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Deployment '%s' rolled back to revision %d: %s %s -> %s\n",
+		deployment.ID,
+		revision,
+		deployment.ServerName,
+		cliCommon.FormatVersionForDisplay(current.Version),
+		cliCommon.FormatVersionForDisplay(deployment.Version),
+	)
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	cliCommon "github.com/agentregistry-dev/agentregistry/internal/cli/common"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	"github.com/spf13/cobra"
 )
@@ -18,6 +19,7 @@ var ShowCmd = &cobra.Command{
 
 Example:
   arctl deployments show eb2d8231
+  arctl deployments show eb2d8231 --check-health
   arctl deployments show eb2d8231-def6-7890-ghij-klmnopqrstuv`,
 	Args:          cobra.ExactArgs(1),
 	RunE:          runShow,
@@ -27,6 +29,7 @@ Example:
 
 func init() {
 	ShowCmd.Flags().StringP("output", "o", "table", "Output format (table, json)")
+	ShowCmd.Flags().Bool("check-health", false, "Probe the deployment now instead of showing the last health check")
 }
 
 func runShow(cmd *cobra.Command, args []string) error {
@@ -35,6 +38,7 @@ func runShow(cmd *cobra.Command, args []string) error {
 	}
 
 	outputFormat, _ := cmd.Flags().GetString("output")
+	checkHealth, _ := cmd.Flags().GetBool("check-health")
 
 	fullID, err := resolveDeploymentID(args[0])
 	if err != nil {
@@ -49,6 +53,14 @@ func runShow(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("deployment not found: %s", args[0])
 	}
 
+	if checkHealth {
+		health, err := apiClient.GetDeploymentHealth(dep.ID, true)
+		if err != nil {
+			return err
+		}
+		dep.Health = health
+	}
+
 	// Redact env values to avoid leaking secrets (API keys, etc.)
 	dep.Env = redactEnv(dep.Env)
 
@@ -91,6 +103,14 @@ func runShow(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if dep.Health != nil {
+		t.AddRow("Health", formatDeploymentHealth(dep.Health))
+		if dep.Health.Endpoint != "" {
+			t.AddRow("Health Endpoint", dep.Health.Endpoint)
+		}
+		t.AddRow("Health Checked", printer.FormatAge(dep.Health.CheckedAt))
+	}
+
 	if len(dep.Env) > 0 {
 		t.AddRow("Env Vars", fmt.Sprintf("%d configured", len(dep.Env)))
 	}
@@ -102,6 +122,18 @@ func runShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatDeploymentHealth summarizes a probe result, e.g. "healthy (42ms, 5 tools)".
+func formatDeploymentHealth(health *models.DeploymentHealth) string {
+	if !health.Healthy {
+		return "unhealthy: " + health.Error
+	}
+	details := []string{fmt.Sprintf("%dms", health.LatencyMs)}
+	if health.ToolCount != nil {
+		details = append(details, fmt.Sprintf("%d tools", *health.ToolCount))
+	}
+	return fmt.Sprintf("healthy (%s)", strings.Join(details, ", "))
+}
+
 // redactEnv returns a copy of the env map with all values replaced by "***".
 func redactEnv(env map[string]string) map[string]string {
 	if len(env) == 0 {
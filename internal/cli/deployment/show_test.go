@@ -0,0 +1,39 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestFormatDeploymentHealth(t *testing.T) {
+	toolCount := 5
+	tests := []struct {
+		name   string
+		health *models.DeploymentHealth
+		want   string
+	}{
+		{
+			name:   "mcp server",
+			health: &models.DeploymentHealth{Healthy: true, LatencyMs: 42, ToolCount: &toolCount},
+			want:   "healthy (42ms, 5 tools)",
+		},
+		{
+			name:   "agent",
+			health: &models.DeploymentHealth{Healthy: true, LatencyMs: 7},
+			want:   "healthy (7ms)",
+		},
+		{
+			name:   "failed probe",
+			health: &models.DeploymentHealth{Error: "mcp initialize: connection refused"},
+			want:   "unhealthy: mcp initialize: connection refused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatDeploymentHealth(tt.health); got != tt.want {
+				t.Fatalf("formatDeploymentHealth() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
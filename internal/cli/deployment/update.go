@@ -0,0 +1,139 @@
+package deployment
+
+import (
+	"fmt"
+	"maps"
+
+	cliCommon "github.com/agentregistry-dev/agentregistry/internal/cli/common"
+	cliUtils "github.com/agentregistry-dev/agentregistry/internal/cli/utils"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var UpdateCmd = &cobra.Command{
+	Use:   "update <deployment-id>",
+	Short: "Update a deployment in place",
+	Long: `Roll a deployment to a new version or configuration without changing its ID.
+Env and secret settings are merged onto the deployment's current values. If the
+rollout fails, the previous configuration is restored automatically.
+
+Example:
+  arctl deployments update abc12345 --version 1.3.0
+  arctl deployments update abc12345 --env LOG_LEVEL=debug --unset-env DEBUG
+  arctl deployments update abc12345 --secret-env GITHUB_TOKEN=github-token-v2`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runUpdate,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	UpdateCmd.Flags().String("version", "", "Version to roll to (use 'latest' for the newest published version)")
+	UpdateCmd.Flags().StringArrayP("env", "e", []string{}, "Environment variables to set (KEY=VALUE)")
+	UpdateCmd.Flags().StringArray("unset-env", []string{}, "Environment variables or secret references to remove (KEY)")
+	UpdateCmd.Flags().StringArray("secret-env", []string{}, "Environment variables read from stored secrets (KEY=SECRET_NAME)")
+	UpdateCmd.Flags().Bool("prefer-remote", false, "Prefer using a remote source when available")
+	UpdateCmd.Flags().Bool("wait", true, "Wait for the deployment to become ready before returning")
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	version, _ := cmd.Flags().GetString("version")
+	envFlags, _ := cmd.Flags().GetStringArray("env")
+	unsetFlags, _ := cmd.Flags().GetStringArray("unset-env")
+	secretEnvFlags, _ := cmd.Flags().GetStringArray("secret-env")
+	wait, _ := cmd.Flags().GetBool("wait")
+
+	envMap, err := cliUtils.ParseEnvFlags(envFlags)
+	if err != nil {
+		return err
+	}
+	secretRefs, err := cliUtils.ParseEnvFlags(secretEnvFlags)
+	if err != nil {
+		return err
+	}
+
+	fullID, err := resolveDeploymentID(args[0])
+	if err != nil {
+		return err
+	}
+	current, err := apiClient.GetDeploymentByID(fullID)
+	if err != nil {
+		return fmt.Errorf("failed to get deployment: %w", err)
+	}
+	if current == nil {
+		return fmt.Errorf("deployment not found: %s", args[0])
+	}
+
+	update := buildDeploymentUpdate(current, version, envMap, secretRefs, unsetFlags)
+	if cmd.Flags().Changed("prefer-remote") {
+		preferRemote, _ := cmd.Flags().GetBool("prefer-remote")
+		update.PreferRemote = &preferRemote
+	}
+	if update.Version == nil && update.Env == nil && update.SecretRefs == nil && update.PreferRemote == nil {
+		return fmt.Errorf("nothing to update: pass --version, --env, --unset-env, --secret-env or --prefer-remote")
+	}
+
+	deployment, err := apiClient.UpdateDeployment(fullID, update)
+	if err != nil {
+		return fmt.Errorf("failed to update deployment: %w", err)
+	}
+
+	if deployment.ProviderID != "local" && wait {
+		fmt.Printf("Waiting for '%s' to become ready...\n", deployment.ServerName)
+		if err := cliCommon.WaitForDeploymentReady(apiClient, deployment.ID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Deployment '%s' updated: %s %s -> %s\n",
+		deployment.ID,
+		deployment.ServerName,
+		cliCommon.FormatVersionForDisplay(current.Version),
+		cliCommon.FormatVersionForDisplay(deployment.Version),
+	)
+	return nil
+}
+
+// buildDeploymentUpdate merges flag values onto the deployment's current env
+// and secret references. Maps are only sent when they change, since the API
+// replaces them wholesale.
+func buildDeploymentUpdate(current *models.Deployment, version string, setEnv, setSecrets map[string]string, unset []string) *models.DeploymentUpdate {
+	update := &models.DeploymentUpdate{}
+	if version != "" {
+		update.Version = &version
+	}
+
+	env := maps.Clone(current.Env)
+	if env == nil {
+		env = map[string]string{}
+	}
+	secretRefs := maps.Clone(current.SecretRefs)
+	if secretRefs == nil {
+		secretRefs = map[string]string{}
+	}
+	// A key is either a plain value or a secret, so setting one form drops the other.
+	for key, value := range setEnv {
+		env[key] = value
+		delete(secretRefs, key)
+	}
+	for key, secretName := range setSecrets {
+		secretRefs[key] = secretName
+		delete(env, key)
+	}
+	for _, key := range unset {
+		delete(env, key)
+		delete(secretRefs, key)
+	}
+
+	if !maps.Equal(env, current.Env) {
+		update.Env = env
+	}
+	if !maps.Equal(secretRefs, current.SecretRefs) {
+		update.SecretRefs = secretRefs
+	}
+	return update
+}
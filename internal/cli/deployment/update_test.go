@@ -0,0 +1,47 @@
+package deployment
+
+import (
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestBuildDeploymentUpdate_MergesOntoCurrentValues(t *testing.T) {
+	current := &models.Deployment{
+		Version:    "1.0.0",
+		Env:        map[string]string{"LOG_LEVEL": "info", "DEBUG": "1", "TOKEN": "plain"},
+		SecretRefs: map[string]string{"API_KEY": "api-key"},
+	}
+
+	update := buildDeploymentUpdate(current, "", map[string]string{"LOG_LEVEL": "debug"},
+		map[string]string{"TOKEN": "token-secret"}, []string{"DEBUG", "API_KEY"})
+
+	if update.Version != nil {
+		t.Fatalf("Version = %q, want unset", *update.Version)
+	}
+	if got := update.Env; len(got) != 1 || got["LOG_LEVEL"] != "debug" {
+		t.Fatalf("Env = %v, want only LOG_LEVEL=debug", got)
+	}
+	if got := update.SecretRefs; len(got) != 1 || got["TOKEN"] != "token-secret" {
+		t.Fatalf("SecretRefs = %v, want only TOKEN=token-secret", got)
+	}
+	if current.Env["DEBUG"] != "1" {
+		t.Fatalf("current env was modified: %v", current.Env)
+	}
+}
+
+func TestBuildDeploymentUpdate_OmitsUnchangedMaps(t *testing.T) {
+	current := &models.Deployment{
+		Version: "1.0.0",
+		Env:     map[string]string{"LOG_LEVEL": "info"},
+	}
+
+	update := buildDeploymentUpdate(current, "2.0.0", map[string]string{"LOG_LEVEL": "info"}, nil, nil)
+
+	if update.Version == nil || *update.Version != "2.0.0" {
+		t.Fatalf("Version = %v, want 2.0.0", update.Version)
+	}
+	if update.Env != nil || update.SecretRefs != nil {
+		t.Fatalf("unchanged maps should be omitted, got env=%v secretRefs=%v", update.Env, update.SecretRefs)
+	}
+}
@@ -21,6 +21,8 @@ var (
 	embeddingsDryRun         bool
 	embeddingsIncludeServers bool
 	embeddingsIncludeAgents  bool
+	embeddingsIncludeSkills  bool
+	embeddingsIncludePrompts bool
 	embeddingsStream         bool
 	embeddingsPollInterval   time.Duration
 )
@@ -33,7 +35,7 @@ var EmbeddingsCmd = &cobra.Command{
 
 var embeddingsGenerateCmd = &cobra.Command{
 	Use:   "generate",
-	Short: "Generate embeddings for existing servers and agents (backfill or refresh)",
+	Short: "Generate embeddings for existing servers, agents, skills and prompts (backfill or refresh)",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
 		if ctx == nil {
@@ -49,6 +51,8 @@ func init() {
 	embeddingsGenerateCmd.Flags().BoolVar(&embeddingsDryRun, "dry-run", false, "Print planned changes without calling the embedding provider or writing to the database")
 	embeddingsGenerateCmd.Flags().BoolVar(&embeddingsIncludeServers, "servers", true, "Include MCP servers when generating embeddings")
 	embeddingsGenerateCmd.Flags().BoolVar(&embeddingsIncludeAgents, "agents", true, "Include agents when generating embeddings")
+	embeddingsGenerateCmd.Flags().BoolVar(&embeddingsIncludeSkills, "skills", true, "Include skills when generating embeddings")
+	embeddingsGenerateCmd.Flags().BoolVar(&embeddingsIncludePrompts, "prompts", true, "Include prompts when generating embeddings")
 	embeddingsGenerateCmd.Flags().BoolVar(&embeddingsStream, "stream", true, "Use SSE streaming for progress updates")
 	embeddingsGenerateCmd.Flags().DurationVar(&embeddingsPollInterval, "poll-interval", 2*time.Second, "Poll interval when not using streaming")
 	EmbeddingsCmd.AddCommand(embeddingsGenerateCmd)
@@ -65,8 +69,8 @@ type sseEvent struct {
 }
 
 func runEmbeddingsGenerate(ctx context.Context) error {
-	if !embeddingsIncludeServers && !embeddingsIncludeAgents {
-		return fmt.Errorf("no targets selected; use --servers or --agents")
+	if !embeddingsIncludeServers && !embeddingsIncludeAgents && !embeddingsIncludeSkills && !embeddingsIncludePrompts {
+		return fmt.Errorf("no targets selected; use --servers, --agents, --skills or --prompts")
 	}
 
 	c, err := client.NewClientFromEnv()
@@ -81,6 +85,8 @@ func runEmbeddingsGenerate(ctx context.Context) error {
 		DryRun:         embeddingsDryRun,
 		IncludeServers: embeddingsIncludeServers,
 		IncludeAgents:  embeddingsIncludeAgents,
+		IncludeSkills:  embeddingsIncludeSkills,
+		IncludePrompts: embeddingsIncludePrompts,
 	}
 
 	if embeddingsStream {
@@ -142,15 +148,7 @@ func streamIndex(ctx context.Context, c *client.Client, req client.IndexRequest)
 				fmt.Println("Embedding indexing complete.")
 				var result jobs.JobResult
 				if err := json.Unmarshal(event.Result, &result); err == nil {
-					fmt.Printf("  Servers: processed=%d updated=%d skipped=%d failures=%d\n",
-						result.ServersProcessed, result.ServersUpdated, result.ServersSkipped, result.ServerFailures)
-					fmt.Printf("  Agents: processed=%d updated=%d skipped=%d failures=%d\n",
-						result.AgentsProcessed, result.AgentsUpdated, result.AgentsSkipped, result.AgentFailures)
-
-					totalFailures := result.ServerFailures + result.AgentFailures
-					if totalFailures > 0 {
-						return fmt.Errorf("%d embedding(s) failed; see logs for details", totalFailures)
-					}
+					return printIndexResult(&result)
 				}
 				return nil
 			case "error":
@@ -194,15 +192,7 @@ func pollIndex(ctx context.Context, c *client.Client, req client.IndexRequest) e
 			if status.Status == "completed" {
 				fmt.Println("Embedding indexing complete.")
 				if status.Result != nil {
-					fmt.Printf("  Servers: processed=%d updated=%d skipped=%d failures=%d\n",
-						status.Result.ServersProcessed, status.Result.ServersUpdated, status.Result.ServersSkipped, status.Result.ServerFailures)
-					fmt.Printf("  Agents: processed=%d updated=%d skipped=%d failures=%d\n",
-						status.Result.AgentsProcessed, status.Result.AgentsUpdated, status.Result.AgentsSkipped, status.Result.AgentFailures)
-
-					totalFailures := status.Result.ServerFailures + status.Result.AgentFailures
-					if totalFailures > 0 {
-						return fmt.Errorf("%d embedding(s) failed; see logs for details", totalFailures)
-					}
+					return printIndexResult(status.Result)
 				}
 				return nil
 			}
@@ -217,3 +207,22 @@ func pollIndex(ctx context.Context, c *client.Client, req client.IndexRequest) e
 		}
 	}
 }
+
+// printIndexResult prints per-resource counts for a finished index job and
+// returns an error when any embedding failed.
+func printIndexResult(result *jobs.JobResult) error {
+	fmt.Printf("  Servers: processed=%d updated=%d skipped=%d failures=%d\n",
+		result.ServersProcessed, result.ServersUpdated, result.ServersSkipped, result.ServerFailures)
+	fmt.Printf("  Agents: processed=%d updated=%d skipped=%d failures=%d\n",
+		result.AgentsProcessed, result.AgentsUpdated, result.AgentsSkipped, result.AgentFailures)
+	fmt.Printf("  Skills: processed=%d updated=%d skipped=%d failures=%d\n",
+		result.SkillsProcessed, result.SkillsUpdated, result.SkillsSkipped, result.SkillFailures)
+	fmt.Printf("  Prompts: processed=%d updated=%d skipped=%d failures=%d\n",
+		result.PromptsProcessed, result.PromptsUpdated, result.PromptsSkipped, result.PromptFailures)
+
+	totalFailures := result.ServerFailures + result.AgentFailures + result.SkillFailures + result.PromptFailures
+	if totalFailures > 0 {
+		return fmt.Errorf("%d embedding(s) failed; see logs for details", totalFailures)
+	}
+	return nil
+}
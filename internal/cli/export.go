@@ -19,13 +19,16 @@ import (
 var (
 	exportOutput       string
 	exportReadmeOutput string
+	exportServersOnly  bool
 )
 
 var ExportCmd = &cobra.Command{
 	Use:    "export",
 	Hidden: true,
-	Short:  "Export servers from the registry database",
-	Long:   "Exports all MCP server entries from the local registry database into a JSON seed file compatible with arctl import.",
+	Short:  "Export registry content from the registry database",
+	Long: `Exports every version of every server, agent, skill and prompt, with statuses, server READMEs and
+deployment providers, into a registry bundle compatible with arctl import. Bundles are written as YAML when
+--output ends in .yaml or .yml and as JSON otherwise. Use --servers-only to write a legacy ServerJSON seed file.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		outputPath := strings.TrimSpace(exportOutput)
 		if outputPath == "" {
@@ -60,13 +63,18 @@ var ExportCmd = &cobra.Command{
 		}
 
 		exporterService.SetReadmeOutputPath(exportReadmeOutput)
+		exporterService.SetServersOnly(exportServersOnly)
 
 		count, err := exporterService.ExportToPath(exportCtx, outputPath)
 		if err != nil {
-			return fmt.Errorf("failed to export servers: %w", err)
+			return fmt.Errorf("failed to export registry: %w", err)
 		}
 
-		fmt.Printf("✓ Exported %d servers to %s\n", count, outputPath)
+		if exportServersOnly {
+			fmt.Printf("✓ Exported %d servers to %s\n", count, outputPath)
+		} else {
+			fmt.Printf("✓ Exported %d entries to %s\n", count, outputPath)
+		}
 		return nil
 	},
 }
@@ -74,5 +82,6 @@ var ExportCmd = &cobra.Command{
 func init() {
 	ExportCmd.Flags().StringVar(&exportOutput, "output", "", "Destination seed file path (required)")
 	ExportCmd.Flags().StringVar(&exportReadmeOutput, "readme-output", "", "Optional README seed output path")
+	ExportCmd.Flags().BoolVar(&exportServersOnly, "servers-only", false, "Write a legacy ServerJSON array containing only servers")
 	_ = ExportCmd.MarkFlagRequired("output")
 }
@@ -25,6 +25,7 @@ var (
 	importTimeout            time.Duration
 	importGithubToken        string
 	importUpdate             bool
+	importOnConflict         string
 	importReadmeSeed         string
 	importProgressCache      string
 	enrichServerData         bool
@@ -34,8 +35,10 @@ var (
 var ImportCmd = &cobra.Command{
 	Use:    "import",
 	Hidden: true,
-	Short:  "Import servers into the registry database",
-	Long:   "Imports MCP server entries from a JSON seed file or a registry /v0/servers endpoint into the local registry database.",
+	Short:  "Import servers or registry bundles into the registry database",
+	Long: `Imports MCP server entries from a JSON seed file or a registry /v0/servers endpoint into the local registry database.
+Registry bundles written by arctl export (JSON or YAML) restore servers, agents, skills, prompts and providers,
+including every version, status and README. Existing bundle entries are handled according to --on-conflict.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if strings.TrimSpace(importSource) == "" {
 			return errors.New("--source is required (file path, HTTP URL, or /v0/servers endpoint)")
@@ -84,7 +87,16 @@ var ImportCmd = &cobra.Command{
 			headerMap[key] = value
 		}
 
+		var conflictPolicy importer.ConflictPolicy
+		if cmd.Flags().Changed("on-conflict") {
+			conflictPolicy, err = importer.ParseConflictPolicy(importOnConflict)
+			if err != nil {
+				return err
+			}
+		}
+
 		importerService := importer.NewService(registryService)
+		importerService.SetConflictPolicy(conflictPolicy)
 		importerService.SetHTTPClient(httpClient)
 		importerService.SetRequestHeaders(headerMap)
 		importerService.SetUpdateIfExists(importUpdate)
@@ -116,6 +128,7 @@ func init() {
 	ImportCmd.Flags().DurationVar(&importTimeout, "timeout", 30*time.Second, "HTTP request timeout")
 	ImportCmd.Flags().StringVar(&importGithubToken, "github-token", "", "GitHub token for higher rate limits when enriching metadata")
 	ImportCmd.Flags().BoolVar(&importUpdate, "update", false, "Update existing entries if name/version already exists")
+	ImportCmd.Flags().StringVar(&importOnConflict, "on-conflict", "", "How bundle imports treat existing entries: skip, overwrite or fail (default: overwrite with --update, otherwise skip)")
 	ImportCmd.Flags().StringVar(&importReadmeSeed, "readme-seed", "", "Optional README seed file path or URL")
 	ImportCmd.Flags().StringVar(&importProgressCache, "progress-cache", "", "Optional path to store import progress for resuming interrupted runs")
 	ImportCmd.Flags().BoolVar(&enrichServerData, "enrich-server-data", false, "Enrich server data during import (may increase import time)")
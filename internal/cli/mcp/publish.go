@@ -9,6 +9,7 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/mcp/manifest"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 	"github.com/spf13/cobra"
@@ -41,6 +42,8 @@ var (
 
 	// Flags for remote-only publishing
 	publishRemoteURL string
+
+	signingFlags common.SigningFlags
 )
 
 func init() {
@@ -58,6 +61,8 @@ func init() {
 	PublishCmd.Flags().StringArrayVar(&publishArgs, "arg", nil, "Package argument (repeatable)")
 
 	PublishCmd.Flags().StringVar(&publishRemoteURL, "remote-url", "", "URL of an already-deployed remote MCP server (e.g. https://my-workspace.databricks.com/mcp). Use instead of --type/--package-id for hosted servers.")
+
+	signingFlags.AddFlags(PublishCmd)
 }
 
 var PublishCmd = &cobra.Command{
@@ -325,13 +330,21 @@ func checkAndHandleExistingServer(serverName, version string) error {
 
 // publishToRegistry handles the actual publish or dry-run output.
 func publishToRegistry(serverJSON *apiv0.ServerJSON, dryRun bool) error {
+	sig, err := signingFlags.Sign(signing.ServerPayload(serverJSON))
+	if err != nil {
+		return err
+	}
+	if sig != nil {
+		signing.SetServerSignature(serverJSON, sig)
+	}
+
 	if dryRun {
 		j, _ := json.MarshalIndent(serverJSON, "", "  ")
 		printer.PrintInfo(fmt.Sprintf("[DRY RUN] Would publish to registry %s:\n%s", apiClient.BaseURL, string(j)))
 		return nil
 	}
 
-	_, err := apiClient.CreateMCPServer(serverJSON)
+	_, err = apiClient.CreateMCPServer(serverJSON)
 	if err != nil {
 		return fmt.Errorf("failed to publish to registry: %w", err)
 	}
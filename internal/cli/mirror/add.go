@@ -0,0 +1,62 @@
+package mirror
+
+import (
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var AddCmd = &cobra.Command{
+	Use:   "add <id> <url>",
+	Short: "Add an upstream registry mirror",
+	Long: `Add an upstream registry whose servers are mirrored into this registry.
+
+Include and exclude are glob patterns matched against server names; '*' does
+not match '/'. Server versions that already exist locally and were not
+mirrored from this upstream are never overwritten.
+
+Example:
+  arctl mirror add official https://registry.modelcontextprotocol.io
+  arctl mirror add acme https://registry.acme.dev --include 'io.github.acme/*' --exclude '*/internal-*'
+  arctl mirror add staging https://staging.example.com --interval 15m --disabled`,
+	Args:          cobra.ExactArgs(2),
+	RunE:          runAdd,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	AddCmd.Flags().StringSlice("include", nil, "Only mirror servers whose names match these patterns")
+	AddCmd.Flags().StringSlice("exclude", nil, "Never mirror servers whose names match these patterns")
+	AddCmd.Flags().Duration("interval", 0, "How often to sync the mirror (default 1h)")
+	AddCmd.Flags().Bool("disabled", false, "Create the mirror without scheduling syncs")
+}
+
+func runAdd(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	include, _ := cmd.Flags().GetStringSlice("include")
+	exclude, _ := cmd.Flags().GetStringSlice("exclude")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	disabled, _ := cmd.Flags().GetBool("disabled")
+
+	enabled := !disabled
+	mirror, err := apiClient.CreateMirror(&models.CreateMirrorInput{
+		ID:              args[0],
+		URL:             args[1],
+		Include:         include,
+		Exclude:         exclude,
+		IntervalSeconds: int(interval.Seconds()),
+		Enabled:         &enabled,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add mirror: %w", err)
+	}
+
+	printer.PrintSuccess(fmt.Sprintf("Mirror '%s' added for %s", mirror.ID, mirror.URL))
+	return nil
+}
@@ -0,0 +1,85 @@
+package mirror
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List upstream registry mirrors",
+	Long: `List the upstream registries mirrored into this registry.
+
+Example:
+  arctl mirror list
+  arctl mirror list -o json`,
+	Aliases:       []string{"ls"},
+	RunE:          runList,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	ListCmd.Flags().StringP("output", "o", "table", "Output format (table, json)")
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	outputFormat, _ := cmd.Flags().GetString("output")
+
+	mirrors, err := apiClient.ListMirrors()
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		p := printer.New(printer.OutputTypeJSON, false)
+		return p.PrintJSON(mirrors)
+	}
+
+	if len(mirrors) == 0 {
+		fmt.Println("No mirrors found")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("ID", "URL", "Include", "Exclude", "Interval", "Enabled", "Last Synced")
+	for _, m := range mirrors {
+		t.AddRow(
+			m.ID,
+			m.URL,
+			printer.EmptyValueOrDefault(strings.Join(m.Include, ","), "*"),
+			printer.EmptyValueOrDefault(strings.Join(m.Exclude, ","), "<none>"),
+			formatInterval(m.IntervalSeconds),
+			m.Enabled,
+			formatOptionalAge(m.LastSyncedAt),
+		)
+	}
+	if err := t.Render(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+	return nil
+}
+
+func formatInterval(seconds int) string {
+	if seconds <= 0 {
+		seconds = models.DefaultMirrorIntervalSeconds
+	}
+	return (time.Duration(seconds) * time.Second).String()
+}
+
+func formatOptionalAge(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "never"
+	}
+	return printer.FormatAge(*t)
+}
@@ -0,0 +1,35 @@
+package mirror
+
+import (
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var apiClient *client.Client
+
+func SetAPIClient(c *client.Client) {
+	apiClient = c
+}
+
+var MirrorCmd = &cobra.Command{
+	Use:     "mirror",
+	Aliases: []string{"mirrors"},
+	Short:   "Manage upstream registry mirrors",
+	Long: `Commands for mirroring servers from upstream registries (the official MCP
+registry or another agentregistry). Mirrors are synced on a schedule; only
+servers updated upstream since the last successful sync are fetched.`,
+	Args: cobra.ArbitraryArgs,
+	Example: `arctl mirror add official https://registry.modelcontextprotocol.io --include 'io.github.*/*'
+arctl mirror list
+arctl mirror sync official
+arctl mirror status official
+arctl mirror remove official`,
+}
+
+func init() {
+	MirrorCmd.AddCommand(AddCmd)
+	MirrorCmd.AddCommand(ListCmd)
+	MirrorCmd.AddCommand(RemoveCmd)
+	MirrorCmd.AddCommand(SyncCmd)
+	MirrorCmd.AddCommand(StatusCmd)
+}
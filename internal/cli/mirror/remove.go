@@ -0,0 +1,36 @@
+package mirror
+
+import (
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var RemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove an upstream registry mirror",
+	Long: `Stop mirroring an upstream registry and drop its sync history. Servers that
+were already mirrored are kept.
+
+Example:
+  arctl mirror remove official`,
+	Aliases:       []string{"rm", "delete"},
+	Args:          cobra.ExactArgs(1),
+	RunE:          runRemove,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func runRemove(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	if err := apiClient.DeleteMirror(args[0]); err != nil {
+		return fmt.Errorf("failed to remove mirror: %w", err)
+	}
+
+	printer.PrintSuccess(fmt.Sprintf("Mirror '%s' removed", args[0]))
+	return nil
+}
@@ -0,0 +1,91 @@
+package mirror
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var StatusCmd = &cobra.Command{
+	Use:   "status <id>",
+	Short: "Show a mirror's sync status and history",
+	Long: `Show a mirror's configuration, watermark and recent sync history, newest first.
+
+Example:
+  arctl mirror status official
+  arctl mirror status official --history 25
+  arctl mirror status official -o json`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runStatus,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	StatusCmd.Flags().Int("history", 10, "Number of recent syncs to show")
+	StatusCmd.Flags().StringP("output", "o", "table", "Output format (table, json)")
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	history, _ := cmd.Flags().GetInt("history")
+	outputFormat, _ := cmd.Flags().GetString("output")
+
+	status, err := apiClient.GetMirrorStatus(args[0], history)
+	if err != nil {
+		return fmt.Errorf("failed to get mirror status: %w", err)
+	}
+
+	if outputFormat == "json" {
+		p := printer.New(printer.OutputTypeJSON, false)
+		return p.PrintJSON(status)
+	}
+
+	m := status.Mirror
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Property", "Value")
+	t.AddRow("ID", m.ID)
+	t.AddRow("URL", m.URL)
+	t.AddRow("Include", printer.EmptyValueOrDefault(strings.Join(m.Include, ", "), "*"))
+	t.AddRow("Exclude", printer.EmptyValueOrDefault(strings.Join(m.Exclude, ", "), "<none>"))
+	t.AddRow("Interval", formatInterval(m.IntervalSeconds))
+	t.AddRow("Enabled", m.Enabled)
+	t.AddRow("Last Synced", formatOptionalAge(m.LastSyncedAt))
+	if m.Watermark != nil {
+		t.AddRow("Watermark", printer.FormatTimestamp(*m.Watermark))
+	}
+	if err := t.Render(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+
+	fmt.Println()
+	if len(status.History) == 0 {
+		fmt.Println("No syncs yet")
+		return nil
+	}
+
+	h := printer.NewTablePrinter(os.Stdout)
+	h.SetHeaders("Started", "Status", "Fetched", "Created", "Updated", "Skipped", "Failed", "Error")
+	for _, s := range status.History {
+		h.AddRow(
+			printer.FormatAge(s.StartedAt),
+			s.Status,
+			s.Fetched,
+			s.Created,
+			s.Updated,
+			s.Skipped,
+			s.Failed,
+			printer.EmptyValueOrDefault(s.Error, "-"),
+		)
+	}
+	if err := h.Render(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package mirror
+
+import (
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var SyncCmd = &cobra.Command{
+	Use:   "sync <id>",
+	Short: "Sync a mirror now",
+	Long: `Submit a sync job for a mirror instead of waiting for its interval. Use
+'arctl mirror status' to see the outcome.
+
+Example:
+  arctl mirror sync official`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runSync,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	job, err := apiClient.SyncMirror(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to sync mirror: %w", err)
+	}
+
+	printer.PrintSuccess(fmt.Sprintf("Sync of mirror '%s' submitted (job %s)", args[0], job.ID))
+	return nil
+}
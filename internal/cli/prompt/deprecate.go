@@ -0,0 +1,12 @@
+package prompt
+
+import (
+	"github.com/agentregistry-dev/agentregistry/internal/cli/common"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+)
+
+// DeprecateCmd and UndeprecateCmd set the status of prompt versions.
+var DeprecateCmd, UndeprecateCmd = common.NewDeprecateCommands("prompt",
+	func() *client.Client { return apiClient },
+	(*client.Client).SetPromptStatus,
+)
@@ -0,0 +1,76 @@
+package prompt
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestRunDeprecate_SendsStatusAndSuccessor(t *testing.T) {
+	var gotPath string
+	var got models.StatusUpdate
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		gotPath = r.URL.Path
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"prompt":{"name":"my-prompt","version":"1.0.0","content":""}}`))
+	}))
+	defer ts.Close()
+
+	oldClient := apiClient
+	apiClient = client.NewClient(ts.URL, "")
+	defer func() { apiClient = oldClient }()
+
+	defer func() {
+		_ = DeprecateCmd.Flags().Set("version", "")
+		_ = DeprecateCmd.Flags().Set("successor", "")
+		_ = UndeprecateCmd.Flags().Set("version", "")
+	}()
+	if err := DeprecateCmd.Flags().Set("version", "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeprecateCmd.Flags().Set("successor", "2.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeprecateCmd.RunE(DeprecateCmd, []string{"my-prompt"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "/v0/prompts/my-prompt/versions/1.0.0/status"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if got.Status != "deprecated" || got.Successor != "2.0.0" {
+		t.Errorf("body = %+v, want deprecated with successor 2.0.0", got)
+	}
+
+	got = models.StatusUpdate{}
+	if err := UndeprecateCmd.Flags().Set("version", "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	if err := UndeprecateCmd.RunE(UndeprecateCmd, []string{"my-prompt"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Status != "active" || got.Successor != "" {
+		t.Errorf("body = %+v, want active without successor", got)
+	}
+}
+
+func TestRunDeprecate_NilClient(t *testing.T) {
+	oldClient := apiClient
+	apiClient = nil
+	defer func() { apiClient = oldClient }()
+
+	if err := DeprecateCmd.RunE(DeprecateCmd, []string{"some-prompt"}); err == nil || err.Error() != "API client not initialized" {
+		t.Fatalf("expected 'API client not initialized', got %v", err)
+	}
+}
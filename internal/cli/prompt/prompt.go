@@ -29,5 +29,7 @@ func init() {
 	PromptCmd.AddCommand(ListCmd)
 	PromptCmd.AddCommand(PublishCmd)
 	PromptCmd.AddCommand(DeleteCmd)
+	PromptCmd.AddCommand(DeprecateCmd)
+	PromptCmd.AddCommand(UndeprecateCmd)
 	PromptCmd.AddCommand(ShowCmd)
 }
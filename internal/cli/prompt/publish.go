@@ -29,7 +29,19 @@ The file can be:
   - A plain text file (.txt, .md, etc.) containing the prompt content.
     Use --name and --version flags to set metadata.
   - A YAML file (.yaml, .yml) with structured prompt definition
-    (name, version, description, content fields).
+    (name, version, description, content and arguments fields).
+
+The content is a template: {{name}} placeholders are filled with argument
+values when an agent uses the prompt. Every placeholder must be declared
+under arguments in a YAML prompt, for example:
+
+  content: Review the {{language}} code in {{path}}.
+  arguments:
+    - name: language
+      required: true
+    - name: path
+      description: Directory to review
+      default: .
 
 Examples:
   arctl prompt publish system-prompt.txt --name my-prompt --version 1.0.0
@@ -95,6 +107,9 @@ func runPublish(cmd *cobra.Command, args []string) error {
 	if promptJSON.Version == "" {
 		return fmt.Errorf("prompt version is required (use --version flag)")
 	}
+	if err := promptJSON.ValidateTemplate(); err != nil {
+		return fmt.Errorf("invalid prompt template: %w", err)
+	}
 
 	printer.PrintInfo(fmt.Sprintf("Publishing prompt '%s' version %s from: %s", promptJSON.Name, promptJSON.Version, absPath))
 
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/agentregistry-dev/agentregistry/internal/client"
@@ -245,6 +246,35 @@ func TestRunPublish_MissingName(t *testing.T) {
 	}
 }
 
+func TestRunPublish_UndeclaredPlaceholder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("prompt with an undeclared placeholder must not be published")
+	}))
+	defer ts.Close()
+
+	oldClient := apiClient
+	apiClient = client.NewClient(ts.URL, "")
+	defer func() { apiClient = oldClient }()
+
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "prompt.yaml")
+	content := `name: reviewer
+version: 1.0.0
+content: Review {{language}} code in {{path}}.
+arguments:
+  - name: language
+    required: true
+`
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := runPublish(PublishCmd, []string{filePath})
+	if err == nil || !strings.Contains(err.Error(), "undeclared arguments: path") {
+		t.Fatalf("expected undeclared argument error, got %v", err)
+	}
+}
+
 func TestRunPublish_MissingVersion(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
 	defer ts.Close()
@@ -3,7 +3,10 @@ package prompt
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
 	"github.com/spf13/cobra"
 )
@@ -56,6 +59,13 @@ func runShow(cmd *cobra.Command, args []string) error {
 	t.AddRow("Version", prompt.Prompt.Version)
 	if prompt.Meta.Official != nil {
 		t.AddRow("Status", prompt.Meta.Official.Status)
+		if prompt.Meta.Official.Successor != "" {
+			t.AddRow("Successor", prompt.Meta.Official.Successor)
+		}
+	}
+
+	for _, arg := range prompt.Prompt.Arguments {
+		t.AddRow("Argument "+arg.Name, describePromptArgument(arg))
 	}
 
 	// Show a preview of the content (first 200 chars)
@@ -71,3 +81,24 @@ func runShow(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// describePromptArgument summarizes the type, requiredness, default and
+// description of a prompt argument.
+func describePromptArgument(arg models.PromptArgument) string {
+	typ := arg.Type
+	if typ == "" {
+		typ = models.PromptArgumentTypeString
+	}
+	parts := []string{typ}
+	if arg.Required {
+		parts = append(parts, "required")
+	}
+	if arg.Default != "" {
+		parts = append(parts, "default "+strconv.Quote(arg.Default))
+	}
+	desc := strings.Join(parts, ", ")
+	if arg.Description != "" {
+		desc += ": " + arg.Description
+	}
+	return desc
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchTypes             []string
+	searchSemantic          bool
+	searchSemanticThreshold float64
+	searchAllVersions       bool
+	searchLimit             int
+	searchCursor            string
+	searchOutputFormat      string
+)
+
+// SearchCmd searches servers, agents, skills and prompts in a single ranked result set.
+var SearchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Search servers, agents, skills and prompts in the registry",
+	Long: `Search all artifact types in the registry at once. Results are ranked by substring
+and trigram similarity, combined with vector similarity when --semantic is set.`,
+	Example: `  arctl search postgres
+  arctl search "manage kubernetes clusters" --semantic --type server,agent`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runSearch(strings.Join(args, " "))
+	},
+}
+
+func init() {
+	SearchCmd.Flags().StringSliceVarP(&searchTypes, "type", "t", nil, "Restrict results to artifact types (server, agent, skill, prompt)")
+	SearchCmd.Flags().BoolVar(&searchSemantic, "semantic", false, "Combine substring matching with semantic similarity")
+	SearchCmd.Flags().Float64Var(&searchSemanticThreshold, "semantic-threshold", 0, "Maximum cosine distance for semantic matches (0 disables the threshold)")
+	SearchCmd.Flags().BoolVar(&searchAllVersions, "all-versions", false, "Include all versions instead of only the latest")
+	SearchCmd.Flags().IntVarP(&searchLimit, "limit", "l", 20, "Maximum number of results to return")
+	SearchCmd.Flags().StringVar(&searchCursor, "cursor", "", "Cursor from a previous search to fetch the next page")
+	SearchCmd.Flags().StringVarP(&searchOutputFormat, "output", "o", "table", "Output format (table, json)")
+}
+
+func runSearch(query string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+	if searchSemantic && strings.TrimSpace(query) == "" {
+		return fmt.Errorf("--semantic requires a search query")
+	}
+
+	resp, err := apiClient.Search(client.SearchOptions{
+		Query:             query,
+		Types:             searchTypes,
+		AllVersions:       searchAllVersions,
+		Semantic:          searchSemantic,
+		SemanticThreshold: searchSemanticThreshold,
+		Cursor:            searchCursor,
+		Limit:             searchLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if searchOutputFormat == "json" {
+		p := printer.New(printer.OutputTypeJSON, false)
+		if err := p.PrintJSON(resp); err != nil {
+			return fmt.Errorf("failed to output JSON: %w", err)
+		}
+		return nil
+	}
+
+	if len(resp.Results) == 0 {
+		fmt.Println("No results found")
+		return nil
+	}
+	printSearchResultsTable(resp.Results)
+
+	facets := make([]string, 0, len(models.SearchTypes))
+	for _, t := range models.SearchTypes {
+		facets = append(facets, fmt.Sprintf("%s: %d", t, resp.Metadata.Facets[t]))
+	}
+	fmt.Printf("\nMatches by type: %s\n", strings.Join(facets, ", "))
+	if resp.Metadata.NextCursor != "" {
+		fmt.Printf("More results available. Use --cursor %s to fetch the next page.\n", resp.Metadata.NextCursor)
+	}
+	return nil
+}
+
+func printSearchResultsTable(results []models.SearchResult) {
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Type", "Name", "Version", "Score", "Description")
+
+	for _, r := range results {
+		t.AddRow(
+			r.Type,
+			printer.TruncateString(r.Name, 40),
+			r.Version,
+			fmt.Sprintf("%.2f", r.Score),
+			printer.TruncateString(r.Description, 60),
+		)
+	}
+
+	if err := t.Render(); err != nil {
+		printer.PrintError(fmt.Sprintf("failed to render table: %v", err))
+	}
+}
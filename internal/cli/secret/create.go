@@ -0,0 +1,126 @@
+package secret
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var CreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a secret",
+	Long: `Create a secret. The value is read from an environment variable, a file, or
+standard input, so it never appears in shell history.
+
+Example:
+  arctl secret create github-token --from-env GITHUB_TOKEN
+  arctl secret create tls-key --from-file ./key.pem --description "Gateway TLS key"
+  echo -n "$TOKEN" | arctl secret create api-token`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runCreate,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+var UpdateCmd = &cobra.Command{
+	Use:   "update <name>",
+	Short: "Replace a secret's value",
+	Long: `Replace a secret's value. Deployments that reference the secret receive the
+new value when they are next applied.
+
+Example:
+  arctl secret update github-token --from-env GITHUB_TOKEN`,
+	Args:          cobra.ExactArgs(1),
+	RunE:          runUpdate,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{CreateCmd, UpdateCmd} {
+		cmd.Flags().String("from-env", "", "Read the value from this environment variable")
+		cmd.Flags().String("from-file", "", "Read the value from this file")
+		cmd.Flags().String("description", "", "Secret description")
+		cmd.MarkFlagsMutuallyExclusive("from-env", "from-file")
+	}
+}
+
+func runCreate(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	value, err := readSecretValue(cmd)
+	if err != nil {
+		return err
+	}
+	description, _ := cmd.Flags().GetString("description")
+
+	secret, err := apiClient.CreateSecret(&models.SecretInput{
+		Name:        args[0],
+		Value:       value,
+		Description: description,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+
+	printer.PrintSuccess(fmt.Sprintf("Secret '%s' created", secret.Name))
+	return nil
+}
+
+func runUpdate(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	value, err := readSecretValue(cmd)
+	if err != nil {
+		return err
+	}
+	description, _ := cmd.Flags().GetString("description")
+
+	secret, err := apiClient.UpdateSecret(args[0], value, description)
+	if err != nil {
+		return fmt.Errorf("failed to update secret: %w", err)
+	}
+
+	printer.PrintSuccess(fmt.Sprintf("Secret '%s' updated", secret.Name))
+	return nil
+}
+
+// readSecretValue reads the secret value from --from-env, --from-file or stdin.
+// A single trailing newline is dropped from file and stdin input.
+func readSecretValue(cmd *cobra.Command) (string, error) {
+	fromEnv, _ := cmd.Flags().GetString("from-env")
+	fromFile, _ := cmd.Flags().GetString("from-file")
+
+	if fromEnv != "" {
+		value, ok := os.LookupEnv(fromEnv)
+		if !ok || value == "" {
+			return "", fmt.Errorf("environment variable %s is not set", fromEnv)
+		}
+		return value, nil
+	}
+
+	var data []byte
+	var err error
+	if fromFile != "" {
+		data, err = os.ReadFile(fromFile)
+	} else {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret value: %w", err)
+	}
+	value := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	if value == "" {
+		return "", fmt.Errorf("secret value is empty")
+	}
+	return value, nil
+}
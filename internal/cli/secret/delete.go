@@ -0,0 +1,36 @@
+package secret
+
+import (
+	"fmt"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var DeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a secret",
+	Long: `Delete a secret. Secrets referenced by a deployment cannot be deleted until
+the deployment is removed.
+
+Example:
+  arctl secret delete github-token`,
+	Aliases:       []string{"rm", "remove"},
+	Args:          cobra.ExactArgs(1),
+	RunE:          runDelete,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func runDelete(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	if err := apiClient.DeleteSecret(args[0]); err != nil {
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+
+	printer.PrintSuccess(fmt.Sprintf("Secret '%s' deleted", args[0]))
+	return nil
+}
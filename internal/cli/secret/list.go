@@ -0,0 +1,65 @@
+package secret
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/spf13/cobra"
+)
+
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List secrets",
+	Long: `List stored secrets. Values are never shown.
+
+Example:
+  arctl secret list
+  arctl secret list -o json`,
+	Aliases:       []string{"ls"},
+	RunE:          runList,
+	SilenceUsage:  true,
+	SilenceErrors: false,
+}
+
+func init() {
+	ListCmd.Flags().StringP("output", "o", "table", "Output format (table, json)")
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	if apiClient == nil {
+		return fmt.Errorf("API client not initialized")
+	}
+
+	outputFormat, _ := cmd.Flags().GetString("output")
+
+	secrets, err := apiClient.ListSecrets()
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == "json" {
+		p := printer.New(printer.OutputTypeJSON, false)
+		return p.PrintJSON(secrets)
+	}
+
+	if len(secrets) == 0 {
+		fmt.Println("No secrets found")
+		return nil
+	}
+
+	t := printer.NewTablePrinter(os.Stdout)
+	t.SetHeaders("Name", "Description", "Key ID", "Updated")
+	for _, s := range secrets {
+		t.AddRow(
+			s.Name,
+			printer.EmptyValueOrDefault(s.Description, "<none>"),
+			s.KeyID,
+			printer.FormatAge(s.UpdatedAt),
+		)
+	}
+	if err := t.Render(); err != nil {
+		return fmt.Errorf("failed to render table: %w", err)
+	}
+	return nil
+}
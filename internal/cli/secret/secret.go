@@ -0,0 +1,33 @@
+package secret
+
+import (
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+	"github.com/spf13/cobra"
+)
+
+var apiClient *client.Client
+
+func SetAPIClient(c *client.Client) {
+	apiClient = c
+}
+
+var SecretCmd = &cobra.Command{
+	Use:     "secret",
+	Aliases: []string{"secrets"},
+	Short:   "Manage deployment secrets",
+	Long: `Commands for managing secrets that deployments read as environment variables.
+Secret values are stored encrypted and cannot be read back; deployments
+reference them by name with --secret-env KEY=SECRET_NAME.`,
+	Args: cobra.ArbitraryArgs,
+	Example: `arctl secret create github-token --from-env GITHUB_TOKEN
+arctl secret list
+arctl secret update github-token --from-file ./token.txt
+arctl secret delete github-token`,
+}
+
+func init() {
+	SecretCmd.AddCommand(CreateCmd)
+	SecretCmd.AddCommand(UpdateCmd)
+	SecretCmd.AddCommand(ListCmd)
+	SecretCmd.AddCommand(DeleteCmd)
+}
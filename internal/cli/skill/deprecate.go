@@ -0,0 +1,12 @@
+package skill
+
+import (
+	"github.com/agentregistry-dev/agentregistry/internal/cli/common"
+	"github.com/agentregistry-dev/agentregistry/internal/client"
+)
+
+// DeprecateCmd and UndeprecateCmd set the status of skill versions.
+var DeprecateCmd, UndeprecateCmd = common.NewDeprecateCommands("skill",
+	func() *client.Client { return apiClient },
+	(*client.Client).SetSkillStatus,
+)
@@ -1,7 +1,6 @@
 package skill
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -14,8 +13,9 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common/gitutil"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v3"
 )
 
 var (
@@ -24,7 +24,9 @@ var (
 	dryRunFlag      bool
 	gitRepository   string
 	dockerImageFlag string
+	bundleFlag      bool
 	publishDesc     string
+	signingFlags    common.SigningFlags
 )
 
 // githubRawBaseURL is the base URL for raw GitHub content checks.
@@ -41,6 +43,7 @@ This command supports three modes:
 1. From a local skill folder (with SKILL.md):
    arctl skill publish ./my-skill --git https://github.com/org/repo --version 1.0.0
    arctl skill publish ./my-skill --docker-image docker.io/myorg/my-skill:v1.0.0 --version 1.0.0
+   arctl skill publish ./my-skill --bundle --version 1.0.0
 
 2. Direct registration with Git repository:
    arctl skill publish my-skill \
@@ -57,6 +60,9 @@ This command supports three modes:
 For Git modes, SKILL.md must exist at the specified Git repository path.
 In folder mode, the local skill folder must also contain a SKILL.md file with proper YAML frontmatter.
 
+With --bundle, the skill folder is uploaded to the registry as a gzipped tarball
+and stored under its sha256 digest, so pulling it needs neither Docker nor Git.
+
 To build a skill as a Docker image, use "arctl skill build" instead.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runPublish,
@@ -72,7 +78,12 @@ func init() {
 	// Docker-only flags
 	PublishCmd.Flags().StringVar(&dockerImageFlag, "docker-image", "", "Docker image URL. For example: docker.io/myorg/my-skill:v1.0.0")
 
-	PublishCmd.MarkFlagsOneRequired("git", "docker-image")
+	// Bundle flags
+	PublishCmd.Flags().BoolVar(&bundleFlag, "bundle", false, "Upload the skill folder to the registry as a bundle (folder mode only)")
+
+	signingFlags.AddFlags(PublishCmd)
+
+	PublishCmd.MarkFlagsOneRequired("git", "docker-image", "bundle")
 }
 
 func runPublish(cmd *cobra.Command, args []string) error {
@@ -111,8 +122,10 @@ func runPublishFromFolder(skillFolderPath string) error {
 		skillJson, err = buildSkillFromGitHub(skillFolderPath)
 	case dockerImageFlag != "":
 		skillJson, err = buildSkillFromDocker(skillFolderPath)
+	case bundleFlag:
+		skillJson, err = buildSkillFromBundle(skillFolderPath)
 	default:
-		return fmt.Errorf("--git or --docker-image is required")
+		return fmt.Errorf("--git, --docker-image or --bundle is required")
 	}
 	if err != nil {
 		return fmt.Errorf("failed to build skill '%s': %w", skillFolderPath, err)
@@ -136,6 +149,8 @@ func runPublishDirect(skillName string) error {
 		skillJson, err = buildSkillDirectGitHub(skillName)
 	case dockerImageFlag != "":
 		skillJson, err = buildSkillDirectDocker(skillName)
+	case bundleFlag:
+		return fmt.Errorf("--bundle requires a skill folder containing SKILL.md")
 	default:
 		return fmt.Errorf("--git or --docker-image is required")
 	}
@@ -156,13 +171,19 @@ func runPublishDirect(skillName string) error {
 
 // publishSkillJSON publishes or dry-runs a single SkillJSON.
 func publishSkillJSON(skillJson *models.SkillJSON) error {
+	sig, err := signingFlags.Sign(signing.SkillPayload(skillJson))
+	if err != nil {
+		return err
+	}
+	skillJson.Signature = sig
+
 	if dryRunFlag {
 		j, _ := json.Marshal(skillJson)
 		printer.PrintInfo("[DRY RUN] Would publish skill to registry " + apiClient.BaseURL + ": " + string(j))
 		return nil
 	}
 
-	_, err := apiClient.CreateSkill(skillJson)
+	_, err = apiClient.CreateSkill(skillJson)
 	if err != nil {
 		return fmt.Errorf("failed to publish skill '%s': %w", skillJson.Name, err)
 	}
@@ -223,62 +244,13 @@ func buildSkillDirectDocker(skillName string) (*models.SkillJSON, error) {
 	return skill, nil
 }
 
-type skillFrontmatter struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
-}
-
 // parseSkillFrontmatter reads and parses the YAML frontmatter from a SKILL.md file.
-func parseSkillFrontmatter(skillPath string) (*skillFrontmatter, error) {
-	skillMd := filepath.Join(skillPath, "SKILL.md")
-	f, err := os.Open(skillMd)
+func parseSkillFrontmatter(skillPath string) (*skillbundle.Frontmatter, error) {
+	content, err := os.ReadFile(filepath.Join(skillPath, "SKILL.md"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open SKILL.md: %w", err)
 	}
-	defer func() { _ = f.Close() }()
-
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
-	var lines []string
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("failed reading SKILL.md: %w", err)
-	}
-	if len(lines) == 0 {
-		return nil, fmt.Errorf("SKILL.md is empty")
-	}
-
-	var yamlStart, yamlEnd = -1, -1
-	for i, l := range lines {
-		if strings.TrimSpace(l) == "---" {
-			if yamlStart == -1 {
-				yamlStart = i + 1
-			} else {
-				yamlEnd = i
-				break
-			}
-		}
-	}
-	if yamlStart == -1 || yamlEnd == -1 || yamlEnd <= yamlStart {
-		return nil, fmt.Errorf("SKILL.md missing YAML frontmatter delimited by ---")
-	}
-	yamlContent := strings.Join(lines[yamlStart:yamlEnd], "\n")
-
-	var fm skillFrontmatter
-	if err := yaml.Unmarshal([]byte(yamlContent), &fm); err != nil {
-		return nil, fmt.Errorf("failed to parse SKILL.md frontmatter: %w", err)
-	}
-
-	if fm.Name == "" {
-		return nil, fmt.Errorf("SKILL.md frontmatter missing required field: name")
-	}
-	if fm.Description == "" {
-		return nil, fmt.Errorf("SKILL.md frontmatter missing required field: description")
-	}
-
-	return &fm, nil
+	return skillbundle.ParseFrontmatter(content)
 }
 
 // resolveSkillMeta parses SKILL.md frontmatter and returns the skill name and description.
@@ -400,6 +372,51 @@ func buildSkillFromDocker(skillPath string) (*models.SkillJSON, error) {
 	return skill, nil
 }
 
+// buildSkillFromBundle reads SKILL.md frontmatter, uploads the skill folder as
+// a bundle and registers the skill with a registry package pointing at it.
+func buildSkillFromBundle(skillPath string) (*models.SkillJSON, error) {
+	name, description, err := resolveSkillMeta(skillPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if versionFlag == "" {
+		return nil, fmt.Errorf("--version is required when publishing with --bundle")
+	}
+
+	data, err := skillbundle.Pack(skillPath)
+	if err != nil {
+		return nil, err
+	}
+	digest := skillbundle.Digest(data)
+	if dryRunFlag {
+		printer.PrintInfo(fmt.Sprintf("[DRY RUN] Would upload skill bundle %s (%d bytes)", digest, len(data)))
+	} else {
+		bundle, err := apiClient.UploadSkillBundle(data)
+		if err != nil {
+			return nil, err
+		}
+		digest = bundle.Digest
+		printer.PrintInfo(fmt.Sprintf("Uploaded skill bundle %s (%d bytes)", digest, bundle.SizeBytes))
+	}
+
+	skill := &models.SkillJSON{
+		Name:        name,
+		Description: description,
+		Version:     versionFlag,
+	}
+
+	pkg := models.SkillPackageInfo{
+		RegistryType: models.SkillPackageTypeRegistry,
+		Identifier:   digest,
+		Version:      versionFlag,
+	}
+	pkg.Transport.Type = models.SkillPackageTypeRegistry
+	skill.Packages = append(skill.Packages, pkg)
+
+	return skill, nil
+}
+
 // isValidSkillDir checks whether a directory contains a SKILL.md with valid YAML frontmatter.
 func isValidSkillDir(dir string) bool {
 	if !hasSkillMd(dir) {
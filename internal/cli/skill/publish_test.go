@@ -2,6 +2,7 @@ package skill
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/client"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
 )
 
 func TestParseSkillFrontmatter(t *testing.T) {
@@ -326,6 +328,7 @@ func savePublishFlags(t *testing.T) {
 	origDryRunFlag := dryRunFlag
 	origGithubRepo := gitRepository
 	origDockerImage := dockerImageFlag
+	origBundle := bundleFlag
 	origClient := apiClient
 	origGithubRawBaseURL := githubRawBaseURL
 
@@ -334,6 +337,7 @@ func savePublishFlags(t *testing.T) {
 		dryRunFlag = origDryRunFlag
 		gitRepository = origGithubRepo
 		dockerImageFlag = origDockerImage
+		bundleFlag = origBundle
 		apiClient = origClient
 		githubRawBaseURL = origGithubRawBaseURL
 	})
@@ -496,6 +500,66 @@ func TestRunPublish_GitHubSuccess(t *testing.T) {
 	}
 }
 
+func TestRunPublish_BundleSuccess(t *testing.T) {
+	savePublishFlags(t)
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: my-skill\ndescription: test\n---\n")
+	writeFile(t, filepath.Join(dir, "helper.py"), "print('hi')\n")
+
+	var uploaded []byte
+	var published models.SkillJSON
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v0/skill-bundles":
+			uploaded, _ = io.ReadAll(r.Body)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.SkillBundle{Digest: skillbundle.Digest(uploaded), Name: "my-skill", SizeBytes: len(uploaded)})
+		case r.Method == http.MethodPost && r.URL.Path == "/v0/skills":
+			if err := json.NewDecoder(r.Body).Decode(&published); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(models.SkillResponse{Skill: published})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	apiClient = client.NewClient(srv.URL, "")
+	bundleFlag = true
+	versionFlag = "1.0.0"
+	dryRunFlag = false
+
+	if err := runPublish(nil, []string{dir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := skillbundle.Inspect(uploaded); err != nil {
+		t.Fatalf("uploaded bundle is invalid: %v", err)
+	}
+	if len(published.Packages) != 1 {
+		t.Fatalf("published packages = %+v, want one registry package", published.Packages)
+	}
+	pkg := published.Packages[0]
+	if pkg.RegistryType != models.SkillPackageTypeRegistry || pkg.Identifier != skillbundle.Digest(uploaded) {
+		t.Errorf("package = %+v, want registry package for %s", pkg, skillbundle.Digest(uploaded))
+	}
+}
+
+func TestRunPublish_BundleRequiresFolder(t *testing.T) {
+	savePublishFlags(t)
+	apiClient = client.NewClient("http://localhost:0", "")
+	bundleFlag = true
+	versionFlag = "1.0.0"
+
+	err := runPublish(nil, []string{"not-a-folder"})
+	if err == nil || !contains(err.Error(), "--bundle requires a skill folder") {
+		t.Fatalf("expected --bundle folder error, got %v", err)
+	}
+}
+
 func TestRunPublish_GitHubAPIError(t *testing.T) {
 	savePublishFlags(t)
 	mockGitHubSkillMdCheck(t)
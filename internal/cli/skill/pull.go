@@ -9,7 +9,9 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common/docker"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common/gitutil"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/printer"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
 	"github.com/spf13/cobra"
 )
 
@@ -19,7 +21,8 @@ var PullCmd = &cobra.Command{
 	Use:   "pull <skill-name> [output-directory]",
 	Short: "Pull a skill from the registry and extract it locally",
 	Long: `Pull a skill from the registry and extract its contents to a local directory.
-Supports skills packaged as Docker images or hosted in Git repositories.
+Supports skills hosted by the registry as bundles, packaged as Docker images or
+hosted in Git repositories. Registry bundles are checked against their digest.
 
 If output-directory is not specified, it will be extracted to ./skills/<skill-name>`,
 	Args: cobra.RangeArgs(1, 2),
@@ -66,12 +69,14 @@ func runPull(cmd *cobra.Command, args []string) error {
 
 	printer.PrintSuccess(fmt.Sprintf("Found skill: %s (version %s)", skillResp.Skill.Name, skillResp.Skill.Version))
 
-	// 2. Determine source: Docker package or git repository
-	var dockerImage string
+	// 2. Determine source: registry bundle, Docker package or git repository
+	var bundleDigest, dockerImage string
 	for _, pkg := range skillResp.Skill.Packages {
-		if pkg.RegistryType == "docker" {
+		switch {
+		case pkg.RegistryType == models.SkillPackageTypeRegistry && bundleDigest == "":
+			bundleDigest = pkg.Identifier
+		case pkg.RegistryType == "docker" && dockerImage == "":
 			dockerImage = pkg.Identifier
-			break
 		}
 	}
 
@@ -84,7 +89,11 @@ func runPull(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	if dockerImage != "" {
+	if bundleDigest != "" {
+		if err := pullFromBundle(bundleDigest, absOutputDir); err != nil {
+			return err
+		}
+	} else if dockerImage != "" {
 		if err := pullFromDocker(dockerImage, absOutputDir); err != nil {
 			return err
 		}
@@ -134,6 +143,22 @@ func resolveSkillVersion(skillName, requestedVersion string) (string, error) {
 	return "", fmt.Errorf("multiple versions available, specify one with --version")
 }
 
+// pullFromBundle downloads a skill bundle from the registry, verifies its
+// digest and extracts it.
+func pullFromBundle(digest, absOutputDir string) error {
+	printer.PrintInfo(fmt.Sprintf("Downloading skill bundle: %s", digest))
+	data, err := apiClient.GetSkillBundle(digest)
+	if err != nil {
+		return err
+	}
+
+	printer.PrintInfo(fmt.Sprintf("Extracting skill contents to: %s", absOutputDir))
+	if err := skillbundle.Unpack(data, absOutputDir); err != nil {
+		return fmt.Errorf("failed to extract skill bundle: %w", err)
+	}
+	return nil
+}
+
 // pullFromDocker pulls a skill from a Docker image and extracts its contents.
 func pullFromDocker(dockerImage, absOutputDir string) error {
 	printer.PrintInfo(fmt.Sprintf("Docker image: %s", dockerImage))
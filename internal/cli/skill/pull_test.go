@@ -11,6 +11,7 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/client"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
 )
 
 // newTestServer creates an httptest server that serves skill API responses.
@@ -288,3 +289,57 @@ func TestRunPull_CustomOutputDir(t *testing.T) {
 func stringContains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestRunPull_RegistryBundle(t *testing.T) {
+	skillDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("---\nname: bundled\ndescription: A bundled skill\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, err := skillbundle.Pack(skillDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := skillbundle.Digest(data)
+
+	served := data
+	_, c := newTestServer(t, map[string]http.HandlerFunc{
+		"/skills/bundled/versions/1.0.0": func(w http.ResponseWriter, r *http.Request) {
+			pkg := models.SkillPackageInfo{RegistryType: models.SkillPackageTypeRegistry, Identifier: digest, Version: "1.0.0"}
+			jsonResponse(t, w, models.SkillResponse{
+				Skill: models.SkillJSON{Name: "bundled", Version: "1.0.0", Packages: []models.SkillPackageInfo{pkg}},
+			})
+		},
+		"/skill-bundles/": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v0/skill-bundles/"+digest {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", skillbundle.ContentType)
+			_, _ = w.Write(served)
+		},
+	})
+	origClient := apiClient
+	origVersion := pullVersion
+	t.Cleanup(func() {
+		apiClient = origClient
+		pullVersion = origVersion
+	})
+	apiClient = c
+	pullVersion = "1.0.0"
+
+	outDir := filepath.Join(t.TempDir(), "out")
+	if err := runPull(nil, []string{"bundled", outDir}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "SKILL.md")); err != nil {
+		t.Errorf("expected SKILL.md to be extracted: %v", err)
+	}
+
+	// A bundle that does not match its digest is rejected.
+	served = append([]byte{}, data...)
+	served[len(served)-1] ^= 0xff
+	err = runPull(nil, []string{"bundled", filepath.Join(t.TempDir(), "tampered")})
+	if err == nil || !strings.Contains(err.Error(), "digest mismatch") {
+		t.Fatalf("expected digest mismatch error, got %v", err)
+	}
+}
@@ -61,6 +61,9 @@ func runShow(cmd *cobra.Command, args []string) error {
 
 	if skill.Meta.Official != nil {
 		t.AddRow("Status", printer.EmptyValueOrDefault(skill.Meta.Official.Status, "<none>"))
+		if skill.Meta.Official.Successor != "" {
+			t.AddRow("Successor", skill.Meta.Official.Successor)
+		}
 	}
 
 	if err := t.Render(); err != nil {
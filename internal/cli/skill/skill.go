@@ -31,6 +31,8 @@ func init() {
 	SkillCmd.AddCommand(ListCmd)
 	SkillCmd.AddCommand(PublishCmd)
 	SkillCmd.AddCommand(DeleteCmd)
+	SkillCmd.AddCommand(DeprecateCmd)
+	SkillCmd.AddCommand(UndeprecateCmd)
 	SkillCmd.AddCommand(PullCmd)
 	SkillCmd.AddCommand(ShowCmd)
 }
@@ -15,6 +15,7 @@ import (
 
 	apitypes "github.com/agentregistry-dev/agentregistry/internal/registry/api/apitypes"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
 	v0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
@@ -33,7 +34,7 @@ const (
 
 type VersionBody = apitypes.VersionBody
 
-type deploymentRequest = apitypes.DeploymentRequest
+type DeploymentRequest = apitypes.DeploymentRequest
 
 type IndexRequest = apitypes.IndexRequest
 
@@ -47,8 +48,16 @@ type JobStatusResponse = apitypes.JobStatusResponse
 
 type DeploymentResponse = models.Deployment
 
+type DeploymentRenderRequest = apitypes.DeploymentRenderRequest
+
 type DeploymentsListResponse = apitypes.DeploymentsListResponse
 
+type DeploymentLogsBody = apitypes.DeploymentLogsBody
+
+type DeploymentRevisionsResponse = apitypes.DeploymentRevisionsResponse
+
+type DeploymentLogEvent = apitypes.DeploymentLogEvent
+
 // NewClientFromEnv constructs a client using environment variables
 func NewClientFromEnv() (*Client, error) {
 	base := os.Getenv("ARCTL_API_BASE_URL")
@@ -115,12 +124,8 @@ func (c *Client) doJSON(req *http.Request, out any) error {
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		if msg := extractAPIErrorMessage(errBody); msg != "" {
-			return fmt.Errorf("%s: %s", resp.Status, msg)
-		}
-		return fmt.Errorf("unexpected status: %s, %s", resp.Status, string(errBody))
+	if err := statusError(resp); err != nil {
+		return err
 	}
 	if out == nil {
 		return nil
@@ -129,6 +134,18 @@ func (c *Client) doJSON(req *http.Request, out any) error {
 	return dec.Decode(out)
 }
 
+// statusError returns an error describing a non-2xx response, or nil.
+func statusError(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if msg := extractAPIErrorMessage(errBody); msg != "" {
+		return fmt.Errorf("%s: %s", resp.Status, msg)
+	}
+	return fmt.Errorf("unexpected status: %s, %s", resp.Status, string(errBody))
+}
+
 // extractAPIErrorMessage parses a Huma-style JSON error body and returns a
 // human-readable string with just the error messages. Returns "" if the body
 // cannot be parsed.
@@ -482,6 +499,45 @@ func (c *Client) GetPromptByNameAndVersion(name, version string) (*models.Prompt
 	return &resp, nil
 }
 
+// GetPromptVersions returns all versions for a prompt by name.
+func (c *Client) GetPromptVersions(name string) ([]*models.PromptResponse, error) {
+	encName := url.PathEscape(name)
+	req, err := c.newRequest(http.MethodGet, "/prompts/"+encName+"/versions")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.PromptListResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		// 404 -> not found returns empty list
+		if respErr := asHTTPStatus(err); respErr == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get prompt versions: %w", err)
+	}
+
+	result := make([]*models.PromptResponse, len(resp.Prompts))
+	for i := range resp.Prompts {
+		result[i] = &resp.Prompts[i]
+	}
+
+	return result, nil
+}
+
+// RenderPrompt renders a prompt version with argument values. version may
+// be "latest". It returns nil when the prompt does not exist.
+func (c *Client) RenderPrompt(name, version string, arguments map[string]string) (*models.PromptRenderResponse, error) {
+	var resp models.PromptRenderResponse
+	body := models.PromptRenderRequest{Arguments: arguments}
+	if err := c.doJsonRequest(http.MethodPost, "/prompts/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version)+"/render", body, &resp); err != nil {
+		if respErr := asHTTPStatus(err); respErr == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to render prompt: %w", err)
+	}
+	return &resp, nil
+}
+
 // CreatePrompt creates a prompt in the registry (immediately visible)
 func (c *Client) CreatePrompt(prompt *models.PromptJSON) (*models.PromptResponse, error) {
 	var resp models.PromptResponse
@@ -509,6 +565,49 @@ func (c *Client) CreateSkill(skill *models.SkillJSON) (*models.SkillResponse, er
 	return &resp, err
 }
 
+// UploadSkillBundle uploads a gzipped skill bundle. The registry stores it
+// under its digest, which skill packages of type "registry" refer to.
+func (c *Client) UploadSkillBundle(data []byte) (*models.SkillBundle, error) {
+	req, err := c.newRequest(http.MethodPost, "/skill-bundles")
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", skillbundle.ContentType)
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+
+	var resp models.SkillBundle
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to upload skill bundle: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetSkillBundle downloads a skill bundle and verifies it against its digest.
+func (c *Client) GetSkillBundle(digest string) ([]byte, error) {
+	req, err := c.newRequest(http.MethodGet, "/skill-bundles/"+url.PathEscape(digest))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download skill bundle %s: %w", digest, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if err := statusError(resp); err != nil {
+		return nil, fmt.Errorf("failed to download skill bundle %s: %w", digest, err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, skillbundle.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download skill bundle %s: %w", digest, err)
+	}
+	if err := skillbundle.Verify(data, digest); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
 // CreateAgent creates or updates an agent entry.
 func (c *Client) CreateAgent(agent *models.AgentJSON) (*models.AgentResponse, error) {
 	var resp models.AgentResponse
@@ -572,6 +671,39 @@ func (c *Client) DeleteSkill(name, version string) error {
 	return c.doJSON(req, nil)
 }
 
+// SetAgentStatus changes the status of an agent version. successor names the
+// version that replaces a deprecated one and may be empty.
+func (c *Client) SetAgentStatus(name, version, status, successor string) (*models.AgentResponse, error) {
+	var resp models.AgentResponse
+	body := models.StatusUpdate{Status: status, Successor: successor}
+	if err := c.doJsonRequest(http.MethodPut, "/agents/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version)+"/status", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetSkillStatus changes the status of a skill version. successor names the
+// version that replaces a deprecated one and may be empty.
+func (c *Client) SetSkillStatus(name, version, status, successor string) (*models.SkillResponse, error) {
+	var resp models.SkillResponse
+	body := models.StatusUpdate{Status: status, Successor: successor}
+	if err := c.doJsonRequest(http.MethodPut, "/skills/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version)+"/status", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetPromptStatus changes the status of a prompt version. successor names the
+// version that replaces a deprecated one and may be empty.
+func (c *Client) SetPromptStatus(name, version, status, successor string) (*models.PromptResponse, error) {
+	var resp models.PromptResponse
+	body := models.StatusUpdate{Status: status, Successor: successor}
+	if err := c.doJsonRequest(http.MethodPut, "/prompts/"+url.PathEscape(name)+"/versions/"+url.PathEscape(version)+"/status", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // DeleteMCPServer deletes an MCP server from the registry by setting its status to deleted
 func (c *Client) DeleteMCPServer(name, version string) error {
 	encName := url.PathEscape(name)
@@ -655,16 +787,33 @@ func (c *Client) GetDeploymentByID(id string) (*DeploymentResponse, error) {
 
 // DeployServer deploys a server with deployment environment variables.
 func (c *Client) DeployServer(name, version string, env map[string]string, preferRemote bool, providerID string) (*DeploymentResponse, error) {
-	if strings.TrimSpace(providerID) == "" {
-		providerID = defaultDeployProviderID
-	}
-	payload := deploymentRequest{
+	return c.CreateDeployment(&DeploymentRequest{
 		ServerName:   name,
 		Version:      version,
 		Env:          env,
 		PreferRemote: preferRemote,
 		ResourceType: "mcp",
 		ProviderID:   providerID,
+	})
+}
+
+// DeployAgent deploys an agent with deployment environment variables.
+func (c *Client) DeployAgent(name, version string, env map[string]string, providerID string) (*DeploymentResponse, error) {
+	return c.CreateDeployment(&DeploymentRequest{
+		ServerName:   name,
+		Version:      version,
+		Env:          env,
+		ResourceType: "agent",
+		ProviderID:   providerID,
+	})
+}
+
+// CreateDeployment creates a deployment from a full request, including secret
+// references. An empty provider ID targets the default provider.
+func (c *Client) CreateDeployment(req *DeploymentRequest) (*DeploymentResponse, error) {
+	payload := *req
+	if strings.TrimSpace(payload.ProviderID) == "" {
+		payload.ProviderID = defaultDeployProviderID
 	}
 
 	var deployment DeploymentResponse
@@ -675,27 +824,74 @@ func (c *Client) DeployServer(name, version string, env map[string]string, prefe
 	return &deployment, nil
 }
 
-// DeployAgent deploys an agent with deployment environment variables.
-func (c *Client) DeployAgent(name, version string, env map[string]string, providerID string) (*DeploymentResponse, error) {
-	if strings.TrimSpace(providerID) == "" {
-		providerID = defaultDeployProviderID
+// RenderDeployment returns the manifests a deployment would apply, without
+// deploying it.
+func (c *Client) RenderDeployment(req *DeploymentRenderRequest) (*models.DeploymentRender, error) {
+	payload := *req
+	if strings.TrimSpace(payload.ProviderID) == "" {
+		payload.ProviderID = defaultDeployProviderID
 	}
-	payload := deploymentRequest{
-		ServerName:   name,
-		Version:      version,
-		Env:          env,
-		ResourceType: "agent",
-		ProviderID:   providerID,
+
+	var render models.DeploymentRender
+	if err := c.doJsonRequest(http.MethodPost, "/deployments/render", payload, &render); err != nil {
+		return nil, err
 	}
 
+	return &render, nil
+}
+
+// UpdateDeployment rolls an existing deployment to a new version or
+// configuration in place.
+func (c *Client) UpdateDeployment(id string, update *models.DeploymentUpdate) (*DeploymentResponse, error) {
 	var deployment DeploymentResponse
-	if err := c.doJsonRequest(http.MethodPost, "/deployments", payload, &deployment); err != nil {
+	if err := c.doJsonRequest(http.MethodPatch, "/deployments/"+url.PathEscape(id), update, &deployment); err != nil {
 		return nil, err
 	}
 
 	return &deployment, nil
 }
 
+// RollbackDeployment re-applies a previous revision of a deployment.
+func (c *Client) RollbackDeployment(id string, revision int) (*DeploymentResponse, error) {
+	var deployment DeploymentResponse
+	body := apitypes.DeploymentRollbackRequest{Revision: revision}
+	if err := c.doJsonRequest(http.MethodPost, "/deployments/"+url.PathEscape(id)+"/rollback", body, &deployment); err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+// ListDeploymentRevisions lists the revisions of a deployment, oldest first.
+func (c *Client) ListDeploymentRevisions(id string) ([]models.DeploymentRevision, error) {
+	req, err := c.newRequest(http.MethodGet, "/deployments/"+url.PathEscape(id)+"/revisions")
+	if err != nil {
+		return nil, err
+	}
+	var resp DeploymentRevisionsResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list deployment revisions: %w", err)
+	}
+	return resp.Revisions, nil
+}
+
+// GetDeploymentHealth returns the last health probe of a deployment. With
+// refresh set, the registry probes the deployment before responding.
+func (c *Client) GetDeploymentHealth(id string, refresh bool) (*models.DeploymentHealth, error) {
+	path := "/deployments/" + url.PathEscape(id) + "/health"
+	if refresh {
+		path += "?refresh=true"
+	}
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	var health models.DeploymentHealth
+	if err := c.doJSON(req, &health); err != nil {
+		return nil, fmt.Errorf("failed to get deployment health: %w", err)
+	}
+	return &health, nil
+}
+
 // RemoveDeploymentByID removes a deployment by ID.
 func (c *Client) RemoveDeploymentByID(id string) error {
 	encID := url.PathEscape(id)
@@ -707,6 +903,150 @@ func (c *Client) RemoveDeploymentByID(id string) error {
 	return c.doJSON(req, nil)
 }
 
+// GetDeploymentLogs fetches a snapshot of deployment logs. tailLines of zero
+// returns all lines; since may be an RFC3339 timestamp or a duration such as "10m".
+func (c *Client) GetDeploymentLogs(id string, tailLines int64, since string) (*DeploymentLogsBody, error) {
+	req, err := c.newRequest(http.MethodGet, "/deployments/"+url.PathEscape(id)+"/logs"+deploymentLogsQuery(tailLines, since))
+	if err != nil {
+		return nil, err
+	}
+	var resp DeploymentLogsBody
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get deployment logs: %w", err)
+	}
+	return &resp, nil
+}
+
+// NewDeploymentLogsStreamRequest creates a request for following deployment logs as SSE events.
+func (c *Client) NewDeploymentLogsStreamRequest(ctx context.Context, id string, tailLines int64, since string) (*http.Request, error) {
+	req, err := c.newRequest(http.MethodGet, "/deployments/"+url.PathEscape(id)+"/logs/stream"+deploymentLogsQuery(tailLines, since))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "text/event-stream")
+	return req, nil
+}
+
+func deploymentLogsQuery(tailLines int64, since string) string {
+	q := url.Values{}
+	if tailLines > 0 {
+		q.Set("tailLines", strconv.FormatInt(tailLines, 10))
+	}
+	if since = strings.TrimSpace(since); since != "" {
+		q.Set("since", since)
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+// SearchOptions configures a cross-artifact registry search.
+type SearchOptions struct {
+	Query             string
+	Types             []string
+	AllVersions       bool
+	Semantic          bool
+	SemanticThreshold float64
+	Cursor            string
+	Limit             int
+}
+
+// Search returns a single ranked page of servers, agents, skills and prompts matching opts.
+func (c *Client) Search(opts SearchOptions) (*models.SearchResponse, error) {
+	req, err := c.newRequest(http.MethodGet, "/search"+searchQuery(opts))
+	if err != nil {
+		return nil, err
+	}
+	var resp models.SearchResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to search registry: %w", err)
+	}
+	return &resp, nil
+}
+
+func searchQuery(opts SearchOptions) string {
+	q := url.Values{}
+	if query := strings.TrimSpace(opts.Query); query != "" {
+		q.Set("q", query)
+	}
+	if len(opts.Types) > 0 {
+		q.Set("type", strings.Join(opts.Types, ","))
+	}
+	if opts.AllVersions {
+		q.Set("all_versions", "true")
+	}
+	if opts.Semantic {
+		q.Set("semantic_search", "true")
+		if opts.SemanticThreshold > 0 {
+			q.Set("semantic_threshold", strconv.FormatFloat(opts.SemanticThreshold, 'f', -1, 64))
+		}
+	}
+	if opts.Cursor != "" {
+		q.Set("cursor", opts.Cursor)
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
+// AuditOptions filters an audit log query. Empty fields are not filtered on;
+// Since and Until are RFC3339 timestamps.
+type AuditOptions struct {
+	Principal    string
+	Action       string
+	ResourceType string
+	ResourceName string
+	RequestID    string
+	Since        string
+	Until        string
+	Cursor       string
+	Limit        int
+}
+
+// ListAuditEntries returns a page of audit log entries, newest first.
+func (c *Client) ListAuditEntries(opts AuditOptions) (*models.AuditLogResponse, error) {
+	req, err := c.newRequest(http.MethodGet, "/audit"+auditQuery(opts))
+	if err != nil {
+		return nil, err
+	}
+	var resp models.AuditLogResponse
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return &resp, nil
+}
+
+func auditQuery(opts AuditOptions) string {
+	q := url.Values{}
+	for key, value := range map[string]string{
+		"principal":     opts.Principal,
+		"action":        opts.Action,
+		"resource_type": opts.ResourceType,
+		"resource_name": opts.ResourceName,
+		"request_id":    opts.RequestID,
+		"since":         opts.Since,
+		"until":         opts.Until,
+		"cursor":        opts.Cursor,
+	} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if len(q) == 0 {
+		return ""
+	}
+	return "?" + q.Encode()
+}
+
 // SSEClient returns the HTTP client used for SSE requests.
 func (c *Client) SSEClient() *http.Client {
 	return &http.Client{
@@ -756,3 +1096,108 @@ func (c *Client) GetIndexStatus(jobID string) (*JobStatusResponse, error) {
 	}
 	return &resp, nil
 }
+
+// ListMirrors returns the upstream registries mirrored into this registry.
+func (c *Client) ListMirrors() ([]models.Mirror, error) {
+	req, err := c.newRequest(http.MethodGet, "/mirrors")
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Mirrors []models.Mirror `json:"mirrors"`
+	}
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list mirrors: %w", err)
+	}
+	return resp.Mirrors, nil
+}
+
+// CreateMirror registers an upstream registry mirror.
+func (c *Client) CreateMirror(in *models.CreateMirrorInput) (*models.Mirror, error) {
+	var mirror models.Mirror
+	if err := c.doJsonRequest(http.MethodPost, "/mirrors", in, &mirror); err != nil {
+		return nil, err
+	}
+	return &mirror, nil
+}
+
+// DeleteMirror removes a mirror and its sync history.
+func (c *Client) DeleteMirror(id string) error {
+	req, err := c.newRequest(http.MethodDelete, "/mirrors/"+url.PathEscape(id))
+	if err != nil {
+		return err
+	}
+	return c.doJSON(req, nil)
+}
+
+// GetMirrorStatus returns a mirror with up to history of its most recent syncs.
+func (c *Client) GetMirrorStatus(id string, history int) (*models.MirrorStatus, error) {
+	path := "/mirrors/" + url.PathEscape(id) + "/status"
+	if history > 0 {
+		path += "?history=" + strconv.Itoa(history)
+	}
+	req, err := c.newRequest(http.MethodGet, path)
+	if err != nil {
+		return nil, err
+	}
+	var status models.MirrorStatus
+	if err := c.doJSON(req, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// SyncMirror submits an on-demand sync job for a mirror.
+func (c *Client) SyncMirror(id string) (*models.Job, error) {
+	var job models.Job
+	if err := c.doJsonRequest(http.MethodPost, "/mirrors/"+url.PathEscape(id)+"/sync", nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListSecrets returns the metadata of stored secrets.
+func (c *Client) ListSecrets() ([]models.Secret, error) {
+	req, err := c.newRequest(http.MethodGet, "/secrets")
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Secrets []models.Secret `json:"secrets"`
+	}
+	if err := c.doJSON(req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	return resp.Secrets, nil
+}
+
+// CreateSecret stores a new secret.
+func (c *Client) CreateSecret(in *models.SecretInput) (*models.Secret, error) {
+	var secret models.Secret
+	if err := c.doJsonRequest(http.MethodPost, "/secrets", in, &secret); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// UpdateSecret replaces the value and description of an existing secret.
+func (c *Client) UpdateSecret(name, value, description string) (*models.Secret, error) {
+	body := map[string]string{"value": value}
+	if description != "" {
+		body["description"] = description
+	}
+	var secret models.Secret
+	if err := c.doJsonRequest(http.MethodPut, "/secrets/"+url.PathEscape(name), body, &secret); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// DeleteSecret removes a secret that no deployment references.
+func (c *Client) DeleteSecret(name string) error {
+	req, err := c.newRequest(http.MethodDelete, "/secrets/"+url.PathEscape(name))
+	if err != nil {
+		return err
+	}
+	return c.doJSON(req, nil)
+}
@@ -153,3 +153,25 @@ func TestNewClient_HttpClientNotNil(t *testing.T) {
 		t.Error("NewClient httpClient should not be nil")
 	}
 }
+
+func TestSearchQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want string
+	}{
+		{"empty", SearchOptions{}, ""},
+		{"query and types", SearchOptions{Query: " postgres ", Types: []string{"server", "skill"}}, "?q=postgres&type=server%2Cskill"},
+		{"semantic with threshold", SearchOptions{Query: "db", Semantic: true, SemanticThreshold: 0.35}, "?q=db&semantic_search=true&semantic_threshold=0.35"},
+		{"threshold ignored without semantic", SearchOptions{Query: "db", SemanticThreshold: 0.35}, "?q=db"},
+		{"pagination and versions", SearchOptions{AllVersions: true, Cursor: "abc", Limit: 20}, "?all_versions=true&cursor=abc&limit=20"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := searchQuery(tt.opts); got != tt.want {
+				t.Errorf("searchQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -212,7 +212,9 @@ func TestDeploymentTools_DeployRemove(t *testing.T) {
 	require.NoError(t, json.Unmarshal(raw, &dep))
 	assert.Equal(t, "com.example/echo", dep.ServerName)
 	assert.Equal(t, "mcp", dep.ResourceType)
-	assert.Equal(t, "prod", dep.Env["ENV"])
+	// Env values are redacted in tool results.
+	assert.Equal(t, models.RedactedEnvValue, dep.Env["ENV"])
+	assert.Equal(t, "prod", deployed.Env["ENV"])
 
 	// deploy_agent
 	res, err = clientSession.CallTool(ctx, &mcp.CallToolParams{
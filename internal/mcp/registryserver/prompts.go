@@ -0,0 +1,181 @@
+package registryserver
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// builtinPromptNames are the prompts registered by addServerPrompts. Registry
+// prompts with the same name are only exposed as name@version so they cannot
+// shadow them.
+var builtinPromptNames = map[string]bool{
+	"search_registry":   true,
+	"deploy_mcp_server": true,
+	"registry_overview": true,
+}
+
+// PromptSync keeps the MCP prompts of a registry MCP server in step with the
+// active prompts in the registry. Every active version is exposed as
+// name@version and the latest version also as name. The MCP server notifies
+// connected clients with notifications/prompts/list_changed whenever a prompt
+// is added, changed or removed. Publishes may happen on any replica, so the
+// registry is polled rather than watched.
+type PromptSync struct {
+	server   *mcp.Server
+	registry service.RegistryService
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu         sync.Mutex
+	registered map[string]string // MCP prompt name -> fingerprint of its definition
+}
+
+// NewPromptSync creates a prompt sync for server that runs every interval.
+func NewPromptSync(server *mcp.Server, registry service.RegistryService, interval time.Duration) *PromptSync {
+	return &PromptSync{
+		server:     server,
+		registry:   registry,
+		interval:   interval,
+		logger:     slog.Default().With("component", "mcp-prompt-sync"),
+		registered: make(map[string]string),
+	}
+}
+
+// Run syncs prompts once and then on every tick until ctx is cancelled. A
+// non-positive interval syncs only once.
+func (p *PromptSync) Run(ctx context.Context) {
+	if err := p.Sync(ctx); err != nil {
+		p.logger.Error("failed to sync registry prompts", "error", err)
+	}
+	if p.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := p.Sync(ctx); err != nil {
+			p.logger.Error("failed to sync registry prompts", "error", err)
+		}
+	}
+}
+
+// Sync registers the active registry prompts that are missing or changed and
+// removes the ones that are no longer active.
+func (p *PromptSync) Sync(ctx context.Context) error {
+	prompts, err := p.listActivePrompts(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for name, prompt := range prompts {
+		fingerprint, err := json.Marshal(prompt.mcp)
+		if err != nil {
+			return err
+		}
+		if p.registered[name] == string(fingerprint) {
+			continue
+		}
+		p.server.AddPrompt(prompt.mcp, p.renderHandler(prompt.name, prompt.version))
+		p.registered[name] = string(fingerprint)
+	}
+
+	var removed []string
+	for name := range p.registered {
+		if _, ok := prompts[name]; !ok {
+			removed = append(removed, name)
+			delete(p.registered, name)
+		}
+	}
+	if len(removed) > 0 {
+		p.server.RemovePrompts(removed...)
+	}
+	return nil
+}
+
+type registryPrompt struct {
+	name    string
+	version string
+	mcp     *mcp.Prompt
+}
+
+func (p *PromptSync) listActivePrompts(ctx context.Context) (map[string]registryPrompt, error) {
+	out := make(map[string]registryPrompt)
+	cursor := ""
+	for {
+		prompts, next, err := p.registry.ListPrompts(ctx, nil, cursor, maxPageLimit)
+		if err != nil {
+			return nil, err
+		}
+		for _, prompt := range prompts {
+			if prompt.Meta.Official != nil && prompt.Meta.Official.Status != "" && prompt.Meta.Official.Status != string(model.StatusActive) {
+				continue
+			}
+			versioned := prompt.Prompt.Name + "@" + prompt.Prompt.Version
+			out[versioned] = registryPrompt{
+				name:    prompt.Prompt.Name,
+				version: prompt.Prompt.Version,
+				mcp:     toMCPPrompt(versioned, &prompt.Prompt),
+			}
+			if prompt.Meta.Official != nil && prompt.Meta.Official.IsLatest && !builtinPromptNames[prompt.Prompt.Name] {
+				out[prompt.Prompt.Name] = registryPrompt{
+					name:    prompt.Prompt.Name,
+					version: "latest",
+					mcp:     toMCPPrompt(prompt.Prompt.Name, &prompt.Prompt),
+				}
+			}
+		}
+		if next == "" || next == cursor {
+			return out, nil
+		}
+		cursor = next
+	}
+}
+
+func toMCPPrompt(name string, prompt *models.PromptJSON) *mcp.Prompt {
+	out := &mcp.Prompt{
+		Name:        name,
+		Title:       prompt.Name + " " + prompt.Version,
+		Description: prompt.Description,
+	}
+	for _, arg := range prompt.Arguments {
+		out.Arguments = append(out.Arguments, &mcp.PromptArgument{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Required:    arg.Required,
+		})
+	}
+	return out
+}
+
+// renderHandler renders the prompt on the registry, so argument defaults and
+// type checks match the render endpoint.
+func (p *PromptSync) renderHandler(name, version string) mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		rendered, err := p.registry.RenderPrompt(ctx, name, version, req.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.GetPromptResult{
+			Description: "Registry prompt " + rendered.Name + " version " + rendered.Version,
+			Messages: []*mcp.PromptMessage{
+				{Role: "user", Content: &mcp.TextContent{Text: rendered.Content}},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,122 @@
+package registryserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+func registryPromptResponse(name, version, status string, latest bool) *models.PromptResponse {
+	return &models.PromptResponse{
+		Prompt: models.PromptJSON{
+			Name:        name,
+			Version:     version,
+			Description: "Review code",
+			Content:     "Review {{language}} code",
+			Arguments:   []models.PromptArgument{{Name: "language", Description: "Language to review", Required: true}},
+		},
+		Meta: models.PromptResponseMeta{
+			Official: &models.PromptRegistryExtensions{Status: status, IsLatest: latest},
+		},
+	}
+}
+
+func TestPromptSync_ExposesRegistryPrompts(t *testing.T) {
+	ctx := context.Background()
+
+	reg := servicetesting.NewFakeRegistry()
+	reg.Prompts = []*models.PromptResponse{
+		registryPromptResponse("code-review", "1.0.0", string(model.StatusDeprecated), false),
+		registryPromptResponse("code-review", "1.1.0", string(model.StatusActive), false),
+		registryPromptResponse("code-review", "2.0.0", string(model.StatusActive), true),
+		registryPromptResponse("registry_overview", "1.0.0", string(model.StatusActive), true),
+	}
+	var renderedName, renderedVersion string
+	reg.RenderPromptFn = func(_ context.Context, name, version string, arguments map[string]string) (*models.PromptRenderResponse, error) {
+		renderedName, renderedVersion = name, version
+		return &models.PromptRenderResponse{Name: name, Version: "2.0.0", Content: "Review " + arguments["language"] + " code"}, nil
+	}
+
+	server := NewServer(reg)
+	promptSync := NewPromptSync(server, reg, 0)
+	require.NoError(t, promptSync.Sync(ctx))
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, serverSession.Wait())
+	}()
+
+	listChanged := make(chan struct{}, 1)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1"}, &mcp.ClientOptions{
+		PromptListChangedHandler: func(context.Context, *mcp.PromptListChangedRequest) {
+			select {
+			case listChanged <- struct{}{}:
+			default:
+			}
+		},
+	})
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = clientSession.Close() }()
+
+	prompts := listPromptNames(ctx, t, clientSession)
+	assert.Contains(t, prompts, "code-review")
+	assert.Contains(t, prompts, "code-review@1.1.0")
+	assert.Contains(t, prompts, "code-review@2.0.0")
+	assert.NotContains(t, prompts, "code-review@1.0.0", "deprecated versions are not exposed")
+	assert.Contains(t, prompts, "registry_overview@1.0.0")
+
+	res, err := clientSession.GetPrompt(ctx, &mcp.GetPromptParams{Name: "registry_overview"})
+	require.NoError(t, err)
+	assert.Empty(t, renderedName, "registry prompts cannot shadow built-in prompts")
+
+	res, err = clientSession.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "code-review",
+		Arguments: map[string]string{"language": "Go"},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Messages, 1)
+	assert.Equal(t, "Review Go code", res.Messages[0].Content.(*mcp.TextContent).Text)
+	assert.Equal(t, "code-review", renderedName)
+	assert.Equal(t, "latest", renderedVersion)
+
+	_, err = clientSession.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "code-review@1.1.0",
+		Arguments: map[string]string{"language": "Go"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", renderedVersion)
+
+	// Deleting a version removes its prompt and notifies the client.
+	reg.Prompts = reg.Prompts[2:]
+	require.NoError(t, promptSync.Sync(ctx))
+	select {
+	case <-listChanged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a prompts/list_changed notification")
+	}
+	prompts = listPromptNames(ctx, t, clientSession)
+	assert.NotContains(t, prompts, "code-review@1.1.0")
+	assert.Contains(t, prompts, "code-review@2.0.0")
+}
+
+func listPromptNames(ctx context.Context, t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+	res, err := session.ListPrompts(ctx, &mcp.ListPromptsParams{})
+	require.NoError(t, err)
+	names := make([]string, len(res.Prompts))
+	for i, p := range res.Prompts {
+		names[i] = p.Name
+	}
+	return names
+}
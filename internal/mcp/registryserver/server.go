@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	restv0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
@@ -35,6 +36,7 @@ func NewServer(registry service.RegistryService) *mcp.Server {
 	addAgentTools(server, registry)
 	addServerTools(server, registry)
 	addSkillTools(server, registry)
+	addSearchTools(server, registry)
 	addDeploymentTools(server, registry)
 	addMetaTools(server)
 	addServerPrompts(server)
@@ -264,7 +266,7 @@ type listSkillsArgs = restv0.ListSkillsInput
 func addSkillTools(server *mcp.Server, registry service.RegistryService) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_skills",
-		Description: "List published skills with optional search and pagination",
+		Description: "List published skills with optional search and pagination. Set semantic_search=true for natural-language queries.",
 	}, func(ctx context.Context, _ *mcp.CallToolRequest, args listSkillsArgs) (*mcp.CallToolResult, models.SkillListResponse, error) {
 		filter := &database.SkillFilter{}
 
@@ -275,7 +277,17 @@ func addSkillTools(server *mcp.Server, registry service.RegistryService) {
 			}
 			filter.UpdatedSince = &ts
 		}
-		if args.Search != "" {
+		// When semantic search is active, use pure vector similarity.
+		// Otherwise fall back to substring name matching.
+		if args.Semantic {
+			if args.Search == "" {
+				return nil, models.SkillListResponse{}, fmt.Errorf("semantic_search requires the search parameter")
+			}
+			filter.Semantic = &database.SemanticSearchOptions{
+				RawQuery:  args.Search,
+				Threshold: args.SemanticMatchThreshold,
+			}
+		} else if args.Search != "" {
 			filter.SubstringName = &args.Search
 		}
 		if args.Version != "" {
@@ -333,6 +345,37 @@ func addSkillTools(server *mcp.Server, registry service.RegistryService) {
 	})
 }
 
+type searchRegistryArgs = restv0.SearchInput
+
+func addSearchTools(server *mcp.Server, registry service.RegistryService) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_registry",
+		Description: "Search published servers, agents, skills and prompts in one ranked result set. Filter with type, page with cursor, and set semantic_search=true for natural-language queries.",
+	}, func(ctx context.Context, _ *mcp.CallToolRequest, args searchRegistryArgs) (*mcp.CallToolResult, models.SearchResponse, error) {
+		filter := &database.SearchFilter{
+			Query:       strings.TrimSpace(args.Query),
+			Types:       args.Types,
+			AllVersions: args.AllVersions,
+		}
+		if args.Semantic {
+			if filter.Query == "" {
+				return nil, models.SearchResponse{}, fmt.Errorf("semantic_search requires the q parameter")
+			}
+			filter.Semantic = &database.SemanticSearchOptions{
+				RawQuery:        filter.Query,
+				Threshold:       args.SemanticMatchThreshold,
+				HybridSubstring: &filter.Query,
+			}
+		}
+
+		resp, err := registry.SearchRegistry(ctx, filter, args.Cursor, clampLimit(args.Limit))
+		if err != nil {
+			return nil, models.SearchResponse{}, err
+		}
+		return nil, *resp, nil
+	})
+}
+
 func addMetaTools(server *mcp.Server) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "registry_health",
@@ -393,7 +436,7 @@ func addDeploymentTools(server *mcp.Server, registry service.RegistryService) {
 			if args.ResourceType != "" && d.ResourceType != args.ResourceType {
 				continue
 			}
-			resp.Deployments[outIdx] = *d
+			resp.Deployments[outIdx] = d.Redacted()
 			outIdx++
 		}
 		resp.Deployments = resp.Deployments[:outIdx]
@@ -413,7 +456,7 @@ func addDeploymentTools(server *mcp.Server, registry service.RegistryService) {
 		if err != nil {
 			return nil, models.Deployment{}, err
 		}
-		return nil, *deployment, nil
+		return nil, deployment.Redacted(), nil
 	})
 
 	// Deploy server
@@ -433,7 +476,7 @@ func addDeploymentTools(server *mcp.Server, registry service.RegistryService) {
 		if err != nil {
 			return nil, models.Deployment{}, err
 		}
-		return nil, *deployment, nil
+		return nil, deployment.Redacted(), nil
 	})
 
 	// Deploy agent
@@ -453,7 +496,7 @@ func addDeploymentTools(server *mcp.Server, registry service.RegistryService) {
 		if err != nil {
 			return nil, models.Deployment{}, err
 		}
-		return nil, *deployment, nil
+		return nil, deployment.Redacted(), nil
 	})
 
 	// Remove deployment
@@ -534,7 +577,7 @@ func addServerPrompts(server *mcp.Server) {
 		if resourceType != "" {
 			instruction += " (filter to " + resourceType + " only)"
 		}
-		instruction += ". Use the appropriate list tool (list_servers, list_agents, list_skills) with the search parameter. Summarize what you find including names, descriptions, and versions."
+		instruction += ". Use the search_registry tool, setting its type parameter when filtering by resource type. Summarize what you find including names, descriptions, and versions."
 
 		return &mcp.GetPromptResult{
 			Description: "Search the registry for resources matching a query",
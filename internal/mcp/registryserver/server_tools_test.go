@@ -192,3 +192,130 @@ func TestAgentAndSkillTools_ListAndGet(t *testing.T) {
 	require.NoError(t, json.Unmarshal(raw, &skillOne))
 	assert.Equal(t, "com.example/skill", skillOne.Skill.Name)
 }
+
+func TestSkillTools_ListSemantic(t *testing.T) {
+	ctx := context.Background()
+
+	var gotFilter *database.SkillFilter
+	reg := servicetesting.NewFakeRegistry()
+	reg.ListSkillsFn = func(_ context.Context, filter *database.SkillFilter, _ string, _ int) ([]*models.SkillResponse, string, error) {
+		gotFilter = filter
+		return []*models.SkillResponse{
+			{
+				Skill: models.SkillJSON{Name: "pdf-tools", Version: "1.0.0"},
+				Meta:  models.SkillResponseMeta{Semantic: &models.SkillSemanticMeta{Score: 0.12}},
+			},
+		}, "", nil
+	}
+
+	server := NewServer(reg)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, serverSession.Wait())
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = clientSession.Close() }()
+
+	res, err := clientSession.CallTool(ctx, &mcp.CallToolParams{
+		Name: "list_skills",
+		Arguments: map[string]any{
+			"search":             "read pdf documents",
+			"semantic_search":    true,
+			"semantic_threshold": 0.5,
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+
+	require.NotNil(t, gotFilter)
+	require.NotNil(t, gotFilter.Semantic)
+	assert.Equal(t, "read pdf documents", gotFilter.Semantic.RawQuery)
+	assert.InDelta(t, 0.5, gotFilter.Semantic.Threshold, 1e-9)
+	assert.Nil(t, gotFilter.SubstringName)
+
+	raw, _ := json.Marshal(res.StructuredContent)
+	var skillList models.SkillListResponse
+	require.NoError(t, json.Unmarshal(raw, &skillList))
+	require.Len(t, skillList.Skills, 1)
+	require.NotNil(t, skillList.Skills[0].Meta.Semantic)
+
+	// semantic_search without a search term is a tool error.
+	res, err = clientSession.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "list_skills",
+		Arguments: map[string]any{"semantic_search": true},
+	})
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+}
+
+func TestSearchTools_SearchRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	var gotFilter *database.SearchFilter
+	var gotLimit int
+	reg := servicetesting.NewFakeRegistry()
+	reg.SearchRegistryFn = func(_ context.Context, filter *database.SearchFilter, _ string, limit int) (*models.SearchResponse, error) {
+		gotFilter, gotLimit = filter, limit
+		return &models.SearchResponse{
+			Results: []models.SearchResult{
+				{Type: models.SearchTypeAgent, Name: "dba-agent", Version: "0.1.0", Score: 0.9},
+			},
+			Metadata: models.SearchMetadata{
+				Count:  1,
+				Facets: map[string]int{"server": 0, "agent": 1, "skill": 0, "prompt": 0},
+			},
+		}, nil
+	}
+
+	server := NewServer(reg)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, serverSession.Wait())
+	}()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = clientSession.Close() }()
+
+	res, err := clientSession.CallTool(ctx, &mcp.CallToolParams{
+		Name: "search_registry",
+		Arguments: map[string]any{
+			"q":               "keep postgres healthy",
+			"type":            []string{"agent", "skill"},
+			"semantic_search": true,
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+
+	require.NotNil(t, gotFilter)
+	assert.Equal(t, "keep postgres healthy", gotFilter.Query)
+	assert.Equal(t, []string{"agent", "skill"}, gotFilter.Types)
+	require.NotNil(t, gotFilter.Semantic)
+	require.NotNil(t, gotFilter.Semantic.HybridSubstring)
+	assert.Equal(t, "keep postgres healthy", *gotFilter.Semantic.HybridSubstring)
+	assert.Equal(t, defaultPageLimit, gotLimit)
+
+	raw, _ := json.Marshal(res.StructuredContent)
+	var out models.SearchResponse
+	require.NoError(t, json.Unmarshal(raw, &out))
+	require.Len(t, out.Results, 1)
+	assert.Equal(t, models.SearchTypeAgent, out.Results[0].Type)
+	assert.Equal(t, 1, out.Metadata.Facets["agent"])
+
+	// semantic_search without a query is a tool error.
+	res, err = clientSession.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "search_registry",
+		Arguments: map[string]any{"semantic_search": true},
+	})
+	require.NoError(t, err)
+	assert.True(t, res.IsError)
+}
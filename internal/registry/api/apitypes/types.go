@@ -17,6 +17,7 @@ type DeploymentRequest struct {
 	ServerName     string            `json:"serverName" doc:"Server name to deploy" example:"io.github.user/weather"`
 	Version        string            `json:"version" doc:"Version to deploy (use 'latest' for latest version)" default:"latest" example:"1.0.0"`
 	Env            map[string]string `json:"env,omitempty" doc:"Deployment environment variables."`
+	SecretRefs     map[string]string `json:"secretRefs,omitempty" doc:"Environment variables read from stored secrets, mapped to the secret name."`
 	ProviderConfig map[string]any    `json:"providerConfig,omitempty" doc:"Optional provider-specific deployment settings (not env vars)."`
 	PreferRemote   bool              `json:"preferRemote,omitempty" doc:"Prefer remote deployment over local" default:"false"`
 	ResourceType   string            `json:"resourceType,omitempty" doc:"Type of resource to deploy (mcp, agent)" default:"mcp" example:"mcp" enum:"mcp,agent"`
@@ -30,6 +31,8 @@ type IndexRequest struct {
 	DryRun         bool `json:"dryRun,omitempty" doc:"Preview changes without writing to database" default:"false"`
 	IncludeServers bool `json:"includeServers,omitempty" doc:"Include MCP servers" default:"true"`
 	IncludeAgents  bool `json:"includeAgents,omitempty" doc:"Include agents" default:"true"`
+	IncludeSkills  bool `json:"includeSkills,omitempty" doc:"Include skills" default:"true"`
+	IncludePrompts bool `json:"includePrompts,omitempty" doc:"Include prompts" default:"true"`
 	Stream         bool `json:"stream,omitempty" doc:"Use SSE streaming for progress updates" default:"false"`
 }
 
@@ -49,18 +52,48 @@ type JobResult = jobs.JobResult
 type JobStatusResponse struct {
 	JobID     string      `json:"jobId" doc:"Unique job identifier"`
 	Type      string      `json:"type" doc:"Job type"`
-	Status    string      `json:"status" doc:"Current job status (pending, running, completed, failed)"`
+	Status    string      `json:"status" doc:"Current job status (pending, running, completed, failed, cancelled)"`
 	Progress  JobProgress `json:"progress" doc:"Current progress"`
 	Result    *JobResult  `json:"result,omitempty" doc:"Final result (when completed or failed)"`
 	CreatedAt string      `json:"createdAt" doc:"Job creation timestamp"`
 	UpdatedAt string      `json:"updatedAt" doc:"Last update timestamp"`
 }
 
+// CreateJobRequest is the request body for submitting a background job.
+type CreateJobRequest struct {
+	Type   string         `json:"type" doc:"Job type (e.g. embeddings-index, import, export)" required:"true" example:"export"`
+	Params map[string]any `json:"params,omitempty" doc:"Job type specific parameters"`
+}
+
+// JobsListResponse is the job list response body.
+type JobsListResponse struct {
+	Jobs  []models.Job `json:"jobs" doc:"Jobs, newest first"`
+	Count int          `json:"count" doc:"Number of jobs returned"`
+}
+
 // DeploymentsListResponse is the deployment list response body.
 type DeploymentsListResponse struct {
 	Deployments []models.Deployment `json:"deployments" doc:"List of deployed servers"`
 }
 
+// DeploymentRevisionsResponse is the deployment revision list response body.
+type DeploymentRevisionsResponse struct {
+	DeploymentID string                      `json:"deploymentId" doc:"Deployment ID"`
+	Revisions    []models.DeploymentRevision `json:"revisions" doc:"Deployment revisions, oldest first"`
+}
+
+// DeploymentRenderRequest is the request body for rendering a deployment's
+// manifests without deploying it.
+type DeploymentRenderRequest struct {
+	DeploymentRequest
+	Format string `json:"format,omitempty" doc:"Render format. helm and kustomize are only supported on Kubernetes platforms." default:"yaml" enum:"yaml,helm,kustomize"`
+}
+
+// DeploymentRollbackRequest is the request body for rolling a deployment back.
+type DeploymentRollbackRequest struct {
+	Revision int `json:"revision" doc:"Revision to re-apply" minimum:"1" required:"true" example:"3"`
+}
+
 // DeploymentLogsBody is the JSON body returned for deployment logs requests.
 type DeploymentLogsBody struct {
 	DeploymentID string   `json:"deploymentId"`
@@ -72,3 +105,12 @@ type DeploymentLogsBody struct {
 type DeploymentLogsResponse struct {
 	Body DeploymentLogsBody
 }
+
+// DeploymentLogEvent is a server-sent event emitted by the deployment logs stream.
+// Type is one of "log", "error", or "completed".
+type DeploymentLogEvent struct {
+	Type         string `json:"type"`
+	DeploymentID string `json:"deploymentId,omitempty"`
+	Line         string `json:"line,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
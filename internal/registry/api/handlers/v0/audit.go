@@ -0,0 +1,95 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// AuditListInput represents the input for listing audit log entries
+type AuditListInput struct {
+	Principal    string `query:"principal" json:"principal,omitempty" doc:"Filter by principal" required:"false" example:"octocat"`
+	Action       string `query:"action" json:"action,omitempty" doc:"Filter by action (publish, edit, set-status, delete, create, deploy, undeploy, cancel, upload)" required:"false" example:"delete"`
+	ResourceType string `query:"resource_type" json:"resource_type,omitempty" doc:"Filter by resource type (server, agent, skill, skill-bundle, prompt, provider, deployment, mirror)" required:"false" example:"server"`
+	ResourceName string `query:"resource_name" json:"resource_name,omitempty" doc:"Filter by exact resource name" required:"false" example:"io.github.example/weather"`
+	RequestID    string `query:"request_id" json:"request_id,omitempty" doc:"Filter by request ID" required:"false"`
+	Since        string `query:"since" json:"since,omitempty" doc:"Only entries recorded at or after this time (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Until        string `query:"until" json:"until,omitempty" doc:"Only entries recorded before this time (RFC3339 datetime)" required:"false"`
+	Cursor       string `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false"`
+	Limit        int    `query:"limit" json:"limit,omitempty" doc:"Number of entries per page" default:"50" minimum:"1" maximum:"500"`
+}
+
+func optionalQuery(value string) *string {
+	if value = strings.TrimSpace(value); value == "" {
+		return nil
+	}
+	return &value
+}
+
+func parseAuditTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, huma.Error400BadRequest("Invalid " + name + " format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
+	}
+	return &t, nil
+}
+
+// RegisterAuditEndpoint registers the audit log endpoint with a custom path prefix.
+func RegisterAuditEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-audit-entries" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/audit",
+		Summary:     "List audit log entries",
+		Description: "List the append-only record of mutating registry operations, newest first. Requires a registry admin.",
+		Tags:        []string{"audit", "admin"},
+	}, func(ctx context.Context, input *AuditListInput) (*types.Response[models.AuditLogResponse], error) {
+		filter := &models.AuditFilter{
+			Principal:    optionalQuery(input.Principal),
+			Action:       optionalQuery(input.Action),
+			ResourceType: optionalQuery(input.ResourceType),
+			ResourceName: optionalQuery(input.ResourceName),
+			RequestID:    optionalQuery(input.RequestID),
+		}
+		var err error
+		if filter.Since, err = parseAuditTime("since", input.Since); err != nil {
+			return nil, err
+		}
+		if filter.Until, err = parseAuditTime("until", input.Until); err != nil {
+			return nil, err
+		}
+
+		entries, nextCursor, err := registry.ListAuditEntries(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error401Unauthorized("Authentication required")
+			}
+			if errors.Is(err, auth.ErrForbidden) {
+				return nil, huma.Error403Forbidden("Forbidden")
+			}
+			return nil, huma.Error500InternalServerError("Failed to list audit entries", err)
+		}
+
+		resp := models.AuditLogResponse{Entries: make([]models.AuditEntry, 0, len(entries))}
+		for _, entry := range entries {
+			resp.Entries = append(resp.Entries, *entry)
+		}
+		resp.Metadata = models.AuditMetadata{NextCursor: nextCursor, Count: len(resp.Entries)}
+		return &types.Response[models.AuditLogResponse]{Body: resp}, nil
+	})
+}
@@ -0,0 +1,74 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+func TestListAuditEntriesEndpoint(t *testing.T) {
+	var gotFilter *models.AuditFilter
+	var gotCursor string
+	var gotLimit int
+	registry := servicetesting.NewFakeRegistry()
+	registry.ListAuditEntriesFn = func(_ context.Context, filter *models.AuditFilter, cursor string, limit int) ([]*models.AuditEntry, string, error) {
+		gotFilter, gotCursor, gotLimit = filter, cursor, limit
+		return []*models.AuditEntry{{
+			ID:           7,
+			Principal:    "octocat",
+			AuthMethod:   "github-at",
+			Action:       models.AuditActionDelete,
+			ResourceType: models.AuditResourceServer,
+			ResourceName: "io.github.octocat/weather",
+		}}, "7", nil
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterAuditEndpoint(api, "/v0", registry)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/audit?principal=octocat&action=delete&since=2025-08-01T00:00:00Z&cursor=12&limit=1", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp models.AuditLogResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Entries, 1)
+	assert.Equal(t, "octocat", resp.Entries[0].Principal)
+	assert.Equal(t, "7", resp.Metadata.NextCursor)
+	assert.Equal(t, 1, resp.Metadata.Count)
+
+	require.NotNil(t, gotFilter.Principal)
+	assert.Equal(t, "octocat", *gotFilter.Principal)
+	require.NotNil(t, gotFilter.Action)
+	assert.Equal(t, models.AuditActionDelete, *gotFilter.Action)
+	assert.Nil(t, gotFilter.ResourceType)
+	require.NotNil(t, gotFilter.Since)
+	assert.Nil(t, gotFilter.Until)
+	assert.Equal(t, "12", gotCursor)
+	assert.Equal(t, 1, gotLimit)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/audit?since=yesterday", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// The log is restricted to registry admins.
+	registry.ListAuditEntriesFn = func(context.Context, *models.AuditFilter, string, int) ([]*models.AuditEntry, string, error) {
+		return nil, "", auth.ErrForbidden
+	}
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/audit", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -461,7 +461,7 @@ func TestPermissionResourceMatching(t *testing.T) {
 				},
 			}
 
-			hasPermission := jwtManager.HasPermission(tc.resource, tc.action, permissions)
+			hasPermission := hasPermission(jwtManager, tc.resource, tc.action, permissions)
 			assert.Equal(t, tc.expectedMatch, hasPermission)
 		})
 	}
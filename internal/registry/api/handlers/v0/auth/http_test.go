@@ -543,7 +543,7 @@ func TestHTTPAuthHandler_Permissions(t *testing.T) {
 				assert.NotContains(t, patterns, unexpectedPattern, "Unexpected pattern %s found", unexpectedPattern)
 			}
 
-			// Verify the permission patterns work correctly with the JWT manager's HasPermission method
+			// Verify the permission patterns work correctly with the JWT manager's permission check
 			for _, expectedPattern := range tt.expectedPatterns {
 				// Find the permission with this pattern
 				var foundPerm *intauth.Permission
@@ -559,12 +559,12 @@ func TestHTTPAuthHandler_Permissions(t *testing.T) {
 				if basePattern, found := strings.CutSuffix(expectedPattern, "/*"); found {
 					// Exact domain permissions (e.g., "com.example/*")
 					testResource := basePattern + "/my-package"
-					assert.True(t, jwtManager.HasPermission(testResource, intauth.PermissionActionPublish, claims.Permissions),
+					assert.True(t, hasPermission(jwtManager, testResource, intauth.PermissionActionPublish, claims.Permissions),
 						"Should have permission for %s with pattern %s", testResource, expectedPattern)
 
 					// Test that subdomain resources are NOT allowed for HTTP
 					subdomainResource := basePattern + ".subdomain/my-package"
-					assert.False(t, jwtManager.HasPermission(subdomainResource, intauth.PermissionActionPublish, claims.Permissions),
+					assert.False(t, hasPermission(jwtManager, subdomainResource, intauth.PermissionActionPublish, claims.Permissions),
 						"Should NOT have permission for subdomain %s with HTTP auth", subdomainResource)
 				}
 			}
@@ -664,7 +664,7 @@ func TestHTTPAuthHandler_PermissionValidation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			hasPermission := jwtManager.HasPermission(tc.resource, tc.action, claims.Permissions)
+			hasPermission := hasPermission(jwtManager, tc.resource, tc.action, claims.Permissions)
 			if tc.shouldPass {
 				assert.True(t, hasPermission, "Expected permission for resource %s with action %s", tc.resource, tc.action)
 			} else {
@@ -757,8 +757,8 @@ func TestHTTPvsDNS_PermissionDifferences(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			httpPermission := jwtManager.HasPermission(tc.resource, intauth.PermissionActionPublish, httpClaims.Permissions)
-			dnsPermission := jwtManager.HasPermission(tc.resource, intauth.PermissionActionPublish, dnsClaims.Permissions)
+			httpPermission := hasPermission(jwtManager, tc.resource, intauth.PermissionActionPublish, httpClaims.Permissions)
+			dnsPermission := hasPermission(jwtManager, tc.resource, intauth.PermissionActionPublish, dnsClaims.Permissions)
 
 			assert.Equal(t, tc.httpAllowed, httpPermission, "HTTP permission mismatch for %s", tc.resource)
 			assert.Equal(t, tc.dnsAllowed, dnsPermission, "DNS permission mismatch for %s", tc.resource)
@@ -1050,7 +1050,7 @@ func TestHTTPAuthHandler_ECDSAP384_Permissions(t *testing.T) {
 				assert.NotContains(t, patterns, unexpectedPattern, "Unexpected pattern %s found", unexpectedPattern)
 			}
 
-			// Verify the permission patterns work correctly with the JWT manager's HasPermission method
+			// Verify the permission patterns work correctly with the JWT manager's permission check
 			for _, expectedPattern := range tt.expectedPatterns {
 				// Find the permission with this pattern
 				var foundPerm *intauth.Permission
@@ -1065,7 +1065,7 @@ func TestHTTPAuthHandler_ECDSAP384_Permissions(t *testing.T) {
 				// Test resource scenarios - only exact domain should work for HTTP
 				basePattern := strings.TrimSuffix(expectedPattern, "/*")
 				testResource := basePattern + "/my-package"
-				assert.True(t, jwtManager.HasPermission(testResource, intauth.PermissionActionPublish, claims.Permissions),
+				assert.True(t, hasPermission(jwtManager, testResource, intauth.PermissionActionPublish, claims.Permissions),
 					"Should have permission for %s with pattern %s", testResource, expectedPattern)
 			}
 		})
@@ -1144,7 +1144,7 @@ func TestHTTPAuthHandler_ECDSAP384_PermissionValidation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			hasPermission := jwtManager.HasPermission(tc.resource, tc.action, claims.Permissions)
+			hasPermission := hasPermission(jwtManager, tc.resource, tc.action, claims.Permissions)
 			if tc.shouldPass {
 				assert.True(t, hasPermission, "Expected permission for resource %s with action %s", tc.resource, tc.action)
 			} else {
@@ -1229,8 +1229,8 @@ func TestHTTPAuthHandler_Ed25519_vs_ECDSAP384_Equivalence(t *testing.T) {
 			}
 
 			for _, resource := range testResources {
-				ed25519HasPerm := jwtManager.HasPermission(resource, intauth.PermissionActionPublish, ed25519Claims.Permissions)
-				ecdsaHasPerm := jwtManager.HasPermission(resource, intauth.PermissionActionPublish, ecdsaClaims.Permissions)
+				ed25519HasPerm := hasPermission(jwtManager, resource, intauth.PermissionActionPublish, ed25519Claims.Permissions)
+				ecdsaHasPerm := hasPermission(jwtManager, resource, intauth.PermissionActionPublish, ecdsaClaims.Permissions)
 				assert.Equal(t, ed25519HasPerm, ecdsaHasPerm, "Permission mismatch for resource %s between Ed25519 and ECDSA P-384", resource)
 			}
 		})
@@ -1320,3 +1320,17 @@ func TestHTTPAuthHandler_Algorithm_Support(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid signature size for Ed25519")
 	})
 }
+
+// permissionSession is a session holding only the given permissions.
+type permissionSession struct {
+	permissions []intauth.Permission
+}
+
+func (s *permissionSession) Principal() intauth.Principal {
+	return intauth.Principal{User: intauth.User{Permissions: s.permissions}}
+}
+
+// hasPermission reports whether permissions allow action on the named resource.
+func hasPermission(jwtManager *intauth.JWTManager, resource string, action intauth.PermissionAction, permissions []intauth.Permission) bool {
+	return jwtManager.Check(context.Background(), &permissionSession{permissions: permissions}, action, intauth.Resource{Name: resource}) == nil
+}
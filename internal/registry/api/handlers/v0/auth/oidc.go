@@ -241,10 +241,7 @@ func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
 		for pattern := range strings.SplitSeq(h.config.OIDCReadPerms, ",") {
 			pattern = strings.TrimSpace(pattern)
 			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionRead,
-					ResourcePattern: pattern,
-				})
+				permissions = append(permissions, auth.ParsePermission(auth.PermissionActionRead, pattern))
 			}
 		}
 	}
@@ -253,10 +250,7 @@ func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
 		for pattern := range strings.SplitSeq(h.config.OIDCPushPerms, ",") {
 			pattern = strings.TrimSpace(pattern)
 			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionPublish,
-					ResourcePattern: pattern,
-				})
+				permissions = append(permissions, auth.ParsePermission(auth.PermissionActionPublish, pattern))
 			}
 		}
 	}
@@ -265,10 +259,7 @@ func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
 		for pattern := range strings.SplitSeq(h.config.OIDCDeployPerms, ",") {
 			pattern = strings.TrimSpace(pattern)
 			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionDeploy,
-					ResourcePattern: pattern,
-				})
+				permissions = append(permissions, auth.ParsePermission(auth.PermissionActionDeploy, pattern))
 			}
 		}
 	}
@@ -278,10 +269,7 @@ func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
 		for pattern := range strings.SplitSeq(h.config.OIDCPublishPerms, ",") {
 			pattern = strings.TrimSpace(pattern)
 			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionPublish,
-					ResourcePattern: pattern,
-				})
+				permissions = append(permissions, auth.ParsePermission(auth.PermissionActionPublish, pattern))
 			}
 		}
 	}
@@ -290,10 +278,7 @@ func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
 		for pattern := range strings.SplitSeq(h.config.OIDCEditPerms, ",") {
 			pattern = strings.TrimSpace(pattern)
 			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionEdit,
-					ResourcePattern: pattern,
-				})
+				permissions = append(permissions, auth.ParsePermission(auth.PermissionActionEdit, pattern))
 			}
 		}
 	}
@@ -302,10 +287,7 @@ func (h *OIDCHandler) buildPermissions(_ *OIDCClaims) []auth.Permission {
 		for pattern := range strings.SplitSeq(h.config.OIDCDeletePerms, ",") {
 			pattern = strings.TrimSpace(pattern)
 			if pattern != "" {
-				permissions = append(permissions, auth.Permission{
-					Action:          auth.PermissionActionDelete,
-					ResourcePattern: pattern,
-				})
+				permissions = append(permissions, auth.ParsePermission(auth.PermissionActionDelete, pattern))
 			}
 		}
 	}
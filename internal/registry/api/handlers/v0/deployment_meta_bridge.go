@@ -25,7 +25,9 @@ func deploymentResourceIndex(ctx context.Context, registry service.RegistryServi
 		if deployment == nil {
 			continue
 		}
-		if deployment.Status != models.DeploymentStatusDeployed {
+		// Drifted, missing and unhealthy deployments are still listed so the
+		// catalog shows that a workload needs attention.
+		if !models.IsReconciledDeploymentStatus(deployment.Status) {
 			continue
 		}
 
@@ -45,6 +47,7 @@ func deploymentResourceIndex(ctx context.Context, registry service.RegistryServi
 			Status:     deployment.Status,
 			Origin:     deployment.Origin,
 			Version:    deployment.Version,
+			Error:      deployment.Error,
 			DeployedAt: deployment.DeployedAt,
 			UpdatedAt:  deployment.UpdatedAt,
 		})
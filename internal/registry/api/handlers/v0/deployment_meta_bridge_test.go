@@ -67,3 +67,27 @@ func TestAttachServerDeploymentMetaMatchesVersionAndLatest(t *testing.T) {
 	assert.Equal(t, 1, enriched[1].Meta.Deployments.Count)
 	assert.Equal(t, "dep-latest", enriched[1].Meta.Deployments.Deployments[0].ID)
 }
+
+func TestDeploymentResourceIndexSurfacesDriftStatuses(t *testing.T) {
+	now := time.Now().UTC()
+	reg := &servicetest.FakeRegistry{
+		GetDeploymentsFn: func(_ context.Context, _ *models.DeploymentFilter) ([]*models.Deployment, error) {
+			return []*models.Deployment{
+				{ID: "dep-drifted", ServerName: "io.test/server", ResourceType: "mcp", Status: models.DeploymentStatusDrifted, Error: "service runs image demo:1.0.0, expected demo:2.0.0", UpdatedAt: now},
+				{ID: "dep-missing", ServerName: "io.test/server", ResourceType: "mcp", Status: models.DeploymentStatusMissing, UpdatedAt: now.Add(-time.Second)},
+				{ID: "dep-unhealthy", ServerName: "io.test/server", ResourceType: "mcp", Status: models.DeploymentStatusUnhealthy, UpdatedAt: now.Add(-2 * time.Second)},
+				{ID: "dep-failed", ServerName: "io.test/server", ResourceType: "mcp", Status: models.DeploymentStatusFailed, UpdatedAt: now.Add(-3 * time.Second)},
+			}, nil
+		},
+	}
+
+	index := deploymentResourceIndex(context.Background(), reg)
+	key := deploymentResourceKey{resourceType: "mcp", resourceName: "io.test/server"}
+
+	require.Len(t, index[key], 3)
+	assert.Equal(t, "dep-drifted", index[key][0].ID)
+	assert.Equal(t, models.DeploymentStatusDrifted, index[key][0].Status)
+	assert.Contains(t, index[key][0].Error, "demo:1.0.0")
+	assert.Equal(t, "dep-missing", index[key][1].ID)
+	assert.Equal(t, "dep-unhealthy", index[key][2].ID)
+}
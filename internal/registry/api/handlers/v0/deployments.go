@@ -2,12 +2,16 @@ package v0
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/api/apitypes"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/platforms/utils"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/secrets"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
@@ -34,6 +38,46 @@ type DeploymentByIDInput struct {
 	ID string `path:"id" json:"id" doc:"Deployment ID" example:"6b7ce4ab-ec3d-4789-95f4-8be5fac2e6be"`
 }
 
+// DeploymentUpdateInput represents the path and body of an in-place deployment update.
+type DeploymentUpdateInput struct {
+	ID   string `path:"id" json:"id" doc:"Deployment ID" example:"6b7ce4ab-ec3d-4789-95f4-8be5fac2e6be"`
+	Body models.DeploymentUpdate
+}
+
+// DeploymentRollbackInput represents the path and body of a deployment rollback.
+type DeploymentRollbackInput struct {
+	ID   string `path:"id" json:"id" doc:"Deployment ID" example:"6b7ce4ab-ec3d-4789-95f4-8be5fac2e6be"`
+	Body apitypes.DeploymentRollbackRequest
+}
+
+// DeploymentRevisionsResponse represents the revision history of a deployment.
+type DeploymentRevisionsResponse struct {
+	Body apitypes.DeploymentRevisionsResponse
+}
+
+// DeploymentLogsInput represents path and query parameters for deployment log operations.
+type DeploymentLogsInput struct {
+	ID        string `path:"id" json:"id" doc:"Deployment ID" example:"6b7ce4ab-ec3d-4789-95f4-8be5fac2e6be"`
+	TailLines int64  `query:"tailLines" json:"tailLines,omitempty" doc:"Only return the last N lines per workload (0 returns all lines)" minimum:"0"`
+	Since     string `query:"since" json:"since,omitempty" doc:"Only return lines newer than an RFC3339 timestamp or a relative duration" example:"10m"`
+}
+
+// DeploymentRenderResponse represents the manifests rendered for a deployment.
+type DeploymentRenderResponse struct {
+	Body models.DeploymentRender
+}
+
+// DeploymentHealthInput represents path and query parameters for deployment health checks.
+type DeploymentHealthInput struct {
+	ID      string `path:"id" json:"id" doc:"Deployment ID" example:"6b7ce4ab-ec3d-4789-95f4-8be5fac2e6be"`
+	Refresh bool   `query:"refresh" json:"refresh,omitempty" doc:"Probe the deployment now instead of returning the last stored result"`
+}
+
+// DeploymentHealthResponse represents the last health probe of a deployment.
+type DeploymentHealthResponse struct {
+	Body models.DeploymentHealth
+}
+
 // DeploymentsListInput represents query parameters for listing deployments
 type DeploymentsListInput struct {
 	Platform     string `query:"platform" json:"platform,omitempty" doc:"Filter by provider platform type (matches registered provider platforms)" example:"local"`
@@ -62,6 +106,10 @@ func createDeploymentHTTPError(err error) error {
 		return huma.Error403Forbidden("Forbidden")
 	case errors.Is(err, database.ErrAlreadyExists):
 		return huma.Error409Conflict("Deployment with this ID already exists")
+	case errors.Is(err, utils.ErrDeploymentCancelled):
+		return huma.Error409Conflict("Deployment was cancelled")
+	case errors.Is(err, secrets.ErrNotConfigured):
+		return huma.Error503ServiceUnavailable("Secret storage is not configured")
 	case err.Error() == "agent deployment is not yet implemented":
 		return huma.Error501NotImplemented("Agent deployment is not yet supported")
 	default:
@@ -69,6 +117,44 @@ func createDeploymentHTTPError(err error) error {
 	}
 }
 
+func renderDeploymentHTTPError(err error) error {
+	switch {
+	case service.IsUnsupportedDeploymentPlatformError(err):
+		return huma.Error400BadRequest("Unsupported provider or platform for deployment")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound(err.Error())
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Forbidden")
+	default:
+		return huma.Error500InternalServerError("Failed to render deployment", err)
+	}
+}
+
+func updateDeploymentHTTPError(err error) error {
+	switch {
+	case service.IsUnsupportedDeploymentPlatformError(err):
+		return huma.Error400BadRequest("Unsupported provider or platform for deployment")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound(err.Error())
+	case errors.Is(err, database.ErrConflict):
+		return huma.Error409Conflict(err.Error())
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Forbidden")
+	case errors.Is(err, secrets.ErrNotConfigured):
+		return huma.Error503ServiceUnavailable("Secret storage is not configured")
+	default:
+		return huma.Error500InternalServerError("Failed to update deployment", err)
+	}
+}
+
 func removeDeploymentHTTPError(err error) error {
 	switch {
 	case service.IsUnsupportedDeploymentPlatformError(err):
@@ -137,7 +223,7 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 		resp := &DeploymentsListResponse{}
 		resp.Body.Deployments = make([]models.Deployment, 0, len(deployments))
 		for _, d := range deployments {
-			resp.Body.Deployments = append(resp.Body.Deployments, *d)
+			resp.Body.Deployments = append(resp.Body.Deployments, d.Redacted())
 		}
 
 		return resp, nil
@@ -166,7 +252,7 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 			return nil, huma.Error500InternalServerError("Failed to retrieve deployment", err)
 		}
 
-		return &DeploymentResponse{Body: *deployment}, nil
+		return &DeploymentResponse{Body: deployment.Redacted()}, nil
 	})
 
 	// Deploy a server
@@ -207,6 +293,7 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 			ResourceType:   resourceType,
 			Origin:         "managed",
 			Env:            input.Body.Env,
+			SecretRefs:     input.Body.SecretRefs,
 			ProviderConfig: input.Body.ProviderConfig,
 			PreferRemote:   input.Body.PreferRemote,
 		}
@@ -216,7 +303,50 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 			return nil, createDeploymentHTTPError(err)
 		}
 
-		return &DeploymentResponse{Body: *deployment}, nil
+		return &DeploymentResponse{Body: deployment.Redacted()}, nil
+	})
+
+	// Render a deployment without applying it
+	huma.Register(api, huma.Operation{
+		OperationID: "render-deployment",
+		Method:      http.MethodPost,
+		Path:        basePath + "/deployments/render",
+		Summary:     "Render deployment manifests",
+		Description: "Return the manifests the provider's platform would apply for a resource, without deploying it or recording a deployment. Kubernetes platforms render plain YAML, a Helm chart or a kustomization; the local platform renders its compose and gateway files. Secret values are never rendered: secret keys are listed with empty values.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *struct {
+		Body apitypes.DeploymentRenderRequest
+	}) (*DeploymentRenderResponse, error) {
+		resourceType := input.Body.ResourceType
+		if resourceType == "" {
+			resourceType = "mcp"
+		}
+		if resourceType != "mcp" && resourceType != "agent" {
+			return nil, huma.Error400BadRequest("Invalid resource type. Must be 'mcp' or 'agent'")
+		}
+
+		providerID := strings.TrimSpace(input.Body.ProviderID)
+		if providerID == "" {
+			return nil, huma.Error400BadRequest("providerId is required")
+		}
+		if _, err := getProviderByID(ctx, registry, extensions, providerID, ""); err != nil {
+			return nil, err
+		}
+
+		render, err := registry.RenderDeployment(ctx, &models.Deployment{
+			ServerName:     input.Body.ServerName,
+			Version:        input.Body.Version,
+			ProviderID:     providerID,
+			ResourceType:   resourceType,
+			Env:            input.Body.Env,
+			SecretRefs:     input.Body.SecretRefs,
+			ProviderConfig: input.Body.ProviderConfig,
+			PreferRemote:   input.Body.PreferRemote,
+		}, input.Body.Format)
+		if err != nil {
+			return nil, renderDeploymentHTTPError(err)
+		}
+		return &DeploymentRenderResponse{Body: *render}, nil
 	})
 
 	// Remove a deployment
@@ -255,15 +385,83 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 		return &struct{}{}, nil
 	})
 
-	// Get deployment logs (async providers)
+	// Update a deployment in place
+	huma.Register(api, huma.Operation{
+		OperationID: "update-deployment",
+		Method:      http.MethodPatch,
+		Path:        basePath + "/deployments/{id}",
+		Summary:     "Update a deployment",
+		Description: "Roll a deployment to a new version, env, secret references, or provider config in place. The deployment keeps its ID. Omitted fields are left unchanged; `env` and `secretRefs` replace the current maps. If the rollout fails, the previous configuration is restored and the failure is recorded in the deployment's error.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *DeploymentUpdateInput) (*DeploymentResponse, error) {
+		deployment, err := registry.UpdateDeployment(ctx, input.ID, &input.Body)
+		if err != nil {
+			return nil, updateDeploymentHTTPError(err)
+		}
+		return &DeploymentResponse{Body: deployment.Redacted()}, nil
+	})
+
+	// List deployment revisions
+	huma.Register(api, huma.Operation{
+		OperationID: "list-deployment-revisions",
+		Method:      http.MethodGet,
+		Path:        basePath + "/deployments/{id}/revisions",
+		Summary:     "List deployment revisions",
+		Description: "List the revisions recorded for a deployment, oldest first. Every deploy, update, rollback and undeploy records an immutable revision with the applied version, env keys, secret references, provider config and resulting status. Env values are not recorded. Revisions remain available after the deployment is removed.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *DeploymentByIDInput) (*DeploymentRevisionsResponse, error) {
+		revisions, err := registry.ListDeploymentRevisions(ctx, input.ID)
+		if err != nil {
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				return nil, huma.Error404NotFound("Deployment not found")
+			case errors.Is(err, auth.ErrUnauthenticated):
+				return nil, huma.Error401Unauthorized("Authentication required")
+			case errors.Is(err, auth.ErrForbidden):
+				return nil, huma.Error403Forbidden("Forbidden")
+			default:
+				return nil, huma.Error500InternalServerError("Failed to list deployment revisions", err)
+			}
+		}
+		body := apitypes.DeploymentRevisionsResponse{
+			DeploymentID: input.ID,
+			Revisions:    make([]models.DeploymentRevision, 0, len(revisions)),
+		}
+		for _, revision := range revisions {
+			body.Revisions = append(body.Revisions, *revision)
+		}
+		return &DeploymentRevisionsResponse{Body: body}, nil
+	})
+
+	// Roll a deployment back to a previous revision
+	huma.Register(api, huma.Operation{
+		OperationID: "rollback-deployment",
+		Method:      http.MethodPost,
+		Path:        basePath + "/deployments/{id}/rollback",
+		Summary:     "Roll back a deployment",
+		Description: "Re-apply the version, secret references and provider config of a previous revision through the deployment's platform adapter. Env is restored to the revision's keys with their current values; a key the deployment no longer sets must be supplied through an update instead. The rollback is recorded as a new revision. Failed and undeploy revisions cannot be re-applied.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *DeploymentRollbackInput) (*DeploymentResponse, error) {
+		deployment, err := registry.RollbackDeployment(ctx, input.ID, input.Body.Revision)
+		if err != nil {
+			return nil, updateDeploymentHTTPError(err)
+		}
+		return &DeploymentResponse{Body: deployment.Redacted()}, nil
+	})
+
+	// Get deployment logs
 	huma.Register(api, huma.Operation{
 		OperationID: "get-deployment-logs",
 		Method:      http.MethodGet,
 		Path:        basePath + "/deployments/{id}/logs",
 		Summary:     "Get deployment logs",
-		Description: "Get logs for async deployments when supported by the provider",
+		Description: "Get logs for a deployment when supported by the provider",
 		Tags:        []string{"deployments"},
-	}, func(ctx context.Context, input *DeploymentByIDInput) (*DeploymentLogsResponse, error) {
+	}, func(ctx context.Context, input *DeploymentLogsInput) (*DeploymentLogsResponse, error) {
+		opts, err := deploymentLogOptions(input, false)
+		if err != nil {
+			return nil, err
+		}
 		deployment, err := registry.GetDeploymentByID(ctx, input.ID)
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
@@ -278,18 +476,9 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 			return nil, huma.Error500InternalServerError("Failed to retrieve deployment", err)
 		}
 
-		logs, err := registry.GetDeploymentLogs(ctx, deployment)
+		logs, err := registry.GetDeploymentLogs(ctx, deployment, opts)
 		if err != nil {
-			if errors.Is(err, database.ErrInvalidInput) {
-				return nil, huma.Error400BadRequest("Invalid deployment logs request")
-			}
-			if errors.Is(err, database.ErrNotFound) {
-				return nil, huma.Error404NotFound("Deployment logs not found")
-			}
-			if errors.Is(err, utils.ErrDeploymentNotSupported) {
-				return nil, huma.Error501NotImplemented("Deployment logs are not supported for this provider")
-			}
-			return nil, huma.Error500InternalServerError("Failed to fetch deployment logs", err)
+			return nil, deploymentLogsHTTPError(err)
 		}
 		return &DeploymentLogsResponse{Body: apitypes.DeploymentLogsBody{
 			DeploymentID: deployment.ID,
@@ -298,13 +487,61 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 		}}, nil
 	})
 
+	// Get deployment health
+	huma.Register(api, huma.Operation{
+		OperationID: "get-deployment-health",
+		Method:      http.MethodGet,
+		Path:        basePath + "/deployments/{id}/health",
+		Summary:     "Get deployment health",
+		Description: "Get the last health probe of a deployment: an MCP initialize handshake and tool listing through its gateway route for servers, or an agent card fetch for agents. Deployments that were never probed, or requests with `refresh`, are probed before responding.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *DeploymentHealthInput) (*DeploymentHealthResponse, error) {
+		health, err := registry.GetDeploymentHealth(ctx, input.ID, input.Refresh)
+		if err != nil {
+			return nil, deploymentHealthHTTPError(err)
+		}
+		return &DeploymentHealthResponse{Body: *health}, nil
+	})
+
+	// Follow deployment logs as server-sent events
+	huma.Register(api, huma.Operation{
+		OperationID: "stream-deployment-logs",
+		Method:      http.MethodGet,
+		Path:        basePath + "/deployments/{id}/logs/stream",
+		Summary:     "Stream deployment logs",
+		Description: "Follow logs for a deployment as server-sent events. Each event is a JSON object with a `type` of log, error, or completed.",
+		Tags:        []string{"deployments"},
+	}, func(ctx context.Context, input *DeploymentLogsInput) (*huma.StreamResponse, error) {
+		opts, err := deploymentLogOptions(input, true)
+		if err != nil {
+			return nil, err
+		}
+		deployment, err := registry.GetDeploymentByID(ctx, input.ID)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Deployment not found")
+			}
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error401Unauthorized("Authentication required")
+			}
+			if errors.Is(err, auth.ErrForbidden) {
+				return nil, huma.Error403Forbidden("Forbidden")
+			}
+			return nil, huma.Error500InternalServerError("Failed to retrieve deployment", err)
+		}
+
+		return &huma.StreamResponse{Body: func(hctx huma.Context) {
+			streamDeploymentLogs(hctx, registry, deployment, opts)
+		}}, nil
+	})
+
 	// Cancel in-progress deployment (async providers)
 	huma.Register(api, huma.Operation{
 		OperationID: "cancel-deployment",
 		Method:      http.MethodPost,
 		Path:        basePath + "/deployments/{id}/cancel",
 		Summary:     "Cancel deployment",
-		Description: "Cancel a deployment when supported by the provider",
+		Description: "Cancel an in-progress deployment when supported by the provider. Partially applied platform state is rolled back and the deployment is marked cancelled. Requires deploy permission on the deployed artifact. Only the registry instance applying the deployment can cancel it; other instances respond with 409 Conflict.",
 		Tags:        []string{"deployments"},
 	}, func(ctx context.Context, input *DeploymentByIDInput) (*struct{}, error) {
 		deployment, err := registry.GetDeploymentByID(ctx, input.ID)
@@ -323,11 +560,20 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 
 		if err := registry.CancelDeployment(ctx, deployment); err != nil {
 			if errors.Is(err, database.ErrInvalidInput) {
-				return nil, huma.Error400BadRequest("Invalid deployment cancel request")
+				return nil, huma.Error400BadRequest(err.Error())
 			}
 			if errors.Is(err, database.ErrNotFound) {
 				return nil, huma.Error404NotFound("Deployment job not found")
 			}
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error401Unauthorized("Authentication required")
+			}
+			if errors.Is(err, auth.ErrForbidden) {
+				return nil, huma.Error403Forbidden("Forbidden")
+			}
+			if errors.Is(err, database.ErrConflict) {
+				return nil, huma.Error409Conflict(err.Error())
+			}
 			if errors.Is(err, utils.ErrDeploymentNotSupported) {
 				return nil, huma.Error501NotImplemented("Deployment cancel is not supported for this provider")
 			}
@@ -336,3 +582,103 @@ func RegisterDeploymentsEndpoints(api huma.API, basePath string, registry servic
 		return &struct{}{}, nil
 	})
 }
+
+// deploymentLogOptions converts log query parameters into adapter options.
+func deploymentLogOptions(input *DeploymentLogsInput, follow bool) (models.DeploymentLogOptions, error) {
+	opts := models.DeploymentLogOptions{TailLines: input.TailLines, Follow: follow}
+	since := strings.TrimSpace(input.Since)
+	if since == "" {
+		return opts, nil
+	}
+	if ts, err := time.Parse(time.RFC3339, since); err == nil {
+		opts.Since = &ts
+		return opts, nil
+	}
+	d, err := time.ParseDuration(since)
+	if err != nil || d < 0 {
+		return opts, huma.Error400BadRequest("since must be an RFC3339 timestamp or a positive duration")
+	}
+	ts := time.Now().Add(-d)
+	opts.Since = &ts
+	return opts, nil
+}
+
+func deploymentLogsHTTPError(err error) error {
+	switch {
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest("Invalid deployment logs request")
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Deployment logs not found")
+	case errors.Is(err, utils.ErrDeploymentNotSupported):
+		return huma.Error501NotImplemented("Deployment logs are not supported for this provider")
+	default:
+		return huma.Error500InternalServerError("Failed to fetch deployment logs", err)
+	}
+}
+
+func deploymentHealthHTTPError(err error) error {
+	switch {
+	case service.IsUnsupportedDeploymentPlatformError(err):
+		return huma.Error400BadRequest("Unsupported provider or platform for deployment")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Deployment not found")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Forbidden")
+	default:
+		return huma.Error500InternalServerError("Failed to check deployment health", err)
+	}
+}
+
+// streamDeploymentLogs writes deployment log lines as SSE events until the
+// adapter stream ends or the client disconnects.
+func streamDeploymentLogs(hctx huma.Context, registry service.RegistryService, deployment *models.Deployment, opts models.DeploymentLogOptions) {
+	hctx.SetHeader("Content-Type", "text/event-stream")
+	hctx.SetHeader("Cache-Control", "no-cache")
+	hctx.SetHeader("Connection", "keep-alive")
+	hctx.SetHeader("X-Accel-Buffering", "no")
+	hctx.SetStatus(http.StatusOK)
+
+	w := hctx.BodyWriter()
+	flusher, _ := w.(http.Flusher)
+	sendEvent := func(event apitypes.DeploymentLogEvent) error {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	ctx := hctx.Context()
+	err := registry.StreamDeploymentLogs(ctx, deployment, opts, func(line string) error {
+		return sendEvent(apitypes.DeploymentLogEvent{
+			Type:         "log",
+			DeploymentID: deployment.ID,
+			Line:         line,
+		})
+	})
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		_ = sendEvent(apitypes.DeploymentLogEvent{
+			Type:         "error",
+			DeploymentID: deployment.ID,
+			Error:        err.Error(),
+		})
+		return
+	}
+	_ = sendEvent(apitypes.DeploymentLogEvent{
+		Type:         "completed",
+		DeploymentID: deployment.ID,
+	})
+}
@@ -7,13 +7,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/apitypes"
 	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
 	platformutils "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/utils"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	registrytypes "github.com/agentregistry-dev/agentregistry/pkg/types"
 	"github.com/danielgtaylor/huma/v2"
@@ -469,6 +473,204 @@ func TestDeleteDeployment_UnsupportedPlatformReturnsBadRequest(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Unsupported provider or platform for deployment")
 }
 
+func TestUpdateDeployment_PassesUpdateToService(t *testing.T) {
+	reg := servicetesting.NewFakeRegistry()
+	var gotID string
+	var gotUpdate *models.DeploymentUpdate
+	reg.UpdateDeploymentFn = func(_ context.Context, id string, update *models.DeploymentUpdate) (*models.Deployment, error) {
+		gotID = id
+		gotUpdate = update
+		return &models.Deployment{ID: id, Version: *update.Version, Status: "deployed"}, nil
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+	body := `{"version":"2.0.0","env":{"LOG_LEVEL":"debug"}}`
+	req := httptest.NewRequest(http.MethodPatch, "/v0/deployments/dep-1", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "dep-1", gotID)
+	require.NotNil(t, gotUpdate)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, gotUpdate.Env)
+	assert.Nil(t, gotUpdate.SecretRefs)
+	var resp models.Deployment
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "2.0.0", resp.Version)
+}
+
+func TestUpdateDeployment_MapsServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "invalid input", err: fmt.Errorf("%w: no deployment changes requested", database.ErrInvalidInput), want: http.StatusBadRequest},
+		{name: "not found", err: database.ErrNotFound, want: http.StatusNotFound},
+		{name: "conflict", err: fmt.Errorf("%w: deployment is being updated concurrently", database.ErrConflict), want: http.StatusConflict},
+		{name: "rollout failure", err: fmt.Errorf("update failed and was rolled back: boom"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := servicetesting.NewFakeRegistry()
+			reg.UpdateDeploymentFn = func(context.Context, string, *models.DeploymentUpdate) (*models.Deployment, error) {
+				return nil, tt.err
+			}
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+			req := httptest.NewRequest(http.MethodPatch, "/v0/deployments/dep-1", strings.NewReader(`{"version":"2.0.0"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestGetDeploymentHealth_ReturnsProbeResult(t *testing.T) {
+	toolCount := 4
+	var gotID string
+	var gotRefresh bool
+	reg := servicetesting.NewFakeRegistry()
+	reg.GetDeploymentHealthFn = func(_ context.Context, id string, refresh bool) (*models.DeploymentHealth, error) {
+		gotID, gotRefresh = id, refresh
+		return &models.DeploymentHealth{Healthy: true, LatencyMs: 12, Endpoint: "http://localhost:8081/mcp", ToolCount: &toolCount}, nil
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/deployments/dep-1/health?refresh=true", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "dep-1", gotID)
+	assert.True(t, gotRefresh)
+	var body models.DeploymentHealth
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.True(t, body.Healthy)
+	assert.Equal(t, int64(12), body.LatencyMs)
+	require.NotNil(t, body.ToolCount)
+	assert.Equal(t, 4, *body.ToolCount)
+}
+
+func TestGetDeploymentHealth_MapsServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "unsupported platform", err: fmt.Errorf("%w: health probes are not supported on platform test", database.ErrInvalidInput), want: http.StatusBadRequest},
+		{name: "not found", err: database.ErrNotFound, want: http.StatusNotFound},
+		{name: "forbidden", err: auth.ErrForbidden, want: http.StatusForbidden},
+		{name: "storage failure", err: fmt.Errorf("failed to store deployment health: boom"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := servicetesting.NewFakeRegistry()
+			reg.GetDeploymentHealthFn = func(context.Context, string, bool) (*models.DeploymentHealth, error) {
+				return nil, tt.err
+			}
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+			req := httptest.NewRequest(http.MethodGet, "/v0/deployments/dep-1/health", nil)
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestRenderDeployment_PassesRequestAndFormat(t *testing.T) {
+	reg := servicetesting.NewFakeRegistry()
+	reg.GetProviderByIDFn = func(ctx context.Context, providerID string) (*models.Provider, error) {
+		return &models.Provider{ID: providerID, Platform: "local"}, nil
+	}
+	var gotReq *models.Deployment
+	var gotFormat string
+	reg.RenderDeploymentFn = func(_ context.Context, req *models.Deployment, format string) (*models.DeploymentRender, error) {
+		gotReq, gotFormat = req, format
+		return &models.DeploymentRender{
+			Platform: "local",
+			Format:   format,
+			Files:    []models.RenderedFile{{Path: "docker-compose.yaml", Content: "services: {}\n"}},
+		}, nil
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{
+		ProviderPlatforms: v0.DefaultProviderPlatformAdapters(reg),
+	})
+
+	payload, err := json.Marshal(map[string]any{
+		"serverName":   "planner",
+		"version":      "1.0.0",
+		"resourceType": "agent",
+		"providerId":   "local",
+		"secretRefs":   map[string]string{"OPENAI_API_KEY": "openai-key"},
+	})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/v0/deployments/render", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.NotNil(t, gotReq)
+	assert.Equal(t, "planner", gotReq.ServerName)
+	assert.Equal(t, "agent", gotReq.ResourceType)
+	assert.Equal(t, "openai-key", gotReq.SecretRefs["OPENAI_API_KEY"])
+	assert.Equal(t, models.DeploymentRenderFormatYAML, gotFormat)
+	var body models.DeploymentRender
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Files, 1)
+	assert.Equal(t, "docker-compose.yaml", body.Files[0].Path)
+}
+
+func TestRenderDeployment_MapsServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "unsupported format", err: fmt.Errorf("render format %q is not supported on local: %w", "helm", database.ErrInvalidInput), want: http.StatusBadRequest},
+		{name: "artifact not found", err: fmt.Errorf("agent planner not found in registry: %w", database.ErrNotFound), want: http.StatusNotFound},
+		{name: "forbidden", err: auth.ErrForbidden, want: http.StatusForbidden},
+		{name: "translation failure", err: fmt.Errorf("translate kubernetes platform config: boom"), want: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := servicetesting.NewFakeRegistry()
+			reg.GetProviderByIDFn = func(ctx context.Context, providerID string) (*models.Provider, error) {
+				return &models.Provider{ID: providerID, Platform: "local"}, nil
+			}
+			reg.RenderDeploymentFn = func(context.Context, *models.Deployment, string) (*models.DeploymentRender, error) {
+				return nil, tt.err
+			}
+			mux := http.NewServeMux()
+			api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+			v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{
+				ProviderPlatforms: v0.DefaultProviderPlatformAdapters(reg),
+			})
+
+			payload := []byte(`{"serverName":"planner","version":"1.0.0","resourceType":"agent","providerId":"local","format":"helm"}`)
+			req := httptest.NewRequest(http.MethodPost, "/v0/deployments/render", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code, w.Body.String())
+		})
+	}
+}
+
 func TestGetDeploymentLogs_UsesAdapterWhenRegistered(t *testing.T) {
 	reg := servicetesting.NewFakeRegistry()
 	reg.GetDeploymentByIDFn = func(ctx context.Context, id string) (*models.Deployment, error) {
@@ -483,7 +685,7 @@ func TestGetDeploymentLogs_UsesAdapterWhenRegistered(t *testing.T) {
 	}
 
 	adapter := &fakeDeploymentAdapter{}
-	reg.GetDeploymentLogsFn = func(ctx context.Context, deployment *models.Deployment) ([]string, error) {
+	reg.GetDeploymentLogsFn = func(ctx context.Context, deployment *models.Deployment, _ models.DeploymentLogOptions) ([]string, error) {
 		return adapter.GetLogs(ctx, deployment)
 	}
 	mux := http.NewServeMux()
@@ -523,7 +725,7 @@ func TestGetDeploymentLogs_NotFoundFromAdapterReturnsNotFound(t *testing.T) {
 	}
 
 	adapter := &fakeDeploymentAdapter{getLogsErr: database.ErrNotFound}
-	reg.GetDeploymentLogsFn = func(ctx context.Context, deployment *models.Deployment) ([]string, error) {
+	reg.GetDeploymentLogsFn = func(ctx context.Context, deployment *models.Deployment, _ models.DeploymentLogOptions) ([]string, error) {
 		return adapter.GetLogs(ctx, deployment)
 	}
 	mux := http.NewServeMux()
@@ -557,7 +759,7 @@ func TestGetDeploymentLogs_NotSupportedFromAdapterReturnsNotImplemented(t *testi
 	}
 
 	adapter := &fakeDeploymentAdapter{getLogsErr: platformutils.ErrDeploymentNotSupported}
-	reg.GetDeploymentLogsFn = func(ctx context.Context, deployment *models.Deployment) ([]string, error) {
+	reg.GetDeploymentLogsFn = func(ctx context.Context, deployment *models.Deployment, _ models.DeploymentLogOptions) ([]string, error) {
 		return adapter.GetLogs(ctx, deployment)
 	}
 	mux := http.NewServeMux()
@@ -577,6 +779,101 @@ func TestGetDeploymentLogs_NotSupportedFromAdapterReturnsNotImplemented(t *testi
 	assert.True(t, adapter.getLogsCalled)
 }
 
+func TestGetDeploymentLogs_PassesTailAndSinceOptions(t *testing.T) {
+	reg := servicetesting.NewFakeRegistry()
+	reg.GetDeploymentByIDFn = func(ctx context.Context, id string) (*models.Deployment, error) {
+		return &models.Deployment{ID: id, ProviderID: "local", Status: "deployed"}, nil
+	}
+
+	var gotOpts models.DeploymentLogOptions
+	reg.GetDeploymentLogsFn = func(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions) ([]string, error) {
+		gotOpts = opts
+		return []string{"line-1"}, nil
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/deployments/dep-2/logs?tailLines=50&since=2026-01-02T03:04:05Z", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(50), gotOpts.TailLines)
+	require.NotNil(t, gotOpts.Since)
+	assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), gotOpts.Since.UTC())
+	assert.False(t, gotOpts.Follow)
+
+	req = httptest.NewRequest(http.MethodGet, "/v0/deployments/dep-2/logs?since=yesterday", nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestStreamDeploymentLogs_WritesSSEEvents(t *testing.T) {
+	reg := servicetesting.NewFakeRegistry()
+	reg.GetDeploymentByIDFn = func(ctx context.Context, id string) (*models.Deployment, error) {
+		return &models.Deployment{ID: id, ProviderID: "local", Status: "deployed"}, nil
+	}
+
+	var gotOpts models.DeploymentLogOptions
+	reg.StreamDeploymentLogsFn = func(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions, emit func(line string) error) error {
+		gotOpts = opts
+		for _, line := range []string{"line-1", "line-2"} {
+			if err := emit(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/deployments/dep-2/logs/stream?tailLines=10", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.True(t, gotOpts.Follow)
+	assert.Equal(t, int64(10), gotOpts.TailLines)
+
+	var events []apitypes.DeploymentLogEvent
+	for _, chunk := range strings.Split(strings.TrimSpace(w.Body.String()), "\n\n") {
+		var event apitypes.DeploymentLogEvent
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(chunk, "data: ")), &event))
+		events = append(events, event)
+	}
+	require.Len(t, events, 3)
+	assert.Equal(t, "log", events[0].Type)
+	assert.Equal(t, "line-1", events[0].Line)
+	assert.Equal(t, "line-2", events[1].Line)
+	assert.Equal(t, "completed", events[2].Type)
+	assert.Equal(t, "dep-2", events[2].DeploymentID)
+}
+
+func TestStreamDeploymentLogs_AdapterErrorIsSentAsEvent(t *testing.T) {
+	reg := servicetesting.NewFakeRegistry()
+	reg.GetDeploymentByIDFn = func(ctx context.Context, id string) (*models.Deployment, error) {
+		return &models.Deployment{ID: id, ProviderID: "local", Status: "deployed"}, nil
+	}
+	reg.StreamDeploymentLogsFn = func(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions, emit func(line string) error) error {
+		return platformutils.ErrDeploymentNotSupported
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/deployments/dep-2/logs/stream", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"type":"error"`)
+	assert.Contains(t, w.Body.String(), platformutils.ErrDeploymentNotSupported.Error())
+}
+
 func TestCancelDeployment_UsesAdapterWhenRegistered(t *testing.T) {
 	reg := servicetesting.NewFakeRegistry()
 	reg.GetDeploymentByIDFn = func(ctx context.Context, id string) (*models.Deployment, error) {
@@ -678,3 +975,125 @@ func TestCancelDeployment_NotSupportedFromAdapterReturnsNotImplemented(t *testin
 	assert.Equal(t, http.StatusNotImplemented, w.Code)
 	assert.True(t, adapter.cancelCalled)
 }
+
+func TestCancelDeployment_NotInFlightReturnsConflict(t *testing.T) {
+	reg := servicetesting.NewFakeRegistry()
+	reg.GetDeploymentByIDFn = func(ctx context.Context, id string) (*models.Deployment, error) {
+		return &models.Deployment{
+			ID:         id,
+			ProviderID: "local",
+			Status:     "deploying",
+		}, nil
+	}
+	reg.GetProviderByIDFn = func(ctx context.Context, providerID string) (*models.Provider, error) {
+		return &models.Provider{ID: providerID, Platform: "local"}, nil
+	}
+
+	adapter := &fakeDeploymentAdapter{cancelErr: platformutils.ErrDeploymentNotInFlight}
+	reg.CancelDeploymentFn = func(ctx context.Context, deployment *models.Deployment) error {
+		return adapter.Cancel(ctx, deployment)
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{
+		ProviderPlatforms: v0.DefaultProviderPlatformAdapters(reg),
+		DeploymentPlatforms: map[string]registrytypes.DeploymentPlatformAdapter{
+			"local": adapter,
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/deployments/dep-3/cancel", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.True(t, adapter.cancelCalled)
+}
+
+func TestGetDeployments_RedactsEnvValues(t *testing.T) {
+	deployment := &models.Deployment{
+		ID:         "dep-1",
+		ServerName: "io.test/server",
+		Env:        map[string]string{"API_TOKEN": "secret"},
+	}
+	reg := servicetesting.NewFakeRegistry()
+	reg.GetDeploymentByIDFn = func(_ context.Context, _ string) (*models.Deployment, error) {
+		return deployment, nil
+	}
+	reg.GetDeploymentsFn = func(_ context.Context, _ *models.DeploymentFilter) ([]*models.Deployment, error) {
+		return []*models.Deployment{deployment}, nil
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+	for _, path := range []string{"/v0/deployments/dep-1", "/v0/deployments"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.NotContains(t, w.Body.String(), "secret", path)
+		assert.Contains(t, w.Body.String(), `"API_TOKEN":"***"`, path)
+	}
+	assert.Equal(t, "secret", deployment.Env["API_TOKEN"])
+}
+
+func TestListDeploymentRevisions_ReturnsEnvKeys(t *testing.T) {
+	reg := servicetesting.NewFakeRegistry()
+	reg.ListDeploymentRevisionsFn = func(_ context.Context, id string) ([]*models.DeploymentRevision, error) {
+		return []*models.DeploymentRevision{{
+			DeploymentID: id,
+			Revision:     1,
+			Action:       models.DeploymentRevisionActionDeploy,
+			Version:      "1.0.0",
+			EnvKeys:      []string{"API_TOKEN"},
+			Status:       models.DeploymentStatusDeployed,
+		}}, nil
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/deployments/dep-1/revisions", nil)
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body apitypes.DeploymentRevisionsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "dep-1", body.DeploymentID)
+	require.Len(t, body.Revisions, 1)
+	assert.Equal(t, []string{"API_TOKEN"}, body.Revisions[0].EnvKeys)
+}
+
+func TestRollbackDeployment_PassesRevision(t *testing.T) {
+	var gotID string
+	var gotRevision int
+	reg := servicetesting.NewFakeRegistry()
+	reg.RollbackDeploymentFn = func(_ context.Context, id string, revision int) (*models.Deployment, error) {
+		gotID, gotRevision = id, revision
+		if revision == 2 {
+			return nil, fmt.Errorf("%w: revision 2 failed and cannot be re-applied", database.ErrInvalidInput)
+		}
+		return &models.Deployment{ID: id, Version: "1.0.0", Status: models.DeploymentStatusDeployed}, nil
+	}
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterDeploymentsEndpoints(api, "/v0", reg, v0.PlatformExtensions{})
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/deployments/dep-1/rollback", strings.NewReader(`{"revision":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "dep-1", gotID)
+	assert.Equal(t, 1, gotRevision)
+
+	req = httptest.NewRequest(http.MethodPost, "/v0/deployments/dep-1/rollback", strings.NewReader(`{"revision":2}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
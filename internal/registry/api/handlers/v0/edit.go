@@ -25,7 +25,53 @@ type EditServerInput struct {
 	Body       apiv0.ServerJSON `body:""`
 }
 
-// RegisterEditEndpoints registers the edit endpoint with a custom path prefix
+// EditAgentInput represents the input for editing an agent
+type EditAgentInput struct {
+	AgentName string           `path:"agentName" doc:"URL-encoded agent name" example:"com.example%2Fmy-agent"`
+	Version   string           `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
+	Status    string           `query:"status" doc:"New status for the agent (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
+	Body      models.AgentJSON `body:""`
+}
+
+// SetAgentStatusInput represents the input for changing the status of an agent
+type SetAgentStatusInput struct {
+	AgentName string              `path:"agentName" doc:"URL-encoded agent name" example:"com.example%2Fmy-agent"`
+	Version   string              `path:"version" doc:"URL-encoded agent version" example:"1.0.0"`
+	Body      models.StatusUpdate `body:""`
+}
+
+// EditSkillInput represents the input for editing a skill
+type EditSkillInput struct {
+	SkillName string           `path:"skillName" doc:"URL-encoded skill name" example:"com.example%2Fmy-skill"`
+	Version   string           `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
+	Status    string           `query:"status" doc:"New status for the skill (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
+	Body      models.SkillJSON `body:""`
+}
+
+// SetSkillStatusInput represents the input for changing the status of a skill
+type SetSkillStatusInput struct {
+	SkillName string              `path:"skillName" doc:"URL-encoded skill name" example:"com.example%2Fmy-skill"`
+	Version   string              `path:"version" doc:"URL-encoded skill version" example:"1.0.0"`
+	Body      models.StatusUpdate `body:""`
+}
+
+// EditPromptInput represents the input for editing a prompt
+type EditPromptInput struct {
+	PromptName string            `path:"promptName" doc:"URL-encoded prompt name" example:"com.example%2Fmy-prompt"`
+	Version    string            `path:"version" doc:"URL-encoded version to edit" example:"1.0.0"`
+	Status     string            `query:"status" doc:"New status for the prompt (active, deprecated, deleted)" required:"false" enum:"active,deprecated,deleted"`
+	Body       models.PromptJSON `body:""`
+}
+
+// SetPromptStatusInput represents the input for changing the status of a prompt
+type SetPromptStatusInput struct {
+	PromptName string              `path:"promptName" doc:"URL-encoded prompt name" example:"com.example%2Fmy-prompt"`
+	Version    string              `path:"version" doc:"URL-encoded prompt version" example:"1.0.0"`
+	Body       models.StatusUpdate `body:""`
+}
+
+// RegisterEditEndpoints registers the edit and status endpoints of servers,
+// agents, skills and prompts with a custom path prefix
 func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
 	// Edit server endpoint
 	huma.Register(api, huma.Operation{
@@ -119,4 +165,208 @@ func RegisterEditEndpoints(api huma.API, pathPrefix string, registry service.Reg
 			)[0],
 		}, nil
 	})
+
+	// Edit agent endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "edit-agent" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/agents/{agentName}/versions/{version}",
+		Summary:     "Edit agent",
+		Description: "Update a specific version of an existing agent and optionally its status.",
+		Tags:        []string{"agents", "admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *EditAgentInput) (*types.Response[models.AgentResponse], error) {
+		agentName, version, err := unescapeNameAndVersion("agent", input.AgentName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+		if input.Body.Name != agentName {
+			return nil, huma.Error400BadRequest("Cannot rename agent")
+		}
+		if input.Body.Version != version {
+			return nil, huma.Error400BadRequest("Version in request body must match URL path parameter")
+		}
+
+		updated, err := registry.UpdateAgent(ctx, agentName, version, &input.Body, optionalStatus(input.Status))
+		if err != nil {
+			return nil, editArtifactError("Agent", err)
+		}
+		return &types.Response[models.AgentResponse]{
+			Body: attachAgentDeploymentMeta(ctx, registry, []models.AgentResponse{*updated})[0],
+		}, nil
+	})
+
+	// Set agent status endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "set-agent-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/agents/{agentName}/versions/{version}/status",
+		Summary:     "Set agent status",
+		Description: "Deprecate, undeprecate or delete a specific agent version. A deprecated version can name the version that succeeds it. Deleted versions cannot be undeleted.",
+		Tags:        []string{"agents", "admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetAgentStatusInput) (*types.Response[models.AgentResponse], error) {
+		agentName, version, err := unescapeNameAndVersion("agent", input.AgentName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := registry.SetAgentStatus(ctx, agentName, version, input.Body.Status, input.Body.Successor)
+		if err != nil {
+			return nil, editArtifactError("Agent", err)
+		}
+		return &types.Response[models.AgentResponse]{
+			Body: attachAgentDeploymentMeta(ctx, registry, []models.AgentResponse{*updated})[0],
+		}, nil
+	})
+
+	// Edit skill endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "edit-skill" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/skills/{skillName}/versions/{version}",
+		Summary:     "Edit skill",
+		Description: "Update a specific version of an existing skill and optionally its status.",
+		Tags:        []string{"skills", "admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *EditSkillInput) (*types.Response[models.SkillResponse], error) {
+		skillName, version, err := unescapeNameAndVersion("skill", input.SkillName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+		if input.Body.Name != skillName {
+			return nil, huma.Error400BadRequest("Cannot rename skill")
+		}
+		if input.Body.Version != version {
+			return nil, huma.Error400BadRequest("Version in request body must match URL path parameter")
+		}
+
+		updated, err := registry.UpdateSkill(ctx, skillName, version, &input.Body, optionalStatus(input.Status))
+		if err != nil {
+			return nil, editArtifactError("Skill", err)
+		}
+		return &types.Response[models.SkillResponse]{Body: *updated}, nil
+	})
+
+	// Set skill status endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "set-skill-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/skills/{skillName}/versions/{version}/status",
+		Summary:     "Set skill status",
+		Description: "Deprecate, undeprecate or delete a specific skill version. A deprecated version can name the version that succeeds it. Deleted versions cannot be undeleted.",
+		Tags:        []string{"skills", "admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetSkillStatusInput) (*types.Response[models.SkillResponse], error) {
+		skillName, version, err := unescapeNameAndVersion("skill", input.SkillName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := registry.SetSkillStatus(ctx, skillName, version, input.Body.Status, input.Body.Successor)
+		if err != nil {
+			return nil, editArtifactError("Skill", err)
+		}
+		return &types.Response[models.SkillResponse]{Body: *updated}, nil
+	})
+
+	// Edit prompt endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "edit-prompt" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/prompts/{promptName}/versions/{version}",
+		Summary:     "Edit prompt",
+		Description: "Update a specific version of an existing prompt and optionally its status.",
+		Tags:        []string{"prompts", "admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *EditPromptInput) (*types.Response[models.PromptResponse], error) {
+		promptName, version, err := unescapeNameAndVersion("prompt", input.PromptName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+		if input.Body.Name != promptName {
+			return nil, huma.Error400BadRequest("Cannot rename prompt")
+		}
+		if input.Body.Version != version {
+			return nil, huma.Error400BadRequest("Version in request body must match URL path parameter")
+		}
+
+		updated, err := registry.UpdatePrompt(ctx, promptName, version, &input.Body, optionalStatus(input.Status))
+		if err != nil {
+			return nil, editArtifactError("Prompt", err)
+		}
+		return &types.Response[models.PromptResponse]{Body: *updated}, nil
+	})
+
+	// Set prompt status endpoint
+	huma.Register(api, huma.Operation{
+		OperationID: "set-prompt-status" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPut,
+		Path:        pathPrefix + "/prompts/{promptName}/versions/{version}/status",
+		Summary:     "Set prompt status",
+		Description: "Deprecate, undeprecate or delete a specific prompt version. A deprecated version can name the version that succeeds it. Deleted versions cannot be undeleted.",
+		Tags:        []string{"prompts", "admin"},
+		Security: []map[string][]string{
+			{"bearer": {}},
+		},
+	}, func(ctx context.Context, input *SetPromptStatusInput) (*types.Response[models.PromptResponse], error) {
+		promptName, version, err := unescapeNameAndVersion("prompt", input.PromptName, input.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		updated, err := registry.SetPromptStatus(ctx, promptName, version, input.Body.Status, input.Body.Successor)
+		if err != nil {
+			return nil, editArtifactError("Prompt", err)
+		}
+		return &types.Response[models.PromptResponse]{Body: *updated}, nil
+	})
+}
+
+// unescapeNameAndVersion URL-decodes the name and version path parameters of
+// a kind of artifact.
+func unescapeNameAndVersion(kind, name, version string) (string, string, error) {
+	name, err := url.PathUnescape(name)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid "+kind+" name encoding", err)
+	}
+	version, err = url.PathUnescape(version)
+	if err != nil {
+		return "", "", huma.Error400BadRequest("Invalid version encoding", err)
+	}
+	return name, version, nil
+}
+
+func optionalStatus(status string) *string {
+	if status == "" {
+		return nil
+	}
+	return &status
+}
+
+// editArtifactError maps an error from editing an agent, skill or prompt to
+// an HTTP error.
+func editArtifactError(kind string, err error) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound(kind + " not found")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Forbidden")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error(), err)
+	default:
+		return huma.Error500InternalServerError("Failed to edit "+strings.ToLower(kind), err)
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -21,7 +22,10 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	pkgdb "github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -693,6 +697,85 @@ func TestEditServerEndpointEdgeCases(t *testing.T) {
 	})
 }
 
+func TestSetArtifactStatusEndpoints(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	registry := servicetesting.NewFakeRegistry()
+	registry.SetSkillStatusFn = func(_ context.Context, name, version, status, successor string) (*models.SkillResponse, error) {
+		if successor == "9.9.9" {
+			return nil, fmt.Errorf("%w: successor version %s of skill %s does not exist", pkgdb.ErrInvalidInput, successor, name)
+		}
+		return &models.SkillResponse{
+			Skill: models.SkillJSON{Name: name, Version: version},
+			Meta: models.SkillResponseMeta{Official: &models.SkillRegistryExtensions{
+				Status:    status,
+				Successor: successor,
+			}},
+		}, nil
+	}
+	var editedStatus *string
+	registry.UpdatePromptFn = func(_ context.Context, name, version string, req *models.PromptJSON, newStatus *string) (*models.PromptResponse, error) {
+		editedStatus = newStatus
+		return &models.PromptResponse{Prompt: *req}, nil
+	}
+	v0.RegisterEditEndpoints(api, "/v0", registry)
+
+	send := func(method, path string, body any) *httptest.ResponseRecorder {
+		t.Helper()
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("deprecate skill with successor", func(t *testing.T) {
+		w := send(http.MethodPut, "/v0/skills/"+url.PathEscape("com.example/skill")+"/versions/1.0.0/status",
+			models.StatusUpdate{Status: "deprecated", Successor: "2.0.0"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var response models.SkillResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "com.example/skill", response.Skill.Name)
+		assert.Equal(t, "deprecated", response.Meta.Official.Status)
+		assert.Equal(t, "2.0.0", response.Meta.Official.Successor)
+	})
+
+	t.Run("unknown successor is a bad request", func(t *testing.T) {
+		w := send(http.MethodPut, "/v0/skills/skill/versions/1.0.0/status",
+			models.StatusUpdate{Status: "deprecated", Successor: "9.9.9"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "successor version 9.9.9")
+	})
+
+	t.Run("invalid status is rejected", func(t *testing.T) {
+		w := send(http.MethodPut, "/v0/skills/skill/versions/1.0.0/status", models.StatusUpdate{Status: "yanked"})
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("missing agent", func(t *testing.T) {
+		w := send(http.MethodPut, "/v0/agents/agent/versions/1.0.0/status", models.StatusUpdate{Status: "deprecated"})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("edit prompt with status", func(t *testing.T) {
+		w := send(http.MethodPut, "/v0/prompts/prompt/versions/1.0.0?status=deprecated",
+			models.PromptJSON{Name: "prompt", Version: "1.0.0", Content: "Hi"})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		require.NotNil(t, editedStatus)
+		assert.Equal(t, "deprecated", *editedStatus)
+	})
+
+	t.Run("cannot rename prompt", func(t *testing.T) {
+		w := send(http.MethodPut, "/v0/prompts/prompt/versions/1.0.0",
+			models.PromptJSON{Name: "renamed", Version: "1.0.0", Content: "Hi"})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Cannot rename prompt")
+	})
+}
+
 // Helper function
 func stringPtr(s string) *string {
 	return &s
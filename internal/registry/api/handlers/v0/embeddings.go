@@ -2,12 +2,16 @@ package v0
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
 	apitypes "github.com/agentregistry-dev/agentregistry/internal/registry/api/apitypes"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/types"
 	"github.com/danielgtaylor/huma/v2"
@@ -36,6 +40,7 @@ func RegisterEmbeddingsEndpoints(
 	indexer service.Indexer,
 	jobManager *jobs.Manager,
 ) {
+	registerIndexJobRunner(jobManager, indexer)
 	registerIndexEndpoint(api, pathPrefix, indexer, jobManager)
 	registerJobStatusEndpoint(api, pathPrefix, jobManager)
 }
@@ -51,7 +56,7 @@ func registerIndexEndpoint(
 		Method:      http.MethodPost,
 		Path:        pathPrefix + "/embeddings/index",
 		Summary:     "Start embeddings indexing",
-		Description: "Start a background job to generate embeddings for servers and/or agents. Use stream=true for SSE progress updates.",
+		Description: "Start a background job to generate embeddings for servers, agents, skills and/or prompts. Use stream=true for SSE progress updates.",
 		Tags:        []string{"embeddings"},
 	}, func(ctx context.Context, input *IndexInput) (*types.Response[IndexJobResponse], error) {
 		if indexer == nil {
@@ -60,10 +65,12 @@ func registerIndexEndpoint(
 
 		req := input.Body
 
-		// Default to including both if neither specified
-		if !req.IncludeServers && !req.IncludeAgents {
+		// Default to including every resource type if none specified
+		if !req.IncludeServers && !req.IncludeAgents && !req.IncludeSkills && !req.IncludePrompts {
 			req.IncludeServers = true
 			req.IncludeAgents = true
+			req.IncludeSkills = true
+			req.IncludePrompts = true
 		}
 
 		if req.BatchSize <= 0 {
@@ -75,45 +82,79 @@ func registerIndexEndpoint(
 			return nil, huma.Error400BadRequest("SSE streaming should use GET /embeddings/index/stream with query parameters")
 		}
 
-		// Create a new job
-		job, err := jobManager.CreateJob(jobs.IndexJobType)
+		job, err := jobManager.Submit(ctx, jobs.IndexJobType, indexJobParams(req))
 		if err != nil {
-			if err == jobs.ErrJobAlreadyRunning {
-				existingJob := jobManager.GetRunningJob(jobs.IndexJobType)
+			if errors.Is(err, auth.ErrUnauthenticated) || errors.Is(err, auth.ErrForbidden) {
+				return nil, jobHTTPError(err, "")
+			}
+			if errors.Is(err, jobs.ErrJobAlreadyRunning) {
+				existingJob := jobManager.GetRunningJob(ctx, jobs.IndexJobType)
 				if existingJob != nil {
-					return nil, huma.Error409Conflict("indexing job already running: " + string(existingJob.ID))
+					return nil, huma.Error409Conflict("indexing job already running: " + existingJob.ID)
 				}
 				return nil, huma.Error409Conflict("indexing job already running")
 			}
 			return nil, huma.Error500InternalServerError("failed to create job: " + err.Error())
 		}
 
-		initialStatus := string(job.Status)
-
-		// Run indexing in background
-		go runIndexJob(indexer, jobManager, job.ID, req)
-
 		return &types.Response[IndexJobResponse]{
 			Body: IndexJobResponse{
-				JobID:  string(job.ID),
-				Status: initialStatus,
+				JobID:  job.ID,
+				Status: job.Status,
 			},
 		}, nil
 	})
 }
 
-func runIndexJob(
-	indexer service.Indexer,
-	jobManager *jobs.Manager,
-	jobID jobs.JobID,
-	req IndexRequest,
-) {
-	ctx := auth.WithSystemContext(context.Background())
-
-	if err := jobManager.StartJob(jobID); err != nil {
-		_ = jobManager.FailJob(jobID, "failed to start job: "+err.Error())
+// registerIndexJobRunner installs the embeddings-index runner on the job
+// manager so that index jobs can be submitted, and resumed by any replica.
+func registerIndexJobRunner(jobManager *jobs.Manager, indexer service.Indexer) {
+	if indexer == nil {
 		return
 	}
+	jobManager.Register(jobs.IndexJobType, func(ctx context.Context, job *jobs.Job, report jobs.ProgressFunc) (*jobs.JobResult, error) {
+		req, err := indexRequestFromParams(job.Params)
+		if err != nil {
+			return nil, err
+		}
+		return runIndex(ctx, indexer, req, report, nil)
+	}, jobs.RunnerOptions{Exclusive: true, Authorize: jobs.RequireRegistryAdmin})
+}
+
+func indexJobParams(req IndexRequest) models.JSONObject {
+	return models.JSONObject{
+		"batchSize":      req.BatchSize,
+		"force":          req.Force,
+		"dryRun":         req.DryRun,
+		"includeServers": req.IncludeServers,
+		"includeAgents":  req.IncludeAgents,
+		"includeSkills":  req.IncludeSkills,
+		"includePrompts": req.IncludePrompts,
+	}
+}
+
+func indexRequestFromParams(params models.JSONObject) (IndexRequest, error) {
+	var req IndexRequest
+	data, err := json.Marshal(params)
+	if err != nil {
+		return req, fmt.Errorf("invalid index job params: %w", err)
+	}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return req, fmt.Errorf("invalid index job params: %w", err)
+	}
+	return req, nil
+}
+
+// runIndex runs the indexer as the system identity, reporting aggregated job
+// progress and, optionally, per-resource stats.
+func runIndex(
+	ctx context.Context,
+	indexer service.Indexer,
+	req IndexRequest,
+	report jobs.ProgressFunc,
+	onResource func(resource string, stats service.IndexStats),
+) (*jobs.JobResult, error) {
+	ctx = auth.WithSystemContext(ctx)
 
 	opts := service.IndexOptions{
 		BatchSize:      req.BatchSize,
@@ -121,33 +162,32 @@ func runIndexJob(
 		DryRun:         req.DryRun,
 		IncludeServers: req.IncludeServers,
 		IncludeAgents:  req.IncludeAgents,
+		IncludeSkills:  req.IncludeSkills,
+		IncludePrompts: req.IncludePrompts,
 	}
 
-	var serverStats, agentStats service.IndexStats
+	resourceStats := map[string]service.IndexStats{}
 
 	result, err := indexer.Run(ctx, opts, func(resource string, stats service.IndexStats) {
-		switch resource {
-		case "servers":
-			serverStats = stats
-		case "agents":
-			agentStats = stats
+		resourceStats[resource] = stats
+		if onResource != nil {
+			onResource(resource, stats)
 		}
 
-		progress := jobs.JobProgress{
-			Processed: serverStats.Processed + agentStats.Processed,
-			Updated:   serverStats.Updated + agentStats.Updated,
-			Skipped:   serverStats.Skipped + agentStats.Skipped,
-			Failures:  serverStats.Failures + agentStats.Failures,
+		var progress jobs.JobProgress
+		for _, st := range resourceStats {
+			progress.Processed += st.Processed
+			progress.Updated += st.Updated
+			progress.Skipped += st.Skipped
+			progress.Failures += st.Failures
 		}
-		_ = jobManager.UpdateProgress(jobID, progress)
+		report(progress)
 	})
-
 	if err != nil {
-		_ = jobManager.FailJob(jobID, err.Error())
-		return
+		return nil, err
 	}
 
-	jobResult := &jobs.JobResult{
+	return &jobs.JobResult{
 		ServersProcessed: result.Servers.Processed,
 		ServersUpdated:   result.Servers.Updated,
 		ServersSkipped:   result.Servers.Skipped,
@@ -156,9 +196,15 @@ func runIndexJob(
 		AgentsUpdated:    result.Agents.Updated,
 		AgentsSkipped:    result.Agents.Skipped,
 		AgentFailures:    result.Agents.Failures,
-	}
-
-	_ = jobManager.CompleteJob(jobID, jobResult)
+		SkillsProcessed:  result.Skills.Processed,
+		SkillsUpdated:    result.Skills.Updated,
+		SkillsSkipped:    result.Skills.Skipped,
+		SkillFailures:    result.Skills.Failures,
+		PromptsProcessed: result.Prompts.Processed,
+		PromptsUpdated:   result.Prompts.Updated,
+		PromptsSkipped:   result.Prompts.Skipped,
+		PromptFailures:   result.Prompts.Failures,
+	}, nil
 }
 
 func registerJobStatusEndpoint(
@@ -174,9 +220,9 @@ func registerJobStatusEndpoint(
 		Description: "Get the status and progress of an indexing job.",
 		Tags:        []string{"embeddings"},
 	}, func(ctx context.Context, input *JobStatusInput) (*types.Response[JobStatusResponse], error) {
-		job, err := jobManager.GetJob(jobs.JobID(input.JobID))
+		job, err := jobManager.GetJob(ctx, jobs.JobID(input.JobID))
 		if err != nil {
-			if err == jobs.ErrJobNotFound {
+			if errors.Is(err, jobs.ErrJobNotFound) {
 				return nil, huma.Error404NotFound("job not found: " + input.JobID)
 			}
 			return nil, huma.Error500InternalServerError("failed to get job: " + err.Error())
@@ -184,9 +230,9 @@ func registerJobStatusEndpoint(
 
 		return &types.Response[JobStatusResponse]{
 			Body: JobStatusResponse{
-				JobID:     string(job.ID),
+				JobID:     job.ID,
 				Type:      job.Type,
-				Status:    string(job.Status),
+				Status:    job.Status,
 				Progress:  job.Progress,
 				Result:    job.Result,
 				CreatedAt: job.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
@@ -3,6 +3,7 @@ package v0
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -28,11 +29,24 @@ func RegisterEmbeddingsSSEHandler(
 	pathPrefix string,
 	indexer service.Indexer,
 	jobManager *jobs.Manager,
+	authn auth.AuthnProvider,
 ) {
 	// Use POST to accept JSON body with options, and method-specific pattern
 	// to avoid conflict with Huma's {jobId} route
 	path := "POST " + pathPrefix + "/embeddings/index/stream"
+	registerIndexJobRunner(jobManager, indexer)
 	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		// Raw mux handlers bypass the huma authn middleware.
+		if authn != nil {
+			session, err := authn.Authenticate(r.Context(), r.Header.Get, r.URL.Query())
+			if err != nil {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if session != nil {
+				r = r.WithContext(auth.AuthSessionTo(r.Context(), session))
+			}
+		}
 		handleSSEIndex(w, r, indexer, jobManager)
 	})
 }
@@ -66,17 +80,26 @@ func handleSSEIndex(
 	if req.BatchSize <= 0 {
 		req.BatchSize = 100
 	}
-	if !req.IncludeServers && !req.IncludeAgents {
+	if !req.IncludeServers && !req.IncludeAgents && !req.IncludeSkills && !req.IncludePrompts {
 		req.IncludeServers = true
 		req.IncludeAgents = true
+		req.IncludeSkills = true
+		req.IncludePrompts = true
 	}
 
-	// Create a job for tracking
-	job, err := jobManager.CreateJob(jobs.IndexJobType)
+	// Create a job for tracking, claimed by this replica since it runs inline
+	job, err := jobManager.Begin(r.Context(), jobs.IndexJobType, indexJobParams(req))
 	if err != nil {
-		if err == jobs.ErrJobAlreadyRunning {
+		switch {
+		case errors.Is(err, jobs.ErrJobAlreadyRunning):
 			http.Error(w, "Indexing job already running", http.StatusConflict)
 			return
+		case errors.Is(err, auth.ErrUnauthenticated):
+			http.Error(w, "Authentication required", http.StatusUnauthorized)
+			return
+		case errors.Is(err, auth.ErrForbidden):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
 		}
 		http.Error(w, "Failed to create job: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -101,83 +124,32 @@ func handleSSEIndex(
 	// Send started event
 	sendEvent(SSEEvent{
 		Type:  "started",
-		JobID: string(job.ID),
+		JobID: job.ID,
 	})
 
-	ctx := r.Context()
-	// Use system context for database operations
-	dbCtx := auth.WithSystemContext(context.Background())
-
-	if err := jobManager.StartJob(job.ID); err != nil {
-		sendEvent(SSEEvent{
-			Type:  "error",
-			JobID: string(job.ID),
-			Error: "Failed to start job: " + err.Error(),
+	// The run is cancelled when the client disconnects or the job is cancelled.
+	jobResult, err := jobManager.Execute(r.Context(), job, func(ctx context.Context, job *jobs.Job, report jobs.ProgressFunc) (*jobs.JobResult, error) {
+		return runIndex(ctx, indexer, req, report, func(resource string, stats service.IndexStats) {
+			sendEvent(SSEEvent{
+				Type:     "progress",
+				JobID:    job.ID,
+				Resource: resource,
+				Stats:    stats,
+			})
 		})
-		return
-	}
-
-	opts := service.IndexOptions{
-		BatchSize:      req.BatchSize,
-		Force:          req.Force,
-		DryRun:         req.DryRun,
-		IncludeServers: req.IncludeServers,
-		IncludeAgents:  req.IncludeAgents,
-	}
-
-	// Create a context that cancels when client disconnects
-	runCtx, cancel := context.WithCancel(dbCtx)
-	defer cancel()
-
-	go func() {
-		<-ctx.Done()
-		cancel()
-	}()
-
-	result, err := indexer.Run(runCtx, opts, func(resource string, stats service.IndexStats) {
-		sendEvent(SSEEvent{
-			Type:     "progress",
-			JobID:    string(job.ID),
-			Resource: resource,
-			Stats:    stats,
-		})
-
-		// Also update job progress
-		progress := jobs.JobProgress{
-			Processed: stats.Processed,
-			Updated:   stats.Updated,
-			Skipped:   stats.Skipped,
-			Failures:  stats.Failures,
-		}
-		_ = jobManager.UpdateProgress(job.ID, progress)
 	})
-
 	if err != nil {
-		_ = jobManager.FailJob(job.ID, err.Error())
 		sendEvent(SSEEvent{
 			Type:  "error",
-			JobID: string(job.ID),
+			JobID: job.ID,
 			Error: err.Error(),
 		})
 		return
 	}
 
-	jobResult := &jobs.JobResult{
-		ServersProcessed: result.Servers.Processed,
-		ServersUpdated:   result.Servers.Updated,
-		ServersSkipped:   result.Servers.Skipped,
-		ServerFailures:   result.Servers.Failures,
-		AgentsProcessed:  result.Agents.Processed,
-		AgentsUpdated:    result.Agents.Updated,
-		AgentsSkipped:    result.Agents.Skipped,
-		AgentFailures:    result.Agents.Failures,
-	}
-
-	_ = jobManager.CompleteJob(job.ID, jobResult)
-
 	sendEvent(SSEEvent{
 		Type:   "completed",
-		JobID:  string(job.ID),
+		JobID:  job.ID,
 		Result: jobResult,
 	})
 }
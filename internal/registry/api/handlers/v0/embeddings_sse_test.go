@@ -57,7 +57,7 @@ func TestSSEIndex_Success(t *testing.T) {
 
 	jobManager := jobs.NewManager()
 	mux := http.NewServeMux()
-	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager)
+	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager, nil)
 
 	body := strings.NewReader(`{"includeServers": true}`)
 	req := httptest.NewRequest(http.MethodPost, "/v0/embeddings/index/stream", body)
@@ -78,7 +78,7 @@ func TestSSEIndex_Success(t *testing.T) {
 func TestSSEIndex_IndexerNil(t *testing.T) {
 	jobManager := jobs.NewManager()
 	mux := http.NewServeMux()
-	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", nil, jobManager)
+	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", nil, jobManager, nil)
 
 	body := strings.NewReader(`{}`)
 	req := httptest.NewRequest(http.MethodPost, "/v0/embeddings/index/stream", body)
@@ -96,7 +96,7 @@ func TestSSEIndex_InvalidJSON(t *testing.T) {
 
 	jobManager := jobs.NewManager()
 	mux := http.NewServeMux()
-	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager)
+	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager, nil)
 
 	body := strings.NewReader(`{invalid json`)
 	req := httptest.NewRequest(http.MethodPost, "/v0/embeddings/index/stream", body)
@@ -123,7 +123,7 @@ func TestSSEIndex_JobAlreadyRunning(t *testing.T) {
 
 	jobManager := jobs.NewManager()
 	mux := http.NewServeMux()
-	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager)
+	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager, nil)
 
 	// Start first request in a goroutine
 	go func() {
@@ -161,7 +161,7 @@ func TestSSEIndex_IndexerError(t *testing.T) {
 
 	jobManager := jobs.NewManager()
 	mux := http.NewServeMux()
-	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager)
+	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager, nil)
 
 	body := strings.NewReader(`{}`)
 	req := httptest.NewRequest(http.MethodPost, "/v0/embeddings/index/stream", body)
@@ -197,7 +197,7 @@ func TestSSEIndex_Headers(t *testing.T) {
 
 	jobManager := jobs.NewManager()
 	mux := http.NewServeMux()
-	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager)
+	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager, nil)
 
 	body := strings.NewReader(`{}`)
 	req := httptest.NewRequest(http.MethodPost, "/v0/embeddings/index/stream", body)
@@ -228,7 +228,7 @@ func TestSSEIndex_ProgressEvents(t *testing.T) {
 
 	jobManager := jobs.NewManager()
 	mux := http.NewServeMux()
-	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager)
+	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager, nil)
 
 	body := strings.NewReader(`{}`)
 	req := httptest.NewRequest(http.MethodPost, "/v0/embeddings/index/stream", body)
@@ -270,7 +270,7 @@ func TestSSEIndex_DefaultsApplied(t *testing.T) {
 
 	jobManager := jobs.NewManager()
 	mux := http.NewServeMux()
-	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager)
+	v0.RegisterEmbeddingsSSEHandler(mux, "/v0", mockIdx, jobManager, nil)
 
 	// Empty body - should apply defaults
 	body := strings.NewReader(`{}`)
@@ -0,0 +1,135 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	apitypes "github.com/agentregistry-dev/agentregistry/internal/registry/api/apitypes"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type JobsListInput struct {
+	Type   string `query:"type" json:"type,omitempty" doc:"Filter jobs by type"`
+	Status string `query:"status" json:"status,omitempty" doc:"Filter jobs by status (pending, running, completed, failed, cancelled)"`
+	Limit  int    `query:"limit" json:"limit,omitempty" doc:"Maximum number of jobs to return" default:"50" minimum:"1" maximum:"500"`
+}
+
+type JobByIDInput struct {
+	JobID string `path:"jobId" json:"jobId" doc:"Job identifier"`
+}
+
+type CreateJobInput struct {
+	Body apitypes.CreateJobRequest
+}
+
+type JobsListResponse struct {
+	Body apitypes.JobsListResponse
+}
+
+type JobResponse struct {
+	Body models.Job
+}
+
+func jobHTTPError(err error, jobID string) error {
+	switch {
+	case errors.Is(err, jobs.ErrJobNotFound):
+		return huma.Error404NotFound("Job not found: " + jobID)
+	case errors.Is(err, jobs.ErrJobAlreadyRunning):
+		return huma.Error409Conflict("A job of this type is already running")
+	case errors.Is(err, jobs.ErrUnknownJobType):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Forbidden")
+	default:
+		return huma.Error500InternalServerError("Failed to process job request", err)
+	}
+}
+
+// RegisterJobsEndpoints registers the generic background job endpoints.
+func RegisterJobsEndpoints(api huma.API, basePath string, jobManager *jobs.Manager) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-jobs",
+		Method:      http.MethodGet,
+		Path:        basePath + "/jobs",
+		Summary:     "List jobs",
+		Description: "List background jobs (indexing, import, export), newest first.",
+		Tags:        []string{"jobs"},
+	}, func(ctx context.Context, input *JobsListInput) (*JobsListResponse, error) {
+		filter := &models.JobFilter{Limit: input.Limit}
+		if jobType := strings.TrimSpace(input.Type); jobType != "" {
+			filter.Type = &jobType
+		}
+		if status := strings.TrimSpace(input.Status); status != "" {
+			filter.Status = &status
+		}
+
+		list, err := jobManager.ListJobs(ctx, filter)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("Failed to list jobs", err)
+		}
+
+		resp := &JobsListResponse{}
+		resp.Body.Jobs = make([]models.Job, 0, len(list))
+		for _, job := range list {
+			resp.Body.Jobs = append(resp.Body.Jobs, *job)
+		}
+		resp.Body.Count = len(resp.Body.Jobs)
+		return resp, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-job",
+		Method:      http.MethodPost,
+		Path:        basePath + "/jobs",
+		Summary:     "Submit job",
+		Description: "Submit a background job of a registered type. The job is executed by one registry replica.",
+		Tags:        []string{"jobs"},
+	}, func(ctx context.Context, input *CreateJobInput) (*JobResponse, error) {
+		jobType := strings.TrimSpace(input.Body.Type)
+		if jobType == "" {
+			return nil, huma.Error400BadRequest("type is required")
+		}
+		job, err := jobManager.Submit(ctx, jobType, input.Body.Params)
+		if err != nil {
+			return nil, jobHTTPError(err, "")
+		}
+		return &JobResponse{Body: *job}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-job",
+		Method:      http.MethodGet,
+		Path:        basePath + "/jobs/{jobId}",
+		Summary:     "Get job",
+		Description: "Get the status, progress and result of a background job.",
+		Tags:        []string{"jobs"},
+	}, func(ctx context.Context, input *JobByIDInput) (*JobResponse, error) {
+		job, err := jobManager.GetJob(ctx, jobs.JobID(input.JobID))
+		if err != nil {
+			return nil, jobHTTPError(err, input.JobID)
+		}
+		return &JobResponse{Body: *job}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "cancel-job",
+		Method:      http.MethodPost,
+		Path:        basePath + "/jobs/{jobId}/cancel",
+		Summary:     "Cancel job",
+		Description: "Request cancellation of a pending or running job. Cancelling a finished job has no effect.",
+		Tags:        []string{"jobs"},
+	}, func(ctx context.Context, input *JobByIDInput) (*JobResponse, error) {
+		job, err := jobManager.Cancel(ctx, jobs.JobID(input.JobID))
+		if err != nil {
+			return nil, jobHTTPError(err, input.JobID)
+		}
+		return &JobResponse{Body: *job}, nil
+	})
+}
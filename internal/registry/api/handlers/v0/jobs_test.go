@@ -0,0 +1,112 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/api/apitypes"
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func newJobsTestAPI(t *testing.T, jobManager *jobs.Manager) *http.ServeMux {
+	t.Helper()
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterJobsEndpoints(api, "/v0", jobManager)
+	return mux
+}
+
+func TestJobsEndpoints_SubmitListAndGet(t *testing.T) {
+	jobManager := jobs.NewManager()
+	jobManager.Register(jobs.ExportJobType, func(ctx context.Context, job *jobs.Job, report jobs.ProgressFunc) (*jobs.JobResult, error) {
+		return &jobs.JobResult{ItemsExported: 3}, nil
+	}, jobs.RunnerOptions{})
+	mux := newJobsTestAPI(t, jobManager)
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/jobs", strings.NewReader(`{"type":"export","params":{"fileName":"servers.json"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var created models.Job
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, jobs.ExportJobType, created.Type)
+	assert.Equal(t, "servers.json", created.Params["fileName"])
+
+	require.Eventually(t, func() bool {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/jobs/"+created.ID, nil))
+		var job models.Job
+		return w.Code == http.StatusOK && json.Unmarshal(w.Body.Bytes(), &job) == nil &&
+			job.Status == models.JobStatusCompleted && job.Result != nil && job.Result.ItemsExported == 3
+	}, 2*time.Second, 10*time.Millisecond)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/jobs?type=export&status=completed", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var list apitypes.JobsListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Equal(t, 1, list.Count)
+	assert.Equal(t, created.ID, list.Jobs[0].ID)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/jobs?type=import", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Equal(t, 0, list.Count)
+}
+
+func TestJobsEndpoints_UnknownTypeAndMissingJob(t *testing.T) {
+	mux := newJobsTestAPI(t, jobs.NewManager())
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/jobs", strings.NewReader(`{"type":"reindex-everything"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/jobs/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v0/jobs/missing/cancel", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestJobsEndpoints_CancelRunningJob(t *testing.T) {
+	jobManager := jobs.NewManager()
+	started := make(chan struct{})
+	jobManager.Register(jobs.ImportJobType, func(ctx context.Context, job *jobs.Job, report jobs.ProgressFunc) (*jobs.JobResult, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, jobs.RunnerOptions{Exclusive: true})
+	mux := newJobsTestAPI(t, jobManager)
+
+	job, err := jobManager.Submit(context.Background(), jobs.ImportJobType, nil)
+	require.NoError(t, err)
+	<-started
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v0/jobs/"+job.ID+"/cancel", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	require.Eventually(t, func() bool {
+		current, err := jobManager.GetJob(context.Background(), jobs.JobID(job.ID))
+		return err == nil && current.Status == models.JobStatusCancelled
+	}, 2*time.Second, 10*time.Millisecond)
+}
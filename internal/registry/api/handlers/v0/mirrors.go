@@ -0,0 +1,158 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type MirrorByIDInput struct {
+	MirrorID string `path:"mirrorId" json:"mirrorId" doc:"Mirror ID"`
+}
+
+type MirrorStatusInput struct {
+	MirrorID string `path:"mirrorId" json:"mirrorId" doc:"Mirror ID"`
+	History  int    `query:"history" json:"history,omitempty" doc:"Number of recent syncs to return" default:"10" minimum:"1" maximum:"100"`
+}
+
+type CreateMirrorRequest struct {
+	Body models.CreateMirrorInput
+}
+
+type MirrorsListResponse struct {
+	Body struct {
+		Mirrors []models.Mirror `json:"mirrors"`
+		Count   int             `json:"count"`
+	}
+}
+
+type MirrorResponse struct {
+	Body models.Mirror
+}
+
+type MirrorStatusResponse struct {
+	Body models.MirrorStatus
+}
+
+func mirrorHTTPError(err error, action string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Mirror not found")
+	case errors.Is(err, database.ErrAlreadyExists):
+		return huma.Error409Conflict("Mirror already exists")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Forbidden")
+	default:
+		return huma.Error500InternalServerError("Failed to "+action, err)
+	}
+}
+
+// RegisterMirrorsEndpoints registers upstream registry mirror endpoints. The
+// on-demand sync endpoint is only registered when a job manager is available.
+func RegisterMirrorsEndpoints(api huma.API, basePath string, registry service.RegistryService, jobManager *jobs.Manager) {
+	tags := []string{"mirrors", "admin"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-mirrors",
+		Method:      http.MethodGet,
+		Path:        basePath + "/mirrors",
+		Summary:     "List mirrors",
+		Description: "List upstream registries mirrored into this registry.",
+		Tags:        tags,
+	}, func(ctx context.Context, _ *struct{}) (*MirrorsListResponse, error) {
+		mirrors, err := registry.ListMirrors(ctx)
+		if err != nil {
+			return nil, mirrorHTTPError(err, "list mirrors")
+		}
+		resp := &MirrorsListResponse{}
+		resp.Body.Mirrors = make([]models.Mirror, 0, len(mirrors))
+		for _, m := range mirrors {
+			resp.Body.Mirrors = append(resp.Body.Mirrors, *m)
+		}
+		resp.Body.Count = len(resp.Body.Mirrors)
+		return resp, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-mirror",
+		Method:      http.MethodPost,
+		Path:        basePath + "/mirrors",
+		Summary:     "Create mirror",
+		Description: "Register an upstream registry (the official MCP registry or another agentregistry) whose servers are synced on a schedule. Include and exclude are glob patterns matched against server names.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CreateMirrorRequest) (*MirrorResponse, error) {
+		mirror, err := registry.CreateMirror(ctx, &input.Body)
+		if err != nil {
+			return nil, mirrorHTTPError(err, "create mirror")
+		}
+		return &MirrorResponse{Body: *mirror}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-mirror",
+		Method:      http.MethodDelete,
+		Path:        basePath + "/mirrors/{mirrorId}",
+		Summary:     "Delete mirror",
+		Description: "Stop mirroring an upstream registry and drop its sync history. Servers already mirrored are kept.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *MirrorByIDInput) (*types.Response[types.EmptyResponse], error) {
+		if err := registry.DeleteMirror(ctx, input.MirrorID); err != nil {
+			return nil, mirrorHTTPError(err, "delete mirror")
+		}
+		return &types.Response[types.EmptyResponse]{
+			Body: types.EmptyResponse{Message: "Mirror deleted successfully"},
+		}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-mirror-status",
+		Method:      http.MethodGet,
+		Path:        basePath + "/mirrors/{mirrorId}/status",
+		Summary:     "Get mirror status",
+		Description: "Get a mirror's configuration, watermark and recent sync history, newest first.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *MirrorStatusInput) (*MirrorStatusResponse, error) {
+		status, err := registry.GetMirrorStatus(ctx, input.MirrorID, input.History)
+		if err != nil {
+			return nil, mirrorHTTPError(err, "get mirror status")
+		}
+		return &MirrorStatusResponse{Body: *status}, nil
+	})
+
+	if jobManager == nil {
+		return
+	}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "sync-mirror",
+		Method:      http.MethodPost,
+		Path:        basePath + "/mirrors/{mirrorId}/sync",
+		Summary:     "Sync mirror",
+		Description: "Submit a sync job for a mirror now instead of waiting for its interval. Poll the job or the mirror status for the outcome.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *MirrorByIDInput) (*JobResponse, error) {
+		if _, err := registry.GetMirrorByID(ctx, input.MirrorID); err != nil {
+			return nil, mirrorHTTPError(err, "sync mirror")
+		}
+		job, err := jobManager.Submit(ctx, jobs.MirrorSyncJobType, models.JSONObject{"mirrorId": input.MirrorID})
+		if err != nil {
+			if errors.Is(err, jobs.ErrJobAlreadyRunning) {
+				return nil, huma.Error409Conflict("A mirror sync is already running")
+			}
+			return nil, jobHTTPError(err, "")
+		}
+		return &JobResponse{Body: *job}, nil
+	})
+}
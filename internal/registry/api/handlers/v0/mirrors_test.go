@@ -0,0 +1,133 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func TestMirrorsEndpoints(t *testing.T) {
+	mirrors := map[string]*models.Mirror{}
+	registry := servicetesting.NewFakeRegistry()
+	registry.CreateMirrorFn = func(_ context.Context, in *models.CreateMirrorInput) (*models.Mirror, error) {
+		if !strings.HasPrefix(in.URL, "https://") {
+			return nil, fmt.Errorf("%w: mirror url must be an http(s) URL", database.ErrInvalidInput)
+		}
+		if _, ok := mirrors[in.ID]; ok {
+			return nil, database.ErrAlreadyExists
+		}
+		m := &models.Mirror{ID: in.ID, URL: in.URL, Include: in.Include, IntervalSeconds: models.DefaultMirrorIntervalSeconds, Enabled: true}
+		mirrors[in.ID] = m
+		return m, nil
+	}
+	registry.ListMirrorsFn = func(context.Context) ([]*models.Mirror, error) {
+		out := make([]*models.Mirror, 0, len(mirrors))
+		for _, m := range mirrors {
+			out = append(out, m)
+		}
+		return out, nil
+	}
+	registry.GetMirrorByIDFn = func(_ context.Context, id string) (*models.Mirror, error) {
+		if m, ok := mirrors[id]; ok {
+			return m, nil
+		}
+		return nil, database.ErrNotFound
+	}
+	var gotHistory int
+	registry.GetMirrorStatusFn = func(_ context.Context, id string, history int) (*models.MirrorStatus, error) {
+		gotHistory = history
+		m, ok := mirrors[id]
+		if !ok {
+			return nil, database.ErrNotFound
+		}
+		return &models.MirrorStatus{
+			Mirror:  *m,
+			History: []models.MirrorSync{{ID: 1, MirrorID: id, Status: models.MirrorSyncStatusCompleted, Created: 2}},
+		}, nil
+	}
+	registry.DeleteMirrorFn = func(_ context.Context, id string) error {
+		if _, ok := mirrors[id]; !ok {
+			return database.ErrNotFound
+		}
+		delete(mirrors, id)
+		return nil
+	}
+
+	syncedMirrors := make(chan string, 1)
+	jobManager := jobs.NewManager()
+	jobManager.Register(jobs.MirrorSyncJobType, func(_ context.Context, job *jobs.Job, _ jobs.ProgressFunc) (*jobs.JobResult, error) {
+		syncedMirrors <- job.Params["mirrorId"].(string)
+		return &jobs.JobResult{}, nil
+	}, jobs.RunnerOptions{Exclusive: true})
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterMirrorsEndpoints(api, "/v0", registry, jobManager)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post("/v0/mirrors", `{"id":"official","url":"https://registry.modelcontextprotocol.io","include":["io.github.*"]}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var created models.Mirror
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, []string{"io.github.*"}, created.Include)
+
+	assert.Equal(t, http.StatusConflict, post("/v0/mirrors", `{"id":"official","url":"https://example.com"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, post("/v0/mirrors", `{"id":"other","url":"ftp://example.com"}`).Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/mirrors", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var list v0.MirrorsListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list.Body))
+	assert.Equal(t, 1, list.Body.Count)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/mirrors/official/status?history=5", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var status models.MirrorStatus
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+	assert.Equal(t, "official", status.Mirror.ID)
+	require.Len(t, status.History, 1)
+	assert.Equal(t, 2, status.History[0].Created)
+	assert.Equal(t, 5, gotHistory)
+
+	w = post("/v0/mirrors/official/sync", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	select {
+	case id := <-syncedMirrors:
+		assert.Equal(t, "official", id)
+	case <-time.After(2 * time.Second):
+		t.Fatal("mirror sync job did not run")
+	}
+	assert.Equal(t, http.StatusNotFound, post("/v0/mirrors/missing/sync", "").Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v0/mirrors/official", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/mirrors/official/status", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -18,11 +18,13 @@ import (
 
 // ListPromptsInput represents the input for listing prompts
 type ListPromptsInput struct {
-	Cursor       string `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false" example:"prompt-cursor-123"`
-	Limit        int    `query:"limit" json:"limit,omitempty" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
-	UpdatedSince string `query:"updated_since" json:"updated_since,omitempty" doc:"Filter prompts updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
-	Search       string `query:"search" json:"search,omitempty" doc:"Search prompts by name (substring match)" required:"false" example:"code-review"`
-	Version      string `query:"version" json:"version,omitempty" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Cursor                 string  `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false" example:"prompt-cursor-123"`
+	Limit                  int     `query:"limit" json:"limit,omitempty" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+	UpdatedSince           string  `query:"updated_since" json:"updated_since,omitempty" doc:"Filter prompts updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Search                 string  `query:"search" json:"search,omitempty" doc:"Search prompts by name (substring match)" required:"false" example:"code-review"`
+	Version                string  `query:"version" json:"version,omitempty" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Semantic               bool    `query:"semantic_search" json:"semantic_search,omitempty" doc:"Use semantic search for the search term"`
+	SemanticMatchThreshold float64 `query:"semantic_threshold" json:"semantic_threshold,omitempty" doc:"Optional maximum cosine distance when semantic_search is enabled" required:"false"`
 }
 
 // PromptDetailInput represents the input for getting prompt details
@@ -41,6 +43,13 @@ type PromptVersionsInput struct {
 	PromptName string `path:"promptName" json:"promptName" doc:"Prompt name (letters, digits, hyphens, underscores)" example:"my-prompt"`
 }
 
+// RenderPromptInput represents the input for rendering a prompt version
+type RenderPromptInput struct {
+	PromptName string                           `path:"promptName" json:"promptName" doc:"Prompt name (letters, digits, hyphens, underscores)" example:"my-prompt"`
+	Version    string                           `path:"version" json:"version" doc:"URL-encoded prompt version, or 'latest'" example:"1.0.0"`
+	Body       promptmodels.PromptRenderRequest `body:""`
+}
+
 // RegisterPromptsEndpoints registers all prompt-related endpoints with a custom path prefix.
 func RegisterPromptsEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
 	tags := []string{"prompts"}
@@ -67,7 +76,17 @@ func RegisterPromptsEndpoints(api huma.API, pathPrefix string, registry service.
 				return nil, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
 			}
 		}
-		if input.Search != "" {
+		// When semantic search is active, use pure vector similarity instead of
+		// AND-ing with a substring name filter.
+		if input.Semantic {
+			if strings.TrimSpace(input.Search) == "" {
+				return nil, huma.Error400BadRequest("semantic_search requires the search parameter to be provided", nil)
+			}
+			filter.Semantic = &database.SemanticSearchOptions{
+				RawQuery:  input.Search,
+				Threshold: input.SemanticMatchThreshold,
+			}
+		} else if input.Search != "" {
 			filter.SubstringName = &input.Search
 		}
 		if input.Version != "" {
@@ -81,6 +100,9 @@ func RegisterPromptsEndpoints(api huma.API, pathPrefix string, registry service.
 
 		prompts, nextCursor, err := registry.ListPrompts(ctx, filter, input.Cursor, input.Limit)
 		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
 			if errors.Is(err, auth.ErrUnauthenticated) {
 				return nil, huma.Error401Unauthorized("Authentication required")
 			}
@@ -180,6 +202,43 @@ func RegisterPromptsEndpoints(api huma.API, pathPrefix string, registry service.
 		}, nil
 	})
 
+	// Render a prompt version with argument values (supports "latest")
+	huma.Register(api, huma.Operation{
+		OperationID: "render-prompt-version" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodPost,
+		Path:        pathPrefix + "/prompts/{promptName}/versions/{version}/render",
+		Summary:     "Render a prompt version",
+		Description: "Fill the placeholders of a prompt template with argument values. Arguments that are not given take their default; missing required arguments are rejected.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *RenderPromptInput) (*types.Response[promptmodels.PromptRenderResponse], error) {
+		promptName, err := url.PathUnescape(input.PromptName)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid prompt name encoding", err)
+		}
+		version, err := url.PathUnescape(input.Version)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid version encoding", err)
+		}
+
+		rendered, err := registry.RenderPrompt(ctx, promptName, version, input.Body.Arguments)
+		if err != nil {
+			if err.Error() == errRecordNotFound || errors.Is(err, database.ErrNotFound) {
+				return nil, huma.Error404NotFound("Prompt not found")
+			}
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error401Unauthorized("Authentication required")
+			}
+			if errors.Is(err, auth.ErrForbidden) {
+				return nil, huma.Error403Forbidden("Forbidden")
+			}
+			return nil, huma.Error500InternalServerError("Failed to render prompt", err)
+		}
+		return &types.Response[promptmodels.PromptRenderResponse]{Body: *rendered}, nil
+	})
+
 	// Get all versions for a prompt
 	huma.Register(api, huma.Operation{
 		OperationID: "get-prompt-versions" + strings.ReplaceAll(pathPrefix, "/", "-"),
@@ -0,0 +1,133 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPromptsSemanticSearch(t *testing.T) {
+	ctx := context.Background()
+	db := internaldb.NewTestDB(t, internaldb.WithVector())
+
+	cfg := config.NewConfig()
+	cfg.Embeddings.Enabled = true
+	cfg.Embeddings.Provider = "stub"
+	cfg.Embeddings.Model = "stub-model"
+
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, randErr := rand.Read(testSeed)
+	require.NoError(t, randErr)
+	cfg.JWTPrivateKey = hex.EncodeToString(testSeed)
+
+	provider := newStubEmbeddingProvider(map[string][]float32{
+		"review code": {0.95, 0.1, 0.0},
+	})
+	registryService := service.NewRegistryService(db, cfg, provider)
+
+	for _, name := range []string{"code-reviewer", "travel-planner"} {
+		_, err := registryService.CreatePrompt(ctx, &models.PromptJSON{
+			Name:    name,
+			Version: "1.0.0",
+			Content: "You are a " + name + ".",
+		})
+		require.NoError(t, err)
+	}
+
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+	require.NoError(t, registryService.UpsertPromptEmbedding(ctxWithAuth, "code-reviewer", "1.0.0", &database.SemanticEmbedding{
+		Vector:     semanticVector(0.9, 0.1, 0.0),
+		Provider:   "stub",
+		Model:      "stub-model",
+		Dimensions: semanticEmbeddingDimensions,
+		Checksum:   "reviewer",
+		Generated:  time.Now().UTC(),
+	}))
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterPromptsEndpoints(api, "/v0", registryService)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/prompts?search=review+code&semantic_search=true", nil)
+	w := httptest.NewRecorder()
+
+	mux.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp models.PromptListResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	// Prompts without an embedding are excluded from semantic results.
+	require.Len(t, resp.Prompts, 1)
+	assert.Equal(t, "code-reviewer", resp.Prompts[0].Prompt.Name)
+	require.NotNil(t, resp.Prompts[0].Meta.Semantic)
+	assert.Equal(t, []string{"review code"}, provider.Queries())
+}
+
+func TestRenderPromptEndpoint(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	registry := servicetesting.NewFakeRegistry()
+	prompt := models.PromptJSON{
+		Name:      "greeter",
+		Version:   "1.0.0",
+		Content:   "Greet {{name}} in {{language}}.",
+		Arguments: []models.PromptArgument{{Name: "name", Required: true}, {Name: "language", Default: "English"}},
+	}
+	registry.RenderPromptFn = func(_ context.Context, promptName, version string, arguments map[string]string) (*models.PromptRenderResponse, error) {
+		if promptName != prompt.Name || (version != prompt.Version && version != "latest") {
+			return nil, database.ErrNotFound
+		}
+		content, err := prompt.Render(arguments)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+		}
+		return &models.PromptRenderResponse{Name: prompt.Name, Version: prompt.Version, Content: content}, nil
+	}
+	v0.RegisterPromptsEndpoints(api, "/v0", registry)
+
+	render := func(path string, arguments map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		body, err := json.Marshal(models.PromptRenderRequest{Arguments: arguments})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := render("/v0/prompts/greeter/versions/latest/render", map[string]string{"name": "Ada"})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var rendered models.PromptRenderResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&rendered))
+	assert.Equal(t, "Greet Ada in English.", rendered.Content)
+	assert.Equal(t, "1.0.0", rendered.Version)
+
+	w = render("/v0/prompts/greeter/versions/1.0.0/render", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "missing required prompt arguments: name")
+
+	w = render("/v0/prompts/unknown/versions/1.0.0/render", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
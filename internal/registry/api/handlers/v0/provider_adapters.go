@@ -78,8 +78,11 @@ type kubernetesProviderAdapter struct {
 // NOTE: local and kubernetes currently share the same adapter base behavior.
 // Provider CRUD remains extension-driven, and these concrete adapter types are
 // kept explicit so platform-specific validation can diverge later if needed.
+// kubernetes-native providers select a cluster the same way as kubernetes
+// providers, so they use the kubernetes adapter type.
 
-// DefaultProviderPlatformAdapters returns OSS provider adapters for local and kubernetes.
+// DefaultProviderPlatformAdapters returns OSS provider adapters for local,
+// kubernetes and kubernetes-native.
 func DefaultProviderPlatformAdapters(registry service.RegistryService) map[string]registrytypes.ProviderPlatformAdapter {
 	return map[string]registrytypes.ProviderPlatformAdapter{
 		"local": &localProviderAdapter{
@@ -94,5 +97,11 @@ func DefaultProviderPlatformAdapters(registry service.RegistryService) map[strin
 				registry:         registry,
 			},
 		},
+		"kubernetes-native": &kubernetesProviderAdapter{
+			providerAdapterBase: providerAdapterBase{
+				providerPlatform: "kubernetes-native",
+				registry:         registry,
+			},
+		},
 	}
 }
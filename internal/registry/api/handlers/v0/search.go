@@ -0,0 +1,82 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// SearchInput represents the input for searching across all artifact types
+type SearchInput struct {
+	Query                  string   `query:"q" json:"q,omitempty" doc:"Search term matched against names, titles and descriptions" required:"false" example:"postgres"`
+	Types                  []string `query:"type" json:"type,omitempty" doc:"Restrict results to these artifact types (server, agent, skill, prompt)" required:"false" example:"server,agent"`
+	Cursor                 string   `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false"`
+	Limit                  int      `query:"limit" json:"limit,omitempty" doc:"Number of results per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+	AllVersions            bool     `query:"all_versions" json:"all_versions,omitempty" doc:"Include all versions instead of only the latest version of each artifact"`
+	IncludeDeleted         bool     `query:"include_deleted" json:"include_deleted,omitempty" doc:"Include versions with status deleted"`
+	Semantic               bool     `query:"semantic_search" json:"semantic_search,omitempty" doc:"Combine substring matching with semantic similarity for the search term"`
+	SemanticMatchThreshold float64  `query:"semantic_threshold" json:"semantic_threshold,omitempty" doc:"Optional maximum cosine distance when semantic_search is enabled" required:"false"`
+}
+
+// RegisterSearchEndpoint registers the cross-artifact search endpoint with a custom path prefix.
+func RegisterSearchEndpoint(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "search-registry" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/search",
+		Summary:     "Search the registry",
+		Description: "Search servers, agents, skills and prompts in a single ranked result set, with per-type facet counts",
+		Tags:        []string{"search"},
+	}, func(ctx context.Context, input *SearchInput) (*types.Response[models.SearchResponse], error) {
+		filter := &database.SearchFilter{
+			Query:          strings.TrimSpace(input.Query),
+			AllVersions:    input.AllVersions,
+			IncludeDeleted: input.IncludeDeleted,
+		}
+		for _, t := range input.Types {
+			for _, part := range strings.Split(t, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					filter.Types = append(filter.Types, part)
+				}
+			}
+		}
+		// Semantic search keeps the substring query so results are ranked on
+		// both trigram and vector similarity.
+		if input.Semantic {
+			if filter.Query == "" {
+				return nil, huma.Error400BadRequest("semantic_search requires the q parameter to be provided", nil)
+			}
+			filter.Semantic = &database.SemanticSearchOptions{
+				RawQuery:        filter.Query,
+				Threshold:       input.SemanticMatchThreshold,
+				HybridSubstring: &filter.Query,
+			}
+		}
+
+		resp, err := registry.SearchRegistry(ctx, filter, input.Cursor, input.Limit)
+		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				return nil, huma.Error401Unauthorized("Authentication required")
+			}
+			if errors.Is(err, auth.ErrForbidden) {
+				return nil, huma.Error403Forbidden("Forbidden")
+			}
+			return nil, huma.Error500InternalServerError("Failed to search registry", err)
+		}
+
+		return &types.Response[models.SearchResponse]{
+			Body: *resp,
+		}, nil
+	})
+}
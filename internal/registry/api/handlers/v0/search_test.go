@@ -0,0 +1,93 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchEndpoint(t *testing.T) {
+	var gotFilter *database.SearchFilter
+	var gotCursor string
+	var gotLimit int
+
+	registry := servicetesting.NewFakeRegistry()
+	registry.SearchRegistryFn = func(_ context.Context, filter *database.SearchFilter, cursor string, limit int) (*models.SearchResponse, error) {
+		gotFilter, gotCursor, gotLimit = filter, cursor, limit
+		for _, typ := range filter.Types {
+			if typ == "widget" {
+				return nil, fmt.Errorf("%w: unknown artifact type %q", database.ErrInvalidInput, typ)
+			}
+		}
+		return &models.SearchResponse{
+			Results: []models.SearchResult{
+				{Type: models.SearchTypeServer, Name: "io.example/postgres", Version: "1.0.0", Score: 1},
+				{Type: models.SearchTypeSkill, Name: "postgres-tuning", Version: "0.2.0", Score: 0.8},
+			},
+			Metadata: models.SearchMetadata{
+				NextCursor: "next",
+				Count:      2,
+				Facets:     map[string]int{"server": 1, "agent": 0, "skill": 1, "prompt": 0},
+			},
+		}, nil
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterSearchEndpoint(api, "/v0", registry)
+
+	t.Run("substring search with type facets", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/search?q=postgres&type=server,skill&cursor=abc&limit=5", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var resp models.SearchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Len(t, resp.Results, 2)
+		assert.Equal(t, models.SearchTypeServer, resp.Results[0].Type)
+		assert.Equal(t, "next", resp.Metadata.NextCursor)
+		assert.Equal(t, 1, resp.Metadata.Facets["skill"])
+
+		assert.Equal(t, "postgres", gotFilter.Query)
+		assert.Equal(t, []string{"server", "skill"}, gotFilter.Types)
+		assert.Nil(t, gotFilter.Semantic)
+		assert.Equal(t, "abc", gotCursor)
+		assert.Equal(t, 5, gotLimit)
+	})
+
+	t.Run("semantic search is hybrid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/search?q=database+access&semantic_search=true&semantic_threshold=0.4", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		require.NotNil(t, gotFilter.Semantic)
+		assert.Equal(t, "database access", gotFilter.Semantic.RawQuery)
+		assert.Equal(t, 0.4, gotFilter.Semantic.Threshold)
+		require.NotNil(t, gotFilter.Semantic.HybridSubstring)
+		assert.Equal(t, "database access", *gotFilter.Semantic.HybridSubstring)
+	})
+
+	t.Run("semantic search requires a query", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/search?semantic_search=true", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("unknown type is a bad request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v0/search?q=x&type=widget", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
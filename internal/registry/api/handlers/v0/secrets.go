@@ -0,0 +1,140 @@
+package v0
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/secrets"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+type SecretByNameInput struct {
+	Name string `path:"name" json:"name" doc:"Secret name"`
+}
+
+type CreateSecretRequest struct {
+	Body models.SecretInput
+}
+
+type UpdateSecretRequest struct {
+	Name string `path:"name" json:"name" doc:"Secret name"`
+	Body struct {
+		Value       string `json:"value" doc:"New secret value"`
+		Description string `json:"description,omitempty" doc:"Secret description"`
+	}
+}
+
+type SecretsListResponse struct {
+	Body struct {
+		Secrets []models.Secret `json:"secrets"`
+		Count   int             `json:"count"`
+	}
+}
+
+type SecretResponse struct {
+	Body models.Secret
+}
+
+func secretHTTPError(err error, action string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Secret not found")
+	case errors.Is(err, database.ErrAlreadyExists):
+		return huma.Error409Conflict("Secret already exists")
+	case errors.Is(err, database.ErrInUse):
+		return huma.Error409Conflict(err.Error())
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error())
+	case errors.Is(err, secrets.ErrNotConfigured):
+		return huma.Error503ServiceUnavailable("Secret storage is not configured")
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Forbidden")
+	default:
+		return huma.Error500InternalServerError("Failed to "+action, err)
+	}
+}
+
+// RegisterSecretsEndpoints registers secret management endpoints. Secret values
+// are write-only: responses only ever carry metadata.
+func RegisterSecretsEndpoints(api huma.API, basePath string, registry service.RegistryService) {
+	tags := []string{"secrets", "admin"}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-secrets",
+		Method:      http.MethodGet,
+		Path:        basePath + "/secrets",
+		Summary:     "List secrets",
+		Description: "List stored secrets. Only metadata is returned; values cannot be read back. Requires a registry admin.",
+		Tags:        tags,
+	}, func(ctx context.Context, _ *struct{}) (*SecretsListResponse, error) {
+		list, err := registry.ListSecrets(ctx)
+		if err != nil {
+			return nil, secretHTTPError(err, "list secrets")
+		}
+		resp := &SecretsListResponse{}
+		resp.Body.Secrets = make([]models.Secret, 0, len(list))
+		for _, s := range list {
+			resp.Body.Secrets = append(resp.Body.Secrets, *s)
+		}
+		resp.Body.Count = len(resp.Body.Secrets)
+		return resp, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-secret",
+		Method:      http.MethodPost,
+		Path:        basePath + "/secrets",
+		Summary:     "Create secret",
+		Description: "Encrypt and store a secret. Deployments reference it by name through secretRefs and receive the value as an environment variable.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *CreateSecretRequest) (*SecretResponse, error) {
+		secret, err := registry.CreateSecret(ctx, &input.Body)
+		if err != nil {
+			return nil, secretHTTPError(err, "create secret")
+		}
+		return &SecretResponse{Body: *secret}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-secret",
+		Method:      http.MethodPut,
+		Path:        basePath + "/secrets/{name}",
+		Summary:     "Update secret",
+		Description: "Replace a secret's value. Existing deployments pick up the new value when they are next applied.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *UpdateSecretRequest) (*SecretResponse, error) {
+		secret, err := registry.UpdateSecret(ctx, input.Name, &models.SecretInput{
+			Name:        input.Name,
+			Value:       input.Body.Value,
+			Description: input.Body.Description,
+		})
+		if err != nil {
+			return nil, secretHTTPError(err, "update secret")
+		}
+		return &SecretResponse{Body: *secret}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-secret",
+		Method:      http.MethodDelete,
+		Path:        basePath + "/secrets/{name}",
+		Summary:     "Delete secret",
+		Description: "Delete a secret. Secrets referenced by a deployment cannot be deleted.",
+		Tags:        tags,
+	}, func(ctx context.Context, input *SecretByNameInput) (*types.Response[types.EmptyResponse], error) {
+		if err := registry.DeleteSecret(ctx, input.Name); err != nil {
+			return nil, secretHTTPError(err, "delete secret")
+		}
+		return &types.Response[types.EmptyResponse]{
+			Body: types.EmptyResponse{Message: "Secret deleted successfully"},
+		}, nil
+	})
+}
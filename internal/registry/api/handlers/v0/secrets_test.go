@@ -0,0 +1,116 @@
+package v0_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/secrets"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+func TestSecretsEndpoints(t *testing.T) {
+	values := map[string]string{}
+	registry := servicetesting.NewFakeRegistry()
+	registry.CreateSecretFn = func(_ context.Context, in *models.SecretInput) (*models.Secret, error) {
+		if in.Value == "" {
+			return nil, fmt.Errorf("%w: secret value is required", database.ErrInvalidInput)
+		}
+		if _, ok := values[in.Name]; ok {
+			return nil, database.ErrAlreadyExists
+		}
+		values[in.Name] = in.Value
+		return &models.Secret{Name: in.Name, Description: in.Description, KeyID: "k1"}, nil
+	}
+	registry.UpdateSecretFn = func(_ context.Context, name string, in *models.SecretInput) (*models.Secret, error) {
+		if _, ok := values[name]; !ok {
+			return nil, database.ErrNotFound
+		}
+		values[name] = in.Value
+		return &models.Secret{Name: name, KeyID: "k1"}, nil
+	}
+	registry.ListSecretsFn = func(context.Context) ([]*models.Secret, error) {
+		out := make([]*models.Secret, 0, len(values))
+		for name := range values {
+			out = append(out, &models.Secret{Name: name, KeyID: "k1"})
+		}
+		return out, nil
+	}
+	registry.DeleteSecretFn = func(_ context.Context, name string) error {
+		if name == "in-use" {
+			return fmt.Errorf("%w: secret in-use is referenced by a deployment", database.ErrInUse)
+		}
+		if _, ok := values[name]; !ok {
+			return database.ErrNotFound
+		}
+		delete(values, name)
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterSecretsEndpoints(api, "/v0", registry)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodPost, "/v0/secrets", `{"name":"github-token","value":"ghp_secret","description":"CI token"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.NotContains(t, w.Body.String(), "ghp_secret")
+	var created models.Secret
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "github-token", created.Name)
+
+	assert.Equal(t, http.StatusConflict, do(http.MethodPost, "/v0/secrets", `{"name":"github-token","value":"x"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/v0/secrets", `{"name":"empty","value":""}`).Code)
+
+	w = do(http.MethodPut, "/v0/secrets/github-token", `{"value":"ghp_rotated"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "ghp_rotated", values["github-token"])
+	assert.Equal(t, http.StatusNotFound, do(http.MethodPut, "/v0/secrets/missing", `{"value":"x"}`).Code)
+
+	w = do(http.MethodGet, "/v0/secrets", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var list v0.SecretsListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list.Body))
+	assert.Equal(t, 1, list.Body.Count)
+	assert.NotContains(t, w.Body.String(), "ghp_rotated")
+
+	assert.Equal(t, http.StatusConflict, do(http.MethodDelete, "/v0/secrets/in-use", "").Code)
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, "/v0/secrets/github-token", "").Code)
+	assert.Equal(t, http.StatusNotFound, do(http.MethodDelete, "/v0/secrets/github-token", "").Code)
+}
+
+func TestSecretsEndpoints_NotConfigured(t *testing.T) {
+	registry := servicetesting.NewFakeRegistry()
+	registry.CreateSecretFn = func(context.Context, *models.SecretInput) (*models.Secret, error) {
+		return nil, secrets.ErrNotConfigured
+	}
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterSecretsEndpoints(api, "/v0", registry)
+
+	req := httptest.NewRequest(http.MethodPost, "/v0/secrets", strings.NewReader(`{"name":"token","value":"x"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
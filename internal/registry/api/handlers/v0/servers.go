@@ -4,11 +4,13 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"maps"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/mirror"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
@@ -21,8 +23,8 @@ import (
 const errRecordNotFound = "record not found"
 const semanticMetadataKey = "aregistry.ai/semantic"
 
-// normalizeServerResponse moves semantic metadata into a dedicated response meta
-// field while keeping publisher-provided data untouched.
+// normalizeServerResponse moves semantic metadata and mirror provenance into
+// dedicated response meta fields while keeping publisher-provided data untouched.
 func normalizeServerResponse(src *apiv0.ServerResponse) models.ServerResponse {
 	if src == nil {
 		return models.ServerResponse{}
@@ -52,6 +54,15 @@ func normalizeServerResponse(src *apiv0.ServerResponse) models.ServerResponse {
 	if semanticScore != nil {
 		meta.Semantic = &models.ServerSemanticMeta{Score: *semanticScore}
 	}
+	if provenance := mirror.Provenance(&server); provenance != nil {
+		meta.Mirror = provenance
+		provided := maps.Clone(server.Meta.PublisherProvided)
+		delete(provided, mirror.MetadataKey)
+		if len(provided) == 0 {
+			provided = nil
+		}
+		server.Meta = &apiv0.ServerMeta{PublisherProvided: provided}
+	}
 
 	return models.ServerResponse{
 		Server: server,
@@ -474,6 +474,18 @@ func (s *stubEmbeddingProvider) Generate(ctx context.Context, payload embeddings
 	}, nil
 }
 
+func (s *stubEmbeddingProvider) GenerateBatch(ctx context.Context, payloads []embeddings.Payload) ([]*embeddings.Result, error) {
+	results := make([]*embeddings.Result, 0, len(payloads))
+	for _, payload := range payloads {
+		result, err := s.Generate(ctx, payload)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 func (s *stubEmbeddingProvider) Queries() []string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
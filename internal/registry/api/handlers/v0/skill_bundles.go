@@ -0,0 +1,103 @@
+package v0
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
+	"github.com/agentregistry-dev/agentregistry/pkg/types"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// UploadSkillBundleInput carries a gzipped tarball of a skill folder
+type UploadSkillBundleInput struct {
+	RawBody []byte `contentType:"application/gzip"`
+}
+
+// SkillBundleInput identifies a stored skill bundle
+type SkillBundleInput struct {
+	Digest string `path:"digest" json:"digest" doc:"URL-encoded bundle digest" example:"sha256%3A2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"`
+}
+
+// SkillBundleDownload is the raw bundle returned by the download endpoint
+type SkillBundleDownload struct {
+	ContentType  string `header:"Content-Type"`
+	ETag         string `header:"ETag"`
+	CacheControl string `header:"Cache-Control"`
+	Body         []byte
+}
+
+// RegisterSkillBundleEndpoints registers the endpoints that upload and serve
+// registry-hosted skill bundles.
+func RegisterSkillBundleEndpoints(api huma.API, pathPrefix string, registry service.RegistryService) {
+	huma.Register(api, huma.Operation{
+		OperationID:  "upload-skill-bundle" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:       http.MethodPost,
+		Path:         pathPrefix + "/skill-bundles",
+		Summary:      "Upload skill bundle",
+		Description:  "Upload a gzipped tarball of a skill folder with SKILL.md at its root. The bundle is stored under the sha256 digest of its bytes; publish a skill version with a package of registryType \"registry\" and the digest as identifier to use it.",
+		Tags:         []string{"skills"},
+		MaxBodyBytes: skillbundle.MaxSize,
+	}, func(ctx context.Context, input *UploadSkillBundleInput) (*types.Response[models.SkillBundle], error) {
+		// The raw body is backed by a pooled buffer that is reused after the
+		// request, so hand the service its own copy.
+		bundle, err := registry.UploadSkillBundle(ctx, bytes.Clone(input.RawBody))
+		if err != nil {
+			return nil, skillBundleError(err, "Failed to upload skill bundle")
+		}
+		return &types.Response[models.SkillBundle]{Body: *bundle}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-skill-bundle" + strings.ReplaceAll(pathPrefix, "/", "-"),
+		Method:      http.MethodGet,
+		Path:        pathPrefix + "/skill-bundles/{digest}",
+		Summary:     "Download skill bundle",
+		Description: "Download a skill bundle by digest. Clients should check that the sha256 of the body matches the digest.",
+		Tags:        []string{"skills"},
+		Responses: map[string]*huma.Response{
+			"200": {
+				Description: "Skill bundle",
+				Content:     map[string]*huma.MediaType{skillbundle.ContentType: {}},
+			},
+		},
+	}, func(ctx context.Context, input *SkillBundleInput) (*SkillBundleDownload, error) {
+		digest, err := url.PathUnescape(input.Digest)
+		if err != nil {
+			return nil, huma.Error400BadRequest("Invalid digest encoding", err)
+		}
+		bundle, err := registry.GetSkillBundle(ctx, digest)
+		if err != nil {
+			return nil, skillBundleError(err, "Failed to get skill bundle")
+		}
+		return &SkillBundleDownload{
+			ContentType:  skillbundle.ContentType,
+			ETag:         `"` + bundle.Digest + `"`,
+			CacheControl: "public, max-age=31536000, immutable",
+			Body:         bundle.Content,
+		}, nil
+	})
+}
+
+func skillBundleError(err error, msg string) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		return huma.Error404NotFound("Skill bundle not found")
+	case errors.Is(err, database.ErrInvalidInput):
+		return huma.Error400BadRequest(err.Error(), err)
+	case errors.Is(err, auth.ErrUnauthenticated):
+		return huma.Error401Unauthorized("Authentication required")
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("Forbidden")
+	default:
+		return huma.Error500InternalServerError(msg, err)
+	}
+}
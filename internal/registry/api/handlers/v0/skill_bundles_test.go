@@ -0,0 +1,83 @@
+package v0_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkillBundleEndpoints(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	registry := servicetesting.NewFakeRegistry()
+	stored := map[string]*database.SkillBundle{}
+	registry.UploadSkillBundleFn = func(_ context.Context, data []byte) (*models.SkillBundle, error) {
+		fm, err := skillbundle.Inspect(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+		}
+		digest := skillbundle.Digest(data)
+		stored[digest] = &database.SkillBundle{Digest: digest, SkillName: fm.Name, Content: data}
+		return &models.SkillBundle{Digest: digest, Name: fm.Name, Description: fm.Description, SizeBytes: len(data)}, nil
+	}
+	registry.GetSkillBundleFn = func(_ context.Context, digest string) (*database.SkillBundle, error) {
+		if bundle, ok := stored[digest]; ok {
+			return bundle, nil
+		}
+		return nil, database.ErrNotFound
+	}
+	v0.RegisterSkillBundleEndpoints(api, "/v0", registry)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("---\nname: pdf-tools\ndescription: Work with PDFs\n---\n"), 0o644))
+	data, err := skillbundle.Pack(dir)
+	require.NoError(t, err)
+
+	upload := func(body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v0/skill-bundles", bytes.NewReader(body))
+		req.Header.Set("Content-Type", skillbundle.ContentType)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := upload(data)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var bundle models.SkillBundle
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&bundle))
+	assert.Equal(t, skillbundle.Digest(data), bundle.Digest)
+	assert.Equal(t, "pdf-tools", bundle.Name)
+
+	w = upload([]byte("not a tarball"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/v0/skill-bundles/"+url.PathEscape(bundle.Digest), nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, skillbundle.ContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, `"`+bundle.Digest+`"`, w.Header().Get("ETag"))
+	assert.NoError(t, skillbundle.Verify(w.Body.Bytes(), bundle.Digest))
+
+	req = httptest.NewRequest(http.MethodGet, "/v0/skill-bundles/"+url.PathEscape(skillbundle.Digest([]byte("other"))), nil)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -18,11 +18,13 @@ import (
 
 // ListSkillsInput represents the input for listing skills
 type ListSkillsInput struct {
-	Cursor       string `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false" example:"skill-cursor-123"`
-	Limit        int    `query:"limit" json:"limit,omitempty" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
-	UpdatedSince string `query:"updated_since" json:"updated_since,omitempty" doc:"Filter skills updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
-	Search       string `query:"search" json:"search,omitempty" doc:"Search skills by name (substring match)" required:"false" example:"filesystem"`
-	Version      string `query:"version" json:"version,omitempty" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Cursor                 string  `query:"cursor" json:"cursor,omitempty" doc:"Pagination cursor" required:"false" example:"skill-cursor-123"`
+	Limit                  int     `query:"limit" json:"limit,omitempty" doc:"Number of items per page" default:"30" minimum:"1" maximum:"100" example:"50"`
+	UpdatedSince           string  `query:"updated_since" json:"updated_since,omitempty" doc:"Filter skills updated since timestamp (RFC3339 datetime)" required:"false" example:"2025-08-07T13:15:04.280Z"`
+	Search                 string  `query:"search" json:"search,omitempty" doc:"Search skills by name (substring match)" required:"false" example:"filesystem"`
+	Version                string  `query:"version" json:"version,omitempty" doc:"Filter by version ('latest' for latest version, or an exact version like '1.2.3')" required:"false" example:"latest"`
+	Semantic               bool    `query:"semantic_search" json:"semantic_search,omitempty" doc:"Use semantic search for the search term"`
+	SemanticMatchThreshold float64 `query:"semantic_threshold" json:"semantic_threshold,omitempty" doc:"Optional maximum cosine distance when semantic_search is enabled" required:"false"`
 }
 
 // SkillDetailInput represents the input for getting skill details
@@ -69,7 +71,17 @@ func RegisterSkillsEndpoints(api huma.API, pathPrefix string, registry service.R
 				return nil, huma.Error400BadRequest("Invalid updated_since format: expected RFC3339 timestamp (e.g., 2025-08-07T13:15:04.280Z)")
 			}
 		}
-		if input.Search != "" {
+		// When semantic search is active, use pure vector similarity instead of
+		// AND-ing with a substring name filter.
+		if input.Semantic {
+			if strings.TrimSpace(input.Search) == "" {
+				return nil, huma.Error400BadRequest("semantic_search requires the search parameter to be provided", nil)
+			}
+			filter.Semantic = &database.SemanticSearchOptions{
+				RawQuery:  input.Search,
+				Threshold: input.SemanticMatchThreshold,
+			}
+		} else if input.Search != "" {
 			filter.SubstringName = &input.Search
 		}
 		if input.Version != "" {
@@ -83,6 +95,9 @@ func RegisterSkillsEndpoints(api huma.API, pathPrefix string, registry service.R
 
 		skills, nextCursor, err := registry.ListSkills(ctx, filter, input.Cursor, input.Limit)
 		if err != nil {
+			if errors.Is(err, database.ErrInvalidInput) {
+				return nil, huma.Error400BadRequest(err.Error(), err)
+			}
 			if errors.Is(err, auth.ErrUnauthenticated) {
 				return nil, huma.Error401Unauthorized("Authentication required")
 			}
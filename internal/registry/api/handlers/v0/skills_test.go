@@ -0,0 +1,124 @@
+package v0_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v0 "github.com/agentregistry-dev/agentregistry/internal/registry/api/handlers/v0"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListSkillsSemanticSearch(t *testing.T) {
+	ctx := context.Background()
+	db := internaldb.NewTestDB(t, internaldb.WithVector())
+
+	cfg := config.NewConfig()
+	cfg.Embeddings.Enabled = true
+	cfg.Embeddings.Provider = "stub"
+	cfg.Embeddings.Model = "stub-model"
+
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, randErr := rand.Read(testSeed)
+	require.NoError(t, randErr)
+	cfg.JWTPrivateKey = hex.EncodeToString(testSeed)
+
+	provider := newStubEmbeddingProvider(map[string][]float32{
+		"read pdf documents": {0.1, 0.95, 0.0},
+	})
+	registryService := service.NewRegistryService(db, cfg, provider)
+
+	pdfSkill := "pdf-tools"
+	gitSkill := "git-helper"
+	for _, skill := range []struct {
+		name        string
+		description string
+	}{
+		{name: pdfSkill, description: "Extract text and tables from PDF files"},
+		{name: gitSkill, description: "Summarize git history"},
+	} {
+		_, err := registryService.CreateSkill(ctx, &models.SkillJSON{
+			Name:        skill.name,
+			Description: skill.description,
+			Version:     "1.0.0",
+		})
+		require.NoError(t, err)
+	}
+
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+	require.NoError(t, registryService.UpsertSkillEmbedding(ctxWithAuth, pdfSkill, "1.0.0", &database.SemanticEmbedding{
+		Vector:     semanticVector(0.1, 0.9, 0.0),
+		Provider:   "stub",
+		Model:      "stub-model",
+		Dimensions: semanticEmbeddingDimensions,
+		Checksum:   "pdf",
+		Generated:  time.Now().UTC(),
+	}))
+	require.NoError(t, registryService.UpsertSkillEmbedding(ctxWithAuth, gitSkill, "1.0.0", &database.SemanticEmbedding{
+		Vector:     semanticVector(0.9, 0.1, 0.0),
+		Provider:   "stub",
+		Model:      "stub-model",
+		Dimensions: semanticEmbeddingDimensions,
+		Checksum:   "git",
+		Generated:  time.Now().UTC(),
+	}))
+
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	v0.RegisterSkillsEndpoints(api, "/v0", registryService)
+
+	t.Run("semantic search ranks by similarity", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/skills?search=read+pdf+documents&semantic_search=true", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp models.SkillListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Skills, 2)
+		assert.Equal(t, pdfSkill, resp.Skills[0].Skill.Name)
+		require.NotNil(t, resp.Skills[0].Meta.Semantic)
+		assert.Less(t, resp.Skills[0].Meta.Semantic.Score, resp.Skills[1].Meta.Semantic.Score)
+		assert.Empty(t, resp.Metadata.NextCursor)
+	})
+
+	t.Run("semantic threshold filters distant matches", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/skills?search=read+pdf+documents&semantic_search=true&semantic_threshold=0.1", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp models.SkillListResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		require.Len(t, resp.Skills, 1)
+		assert.Equal(t, pdfSkill, resp.Skills[0].Skill.Name)
+	})
+
+	t.Run("semantic search without search term is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v0/skills?semantic_search=true", nil)
+		w := httptest.NewRecorder()
+
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "semantic_search requires the search parameter")
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	apitypes "github.com/agentregistry-dev/agentregistry/internal/registry/api/apitypes"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/audit"
 	"github.com/agentregistry-dev/agentregistry/pkg/logging"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/danielgtaylor/huma/v2"
@@ -142,6 +143,9 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 	// Create a new API using humago adapter for standard library
 	api := humago.New(mux, humaConfig)
 
+	// Correlate audit entries and logs with the request that caused them
+	api.UseMiddleware(audit.RequestIDMiddleware())
+
 	// Add authn middleware if configured
 	if authnProvider != nil {
 		api.UseMiddleware(auth.AuthnMiddleware(authnProvider,
@@ -198,6 +202,7 @@ func NewHumaAPI(cfg *config.Config, registry service.RegistryService, mux *http.
 	// Set the mux on routeOpts for SSE handlers that need direct mux access
 	if routeOpts != nil {
 		routeOpts.Mux = mux
+		routeOpts.Authn = authnProvider
 	}
 
 	// Register all API routes under /v0
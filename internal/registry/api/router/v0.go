@@ -14,6 +14,7 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 )
 
 // RouteOptions contains optional services for route registration.
@@ -21,6 +22,8 @@ type RouteOptions struct {
 	Indexer    service.Indexer
 	JobManager *jobs.Manager
 	Mux        *http.ServeMux
+	// Authn authenticates requests to handlers registered directly on Mux.
+	Authn auth.AuthnProvider
 
 	// Optional deployment adapters keyed by provider platform type.
 	ProviderPlatforms   map[string]registrytypes.ProviderPlatformAdapter
@@ -59,13 +62,26 @@ func RegisterRoutes(
 	v0.RegisterAgentsCreateEndpoint(api, pathPrefix, registry)
 	v0.RegisterSkillsEndpoints(api, pathPrefix, registry)
 	v0.RegisterSkillsCreateEndpoint(api, pathPrefix, registry)
+	v0.RegisterSkillBundleEndpoints(api, pathPrefix, registry)
 	v0.RegisterPromptsEndpoints(api, pathPrefix, registry)
 	v0.RegisterPromptsCreateEndpoint(api, pathPrefix, registry)
+	v0.RegisterSearchEndpoint(api, pathPrefix, registry)
+	v0.RegisterAuditEndpoint(api, pathPrefix, registry)
+	v0.RegisterSecretsEndpoints(api, pathPrefix, registry)
 
+	var jobManager *jobs.Manager
+	if opts != nil {
+		jobManager = opts.JobManager
+	}
+	v0.RegisterMirrorsEndpoints(api, pathPrefix, registry, jobManager)
+
+	if opts != nil && opts.JobManager != nil {
+		v0.RegisterJobsEndpoints(api, pathPrefix, opts.JobManager)
+	}
 	if opts != nil && opts.Indexer != nil && opts.JobManager != nil {
 		v0.RegisterEmbeddingsEndpoints(api, pathPrefix, opts.Indexer, opts.JobManager)
 		if opts.Mux != nil {
-			v0.RegisterEmbeddingsSSEHandler(opts.Mux, pathPrefix, opts.Indexer, opts.JobManager)
+			v0.RegisterEmbeddingsSSEHandler(opts.Mux, pathPrefix, opts.Indexer, opts.JobManager, opts.Authn)
 		}
 	}
 	if opts != nil && opts.ExtraRoutes != nil {
@@ -182,7 +182,7 @@ func NewServer(cfg *config.Config, registryService service.RegistryService, metr
 			http.MethodOptions,
 		},
 		AllowedHeaders:   []string{"*"},
-		ExposedHeaders:   []string{"Content-Type", "Content-Length"},
+		ExposedHeaders:   []string{"Content-Type", "Content-Length", "X-Request-ID"},
 		AllowCredentials: false, // Must be false when AllowedOrigins is "*"
 		MaxAge:           86400, // 24 hours
 	})
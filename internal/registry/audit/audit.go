@@ -0,0 +1,112 @@
+// Package audit builds entries for the append-only log of mutating registry
+// operations and carries the request ID that correlates them with API calls.
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// RequestIDHeader is the header a request ID is read from and echoed in.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied request IDs; longer ones are replaced.
+const maxRequestIDLength = 128
+
+type requestIDKeyType struct{}
+
+var requestIDKey = requestIDKeyType{}
+
+// WithRequestID returns a context carrying the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFrom returns the request ID carried by ctx, or "".
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// RequestIDMiddleware propagates the caller's X-Request-ID, or generates one,
+// into the request context and echoes it in the response.
+func RequestIDMiddleware() func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		id := strings.TrimSpace(ctx.Header(RequestIDHeader))
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+		ctx.SetHeader(RequestIDHeader, id)
+		next(huma.WithContext(ctx, WithRequestID(ctx.Context(), id)))
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// Actor returns the principal and auth method to record for the session in ctx.
+// Requests without a session are recorded as anonymous and internal operations
+// running under the system session as system.
+func Actor(ctx context.Context) (principal, method string) {
+	session, ok := auth.AuthSessionFrom(ctx)
+	if !ok {
+		return models.AuditPrincipalAnonymous, string(auth.MethodNone)
+	}
+	if auth.IsSystemSession(session) {
+		return models.AuditPrincipalSystem, models.AuditPrincipalSystem
+	}
+	p := session.Principal()
+	principal = p.User.Subject
+	if principal == "" {
+		principal = models.AuditPrincipalAnonymous
+	}
+	method = string(p.AuthMethod)
+	if method == "" {
+		method = string(auth.MethodNone)
+	}
+	return principal, method
+}
+
+// Digest returns the sha256 digest of v's JSON encoding as "sha256:<hex>", or
+// "" when v is nil or cannot be encoded.
+func Digest(v any) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil || string(data) == "null" {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// NewEntry builds an audit entry for the actor and request in ctx. before and
+// after are the resource state around the change; either may be nil.
+func NewEntry(ctx context.Context, action, resourceType, name, version string, before, after any) *models.AuditEntry {
+	principal, method := Actor(ctx)
+	return &models.AuditEntry{
+		Principal:       principal,
+		AuthMethod:      method,
+		Action:          action,
+		ResourceType:    resourceType,
+		ResourceName:    name,
+		ResourceVersion: version,
+		BeforeDigest:    Digest(before),
+		AfterDigest:     Digest(after),
+		RequestID:       RequestIDFrom(ctx),
+	}
+}
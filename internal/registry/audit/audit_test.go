@@ -0,0 +1,93 @@
+package audit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humago"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/audit"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+type userSession struct {
+	principal auth.Principal
+}
+
+func (s userSession) Principal() auth.Principal { return s.principal }
+
+func TestActor(t *testing.T) {
+	principal, method := audit.Actor(context.Background())
+	assert.Equal(t, models.AuditPrincipalAnonymous, principal)
+	assert.Equal(t, string(auth.MethodNone), method)
+
+	principal, method = audit.Actor(auth.WithSystemContext(context.Background()))
+	assert.Equal(t, models.AuditPrincipalSystem, principal)
+	assert.Equal(t, models.AuditPrincipalSystem, method)
+
+	ctx := auth.AuthSessionTo(context.Background(), userSession{principal: auth.Principal{
+		User:       auth.User{Subject: "octocat"},
+		AuthMethod: auth.MethodGitHubAT,
+	}})
+	principal, method = audit.Actor(ctx)
+	assert.Equal(t, "octocat", principal)
+	assert.Equal(t, string(auth.MethodGitHubAT), method)
+}
+
+func TestDigest(t *testing.T) {
+	var missing *models.Provider
+	assert.Empty(t, audit.Digest(nil))
+	assert.Empty(t, audit.Digest(missing))
+
+	a := audit.Digest(&models.Provider{ID: "local", Platform: "local"})
+	b := audit.Digest(&models.Provider{ID: "local", Platform: "kubernetes"})
+	assert.True(t, strings.HasPrefix(a, "sha256:"))
+	assert.Len(t, a, len("sha256:")+64)
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, a, audit.Digest(&models.Provider{ID: "local", Platform: "local"}))
+}
+
+func TestNewEntry(t *testing.T) {
+	ctx := audit.WithRequestID(auth.WithSystemContext(context.Background()), "req-1")
+	entry := audit.NewEntry(ctx, models.AuditActionDelete, models.AuditResourceServer, "io.github.example/weather", "1.0.0", map[string]string{"v": "1"}, nil)
+	assert.Equal(t, models.AuditPrincipalSystem, entry.Principal)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.NotEmpty(t, entry.BeforeDigest)
+	assert.Empty(t, entry.AfterDigest)
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	api := humago.New(mux, huma.DefaultConfig("Test API", "1.0.0"))
+	api.UseMiddleware(audit.RequestIDMiddleware())
+
+	var seen string
+	huma.Register(api, huma.Operation{
+		OperationID: "echo",
+		Method:      http.MethodGet,
+		Path:        "/echo",
+	}, func(ctx context.Context, _ *struct{}) (*struct{}, error) {
+		seen = audit.RequestIDFrom(ctx)
+		return nil, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/echo", nil)
+	req.Header.Set(audit.RequestIDHeader, "client-supplied")
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, req)
+	assert.Equal(t, "client-supplied", seen)
+	assert.Equal(t, "client-supplied", w.Header().Get(audit.RequestIDHeader))
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/echo", nil))
+	require.NotEmpty(t, seen)
+	assert.NotEqual(t, "client-supplied", seen)
+	assert.Equal(t, seen, w.Header().Get(audit.RequestIDHeader))
+}
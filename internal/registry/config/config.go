@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"log/slog"
 	"os"
+	"time"
 
 	env "github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
@@ -28,6 +29,33 @@ type Config struct {
 	EnableRegistryValidation bool   `env:"ENABLE_REGISTRY_VALIDATION" envDefault:"true"`
 	LogLevel                 string `env:"LOG_LEVEL" envDefault:"info"`
 
+	// Import sources and export destinations of data jobs are confined to
+	// DataJobsDir, which should be shared by every replica since any of them
+	// may run a job. Import jobs fetch URL sources only when they start with
+	// one of DataJobsAllowedURLs.
+	DataJobsDir         string   `env:"DATA_JOBS_DIR" envDefault:""`
+	DataJobsAllowedURLs []string `env:"DATA_JOBS_ALLOWED_URLS" envSeparator:","`
+
+	// SecretsMasterKey wraps the data keys of stored secrets. It is a 32-byte
+	// key, hex or base64 encoded. Secrets are unavailable when it is unset.
+	SecretsMasterKey string `env:"SECRETS_MASTER_KEY" envDefault:""`
+
+	// Artifact signing. Signatures attached to servers, agents and skills are
+	// verified on publish against the trusted public keys in
+	// SigningTrustedKeysDir (one <key-id>.pem per key) and, for keyless
+	// signatures, the root certificates in SigningTrustRoots. SigningIdentities
+	// limits the certificate identities (email or URI) accepted, and
+	// SigningAcceptExpiredCertificates checks certificates as of their issue
+	// time rather than now (see signing.TrustPolicy.AcceptExpiredCertificates).
+	// The require flags reject unsigned, unverified or unpinned versions on
+	// publish and deploy.
+	SigningTrustedKeysDir            string   `env:"SIGNING_TRUSTED_KEYS_DIR" envDefault:""`
+	SigningTrustRoots                string   `env:"SIGNING_TRUST_ROOTS" envDefault:""`
+	SigningIdentities                []string `env:"SIGNING_IDENTITIES" envSeparator:","`
+	SigningAcceptExpiredCertificates bool     `env:"SIGNING_ACCEPT_EXPIRED_CERTIFICATES" envDefault:"false"`
+	SigningRequireOnPublish          bool     `env:"SIGNING_REQUIRE_ON_PUBLISH" envDefault:"false"`
+	SigningRequireOnDeploy           bool     `env:"SIGNING_REQUIRE_ON_DEPLOY" envDefault:"false"`
+
 	// OIDC Configuration
 	OIDCEnabled      bool   `env:"OIDC_ENABLED" envDefault:"false"`
 	OIDCIssuer       string `env:"OIDC_ISSUER" envDefault:""`
@@ -48,6 +76,22 @@ type Config struct {
 
 	// Agent Gateway Configuration
 	AgentGatewayPort uint16 `env:"AGENT_GATEWAY_PORT" envDefault:"8081"`
+	// AgentGatewayHost is the host the registry uses to reach the local agent
+	// gateway when health probing deployments.
+	AgentGatewayHost string `env:"AGENT_GATEWAY_HOST" envDefault:"localhost"`
+
+	// Deployment reconciliation. Managed deployments are compared with live
+	// platform state every interval (0 disables it); with repair enabled,
+	// missing and drifted workloads are re-applied.
+	DeploymentReconcileInterval time.Duration `env:"DEPLOYMENT_RECONCILE_INTERVAL" envDefault:"1m"`
+	DeploymentReconcileRepair   bool          `env:"DEPLOYMENT_RECONCILE_REPAIR" envDefault:"false"`
+	// Running deployments are health probed on the same interval; each probe
+	// gives up after DeploymentHealthTimeout.
+	DeploymentHealthTimeout time.Duration `env:"DEPLOYMENT_HEALTH_TIMEOUT" envDefault:"10s"`
+
+	// How often the MCP server re-reads the active registry prompts it exposes
+	// (0 reads them once at startup).
+	MCPPromptSyncInterval time.Duration `env:"MCP_PROMPT_SYNC_INTERVAL" envDefault:"10s"`
 
 	// Runtime Configuration
 	RuntimeDir string `env:"RUNTIME_DIR" envDefault:"/tmp/arctl-runtime"`
@@ -67,6 +111,15 @@ type EmbeddingsConfig struct {
 	OpenAIBaseURL string `env:"OPENAI_BASE_URL" envDefault:"https://api.openai.com/v1"`
 	OpenAIOrg     string `env:"OPENAI_ORG" envDefault:""`
 	OnPublish     bool   `env:"EMBEDDINGS_ON_PUBLISH" envDefault:"false"`
+
+	// Settings for the ollama and openai-compatible providers.
+	BaseURL    string            `env:"EMBEDDINGS_BASE_URL" envDefault:""`
+	APIKey     string            `env:"EMBEDDINGS_API_KEY" envDefault:""`
+	AuthHeader string            `env:"EMBEDDINGS_AUTH_HEADER" envDefault:"Authorization"`
+	Headers    map[string]string `env:"EMBEDDINGS_HEADERS"`
+
+	// BatchSize caps how many payloads are sent to the provider per request.
+	BatchSize int `env:"EMBEDDINGS_BATCH_SIZE" envDefault:"64"`
 }
 
 // NewConfig creates a new configuration with default values
@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Validate performs runtime validations on the loaded configuration.
 // It is intentionally strict for embeddings to avoid runtime pgvector errors.
@@ -23,5 +26,10 @@ func Validate(cfg *Config) error {
 			return fmt.Errorf("embeddings provider must be specified when embeddings are enabled")
 		}
 	}
+	if cfg.SigningRequireOnPublish || cfg.SigningRequireOnDeploy {
+		if strings.TrimSpace(cfg.SigningTrustedKeysDir) == "" && strings.TrimSpace(cfg.SigningTrustRoots) == "" {
+			return fmt.Errorf("requiring signatures needs trusted signing keys or trust roots to be configured")
+		}
+	}
 	return nil
 }
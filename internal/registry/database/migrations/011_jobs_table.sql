@@ -0,0 +1,34 @@
+-- =============================================================================
+-- JOBS TABLE
+-- =============================================================================
+-- Background jobs (embeddings indexing, imports, exports) shared by all
+-- registry replicas. A replica executes a job only while it holds the lease;
+-- leases are renewed by heartbeats and expired leases can be reclaimed.
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id VARCHAR(255) PRIMARY KEY,
+    type VARCHAR(100) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending',
+    params JSONB NOT NULL DEFAULT '{}'::jsonb,
+    progress JSONB NOT NULL DEFAULT '{}'::jsonb,
+    result JSONB,
+
+    -- Leasing
+    lease_owner VARCHAR(255),
+    lease_expires_at TIMESTAMP WITH TIME ZONE,
+    cancel_requested BOOLEAN NOT NULL DEFAULT false,
+    attempts INTEGER NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_type_status ON jobs (type, status);
+CREATE INDEX IF NOT EXISTS idx_jobs_claimable ON jobs (created_at)
+    WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS idx_jobs_created_at ON jobs (created_at DESC);
+
+ALTER TABLE jobs ADD CONSTRAINT check_job_status_valid
+    CHECK (status IN ('pending', 'running', 'completed', 'failed', 'cancelled'));
@@ -0,0 +1,40 @@
+-- =============================================================================
+-- MIRRORS
+-- =============================================================================
+-- Upstream registries whose servers are synced into this registry on a
+-- schedule, and the history of those syncs. The watermark is the newest
+-- upstream updatedAt applied so far and is sent as updated_since on the next
+-- poll.
+
+CREATE TABLE IF NOT EXISTS mirrors (
+    id TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    include_patterns JSONB NOT NULL DEFAULT '[]'::jsonb,
+    exclude_patterns JSONB NOT NULL DEFAULT '[]'::jsonb,
+    interval_seconds INTEGER NOT NULL DEFAULT 3600,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    watermark TIMESTAMP WITH TIME ZONE,
+    last_synced_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_mirror_interval_positive CHECK (interval_seconds > 0)
+);
+
+CREATE TABLE IF NOT EXISTS mirror_syncs (
+    id BIGSERIAL PRIMARY KEY,
+    mirror_id TEXT NOT NULL REFERENCES mirrors(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL,
+    since TIMESTAMP WITH TIME ZONE,
+    watermark TIMESTAMP WITH TIME ZONE,
+    fetched INTEGER NOT NULL DEFAULT 0,
+    created INTEGER NOT NULL DEFAULT 0,
+    updated INTEGER NOT NULL DEFAULT 0,
+    skipped INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    CONSTRAINT check_mirror_sync_status_valid CHECK (status IN ('completed', 'partial', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_mirror_syncs_mirror_started ON mirror_syncs (mirror_id, started_at DESC);
@@ -0,0 +1,39 @@
+-- =============================================================================
+-- AUDIT LOG
+-- =============================================================================
+-- Append-only record of every mutating registry operation: who did what to
+-- which resource, with digests of the resource before and after the change.
+-- Entries are written in the same transaction as the change they describe.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    principal TEXT NOT NULL,
+    auth_method VARCHAR(50) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL,
+    resource_name TEXT NOT NULL,
+    resource_version VARCHAR(255),
+    before_digest VARCHAR(100),
+    after_digest VARCHAR(100),
+    request_id VARCHAR(255),
+    details JSONB
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_principal ON audit_log (principal, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_resource ON audit_log (resource_type, resource_name, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_request_id ON audit_log (request_id) WHERE request_id IS NOT NULL;
+
+-- Reject updates and deletes so entries cannot be rewritten after the fact.
+CREATE OR REPLACE FUNCTION audit_log_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW
+    EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,19 @@
+-- =============================================================================
+-- SECRETS
+-- =============================================================================
+-- Envelope-encrypted secret values. Each value is sealed with its own data key;
+-- the data key is stored wrapped with the registry master key identified by
+-- key_id. Plaintext values are never written to the database.
+
+CREATE TABLE IF NOT EXISTS secrets (
+    name VARCHAR(255) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    ciphertext BYTEA NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    key_id VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Deployments reference secrets by name instead of embedding their values.
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS secret_refs JSONB NOT NULL DEFAULT '{}'::jsonb;
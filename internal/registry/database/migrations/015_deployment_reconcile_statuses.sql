@@ -0,0 +1,5 @@
+-- The deployment reconciler records workloads that no longer match their
+-- desired state as drifted, missing or unhealthy.
+ALTER TABLE deployments DROP CONSTRAINT IF EXISTS check_deployment_status_valid;
+ALTER TABLE deployments ADD CONSTRAINT check_deployment_status_valid
+    CHECK (status IN ('active', 'deploying', 'deployed', 'failed', 'cancelled', 'discovered', 'stopped', 'drifted', 'missing', 'unhealthy'));
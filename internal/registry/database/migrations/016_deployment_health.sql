@@ -0,0 +1,3 @@
+-- Result of the last health probe of a deployment: an MCP handshake for
+-- servers or an agent card fetch for agents. NULL until first probed.
+ALTER TABLE deployments ADD COLUMN IF NOT EXISTS health JSONB;
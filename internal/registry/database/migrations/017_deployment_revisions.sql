@@ -0,0 +1,38 @@
+-- =============================================================================
+-- DEPLOYMENT REVISIONS
+-- =============================================================================
+-- Immutable history of every deploy, update, rollback and undeploy of a
+-- deployment: what was applied and how it ended. Revisions outlive their
+-- deployment record, so there is no foreign key to deployments.
+
+CREATE TABLE IF NOT EXISTS deployment_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    deployment_id TEXT NOT NULL,
+    revision INTEGER NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    resource_type VARCHAR(50) NOT NULL,
+    server_name TEXT NOT NULL,
+    version VARCHAR(255) NOT NULL,
+    provider_id TEXT,
+    env JSONB NOT NULL DEFAULT '{}'::jsonb,
+    secret_refs JSONB NOT NULL DEFAULT '{}'::jsonb,
+    provider_config JSONB NOT NULL DEFAULT '{}'::jsonb,
+    prefer_remote BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(50) NOT NULL,
+    error TEXT,
+    source_revision INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_deployment_revision UNIQUE (deployment_id, revision)
+);
+
+CREATE OR REPLACE FUNCTION deployment_revisions_immutable()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'deployment_revisions is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_deployment_revisions_immutable
+    BEFORE UPDATE OR DELETE ON deployment_revisions
+    FOR EACH ROW
+    EXECUTE FUNCTION deployment_revisions_immutable();
@@ -0,0 +1,5 @@
+-- Name of the version that replaces a deprecated agent, skill or prompt
+-- version, shown to clients alongside the deprecated status.
+ALTER TABLE agents ADD COLUMN IF NOT EXISTS successor TEXT;
+ALTER TABLE skills ADD COLUMN IF NOT EXISTS successor TEXT;
+ALTER TABLE prompts ADD COLUMN IF NOT EXISTS successor TEXT;
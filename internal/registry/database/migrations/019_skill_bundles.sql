@@ -0,0 +1,17 @@
+-- =============================================================================
+-- SKILL BUNDLES
+-- =============================================================================
+-- Gzipped tarballs of skill folders hosted by the registry. Bundles are content
+-- addressed: the digest is the sha256 of the bytes, so identical uploads are
+-- stored once. Skill versions reference a bundle through a package with
+-- registryType "registry" whose identifier is the digest.
+
+CREATE TABLE IF NOT EXISTS skill_bundles (
+    digest VARCHAR(71) PRIMARY KEY,
+    skill_name VARCHAR(255) NOT NULL,
+    content BYTEA NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_skill_bundles_skill_name ON skill_bundles (skill_name);
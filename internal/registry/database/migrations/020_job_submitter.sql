@@ -0,0 +1,3 @@
+-- Principal that submitted each job. Jobs run with the submitter's
+-- permissions; jobs without one run anonymously.
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS submitter JSONB;
@@ -0,0 +1,4 @@
+-- Principal that created each mirror. Syncs run with its permissions, so a
+-- mirror can only publish servers its creator could publish; mirrors created
+-- before this column existed sync anonymously.
+ALTER TABLE mirrors ADD COLUMN IF NOT EXISTS created_by JSONB;
@@ -0,0 +1,14 @@
+-- Deployment revisions keep only the names of plain environment variables:
+-- values may hold credentials and must not be kept in history. Revisions stay
+-- append-only and still outlive their deployment record.
+
+ALTER TABLE deployment_revisions DISABLE TRIGGER trg_deployment_revisions_immutable;
+
+ALTER TABLE deployment_revisions ADD COLUMN IF NOT EXISTS env_keys JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+UPDATE deployment_revisions
+SET env_keys = COALESCE((SELECT jsonb_agg(key ORDER BY key) FROM jsonb_object_keys(env) AS key), '[]'::jsonb);
+
+ALTER TABLE deployment_revisions DROP COLUMN IF EXISTS env;
+
+ALTER TABLE deployment_revisions ENABLE TRIGGER trg_deployment_revisions_immutable;
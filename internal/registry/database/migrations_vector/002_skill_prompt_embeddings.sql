@@ -0,0 +1,22 @@
+-- Semantic embedding columns for skills and prompts.
+-- Applied only when database.postgres.vectorEnabled=true (AGENT_REGISTRY_DATABASE_VECTOR_ENABLED=true).
+
+ALTER TABLE skills
+    ADD COLUMN IF NOT EXISTS semantic_embedding vector(1536),
+    ADD COLUMN IF NOT EXISTS semantic_embedding_provider TEXT,
+    ADD COLUMN IF NOT EXISTS semantic_embedding_model TEXT,
+    ADD COLUMN IF NOT EXISTS semantic_embedding_dimensions INTEGER,
+    ADD COLUMN IF NOT EXISTS semantic_embedding_checksum TEXT,
+    ADD COLUMN IF NOT EXISTS semantic_embedding_generated_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_skills_semantic_embedding_hnsw ON skills USING hnsw (semantic_embedding vector_cosine_ops);
+
+ALTER TABLE prompts
+    ADD COLUMN IF NOT EXISTS semantic_embedding vector(1536),
+    ADD COLUMN IF NOT EXISTS semantic_embedding_provider TEXT,
+    ADD COLUMN IF NOT EXISTS semantic_embedding_model TEXT,
+    ADD COLUMN IF NOT EXISTS semantic_embedding_dimensions INTEGER,
+    ADD COLUMN IF NOT EXISTS semantic_embedding_checksum TEXT,
+    ADD COLUMN IF NOT EXISTS semantic_embedding_generated_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_prompts_semantic_embedding_hnsw ON prompts USING hnsw (semantic_embedding vector_cosine_ops);
@@ -4,10 +4,13 @@ import (
 	"context"
 	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -94,6 +97,33 @@ func NewPostgreSQL(ctx context.Context, connectionURI string, authz auth.Authori
 	}, nil
 }
 
+// readCondition returns a WHERE condition limiting column to the names rf
+// allows, with its argument at position argIndex. The condition is "" when rf is
+// unrestricted, and matches nothing when rf has no patterns.
+func readCondition(rf auth.ReadFilter, column string, argIndex int) (string, []any) {
+	if rf.Unrestricted {
+		return "", nil
+	}
+	if len(rf.Patterns) == 0 {
+		return "FALSE", nil
+	}
+	likePatterns := make([]string, 0, len(rf.Patterns))
+	for _, pattern := range rf.Patterns {
+		likePatterns = append(likePatterns, readPatternToLike(pattern))
+	}
+	return fmt.Sprintf("%s LIKE ANY($%d)", column, argIndex), []any{likePatterns}
+}
+
+// readPatternToLike converts a permission resource pattern ("name" or
+// "prefix*") to the equivalent LIKE pattern.
+func readPatternToLike(pattern string) string {
+	escape := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	if prefix, found := strings.CutSuffix(pattern, "*"); found {
+		return escape.Replace(prefix) + "%"
+	}
+	return escape.Replace(pattern)
+}
+
 func (db *PostgreSQL) ListServers(
 	ctx context.Context,
 	tx pgx.Tx,
@@ -156,6 +186,12 @@ func (db *PostgreSQL) ListServers(
 		}
 	}
 
+	if condition, conditionArgs := readCondition(db.authz.ReadFilter(ctx, auth.PermissionArtifactTypeServer), "server_name", argIndex); condition != "" {
+		whereConditions = append(whereConditions, condition)
+		args = append(args, conditionArgs...)
+		argIndex += len(conditionArgs)
+	}
+
 	if semanticActive {
 		whereConditions = append(whereConditions, "semantic_embedding IS NOT NULL")
 	}
@@ -1208,6 +1244,12 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 		}
 	}
 
+	if condition, conditionArgs := readCondition(db.authz.ReadFilter(ctx, auth.PermissionArtifactTypeAgent), "agent_name", argIndex); condition != "" {
+		whereConditions = append(whereConditions, condition)
+		args = append(args, conditionArgs...)
+		argIndex += len(conditionArgs)
+	}
+
 	if semanticActive {
 		whereConditions = append(whereConditions, "semantic_embedding IS NOT NULL")
 	}
@@ -1233,7 +1275,7 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 	}
 
 	selectClause := `
-		SELECT agent_name, version, status, published_at, updated_at, is_latest, value`
+		SELECT agent_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value`
 	orderClause := "ORDER BY agent_name, version"
 
 	if semanticActive {
@@ -1273,7 +1315,7 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 
 	var results []*models.AgentResponse
 	for rows.Next() {
-		var name, version, status string
+		var name, version, status, successor string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
 		var valueJSON []byte
@@ -1281,9 +1323,9 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 
 		var scanErr error
 		if semanticActive {
-			scanErr = rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON, &semanticScore)
+			scanErr = rows.Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON, &semanticScore)
 		} else {
-			scanErr = rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+			scanErr = rows.Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON)
 		}
 
 		if scanErr != nil {
@@ -1300,6 +1342,7 @@ func (db *PostgreSQL) ListAgents(ctx context.Context, tx pgx.Tx, filter *databas
 			Meta: models.AgentResponseMeta{
 				Official: &models.AgentRegistryExtensions{
 					Status:      status,
+					Successor:   successor,
 					PublishedAt: publishedAt,
 					UpdatedAt:   updatedAt,
 					IsLatest:    isLatest,
@@ -1339,17 +1382,17 @@ func (db *PostgreSQL) GetAgentByName(ctx context.Context, tx pgx.Tx, agentName s
 	}
 
 	query := `
-		SELECT agent_name, version, status, published_at, updated_at, is_latest, value
+		SELECT agent_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value
 		FROM agents
 		WHERE agent_name = $1 AND is_latest = true
 		ORDER BY published_at DESC
 		LIMIT 1
 	`
-	var name, version, status string
+	var name, version, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, agentName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, agentName).Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -1364,6 +1407,7 @@ func (db *PostgreSQL) GetAgentByName(ctx context.Context, tx pgx.Tx, agentName s
 		Meta: models.AgentResponseMeta{
 			Official: &models.AgentRegistryExtensions{
 				Status:      status,
+				Successor:   successor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -1386,16 +1430,16 @@ func (db *PostgreSQL) GetAgentByNameAndVersion(ctx context.Context, tx pgx.Tx, a
 	}
 
 	query := `
-		SELECT agent_name, version, status, published_at, updated_at, is_latest, value
+		SELECT agent_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value
 		FROM agents
 		WHERE agent_name = $1 AND version = $2
 		LIMIT 1
 	`
-	var name, vers, status string
+	var name, vers, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, agentName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, agentName, version).Scan(&name, &vers, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -1410,6 +1454,7 @@ func (db *PostgreSQL) GetAgentByNameAndVersion(ctx context.Context, tx pgx.Tx, a
 		Meta: models.AgentResponseMeta{
 			Official: &models.AgentRegistryExtensions{
 				Status:      status,
+				Successor:   successor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -1431,7 +1476,7 @@ func (db *PostgreSQL) GetAllVersionsByAgentName(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-		SELECT agent_name, version, status, published_at, updated_at, is_latest, value
+		SELECT agent_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value
 		FROM agents
 		WHERE agent_name = $1
 		ORDER BY published_at DESC
@@ -1443,11 +1488,11 @@ func (db *PostgreSQL) GetAllVersionsByAgentName(ctx context.Context, tx pgx.Tx,
 	defer rows.Close()
 	var results []*models.AgentResponse
 	for rows.Next() {
-		var name, version, status string
+		var name, version, status, successor string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
 		var valueJSON []byte
-		if err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+		if err := rows.Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 			return nil, fmt.Errorf("failed to scan agent row: %w", err)
 		}
 		var agentJSON models.AgentJSON
@@ -1459,6 +1504,7 @@ func (db *PostgreSQL) GetAllVersionsByAgentName(ctx context.Context, tx pgx.Tx,
 			Meta: models.AgentResponseMeta{
 				Official: &models.AgentRegistryExtensions{
 					Status:      status,
+					Successor:   successor,
 					PublishedAt: publishedAt,
 					UpdatedAt:   updatedAt,
 					IsLatest:    isLatest,
@@ -1546,12 +1592,12 @@ func (db *PostgreSQL) UpdateAgent(ctx context.Context, tx pgx.Tx, agentName, ver
 		UPDATE agents
 		SET value = $1, updated_at = NOW()
 		WHERE agent_name = $2 AND version = $3
-		RETURNING agent_name, version, status, published_at, updated_at, is_latest
+		RETURNING agent_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest
 	`
-	var name, vers, status string
+	var name, vers, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
-	if err := db.getExecutor(tx).QueryRow(ctx, query, valueJSON, agentName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, valueJSON, agentName, version).Scan(&name, &vers, &status, &successor, &publishedAt, &updatedAt, &isLatest); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -1562,6 +1608,7 @@ func (db *PostgreSQL) UpdateAgent(ctx context.Context, tx pgx.Tx, agentName, ver
 		Meta: models.AgentResponseMeta{
 			Official: &models.AgentRegistryExtensions{
 				Status:      status,
+				Successor:   successor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -1570,7 +1617,7 @@ func (db *PostgreSQL) UpdateAgent(ctx context.Context, tx pgx.Tx, agentName, ver
 	}, nil
 }
 
-func (db *PostgreSQL) SetAgentStatus(ctx context.Context, tx pgx.Tx, agentName, version string, status string) (*models.AgentResponse, error) {
+func (db *PostgreSQL) SetAgentStatus(ctx context.Context, tx pgx.Tx, agentName, version, status, successor string) (*models.AgentResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -1584,15 +1631,15 @@ func (db *PostgreSQL) SetAgentStatus(ctx context.Context, tx pgx.Tx, agentName,
 
 	query := `
 		UPDATE agents
-		SET status = $1, updated_at = NOW()
+		SET status = $1, successor = NULLIF($4, ''), updated_at = NOW()
 		WHERE agent_name = $2 AND version = $3
-		RETURNING agent_name, version, status, value, published_at, updated_at, is_latest
+		RETURNING agent_name, version, status, COALESCE(successor, ''), value, published_at, updated_at, is_latest
 	`
-	var name, vers, currentStatus string
+	var name, vers, currentStatus, currentSuccessor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, status, agentName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, status, agentName, version, successor).Scan(&name, &vers, &currentStatus, &currentSuccessor, &valueJSON, &publishedAt, &updatedAt, &isLatest); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -1607,6 +1654,7 @@ func (db *PostgreSQL) SetAgentStatus(ctx context.Context, tx pgx.Tx, agentName,
 		Meta: models.AgentResponseMeta{
 			Official: &models.AgentRegistryExtensions{
 				Status:      currentStatus,
+				Successor:   currentSuccessor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -1629,16 +1677,16 @@ func (db *PostgreSQL) GetCurrentLatestAgentVersion(ctx context.Context, tx pgx.T
 
 	executor := db.getExecutor(tx)
 	query := `
-		SELECT agent_name, version, status, value, published_at, updated_at, is_latest
+		SELECT agent_name, version, status, COALESCE(successor, ''), value, published_at, updated_at, is_latest
 		FROM agents
 		WHERE agent_name = $1 AND is_latest = true
 	`
 	row := executor.QueryRow(ctx, query, agentName)
-	var name, version, status string
+	var name, version, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var jsonValue []byte
-	if err := row.Scan(&name, &version, &status, &jsonValue, &publishedAt, &updatedAt, &isLatest); err != nil {
+	if err := row.Scan(&name, &version, &status, &successor, &jsonValue, &publishedAt, &updatedAt, &isLatest); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -1656,6 +1704,7 @@ func (db *PostgreSQL) GetCurrentLatestAgentVersion(ctx context.Context, tx pgx.T
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
 				Status:      status,
+				Successor:   successor,
 			},
 		},
 	}, nil
@@ -1880,6 +1929,16 @@ func (db *PostgreSQL) ListSkills(ctx context.Context, tx pgx.Tx, filter *databas
 		return nil, "", ctx.Err()
 	}
 
+	semanticActive := filter != nil && filter.Semantic != nil && len(filter.Semantic.QueryEmbedding) > 0
+	var semanticLiteral string
+	if semanticActive {
+		var err error
+		semanticLiteral, err = dbUtils.VectorLiteral(filter.Semantic.QueryEmbedding)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid semantic embedding: %w", err)
+		}
+	}
+
 	var whereConditions []string
 	args := []any{}
 	argIndex := 1
@@ -1917,7 +1976,17 @@ func (db *PostgreSQL) ListSkills(ctx context.Context, tx pgx.Tx, filter *databas
 		}
 	}
 
-	if cursor != "" {
+	if condition, conditionArgs := readCondition(db.authz.ReadFilter(ctx, auth.PermissionArtifactTypeSkill), "skill_name", argIndex); condition != "" {
+		whereConditions = append(whereConditions, condition)
+		args = append(args, conditionArgs...)
+		argIndex += len(conditionArgs)
+	}
+
+	if semanticActive {
+		whereConditions = append(whereConditions, "semantic_embedding IS NOT NULL")
+	}
+
+	if cursor != "" && !semanticActive {
 		parts := strings.SplitN(cursor, ":", 2)
 		if len(parts) == 2 {
 			cursorName := parts[0]
@@ -1937,13 +2006,37 @@ func (db *PostgreSQL) ListSkills(ctx context.Context, tx pgx.Tx, filter *databas
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
+	selectClause := `
+		SELECT skill_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value`
+	orderClause := "ORDER BY skill_name, version"
+
+	if semanticActive {
+		selectClause += fmt.Sprintf(", semantic_embedding <=> $%d::vector AS semantic_score", argIndex)
+		args = append(args, semanticLiteral)
+		vectorParamIdx := argIndex
+		argIndex++
+
+		if filter.Semantic.Threshold > 0 {
+			condition := fmt.Sprintf("semantic_embedding <=> $%d::vector <= $%d", vectorParamIdx, argIndex)
+			if whereClause == "" {
+				whereClause = "WHERE " + condition
+			} else {
+				whereClause += " AND " + condition
+			}
+			args = append(args, filter.Semantic.Threshold)
+			argIndex++
+		}
+
+		orderClause = "ORDER BY semantic_score ASC, skill_name, version"
+	}
+
 	query := fmt.Sprintf(`
-        SELECT skill_name, version, status, published_at, updated_at, is_latest, value
-        FROM skills
-        %s
-        ORDER BY skill_name, version
-        LIMIT $%d
-    `, whereClause, argIndex)
+		%s
+		FROM skills
+		%s
+		%s
+		LIMIT $%d
+	`, selectClause, whereClause, orderClause, argIndex)
 	args = append(args, limit)
 
 	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
@@ -1954,13 +2047,21 @@ func (db *PostgreSQL) ListSkills(ctx context.Context, tx pgx.Tx, filter *databas
 
 	var results []*models.SkillResponse
 	for rows.Next() {
-		var name, version, status string
+		var name, version, status, successor string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
 		var valueJSON []byte
+		var semanticScore sql.NullFloat64
+
+		var scanErr error
+		if semanticActive {
+			scanErr = rows.Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON, &semanticScore)
+		} else {
+			scanErr = rows.Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		}
 
-		if err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
-			return nil, "", fmt.Errorf("failed to scan skill row: %w", err)
+		if scanErr != nil {
+			return nil, "", fmt.Errorf("failed to scan skill row: %w", scanErr)
 		}
 
 		var skillJSON models.SkillJSON
@@ -1973,12 +2074,18 @@ func (db *PostgreSQL) ListSkills(ctx context.Context, tx pgx.Tx, filter *databas
 			Meta: models.SkillResponseMeta{
 				Official: &models.SkillRegistryExtensions{
 					Status:      status,
+					Successor:   successor,
 					PublishedAt: publishedAt,
 					UpdatedAt:   updatedAt,
 					IsLatest:    isLatest,
 				},
 			},
 		}
+		if semanticActive && semanticScore.Valid {
+			resp.Meta.Semantic = &models.SkillSemanticMeta{
+				Score: semanticScore.Float64,
+			}
+		}
 		results = append(results, resp)
 	}
 	if err := rows.Err(); err != nil {
@@ -1986,7 +2093,7 @@ func (db *PostgreSQL) ListSkills(ctx context.Context, tx pgx.Tx, filter *databas
 	}
 
 	nextCursor := ""
-	if len(results) > 0 && len(results) >= limit {
+	if !semanticActive && len(results) > 0 && len(results) >= limit {
 		last := results[len(results)-1]
 		nextCursor = last.Skill.Name + ":" + last.Skill.Version
 	}
@@ -2006,17 +2113,17 @@ func (db *PostgreSQL) GetSkillByName(ctx context.Context, tx pgx.Tx, skillName s
 	}
 
 	query := `
-        SELECT skill_name, version, status, published_at, updated_at, is_latest, value
+        SELECT skill_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value
         FROM skills
         WHERE skill_name = $1 AND is_latest = true
         ORDER BY published_at DESC
         LIMIT 1
     `
-	var name, version, status string
+	var name, version, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, skillName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, skillName).Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -2031,6 +2138,7 @@ func (db *PostgreSQL) GetSkillByName(ctx context.Context, tx pgx.Tx, skillName s
 		Meta: models.SkillResponseMeta{
 			Official: &models.SkillRegistryExtensions{
 				Status:      status,
+				Successor:   successor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -2052,16 +2160,16 @@ func (db *PostgreSQL) GetSkillByNameAndVersion(ctx context.Context, tx pgx.Tx, s
 	}
 
 	query := `
-        SELECT skill_name, version, status, published_at, updated_at, is_latest, value
+        SELECT skill_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value
         FROM skills
         WHERE skill_name = $1 AND version = $2
         LIMIT 1
     `
-	var name, vers, status string
+	var name, vers, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, skillName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, skillName, version).Scan(&name, &vers, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -2076,6 +2184,7 @@ func (db *PostgreSQL) GetSkillByNameAndVersion(ctx context.Context, tx pgx.Tx, s
 		Meta: models.SkillResponseMeta{
 			Official: &models.SkillRegistryExtensions{
 				Status:      status,
+				Successor:   successor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -2097,7 +2206,7 @@ func (db *PostgreSQL) GetAllVersionsBySkillName(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-        SELECT skill_name, version, status, published_at, updated_at, is_latest, value
+        SELECT skill_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value
         FROM skills
         WHERE skill_name = $1
         ORDER BY published_at DESC
@@ -2109,11 +2218,11 @@ func (db *PostgreSQL) GetAllVersionsBySkillName(ctx context.Context, tx pgx.Tx,
 	defer rows.Close()
 	var results []*models.SkillResponse
 	for rows.Next() {
-		var name, version, status string
+		var name, version, status, successor string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
 		var valueJSON []byte
-		if err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+		if err := rows.Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 			return nil, fmt.Errorf("failed to scan skill row: %w", err)
 		}
 		var skillJSON models.SkillJSON
@@ -2125,6 +2234,7 @@ func (db *PostgreSQL) GetAllVersionsBySkillName(ctx context.Context, tx pgx.Tx,
 			Meta: models.SkillResponseMeta{
 				Official: &models.SkillRegistryExtensions{
 					Status:      status,
+					Successor:   successor,
 					PublishedAt: publishedAt,
 					UpdatedAt:   updatedAt,
 					IsLatest:    isLatest,
@@ -2212,12 +2322,12 @@ func (db *PostgreSQL) UpdateSkill(ctx context.Context, tx pgx.Tx, skillName, ver
         UPDATE skills
         SET value = $1, updated_at = NOW()
         WHERE skill_name = $2 AND version = $3
-        RETURNING skill_name, version, status, published_at, updated_at, is_latest
+        RETURNING skill_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest
     `
-	var name, vers, status string
+	var name, vers, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
-	if err := db.getExecutor(tx).QueryRow(ctx, query, valueJSON, skillName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, valueJSON, skillName, version).Scan(&name, &vers, &status, &successor, &publishedAt, &updatedAt, &isLatest); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -2228,6 +2338,7 @@ func (db *PostgreSQL) UpdateSkill(ctx context.Context, tx pgx.Tx, skillName, ver
 		Meta: models.SkillResponseMeta{
 			Official: &models.SkillRegistryExtensions{
 				Status:      status,
+				Successor:   successor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -2236,7 +2347,7 @@ func (db *PostgreSQL) UpdateSkill(ctx context.Context, tx pgx.Tx, skillName, ver
 	}, nil
 }
 
-func (db *PostgreSQL) SetSkillStatus(ctx context.Context, tx pgx.Tx, skillName, version string, status string) (*models.SkillResponse, error) {
+func (db *PostgreSQL) SetSkillStatus(ctx context.Context, tx pgx.Tx, skillName, version, status, successor string) (*models.SkillResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
@@ -2250,15 +2361,15 @@ func (db *PostgreSQL) SetSkillStatus(ctx context.Context, tx pgx.Tx, skillName,
 
 	query := `
         UPDATE skills
-        SET status = $1, updated_at = NOW()
+        SET status = $1, successor = NULLIF($4, ''), updated_at = NOW()
         WHERE skill_name = $2 AND version = $3
-        RETURNING skill_name, version, status, value, published_at, updated_at, is_latest
+        RETURNING skill_name, version, status, COALESCE(successor, ''), value, published_at, updated_at, is_latest
     `
-	var name, vers, currentStatus string
+	var name, vers, currentStatus, currentSuccessor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, status, skillName, version).Scan(&name, &vers, &currentStatus, &valueJSON, &publishedAt, &updatedAt, &isLatest); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, status, skillName, version, successor).Scan(&name, &vers, &currentStatus, &currentSuccessor, &valueJSON, &publishedAt, &updatedAt, &isLatest); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -2273,6 +2384,7 @@ func (db *PostgreSQL) SetSkillStatus(ctx context.Context, tx pgx.Tx, skillName,
 		Meta: models.SkillResponseMeta{
 			Official: &models.SkillRegistryExtensions{
 				Status:      currentStatus,
+				Successor:   currentSuccessor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -2295,16 +2407,16 @@ func (db *PostgreSQL) GetCurrentLatestSkillVersion(ctx context.Context, tx pgx.T
 
 	executor := db.getExecutor(tx)
 	query := `
-        SELECT skill_name, version, status, value, published_at, updated_at, is_latest
+        SELECT skill_name, version, status, COALESCE(successor, ''), value, published_at, updated_at, is_latest
         FROM skills
         WHERE skill_name = $1 AND is_latest = true
     `
 	row := executor.QueryRow(ctx, query, skillName)
-	var name, version, status string
+	var name, version, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var jsonValue []byte
-	if err := row.Scan(&name, &version, &status, &jsonValue, &publishedAt, &updatedAt, &isLatest); err != nil {
+	if err := row.Scan(&name, &version, &status, &successor, &jsonValue, &publishedAt, &updatedAt, &isLatest); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -2322,6 +2434,7 @@ func (db *PostgreSQL) GetCurrentLatestSkillVersion(ctx context.Context, tx pgx.T
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
 				Status:      status,
+				Successor:   successor,
 			},
 		},
 	}, nil
@@ -2443,143 +2556,428 @@ func (db *PostgreSQL) DeleteSkill(ctx context.Context, tx pgx.Tx, skillName, ver
 	return nil
 }
 
-// CreateProvider creates a provider record.
-func (db *PostgreSQL) CreateProvider(ctx context.Context, tx pgx.Tx, in *models.CreateProviderInput) (*models.Provider, error) {
-	if in == nil {
-		return nil, database.ErrInvalidInput
+// CreateSkillBundle stores a skill bundle. Bundles are content addressed, so
+// storing a digest that already exists is a no-op.
+func (db *PostgreSQL) CreateSkillBundle(ctx context.Context, tx pgx.Tx, bundle *database.SkillBundle) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
-	if strings.TrimSpace(in.ID) == "" || strings.TrimSpace(in.Name) == "" || strings.TrimSpace(in.Platform) == "" {
-		return nil, database.ErrInvalidInput
+	if bundle == nil || bundle.Digest == "" || bundle.SkillName == "" {
+		return fmt.Errorf("skill bundle digest and skill name are required")
 	}
-	executor := db.getExecutor(tx)
-	configJSON, err := json.Marshal(in.Config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal provider config: %w", err)
+
+	if err := db.authz.Check(ctx, auth.PermissionActionPublish, auth.Resource{
+		Name: bundle.SkillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return err
 	}
+
+	if bundle.SizeBytes == 0 {
+		bundle.SizeBytes = len(bundle.Content)
+	}
+	if bundle.CreatedAt.IsZero() {
+		bundle.CreatedAt = time.Now()
+	}
+
 	query := `
-		INSERT INTO providers (id, name, platform, config)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, name, platform, COALESCE(config, '{}'::jsonb), created_at, updated_at
-	`
-	var provider models.Provider
-	var configOut []byte
-	err = executor.QueryRow(ctx, query, in.ID, in.Name, in.Platform, configJSON).Scan(
-		&provider.ID,
-		&provider.Name,
-		&provider.Platform,
-		&configOut,
-		&provider.CreatedAt,
-		&provider.UpdatedAt,
-	)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
-			return nil, database.ErrAlreadyExists
-		}
-		return nil, fmt.Errorf("failed to create provider: %w", err)
+        INSERT INTO skill_bundles (digest, skill_name, content, size_bytes, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (digest) DO NOTHING
+    `
+	if _, err := db.getExecutor(tx).Exec(ctx, query,
+		bundle.Digest,
+		bundle.SkillName,
+		bundle.Content,
+		bundle.SizeBytes,
+		bundle.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to insert skill bundle: %w", err)
 	}
-	if len(configOut) > 0 {
-		if err := json.Unmarshal(configOut, &provider.Config); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal provider config: %w", err)
+	return nil
+}
+
+// GetSkillBundle retrieves a skill bundle by digest. Reading it requires read
+// access to the skill it was uploaded for.
+func (db *PostgreSQL) GetSkillBundle(ctx context.Context, tx pgx.Tx, digest string) (*database.SkillBundle, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	query := `
+        SELECT digest, skill_name, content, size_bytes, created_at
+        FROM skill_bundles
+        WHERE digest = $1
+    `
+	var bundle database.SkillBundle
+	if err := db.getExecutor(tx).QueryRow(ctx, query, digest).Scan(
+		&bundle.Digest,
+		&bundle.SkillName,
+		&bundle.Content,
+		&bundle.SizeBytes,
+		&bundle.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
 		}
+		return nil, fmt.Errorf("failed to get skill bundle: %w", err)
 	}
-	if provider.Config == nil {
-		provider.Config = map[string]any{}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: bundle.SkillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return nil, err
 	}
-	return &provider, nil
+	return &bundle, nil
 }
 
-// ListProviders lists providers, optionally filtered by platform.
-func (db *PostgreSQL) ListProviders(ctx context.Context, tx pgx.Tx, platform *string) ([]*models.Provider, error) {
-	executor := db.getExecutor(tx)
-	query := `SELECT id, name, platform, COALESCE(config, '{}'::jsonb), created_at, updated_at FROM providers`
-	args := []any{}
-	if platform != nil && strings.TrimSpace(*platform) != "" {
-		query += ` WHERE platform = $1`
-		args = append(args, strings.TrimSpace(*platform))
+// skillBundleUnreferenced matches skill_bundles rows that no skill version
+// lists as a registry package.
+const skillBundleUnreferenced = `NOT EXISTS (
+            SELECT 1 FROM skills
+            WHERE skills.value->'packages' @> jsonb_build_array(jsonb_build_object('registryType', 'registry', 'identifier', skill_bundles.digest))
+        )`
+
+// DeleteUnreferencedSkillBundles deletes the bundles uploaded for a skill
+// before createdBefore that no version of any skill references. It requires
+// publish access to the skill.
+func (db *PostgreSQL) DeleteUnreferencedSkillBundles(ctx context.Context, tx pgx.Tx, skillName string, createdBefore time.Time) ([]string, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
-	query += ` ORDER BY created_at ASC`
-	rows, err := executor.Query(ctx, query, args...)
+	if err := db.authz.Check(ctx, auth.PermissionActionPublish, auth.Resource{
+		Name: skillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        DELETE FROM skill_bundles
+        WHERE skill_name = $1 AND created_at < $2 AND ` + skillBundleUnreferenced + `
+        RETURNING digest
+    `
+	rows, err := db.getExecutor(tx).Query(ctx, query, skillName, createdBefore)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list providers: %w", err)
+		return nil, fmt.Errorf("failed to delete unreferenced skill bundles: %w", err)
 	}
 	defer rows.Close()
-	var out []*models.Provider
+
+	var digests []string
 	for rows.Next() {
-		var p models.Provider
-		var configJSON []byte
-		if err := rows.Scan(&p.ID, &p.Name, &p.Platform, &configJSON, &p.CreatedAt, &p.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan provider: %w", err)
-		}
-		if len(configJSON) > 0 {
-			if err := json.Unmarshal(configJSON, &p.Config); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal provider config: %w", err)
-			}
-		}
-		if p.Config == nil {
-			p.Config = map[string]any{}
+		var digest string
+		if err := rows.Scan(&digest); err != nil {
+			return nil, fmt.Errorf("failed to scan skill bundle digest: %w", err)
 		}
-		out = append(out, &p)
+		digests = append(digests, digest)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("failed to iterate providers: %w", err)
+		return nil, fmt.Errorf("failed to delete unreferenced skill bundles: %w", err)
 	}
-	return out, nil
+	return digests, nil
 }
 
-// GetProviderByID gets a provider by ID.
-func (db *PostgreSQL) GetProviderByID(ctx context.Context, tx pgx.Tx, providerID string) (*models.Provider, error) {
-	executor := db.getExecutor(tx)
-	query := `SELECT id, name, platform, COALESCE(config, '{}'::jsonb), created_at, updated_at FROM providers WHERE id = $1`
-	var p models.Provider
-	var configJSON []byte
-	if err := executor.QueryRow(ctx, query, providerID).Scan(&p.ID, &p.Name, &p.Platform, &configJSON, &p.CreatedAt, &p.UpdatedAt); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, database.ErrNotFound
-		}
-		return nil, fmt.Errorf("failed to get provider: %w", err)
+// CountUnreferencedSkillBundles counts the bundles uploaded for a skill that
+// no version of any skill references. It requires publish access to the skill.
+func (db *PostgreSQL) CountUnreferencedSkillBundles(ctx context.Context, tx pgx.Tx, skillName string) (int, error) {
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
 	}
-	if len(configJSON) > 0 {
-		if err := json.Unmarshal(configJSON, &p.Config); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal provider config: %w", err)
-		}
+	if err := db.authz.Check(ctx, auth.PermissionActionPublish, auth.Resource{
+		Name: skillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return 0, err
 	}
-	if p.Config == nil {
-		p.Config = map[string]any{}
+
+	query := `SELECT COUNT(*) FROM skill_bundles WHERE skill_name = $1 AND ` + skillBundleUnreferenced
+	var count int
+	if err := db.getExecutor(tx).QueryRow(ctx, query, skillName).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unreferenced skill bundles: %w", err)
 	}
-	return &p, nil
+	return count, nil
 }
 
-// UpdateProvider updates mutable provider fields.
-func (db *PostgreSQL) UpdateProvider(ctx context.Context, tx pgx.Tx, providerID string, in *models.UpdateProviderInput) (*models.Provider, error) {
-	if in == nil {
-		return db.GetProviderByID(ctx, tx, providerID)
-	}
-	current, err := db.GetProviderByID(ctx, tx, providerID)
-	if err != nil {
-		return nil, err
-	}
-	name := current.Name
-	if in.Name != nil {
-		name = *in.Name
-	}
-	config := current.Config
-	if in.Config != nil {
-		config = in.Config
+// SetSkillEmbedding stores semantic embedding metadata for a skill version.
+func (db *PostgreSQL) SetSkillEmbedding(ctx context.Context, tx pgx.Tx, skillName, version string, embedding *database.SemanticEmbedding) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
-	configJSON, err := json.Marshal(config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal provider config: %w", err)
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: skillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return err
 	}
+
 	executor := db.getExecutor(tx)
-	query := `
-		UPDATE providers
-		SET name = $2, config = $3, updated_at = NOW()
-		WHERE id = $1
-		RETURNING id, name, platform, COALESCE(config, '{}'::jsonb), created_at, updated_at
-	`
-	var p models.Provider
-	var configOut []byte
+
+	var (
+		query string
+		args  []any
+	)
+
+	if embedding == nil || len(embedding.Vector) == 0 {
+		query = `
+			UPDATE skills
+			SET semantic_embedding = NULL,
+			    semantic_embedding_provider = NULL,
+			    semantic_embedding_model = NULL,
+			    semantic_embedding_dimensions = NULL,
+			    semantic_embedding_checksum = NULL,
+			    semantic_embedding_generated_at = NULL
+			WHERE skill_name = $1 AND version = $2
+		`
+		args = []any{skillName, version}
+	} else {
+		vectorLiteral, err := dbUtils.VectorLiteral(embedding.Vector)
+		if err != nil {
+			return err
+		}
+		query = `
+			UPDATE skills
+			SET semantic_embedding = $3::vector,
+			    semantic_embedding_provider = $4,
+			    semantic_embedding_model = $5,
+			    semantic_embedding_dimensions = $6,
+			    semantic_embedding_checksum = $7,
+			    semantic_embedding_generated_at = $8
+			WHERE skill_name = $1 AND version = $2
+		`
+		args = []any{
+			skillName,
+			version,
+			vectorLiteral,
+			embedding.Provider,
+			embedding.Model,
+			embedding.Dimensions,
+			embedding.Checksum,
+			embedding.Generated,
+		}
+	}
+
+	result, err := executor.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update skill embedding: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+// GetSkillEmbeddingMetadata retrieves embedding metadata for a skill version without loading the vector.
+func (db *PostgreSQL) GetSkillEmbeddingMetadata(ctx context.Context, tx pgx.Tx, skillName, version string) (*database.SemanticEmbeddingMetadata, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: skillName,
+		Type: auth.PermissionArtifactTypeSkill,
+	}); err != nil {
+		return nil, err
+	}
+
+	executor := db.getExecutor(tx)
+	query := `
+		SELECT
+			semantic_embedding IS NOT NULL AS has_embedding,
+			semantic_embedding_provider,
+			semantic_embedding_model,
+			semantic_embedding_dimensions,
+			semantic_embedding_checksum,
+			semantic_embedding_generated_at
+		FROM skills
+		WHERE skill_name = $1 AND version = $2
+		LIMIT 1
+	`
+
+	var (
+		hasEmbedding bool
+		provider     sql.NullString
+		model        sql.NullString
+		dimensions   sql.NullInt32
+		checksum     sql.NullString
+		generatedAt  sql.NullTime
+	)
+
+	err := executor.QueryRow(ctx, query, skillName, version).Scan(
+		&hasEmbedding,
+		&provider,
+		&model,
+		&dimensions,
+		&checksum,
+		&generatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch skill embedding metadata: %w", err)
+	}
+
+	meta := &database.SemanticEmbeddingMetadata{
+		HasEmbedding: hasEmbedding,
+	}
+	if provider.Valid {
+		meta.Provider = provider.String
+	}
+	if model.Valid {
+		meta.Model = model.String
+	}
+	if dimensions.Valid {
+		meta.Dimensions = int(dimensions.Int32)
+	}
+	if checksum.Valid {
+		meta.Checksum = checksum.String
+	}
+	if generatedAt.Valid {
+		meta.Generated = generatedAt.Time
+	}
+
+	return meta, nil
+}
+
+// CreateProvider creates a provider record.
+func (db *PostgreSQL) CreateProvider(ctx context.Context, tx pgx.Tx, in *models.CreateProviderInput) (*models.Provider, error) {
+	if in == nil {
+		return nil, database.ErrInvalidInput
+	}
+	if strings.TrimSpace(in.ID) == "" || strings.TrimSpace(in.Name) == "" || strings.TrimSpace(in.Platform) == "" {
+		return nil, database.ErrInvalidInput
+	}
+	executor := db.getExecutor(tx)
+	configJSON, err := json.Marshal(in.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal provider config: %w", err)
+	}
+	query := `
+		INSERT INTO providers (id, name, platform, config)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, name, platform, COALESCE(config, '{}'::jsonb), created_at, updated_at
+	`
+	var provider models.Provider
+	var configOut []byte
+	err = executor.QueryRow(ctx, query, in.ID, in.Name, in.Platform, configJSON).Scan(
+		&provider.ID,
+		&provider.Name,
+		&provider.Platform,
+		&configOut,
+		&provider.CreatedAt,
+		&provider.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, database.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create provider: %w", err)
+	}
+	if len(configOut) > 0 {
+		if err := json.Unmarshal(configOut, &provider.Config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal provider config: %w", err)
+		}
+	}
+	if provider.Config == nil {
+		provider.Config = map[string]any{}
+	}
+	return &provider, nil
+}
+
+// ListProviders lists providers, optionally filtered by platform.
+func (db *PostgreSQL) ListProviders(ctx context.Context, tx pgx.Tx, platform *string) ([]*models.Provider, error) {
+	executor := db.getExecutor(tx)
+	query := `SELECT id, name, platform, COALESCE(config, '{}'::jsonb), created_at, updated_at FROM providers`
+	args := []any{}
+	if platform != nil && strings.TrimSpace(*platform) != "" {
+		query += ` WHERE platform = $1`
+		args = append(args, strings.TrimSpace(*platform))
+	}
+	query += ` ORDER BY created_at ASC`
+	rows, err := executor.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list providers: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.Provider
+	for rows.Next() {
+		var p models.Provider
+		var configJSON []byte
+		if err := rows.Scan(&p.ID, &p.Name, &p.Platform, &configJSON, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan provider: %w", err)
+		}
+		if len(configJSON) > 0 {
+			if err := json.Unmarshal(configJSON, &p.Config); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal provider config: %w", err)
+			}
+		}
+		if p.Config == nil {
+			p.Config = map[string]any{}
+		}
+		out = append(out, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate providers: %w", err)
+	}
+	return out, nil
+}
+
+// GetProviderByID gets a provider by ID.
+func (db *PostgreSQL) GetProviderByID(ctx context.Context, tx pgx.Tx, providerID string) (*models.Provider, error) {
+	executor := db.getExecutor(tx)
+	query := `SELECT id, name, platform, COALESCE(config, '{}'::jsonb), created_at, updated_at FROM providers WHERE id = $1`
+	var p models.Provider
+	var configJSON []byte
+	if err := executor.QueryRow(ctx, query, providerID).Scan(&p.ID, &p.Name, &p.Platform, &configJSON, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get provider: %w", err)
+	}
+	if len(configJSON) > 0 {
+		if err := json.Unmarshal(configJSON, &p.Config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal provider config: %w", err)
+		}
+	}
+	if p.Config == nil {
+		p.Config = map[string]any{}
+	}
+	return &p, nil
+}
+
+// UpdateProvider updates mutable provider fields.
+func (db *PostgreSQL) UpdateProvider(ctx context.Context, tx pgx.Tx, providerID string, in *models.UpdateProviderInput) (*models.Provider, error) {
+	if in == nil {
+		return db.GetProviderByID(ctx, tx, providerID)
+	}
+	current, err := db.GetProviderByID(ctx, tx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	name := current.Name
+	if in.Name != nil {
+		name = *in.Name
+	}
+	config := current.Config
+	if in.Config != nil {
+		config = in.Config
+	}
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal provider config: %w", err)
+	}
+	executor := db.getExecutor(tx)
+	query := `
+		UPDATE providers
+		SET name = $2, config = $3, updated_at = NOW()
+		WHERE id = $1
+		RETURNING id, name, platform, COALESCE(config, '{}'::jsonb), created_at, updated_at
+	`
+	var p models.Provider
+	var configOut []byte
 	if err := executor.QueryRow(ctx, query, providerID, name, configJSON).Scan(&p.ID, &p.Name, &p.Platform, &configOut, &p.CreatedAt, &p.UpdatedAt); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
@@ -2630,6 +3028,14 @@ func (db *PostgreSQL) CreateDeployment(ctx context.Context, tx pgx.Tx, deploymen
 	if err != nil {
 		return fmt.Errorf("failed to marshal deployment env: %w", err)
 	}
+	secretRefs := deployment.SecretRefs
+	if secretRefs == nil {
+		secretRefs = map[string]string{}
+	}
+	secretRefsJSON, err := json.Marshal(secretRefs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal deployment secret refs: %w", err)
+	}
 
 	providerConfigJSON, err := json.Marshal(deployment.ProviderConfig)
 	if err != nil {
@@ -2662,9 +3068,9 @@ func (db *PostgreSQL) CreateDeployment(ctx context.Context, tx pgx.Tx, deploymen
 	query := `
 		INSERT INTO deployments (
 			id, server_name, version, status, config, prefer_remote, resource_type,
-			origin, provider_id, provider_config, provider_metadata, error
+			origin, provider_id, provider_config, provider_metadata, error, secret_refs
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, $11, $12, $13)
 	`
 
 	_, err = executor.Exec(ctx, query,
@@ -2680,6 +3086,7 @@ func (db *PostgreSQL) CreateDeployment(ctx context.Context, tx pgx.Tx, deploymen
 		providerConfigJSON,
 		providerMetadataJSON,
 		deployment.Error,
+		secretRefsJSON,
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -2700,7 +3107,8 @@ func (db *PostgreSQL) GetDeployments(ctx context.Context, tx pgx.Tx, filter *mod
 
 	query := `SELECT
 			d.id, d.server_name, d.version, d.deployed_at, d.updated_at, d.status, d.config, d.prefer_remote, d.resource_type,
-			d.origin, COALESCE(d.provider_id, ''), COALESCE(d.provider_config, '{}'::jsonb), COALESCE(d.provider_metadata, '{}'::jsonb), COALESCE(d.error, '')
+			d.origin, COALESCE(d.provider_id, ''), COALESCE(d.provider_config, '{}'::jsonb), COALESCE(d.provider_metadata, '{}'::jsonb), COALESCE(d.error, ''),
+			d.secret_refs, d.health
 		FROM deployments d`
 	if needsProviderJoin {
 		query += ` LEFT JOIN providers p ON p.id = d.provider_id`
@@ -2722,6 +3130,8 @@ func (db *PostgreSQL) GetDeployments(ctx context.Context, tx pgx.Tx, filter *mod
 		var envJSON []byte
 		var providerConfigJSON []byte
 		var providerMetadataJSON []byte
+		var secretRefsJSON []byte
+		var healthJSON []byte
 
 		err := rows.Scan(
 			&d.ID,
@@ -2738,6 +3148,8 @@ func (db *PostgreSQL) GetDeployments(ctx context.Context, tx pgx.Tx, filter *mod
 			&providerConfigJSON,
 			&providerMetadataJSON,
 			&d.Error,
+			&secretRefsJSON,
+			&healthJSON,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan deployment: %w", err)
@@ -2757,7 +3169,15 @@ func (db *PostgreSQL) GetDeployments(ctx context.Context, tx pgx.Tx, filter *mod
 		if err := json.Unmarshal(providerMetadataJSON, &d.ProviderMetadata); err != nil {
 			return nil, fmt.Errorf("failed to scan provider metadata: %w", err)
 		}
-
+		if err := json.Unmarshal(secretRefsJSON, &d.SecretRefs); err != nil {
+			return nil, fmt.Errorf("failed to scan deployment secret refs: %w", err)
+		}
+		if len(healthJSON) > 0 {
+			if err := json.Unmarshal(healthJSON, &d.Health); err != nil {
+				return nil, fmt.Errorf("failed to scan deployment health: %w", err)
+			}
+		}
+
 		deployments = append(deployments, &d)
 	}
 
@@ -2817,7 +3237,8 @@ func (db *PostgreSQL) GetDeploymentByID(ctx context.Context, tx pgx.Tx, id strin
 	executor := db.getExecutor(tx)
 	query := `SELECT
 			id, server_name, version, deployed_at, updated_at, status, config, prefer_remote, resource_type,
-			origin, COALESCE(provider_id, ''), COALESCE(provider_config, '{}'::jsonb), COALESCE(provider_metadata, '{}'::jsonb), COALESCE(error, '')
+			origin, COALESCE(provider_id, ''), COALESCE(provider_config, '{}'::jsonb), COALESCE(provider_metadata, '{}'::jsonb), COALESCE(error, ''),
+			secret_refs, health
 		FROM deployments
 		WHERE id = $1`
 
@@ -2825,6 +3246,8 @@ func (db *PostgreSQL) GetDeploymentByID(ctx context.Context, tx pgx.Tx, id strin
 	var envJSON []byte
 	var providerConfigJSON []byte
 	var providerMetadataJSON []byte
+	var secretRefsJSON []byte
+	var healthJSON []byte
 	err := executor.QueryRow(ctx, query, id).Scan(
 		&d.ID,
 		&d.ServerName,
@@ -2840,6 +3263,8 @@ func (db *PostgreSQL) GetDeploymentByID(ctx context.Context, tx pgx.Tx, id strin
 		&providerConfigJSON,
 		&providerMetadataJSON,
 		&d.Error,
+		&secretRefsJSON,
+		&healthJSON,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -2861,6 +3286,14 @@ func (db *PostgreSQL) GetDeploymentByID(ctx context.Context, tx pgx.Tx, id strin
 	if err := json.Unmarshal(providerMetadataJSON, &d.ProviderMetadata); err != nil {
 		return nil, fmt.Errorf("failed to scan provider metadata: %w", err)
 	}
+	if err := json.Unmarshal(secretRefsJSON, &d.SecretRefs); err != nil {
+		return nil, fmt.Errorf("failed to scan deployment secret refs: %w", err)
+	}
+	if len(healthJSON) > 0 {
+		if err := json.Unmarshal(healthJSON, &d.Health); err != nil {
+			return nil, fmt.Errorf("failed to scan deployment health: %w", err)
+		}
+	}
 	artifactType := auth.PermissionArtifactTypeServer
 	if d.ResourceType == "agent" {
 		artifactType = auth.PermissionArtifactTypeAgent
@@ -2888,7 +3321,12 @@ func (db *PostgreSQL) UpdateDeploymentState(ctx context.Context, tx pgx.Tx, id s
 	if deployment.ResourceType == "agent" {
 		artifactType = auth.PermissionArtifactTypeAgent
 	}
-	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+	// Changing what is deployed needs the same permission as deploying it.
+	action := auth.PermissionActionEdit
+	if patch.HasSpecChanges() {
+		action = auth.PermissionActionDeploy
+	}
+	if err := db.authz.Check(ctx, action, auth.Resource{
 		Name: deployment.ServerName,
 		Type: artifactType,
 	}); err != nil {
@@ -2926,6 +3364,48 @@ func (db *PostgreSQL) UpdateDeploymentState(ctx context.Context, tx pgx.Tx, id s
 		}
 	}
 
+	setVersion := patch.Version != nil
+	versionValue := deployment.Version
+	if patch.Version != nil {
+		versionValue = *patch.Version
+	}
+
+	setEnv := patch.Env != nil
+	envJSON := []byte("{}")
+	if patch.Env != nil && *patch.Env != nil {
+		envJSON, err = json.Marshal(*patch.Env)
+		if err != nil {
+			return fmt.Errorf("failed to marshal env patch: %w", err)
+		}
+	}
+
+	setSecretRefs := patch.SecretRefs != nil
+	secretRefsJSON := []byte("{}")
+	if patch.SecretRefs != nil && *patch.SecretRefs != nil {
+		secretRefsJSON, err = json.Marshal(*patch.SecretRefs)
+		if err != nil {
+			return fmt.Errorf("failed to marshal secret refs patch: %w", err)
+		}
+	}
+
+	setPreferRemote := patch.PreferRemote != nil
+	preferRemoteValue := deployment.PreferRemote
+	if patch.PreferRemote != nil {
+		preferRemoteValue = *patch.PreferRemote
+	}
+
+	setHealth := patch.Health != nil
+	healthJSON := []byte("null")
+	if patch.Health != nil {
+		healthJSON, err = json.Marshal(patch.Health)
+		if err != nil {
+			return fmt.Errorf("failed to marshal health patch: %w", err)
+		}
+	}
+	// Probe results are runtime observations, not changes to the deployment.
+	onlyHealth := setHealth && !setStatus && !setError && !setProviderConfig && !setProviderMetadata &&
+		!setVersion && !setEnv && !setSecretRefs && !setPreferRemote
+
 	query := `
 		UPDATE deployments
 		SET
@@ -2933,8 +3413,15 @@ func (db *PostgreSQL) UpdateDeploymentState(ctx context.Context, tx pgx.Tx, id s
 			error = CASE WHEN $4 THEN $5 ELSE error END,
 			provider_config = CASE WHEN $6 THEN $7::jsonb ELSE provider_config END,
 			provider_metadata = CASE WHEN $8 THEN $9::jsonb ELSE provider_metadata END,
-			updated_at = NOW()
+			version = CASE WHEN $10 THEN $11 ELSE version END,
+			config = CASE WHEN $12 THEN $13::jsonb ELSE config END,
+			secret_refs = CASE WHEN $14 THEN $15::jsonb ELSE secret_refs END,
+			prefer_remote = CASE WHEN $16 THEN $17 ELSE prefer_remote END,
+			health = CASE WHEN $18 THEN $19::jsonb ELSE health END,
+			updated_at = CASE WHEN $20 THEN updated_at ELSE NOW() END
 		WHERE id = $1
+			AND ($21::text IS NULL OR status <> $21)
+			AND ($22::timestamptz IS NULL OR updated_at = $22)
 	`
 
 	result, err := executor.Exec(
@@ -2949,12 +3436,28 @@ func (db *PostgreSQL) UpdateDeploymentState(ctx context.Context, tx pgx.Tx, id s
 		string(providerConfigJSON),
 		setProviderMetadata,
 		string(providerMetadataJSON),
+		setVersion,
+		versionValue,
+		setEnv,
+		string(envJSON),
+		setSecretRefs,
+		string(secretRefsJSON),
+		setPreferRemote,
+		preferRemoteValue,
+		setHealth,
+		string(healthJSON),
+		onlyHealth,
+		patch.UnlessStatus,
+		patch.IfUpdatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update deployment state: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
+		if patch.UnlessStatus != nil || patch.IfUpdatedAt != nil {
+			return fmt.Errorf("%w: deployment %s", database.ErrConflict, id)
+		}
 		return database.ErrNotFound
 	}
 
@@ -2991,6 +3494,190 @@ func (db *PostgreSQL) RemoveDeploymentByID(ctx context.Context, tx pgx.Tx, id st
 	return nil
 }
 
+// CheckDeployPermission checks the deploy permission on a deployment's artifact
+// for platform changes that are not made through the deployments table, such as
+// cancelling an in-flight deploy.
+func (db *PostgreSQL) CheckDeployPermission(ctx context.Context, tx pgx.Tx, id string) error {
+	deployment, err := db.GetDeploymentByID(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	artifactType := auth.PermissionArtifactTypeServer
+	if deployment.ResourceType == "agent" {
+		artifactType = auth.PermissionArtifactTypeAgent
+	}
+	return db.authz.Check(ctx, auth.PermissionActionDeploy, auth.Resource{
+		Name: deployment.ServerName,
+		Type: artifactType,
+	})
+}
+
+const deploymentRevisionColumns = `deployment_id, revision, action, resource_type, server_name, version,
+	COALESCE(provider_id, ''), env_keys, secret_refs, provider_config, prefer_remote, status, COALESCE(error, ''),
+	COALESCE(source_revision, 0), created_at`
+
+func scanDeploymentRevision(row pgx.Row) (*models.DeploymentRevision, error) {
+	var rev models.DeploymentRevision
+	var envKeysJSON, secretRefsJSON, providerConfigJSON []byte
+	err := row.Scan(
+		&rev.DeploymentID,
+		&rev.Revision,
+		&rev.Action,
+		&rev.ResourceType,
+		&rev.ServerName,
+		&rev.Version,
+		&rev.ProviderID,
+		&envKeysJSON,
+		&secretRefsJSON,
+		&providerConfigJSON,
+		&rev.PreferRemote,
+		&rev.Status,
+		&rev.Error,
+		&rev.SourceRevision,
+		&rev.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan deployment revision: %w", err)
+	}
+	if err := json.Unmarshal(envKeysJSON, &rev.EnvKeys); err != nil {
+		return nil, fmt.Errorf("failed to scan deployment revision env keys: %w", err)
+	}
+	if err := json.Unmarshal(secretRefsJSON, &rev.SecretRefs); err != nil {
+		return nil, fmt.Errorf("failed to scan deployment revision secret refs: %w", err)
+	}
+	if err := json.Unmarshal(providerConfigJSON, &rev.ProviderConfig); err != nil {
+		return nil, fmt.Errorf("failed to scan deployment revision provider config: %w", err)
+	}
+	return &rev, nil
+}
+
+// checkDeploymentRevisionRead checks that the caller can read the resource a
+// deployment revision applied.
+func (db *PostgreSQL) checkDeploymentRevisionRead(ctx context.Context, rev *models.DeploymentRevision) error {
+	artifactType := auth.PermissionArtifactTypeServer
+	if rev.ResourceType == "agent" {
+		artifactType = auth.PermissionArtifactTypeAgent
+	}
+	return db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: rev.ServerName,
+		Type: artifactType,
+	})
+}
+
+// AppendDeploymentRevision records the next revision of a deployment. It must
+// run in the transaction that changes the deployment's state: the deployment
+// row is locked so that concurrent appends are numbered one after the other.
+func (db *PostgreSQL) AppendDeploymentRevision(ctx context.Context, tx pgx.Tx, rev *models.DeploymentRevision) error {
+	if rev == nil || rev.DeploymentID == "" || rev.Action == "" || rev.ServerName == "" || rev.Status == "" {
+		return database.ErrInvalidInput
+	}
+	if tx == nil {
+		return db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			return db.AppendDeploymentRevision(ctx, tx, rev)
+		})
+	}
+	envKeys := slices.Sorted(slices.Values(rev.EnvKeys))
+	if envKeys == nil {
+		envKeys = []string{}
+	}
+	secretRefs := rev.SecretRefs
+	if secretRefs == nil {
+		secretRefs = map[string]string{}
+	}
+	providerConfig := rev.ProviderConfig
+	if providerConfig == nil {
+		providerConfig = models.JSONObject{}
+	}
+	envKeysJSON, err := json.Marshal(envKeys)
+	if err != nil {
+		return fmt.Errorf("failed to marshal deployment revision env keys: %w", err)
+	}
+	secretRefsJSON, err := json.Marshal(secretRefs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal deployment revision secret refs: %w", err)
+	}
+	providerConfigJSON, err := json.Marshal(providerConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal deployment revision provider config: %w", err)
+	}
+
+	var locked string
+	err = tx.QueryRow(ctx, `SELECT id FROM deployments WHERE id = $1 FOR UPDATE`, rev.DeploymentID).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return database.ErrNotFound
+		}
+		return fmt.Errorf("failed to lock deployment for revision: %w", err)
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO deployment_revisions (deployment_id, revision, action, resource_type, server_name, version,
+			provider_id, env_keys, secret_refs, provider_config, prefer_remote, status, error, source_revision)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2::text, $3::text, $4::text, $5::text, NULLIF($6::text, ''),
+			$7::jsonb, $8::jsonb, $9::jsonb, $10::boolean, $11::text, NULLIF($12::text, ''), NULLIF($13::integer, 0)
+		FROM deployment_revisions
+		WHERE deployment_id = $1
+		RETURNING revision, created_at`,
+		rev.DeploymentID, rev.Action, rev.ResourceType, rev.ServerName, rev.Version,
+		rev.ProviderID, envKeysJSON, secretRefsJSON, providerConfigJSON, rev.PreferRemote, rev.Status, rev.Error, rev.SourceRevision,
+	).Scan(&rev.Revision, &rev.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to append deployment revision: %w", err)
+	}
+	rev.EnvKeys = envKeys
+	return nil
+}
+
+// ListDeploymentRevisions lists a deployment's revisions, oldest first.
+func (db *PostgreSQL) ListDeploymentRevisions(ctx context.Context, tx pgx.Tx, deploymentID string) ([]*models.DeploymentRevision, error) {
+	rows, err := db.getExecutor(tx).Query(ctx, `
+		SELECT `+deploymentRevisionColumns+`
+		FROM deployment_revisions
+		WHERE deployment_id = $1
+		ORDER BY revision ASC`, deploymentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*models.DeploymentRevision
+	for rows.Next() {
+		rev, err := scanDeploymentRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating deployment revisions: %w", err)
+	}
+	if len(revisions) == 0 {
+		return nil, database.ErrNotFound
+	}
+	// Every revision of a deployment applies the same resource.
+	if err := db.checkDeploymentRevisionRead(ctx, revisions[0]); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetDeploymentRevision retrieves one revision of a deployment.
+func (db *PostgreSQL) GetDeploymentRevision(ctx context.Context, tx pgx.Tx, deploymentID string, revision int) (*models.DeploymentRevision, error) {
+	rev, err := scanDeploymentRevision(db.getExecutor(tx).QueryRow(ctx, `
+		SELECT `+deploymentRevisionColumns+`
+		FROM deployment_revisions
+		WHERE deployment_id = $1 AND revision = $2`, deploymentID, revision))
+	if err != nil {
+		return nil, err
+	}
+	if err := db.checkDeploymentRevisionRead(ctx, rev); err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
 // DeleteAgent permanently removes an agent version from the database.
 // If the deleted version was the current latest, the most recently published
 // remaining version is promoted to latest.
@@ -3053,6 +3740,16 @@ func (db *PostgreSQL) ListPrompts(ctx context.Context, tx pgx.Tx, filter *databa
 		return nil, "", ctx.Err()
 	}
 
+	semanticActive := filter != nil && filter.Semantic != nil && len(filter.Semantic.QueryEmbedding) > 0
+	var semanticLiteral string
+	if semanticActive {
+		var err error
+		semanticLiteral, err = dbUtils.VectorLiteral(filter.Semantic.QueryEmbedding)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid semantic embedding: %w", err)
+		}
+	}
+
 	var whereConditions []string
 	args := []any{}
 	argIndex := 1
@@ -3085,7 +3782,17 @@ func (db *PostgreSQL) ListPrompts(ctx context.Context, tx pgx.Tx, filter *databa
 		}
 	}
 
-	if cursor != "" {
+	if condition, conditionArgs := readCondition(db.authz.ReadFilter(ctx, auth.PermissionArtifactTypePrompt), "prompt_name", argIndex); condition != "" {
+		whereConditions = append(whereConditions, condition)
+		args = append(args, conditionArgs...)
+		argIndex += len(conditionArgs)
+	}
+
+	if semanticActive {
+		whereConditions = append(whereConditions, "semantic_embedding IS NOT NULL")
+	}
+
+	if cursor != "" && !semanticActive {
 		parts := strings.SplitN(cursor, ":", 2)
 		if len(parts) == 2 {
 			cursorName := parts[0]
@@ -3105,13 +3812,37 @@ func (db *PostgreSQL) ListPrompts(ctx context.Context, tx pgx.Tx, filter *databa
 		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
 	}
 
+	selectClause := `
+		SELECT prompt_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value`
+	orderClause := "ORDER BY prompt_name, version"
+
+	if semanticActive {
+		selectClause += fmt.Sprintf(", semantic_embedding <=> $%d::vector AS semantic_score", argIndex)
+		args = append(args, semanticLiteral)
+		vectorParamIdx := argIndex
+		argIndex++
+
+		if filter.Semantic.Threshold > 0 {
+			condition := fmt.Sprintf("semantic_embedding <=> $%d::vector <= $%d", vectorParamIdx, argIndex)
+			if whereClause == "" {
+				whereClause = "WHERE " + condition
+			} else {
+				whereClause += " AND " + condition
+			}
+			args = append(args, filter.Semantic.Threshold)
+			argIndex++
+		}
+
+		orderClause = "ORDER BY semantic_score ASC, prompt_name, version"
+	}
+
 	query := fmt.Sprintf(`
-        SELECT prompt_name, version, status, published_at, updated_at, is_latest, value
-        FROM prompts
-        %s
-        ORDER BY prompt_name, version
-        LIMIT $%d
-    `, whereClause, argIndex)
+		%s
+		FROM prompts
+		%s
+		%s
+		LIMIT $%d
+	`, selectClause, whereClause, orderClause, argIndex)
 	args = append(args, limit)
 
 	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
@@ -3122,13 +3853,21 @@ func (db *PostgreSQL) ListPrompts(ctx context.Context, tx pgx.Tx, filter *databa
 
 	var results []*models.PromptResponse
 	for rows.Next() {
-		var name, version, status string
+		var name, version, status, successor string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
 		var valueJSON []byte
+		var semanticScore sql.NullFloat64
+
+		var scanErr error
+		if semanticActive {
+			scanErr = rows.Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON, &semanticScore)
+		} else {
+			scanErr = rows.Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON)
+		}
 
-		if err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
-			return nil, "", fmt.Errorf("failed to scan prompt row: %w", err)
+		if scanErr != nil {
+			return nil, "", fmt.Errorf("failed to scan prompt row: %w", scanErr)
 		}
 
 		var promptJSON models.PromptJSON
@@ -3141,12 +3880,18 @@ func (db *PostgreSQL) ListPrompts(ctx context.Context, tx pgx.Tx, filter *databa
 			Meta: models.PromptResponseMeta{
 				Official: &models.PromptRegistryExtensions{
 					Status:      status,
+					Successor:   successor,
 					PublishedAt: publishedAt,
 					UpdatedAt:   updatedAt,
 					IsLatest:    isLatest,
 				},
 			},
 		}
+		if semanticActive && semanticScore.Valid {
+			resp.Meta.Semantic = &models.PromptSemanticMeta{
+				Score: semanticScore.Float64,
+			}
+		}
 		results = append(results, resp)
 	}
 	if err := rows.Err(); err != nil {
@@ -3154,7 +3899,7 @@ func (db *PostgreSQL) ListPrompts(ctx context.Context, tx pgx.Tx, filter *databa
 	}
 
 	nextCursor := ""
-	if len(results) > 0 && len(results) >= limit {
+	if !semanticActive && len(results) > 0 && len(results) >= limit {
 		last := results[len(results)-1]
 		nextCursor = last.Prompt.Name + ":" + last.Prompt.Version
 	}
@@ -3174,17 +3919,17 @@ func (db *PostgreSQL) GetPromptByName(ctx context.Context, tx pgx.Tx, promptName
 	}
 
 	query := `
-        SELECT prompt_name, version, status, published_at, updated_at, is_latest, value
+        SELECT prompt_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value
         FROM prompts
         WHERE prompt_name = $1 AND is_latest = true
         ORDER BY published_at DESC
         LIMIT 1
     `
-	var name, version, status string
+	var name, version, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, promptName).Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, promptName).Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -3199,6 +3944,7 @@ func (db *PostgreSQL) GetPromptByName(ctx context.Context, tx pgx.Tx, promptName
 		Meta: models.PromptResponseMeta{
 			Official: &models.PromptRegistryExtensions{
 				Status:      status,
+				Successor:   successor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -3220,16 +3966,16 @@ func (db *PostgreSQL) GetPromptByNameAndVersion(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-        SELECT prompt_name, version, status, published_at, updated_at, is_latest, value
+        SELECT prompt_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value
         FROM prompts
         WHERE prompt_name = $1 AND version = $2
         LIMIT 1
     `
-	var name, vers, status string
+	var name, vers, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var valueJSON []byte
-	if err := db.getExecutor(tx).QueryRow(ctx, query, promptName, version).Scan(&name, &vers, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+	if err := db.getExecutor(tx).QueryRow(ctx, query, promptName, version).Scan(&name, &vers, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -3244,6 +3990,7 @@ func (db *PostgreSQL) GetPromptByNameAndVersion(ctx context.Context, tx pgx.Tx,
 		Meta: models.PromptResponseMeta{
 			Official: &models.PromptRegistryExtensions{
 				Status:      status,
+				Successor:   successor,
 				PublishedAt: publishedAt,
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
@@ -3265,7 +4012,7 @@ func (db *PostgreSQL) GetAllVersionsByPromptName(ctx context.Context, tx pgx.Tx,
 	}
 
 	query := `
-        SELECT prompt_name, version, status, published_at, updated_at, is_latest, value
+        SELECT prompt_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest, value
         FROM prompts
         WHERE prompt_name = $1
         ORDER BY published_at DESC
@@ -3277,11 +4024,11 @@ func (db *PostgreSQL) GetAllVersionsByPromptName(ctx context.Context, tx pgx.Tx,
 	defer rows.Close()
 	var results []*models.PromptResponse
 	for rows.Next() {
-		var name, version, status string
+		var name, version, status, successor string
 		var publishedAt, updatedAt time.Time
 		var isLatest bool
 		var valueJSON []byte
-		if err := rows.Scan(&name, &version, &status, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
+		if err := rows.Scan(&name, &version, &status, &successor, &publishedAt, &updatedAt, &isLatest, &valueJSON); err != nil {
 			return nil, fmt.Errorf("failed to scan prompt row: %w", err)
 		}
 		var promptJSON models.PromptJSON
@@ -3293,6 +4040,7 @@ func (db *PostgreSQL) GetAllVersionsByPromptName(ctx context.Context, tx pgx.Tx,
 			Meta: models.PromptResponseMeta{
 				Official: &models.PromptRegistryExtensions{
 					Status:      status,
+					Successor:   successor,
 					PublishedAt: publishedAt,
 					UpdatedAt:   updatedAt,
 					IsLatest:    isLatest,
@@ -3354,6 +4102,103 @@ func (db *PostgreSQL) CreatePrompt(ctx context.Context, tx pgx.Tx, promptJSON *m
 	}, nil
 }
 
+func (db *PostgreSQL) UpdatePrompt(ctx context.Context, tx pgx.Tx, promptName, version string, promptJSON *models.PromptJSON) (*models.PromptResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: promptName,
+		Type: auth.PermissionArtifactTypePrompt,
+	}); err != nil {
+		return nil, err
+	}
+
+	if promptJSON == nil {
+		return nil, fmt.Errorf("promptJSON is required")
+	}
+	if promptJSON.Name != promptName || promptJSON.Version != version {
+		return nil, fmt.Errorf("%w: prompt name and version in JSON must match parameters", database.ErrInvalidInput)
+	}
+	valueJSON, err := json.Marshal(promptJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal updated prompt: %w", err)
+	}
+	query := `
+        UPDATE prompts
+        SET value = $1, updated_at = NOW()
+        WHERE prompt_name = $2 AND version = $3
+        RETURNING prompt_name, version, status, COALESCE(successor, ''), published_at, updated_at, is_latest
+    `
+	var name, vers, status, successor string
+	var publishedAt, updatedAt time.Time
+	var isLatest bool
+	if err := db.getExecutor(tx).QueryRow(ctx, query, valueJSON, promptName, version).Scan(&name, &vers, &status, &successor, &publishedAt, &updatedAt, &isLatest); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update prompt: %w", err)
+	}
+	return &models.PromptResponse{
+		Prompt: *promptJSON,
+		Meta: models.PromptResponseMeta{
+			Official: &models.PromptRegistryExtensions{
+				Status:      status,
+				Successor:   successor,
+				PublishedAt: publishedAt,
+				UpdatedAt:   updatedAt,
+				IsLatest:    isLatest,
+			},
+		},
+	}, nil
+}
+
+func (db *PostgreSQL) SetPromptStatus(ctx context.Context, tx pgx.Tx, promptName, version, status, successor string) (*models.PromptResponse, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: promptName,
+		Type: auth.PermissionArtifactTypePrompt,
+	}); err != nil {
+		return nil, err
+	}
+
+	query := `
+        UPDATE prompts
+        SET status = $1, successor = NULLIF($4, ''), updated_at = NOW()
+        WHERE prompt_name = $2 AND version = $3
+        RETURNING prompt_name, version, status, COALESCE(successor, ''), value, published_at, updated_at, is_latest
+    `
+	var name, vers, currentStatus, currentSuccessor string
+	var publishedAt, updatedAt time.Time
+	var isLatest bool
+	var valueJSON []byte
+	if err := db.getExecutor(tx).QueryRow(ctx, query, status, promptName, version, successor).Scan(&name, &vers, &currentStatus, &currentSuccessor, &valueJSON, &publishedAt, &updatedAt, &isLatest); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to update prompt status: %w", err)
+	}
+	var promptJSON models.PromptJSON
+	if err := json.Unmarshal(valueJSON, &promptJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal prompt JSON: %w", err)
+	}
+	return &models.PromptResponse{
+		Prompt: promptJSON,
+		Meta: models.PromptResponseMeta{
+			Official: &models.PromptRegistryExtensions{
+				Status:      currentStatus,
+				Successor:   currentSuccessor,
+				PublishedAt: publishedAt,
+				UpdatedAt:   updatedAt,
+				IsLatest:    isLatest,
+			},
+		},
+	}, nil
+}
+
 func (db *PostgreSQL) GetCurrentLatestPromptVersion(ctx context.Context, tx pgx.Tx, promptName string) (*models.PromptResponse, error) {
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
@@ -3368,16 +4213,16 @@ func (db *PostgreSQL) GetCurrentLatestPromptVersion(ctx context.Context, tx pgx.
 
 	executor := db.getExecutor(tx)
 	query := `
-        SELECT prompt_name, version, status, value, published_at, updated_at, is_latest
+        SELECT prompt_name, version, status, COALESCE(successor, ''), value, published_at, updated_at, is_latest
         FROM prompts
         WHERE prompt_name = $1 AND is_latest = true
     `
 	row := executor.QueryRow(ctx, query, promptName)
-	var name, version, status string
+	var name, version, status, successor string
 	var publishedAt, updatedAt time.Time
 	var isLatest bool
 	var jsonValue []byte
-	if err := row.Scan(&name, &version, &status, &jsonValue, &publishedAt, &updatedAt, &isLatest); err != nil {
+	if err := row.Scan(&name, &version, &status, &successor, &jsonValue, &publishedAt, &updatedAt, &isLatest); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, database.ErrNotFound
 		}
@@ -3395,6 +4240,7 @@ func (db *PostgreSQL) GetCurrentLatestPromptVersion(ctx context.Context, tx pgx.
 				UpdatedAt:   updatedAt,
 				IsLatest:    isLatest,
 				Status:      status,
+				Successor:   successor,
 			},
 		},
 	}, nil
@@ -3516,8 +4362,1258 @@ func (db *PostgreSQL) DeletePrompt(ctx context.Context, tx pgx.Tx, promptName, v
 	return nil
 }
 
-// Close closes the database connection
-func (db *PostgreSQL) Close() error {
-	db.pool.Close()
-	return nil
+// SetPromptEmbedding stores semantic embedding metadata for a prompt version.
+func (db *PostgreSQL) SetPromptEmbedding(ctx context.Context, tx pgx.Tx, promptName, version string, embedding *database.SemanticEmbedding) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, auth.Resource{
+		Name: promptName,
+		Type: auth.PermissionArtifactTypePrompt,
+	}); err != nil {
+		return err
+	}
+
+	executor := db.getExecutor(tx)
+
+	var (
+		query string
+		args  []any
+	)
+
+	if embedding == nil || len(embedding.Vector) == 0 {
+		query = `
+			UPDATE prompts
+			SET semantic_embedding = NULL,
+			    semantic_embedding_provider = NULL,
+			    semantic_embedding_model = NULL,
+			    semantic_embedding_dimensions = NULL,
+			    semantic_embedding_checksum = NULL,
+			    semantic_embedding_generated_at = NULL
+			WHERE prompt_name = $1 AND version = $2
+		`
+		args = []any{promptName, version}
+	} else {
+		vectorLiteral, err := dbUtils.VectorLiteral(embedding.Vector)
+		if err != nil {
+			return err
+		}
+		query = `
+			UPDATE prompts
+			SET semantic_embedding = $3::vector,
+			    semantic_embedding_provider = $4,
+			    semantic_embedding_model = $5,
+			    semantic_embedding_dimensions = $6,
+			    semantic_embedding_checksum = $7,
+			    semantic_embedding_generated_at = $8
+			WHERE prompt_name = $1 AND version = $2
+		`
+		args = []any{
+			promptName,
+			version,
+			vectorLiteral,
+			embedding.Provider,
+			embedding.Model,
+			embedding.Dimensions,
+			embedding.Checksum,
+			embedding.Generated,
+		}
+	}
+
+	result, err := executor.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update prompt embedding: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+// GetPromptEmbeddingMetadata retrieves embedding metadata for a prompt version without loading the vector.
+func (db *PostgreSQL) GetPromptEmbeddingMetadata(ctx context.Context, tx pgx.Tx, promptName, version string) (*database.SemanticEmbeddingMetadata, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, auth.Resource{
+		Name: promptName,
+		Type: auth.PermissionArtifactTypePrompt,
+	}); err != nil {
+		return nil, err
+	}
+
+	executor := db.getExecutor(tx)
+	query := `
+		SELECT
+			semantic_embedding IS NOT NULL AS has_embedding,
+			semantic_embedding_provider,
+			semantic_embedding_model,
+			semantic_embedding_dimensions,
+			semantic_embedding_checksum,
+			semantic_embedding_generated_at
+		FROM prompts
+		WHERE prompt_name = $1 AND version = $2
+		LIMIT 1
+	`
+
+	var (
+		hasEmbedding bool
+		provider     sql.NullString
+		model        sql.NullString
+		dimensions   sql.NullInt32
+		checksum     sql.NullString
+		generatedAt  sql.NullTime
+	)
+
+	err := executor.QueryRow(ctx, query, promptName, version).Scan(
+		&hasEmbedding,
+		&provider,
+		&model,
+		&dimensions,
+		&checksum,
+		&generatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch prompt embedding metadata: %w", err)
+	}
+
+	meta := &database.SemanticEmbeddingMetadata{
+		HasEmbedding: hasEmbedding,
+	}
+	if provider.Valid {
+		meta.Provider = provider.String
+	}
+	if model.Valid {
+		meta.Model = model.String
+	}
+	if dimensions.Valid {
+		meta.Dimensions = int(dimensions.Int32)
+	}
+	if checksum.Valid {
+		meta.Checksum = checksum.String
+	}
+	if generatedAt.Valid {
+		meta.Generated = generatedAt.Time
+	}
+
+	return meta, nil
+}
+
+// searchTable describes how an artifact table is projected into the unified search result set.
+type searchTable struct {
+	artifactType string
+	table        string
+	nameColumn   string
+}
+
+var searchTables = []searchTable{
+	{artifactType: models.SearchTypeServer, table: "servers", nameColumn: "server_name"},
+	{artifactType: models.SearchTypeAgent, table: "agents", nameColumn: "agent_name"},
+	{artifactType: models.SearchTypeSkill, table: "skills", nameColumn: "skill_name"},
+	{artifactType: models.SearchTypePrompt, table: "prompts", nameColumn: "prompt_name"},
+}
+
+// searchSubstringThreshold is the minimum trigram word similarity for a text match
+// when the query is not a plain substring of the artifact name, title or description.
+const searchSubstringThreshold = 0.5
+
+// searchCursor is the decoded form of the opaque search pagination cursor. It holds
+// the sort key of the last result on the previous page.
+type searchCursor struct {
+	Score   float64 `json:"s"`
+	Type    string  `json:"t"`
+	Name    string  `json:"n"`
+	Version string  `json:"v"`
+}
+
+func encodeSearchCursor(result *models.SearchResult) string {
+	raw, _ := json.Marshal(searchCursor{
+		Score:   result.Score,
+		Type:    result.Type,
+		Name:    result.Name,
+		Version: result.Version,
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeSearchCursor(cursor string) (*searchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid search cursor", database.ErrInvalidInput)
+	}
+	var c searchCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("%w: invalid search cursor", database.ErrInvalidInput)
+	}
+	return &c, nil
+}
+
+// searchReadFilters returns the read filter of the caller for each searchable artifact type.
+func (db *PostgreSQL) searchReadFilters(ctx context.Context) map[string]auth.ReadFilter {
+	filters := make(map[string]auth.ReadFilter, len(searchTables))
+	for _, table := range searchTables {
+		filters[table.artifactType] = db.authz.ReadFilter(ctx, auth.PermissionArtifactType(table.artifactType))
+	}
+	return filters
+}
+
+// buildSearchQuery returns a query selecting every artifact matching filter across the
+// requested artifact tables, together with its substring_score, semantic_distance and
+// combined score columns, along with its positional arguments. Rows are limited to the
+// names readFilters allows for each artifact type.
+func buildSearchQuery(filter *database.SearchFilter, types []string, readFilters map[string]auth.ReadFilter) (string, []any, error) {
+	selected := make(map[string]bool, len(types))
+	for _, t := range types {
+		known := false
+		for _, table := range searchTables {
+			if table.artifactType == t {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return "", nil, fmt.Errorf("%w: unknown artifact type %q", database.ErrInvalidInput, t)
+		}
+		selected[t] = true
+	}
+
+	var args []any
+	semanticActive := filter.Semantic != nil && len(filter.Semantic.QueryEmbedding) > 0
+	semanticDistance := "NULL::float8"
+	if semanticActive {
+		semanticLiteral, err := dbUtils.VectorLiteral(filter.Semantic.QueryEmbedding)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid semantic embedding: %w", err)
+		}
+		args = append(args, semanticLiteral)
+		semanticDistance = fmt.Sprintf("semantic_embedding <=> $%d::vector", len(args))
+	}
+
+	var branches []string
+	for _, table := range searchTables {
+		if len(selected) > 0 && !selected[table.artifactType] {
+			continue
+		}
+		var conditions []string
+		if !filter.AllVersions {
+			conditions = append(conditions, "is_latest = true")
+		}
+		if !filter.IncludeDeleted {
+			conditions = append(conditions, "status <> 'deleted'")
+		}
+		if condition, conditionArgs := readCondition(readFilters[table.artifactType], table.nameColumn, len(args)+1); condition != "" {
+			conditions = append(conditions, condition)
+			args = append(args, conditionArgs...)
+		}
+		whereClause := ""
+		if len(conditions) > 0 {
+			whereClause = "WHERE " + strings.Join(conditions, " AND ")
+		}
+		branches = append(branches, fmt.Sprintf(`
+			SELECT '%s' AS artifact_type, %s AS name, version, status, updated_at,
+				COALESCE(value->>'title', '') AS title,
+				COALESCE(value->>'description', '') AS description,
+				%s AS semantic_distance
+			FROM %s
+			%s`, table.artifactType, table.nameColumn, semanticDistance, table.table, whereClause))
+	}
+
+	// In semantic mode the substring part of the hybrid score comes from HybridSubstring;
+	// without it results are ranked on vector distance alone.
+	substring := filter.Query
+	if semanticActive {
+		substring = ""
+		if filter.Semantic.HybridSubstring != nil {
+			substring = *filter.Semantic.HybridSubstring
+		}
+	}
+	substring = strings.TrimSpace(substring)
+
+	substringScore := "0::float8"
+	textMatch := "TRUE"
+	if substring != "" {
+		args = append(args, substring, "%"+substring+"%")
+		queryIdx, patternIdx := len(args)-1, len(args)
+		// Matches on the name outrank matches that only hit the title or description.
+		substringScore = fmt.Sprintf(`GREATEST(
+				CASE WHEN name ILIKE $%[2]d THEN 1.0::float8 ELSE 0::float8 END,
+				word_similarity($%[1]d, name)::float8,
+				CASE WHEN title ILIKE $%[2]d OR description ILIKE $%[2]d THEN 0.8::float8 ELSE 0::float8 END,
+				0.8 * word_similarity($%[1]d, title || ' ' || description)::float8
+			)`, queryIdx, patternIdx)
+		textMatch = fmt.Sprintf("substring_score >= %g", searchSubstringThreshold)
+	}
+
+	score := "substring_score"
+	matchCondition := textMatch
+	if semanticActive {
+		semanticSimilarity := "COALESCE(GREATEST(1 - semantic_distance, 0), 0)"
+		semanticMatch := "semantic_distance IS NOT NULL"
+		if filter.Semantic.Threshold > 0 {
+			args = append(args, filter.Semantic.Threshold)
+			semanticMatch += fmt.Sprintf(" AND semantic_distance <= $%d", len(args))
+		}
+		if substring != "" {
+			score = fmt.Sprintf("(substring_score + %s) / 2", semanticSimilarity)
+			matchCondition = fmt.Sprintf("((%s) OR %s)", semanticMatch, textMatch)
+		} else {
+			score = semanticSimilarity
+			matchCondition = semanticMatch
+		}
+	}
+
+	query := fmt.Sprintf(`
+		WITH artifacts AS (%s
+		), scored AS (
+			SELECT artifacts.*, %s AS substring_score
+			FROM artifacts
+		)
+		SELECT artifact_type, name, version, title, description, status, updated_at,
+			substring_score, semantic_distance, %s AS score
+		FROM scored
+		WHERE %s`, strings.Join(branches, "\n\t\t\tUNION ALL"), substringScore, score, matchCondition)
+	return query, args, nil
+}
+
+// SearchArtifacts ranks servers, agents, skills and prompts matching filter in a single result set.
+// Results are ordered by descending score and paginated with an opaque keyset cursor.
+func (db *PostgreSQL) SearchArtifacts(ctx context.Context, tx pgx.Tx, filter *database.SearchFilter, cursor string, limit int) ([]*models.SearchResult, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+	if filter == nil {
+		filter = &database.SearchFilter{}
+	}
+
+	searchQuery, args, err := buildSearchQuery(filter, filter.Types, db.searchReadFilters(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+
+	cursorCondition := ""
+	if cursor != "" {
+		c, err := decodeSearchCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		args = append(args, c.Score, c.Type, c.Name, c.Version)
+		n := len(args)
+		cursorCondition = fmt.Sprintf("WHERE score < $%d OR (score = $%d AND (artifact_type, name, version) > ($%d, $%d, $%d))",
+			n-3, n-3, n-2, n-1, n)
+	}
+
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT artifact_type, name, version, title, description, status, updated_at,
+			score, substring_score, semantic_distance
+		FROM (%s
+		) ranked
+		%s
+		ORDER BY score DESC, artifact_type, name, version
+		LIMIT $%d
+	`, searchQuery, cursorCondition, len(args))
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to search artifacts: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*models.SearchResult
+	for rows.Next() {
+		var result models.SearchResult
+		var semanticDistance sql.NullFloat64
+		if err := rows.Scan(&result.Type, &result.Name, &result.Version, &result.Title, &result.Description,
+			&result.Status, &result.UpdatedAt, &result.Score, &result.SubstringScore, &semanticDistance); err != nil {
+			return nil, "", fmt.Errorf("failed to scan search row: %w", err)
+		}
+		if semanticDistance.Valid {
+			result.SemanticScore = &semanticDistance.Float64
+		}
+		results = append(results, &result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating search rows: %w", err)
+	}
+
+	nextCursor := ""
+	if len(results) > 0 && len(results) >= limit {
+		nextCursor = encodeSearchCursor(results[len(results)-1])
+	}
+	return results, nextCursor, nil
+}
+
+// CountSearchArtifacts returns the number of artifacts matching filter per artifact type.
+// filter.Types is ignored so that callers can render facets for every type.
+func (db *PostgreSQL) CountSearchArtifacts(ctx context.Context, tx pgx.Tx, filter *database.SearchFilter) (map[string]int, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if filter == nil {
+		filter = &database.SearchFilter{}
+	}
+
+	searchQuery, args, err := buildSearchQuery(filter, nil, db.searchReadFilters(ctx))
+	if err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf(`
+		SELECT artifact_type, COUNT(*)
+		FROM (%s
+		) ranked
+		GROUP BY artifact_type
+	`, searchQuery)
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count search results: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int, len(searchTables))
+	for _, table := range searchTables {
+		counts[table.artifactType] = 0
+	}
+	for rows.Next() {
+		var artifactType string
+		var count int
+		if err := rows.Scan(&artifactType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan search count row: %w", err)
+		}
+		counts[artifactType] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search count rows: %w", err)
+	}
+	return counts, nil
+}
+
+// Close closes the database connection
+func (db *PostgreSQL) Close() error {
+	db.pool.Close()
+	return nil
+}
+
+const jobColumns = `id, type, status, params, progress, result, COALESCE(lease_owner, ''), lease_expires_at,
+	cancel_requested, attempts, created_at, updated_at, started_at, finished_at, submitter`
+
+func scanJob(row pgx.Row) (*models.Job, error) {
+	var job models.Job
+	var paramsJSON, progressJSON, resultJSON, submitterJSON []byte
+	err := row.Scan(
+		&job.ID,
+		&job.Type,
+		&job.Status,
+		&paramsJSON,
+		&progressJSON,
+		&resultJSON,
+		&job.LeaseOwner,
+		&job.LeaseExpiresAt,
+		&job.CancelRequested,
+		&job.Attempts,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&submitterJSON,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan job: %w", err)
+	}
+	if len(submitterJSON) > 0 {
+		job.Submitter = submitterJSON
+	}
+	if len(paramsJSON) > 0 {
+		if err := json.Unmarshal(paramsJSON, &job.Params); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job params: %w", err)
+		}
+	}
+	if len(progressJSON) > 0 {
+		if err := json.Unmarshal(progressJSON, &job.Progress); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job progress: %w", err)
+		}
+	}
+	if len(resultJSON) > 0 && string(resultJSON) != "null" {
+		job.Result = &models.JobResult{}
+		if err := json.Unmarshal(resultJSON, job.Result); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job result: %w", err)
+		}
+	}
+	return &job, nil
+}
+
+// CreateJob inserts a new job. When exclusive is true, it fails with
+// ErrAlreadyExists if a job of the same type is still pending or running.
+func (db *PostgreSQL) CreateJob(ctx context.Context, tx pgx.Tx, job *models.Job, exclusive bool) error {
+	if job == nil || strings.TrimSpace(job.ID) == "" || strings.TrimSpace(job.Type) == "" {
+		return database.ErrInvalidInput
+	}
+	if tx == nil {
+		return db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			return db.CreateJob(ctx, tx, job, exclusive)
+		})
+	}
+
+	if exclusive {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "job."+job.Type); err != nil {
+			return fmt.Errorf("failed to acquire job create lock: %w", err)
+		}
+		var active bool
+		err := tx.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM jobs WHERE type = $1 AND status IN ('pending', 'running'))`,
+			job.Type,
+		).Scan(&active)
+		if err != nil {
+			return fmt.Errorf("failed to check active jobs: %w", err)
+		}
+		if active {
+			return database.ErrAlreadyExists
+		}
+	}
+
+	paramsJSON, err := json.Marshal(job.Params)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job params: %w", err)
+	}
+	if job.Params == nil {
+		paramsJSON = []byte("{}")
+	}
+	progressJSON, err := json.Marshal(job.Progress)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job progress: %w", err)
+	}
+
+	var leaseOwner *string
+	if job.LeaseOwner != "" {
+		leaseOwner = &job.LeaseOwner
+	}
+	var submitterJSON []byte
+	if len(job.Submitter) > 0 {
+		submitterJSON = job.Submitter
+	}
+	query := `
+		INSERT INTO jobs (id, type, status, params, progress, lease_owner, lease_expires_at, attempts, started_at, submitter)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING created_at, updated_at`
+	err = tx.QueryRow(ctx, query,
+		job.ID, job.Type, job.Status, paramsJSON, progressJSON,
+		leaseOwner, job.LeaseExpiresAt, job.Attempts, job.StartedAt, submitterJSON,
+	).Scan(&job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return database.ErrAlreadyExists
+		}
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return nil
+}
+
+// GetJob retrieves a job by ID.
+func (db *PostgreSQL) GetJob(ctx context.Context, tx pgx.Tx, id string) (*models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+	return scanJob(db.getExecutor(tx).QueryRow(ctx, query, id))
+}
+
+// ListJobs lists jobs, newest first.
+func (db *PostgreSQL) ListJobs(ctx context.Context, tx pgx.Tx, filter *models.JobFilter) ([]*models.Job, error) {
+	var where []string
+	var args []any
+	limit := 100
+	if filter != nil {
+		if filter.Type != nil {
+			args = append(args, *filter.Type)
+			where = append(where, fmt.Sprintf("type = $%d", len(args)))
+		}
+		if filter.Status != nil {
+			args = append(args, *filter.Status)
+			where = append(where, fmt.Sprintf("status = $%d", len(args)))
+		}
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args))
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*models.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// cancelAbandonedJobsQuery finishes running jobs whose cancellation was
+// requested but whose owner let the lease expire before stopping them. Such
+// jobs are never claimed again, so they would otherwise stay running forever.
+const cancelAbandonedJobsQuery = `
+	UPDATE jobs
+	SET status = 'cancelled', lease_owner = NULL, lease_expires_at = NULL,
+		result = COALESCE(result, '{}'::jsonb) || '{"error": "job was cancelled"}'::jsonb,
+		finished_at = NOW(), updated_at = NOW()
+	WHERE cancel_requested AND status = 'running' AND lease_expires_at < NOW()`
+
+// ClaimJob acquires the lease on a specific job that is pending or whose lease
+// has expired. Returns ErrNotFound if the job cannot be claimed.
+func (db *PostgreSQL) ClaimJob(ctx context.Context, tx pgx.Tx, id, owner string, leaseUntil time.Time) (*models.Job, error) {
+	if _, err := db.getExecutor(tx).Exec(ctx, cancelAbandonedJobsQuery+` AND id = $1`, id); err != nil {
+		return nil, fmt.Errorf("failed to cancel abandoned job: %w", err)
+	}
+	query := `
+		UPDATE jobs
+		SET status = 'running', lease_owner = $2, lease_expires_at = $3, attempts = attempts + 1,
+			started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+		WHERE id = $1
+			AND NOT cancel_requested
+			AND (status = 'pending' OR (status = 'running' AND lease_expires_at < NOW()))
+		RETURNING ` + jobColumns
+	return scanJob(db.getExecutor(tx).QueryRow(ctx, query, id, owner, leaseUntil))
+}
+
+// ClaimNextJob acquires the lease on the oldest claimable job of one of the
+// given types. Returns ErrNotFound if there is nothing to claim.
+func (db *PostgreSQL) ClaimNextJob(ctx context.Context, tx pgx.Tx, jobTypes []string, owner string, leaseUntil time.Time) (*models.Job, error) {
+	if len(jobTypes) == 0 {
+		return nil, database.ErrNotFound
+	}
+	if _, err := db.getExecutor(tx).Exec(ctx, cancelAbandonedJobsQuery+` AND type = ANY($1)`, jobTypes); err != nil {
+		return nil, fmt.Errorf("failed to cancel abandoned jobs: %w", err)
+	}
+	query := `
+		UPDATE jobs
+		SET status = 'running', lease_owner = $2, lease_expires_at = $3, attempts = attempts + 1,
+			started_at = COALESCE(started_at, NOW()), updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE type = ANY($1)
+				AND NOT cancel_requested
+				AND (status = 'pending' OR (status = 'running' AND lease_expires_at < NOW()))
+			ORDER BY created_at ASC
+			FOR UPDATE SKIP LOCKED
+			LIMIT 1
+		)
+		RETURNING ` + jobColumns
+	return scanJob(db.getExecutor(tx).QueryRow(ctx, query, jobTypes, owner, leaseUntil))
+}
+
+// RenewJobLease extends the lease held by owner and reports whether
+// cancellation was requested. Returns ErrNotFound if owner lost the lease.
+func (db *PostgreSQL) RenewJobLease(ctx context.Context, tx pgx.Tx, id, owner string, leaseUntil time.Time) (bool, error) {
+	query := `
+		UPDATE jobs
+		SET lease_expires_at = $3, updated_at = NOW()
+		WHERE id = $1 AND lease_owner = $2 AND status = 'running'
+		RETURNING cancel_requested`
+	var cancelRequested bool
+	err := db.getExecutor(tx).QueryRow(ctx, query, id, owner, leaseUntil).Scan(&cancelRequested)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, database.ErrNotFound
+		}
+		return false, fmt.Errorf("failed to renew job lease: %w", err)
+	}
+	return cancelRequested, nil
+}
+
+// UpdateJobProgress stores progress for a running job held by owner.
+func (db *PostgreSQL) UpdateJobProgress(ctx context.Context, tx pgx.Tx, id, owner string, progress models.JobProgress) error {
+	progressJSON, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job progress: %w", err)
+	}
+	result, err := db.getExecutor(tx).Exec(ctx,
+		`UPDATE jobs SET progress = $3, updated_at = NOW() WHERE id = $1 AND lease_owner = $2 AND status = 'running'`,
+		id, owner, progressJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update job progress: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+// FinishJob moves a running job held by owner to a terminal status and
+// releases its lease.
+func (db *PostgreSQL) FinishJob(ctx context.Context, tx pgx.Tx, id, owner, status string, result *models.JobResult) error {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job result: %w", err)
+	}
+	tag, err := db.getExecutor(tx).Exec(ctx, `
+		UPDATE jobs
+		SET status = $3, result = $4, lease_owner = NULL, lease_expires_at = NULL,
+			finished_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND lease_owner = $2 AND status = 'running'`,
+		id, owner, status, resultJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to finish job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+// ReleaseJob gives up the lease owner holds on a running job so that any
+// replica can claim it again. A job whose cancellation was requested while it
+// ran is cancelled instead, since such jobs are never claimed again.
+func (db *PostgreSQL) ReleaseJob(ctx context.Context, tx pgx.Tx, id, owner string) error {
+	tag, err := db.getExecutor(tx).Exec(ctx, `
+		UPDATE jobs
+		SET status = CASE WHEN cancel_requested THEN 'cancelled' ELSE 'pending' END,
+			result = CASE WHEN cancel_requested
+				THEN COALESCE(result, '{}'::jsonb) || '{"error": "job was cancelled"}'::jsonb
+				ELSE result END,
+			finished_at = CASE WHEN cancel_requested THEN NOW() ELSE finished_at END,
+			lease_owner = NULL, lease_expires_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND lease_owner = $2 AND status = 'running'`,
+		id, owner,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release job: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+// RequestJobCancel cancels a pending job immediately, or flags a running job so
+// that its lease holder stops it on the next heartbeat. Terminal jobs are
+// returned unchanged.
+func (db *PostgreSQL) RequestJobCancel(ctx context.Context, tx pgx.Tx, id string) (*models.Job, error) {
+	query := `
+		UPDATE jobs
+		SET cancel_requested = true,
+			status = CASE WHEN status = 'pending' THEN 'cancelled' ELSE status END,
+			finished_at = CASE WHEN status = 'pending' THEN NOW() ELSE finished_at END,
+			updated_at = NOW()
+		WHERE id = $1 AND status IN ('pending', 'running')
+		RETURNING ` + jobColumns
+	job, err := scanJob(db.getExecutor(tx).QueryRow(ctx, query, id))
+	if errors.Is(err, database.ErrNotFound) {
+		return db.GetJob(ctx, tx, id)
+	}
+	return job, err
+}
+
+// DeleteJobsFinishedBefore removes terminal jobs that finished before cutoff.
+func (db *PostgreSQL) DeleteJobsFinishedBefore(ctx context.Context, tx pgx.Tx, cutoff time.Time) (int64, error) {
+	tag, err := db.getExecutor(tx).Exec(ctx,
+		`DELETE FROM jobs WHERE status IN ('completed', 'failed', 'cancelled') AND finished_at < $1`,
+		cutoff,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+const secretColumns = `name, description, key_id, created_at, updated_at`
+
+func scanSecret(row pgx.Row) (*models.Secret, error) {
+	var secret models.Secret
+	err := row.Scan(&secret.Name, &secret.Description, &secret.KeyID, &secret.CreatedAt, &secret.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan secret: %w", err)
+	}
+	return &secret, nil
+}
+
+func secretResource(name string) auth.Resource {
+	return auth.Resource{Name: name, Type: auth.PermissionArtifactTypeSecret}
+}
+
+func validEncryptedSecret(secret *database.EncryptedSecret) bool {
+	return secret != nil && strings.TrimSpace(secret.Name) != "" && len(secret.Ciphertext) > 0 &&
+		len(secret.WrappedKey) > 0 && secret.KeyID != ""
+}
+
+// CreateSecret stores a new encrypted secret.
+func (db *PostgreSQL) CreateSecret(ctx context.Context, tx pgx.Tx, secret *database.EncryptedSecret) (*models.Secret, error) {
+	if !validEncryptedSecret(secret) {
+		return nil, database.ErrInvalidInput
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionPublish, secretResource(secret.Name)); err != nil {
+		return nil, err
+	}
+	query := `
+		INSERT INTO secrets (name, description, ciphertext, wrapped_key, key_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + secretColumns
+	created, err := scanSecret(db.getExecutor(tx).QueryRow(ctx, query,
+		secret.Name, secret.Description, secret.Ciphertext, secret.WrappedKey, secret.KeyID))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, database.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create secret: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateSecret replaces the value and description of an existing secret.
+func (db *PostgreSQL) UpdateSecret(ctx context.Context, tx pgx.Tx, secret *database.EncryptedSecret) (*models.Secret, error) {
+	if !validEncryptedSecret(secret) {
+		return nil, database.ErrInvalidInput
+	}
+	if err := db.authz.Check(ctx, auth.PermissionActionEdit, secretResource(secret.Name)); err != nil {
+		return nil, err
+	}
+	query := `
+		UPDATE secrets
+		SET description = $2, ciphertext = $3, wrapped_key = $4, key_id = $5, updated_at = NOW()
+		WHERE name = $1
+		RETURNING ` + secretColumns
+	return scanSecret(db.getExecutor(tx).QueryRow(ctx, query,
+		secret.Name, secret.Description, secret.Ciphertext, secret.WrappedKey, secret.KeyID))
+}
+
+// GetSecret returns a secret with its encrypted value.
+func (db *PostgreSQL) GetSecret(ctx context.Context, tx pgx.Tx, name string) (*database.EncryptedSecret, error) {
+	if err := db.authz.Check(ctx, auth.PermissionActionRead, secretResource(name)); err != nil {
+		return nil, err
+	}
+	var secret database.EncryptedSecret
+	err := db.getExecutor(tx).QueryRow(ctx, `
+		SELECT name, description, key_id, created_at, updated_at, ciphertext, wrapped_key
+		FROM secrets WHERE name = $1`, name).Scan(
+		&secret.Name,
+		&secret.Description,
+		&secret.KeyID,
+		&secret.CreatedAt,
+		&secret.UpdatedAt,
+		&secret.Ciphertext,
+		&secret.WrappedKey,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+	return &secret, nil
+}
+
+// ListSecrets lists secret metadata ordered by name. Only registry admins may
+// list secrets.
+func (db *PostgreSQL) ListSecrets(ctx context.Context, tx pgx.Tx) ([]*models.Secret, error) {
+	if err := db.authz.CheckRegistryAdmin(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+secretColumns+` FROM secrets ORDER BY name ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	defer rows.Close()
+
+	var secrets []*models.Secret
+	for rows.Next() {
+		secret, err := scanSecret(rows)
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+// DeleteSecret removes a secret unless a deployment still references it.
+func (db *PostgreSQL) DeleteSecret(ctx context.Context, tx pgx.Tx, name string) error {
+	if err := db.authz.Check(ctx, auth.PermissionActionDelete, secretResource(name)); err != nil {
+		return err
+	}
+	executor := db.getExecutor(tx)
+	var referenced bool
+	err := executor.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM deployments d, jsonb_each_text(d.secret_refs) ref
+			WHERE ref.value = $1
+		)`, name).Scan(&referenced)
+	if err != nil {
+		return fmt.Errorf("failed to check secret references: %w", err)
+	}
+	if referenced {
+		return fmt.Errorf("%w: secret %s is referenced by a deployment", database.ErrInUse, name)
+	}
+	result, err := executor.Exec(ctx, `DELETE FROM secrets WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+const mirrorColumns = `id, url, include_patterns, exclude_patterns, interval_seconds, enabled,
+	watermark, last_synced_at, created_at, updated_at, created_by`
+
+func scanMirror(row pgx.Row) (*models.Mirror, error) {
+	var m models.Mirror
+	var includeJSON, excludeJSON, createdBy []byte
+	err := row.Scan(
+		&m.ID,
+		&m.URL,
+		&includeJSON,
+		&excludeJSON,
+		&m.IntervalSeconds,
+		&m.Enabled,
+		&m.Watermark,
+		&m.LastSyncedAt,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+		&createdBy,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, database.ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to scan mirror: %w", err)
+	}
+	m.CreatedBy = createdBy
+	if err := json.Unmarshal(includeJSON, &m.Include); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mirror include patterns: %w", err)
+	}
+	if err := json.Unmarshal(excludeJSON, &m.Exclude); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal mirror exclude patterns: %w", err)
+	}
+	return &m, nil
+}
+
+// CreateMirror registers an upstream registry to mirror. Only registry admins may
+// create mirrors; the caller is stored as the principal that syncs run as.
+func (db *PostgreSQL) CreateMirror(ctx context.Context, tx pgx.Tx, in *models.CreateMirrorInput) (*models.Mirror, error) {
+	if err := db.authz.CheckRegistryAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if in == nil || strings.TrimSpace(in.ID) == "" || strings.TrimSpace(in.URL) == "" {
+		return nil, database.ErrInvalidInput
+	}
+	include := in.Include
+	if include == nil {
+		include = []string{}
+	}
+	exclude := in.Exclude
+	if exclude == nil {
+		exclude = []string{}
+	}
+	includeJSON, err := json.Marshal(include)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mirror include patterns: %w", err)
+	}
+	excludeJSON, err := json.Marshal(exclude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal mirror exclude patterns: %w", err)
+	}
+	interval := in.IntervalSeconds
+	if interval <= 0 {
+		interval = models.DefaultMirrorIntervalSeconds
+	}
+	enabled := true
+	if in.Enabled != nil {
+		enabled = *in.Enabled
+	}
+	createdBy, err := auth.MarshalPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO mirrors (id, url, include_patterns, exclude_patterns, interval_seconds, enabled, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + mirrorColumns
+	m, err := scanMirror(db.getExecutor(tx).QueryRow(ctx, query, in.ID, in.URL, includeJSON, excludeJSON, interval, enabled, createdBy))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, database.ErrAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to create mirror: %w", err)
+	}
+	return m, nil
+}
+
+// ListMirrors lists configured mirrors in creation order.
+func (db *PostgreSQL) ListMirrors(ctx context.Context, tx pgx.Tx) ([]*models.Mirror, error) {
+	rows, err := db.getExecutor(tx).Query(ctx, `SELECT `+mirrorColumns+` FROM mirrors ORDER BY created_at ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mirrors: %w", err)
+	}
+	defer rows.Close()
+
+	var mirrors []*models.Mirror
+	for rows.Next() {
+		m, err := scanMirror(rows)
+		if err != nil {
+			return nil, err
+		}
+		mirrors = append(mirrors, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate mirrors: %w", err)
+	}
+	return mirrors, nil
+}
+
+// GetMirrorByID returns a mirror by ID.
+func (db *PostgreSQL) GetMirrorByID(ctx context.Context, tx pgx.Tx, mirrorID string) (*models.Mirror, error) {
+	return scanMirror(db.getExecutor(tx).QueryRow(ctx, `SELECT `+mirrorColumns+` FROM mirrors WHERE id = $1`, mirrorID))
+}
+
+// DeleteMirror removes a mirror and its sync history. Mirrored servers are kept.
+// Only registry admins may delete mirrors.
+func (db *PostgreSQL) DeleteMirror(ctx context.Context, tx pgx.Tx, mirrorID string) error {
+	if err := db.authz.CheckRegistryAdmin(ctx); err != nil {
+		return err
+	}
+	result, err := db.getExecutor(tx).Exec(ctx, `DELETE FROM mirrors WHERE id = $1`, mirrorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete mirror: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+	return nil
+}
+
+// RecordMirrorSync stores a finished sync, stamps the mirror's last sync time and
+// advances its watermark when sync.Watermark is set. The watermark never moves back.
+func (db *PostgreSQL) RecordMirrorSync(ctx context.Context, tx pgx.Tx, sync *models.MirrorSync) error {
+	if sync == nil || strings.TrimSpace(sync.MirrorID) == "" {
+		return database.ErrInvalidInput
+	}
+	if tx == nil {
+		return db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+			return db.RecordMirrorSync(ctx, tx, sync)
+		})
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE mirrors
+		SET last_synced_at = $2,
+			watermark = CASE WHEN $3::timestamptz IS NULL THEN watermark ELSE GREATEST(watermark, $3::timestamptz) END,
+			updated_at = NOW()
+		WHERE id = $1`,
+		sync.MirrorID, sync.FinishedAt, sync.Watermark,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update mirror sync state: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return database.ErrNotFound
+	}
+
+	var syncErr *string
+	if sync.Error != "" {
+		syncErr = &sync.Error
+	}
+	err = tx.QueryRow(ctx, `
+		INSERT INTO mirror_syncs (mirror_id, status, since, watermark, fetched, created, updated, skipped, failed, error, started_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id`,
+		sync.MirrorID, sync.Status, sync.Since, sync.Watermark,
+		sync.Fetched, sync.Created, sync.Updated, sync.Skipped, sync.Failed,
+		syncErr, sync.StartedAt, sync.FinishedAt,
+	).Scan(&sync.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record mirror sync: %w", err)
+	}
+	return nil
+}
+
+// ListMirrorSyncs lists the most recent syncs of a mirror, newest first.
+func (db *PostgreSQL) ListMirrorSyncs(ctx context.Context, tx pgx.Tx, mirrorID string, limit int) ([]*models.MirrorSync, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	rows, err := db.getExecutor(tx).Query(ctx, `
+		SELECT id, mirror_id, status, since, watermark, fetched, created, updated, skipped, failed,
+			COALESCE(error, ''), started_at, finished_at
+		FROM mirror_syncs
+		WHERE mirror_id = $1
+		ORDER BY started_at DESC, id DESC
+		LIMIT $2`,
+		mirrorID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mirror syncs: %w", err)
+	}
+	defer rows.Close()
+
+	var syncs []*models.MirrorSync
+	for rows.Next() {
+		var s models.MirrorSync
+		if err := rows.Scan(
+			&s.ID, &s.MirrorID, &s.Status, &s.Since, &s.Watermark,
+			&s.Fetched, &s.Created, &s.Updated, &s.Skipped, &s.Failed,
+			&s.Error, &s.StartedAt, &s.FinishedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan mirror sync: %w", err)
+		}
+		syncs = append(syncs, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate mirror syncs: %w", err)
+	}
+	return syncs, nil
+}
+
+// AppendAuditEntry appends an entry to the audit log and sets its ID and timestamp.
+func (db *PostgreSQL) AppendAuditEntry(ctx context.Context, tx pgx.Tx, entry *models.AuditEntry) error {
+	if entry == nil || entry.Action == "" || entry.ResourceType == "" || entry.ResourceName == "" {
+		return database.ErrInvalidInput
+	}
+	var detailsJSON []byte
+	if len(entry.Details) > 0 {
+		var err error
+		if detailsJSON, err = json.Marshal(entry.Details); err != nil {
+			return fmt.Errorf("failed to marshal audit details: %w", err)
+		}
+	}
+
+	err := db.getExecutor(tx).QueryRow(ctx, `
+		INSERT INTO audit_log (principal, auth_method, action, resource_type, resource_name, resource_version,
+			before_digest, after_digest, request_id, details)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), $10)
+		RETURNING id, created_at`,
+		entry.Principal, entry.AuthMethod, entry.Action, entry.ResourceType, entry.ResourceName, entry.ResourceVersion,
+		entry.BeforeDigest, entry.AfterDigest, entry.RequestID, detailsJSON,
+	).Scan(&entry.ID, &entry.Timestamp)
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries lists audit entries matching filter, newest first. The
+// cursor is the ID of the last entry of the previous page. The log spans every
+// artifact, so only registry admins may read it.
+func (db *PostgreSQL) ListAuditEntries(ctx context.Context, tx pgx.Tx, filter *models.AuditFilter, cursor string, limit int) ([]*models.AuditEntry, string, error) {
+	if err := db.authz.CheckRegistryAdmin(ctx); err != nil {
+		return nil, "", err
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	var where []string
+	var args []any
+	addCondition := func(condition string, value any) {
+		args = append(args, value)
+		where = append(where, fmt.Sprintf(condition, len(args)))
+	}
+	if filter != nil {
+		if filter.Principal != nil {
+			addCondition("principal = $%d", *filter.Principal)
+		}
+		if filter.Action != nil {
+			addCondition("action = $%d", *filter.Action)
+		}
+		if filter.ResourceType != nil {
+			addCondition("resource_type = $%d", *filter.ResourceType)
+		}
+		if filter.ResourceName != nil {
+			addCondition("resource_name = $%d", *filter.ResourceName)
+		}
+		if filter.RequestID != nil {
+			addCondition("request_id = $%d", *filter.RequestID)
+		}
+		if filter.Since != nil {
+			addCondition("created_at >= $%d", *filter.Since)
+		}
+		if filter.Until != nil {
+			addCondition("created_at < $%d", *filter.Until)
+		}
+	}
+	if cursor != "" {
+		cursorID, err := strconv.ParseInt(cursor, 10, 64)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: invalid cursor", database.ErrInvalidInput)
+		}
+		addCondition("id < $%d", cursorID)
+	}
+
+	query := `
+		SELECT id, created_at, principal, auth_method, action, resource_type, resource_name,
+			COALESCE(resource_version, ''), COALESCE(before_digest, ''), COALESCE(after_digest, ''),
+			COALESCE(request_id, ''), details
+		FROM audit_log`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY id DESC LIMIT $%d", len(args))
+
+	rows, err := db.getExecutor(tx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.AuditEntry
+	for rows.Next() {
+		var e models.AuditEntry
+		var detailsJSON []byte
+		if err := rows.Scan(
+			&e.ID, &e.Timestamp, &e.Principal, &e.AuthMethod, &e.Action, &e.ResourceType, &e.ResourceName,
+			&e.ResourceVersion, &e.BeforeDigest, &e.AfterDigest, &e.RequestID, &detailsJSON,
+		); err != nil {
+			return nil, "", fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if len(detailsJSON) > 0 {
+			if err := json.Unmarshal(detailsJSON, &e.Details); err != nil {
+				return nil, "", fmt.Errorf("failed to unmarshal audit details: %w", err)
+			}
+		}
+		entries = append(entries, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("error iterating audit log: %w", err)
+	}
+
+	nextCursor := ""
+	if len(entries) >= limit {
+		nextCursor = strconv.FormatInt(entries[len(entries)-1].ID, 10)
+	}
+	return entries, nextCursor, nil
 }
@@ -8,6 +8,7 @@ import (
 
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	"github.com/jackc/pgx/v5"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -1024,6 +1025,158 @@ func TestPostgreSQL_UpdateDeploymentState_PatchesMetadataAndError(t *testing.T)
 	assert.Equal(t, "op-123", updated.ProviderMetadata["operationId"])
 }
 
+func TestPostgreSQL_UpdateDeploymentState_RecordsHealthWithoutTouchingUpdatedAt(t *testing.T) {
+	db := internaldb.NewTestDB(t)
+	ctx := context.Background()
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+
+	deployment := &models.Deployment{
+		ServerName:   "com.example/probed",
+		Version:      "1.0.0",
+		Status:       "deployed",
+		Env:          map[string]string{},
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		Origin:       "managed",
+	}
+	require.NoError(t, db.CreateDeployment(ctxWithAuth, nil, deployment))
+	created, err := db.GetDeploymentByID(ctxWithAuth, nil, deployment.ID)
+	require.NoError(t, err)
+	assert.Nil(t, created.Health)
+
+	toolCount := 3
+	health := &models.DeploymentHealth{
+		Healthy:   true,
+		CheckedAt: time.Now().UTC().Truncate(time.Millisecond),
+		LatencyMs: 42,
+		Endpoint:  "http://localhost:8081/mcp",
+		ToolCount: &toolCount,
+	}
+	require.NoError(t, db.UpdateDeploymentState(ctxWithAuth, nil, deployment.ID, &models.DeploymentStatePatch{
+		Health: health,
+	}))
+
+	updated, err := db.GetDeploymentByID(ctxWithAuth, nil, deployment.ID)
+	require.NoError(t, err)
+	require.NotNil(t, updated.Health)
+	assert.True(t, updated.Health.Healthy)
+	assert.Equal(t, int64(42), updated.Health.LatencyMs)
+	require.NotNil(t, updated.Health.ToolCount)
+	assert.Equal(t, 3, *updated.Health.ToolCount)
+	assert.True(t, health.CheckedAt.Equal(updated.Health.CheckedAt))
+	assert.True(t, created.UpdatedAt.Equal(updated.UpdatedAt))
+}
+
+func TestPostgreSQL_UpdateDeploymentState_Conditional(t *testing.T) {
+	db := internaldb.NewTestDB(t)
+	ctx := internaldb.WithTestSession(context.Background())
+
+	deployment := &models.Deployment{
+		ServerName:   "com.example/claimed",
+		Version:      "1.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		Env:          map[string]string{},
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		Origin:       "managed",
+	}
+	require.NoError(t, db.CreateDeployment(ctx, nil, deployment))
+	created, err := db.GetDeploymentByID(ctx, nil, deployment.ID)
+	require.NoError(t, err)
+
+	deploying := models.DeploymentStatusDeploying
+	claim := &models.DeploymentStatePatch{Status: &deploying, UnlessStatus: &deploying, IfUpdatedAt: &created.UpdatedAt}
+	require.NoError(t, db.UpdateDeploymentState(ctx, nil, deployment.ID, claim))
+	// The deployment is already being applied, so a second claim conflicts.
+	assert.ErrorIs(t, db.UpdateDeploymentState(ctx, nil, deployment.ID, claim), database.ErrConflict)
+
+	failed := models.DeploymentStatusFailed
+	require.NoError(t, db.UpdateDeploymentState(ctx, nil, deployment.ID, &models.DeploymentStatePatch{Status: &failed}))
+	// The status changed back, but updated_at moved on since the first read.
+	assert.ErrorIs(t, db.UpdateDeploymentState(ctx, nil, deployment.ID, claim), database.ErrConflict)
+
+	got, err := db.GetDeploymentByID(ctx, nil, deployment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.DeploymentStatusFailed, got.Status)
+}
+
+func TestPostgreSQL_DeploymentRevisions(t *testing.T) {
+	db := internaldb.NewTestDB(t)
+	ctx := context.Background()
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+
+	deployment := &models.Deployment{
+		ServerName:   "com.example/revised",
+		Version:      "1.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		Env:          map[string]string{},
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		Origin:       "managed",
+	}
+	require.NoError(t, db.CreateDeployment(ctxWithAuth, nil, deployment))
+	_, err := db.ListDeploymentRevisions(ctxWithAuth, nil, deployment.ID)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	first := &models.DeploymentRevision{
+		DeploymentID: deployment.ID,
+		Action:       models.DeploymentRevisionActionDeploy,
+		ResourceType: "mcp",
+		ServerName:   "com.example/revised",
+		Version:      "1.0.0",
+		ProviderID:   "local",
+		EnvKeys:      []string{"LOG_LEVEL", "API_URL"},
+		Status:       "deployed",
+	}
+	require.NoError(t, db.AppendDeploymentRevision(ctxWithAuth, nil, first))
+	assert.Equal(t, 1, first.Revision)
+	assert.Equal(t, []string{"API_URL", "LOG_LEVEL"}, first.EnvKeys)
+
+	second := &models.DeploymentRevision{
+		DeploymentID:   deployment.ID,
+		Action:         models.DeploymentRevisionActionRollback,
+		ResourceType:   "mcp",
+		ServerName:     "com.example/revised",
+		Version:        "1.0.0",
+		ProviderID:     "local",
+		Status:         "failed",
+		Error:          "boom",
+		SourceRevision: 1,
+	}
+	require.NoError(t, db.AppendDeploymentRevision(ctxWithAuth, nil, second))
+	assert.Equal(t, 2, second.Revision)
+
+	revisions, err := db.ListDeploymentRevisions(ctxWithAuth, nil, deployment.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, []string{"API_URL", "LOG_LEVEL"}, revisions[0].EnvKeys)
+	assert.Empty(t, revisions[1].EnvKeys)
+	assert.Equal(t, 1, revisions[1].SourceRevision)
+	assert.Equal(t, "boom", revisions[1].Error)
+
+	got, err := db.GetDeploymentRevision(ctxWithAuth, nil, deployment.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, models.DeploymentRevisionActionRollback, got.Action)
+	_, err = db.GetDeploymentRevision(ctxWithAuth, nil, deployment.ID, 3)
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	// Revisions of a deployment that does not exist cannot be recorded.
+	orphan := *second
+	orphan.DeploymentID = "dep-missing"
+	assert.ErrorIs(t, db.AppendDeploymentRevision(ctxWithAuth, nil, &orphan), database.ErrNotFound)
+
+	// Revisions outlive the deployment and stay append-only.
+	require.NoError(t, db.RemoveDeploymentByID(ctxWithAuth, nil, deployment.ID))
+	revisions, err = db.ListDeploymentRevisions(ctxWithAuth, nil, deployment.ID)
+	require.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	err = db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `DELETE FROM deployment_revisions WHERE deployment_id = $1`, deployment.ID)
+		return err
+	})
+	assert.ErrorContains(t, err, "append-only")
+}
+
 // Helper functions for creating pointers to basic types
 func stringPtr(s string) *string {
 	return &s
@@ -1036,3 +1189,407 @@ func boolPtr(b bool) *bool {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestPostgreSQL_SearchArtifacts(t *testing.T) {
+	db := internaldb.NewTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+		Name:        "com.example/postgres-server",
+		Description: "Query a Postgres database",
+		Version:     "1.0.0",
+	}, &apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: now, UpdatedAt: now, IsLatest: true})
+	require.NoError(t, err)
+	_, err = db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+		Name:        "com.example/weather-server",
+		Description: "Weather forecasts",
+		Version:     "1.0.0",
+	}, &apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: now, UpdatedAt: now, IsLatest: true})
+	require.NoError(t, err)
+	_, err = db.CreateAgent(ctx, nil, &models.AgentJSON{
+		AgentManifest: models.AgentManifest{Name: "dba-agent", Description: "Keeps postgres healthy"},
+		Version:       "0.1.0",
+	}, &models.AgentRegistryExtensions{Status: "active", PublishedAt: now, UpdatedAt: now, IsLatest: true})
+	require.NoError(t, err)
+	_, err = db.CreateSkill(ctx, nil, &models.SkillJSON{
+		Name:        "postgres-tuning",
+		Description: "Tune database settings",
+		Version:     "0.2.0",
+	}, &models.SkillRegistryExtensions{Status: "active", PublishedAt: now, UpdatedAt: now, IsLatest: true})
+	require.NoError(t, err)
+	_, err = db.CreatePrompt(ctx, nil, &models.PromptJSON{
+		Name:    "summarize",
+		Version: "1.0.0",
+		Content: "Summarize the input",
+	}, &models.PromptRegistryExtensions{Status: "active", PublishedAt: now, UpdatedAt: now, IsLatest: true})
+	require.NoError(t, err)
+
+	t.Run("ranks matches across artifact types", func(t *testing.T) {
+		results, next, err := db.SearchArtifacts(ctx, nil, &database.SearchFilter{Query: "postgres"}, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, next)
+		require.Len(t, results, 3)
+
+		types := map[string]string{}
+		for _, r := range results {
+			types[r.Name] = r.Type
+		}
+		assert.Equal(t, models.SearchTypeServer, types["com.example/postgres-server"])
+		assert.Equal(t, models.SearchTypeAgent, types["dba-agent"])
+		assert.Equal(t, models.SearchTypeSkill, types["postgres-tuning"])
+		// Name matches outrank description-only matches.
+		assert.Equal(t, "dba-agent", results[2].Name)
+		assert.GreaterOrEqual(t, results[0].Score, results[1].Score)
+	})
+
+	t.Run("type filter and facets", func(t *testing.T) {
+		filter := &database.SearchFilter{Query: "postgres", Types: []string{models.SearchTypeSkill}}
+		results, _, err := db.SearchArtifacts(ctx, nil, filter, "", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "postgres-tuning", results[0].Name)
+
+		facets, err := db.CountSearchArtifacts(ctx, nil, filter)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"server": 1, "agent": 1, "skill": 1, "prompt": 0}, facets)
+	})
+
+	t.Run("paginates with a single cursor", func(t *testing.T) {
+		var names []string
+		cursor := ""
+		for range 10 {
+			results, next, err := db.SearchArtifacts(ctx, nil, &database.SearchFilter{}, cursor, 2)
+			require.NoError(t, err)
+			for _, r := range results {
+				names = append(names, r.Name)
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		assert.Len(t, names, 5)
+		assert.ElementsMatch(t, []string{
+			"com.example/postgres-server", "com.example/weather-server", "dba-agent", "postgres-tuning", "summarize",
+		}, names)
+	})
+
+	t.Run("hides deleted versions unless requested", func(t *testing.T) {
+		_, err := db.CreatePrompt(ctx, nil, &models.PromptJSON{
+			Name:    "postgres-report",
+			Version: "1.0.0",
+			Content: "Report on postgres",
+		}, &models.PromptRegistryExtensions{Status: "deleted", PublishedAt: now, UpdatedAt: now, IsLatest: true})
+		require.NoError(t, err)
+
+		filter := &database.SearchFilter{Query: "postgres", Types: []string{models.SearchTypePrompt}}
+		results, _, err := db.SearchArtifacts(ctx, nil, filter, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		filter.IncludeDeleted = true
+		results, _, err = db.SearchArtifacts(ctx, nil, filter, "", 10)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "postgres-report", results[0].Name)
+	})
+
+	t.Run("rejects unknown types and cursors", func(t *testing.T) {
+		_, _, err := db.SearchArtifacts(ctx, nil, &database.SearchFilter{Types: []string{"widget"}}, "", 10)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+		_, _, err = db.SearchArtifacts(ctx, nil, &database.SearchFilter{}, "not-a-cursor!", 10)
+		assert.ErrorIs(t, err, database.ErrInvalidInput)
+	})
+}
+
+func TestPostgreSQL_Mirrors(t *testing.T) {
+	db := internaldb.NewTestDB(t)
+	ctx := internaldb.WithTestSession(context.Background())
+
+	// Only registry admins manage mirrors.
+	publisherCtx := auth.AuthSessionTo(context.Background(), &readSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.acme/*"},
+	}})
+	_, err := db.CreateMirror(publisherCtx, nil, &models.CreateMirrorInput{ID: "official", URL: "https://example.com"})
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	_, err = db.CreateMirror(context.Background(), nil, &models.CreateMirrorInput{ID: "official", URL: "https://example.com"})
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+
+	mirror, err := db.CreateMirror(ctx, nil, &models.CreateMirrorInput{
+		ID:      "official",
+		URL:     "https://registry.modelcontextprotocol.io",
+		Include: []string{"io.github.*"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"subject":"test-user","permissions":[{"action":"edit","resource":"*"}]}`, string(mirror.CreatedBy))
+	assert.Equal(t, models.DefaultMirrorIntervalSeconds, mirror.IntervalSeconds)
+	assert.True(t, mirror.Enabled)
+	assert.Equal(t, []string{"io.github.*"}, mirror.Include)
+	assert.Empty(t, mirror.Exclude)
+	assert.Nil(t, mirror.Watermark)
+
+	_, err = db.CreateMirror(ctx, nil, &models.CreateMirrorInput{ID: "official", URL: "https://example.com"})
+	assert.ErrorIs(t, err, database.ErrAlreadyExists)
+
+	watermark := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+	first := &models.MirrorSync{
+		MirrorID:   "official",
+		Status:     models.MirrorSyncStatusCompleted,
+		Watermark:  &watermark,
+		Fetched:    3,
+		Created:    3,
+		StartedAt:  time.Now().Add(-2 * time.Minute),
+		FinishedAt: time.Now().Add(-time.Minute),
+	}
+	require.NoError(t, db.RecordMirrorSync(ctx, nil, first))
+	assert.NotZero(t, first.ID)
+
+	// A failed sync without a watermark keeps the previous one.
+	second := &models.MirrorSync{
+		MirrorID:   "official",
+		Status:     models.MirrorSyncStatusFailed,
+		Since:      &watermark,
+		Error:      "upstream unavailable",
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+	}
+	require.NoError(t, db.RecordMirrorSync(ctx, nil, second))
+
+	mirror, err = db.GetMirrorByID(ctx, nil, "official")
+	require.NoError(t, err)
+	require.NotNil(t, mirror.Watermark)
+	assert.True(t, watermark.Equal(*mirror.Watermark))
+	require.NotNil(t, mirror.LastSyncedAt)
+
+	syncs, err := db.ListMirrorSyncs(ctx, nil, "official", 10)
+	require.NoError(t, err)
+	require.Len(t, syncs, 2)
+	assert.Equal(t, models.MirrorSyncStatusFailed, syncs[0].Status)
+	assert.Equal(t, "upstream unavailable", syncs[0].Error)
+	assert.Equal(t, 3, syncs[1].Created)
+
+	assert.ErrorIs(t, db.DeleteMirror(publisherCtx, nil, "official"), auth.ErrForbidden)
+	require.NoError(t, db.DeleteMirror(ctx, nil, "official"))
+	_, err = db.GetMirrorByID(ctx, nil, "official")
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	assert.ErrorIs(t, db.RecordMirrorSync(ctx, nil, first), database.ErrNotFound)
+}
+
+func TestPostgreSQL_Secrets(t *testing.T) {
+	db := internaldb.NewTestDB(t)
+	ctx := internaldb.WithTestSession(context.Background())
+
+	stored := &database.EncryptedSecret{
+		Secret:     models.Secret{Name: "openai-key", Description: "OpenAI API key", KeyID: "k1"},
+		Ciphertext: []byte("sealed"),
+		WrappedKey: []byte("wrapped"),
+	}
+
+	// Secrets are never public, and untyped permissions do not cover them.
+	_, err := db.CreateSecret(context.Background(), nil, stored)
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+	publisherCtx := auth.AuthSessionTo(context.Background(), &readSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "openai-*"},
+		{Action: auth.PermissionActionRead, ResourcePattern: "openai-*"},
+	}})
+	_, err = db.CreateSecret(publisherCtx, nil, stored)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	created, err := db.CreateSecret(ctx, nil, stored)
+	require.NoError(t, err)
+	assert.Equal(t, "openai-key", created.Name)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	_, err = db.CreateSecret(ctx, nil, stored)
+	assert.ErrorIs(t, err, database.ErrAlreadyExists)
+
+	stored.Ciphertext = []byte("resealed")
+	_, err = db.UpdateSecret(ctx, nil, stored)
+	require.NoError(t, err)
+	got, err := db.GetSecret(ctx, nil, "openai-key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("resealed"), got.Ciphertext)
+	assert.Equal(t, []byte("wrapped"), got.WrappedKey)
+
+	_, err = db.GetSecret(publisherCtx, nil, "openai-key")
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	readerCtx := auth.AuthSessionTo(context.Background(), &readSession{permissions: []auth.Permission{
+		auth.ParsePermission(auth.PermissionActionRead, "secret:openai-*"),
+	}})
+	_, err = db.GetSecret(readerCtx, nil, "openai-key")
+	require.NoError(t, err)
+	_, err = db.UpdateSecret(readerCtx, nil, stored)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	assert.ErrorIs(t, db.DeleteSecret(readerCtx, nil, "openai-key"), auth.ErrForbidden)
+	_, err = db.ListSecrets(readerCtx, nil)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	deployment := &models.Deployment{
+		ServerName:   "com.example/weather",
+		Version:      "1.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		SecretRefs:   map[string]string{"OPENAI_API_KEY": "openai-key"},
+	}
+	require.NoError(t, db.CreateDeployment(ctx, nil, deployment))
+	fetched, err := db.GetDeploymentByID(ctx, nil, deployment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, deployment.SecretRefs, fetched.SecretRefs)
+
+	assert.ErrorIs(t, db.DeleteSecret(ctx, nil, "openai-key"), database.ErrInUse)
+	require.NoError(t, db.RemoveDeploymentByID(ctx, nil, deployment.ID))
+	require.NoError(t, db.DeleteSecret(ctx, nil, "openai-key"))
+	assert.ErrorIs(t, db.DeleteSecret(ctx, nil, "openai-key"), database.ErrNotFound)
+
+	secrets, err := db.ListSecrets(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+}
+
+func TestPostgreSQL_AuditLog(t *testing.T) {
+	db := internaldb.NewTestDB(t)
+	ctx := context.Background()
+
+	for i, action := range []string{models.AuditActionPublish, models.AuditActionEdit, models.AuditActionDelete} {
+		entry := &models.AuditEntry{
+			Principal:       "octocat",
+			AuthMethod:      "github-at",
+			Action:          action,
+			ResourceType:    models.AuditResourceServer,
+			ResourceName:    "io.github.octocat/weather",
+			ResourceVersion: "1.0.0",
+			AfterDigest:     fmt.Sprintf("sha256:%064d", i),
+			RequestID:       fmt.Sprintf("req-%d", i),
+		}
+		require.NoError(t, db.AppendAuditEntry(ctx, nil, entry))
+		assert.NotZero(t, entry.ID)
+		assert.False(t, entry.Timestamp.IsZero())
+	}
+	require.NoError(t, db.AppendAuditEntry(ctx, nil, &models.AuditEntry{
+		Principal:    "system",
+		AuthMethod:   "system",
+		Action:       models.AuditActionCreate,
+		ResourceType: models.AuditResourceProvider,
+		ResourceName: "local",
+		Details:      models.JSONObject{"platform": "local"},
+	}))
+	assert.ErrorIs(t, db.AppendAuditEntry(ctx, nil, &models.AuditEntry{Principal: "x"}), database.ErrInvalidInput)
+
+	// Reading the log requires a registry admin.
+	_, _, err := db.ListAuditEntries(ctx, nil, nil, "", 2)
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+	ctx = internaldb.WithTestSession(ctx)
+
+	entries, cursor, err := db.ListAuditEntries(ctx, nil, nil, "", 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, models.AuditResourceProvider, entries[0].ResourceType)
+	assert.Equal(t, "local", entries[0].Details["platform"])
+	assert.Equal(t, models.AuditActionDelete, entries[1].Action)
+	require.NotEmpty(t, cursor)
+
+	entries, _, err = db.ListAuditEntries(ctx, nil, nil, cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, models.AuditActionPublish, entries[1].Action)
+
+	principal := "octocat"
+	action := models.AuditActionEdit
+	entries, _, err = db.ListAuditEntries(ctx, nil, &models.AuditFilter{Principal: &principal, Action: &action}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "req-1", entries[0].RequestID)
+	assert.Equal(t, "1.0.0", entries[0].ResourceVersion)
+
+	_, _, err = db.ListAuditEntries(ctx, nil, nil, "not-a-cursor", 10)
+	assert.ErrorIs(t, err, database.ErrInvalidInput)
+
+	// The log is append-only.
+	err = db.InTransaction(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `DELETE FROM audit_log`)
+		return err
+	})
+	assert.ErrorContains(t, err, "append-only")
+}
+
+// readSession is a session holding only the given permissions.
+type readSession struct {
+	permissions []auth.Permission
+}
+
+func (s *readSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: "reader", Permissions: s.permissions}}
+}
+
+func TestPostgreSQL_ReadFilteredLists(t *testing.T) {
+	db := internaldb.NewTestDB(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, name := range []string{"io.github.acme/alpha", "io.github.acme/beta", "io.github.acme/gamma", "io.github.other/delta", "io.github.acme_x/epsilon"} {
+		_, err := db.CreateServer(ctx, nil, &apiv0.ServerJSON{
+			Name:        name,
+			Description: "Read filter server",
+			Version:     "1.0.0",
+		}, &apiv0.RegistryExtensions{Status: model.StatusActive, PublishedAt: now, UpdatedAt: now, IsLatest: true})
+		require.NoError(t, err)
+	}
+	_, err := db.CreateAgent(ctx, nil, &models.AgentJSON{
+		AgentManifest: models.AgentManifest{Name: "io.github.acme/agent", Description: "Read filter agent"},
+		Version:       "1.0.0",
+	}, &models.AgentRegistryExtensions{Status: "active", PublishedAt: now, UpdatedAt: now, IsLatest: true})
+	require.NoError(t, err)
+
+	publicRead := auth.PublicActions[auth.PermissionActionRead]
+	auth.PublicActions[auth.PermissionActionRead] = false
+	t.Cleanup(func() { auth.PublicActions[auth.PermissionActionRead] = publicRead })
+
+	readerCtx := auth.AuthSessionTo(ctx, &readSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "io.github.acme/*", ResourceType: auth.PermissionArtifactTypeServer},
+		{Action: auth.PermissionActionRead, ResourcePattern: "io.github.other/delta"},
+	}})
+
+	t.Run("lists only readable servers across pages", func(t *testing.T) {
+		var names []string
+		cursor := ""
+		for range 10 {
+			servers, next, err := db.ListServers(readerCtx, nil, nil, cursor, 2)
+			require.NoError(t, err)
+			for _, s := range servers {
+				names = append(names, s.Server.Name)
+			}
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+		// "_" in the pattern is literal, so io.github.acme_x is not readable.
+		assert.Equal(t, []string{"io.github.acme/alpha", "io.github.acme/beta", "io.github.acme/gamma", "io.github.other/delta"}, names)
+	})
+
+	t.Run("typed permissions do not cover other artifact types", func(t *testing.T) {
+		agents, _, err := db.ListAgents(readerCtx, nil, nil, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, agents)
+	})
+
+	t.Run("search results and facets are filtered", func(t *testing.T) {
+		filter := &database.SearchFilter{Query: "read filter"}
+		results, _, err := db.SearchArtifacts(readerCtx, nil, filter, "", 10)
+		require.NoError(t, err)
+		assert.Len(t, results, 4)
+
+		facets, err := db.CountSearchArtifacts(readerCtx, nil, filter)
+		require.NoError(t, err)
+		assert.Equal(t, 4, facets["server"])
+		assert.Equal(t, 0, facets["agent"])
+	})
+
+	t.Run("anonymous callers see nothing", func(t *testing.T) {
+		servers, _, err := db.ListServers(ctx, nil, nil, "", 10)
+		require.NoError(t, err)
+		assert.Empty(t, servers)
+	})
+}
@@ -27,6 +27,7 @@ type testSession struct{}
 func (s *testSession) Principal() auth.Principal {
 	return auth.Principal{
 		User: auth.User{
+			Subject: "test-user",
 			Permissions: []auth.Permission{
 				{
 					Action:          auth.PermissionActionEdit,
@@ -0,0 +1,101 @@
+package embeddings
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+)
+
+const hashModel = "bag-of-words-v1"
+
+// hashProvider is an offline provider that maps text to a bag-of-words vector
+// using feature hashing. It is deterministic and needs no network access, so
+// CI and air-gapped installs can exercise semantic search end to end. Texts
+// that share words end up close to each other; it does not capture synonyms.
+type hashProvider struct {
+	dimensions int
+}
+
+func newHashProvider(cfg *config.EmbeddingsConfig) Provider {
+	dimensions := cfg.Dimensions
+	if dimensions <= 0 {
+		dimensions = 1536
+	}
+	return &hashProvider{dimensions: dimensions}
+}
+
+func (p *hashProvider) Generate(ctx context.Context, payload Payload) (*Result, error) {
+	results, err := p.GenerateBatch(ctx, []Payload{payload})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (p *hashProvider) GenerateBatch(ctx context.Context, payloads []Payload) ([]*Result, error) {
+	vectors, err := chunkPayloads(ctx, payloads, max(len(payloads), 1), func(_ context.Context, texts []string) ([][]float32, error) {
+		vectors := make([][]float32, len(texts))
+		for i, text := range texts {
+			vectors[i] = p.vector(text)
+		}
+		return vectors, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vectorResults(vectors, ProviderHash, hashModel), nil
+}
+
+// vector hashes each token and adjacent token pair into a signed bucket,
+// weights counts sublinearly and L2-normalises the result so cosine distance
+// behaves like it does for model embeddings.
+func (p *hashProvider) vector(text string) []float32 {
+	counts := make(map[string]int)
+	tokens := hashTokens(text)
+	for i, token := range tokens {
+		counts[token]++
+		if i > 0 {
+			counts[tokens[i-1]+" "+token]++
+		}
+	}
+
+	values := make([]float64, p.dimensions)
+	for feature, count := range counts {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(feature))
+		sum := h.Sum64()
+		bucket := int(sum % uint64(p.dimensions))
+		weight := 1 + math.Log(float64(count))
+		if sum&(1<<63) != 0 {
+			weight = -weight
+		}
+		values[bucket] += weight
+	}
+
+	var norm float64
+	for _, v := range values {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+
+	vector := make([]float32, p.dimensions)
+	if norm == 0 {
+		return vector
+	}
+	for i, v := range values {
+		vector[i] = float32(v / norm)
+	}
+	return vector
+}
+
+// hashTokens lower-cases text and splits it on anything that is not a letter
+// or digit, so "io.github.user/postgres-mcp" yields its individual words.
+func hashTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
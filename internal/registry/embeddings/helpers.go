@@ -63,6 +63,42 @@ func BuildAgentEmbeddingPayload(agent *models.AgentJSON) string {
 	return strings.Join(parts, "\n")
 }
 
+// BuildSkillEmbeddingPayload builds the embedding payload for a skill. Name and
+// description come from the SKILL.md frontmatter the skill was published from.
+func BuildSkillEmbeddingPayload(skill *models.SkillJSON) string {
+	if skill == nil {
+		return ""
+	}
+
+	var parts []string
+	appendIf(&parts,
+		skill.Name,
+		skill.Title,
+		skill.Category,
+		skill.Description,
+		skill.Version,
+		skill.WebsiteURL,
+	)
+	appendJSON(&parts, skill.Repository)
+	appendJSONArray(&parts, skill.Packages)
+	appendJSONArray(&parts, skill.Remotes)
+
+	return strings.Join(parts, "\n")
+}
+
+// BuildPromptEmbeddingPayload builds the embedding payload for a prompt,
+// including the prompt content itself.
+func BuildPromptEmbeddingPayload(prompt *models.PromptJSON) string {
+	if prompt == nil {
+		return ""
+	}
+
+	var parts []string
+	appendIf(&parts, prompt.Name, prompt.Description, prompt.Version, prompt.Content)
+
+	return strings.Join(parts, "\n")
+}
+
 // PayloadChecksum returns the deterministic checksum for an embedding payload.
 func PayloadChecksum(payload string) string {
 	sum := sha256.Sum256([]byte(payload))
@@ -84,6 +120,50 @@ func GenerateSemanticEmbedding(ctx context.Context, provider Provider, payload s
 	if err != nil {
 		return nil, err
 	}
+	return toSemanticEmbedding(result, payload, expectedDimensions)
+}
+
+// GenerateSemanticEmbeddings is the batch form of GenerateSemanticEmbedding. It
+// embeds all payloads with a single provider call and returns the embeddings in
+// payload order. Every payload must be non-empty.
+func GenerateSemanticEmbeddings(ctx context.Context, provider Provider, payloads []string, expectedDimensions int) ([]*database.SemanticEmbedding, error) {
+	if provider == nil {
+		return nil, errors.New("embedding provider is not configured")
+	}
+	if len(payloads) == 0 {
+		return nil, nil
+	}
+
+	batch := make([]Payload, len(payloads))
+	for i, payload := range payloads {
+		if strings.TrimSpace(payload) == "" {
+			return nil, errors.New("embedding payload is empty")
+		}
+		batch[i] = Payload{Text: payload}
+	}
+
+	results, err := provider.GenerateBatch(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(payloads) {
+		return nil, fmt.Errorf("embedding provider returned %d results for %d payloads", len(results), len(payloads))
+	}
+
+	records := make([]*database.SemanticEmbedding, len(results))
+	for i, result := range results {
+		records[i], err = toSemanticEmbedding(result, payloads[i], expectedDimensions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+func toSemanticEmbedding(result *Result, payload string, expectedDimensions int) (*database.SemanticEmbedding, error) {
+	if result == nil {
+		return nil, errors.New("embedding provider returned no result")
+	}
 
 	dims := result.Dimensions
 	if dims == 0 {
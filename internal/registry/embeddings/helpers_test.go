@@ -0,0 +1,41 @@
+package embeddings
+
+import (
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildSkillEmbeddingPayload(t *testing.T) {
+	assert.Empty(t, BuildSkillEmbeddingPayload(nil))
+
+	payload := BuildSkillEmbeddingPayload(&models.SkillJSON{
+		Name:        "pdf-tools",
+		Category:    "documents",
+		Description: "Extract text and tables from PDF files",
+		Version:     "1.0.0",
+		Repository:  &models.SkillRepository{URL: "https://github.com/example/pdf-tools", Source: "github"},
+	})
+	assert.Contains(t, payload, "pdf-tools")
+	assert.Contains(t, payload, "Extract text and tables from PDF files")
+	assert.Contains(t, payload, "documents")
+	assert.Contains(t, payload, "https://github.com/example/pdf-tools")
+}
+
+func TestBuildPromptEmbeddingPayload(t *testing.T) {
+	assert.Empty(t, BuildPromptEmbeddingPayload(nil))
+
+	prompt := &models.PromptJSON{
+		Name:        "reviewer",
+		Description: "Code review system prompt",
+		Version:     "1.0.0",
+		Content:     "You are a careful reviewer of Go pull requests.",
+	}
+	payload := BuildPromptEmbeddingPayload(prompt)
+	assert.Contains(t, payload, "Code review system prompt")
+	assert.Contains(t, payload, "You are a careful reviewer of Go pull requests.")
+
+	prompt.Content = "You are a lenient reviewer."
+	assert.NotEqual(t, PayloadChecksum(payload), PayloadChecksum(BuildPromptEmbeddingPayload(prompt)))
+}
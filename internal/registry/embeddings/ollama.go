@@ -0,0 +1,117 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+)
+
+const defaultOllamaBaseURL = "http://localhost:11434"
+
+// ollamaProvider uses the Ollama /api/embed endpoint, which accepts a batch of
+// inputs per request.
+type ollamaProvider struct {
+	cfg        *config.EmbeddingsConfig
+	httpClient *http.Client
+	baseURL    string
+}
+
+type ollamaEmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type ollamaEmbedResponse struct {
+	Embeddings [][]float64 `json:"embeddings"`
+	Error      string      `json:"error,omitempty"`
+}
+
+func newOllamaProvider(cfg *config.EmbeddingsConfig, httpClient *http.Client) Provider {
+	baseURL := strings.TrimSpace(cfg.BaseURL)
+	if baseURL == "" {
+		baseURL = defaultOllamaBaseURL
+	}
+	return &ollamaProvider{
+		cfg:        cfg,
+		httpClient: defaultHTTPClient(httpClient),
+		baseURL:    baseURL,
+	}
+}
+
+func (p *ollamaProvider) Generate(ctx context.Context, payload Payload) (*Result, error) {
+	results, err := p.GenerateBatch(ctx, []Payload{payload})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (p *ollamaProvider) GenerateBatch(ctx context.Context, payloads []Payload) ([]*Result, error) {
+	if len(payloads) == 0 {
+		return nil, nil
+	}
+	vectors, err := chunkPayloads(ctx, payloads, batchSize(p.cfg), p.embed)
+	if err != nil {
+		return nil, err
+	}
+	return vectorResults(vectors, ProviderOllama, p.cfg.Model), nil
+}
+
+func (p *ollamaProvider) embed(ctx context.Context, texts []string) ([][]float32, error) {
+	reqBody, err := json.Marshal(ollamaEmbedRequest{
+		Model: p.cfg.Model,
+		Input: texts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal embedding request: %w", err)
+	}
+
+	endpoint := strings.TrimRight(p.baseURL, "/") + "/api/embed"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embedding request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Ollama itself is unauthenticated; keys are for reverse proxies in front of it.
+	setAPIKey(req, p.cfg.AuthHeader, p.cfg.APIKey)
+	for key, value := range p.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embedding request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedding response: %w", err)
+	}
+
+	var parsed ollamaEmbedResponse
+	if resp.StatusCode >= 400 {
+		if json.Unmarshal(body, &parsed) == nil && parsed.Error != "" {
+			return nil, fmt.Errorf("embedding provider returned %d: %s", resp.StatusCode, parsed.Error)
+		}
+		return nil, fmt.Errorf("embedding provider returned %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode embedding response: %w", err)
+	}
+	if parsed.Error != "" {
+		return nil, fmt.Errorf("embedding provider error: %s", parsed.Error)
+	}
+
+	vectors := make([][]float32, len(parsed.Embeddings))
+	for i, embedding := range parsed.Embeddings {
+		vectors[i] = toFloat32(embedding)
+	}
+	return vectors, nil
+}
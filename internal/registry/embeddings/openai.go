@@ -13,14 +13,22 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 )
 
+// openAIProvider talks to the OpenAI embeddings API or to any server that
+// implements the same /embeddings contract (vLLM, LocalAI, LM Studio, TEI, ...).
 type openAIProvider struct {
 	cfg        *config.EmbeddingsConfig
 	httpClient *http.Client
+
+	name       string
+	baseURL    string
+	apiKey     string
+	authHeader string
+	requireKey bool
 }
 
 type openAIEmbeddingRequest struct {
-	Input string `json:"input"`
-	Model string `json:"model"`
+	Input []string `json:"input"`
+	Model string   `json:"model"`
 }
 
 type openAIEmbeddingResponse struct {
@@ -35,45 +43,86 @@ type openAIEmbeddingResponse struct {
 	} `json:"error"`
 }
 
-func newOpenAIProvider(cfg *config.EmbeddingsConfig, httpClient *http.Client) Provider {
+func defaultHTTPClient(httpClient *http.Client) *http.Client {
 	if httpClient == nil {
-		httpClient = &http.Client{
+		return &http.Client{
 			Timeout: 30 * time.Second,
 		}
 	}
+	return httpClient
+}
+
+func newOpenAIProvider(cfg *config.EmbeddingsConfig, httpClient *http.Client) Provider {
 	return &openAIProvider{
 		cfg:        cfg,
-		httpClient: httpClient,
+		httpClient: defaultHTTPClient(httpClient),
+		name:       ProviderOpenAI,
+		baseURL:    cfg.OpenAIBaseURL,
+		apiKey:     cfg.OpenAIAPIKey,
+		authHeader: "Authorization",
+		requireKey: true,
+	}
+}
+
+func newOpenAICompatibleProvider(cfg *config.EmbeddingsConfig, httpClient *http.Client) Provider {
+	return &openAIProvider{
+		cfg:        cfg,
+		httpClient: defaultHTTPClient(httpClient),
+		name:       ProviderOpenAICompatible,
+		baseURL:    cfg.BaseURL,
+		apiKey:     cfg.APIKey,
+		authHeader: cfg.AuthHeader,
 	}
 }
 
 func (p *openAIProvider) Generate(ctx context.Context, payload Payload) (*Result, error) {
-	if payload.Text == "" {
-		return nil, fmt.Errorf("embedding payload text cannot be empty")
+	results, err := p.GenerateBatch(ctx, []Payload{payload})
+	if err != nil {
+		return nil, err
 	}
-	if p.cfg.OpenAIAPIKey == "" {
+	return results[0], nil
+}
+
+func (p *openAIProvider) GenerateBatch(ctx context.Context, payloads []Payload) ([]*Result, error) {
+	if len(payloads) == 0 {
+		return nil, nil
+	}
+	if p.requireKey && p.apiKey == "" {
 		return nil, fmt.Errorf("OPENAI_API_KEY is required when embeddings are enabled")
 	}
 
+	vectors, err := chunkPayloads(ctx, payloads, batchSize(p.cfg), p.embed)
+	if err != nil {
+		return nil, err
+	}
+	return vectorResults(vectors, p.name, p.cfg.Model), nil
+}
+
+func (p *openAIProvider) embed(ctx context.Context, texts []string) ([][]float32, error) {
 	reqBody, err := json.Marshal(openAIEmbeddingRequest{
-		Input: payload.Text,
+		Input: texts,
 		Model: p.cfg.Model,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal embedding request: %w", err)
 	}
 
-	endpoint := strings.TrimRight(p.cfg.OpenAIBaseURL, "/") + "/embeddings"
+	endpoint := strings.TrimRight(p.baseURL, "/") + "/embeddings"
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create embedding request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+p.cfg.OpenAIAPIKey)
-	if p.cfg.OpenAIOrg != "" {
+	setAPIKey(req, p.authHeader, p.apiKey)
+	if p.name == ProviderOpenAI && p.cfg.OpenAIOrg != "" {
 		req.Header.Set("OpenAI-Organization", p.cfg.OpenAIOrg)
 	}
+	if p.name != ProviderOpenAI {
+		for key, value := range p.cfg.Headers {
+			req.Header.Set(key, value)
+		}
+	}
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
@@ -101,16 +150,22 @@ func (p *openAIProvider) Generate(ctx context.Context, payload Payload) (*Result
 		return nil, fmt.Errorf("embedding provider returned no data")
 	}
 
-	vector := make([]float32, len(parsed.Data[0].Embedding))
-	for i, value := range parsed.Data[0].Embedding {
-		vector[i] = float32(value)
+	// Entries carry their input index; servers are not required to keep order.
+	vectors := make([][]float32, len(texts))
+	for i, item := range parsed.Data {
+		index := item.Index
+		if index < 0 || index >= len(texts) {
+			index = i
+		}
+		if index >= len(vectors) {
+			return nil, fmt.Errorf("embedding provider returned %d vectors for %d inputs", len(parsed.Data), len(texts))
+		}
+		vectors[index] = toFloat32(item.Embedding)
 	}
-
-	return &Result{
-		Vector:      vector,
-		Provider:    "openai",
-		Model:       p.cfg.Model,
-		Dimensions:  len(vector),
-		GeneratedAt: time.Now().UTC(),
-	}, nil
+	for i, vector := range vectors {
+		if vector == nil {
+			return nil, fmt.Errorf("embedding provider returned no vector for input %d", i)
+		}
+	}
+	return vectors, nil
 }
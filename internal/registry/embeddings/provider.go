@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
@@ -28,18 +29,109 @@ type Result struct {
 // Provider defines the interface every embedding provider must implement.
 type Provider interface {
 	Generate(ctx context.Context, payload Payload) (*Result, error)
+	// GenerateBatch embeds several payloads, returning one result per payload
+	// in the same order.
+	GenerateBatch(ctx context.Context, payloads []Payload) ([]*Result, error)
 }
 
+// Supported provider names.
+const (
+	ProviderOpenAI           = "openai"
+	ProviderOpenAICompatible = "openai-compatible"
+	ProviderOllama           = "ollama"
+	ProviderHash             = "hash"
+)
+
+const defaultBatchSize = 64
+
 // Factory creates a Provider from configuration.
 func Factory(cfg *config.EmbeddingsConfig, httpClient *http.Client) (Provider, error) {
 	if cfg == nil || !cfg.Enabled {
 		return nil, errors.New("embeddings disabled")
 	}
 
-	switch cfg.Provider {
-	case "", "openai":
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "", ProviderOpenAI:
 		return newOpenAIProvider(cfg, httpClient), nil
+	case ProviderOpenAICompatible:
+		if strings.TrimSpace(cfg.BaseURL) == "" {
+			return nil, fmt.Errorf("EMBEDDINGS_BASE_URL is required for the %s embeddings provider", ProviderOpenAICompatible)
+		}
+		return newOpenAICompatibleProvider(cfg, httpClient), nil
+	case ProviderOllama:
+		return newOllamaProvider(cfg, httpClient), nil
+	case ProviderHash:
+		return newHashProvider(cfg), nil
 	default:
 		return nil, fmt.Errorf("unsupported embeddings provider %q", cfg.Provider)
 	}
 }
+
+// setAPIKey sends key in header: as "Bearer <key>" for Authorization, or
+// verbatim for any other header (e.g. api-key). Empty keys are not sent.
+func setAPIKey(req *http.Request, header, key string) {
+	if key == "" {
+		return
+	}
+	header = strings.TrimSpace(header)
+	if header == "" || strings.EqualFold(header, "Authorization") {
+		req.Header.Set("Authorization", "Bearer "+key)
+		return
+	}
+	req.Header.Set(header, key)
+}
+
+func batchSize(cfg *config.EmbeddingsConfig) int {
+	if cfg != nil && cfg.BatchSize > 0 {
+		return cfg.BatchSize
+	}
+	return defaultBatchSize
+}
+
+// chunkPayloads splits payloads into request-sized batches and concatenates
+// the results of embed for each batch.
+func chunkPayloads(ctx context.Context, payloads []Payload, size int, embed func(ctx context.Context, texts []string) ([][]float32, error)) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(payloads))
+	for start := 0; start < len(payloads); start += size {
+		end := min(start+size, len(payloads))
+		texts := make([]string, 0, end-start)
+		for _, payload := range payloads[start:end] {
+			if payload.Text == "" {
+				return nil, fmt.Errorf("embedding payload text cannot be empty")
+			}
+			texts = append(texts, payload.Text)
+		}
+		batch, err := embed(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) != len(texts) {
+			return nil, fmt.Errorf("embedding provider returned %d vectors for %d inputs", len(batch), len(texts))
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+func vectorResults(vectors [][]float32, provider, model string) []*Result {
+	now := time.Now().UTC()
+	results := make([]*Result, len(vectors))
+	for i, vector := range vectors {
+		results[i] = &Result{
+			Vector:      vector,
+			Provider:    provider,
+			Model:       model,
+			Dimensions:  len(vector),
+			GeneratedAt: now,
+		}
+	}
+	return results
+}
+
+func toFloat32(values []float64) []float32 {
+	vector := make([]float32, len(values))
+	for i, value := range values {
+		vector[i] = float32(value)
+	}
+	return vector
+}
@@ -0,0 +1,184 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactory_Providers(t *testing.T) {
+	for _, name := range []string{"", "openai", "ollama", "hash", "HASH"} {
+		provider, err := Factory(&config.EmbeddingsConfig{Enabled: true, Provider: name}, nil)
+		require.NoError(t, err, name)
+		assert.NotNil(t, provider, name)
+	}
+
+	_, err := Factory(&config.EmbeddingsConfig{Enabled: true, Provider: "openai-compatible"}, nil)
+	assert.ErrorContains(t, err, "EMBEDDINGS_BASE_URL")
+
+	_, err = Factory(&config.EmbeddingsConfig{Enabled: true, Provider: "cohere"}, nil)
+	assert.ErrorContains(t, err, "unsupported")
+
+	_, err = Factory(&config.EmbeddingsConfig{Enabled: false}, nil)
+	assert.Error(t, err)
+}
+
+func TestOpenAICompatibleProvider_BatchWithCustomAuth(t *testing.T) {
+	var requests []openAIEmbeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("api-key"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+		assert.Equal(t, "tenant-a", r.Header.Get("X-Tenant"))
+
+		var req openAIEmbeddingRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests = append(requests, req)
+
+		// Reply out of order; results must be matched by index.
+		type item struct {
+			Embedding []float64 `json:"embedding"`
+			Index     int       `json:"index"`
+		}
+		var data []item
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			data = append(data, item{Embedding: []float64{float64(len(req.Input[i])), 0}, Index: i})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer server.Close()
+
+	provider, err := Factory(&config.EmbeddingsConfig{
+		Enabled:    true,
+		Provider:   "openai-compatible",
+		Model:      "local-model",
+		BaseURL:    server.URL + "/v1",
+		APIKey:     "secret",
+		AuthHeader: "api-key",
+		Headers:    map[string]string{"X-Tenant": "tenant-a"},
+		BatchSize:  2,
+	}, server.Client())
+	require.NoError(t, err)
+
+	results, err := provider.GenerateBatch(context.Background(), []Payload{{Text: "a"}, {Text: "bb"}, {Text: "ccc"}})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for i, result := range results {
+		assert.Equal(t, float32(i+1), result.Vector[0])
+		assert.Equal(t, "openai-compatible", result.Provider)
+		assert.Equal(t, "local-model", result.Model)
+	}
+
+	require.Len(t, requests, 2, "batch size caps inputs per request")
+	assert.Equal(t, []string{"a", "bb"}, requests[0].Input)
+	assert.Equal(t, []string{"ccc"}, requests[1].Input)
+}
+
+func TestOllamaProvider_GenerateBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/embed", r.URL.Path)
+		assert.Equal(t, "proxy-key", r.Header.Get("X-Proxy-Key"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+		var req ollamaEmbedRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "nomic-embed-text", req.Model)
+
+		embeddings := make([][]float64, len(req.Input))
+		for i := range req.Input {
+			embeddings[i] = []float64{float64(i), 1}
+		}
+		_ = json.NewEncoder(w).Encode(ollamaEmbedResponse{Embeddings: embeddings})
+	}))
+	defer server.Close()
+
+	provider, err := Factory(&config.EmbeddingsConfig{
+		Enabled:    true,
+		Provider:   "ollama",
+		Model:      "nomic-embed-text",
+		BaseURL:    server.URL,
+		APIKey:     "proxy-key",
+		AuthHeader: "X-Proxy-Key",
+	}, server.Client())
+	require.NoError(t, err)
+
+	results, err := provider.GenerateBatch(context.Background(), []Payload{{Text: "one"}, {Text: "two"}})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, []float32{1, 1}, results[1].Vector)
+	assert.Equal(t, "ollama", results[1].Provider)
+
+	result, err := provider.Generate(context.Background(), Payload{Text: "one"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Dimensions)
+}
+
+func TestOllamaProvider_ReportsServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"model \"missing\" not found, try pulling it first"}`))
+	}))
+	defer server.Close()
+
+	provider, err := Factory(&config.EmbeddingsConfig{Enabled: true, Provider: "ollama", Model: "missing", BaseURL: server.URL}, server.Client())
+	require.NoError(t, err)
+
+	_, err = provider.Generate(context.Background(), Payload{Text: "x"})
+	assert.ErrorContains(t, err, "try pulling it first")
+}
+
+func cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+func TestHashProvider_DeterministicAndSimilarityPreserving(t *testing.T) {
+	provider, err := Factory(&config.EmbeddingsConfig{Enabled: true, Provider: "hash", Dimensions: 1536}, nil)
+	require.NoError(t, err)
+
+	results, err := provider.GenerateBatch(context.Background(), []Payload{
+		{Text: "PostgreSQL database MCP server for SQL queries"},
+		{Text: "query a postgresql database with sql"},
+		{Text: "weather forecast for your city"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	again, err := provider.Generate(context.Background(), Payload{Text: "PostgreSQL database MCP server for SQL queries"})
+	require.NoError(t, err)
+	assert.Equal(t, results[0].Vector, again.Vector)
+	assert.Equal(t, 1536, again.Dimensions)
+	assert.Equal(t, "hash", again.Provider)
+	assert.InDelta(t, 1.0, cosine(again.Vector, again.Vector), 1e-6)
+
+	related := cosine(results[0].Vector, results[1].Vector)
+	unrelated := cosine(results[0].Vector, results[2].Vector)
+	assert.Greater(t, related, unrelated)
+
+	_, err = provider.Generate(context.Background(), Payload{Text: ""})
+	assert.Error(t, err)
+}
+
+func TestGenerateSemanticEmbeddings_ValidatesDimensions(t *testing.T) {
+	provider, err := Factory(&config.EmbeddingsConfig{Enabled: true, Provider: "hash", Dimensions: 8}, nil)
+	require.NoError(t, err)
+
+	records, err := GenerateSemanticEmbeddings(context.Background(), provider, []string{"a b", "c d"}, 8)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, PayloadChecksum("c d"), records[1].Checksum)
+
+	_, err = GenerateSemanticEmbeddings(context.Background(), provider, []string{"a b"}, 16)
+	assert.ErrorContains(t, err, "dimensions mismatch")
+}
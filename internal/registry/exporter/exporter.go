@@ -11,17 +11,19 @@ import (
 
 	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 )
 
 const defaultPageSize = 100
 
-// Service handles exporting registry data into seed files.
+// Service handles exporting registry data into bundles and seed files.
 type Service struct {
 	registryService service.RegistryService
 	pageSize        int
 	readmeOutput    string
+	serversOnly     bool
 }
 
 // NewService creates a new exporter service.
@@ -45,26 +47,57 @@ func (s *Service) SetReadmeOutputPath(path string) {
 	s.readmeOutput = strings.TrimSpace(path)
 }
 
-// ExportToPath collects all server definitions from the registry database and
-// writes them to the provided file path using the same schema expected by the
-// importer (array of apiv0.ServerJSON).
+// SetServersOnly switches exports to the legacy seed format: an array of
+// apiv0.ServerJSON holding only servers, as used by the builtin seed data.
+func (s *Service) SetServersOnly(serversOnly bool) {
+	s.serversOnly = serversOnly
+}
+
+// ExportToPath writes the registry contents to the provided file path and returns
+// the number of exported entries. By default it writes a seed.Bundle with every
+// version of every server, agent, skill and prompt plus deployment providers, as
+// YAML when the path ends in .yaml or .yml and as JSON otherwise. With
+// SetServersOnly it writes the legacy array of apiv0.ServerJSON instead.
 func (s *Service) ExportToPath(ctx context.Context, outputPath string) (int, error) {
 	if s.registryService == nil {
 		return 0, fmt.Errorf("registry service is not initialized")
 	}
 
-	servers, err := s.collectServers(ctx)
+	records, err := s.collectServers(ctx)
 	if err != nil {
 		return 0, err
 	}
+	servers := make([]*apiv0.ServerJSON, len(records))
+	for i, record := range records {
+		serverCopy := record.Server
+		servers[i] = &serverCopy
+	}
 
-	if err := ensureDir(outputPath); err != nil {
-		return 0, err
+	var (
+		data  []byte
+		count int
+	)
+	if s.serversOnly {
+		data, err = json.MarshalIndent(servers, "", "  ")
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal servers for export: %w", err)
+		}
+		count = len(servers)
+	} else {
+		bundle, err := s.buildBundle(ctx, records)
+		if err != nil {
+			return 0, err
+		}
+		ext := strings.ToLower(filepath.Ext(outputPath))
+		data, err = seed.MarshalBundle(bundle, ext == ".yaml" || ext == ".yml")
+		if err != nil {
+			return 0, err
+		}
+		count = bundle.Count()
 	}
 
-	data, err := json.MarshalIndent(servers, "", "  ")
-	if err != nil {
-		return 0, fmt.Errorf("failed to marshal servers for export: %w", err)
+	if err := ensureDir(outputPath); err != nil {
+		return 0, err
 	}
 
 	if err := os.WriteFile(outputPath, data, 0o644); err != nil {
@@ -75,12 +108,135 @@ func (s *Service) ExportToPath(ctx context.Context, outputPath string) (int, err
 		return 0, err
 	}
 
-	return len(servers), nil
+	return count, nil
+}
+
+// BuildBundle collects every server, agent, skill, prompt and provider into a bundle.
+func (s *Service) BuildBundle(ctx context.Context) (*seed.Bundle, error) {
+	if s.registryService == nil {
+		return nil, fmt.Errorf("registry service is not initialized")
+	}
+	records, err := s.collectServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildBundle(ctx, records)
 }
 
-func (s *Service) collectServers(ctx context.Context) ([]*apiv0.ServerJSON, error) {
+func (s *Service) buildBundle(ctx context.Context, servers []*apiv0.ServerResponse) (*seed.Bundle, error) {
+	bundle := seed.NewBundle()
+
+	for _, record := range servers {
+		entry := seed.BundleServer{Server: record.Server}
+		if record.Meta.Official != nil {
+			entry.Status = string(record.Meta.Official.Status)
+		}
+		readme, err := s.fetchReadme(ctx, record.Server.Name, record.Server.Version)
+		if err != nil {
+			return nil, err
+		}
+		entry.Readme = readme
+		bundle.Servers = append(bundle.Servers, entry)
+	}
+
+	if err := s.paginate(func(cursor string, limit int) (string, error) {
+		agents, next, err := s.registryService.ListAgents(ctx, nil, cursor, limit)
+		if err != nil {
+			return "", fmt.Errorf("failed to list agents: %w", err)
+		}
+		for _, a := range agents {
+			entry := seed.BundleAgent{Agent: a.Agent}
+			if a.Meta.Official != nil {
+				entry.Status = a.Meta.Official.Status
+				entry.Successor = a.Meta.Official.Successor
+			}
+			bundle.Agents = append(bundle.Agents, entry)
+		}
+		return next, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.paginate(func(cursor string, limit int) (string, error) {
+		skills, next, err := s.registryService.ListSkills(ctx, nil, cursor, limit)
+		if err != nil {
+			return "", fmt.Errorf("failed to list skills: %w", err)
+		}
+		for _, sk := range skills {
+			entry := seed.BundleSkill{Skill: sk.Skill}
+			if sk.Meta.Official != nil {
+				entry.Status = sk.Meta.Official.Status
+				entry.Successor = sk.Meta.Official.Successor
+			}
+			bundles, err := s.fetchSkillBundles(ctx, &sk.Skill)
+			if err != nil {
+				return "", err
+			}
+			entry.Bundles = bundles
+			bundle.Skills = append(bundle.Skills, entry)
+		}
+		return next, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.paginate(func(cursor string, limit int) (string, error) {
+		prompts, next, err := s.registryService.ListPrompts(ctx, nil, cursor, limit)
+		if err != nil {
+			return "", fmt.Errorf("failed to list prompts: %w", err)
+		}
+		for _, p := range prompts {
+			entry := seed.BundlePrompt{Prompt: p.Prompt}
+			if p.Meta.Official != nil {
+				entry.Status = p.Meta.Official.Status
+				entry.Successor = p.Meta.Official.Successor
+			}
+			bundle.Prompts = append(bundle.Prompts, entry)
+		}
+		return next, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	providers, err := s.registryService.ListProviders(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list providers: %w", err)
+	}
+	for _, p := range providers {
+		bundle.Providers = append(bundle.Providers, seed.BundleProvider{
+			ID:       p.ID,
+			Name:     p.Name,
+			Platform: p.Platform,
+			Config:   p.Config,
+		})
+	}
+
+	return bundle, nil
+}
+
+// paginate calls fetch with successive cursors until it returns an empty next cursor.
+func (s *Service) paginate(fetch func(cursor string, limit int) (string, error)) error {
+	pageSize := s.pageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	cursor := ""
+	for {
+		next, err := fetch(cursor, pageSize)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (s *Service) collectServers(ctx context.Context) ([]*apiv0.ServerResponse, error) {
 	var (
-		allServers []*apiv0.ServerJSON
+		allServers []*apiv0.ServerResponse
 		cursor     string
 	)
 
@@ -99,9 +255,7 @@ func (s *Service) collectServers(ctx context.Context) ([]*apiv0.ServerJSON, erro
 			if record == nil {
 				continue
 			}
-
-			serverCopy := record.Server
-			allServers = append(allServers, &serverCopy)
+			allServers = append(allServers, record)
 		}
 
 		if nextCursor == "" {
@@ -161,25 +315,54 @@ func (s *Service) collectReadmes(ctx context.Context, servers []*apiv0.ServerJSO
 	result := make(seed.ReadmeFile)
 
 	for _, server := range servers {
-		readme, err := s.registryService.GetServerReadmeByVersion(ctx, server.Name, server.Version)
+		entry, err := s.fetchReadme(ctx, server.Name, server.Version)
 		if err != nil {
-			if errors.Is(err, database.ErrNotFound) {
-				continue
-			}
-			return nil, fmt.Errorf("failed to fetch README for %s@%s: %w", server.Name, server.Version, err)
+			return nil, err
 		}
-		if readme == nil || len(readme.Content) == 0 {
+		if entry == nil {
+			continue
+		}
+		result[seed.Key(server.Name, server.Version)] = *entry
+	}
+
+	return result, nil
+}
+
+// fetchSkillBundles returns the archives of the registry-hosted packages of a
+// skill version, so the version can be published again on import.
+func (s *Service) fetchSkillBundles(ctx context.Context, skill *models.SkillJSON) ([]seed.SkillBundleEntry, error) {
+	var entries []seed.SkillBundleEntry
+	for _, pkg := range skill.Packages {
+		if pkg.RegistryType != models.SkillPackageTypeRegistry {
 			continue
 		}
+		bundle, err := s.registryService.GetSkillBundle(ctx, pkg.Identifier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch skill bundle %s for %s@%s: %w", pkg.Identifier, skill.Name, skill.Version, err)
+		}
+		entries = append(entries, seed.EncodeSkillBundle(bundle.Content))
+	}
+	return entries, nil
+}
 
-		contentType := readme.ContentType
-		if contentType == "" {
-			contentType = "text/markdown"
+// fetchReadme returns the encoded README for a server version, or nil when it has none.
+func (s *Service) fetchReadme(ctx context.Context, serverName, version string) (*seed.ReadmeEntry, error) {
+	readme, err := s.registryService.GetServerReadmeByVersion(ctx, serverName, version)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("failed to fetch README for %s@%s: %w", serverName, version, err)
+	}
+	if readme == nil || len(readme.Content) == 0 {
+		return nil, nil
+	}
 
-		entry := seed.EncodeReadme(readme.Content, contentType)
-		result[seed.Key(server.Name, server.Version)] = entry
+	contentType := readme.ContentType
+	if contentType == "" {
+		contentType = "text/markdown"
 	}
 
-	return result, nil
+	entry := seed.EncodeReadme(readme.Content, contentType)
+	return &entry, nil
 }
@@ -0,0 +1,124 @@
+// Package health probes deployed MCP servers and agents over the protocols
+// their clients use: an MCP initialize handshake followed by a tool listing,
+// or an A2A agent card fetch.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxAgentCardBytes bounds how much of an agent card response is read.
+const maxAgentCardBytes = 1 << 20
+
+// Prober runs health probes against deployment endpoints.
+type Prober struct {
+	httpClient *http.Client
+	timeout    time.Duration
+}
+
+// NewProber creates a prober whose probes give up after timeout.
+func NewProber(timeout time.Duration) *Prober {
+	return &Prober{
+		httpClient: &http.Client{},
+		timeout:    timeout,
+	}
+}
+
+// Probe checks the target and reports the outcome. Failures are recorded in
+// the returned health rather than returned as errors.
+func (p *Prober) Probe(ctx context.Context, target *models.DeploymentProbeTarget) *models.DeploymentHealth {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	health := &models.DeploymentHealth{
+		CheckedAt: time.Now().UTC(),
+		Endpoint:  target.URL,
+	}
+	var err error
+	switch target.Protocol {
+	case models.DeploymentProbeMCP:
+		var toolCount int
+		toolCount, err = p.probeMCP(ctx, target.URL, target.ToolPrefix)
+		if err == nil {
+			health.ToolCount = &toolCount
+		}
+	case models.DeploymentProbeA2A:
+		err = p.probeAgentCard(ctx, target.URL)
+	default:
+		err = fmt.Errorf("unsupported probe protocol %q", target.Protocol)
+	}
+	health.LatencyMs = time.Since(health.CheckedAt).Milliseconds()
+	health.Healthy = err == nil
+	if err != nil {
+		health.Error = err.Error()
+	}
+	return health
+}
+
+// probeMCP runs the MCP handshake against endpoint and counts the tools it
+// lists, only counting tools named with toolPrefix when it is set.
+func (p *Prober) probeMCP(ctx context.Context, endpoint, toolPrefix string) (int, error) {
+	client := mcp.NewClient(&mcp.Implementation{Name: "agentregistry-health", Version: "v1"}, nil)
+	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{
+		Endpoint:             endpoint,
+		HTTPClient:           p.httpClient,
+		MaxRetries:           -1,
+		DisableStandaloneSSE: true,
+	}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("mcp initialize: %w", err)
+	}
+	defer session.Close()
+
+	count := 0
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return 0, fmt.Errorf("mcp tools/list: %w", err)
+		}
+		if toolPrefix == "" || strings.HasPrefix(tool.Name, toolPrefix) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// probeAgentCard fetches the agent card at cardURL and checks that it names
+// the agent.
+func (p *Prober) probeAgentCard(ctx context.Context, cardURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cardURL, nil)
+	if err != nil {
+		return fmt.Errorf("agent card request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("agent card request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("agent card request returned %s", resp.Status)
+	}
+	var card struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxAgentCardBytes)).Decode(&card); err != nil {
+		return fmt.Errorf("invalid agent card: %w", err)
+	}
+	if card.Name == "" {
+		return fmt.Errorf("invalid agent card: name is missing")
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMCPServer(t *testing.T, toolNames ...string) *httptest.Server {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "v1"}, nil)
+	for _, name := range toolNames {
+		server.AddTool(&mcp.Tool{Name: name, InputSchema: map[string]any{"type": "object"}},
+			func(context.Context, *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{}, nil
+			})
+	}
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil)
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestProbe_MCPCountsTools(t *testing.T) {
+	ts := newTestMCPServer(t, "svc-a_search", "svc-a_fetch", "svc-b_search")
+	prober := NewProber(5 * time.Second)
+
+	health := prober.Probe(context.Background(), &models.DeploymentProbeTarget{
+		Protocol: models.DeploymentProbeMCP,
+		URL:      ts.URL,
+	})
+	require.True(t, health.Healthy, health.Error)
+	require.NotNil(t, health.ToolCount)
+	assert.Equal(t, 3, *health.ToolCount)
+	assert.Equal(t, ts.URL, health.Endpoint)
+	assert.False(t, health.CheckedAt.IsZero())
+
+	health = prober.Probe(context.Background(), &models.DeploymentProbeTarget{
+		Protocol:   models.DeploymentProbeMCP,
+		URL:        ts.URL,
+		ToolPrefix: "svc-a_",
+	})
+	require.True(t, health.Healthy, health.Error)
+	assert.Equal(t, 2, *health.ToolCount)
+}
+
+func TestProbe_MCPReportsUnreachableServer(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	ts.Close()
+
+	health := NewProber(5*time.Second).Probe(context.Background(), &models.DeploymentProbeTarget{
+		Protocol: models.DeploymentProbeMCP,
+		URL:      ts.URL,
+	})
+	assert.False(t, health.Healthy)
+	assert.Nil(t, health.ToolCount)
+	assert.Contains(t, health.Error, "mcp initialize")
+}
+
+func TestProbe_AgentCard(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/good/.well-known/agent-card.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"weather","url":"http://localhost/"}`))
+	})
+	mux.HandleFunc("/nameless/.well-known/agent-card.json", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	prober := NewProber(5 * time.Second)
+
+	tests := []struct {
+		name    string
+		path    string
+		healthy bool
+		wantErr string
+	}{
+		{name: "valid card", path: "/good/.well-known/agent-card.json", healthy: true},
+		{name: "card without name", path: "/nameless/.well-known/agent-card.json", wantErr: "name is missing"},
+		{name: "no card", path: "/missing/.well-known/agent-card.json", wantErr: "404"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := prober.Probe(context.Background(), &models.DeploymentProbeTarget{
+				Protocol: models.DeploymentProbeA2A,
+				URL:      ts.URL + tt.path,
+			})
+			assert.Equal(t, tt.healthy, health.Healthy)
+			assert.Nil(t, health.ToolCount)
+			if tt.wantErr != "" {
+				assert.Contains(t, health.Error, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,458 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/embeddings"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// ConflictPolicy controls what a bundle import does with entries that already exist.
+type ConflictPolicy string
+
+const (
+	// ConflictSkip leaves existing entries untouched.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictOverwrite replaces existing entries with the bundle contents.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	// ConflictFail aborts the import, before anything is written, if any entry already exists.
+	ConflictFail ConflictPolicy = "fail"
+)
+
+// ParseConflictPolicy validates a conflict policy name. An empty value selects ConflictSkip.
+func ParseConflictPolicy(value string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case "":
+		return ConflictSkip, nil
+	case ConflictSkip, ConflictOverwrite, ConflictFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid conflict policy %q (expected skip, overwrite or fail)", value)
+	}
+}
+
+// BundleImportResult summarises the outcome of a bundle import.
+type BundleImportResult struct {
+	Created  int
+	Updated  int
+	Skipped  int
+	Failures []string
+}
+
+// bundleEntry adapts a single bundle item of any kind to the import loop.
+type bundleEntry struct {
+	kind      string
+	name      string
+	version   string
+	exists    func(ctx context.Context) (bool, error)
+	create    func(ctx context.Context) error
+	overwrite func(ctx context.Context) error
+	// successor links a deprecated version to its successor once every entry
+	// is written, since the successor may come later in the bundle. It is nil
+	// when the entry names no successor.
+	successor func(ctx context.Context) error
+}
+
+func (e bundleEntry) String() string {
+	if e.version == "" {
+		return fmt.Sprintf("%s %s", e.kind, e.name)
+	}
+	return fmt.Sprintf("%s %s@%s", e.kind, e.name, e.version)
+}
+
+// ImportBundle writes every entry of a bundle into the registry, applying the
+// configured conflict policy to entries that already exist. Importing the same
+// bundle twice with ConflictSkip or ConflictOverwrite leaves the registry unchanged.
+// Entries that fail are recorded in the result and do not stop the import.
+func (s *Service) ImportBundle(ctx context.Context, bundle *seed.Bundle) (*BundleImportResult, error) {
+	if bundle == nil {
+		return nil, fmt.Errorf("bundle is nil")
+	}
+	policy := s.effectiveConflictPolicy()
+	entries := s.bundleEntries(bundle)
+
+	if policy == ConflictFail {
+		var conflicts []string
+		for _, entry := range entries {
+			exists, err := entry.exists(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check %s: %w", entry, err)
+			}
+			if exists {
+				conflicts = append(conflicts, entry.String())
+			}
+		}
+		if len(conflicts) > 0 {
+			return nil, fmt.Errorf("%d bundle entries already exist: %s", len(conflicts), strings.Join(conflicts, ", "))
+		}
+	}
+
+	result := &BundleImportResult{}
+	var written []bundleEntry
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		exists, err := entry.exists(ctx)
+		if err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("%s: %v", entry, err))
+			continue
+		}
+
+		switch {
+		case !exists:
+			err = entry.create(ctx)
+			if err == nil {
+				result.Created++
+			}
+		case policy == ConflictOverwrite:
+			err = entry.overwrite(ctx)
+			if err == nil {
+				result.Updated++
+			}
+		default:
+			result.Skipped++
+			continue
+		}
+		if err != nil {
+			s.logger.Error("failed to import bundle entry", "entry", entry.String(), "error", err)
+			result.Failures = append(result.Failures, fmt.Sprintf("%s: %v", entry, err))
+			continue
+		}
+		written = append(written, entry)
+	}
+
+	for _, entry := range written {
+		if entry.successor == nil {
+			continue
+		}
+		if err := entry.successor(ctx); err != nil {
+			s.logger.Error("failed to set bundle entry successor", "entry", entry.String(), "error", err)
+			result.Failures = append(result.Failures, fmt.Sprintf("%s: failed to set successor: %v", entry, err))
+		}
+	}
+
+	s.logger.Info("bundle import finished",
+		"created", result.Created, "updated", result.Updated, "skipped", result.Skipped, "failed", len(result.Failures))
+	return result, nil
+}
+
+func (s *Service) effectiveConflictPolicy() ConflictPolicy {
+	if s.conflictPolicy != "" {
+		return s.conflictPolicy
+	}
+	if s.updateIfExists {
+		return ConflictOverwrite
+	}
+	return ConflictSkip
+}
+
+// bundleEntries orders bundle items so that providers, skills and prompts exist
+// before the servers and agents that may reference them.
+func (s *Service) bundleEntries(bundle *seed.Bundle) []bundleEntry {
+	var entries []bundleEntry
+	for i := range bundle.Providers {
+		entries = append(entries, s.providerEntry(&bundle.Providers[i]))
+	}
+	for i := range bundle.Skills {
+		entries = append(entries, s.skillEntry(&bundle.Skills[i]))
+	}
+	for i := range bundle.Prompts {
+		entries = append(entries, s.promptEntry(&bundle.Prompts[i]))
+	}
+	for i := range bundle.Servers {
+		entries = append(entries, s.serverEntry(&bundle.Servers[i]))
+	}
+	for i := range bundle.Agents {
+		entries = append(entries, s.agentEntry(&bundle.Agents[i]))
+	}
+	return entries
+}
+
+func (s *Service) providerEntry(p *seed.BundleProvider) bundleEntry {
+	return bundleEntry{
+		kind: "provider",
+		name: p.ID,
+		exists: func(ctx context.Context) (bool, error) {
+			_, err := s.registry.GetProviderByID(ctx, p.ID)
+			return found(err)
+		},
+		create: func(ctx context.Context) error {
+			_, err := s.registry.CreateProvider(ctx, &models.CreateProviderInput{
+				ID:       p.ID,
+				Name:     p.Name,
+				Platform: p.Platform,
+				Config:   p.Config,
+			})
+			return err
+		},
+		overwrite: func(ctx context.Context) error {
+			existing, err := s.registry.GetProviderByID(ctx, p.ID)
+			if err != nil {
+				return err
+			}
+			if existing.Platform != p.Platform {
+				return fmt.Errorf("cannot change provider platform from %s to %s", existing.Platform, p.Platform)
+			}
+			name := p.Name
+			_, err = s.registry.UpdateProvider(ctx, p.ID, &models.UpdateProviderInput{Name: &name, Config: p.Config})
+			return err
+		},
+	}
+}
+
+func (s *Service) serverEntry(srv *seed.BundleServer) bundleEntry {
+	server := &srv.Server
+	store := func(ctx context.Context) error {
+		if srv.Readme != nil {
+			content, contentType, err := srv.Readme.Decode()
+			if err != nil {
+				return err
+			}
+			if len(content) > 0 {
+				if err := s.registry.StoreServerReadme(ctx, server.Name, server.Version, content, contentType); err != nil {
+					return fmt.Errorf("failed to store README: %w", err)
+				}
+			}
+		}
+		s.storeEmbedding(ctx, "server", server.Name, server.Version, embeddings.BuildServerEmbeddingPayload(server), s.registry.UpsertServerEmbedding)
+		return nil
+	}
+
+	return bundleEntry{
+		kind:    "server",
+		name:    server.Name,
+		version: server.Version,
+		exists: func(ctx context.Context) (bool, error) {
+			_, err := s.registry.GetServerByNameAndVersion(ctx, server.Name, server.Version)
+			return found(err)
+		},
+		create: func(ctx context.Context) error {
+			if err := validators.ValidateServerJSON(server); err != nil {
+				return err
+			}
+			if _, err := s.registry.CreateServer(ctx, server); err != nil {
+				return err
+			}
+			if status := nonActiveStatus(srv.Status); status != nil {
+				if _, err := s.registry.UpdateServer(ctx, server.Name, server.Version, server, status); err != nil {
+					return fmt.Errorf("failed to set status: %w", err)
+				}
+			}
+			return store(ctx)
+		},
+		overwrite: func(ctx context.Context) error {
+			if err := validators.ValidateServerJSON(server); err != nil {
+				return err
+			}
+			if _, err := s.registry.UpdateServer(ctx, server.Name, server.Version, server, bundleStatus(srv.Status)); err != nil {
+				return err
+			}
+			return store(ctx)
+		},
+	}
+}
+
+func (s *Service) agentEntry(a *seed.BundleAgent) bundleEntry {
+	agent := &a.Agent
+	create := func(ctx context.Context) error {
+		if _, err := s.registry.CreateAgent(ctx, agent); err != nil {
+			return err
+		}
+		if status := nonActiveStatus(a.Status); status != nil {
+			if _, err := s.registry.SetAgentStatus(ctx, agent.Name, agent.Version, *status, ""); err != nil {
+				return fmt.Errorf("failed to set status: %w", err)
+			}
+		}
+		s.storeEmbedding(ctx, "agent", agent.Name, agent.Version, embeddings.BuildAgentEmbeddingPayload(agent), s.registry.UpsertAgentEmbedding)
+		return nil
+	}
+
+	return bundleEntry{
+		kind:    "agent",
+		name:    agent.Name,
+		version: agent.Version,
+		exists: func(ctx context.Context) (bool, error) {
+			_, err := s.registry.GetAgentByNameAndVersion(ctx, agent.Name, agent.Version)
+			return found(err)
+		},
+		create: create,
+		overwrite: func(ctx context.Context) error {
+			// Update in place so the version keeps its publish time and latest flag.
+			if _, err := s.registry.UpdateAgent(ctx, agent.Name, agent.Version, agent, bundleStatus(a.Status)); err != nil {
+				return err
+			}
+			s.storeEmbedding(ctx, "agent", agent.Name, agent.Version, embeddings.BuildAgentEmbeddingPayload(agent), s.registry.UpsertAgentEmbedding)
+			return nil
+		},
+		successor: linkSuccessor(a.Successor, func(ctx context.Context) error {
+			_, err := s.registry.SetAgentStatus(ctx, agent.Name, agent.Version, string(model.StatusDeprecated), a.Successor)
+			return err
+		}),
+	}
+}
+
+func (s *Service) skillEntry(sk *seed.BundleSkill) bundleEntry {
+	skill := &sk.Skill
+	create := func(ctx context.Context) error {
+		if err := s.uploadSkillBundles(ctx, sk); err != nil {
+			return err
+		}
+		if _, err := s.registry.CreateSkill(ctx, skill); err != nil {
+			return err
+		}
+		if status := nonActiveStatus(sk.Status); status != nil {
+			if _, err := s.registry.SetSkillStatus(ctx, skill.Name, skill.Version, *status, ""); err != nil {
+				return fmt.Errorf("failed to set status: %w", err)
+			}
+		}
+		s.storeEmbedding(ctx, "skill", skill.Name, skill.Version, embeddings.BuildSkillEmbeddingPayload(skill), s.registry.UpsertSkillEmbedding)
+		return nil
+	}
+
+	return bundleEntry{
+		kind:    "skill",
+		name:    skill.Name,
+		version: skill.Version,
+		exists: func(ctx context.Context) (bool, error) {
+			_, err := s.registry.GetSkillByNameAndVersion(ctx, skill.Name, skill.Version)
+			return found(err)
+		},
+		create: create,
+		overwrite: func(ctx context.Context) error {
+			if err := s.uploadSkillBundles(ctx, sk); err != nil {
+				return err
+			}
+			// Update in place so the version keeps its publish time and latest flag.
+			if _, err := s.registry.UpdateSkill(ctx, skill.Name, skill.Version, skill, bundleStatus(sk.Status)); err != nil {
+				return err
+			}
+			s.storeEmbedding(ctx, "skill", skill.Name, skill.Version, embeddings.BuildSkillEmbeddingPayload(skill), s.registry.UpsertSkillEmbedding)
+			return nil
+		},
+		successor: linkSuccessor(sk.Successor, func(ctx context.Context) error {
+			_, err := s.registry.SetSkillStatus(ctx, skill.Name, skill.Version, string(model.StatusDeprecated), sk.Successor)
+			return err
+		}),
+	}
+}
+
+// uploadSkillBundles stores the skill bundles carried with a skill version
+// before the version that references them is published.
+func (s *Service) uploadSkillBundles(ctx context.Context, sk *seed.BundleSkill) error {
+	for _, entry := range sk.Bundles {
+		data, err := entry.Decode()
+		if err != nil {
+			return err
+		}
+		if _, err := s.registry.UploadSkillBundle(ctx, data); err != nil {
+			return fmt.Errorf("failed to upload skill bundle %s: %w", entry.Digest, err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) promptEntry(p *seed.BundlePrompt) bundleEntry {
+	prompt := &p.Prompt
+	create := func(ctx context.Context) error {
+		if _, err := s.registry.CreatePrompt(ctx, prompt); err != nil {
+			return err
+		}
+		if status := nonActiveStatus(p.Status); status != nil {
+			if _, err := s.registry.SetPromptStatus(ctx, prompt.Name, prompt.Version, *status, ""); err != nil {
+				return fmt.Errorf("failed to set status: %w", err)
+			}
+		}
+		s.storeEmbedding(ctx, "prompt", prompt.Name, prompt.Version, embeddings.BuildPromptEmbeddingPayload(prompt), s.registry.UpsertPromptEmbedding)
+		return nil
+	}
+
+	return bundleEntry{
+		kind:    "prompt",
+		name:    prompt.Name,
+		version: prompt.Version,
+		exists: func(ctx context.Context) (bool, error) {
+			_, err := s.registry.GetPromptByNameAndVersion(ctx, prompt.Name, prompt.Version)
+			return found(err)
+		},
+		create: create,
+		overwrite: func(ctx context.Context) error {
+			// Update in place so the version keeps its publish time and latest flag.
+			if _, err := s.registry.UpdatePrompt(ctx, prompt.Name, prompt.Version, prompt, bundleStatus(p.Status)); err != nil {
+				return err
+			}
+			s.storeEmbedding(ctx, "prompt", prompt.Name, prompt.Version, embeddings.BuildPromptEmbeddingPayload(prompt), s.registry.UpsertPromptEmbedding)
+			return nil
+		},
+		successor: linkSuccessor(p.Successor, func(ctx context.Context) error {
+			_, err := s.registry.SetPromptStatus(ctx, prompt.Name, prompt.Version, string(model.StatusDeprecated), p.Successor)
+			return err
+		}),
+	}
+}
+
+// storeEmbedding generates and stores an embedding when embedding generation is
+// enabled. Failures are logged and never fail the import.
+func (s *Service) storeEmbedding(
+	ctx context.Context,
+	kind, name, version, payload string,
+	upsert func(ctx context.Context, name, version string, embedding *database.SemanticEmbedding) error,
+) {
+	if !s.generateEmbeddings || s.embeddingProvider == nil || strings.TrimSpace(payload) == "" {
+		return
+	}
+	embedding, err := embeddings.GenerateSemanticEmbedding(ctx, s.embeddingProvider, payload, s.embeddingDimensions)
+	if err != nil {
+		s.logger.Warn("failed to generate embedding", "kind", kind, "name", name, "version", version, "error", err)
+		return
+	}
+	if err := upsert(ctx, name, version, embedding); err != nil {
+		s.logger.Warn("failed to store embedding", "kind", kind, "name", name, "version", version, "error", err)
+	}
+}
+
+// found maps a lookup error to whether the record exists.
+func found(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	return false, err
+}
+
+// bundleStatus returns the status to apply when overwriting an entry, or nil to
+// keep the current one.
+func bundleStatus(status string) *string {
+	if status == "" {
+		return nil
+	}
+	return &status
+}
+
+// linkSuccessor returns set as the successor step of a bundle entry, or nil when
+// the entry names no successor.
+func linkSuccessor(successor string, set func(ctx context.Context) error) func(ctx context.Context) error {
+	if successor == "" {
+		return nil
+	}
+	return set
+}
+
+// nonActiveStatus returns the status to apply after creating an entry, or nil when
+// the create default (active) already matches.
+func nonActiveStatus(status string) *string {
+	if status == "" || status == string(model.StatusActive) {
+		return nil
+	}
+	return &status
+}
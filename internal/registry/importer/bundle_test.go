@@ -0,0 +1,222 @@
+package importer_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/importer"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBundleRegistry returns a fake registry that keeps skills and providers in memory.
+func newBundleRegistry() (*servicetesting.FakeRegistry, map[string]*models.SkillResponse, map[string]*models.Provider) {
+	skills := map[string]*models.SkillResponse{}
+	providers := map[string]*models.Provider{}
+
+	registry := servicetesting.NewFakeRegistry()
+	registry.GetSkillByNameAndVersionFn = func(_ context.Context, name, version string) (*models.SkillResponse, error) {
+		if skill, ok := skills[name+"@"+version]; ok {
+			return skill, nil
+		}
+		return nil, database.ErrNotFound
+	}
+	registry.CreateSkillFn = func(_ context.Context, req *models.SkillJSON) (*models.SkillResponse, error) {
+		resp := &models.SkillResponse{Skill: *req}
+		resp.Meta.Official = &models.SkillRegistryExtensions{Status: "active", PublishedAt: time.Now(), IsLatest: true}
+		skills[req.Name+"@"+req.Version] = resp
+		return resp, nil
+	}
+	registry.UpdateSkillFn = func(_ context.Context, name, version string, req *models.SkillJSON, newStatus *string) (*models.SkillResponse, error) {
+		skill, ok := skills[name+"@"+version]
+		if !ok {
+			return nil, database.ErrNotFound
+		}
+		skill.Skill = *req
+		if newStatus != nil {
+			skill.Meta.Official.Status = *newStatus
+		}
+		return skill, nil
+	}
+	registry.SetSkillStatusFn = func(_ context.Context, name, version, status, successor string) (*models.SkillResponse, error) {
+		if _, ok := skills[name+"@"+successor]; successor != "" && !ok {
+			return nil, database.ErrInvalidInput
+		}
+		skill := skills[name+"@"+version]
+		skill.Meta.Official.Status = status
+		skill.Meta.Official.Successor = successor
+		return skill, nil
+	}
+	registry.GetProviderByIDFn = func(_ context.Context, id string) (*models.Provider, error) {
+		if provider, ok := providers[id]; ok {
+			return provider, nil
+		}
+		return nil, database.ErrNotFound
+	}
+	registry.CreateProviderFn = func(_ context.Context, in *models.CreateProviderInput) (*models.Provider, error) {
+		provider := &models.Provider{ID: in.ID, Name: in.Name, Platform: in.Platform, Config: in.Config}
+		providers[in.ID] = provider
+		return provider, nil
+	}
+	registry.UpdateProviderFn = func(_ context.Context, id string, in *models.UpdateProviderInput) (*models.Provider, error) {
+		provider := providers[id]
+		provider.Name = *in.Name
+		provider.Config = in.Config
+		return provider, nil
+	}
+	return registry, skills, providers
+}
+
+func testBundle(description string) *seed.Bundle {
+	bundle := seed.NewBundle()
+	bundle.Skills = []seed.BundleSkill{
+		{Skill: models.SkillJSON{Name: "pdf-tools", Version: "1.0.0", Description: description}, Status: "deprecated", Successor: "2.0.0"},
+		{Skill: models.SkillJSON{Name: "pdf-tools", Version: "2.0.0", Description: description}},
+	}
+	bundle.Providers = []seed.BundleProvider{
+		{ID: "local", Name: description, Platform: "local"},
+	}
+	return bundle
+}
+
+func TestImportBundle_ConflictPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	registry, skills, providers := newBundleRegistry()
+	importerService := importer.NewService(registry)
+
+	result, err := importerService.ImportBundle(ctx, testBundle("original"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Created)
+	assert.Empty(t, result.Failures)
+	assert.Equal(t, "deprecated", skills["pdf-tools@1.0.0"].Meta.Official.Status)
+	assert.Equal(t, "2.0.0", skills["pdf-tools@1.0.0"].Meta.Official.Successor, "the successor is linked after it is created")
+	assert.Equal(t, "active", skills["pdf-tools@2.0.0"].Meta.Official.Status)
+
+	t.Run("skip leaves existing entries untouched", func(t *testing.T) {
+		importerService.SetConflictPolicy(importer.ConflictSkip)
+		result, err := importerService.ImportBundle(ctx, testBundle("changed"))
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Created)
+		assert.Equal(t, 3, result.Skipped)
+		assert.Equal(t, "original", skills["pdf-tools@1.0.0"].Skill.Description)
+		assert.Equal(t, "original", providers["local"].Name)
+	})
+
+	t.Run("fail aborts before writing", func(t *testing.T) {
+		importerService.SetConflictPolicy(importer.ConflictFail)
+		bundle := testBundle("changed")
+		bundle.Skills = append(bundle.Skills, seed.BundleSkill{Skill: models.SkillJSON{Name: "new-skill", Version: "1.0.0"}})
+		_, err := importerService.ImportBundle(ctx, bundle)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pdf-tools@1.0.0")
+		assert.NotContains(t, skills, "new-skill@1.0.0")
+	})
+
+	t.Run("overwrite replaces existing entries", func(t *testing.T) {
+		publishedAt := skills["pdf-tools@1.0.0"].Meta.Official.PublishedAt
+		importerService.SetConflictPolicy(importer.ConflictOverwrite)
+		result, err := importerService.ImportBundle(ctx, testBundle("changed"))
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Updated)
+		assert.Equal(t, "changed", skills["pdf-tools@1.0.0"].Skill.Description)
+		assert.Equal(t, "deprecated", skills["pdf-tools@1.0.0"].Meta.Official.Status)
+		assert.Equal(t, publishedAt, skills["pdf-tools@1.0.0"].Meta.Official.PublishedAt)
+		assert.True(t, skills["pdf-tools@2.0.0"].Meta.Official.IsLatest)
+		assert.Equal(t, "changed", providers["local"].Name)
+	})
+}
+
+func TestImportBundle_UploadsSkillBundles(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(src, "SKILL.md"), []byte("---\nname: pdf-tools\ndescription: PDF helpers\n---\n"), 0o600))
+	archive, err := skillbundle.Pack(src)
+	require.NoError(t, err)
+	digest := skillbundle.Digest(archive)
+
+	registry, skills, _ := newBundleRegistry()
+	uploaded := map[string][]byte{}
+	registry.UploadSkillBundleFn = func(_ context.Context, data []byte) (*models.SkillBundle, error) {
+		uploaded[skillbundle.Digest(data)] = data
+		return &models.SkillBundle{Digest: skillbundle.Digest(data), Name: "pdf-tools"}, nil
+	}
+	createSkill := registry.CreateSkillFn
+	registry.CreateSkillFn = func(ctx context.Context, req *models.SkillJSON) (*models.SkillResponse, error) {
+		if _, ok := uploaded[digest]; !ok {
+			return nil, errors.New("skill bundle was not uploaded before the skill")
+		}
+		return createSkill(ctx, req)
+	}
+
+	entry := seed.EncodeSkillBundle(archive)
+	bundle := seed.NewBundle()
+	bundle.Skills = []seed.BundleSkill{{
+		Skill: models.SkillJSON{
+			Name:     "pdf-tools",
+			Version:  "1.0.0",
+			Packages: []models.SkillPackageInfo{{RegistryType: models.SkillPackageTypeRegistry, Identifier: digest}},
+		},
+		Bundles: []seed.SkillBundleEntry{entry},
+	}}
+	data, err := seed.MarshalBundle(bundle, false)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "registry.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	require.NoError(t, importer.NewService(registry).ImportFromPath(context.Background(), path, false))
+	assert.Equal(t, archive, uploaded[digest])
+	assert.Contains(t, skills, "pdf-tools@1.0.0")
+
+	t.Run("corrupted bundle fails the skill", func(t *testing.T) {
+		registry, skills, _ := newBundleRegistry()
+		registry.UploadSkillBundleFn = func(context.Context, []byte) (*models.SkillBundle, error) {
+			t.Fatal("corrupted skill bundle was uploaded")
+			return nil, nil
+		}
+		corrupted := entry
+		corrupted.Digest = skillbundle.Digest([]byte("other"))
+		bundle.Skills[0].Bundles = []seed.SkillBundleEntry{corrupted}
+
+		result, err := importer.NewService(registry).ImportBundle(context.Background(), bundle)
+		require.NoError(t, err)
+		require.Len(t, result.Failures, 1)
+		assert.Contains(t, result.Failures[0], "digest mismatch")
+		assert.NotContains(t, skills, "pdf-tools@1.0.0")
+	})
+}
+
+func TestImportFromPath_YAMLBundle(t *testing.T) {
+	data, err := seed.MarshalBundle(testBundle("from yaml"), true)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	registry, skills, providers := newBundleRegistry()
+	require.NoError(t, importer.NewService(registry).ImportFromPath(context.Background(), path, false))
+
+	assert.Len(t, skills, 2)
+	assert.Equal(t, "from yaml", skills["pdf-tools@2.0.0"].Skill.Description)
+	assert.Contains(t, providers, "local")
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	policy, err := importer.ParseConflictPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, importer.ConflictSkip, policy)
+
+	policy, err = importer.ParseConflictPolicy("Overwrite")
+	require.NoError(t, err)
+	assert.Equal(t, importer.ConflictOverwrite, policy)
+
+	_, err = importer.ParseConflictPolicy("merge")
+	require.Error(t, err)
+}
@@ -37,6 +37,7 @@ type Service struct {
 	httpClient          *http.Client
 	requestHeaders      map[string]string
 	updateIfExists      bool
+	conflictPolicy      ConflictPolicy
 	githubToken         string
 	readmeSeedPath      string
 	progressCachePath   string
@@ -85,6 +86,12 @@ func (s *Service) SetUpdateIfExists(update bool) {
 	s.updateIfExists = update
 }
 
+// SetConflictPolicy sets how bundle imports treat entries that already exist.
+// When unset, bundles overwrite existing entries if update-if-exists is enabled and skip them otherwise.
+func (s *Service) SetConflictPolicy(policy ConflictPolicy) {
+	s.conflictPolicy = policy
+}
+
 // SetGitHubToken sets a token used only for GitHub enrichment calls
 func (s *Service) SetGitHubToken(token string) {
 	s.githubToken = strings.TrimSpace(token)
@@ -119,11 +126,22 @@ func (s *Service) SetProgressCachePath(path string) {
 // 1. Local file paths (*.json files) - expects ServerJSON array format
 // 2. Direct HTTP URLs to seed.json files - expects ServerJSON array format
 // 3. Registry API endpoints (e.g., /v0/servers) - handles pagination automatically
+// 4. Registry bundles (JSON or YAML, local or HTTP) written by the exporter - imported via ImportBundle
 func (s *Service) ImportFromPath(ctx context.Context, path string, enrichServerData bool) error {
-	servers, err := s.readSeedFile(ctx, path)
+	servers, bundle, err := s.readSeedFile(ctx, path)
 	if err != nil {
 		return fmt.Errorf("failed to read seed data: %w", err)
 	}
+	if bundle != nil {
+		result, err := s.ImportBundle(ctx, bundle)
+		if err != nil {
+			return fmt.Errorf("failed to import bundle: %w", err)
+		}
+		if len(result.Failures) > 0 {
+			return fmt.Errorf("%d bundle entries failed to import: %s", len(result.Failures), strings.Join(result.Failures, "; "))
+		}
+		return nil
+	}
 
 	readmeSeeds, err := s.loadReadmeSeed(ctx)
 	if err != nil {
@@ -259,8 +277,9 @@ func (s *Service) buildServerEmbedding(ctx context.Context, srv *apiv0.ServerJSO
 	return embeddings.GenerateSemanticEmbedding(ctx, s.embeddingProvider, payload, s.embeddingDimensions)
 }
 
-// readSeedFile reads seed data from various sources
-func (s *Service) readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, error) {
+// readSeedFile reads seed data from various sources. Registry bundles are returned
+// as-is; any other document is parsed as a ServerJSON array.
+func (s *Service) readSeedFile(ctx context.Context, path string) ([]*apiv0.ServerJSON, *seed.Bundle, error) {
 	var data []byte
 	var err error
 
@@ -268,7 +287,8 @@ func (s *Service) readSeedFile(ctx context.Context, path string) ([]*apiv0.Serve
 		// Handle HTTP URLs
 		if strings.HasSuffix(path, "/servers") {
 			// This is a registry API endpoint - fetch paginated data
-			return s.fetchFromRegistryAPI(ctx, path)
+			servers, err := s.fetchFromRegistryAPI(ctx, path)
+			return servers, nil, err
 		}
 		// This is a direct file URL
 		data, err = s.fetchFromHTTP(ctx, path)
@@ -278,17 +298,25 @@ func (s *Service) readSeedFile(ctx context.Context, path string) ([]*apiv0.Serve
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to read seed data from %s: %w", path, err)
+		return nil, nil, fmt.Errorf("failed to read seed data from %s: %w", path, err)
+	}
+
+	if seed.IsBundle(data) {
+		bundle, err := seed.ParseBundle(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, bundle, nil
 	}
 
 	// Parse ServerJSON array format
 	var serverResponses []apiv0.ServerJSON
 	if err := json.Unmarshal(data, &serverResponses); err != nil {
-		return nil, fmt.Errorf("failed to parse seed data as ServerJSON array format: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse seed data as ServerJSON array format: %w", err)
 	}
 
 	if len(serverResponses) == 0 {
-		return []*apiv0.ServerJSON{}, nil
+		return []*apiv0.ServerJSON{}, nil, nil
 	}
 
 	// Validate servers and collect warnings instead of failing the whole batch
@@ -316,7 +344,7 @@ func (s *Service) readSeedFile(ctx context.Context, path string) ([]*apiv0.Serve
 		s.logger.Info("validation summary: all servers passed", "count", len(validRecords))
 	}
 
-	return validRecords, nil
+	return validRecords, nil, nil
 }
 
 func (s *Service) fetchFromHTTP(ctx context.Context, url string) ([]byte, error) {
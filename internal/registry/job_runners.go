@@ -0,0 +1,210 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/embeddings"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/exporter"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/importer"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/mirror"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// registerDataJobRunners installs the import, export and mirror sync job runners.
+//
+// Jobs run with the permissions of their submitter, and only registry admins
+// may submit them. Import sources and export files live under
+// cfg.DataJobsDir; sources configured by the operator (the seed import) are
+// exempt because they are submitted by the system.
+//
+// Import params:
+//   - source (string, required): seed file path relative to DataJobsDir, or a
+//     seed or registry API URL allowed by DataJobsAllowedURLs
+//   - enrichServerData (bool): fetch additional metadata while importing
+//   - updateIfExists (bool): overwrite servers that already exist
+//   - conflictPolicy (string): skip, overwrite or fail for existing bundle entries
+//
+// Export params:
+//   - fileName (string): base name of the file written under
+//     <DataJobsDir>/exports; defaults to <job id>.json. A .yaml or .yml
+//     extension writes the bundle as YAML.
+//   - serversOnly (bool): write a legacy ServerJSON array instead of a bundle
+//
+// Mirror sync params:
+//   - mirrorId (string, required): the mirror to sync
+func registerDataJobRunners(
+	jobManager *jobs.Manager,
+	registryService service.RegistryService,
+	cfg *config.Config,
+	embeddingProvider embeddings.Provider,
+) {
+	jobManager.Register(jobs.ImportJobType, func(ctx context.Context, job *jobs.Job, report jobs.ProgressFunc) (*jobs.JobResult, error) {
+		source, _ := job.Params["source"].(string)
+		source = strings.TrimSpace(source)
+		if source == "" {
+			return nil, fmt.Errorf("import job requires a source")
+		}
+		if !submittedBySystem(ctx) {
+			confined, err := confineImportSource(cfg, source)
+			if err != nil {
+				return nil, err
+			}
+			source = confined
+		}
+		enrich, _ := job.Params["enrichServerData"].(bool)
+		updateIfExists, _ := job.Params["updateIfExists"].(bool)
+		policyParam, _ := job.Params["conflictPolicy"].(string)
+		var conflictPolicy importer.ConflictPolicy
+		if strings.TrimSpace(policyParam) != "" {
+			policy, err := importer.ParseConflictPolicy(policyParam)
+			if err != nil {
+				return nil, err
+			}
+			conflictPolicy = policy
+		}
+
+		importerService := importer.NewService(registryService)
+		importerService.SetUpdateIfExists(updateIfExists)
+		importerService.SetConflictPolicy(conflictPolicy)
+		if embeddingProvider != nil {
+			importerService.SetEmbeddingProvider(embeddingProvider)
+			importerService.SetEmbeddingDimensions(cfg.Embeddings.Dimensions)
+			importerService.SetGenerateEmbeddings(cfg.Embeddings.Enabled)
+		}
+		if err := importerService.ImportFromPath(ctx, source, enrich); err != nil {
+			return nil, err
+		}
+		return &jobs.JobResult{}, nil
+	}, jobs.RunnerOptions{Exclusive: true, Authorize: jobs.RequireRegistryAdmin})
+
+	jobManager.Register(jobs.ExportJobType, func(ctx context.Context, job *jobs.Job, report jobs.ProgressFunc) (*jobs.JobResult, error) {
+		fileName, _ := job.Params["fileName"].(string)
+		fileName = strings.TrimSpace(fileName)
+		if fileName == "" {
+			fileName = job.ID + ".json"
+		}
+		// Exports are confined to the data jobs directory.
+		if fileName != filepath.Base(fileName) || fileName == "." || fileName == ".." {
+			return nil, fmt.Errorf("export fileName must be a plain file name: %q", fileName)
+		}
+		if cfg.DataJobsDir == "" {
+			return nil, errDataJobsDirUnset
+		}
+
+		outputDir := filepath.Join(cfg.DataJobsDir, "exports")
+		if err := os.MkdirAll(outputDir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create export directory: %w", err)
+		}
+		outputPath := filepath.Join(outputDir, fileName)
+
+		serversOnly, _ := job.Params["serversOnly"].(bool)
+		exporterService := exporter.NewService(registryService)
+		exporterService.SetServersOnly(serversOnly)
+
+		count, err := exporterService.ExportToPath(ctx, outputPath)
+		if err != nil {
+			return nil, err
+		}
+		report(jobs.JobProgress{Total: count, Processed: count})
+		return &jobs.JobResult{ItemsExported: count, OutputPath: outputPath}, nil
+	}, jobs.RunnerOptions{Authorize: jobs.RequireRegistryAdmin})
+
+	jobManager.Register(jobs.MirrorSyncJobType, func(ctx context.Context, job *jobs.Job, report jobs.ProgressFunc) (*jobs.JobResult, error) {
+		mirrorID, _ := job.Params["mirrorId"].(string)
+		mirrorID = strings.TrimSpace(mirrorID)
+		if mirrorID == "" {
+			return nil, fmt.Errorf("mirror sync job requires a mirrorId")
+		}
+
+		sync, err := mirror.NewSyncer(registryService).Sync(ctx, mirrorID, func(processed, total int) {
+			report(jobs.JobProgress{Total: total, Processed: processed})
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &jobs.JobResult{
+			ServersProcessed: sync.Created + sync.Updated,
+			ServersUpdated:   sync.Updated,
+			ServersSkipped:   sync.Skipped,
+			ServerFailures:   sync.Failed,
+			Error:            sync.Error,
+		}, nil
+	}, jobs.RunnerOptions{Exclusive: true, Authorize: jobs.RequireRegistryAdmin})
+}
+
+var errDataJobsDirUnset = errors.New("import and export jobs require AGENT_REGISTRY_DATA_JOBS_DIR")
+
+// submittedBySystem reports whether the job running in ctx was submitted by
+// the registry itself rather than through the API.
+func submittedBySystem(ctx context.Context) bool {
+	s, ok := auth.AuthSessionFrom(ctx)
+	return ok && auth.IsSystemSession(s)
+}
+
+// confineImportSource returns the import source to read: a URL under one of
+// cfg.DataJobsAllowedURLs, or a path inside cfg.DataJobsDir.
+func confineImportSource(cfg *config.Config, source string) (string, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		for _, allowed := range cfg.DataJobsAllowedURLs {
+			if urlHasPrefix(source, strings.TrimSpace(allowed)) {
+				return source, nil
+			}
+		}
+		return "", fmt.Errorf("import source %q is not under an allowed URL (AGENT_REGISTRY_DATA_JOBS_ALLOWED_URLS)", source)
+	}
+
+	if cfg.DataJobsDir == "" {
+		return "", errDataJobsDirUnset
+	}
+	root, err := filepath.EvalSymlinks(cfg.DataJobsDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve data jobs directory: %w", err)
+	}
+	target := source
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(root, target)
+	}
+	// Resolve symlinks so a link inside the directory cannot point outside it.
+	resolved, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		return "", fmt.Errorf("import source %q: %w", source, err)
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("import source %q is outside the data jobs directory", source)
+	}
+	return resolved, nil
+}
+
+// urlHasPrefix reports whether rawURL has the same scheme and host as prefix
+// and a path under prefix's path.
+func urlHasPrefix(rawURL, prefix string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	p, err := url.Parse(prefix)
+	if err != nil || p.Host == "" {
+		return false
+	}
+	if !strings.EqualFold(u.Scheme, p.Scheme) || !strings.EqualFold(u.Host, p.Host) {
+		return false
+	}
+	if u.User != nil {
+		return false
+	}
+	// Clean the path so dot segments cannot climb out of the prefix.
+	clean := path.Clean("/" + u.Path)
+	base := strings.TrimSuffix(p.Path, "/")
+	return clean == base || strings.HasPrefix(clean, base+"/")
+}
@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
+)
+
+func TestConfineImportSource(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "seed.json"), []byte("[]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(outside, "secret.json"), []byte("[]"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "secret.json"), filepath.Join(root, "link.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		DataJobsDir:         root,
+		DataJobsAllowedURLs: []string{"https://registry.example.com/seeds/"},
+	}
+	tests := []struct {
+		source  string
+		allowed bool
+	}{
+		{"seed.json", true},
+		{filepath.Join(root, "seed.json"), true},
+		{"../" + filepath.Base(outside) + "/secret.json", false},
+		{filepath.Join(outside, "secret.json"), false},
+		{"link.json", false},
+		{"missing.json", false},
+		{"https://registry.example.com/seeds/official.json", true},
+		{"https://registry.example.com/seeds/../admin", false},
+		{"https://registry.example.com/seedsx/official.json", false},
+		{"https://registry.example.com.evil.io/seeds/official.json", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+	}
+	for _, tt := range tests {
+		_, err := confineImportSource(cfg, tt.source)
+		if tt.allowed && err != nil {
+			t.Errorf("confineImportSource(%q) error = %v", tt.source, err)
+		}
+		if !tt.allowed && err == nil {
+			t.Errorf("confineImportSource(%q) succeeded, want error", tt.source)
+		}
+	}
+
+	if _, err := confineImportSource(&config.Config{}, "seed.json"); err != errDataJobsDirUnset {
+		t.Errorf("confineImportSource() without DataJobsDir error = %v, want errDataJobsDirUnset", err)
+	}
+}
@@ -1,19 +1,52 @@
 package jobs
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
 	"sync"
 	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 )
 
 const (
-	// JobTTL is how long completed jobs are retained.
-	JobTTL = 1 * time.Hour
+	// JobTTL is how long finished jobs are retained.
+	JobTTL = 7 * 24 * time.Hour
 
 	// IndexJobType is the type for embedding indexing jobs.
 	IndexJobType = "embeddings-index"
+
+	// ImportJobType is the type for seed data import jobs.
+	ImportJobType = "import"
+
+	// ExportJobType is the type for seed data export jobs.
+	ExportJobType = "export"
+
+	// MirrorSyncJobType is the type for upstream registry mirror sync jobs.
+	MirrorSyncJobType = "mirror-sync"
+
+	// DeploymentReconcileJobType is the type for deployment reconcile passes.
+	DeploymentReconcileJobType = "deployment-reconcile"
+
+	// DefaultLeaseDuration is how long a claimed job stays owned by a replica
+	// without a heartbeat.
+	DefaultLeaseDuration = 30 * time.Second
+
+	// DefaultPollInterval is how often Start looks for unclaimed jobs.
+	DefaultPollInterval = 5 * time.Second
+
+	// DefaultMaxConcurrentJobs bounds how many polled jobs a replica runs at once.
+	DefaultMaxConcurrentJobs = 4
+
+	cleanupInterval = 10 * time.Minute
 )
 
 var (
@@ -22,164 +55,489 @@ var (
 
 	// ErrJobAlreadyRunning is returned when a job of the same type is already running.
 	ErrJobAlreadyRunning = errors.New("job already running")
+
+	// ErrUnknownJobType is returned when no runner is registered for a job type.
+	ErrUnknownJobType = errors.New("unknown job type")
+
+	// ErrJobCancelled is the cancellation cause seen by runners of cancelled jobs.
+	ErrJobCancelled = errors.New("job was cancelled")
+
+	// errLeaseLost is the cancellation cause when another replica took over the job.
+	errLeaseLost = errors.New("job lease lost")
 )
 
-// Manager manages async jobs in memory.
+type registeredRunner struct {
+	run  Runner
+	opts RunnerOptions
+}
+
+// Manager coordinates async jobs. Job state lives in a Store; when the store is
+// shared between registry replicas, leases ensure that each job is executed by
+// exactly one of them at a time.
 type Manager struct {
-	mu   sync.RWMutex
-	jobs map[JobID]*Job
+	store         Store
+	authz         *auth.Authorizer
+	owner         string
+	leaseDuration time.Duration
+	pollInterval  time.Duration
+	maxConcurrent int
+
+	mu      sync.Mutex
+	baseCtx context.Context
+	runners map[string]registeredRunner
+	running map[string]context.CancelCauseFunc
+}
+
+// ManagerOption configures a Manager.
+type ManagerOption func(*Manager)
+
+// WithStore sets the store used to persist jobs. Defaults to an in-memory store.
+func WithStore(store Store) ManagerOption {
+	return func(m *Manager) {
+		m.store = store
+	}
+}
+
+// WithAuthorizer sets the authorizer passed to the Authorize functions of
+// registered job types. Without one, jobs are not authorized.
+func WithAuthorizer(authz *auth.Authorizer) ManagerOption {
+	return func(m *Manager) {
+		m.authz = authz
+	}
+}
+
+// WithOwner sets the lease owner identity for this replica.
+func WithOwner(owner string) ManagerOption {
+	return func(m *Manager) {
+		if owner != "" {
+			m.owner = owner
+		}
+	}
+}
+
+// WithLeaseDuration sets how long a job lease lasts between heartbeats.
+func WithLeaseDuration(d time.Duration) ManagerOption {
+	return func(m *Manager) {
+		if d > 0 {
+			m.leaseDuration = d
+		}
+	}
+}
+
+// WithPollInterval sets how often Start polls for unclaimed jobs.
+func WithPollInterval(d time.Duration) ManagerOption {
+	return func(m *Manager) {
+		if d > 0 {
+			m.pollInterval = d
+		}
+	}
+}
+
+// WithMaxConcurrentJobs bounds how many polled jobs this replica runs at once.
+func WithMaxConcurrentJobs(n int) ManagerOption {
+	return func(m *Manager) {
+		if n > 0 {
+			m.maxConcurrent = n
+		}
+	}
 }
 
 // NewManager creates a new job manager.
-func NewManager() *Manager {
+func NewManager(opts ...ManagerOption) *Manager {
 	m := &Manager{
-		jobs: make(map[JobID]*Job),
+		leaseDuration: DefaultLeaseDuration,
+		pollInterval:  DefaultPollInterval,
+		maxConcurrent: DefaultMaxConcurrentJobs,
+		baseCtx:       context.Background(),
+		runners:       make(map[string]registeredRunner),
+		running:       make(map[string]context.CancelCauseFunc),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.store == nil {
+		m.store = NewMemoryStore()
+	}
+	if m.owner == "" {
+		m.owner = defaultOwner()
 	}
-	go m.cleanupLoop()
 	return m
 }
 
-// CreateJob creates a new job of the given type.
-// Returns ErrJobAlreadyRunning if a job of the same type is already running.
-func (m *Manager) CreateJob(jobType string) (*Job, error) {
+// Owner returns the lease owner identity of this replica.
+func (m *Manager) Owner() string {
+	return m.owner
+}
+
+// Register installs the runner for a job type. Registering a type again
+// replaces its runner.
+func (m *Manager) Register(jobType string, runner Runner, opts RunnerOptions) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.runners[jobType] = registeredRunner{run: runner, opts: opts}
+}
 
-	// Check if a job of this type is already running
-	for _, job := range m.jobs {
-		if job.Type == jobType && !job.IsTerminal() {
-			return nil, ErrJobAlreadyRunning
-		}
+// JobTypes returns the registered job types in sorted order.
+func (m *Manager) JobTypes() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	types := make([]string, 0, len(m.runners))
+	for jobType := range m.runners {
+		types = append(types, jobType)
 	}
+	slices.Sort(types)
+	return types
+}
 
-	id := generateJobID(jobType)
-	now := time.Now().UTC()
+func (m *Manager) runner(jobType string) (registeredRunner, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	r, ok := m.runners[jobType]
+	return r, ok
+}
 
+func (m *Manager) create(ctx context.Context, jobType string, params models.JSONObject) (*Job, registeredRunner, error) {
+	r, ok := m.runner(jobType)
+	if !ok {
+		return nil, registeredRunner{}, fmt.Errorf("%w: %s", ErrUnknownJobType, jobType)
+	}
+	if params == nil {
+		params = models.JSONObject{}
+	}
+	if err := m.authorize(ctx, r, params); err != nil {
+		return nil, registeredRunner{}, err
+	}
+	submitter, err := auth.MarshalPrincipal(ctx)
+	if err != nil {
+		return nil, registeredRunner{}, err
+	}
 	job := &Job{
-		ID:        id,
+		ID:        string(generateJobID(jobType)),
 		Type:      jobType,
 		Status:    JobStatusPending,
-		Progress:  JobProgress{},
-		CreatedAt: now,
-		UpdatedAt: now,
+		Params:    params,
+		Submitter: submitter,
 	}
-
-	m.jobs[id] = job
-	return job, nil
+	if err := m.store.CreateJob(ctx, job, r.opts.Exclusive); err != nil {
+		if errors.Is(err, database.ErrAlreadyExists) {
+			return nil, registeredRunner{}, ErrJobAlreadyRunning
+		}
+		return nil, registeredRunner{}, fmt.Errorf("failed to create job: %w", err)
+	}
+	return job, r, nil
 }
 
-// GetJob retrieves a job by ID.
-func (m *Manager) GetJob(id JobID) (*Job, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+func (m *Manager) authorize(ctx context.Context, r registeredRunner, params models.JSONObject) error {
+	if m.authz == nil || r.opts.Authorize == nil {
+		return nil
+	}
+	return r.opts.Authorize(ctx, m.authz, params)
+}
 
-	job, ok := m.jobs[id]
-	if !ok {
-		return nil, ErrJobNotFound
+// Submit creates a pending job and starts executing it on this replica in the
+// background. The returned job reflects its state at creation time.
+// Returns ErrJobAlreadyRunning if the type is exclusive and a job of the same
+// type is already pending or running.
+func (m *Manager) Submit(ctx context.Context, jobType string, params models.JSONObject) (*Job, error) {
+	job, r, err := m.create(ctx, jobType, params)
+	if err != nil {
+		return nil, err
 	}
-	// Return a copy to avoid race conditions
 	jobCopy := *job
+	go m.claimAndRun(job.ID, r.run)
 	return &jobCopy, nil
 }
 
-// GetRunningJob returns the currently running job of the given type, if any.
-func (m *Manager) GetRunningJob(jobType string) *Job {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+// Begin creates a job that is already claimed by this replica, for callers that
+// execute the job inline through Execute (for example streaming handlers).
+func (m *Manager) Begin(ctx context.Context, jobType string, params models.JSONObject) (*Job, error) {
+	job, _, err := m.create(ctx, jobType, params)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := m.store.ClaimJob(ctx, job.ID, m.owner, m.leaseUntil())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim job: %w", err)
+	}
+	return claimed, nil
+}
+
+// Execute runs a job previously claimed through Begin, keeping its lease alive
+// and recording the outcome. The run is cancelled when ctx is done or the job
+// is cancelled. Only a cancelled job finishes as cancelled: when ctx ends the
+// lease is released and the job is left pending for any replica to claim.
+func (m *Manager) Execute(ctx context.Context, job *Job, runner Runner) (*JobResult, error) {
+	return m.execute(ctx, job, runner)
+}
 
-	for _, job := range m.jobs {
-		if job.Type == jobType && !job.IsTerminal() {
-			jobCopy := *job
-			return &jobCopy
+func (m *Manager) claimAndRun(id string, runner Runner) {
+	ctx := m.context()
+	job, err := m.store.ClaimJob(ctx, id, m.owner, m.leaseUntil())
+	if err != nil {
+		// Another replica claimed it first, or it was cancelled while pending.
+		if !errors.Is(err, database.ErrNotFound) {
+			slog.Error("failed to claim job", "job_id", id, "error", err)
 		}
+		return
 	}
-	return nil
+	_, _ = m.execute(ctx, job, runner)
 }
 
-// StartJob transitions a job to running status.
-func (m *Manager) StartJob(id JobID) error {
+func (m *Manager) execute(ctx context.Context, job *Job, runner Runner) (*JobResult, error) {
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	// Runners act as the submitter, never with the privileges of this replica.
+	runCtx, err := auth.WithStoredPrincipal(runCtx, job.Submitter)
+	if err != nil {
+		if finishErr := m.store.FinishJob(context.WithoutCancel(ctx), job.ID, m.owner, JobStatusFailed, &JobResult{Error: err.Error()}); finishErr != nil {
+			slog.Error("failed to record job outcome", "job_id", job.ID, "status", JobStatusFailed, "error", finishErr)
+		}
+		return nil, err
+	}
+
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.running[job.ID] = cancel
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.running, job.ID)
+		m.mu.Unlock()
+	}()
+
+	storeCtx := context.WithoutCancel(ctx)
+	heartbeatDone := make(chan struct{})
+	stopHeartbeat := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		m.heartbeat(storeCtx, job.ID, cancel, stopHeartbeat)
+	}()
+
+	report := func(progress JobProgress) {
+		if err := m.store.UpdateJobProgress(storeCtx, job.ID, m.owner, progress); err != nil {
+			slog.Warn("failed to update job progress", "job_id", job.ID, "error", err)
+		}
+	}
 
-	job, ok := m.jobs[id]
-	if !ok {
-		return ErrJobNotFound
+	result, err := runner(runCtx, job, report)
+	close(stopHeartbeat)
+	<-heartbeatDone
+
+	cause := context.Cause(runCtx)
+	if errors.Is(cause, errLeaseLost) {
+		slog.Warn("job lease lost; leaving job to its new owner", "job_id", job.ID)
+		return nil, errLeaseLost
 	}
 
-	job.Status = JobStatusRunning
-	job.UpdatedAt = time.Now().UTC()
-	return nil
+	status := JobStatusCompleted
+	if err != nil {
+		status = JobStatusFailed
+		switch {
+		case errors.Is(cause, ErrJobCancelled):
+			status = JobStatusCancelled
+			err = ErrJobCancelled
+		case ctx.Err() != nil:
+			// The replica is shutting down or the caller went away; nobody
+			// cancelled the job, so another run may finish it.
+			if releaseErr := m.store.ReleaseJob(storeCtx, job.ID, m.owner); releaseErr != nil {
+				slog.Error("failed to release job", "job_id", job.ID, "error", releaseErr)
+			}
+			return nil, err
+		}
+		if result == nil {
+			result = &JobResult{}
+		}
+		result.Error = err.Error()
+	}
+
+	if finishErr := m.store.FinishJob(storeCtx, job.ID, m.owner, status, result); finishErr != nil {
+		slog.Error("failed to record job outcome", "job_id", job.ID, "status", status, "error", finishErr)
+	}
+	return result, err
 }
 
-// UpdateProgress updates the progress of a job.
-func (m *Manager) UpdateProgress(id JobID, progress JobProgress) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+// heartbeat renews the job lease until stop is closed, cancelling the run when
+// the job is cancelled or the lease is lost.
+func (m *Manager) heartbeat(ctx context.Context, id string, cancel context.CancelCauseFunc, stop <-chan struct{}) {
+	ticker := time.NewTicker(m.leaseDuration / 3)
+	defer ticker.Stop()
 
-	job, ok := m.jobs[id]
-	if !ok {
-		return ErrJobNotFound
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			cancelRequested, err := m.store.RenewJobLease(ctx, id, m.owner, m.leaseUntil())
+			switch {
+			case errors.Is(err, database.ErrNotFound):
+				cancel(errLeaseLost)
+				return
+			case err != nil:
+				slog.Warn("failed to renew job lease", "job_id", id, "error", err)
+			case cancelRequested:
+				cancel(ErrJobCancelled)
+			}
+		}
 	}
+}
 
-	job.Progress = progress
-	job.UpdatedAt = time.Now().UTC()
+// GetJob retrieves a job by ID.
+func (m *Manager) GetJob(ctx context.Context, id JobID) (*Job, error) {
+	job, err := m.store.GetJob(ctx, string(id))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListJobs lists jobs, newest first.
+func (m *Manager) ListJobs(ctx context.Context, filter *models.JobFilter) ([]*Job, error) {
+	return m.store.ListJobs(ctx, filter)
+}
+
+// GetRunningJob returns the pending or running job of the given type, if any.
+func (m *Manager) GetRunningJob(ctx context.Context, jobType string) *Job {
+	for _, status := range []string{JobStatusRunning, JobStatusPending} {
+		jobs, err := m.store.ListJobs(ctx, &models.JobFilter{Type: &jobType, Status: &status, Limit: 1})
+		if err == nil && len(jobs) > 0 {
+			return jobs[0]
+		}
+	}
 	return nil
 }
 
-// CompleteJob marks a job as completed with a result.
-func (m *Manager) CompleteJob(id JobID, result *JobResult) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+// Cancel requests cancellation of a job. Pending jobs are cancelled
+// immediately; running jobs stop at their owner's next heartbeat, or right away
+// when they run on this replica. Cancelling a finished job is a no-op.
+func (m *Manager) Cancel(ctx context.Context, id JobID) (*Job, error) {
+	existing, err := m.GetJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := m.runner(existing.Type); ok {
+		if err := m.authorize(ctx, r, existing.Params); err != nil {
+			return nil, err
+		}
+	}
 
-	job, ok := m.jobs[id]
-	if !ok {
-		return ErrJobNotFound
+	job, err := m.store.RequestJobCancel(ctx, string(id))
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, err
 	}
 
-	job.Status = JobStatusCompleted
-	job.Result = result
-	job.UpdatedAt = time.Now().UTC()
-	return nil
+	m.mu.Lock()
+	cancel, ok := m.running[job.ID]
+	m.mu.Unlock()
+	if ok {
+		cancel(ErrJobCancelled)
+	}
+	return job, nil
 }
 
-// FailJob marks a job as failed with an error message.
-func (m *Manager) FailJob(id JobID, errMsg string) error {
+// Start runs the background worker until ctx is done. It claims pending jobs
+// of registered types (including jobs orphaned by replicas whose lease
+// expired) and removes finished jobs older than JobTTL.
+func (m *Manager) Start(ctx context.Context) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.baseCtx = ctx
+	m.mu.Unlock()
 
-	job, ok := m.jobs[id]
-	if !ok {
-		return ErrJobNotFound
-	}
+	go m.pollLoop(ctx)
+	go m.cleanupLoop(ctx)
+}
 
-	job.Status = JobStatusFailed
-	job.Result = &JobResult{Error: errMsg}
-	job.UpdatedAt = time.Now().UTC()
-	return nil
+func (m *Manager) context() context.Context {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.baseCtx
 }
 
-// cleanupLoop periodically removes old completed/failed jobs.
-func (m *Manager) cleanupLoop() {
-	ticker := time.NewTicker(10 * time.Minute)
+func (m *Manager) pollLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.pollInterval)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		m.cleanup()
+	slots := make(chan struct{}, m.maxConcurrent)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		jobTypes := m.JobTypes()
+		if len(jobTypes) == 0 {
+			continue
+		}
+	claim:
+		for {
+			select {
+			case slots <- struct{}{}:
+			default:
+				break claim
+			}
+			job, err := m.store.ClaimNextJob(ctx, jobTypes, m.owner, m.leaseUntil())
+			if err != nil {
+				<-slots
+				if !errors.Is(err, database.ErrNotFound) && ctx.Err() == nil {
+					slog.Warn("failed to claim next job", "error", err)
+				}
+				break
+			}
+			r, _ := m.runner(job.Type)
+			go func() {
+				defer func() { <-slots }()
+				_, _ = m.execute(ctx, job, r.run)
+			}()
+		}
 	}
 }
 
-func (m *Manager) cleanup() {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+func (m *Manager) cleanupLoop(ctx context.Context) {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
 
-	cutoff := time.Now().UTC().Add(-JobTTL)
-	for id, job := range m.jobs {
-		if job.IsTerminal() && job.UpdatedAt.Before(cutoff) {
-			delete(m.jobs, id)
+	for {
+		m.cleanup(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
 		}
 	}
 }
 
+func (m *Manager) cleanup(ctx context.Context) {
+	if _, err := m.store.DeleteJobsFinishedBefore(ctx, time.Now().UTC().Add(-JobTTL)); err != nil && ctx.Err() == nil {
+		slog.Warn("failed to clean up finished jobs", "error", err)
+	}
+}
+
+func (m *Manager) leaseUntil() time.Time {
+	return time.Now().UTC().Add(m.leaseDuration)
+}
+
+func defaultOwner() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "registry"
+	}
+	return fmt.Sprintf("%s-%d-%s", host, os.Getpid(), randomHex(4))
+}
+
+func randomHex(n int) string {
+	bytes := make([]byte, n)
+	if _, err := rand.Read(bytes); err != nil {
+		return time.Now().UTC().Format("150405")
+	}
+	return hex.EncodeToString(bytes)
+}
+
 func generateJobID(prefix string) JobID {
 	bytes := make([]byte, 6)
 	if _, err := rand.Read(bytes); err != nil {
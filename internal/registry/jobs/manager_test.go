@@ -0,0 +1,288 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitForStatus(t *testing.T, m *Manager, id string, status JobStatus) *Job {
+	t.Helper()
+	var job *Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = m.GetJob(context.Background(), JobID(id))
+		return err == nil && job.Status == status
+	}, 2*time.Second, 10*time.Millisecond, "job %s never reached status %s", id, status)
+	return job
+}
+
+func TestManager_SubmitRunsJobAndRecordsResult(t *testing.T) {
+	m := NewManager()
+	m.Register("export", func(ctx context.Context, job *Job, report ProgressFunc) (*JobResult, error) {
+		report(JobProgress{Total: 2, Processed: 2})
+		return &JobResult{ItemsExported: 2, OutputPath: job.Params["fileName"].(string)}, nil
+	}, RunnerOptions{})
+
+	job, err := m.Submit(context.Background(), "export", map[string]any{"fileName": "out.json"})
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusPending, job.Status)
+
+	done := waitForStatus(t, m, job.ID, JobStatusCompleted)
+	require.NotNil(t, done.Result)
+	assert.Equal(t, 2, done.Result.ItemsExported)
+	assert.Equal(t, "out.json", done.Result.OutputPath)
+	assert.Equal(t, 2, done.Progress.Processed)
+	assert.Equal(t, 1, done.Attempts)
+	assert.Empty(t, done.LeaseOwner)
+	assert.NotNil(t, done.FinishedAt)
+}
+
+func TestManager_SubmitUnknownType(t *testing.T) {
+	m := NewManager()
+	_, err := m.Submit(context.Background(), "nope", nil)
+	assert.ErrorIs(t, err, ErrUnknownJobType)
+}
+
+func TestManager_ExclusiveTypeRejectsSecondJob(t *testing.T) {
+	m := NewManager()
+	release := make(chan struct{})
+	m.Register(IndexJobType, func(ctx context.Context, job *Job, report ProgressFunc) (*JobResult, error) {
+		<-release
+		return &JobResult{}, nil
+	}, RunnerOptions{Exclusive: true})
+
+	first, err := m.Submit(context.Background(), IndexJobType, nil)
+	require.NoError(t, err)
+
+	_, err = m.Submit(context.Background(), IndexJobType, nil)
+	assert.ErrorIs(t, err, ErrJobAlreadyRunning)
+
+	running := m.GetRunningJob(context.Background(), IndexJobType)
+	require.NotNil(t, running)
+	assert.Equal(t, first.ID, running.ID)
+
+	close(release)
+	waitForStatus(t, m, first.ID, JobStatusCompleted)
+
+	_, err = m.Submit(context.Background(), IndexJobType, nil)
+	assert.NoError(t, err)
+}
+
+func TestManager_CancelStopsRunningJob(t *testing.T) {
+	m := NewManager()
+	started := make(chan struct{})
+	m.Register("import", func(ctx context.Context, job *Job, report ProgressFunc) (*JobResult, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, context.Cause(ctx)
+	}, RunnerOptions{})
+
+	job, err := m.Submit(context.Background(), "import", nil)
+	require.NoError(t, err)
+	<-started
+
+	_, err = m.Cancel(context.Background(), JobID(job.ID))
+	require.NoError(t, err)
+
+	cancelled := waitForStatus(t, m, job.ID, JobStatusCancelled)
+	require.NotNil(t, cancelled.Result)
+	assert.Equal(t, ErrJobCancelled.Error(), cancelled.Result.Error)
+}
+
+func TestManager_CancelPendingJob(t *testing.T) {
+	store := NewMemoryStore()
+	m := NewManager(WithStore(store))
+	m.Register("import", func(ctx context.Context, job *Job, report ProgressFunc) (*JobResult, error) {
+		return &JobResult{}, nil
+	}, RunnerOptions{})
+
+	// Create the job directly so that nothing claims it.
+	job := &Job{ID: "import-pending", Type: "import", Status: JobStatusPending}
+	require.NoError(t, store.CreateJob(context.Background(), job, false))
+
+	cancelled, err := m.Cancel(context.Background(), JobID(job.ID))
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCancelled, cancelled.Status)
+
+	_, err = store.ClaimJob(context.Background(), job.ID, "other", time.Now().Add(time.Minute))
+	assert.Error(t, err, "cancelled jobs must not be claimable")
+
+	_, err = m.Cancel(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestManager_StartResumesJobWithExpiredLease(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	// A replica claimed the job and died without renewing its lease.
+	job := &Job{ID: "export-orphan", Type: "export", Status: JobStatusPending}
+	require.NoError(t, store.CreateJob(ctx, job, false))
+	_, err := store.ClaimJob(ctx, job.ID, "dead-replica", time.Now().Add(-time.Second))
+	require.NoError(t, err)
+
+	m := NewManager(WithStore(store), WithOwner("survivor"), WithPollInterval(10*time.Millisecond))
+	m.Register("export", func(ctx context.Context, job *Job, report ProgressFunc) (*JobResult, error) {
+		return &JobResult{ItemsExported: 1}, nil
+	}, RunnerOptions{})
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	m.Start(runCtx)
+
+	done := waitForStatus(t, m, job.ID, JobStatusCompleted)
+	assert.Equal(t, 2, done.Attempts)
+}
+
+func TestManager_ShutdownReleasesRunningJob(t *testing.T) {
+	store := NewMemoryStore()
+	m := NewManager(WithStore(store), WithOwner("a"), WithPollInterval(10*time.Millisecond))
+	started := make(chan struct{})
+	m.Register("export", func(ctx context.Context, job *Job, report ProgressFunc) (*JobResult, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, RunnerOptions{})
+
+	job := &Job{ID: "export-shutdown", Type: "export", Status: JobStatusPending}
+	require.NoError(t, store.CreateJob(context.Background(), job, false))
+	runCtx, shutdown := context.WithCancel(context.Background())
+	m.Start(runCtx)
+	<-started
+	shutdown()
+
+	// Nobody cancelled the job: it is queued again for any replica.
+	released := waitForStatus(t, m, job.ID, JobStatusPending)
+	assert.Empty(t, released.LeaseOwner)
+	assert.Nil(t, released.LeaseExpiresAt)
+	assert.Nil(t, released.FinishedAt)
+	claimed, err := store.ClaimJob(context.Background(), job.ID, "b", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 2, claimed.Attempts)
+}
+
+func TestMemoryStore_ReleaseCancelsJobWithCancelRequested(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	job := &Job{ID: "import-release", Type: "import", Status: JobStatusPending}
+	require.NoError(t, store.CreateJob(ctx, job, false))
+	_, err := store.ClaimJob(ctx, job.ID, "a", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	_, err = store.RequestJobCancel(ctx, job.ID)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, store.ReleaseJob(ctx, job.ID, "b"), database.ErrNotFound)
+	require.NoError(t, store.ReleaseJob(ctx, job.ID, "a"))
+	released, err := store.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCancelled, released.Status)
+	assert.NotNil(t, released.FinishedAt)
+}
+
+// lostLeaseStore simulates another replica taking over every job lease.
+type lostLeaseStore struct {
+	Store
+}
+
+func (s lostLeaseStore) RenewJobLease(context.Context, string, string, time.Time) (bool, error) {
+	return false, database.ErrNotFound
+}
+
+func TestManager_LostLeaseDoesNotFinishJob(t *testing.T) {
+	store := lostLeaseStore{Store: NewMemoryStore()}
+	m := NewManager(WithStore(store), WithOwner("a"), WithLeaseDuration(30*time.Millisecond))
+	ctx := context.Background()
+
+	job, err := m.Begin(ctx, "import", nil)
+	assert.ErrorIs(t, err, ErrUnknownJobType)
+	assert.Nil(t, job)
+
+	m.Register("import", func(context.Context, *Job, ProgressFunc) (*JobResult, error) { return nil, nil }, RunnerOptions{})
+	job, err = m.Begin(ctx, "import", nil)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusRunning, job.Status)
+	assert.Equal(t, "a", job.LeaseOwner)
+
+	_, err = m.Execute(ctx, job, func(ctx context.Context, job *Job, report ProgressFunc) (*JobResult, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	assert.ErrorIs(t, err, errLeaseLost)
+
+	current, err := m.GetJob(ctx, JobID(job.ID))
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusRunning, current.Status, "the new lease owner finishes the job")
+	assert.Nil(t, current.FinishedAt)
+}
+
+// testSession is a session holding only the given permissions.
+type testSession struct {
+	permissions []auth.Permission
+}
+
+func (s *testSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Subject: "alice", Permissions: s.permissions}}
+}
+
+func TestManager_AuthorizesAndRunsAsSubmitter(t *testing.T) {
+	m := NewManager(WithAuthorizer(&auth.Authorizer{Authz: auth.NewPublicAuthzProvider(nil)}))
+	subjects := make(chan string, 1)
+	m.Register("import", func(ctx context.Context, job *Job, report ProgressFunc) (*JobResult, error) {
+		s, _ := auth.AuthSessionFrom(ctx)
+		if s == nil || auth.IsSystemSession(s) {
+			subjects <- ""
+		} else {
+			subjects <- s.Principal().User.Subject
+		}
+		return &JobResult{}, nil
+	}, RunnerOptions{Authorize: RequireRegistryAdmin})
+
+	_, err := m.Submit(context.Background(), "import", nil)
+	assert.ErrorIs(t, err, auth.ErrUnauthenticated)
+
+	publisher := auth.AuthSessionTo(context.Background(), &testSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "io.github.acme/*"},
+	}})
+	_, err = m.Submit(publisher, "import", nil)
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+
+	admin := auth.AuthSessionTo(context.Background(), &testSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+	}})
+	job, err := m.Submit(admin, "import", nil)
+	require.NoError(t, err)
+	waitForStatus(t, m, job.ID, JobStatusCompleted)
+	assert.Equal(t, "alice", <-subjects)
+
+	_, err = m.Cancel(publisher, JobID(job.ID))
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+}
+
+func TestMemoryStore_ClaimCancelsAbandonedJobs(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+
+	// The owner of a cancelled job died before it noticed the cancellation.
+	job := &Job{ID: "import-abandoned", Type: "import", Status: JobStatusPending}
+	require.NoError(t, store.CreateJob(ctx, job, true))
+	_, err := store.ClaimJob(ctx, job.ID, "dead-replica", time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	_, err = store.RequestJobCancel(ctx, job.ID)
+	require.NoError(t, err)
+
+	_, err = store.ClaimNextJob(ctx, []string{"import"}, "survivor", time.Now().Add(time.Minute))
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	cancelled, err := store.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, JobStatusCancelled, cancelled.Status)
+	assert.NotNil(t, cancelled.FinishedAt)
+	require.NoError(t, store.CreateJob(ctx, &Job{ID: "import-next", Type: "import", Status: JobStatusPending}, true))
+}
@@ -0,0 +1,348 @@
+package jobs
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// Store persists jobs and their leases. Implementations return
+// database.ErrNotFound for missing jobs, unclaimable jobs and lost leases, and
+// database.ErrAlreadyExists when an exclusive job type is already active.
+type Store interface {
+	CreateJob(ctx context.Context, job *Job, exclusive bool) error
+	GetJob(ctx context.Context, id string) (*Job, error)
+	ListJobs(ctx context.Context, filter *models.JobFilter) ([]*Job, error)
+	ClaimJob(ctx context.Context, id, owner string, leaseUntil time.Time) (*Job, error)
+	ClaimNextJob(ctx context.Context, jobTypes []string, owner string, leaseUntil time.Time) (*Job, error)
+	RenewJobLease(ctx context.Context, id, owner string, leaseUntil time.Time) (bool, error)
+	UpdateJobProgress(ctx context.Context, id, owner string, progress JobProgress) error
+	FinishJob(ctx context.Context, id, owner, status string, result *JobResult) error
+	ReleaseJob(ctx context.Context, id, owner string) error
+	RequestJobCancel(ctx context.Context, id string) (*Job, error)
+	DeleteJobsFinishedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// databaseStore adapts database.Database to Store so that jobs are shared by
+// every replica connected to the same registry database.
+type databaseStore struct {
+	db database.Database
+}
+
+// NewDatabaseStore returns a Store backed by the registry database.
+func NewDatabaseStore(db database.Database) Store {
+	return &databaseStore{db: db}
+}
+
+func (s *databaseStore) CreateJob(ctx context.Context, job *Job, exclusive bool) error {
+	return s.db.CreateJob(ctx, nil, job, exclusive)
+}
+
+func (s *databaseStore) GetJob(ctx context.Context, id string) (*Job, error) {
+	return s.db.GetJob(ctx, nil, id)
+}
+
+func (s *databaseStore) ListJobs(ctx context.Context, filter *models.JobFilter) ([]*Job, error) {
+	return s.db.ListJobs(ctx, nil, filter)
+}
+
+func (s *databaseStore) ClaimJob(ctx context.Context, id, owner string, leaseUntil time.Time) (*Job, error) {
+	return s.db.ClaimJob(ctx, nil, id, owner, leaseUntil)
+}
+
+func (s *databaseStore) ClaimNextJob(ctx context.Context, jobTypes []string, owner string, leaseUntil time.Time) (*Job, error) {
+	return s.db.ClaimNextJob(ctx, nil, jobTypes, owner, leaseUntil)
+}
+
+func (s *databaseStore) RenewJobLease(ctx context.Context, id, owner string, leaseUntil time.Time) (bool, error) {
+	return s.db.RenewJobLease(ctx, nil, id, owner, leaseUntil)
+}
+
+func (s *databaseStore) UpdateJobProgress(ctx context.Context, id, owner string, progress JobProgress) error {
+	return s.db.UpdateJobProgress(ctx, nil, id, owner, progress)
+}
+
+func (s *databaseStore) FinishJob(ctx context.Context, id, owner, status string, result *JobResult) error {
+	return s.db.FinishJob(ctx, nil, id, owner, status, result)
+}
+
+func (s *databaseStore) ReleaseJob(ctx context.Context, id, owner string) error {
+	return s.db.ReleaseJob(ctx, nil, id, owner)
+}
+
+func (s *databaseStore) RequestJobCancel(ctx context.Context, id string) (*Job, error) {
+	return s.db.RequestJobCancel(ctx, nil, id)
+}
+
+func (s *databaseStore) DeleteJobsFinishedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	return s.db.DeleteJobsFinishedBefore(ctx, nil, cutoff)
+}
+
+// memoryStore keeps jobs in process memory. It is used when the registry runs
+// without a shared database and in tests.
+type memoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+// NewMemoryStore returns a Store that keeps jobs in process memory.
+func NewMemoryStore() Store {
+	return &memoryStore{jobs: make(map[string]*Job)}
+}
+
+func copyJob(job *Job) *Job {
+	jobCopy := *job
+	jobCopy.Params = models.JSONObject{}
+	for k, v := range job.Params {
+		jobCopy.Params[k] = v
+	}
+	if job.Result != nil {
+		result := *job.Result
+		jobCopy.Result = &result
+	}
+	return &jobCopy
+}
+
+func (s *memoryStore) CreateJob(_ context.Context, job *Job, exclusive bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.ID]; ok {
+		return database.ErrAlreadyExists
+	}
+	if exclusive {
+		for _, existing := range s.jobs {
+			if existing.Type == job.Type && !existing.IsTerminal() {
+				return database.ErrAlreadyExists
+			}
+		}
+	}
+	now := time.Now().UTC()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	s.jobs[job.ID] = copyJob(job)
+	return nil
+}
+
+func (s *memoryStore) GetJob(_ context.Context, id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return copyJob(job), nil
+}
+
+func (s *memoryStore) ListJobs(_ context.Context, filter *models.JobFilter) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		if filter != nil && filter.Type != nil && job.Type != *filter.Type {
+			continue
+		}
+		if filter != nil && filter.Status != nil && job.Status != *filter.Status {
+			continue
+		}
+		out = append(out, copyJob(job))
+	}
+	slices.SortFunc(out, func(a, b *Job) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	if filter != nil && filter.Limit > 0 && len(out) > filter.Limit {
+		out = out[:filter.Limit]
+	}
+	return out, nil
+}
+
+func (s *memoryStore) claimable(job *Job, now time.Time) bool {
+	if job.CancelRequested {
+		return false
+	}
+	if job.Status == JobStatusPending {
+		return true
+	}
+	return job.Status == JobStatusRunning && job.LeaseExpiresAt != nil && job.LeaseExpiresAt.Before(now)
+}
+
+func (s *memoryStore) claim(job *Job, owner string, leaseUntil, now time.Time) *Job {
+	job.Status = JobStatusRunning
+	job.LeaseOwner = owner
+	job.LeaseExpiresAt = &leaseUntil
+	job.Attempts++
+	if job.StartedAt == nil {
+		job.StartedAt = &now
+	}
+	job.UpdatedAt = now
+	return copyJob(job)
+}
+
+// cancelAbandoned finishes running jobs whose cancellation was requested but
+// whose lease expired before their owner stopped them.
+func (s *memoryStore) cancelAbandoned(now time.Time) {
+	for _, job := range s.jobs {
+		if !job.CancelRequested || job.Status != JobStatusRunning || job.LeaseExpiresAt == nil || !job.LeaseExpiresAt.Before(now) {
+			continue
+		}
+		if job.Result == nil {
+			job.Result = &JobResult{}
+		}
+		job.Result.Error = ErrJobCancelled.Error()
+		job.Status = JobStatusCancelled
+		job.LeaseOwner = ""
+		job.LeaseExpiresAt = nil
+		job.FinishedAt = &now
+		job.UpdatedAt = now
+	}
+}
+
+func (s *memoryStore) ClaimJob(_ context.Context, id, owner string, leaseUntil time.Time) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	s.cancelAbandoned(now)
+	job, ok := s.jobs[id]
+	if !ok || !s.claimable(job, now) {
+		return nil, database.ErrNotFound
+	}
+	return s.claim(job, owner, leaseUntil, now), nil
+}
+
+func (s *memoryStore) ClaimNextJob(_ context.Context, jobTypes []string, owner string, leaseUntil time.Time) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	s.cancelAbandoned(now)
+	var next *Job
+	for _, job := range s.jobs {
+		if !slices.Contains(jobTypes, job.Type) || !s.claimable(job, now) {
+			continue
+		}
+		if next == nil || job.CreatedAt.Before(next.CreatedAt) {
+			next = job
+		}
+	}
+	if next == nil {
+		return nil, database.ErrNotFound
+	}
+	return s.claim(next, owner, leaseUntil, now), nil
+}
+
+func (s *memoryStore) heldJob(id, owner string) (*Job, error) {
+	job, ok := s.jobs[id]
+	if !ok || job.Status != JobStatusRunning || job.LeaseOwner != owner {
+		return nil, database.ErrNotFound
+	}
+	return job, nil
+}
+
+func (s *memoryStore) RenewJobLease(_ context.Context, id, owner string, leaseUntil time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.heldJob(id, owner)
+	if err != nil {
+		return false, err
+	}
+	job.LeaseExpiresAt = &leaseUntil
+	job.UpdatedAt = time.Now().UTC()
+	return job.CancelRequested, nil
+}
+
+func (s *memoryStore) UpdateJobProgress(_ context.Context, id, owner string, progress JobProgress) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.heldJob(id, owner)
+	if err != nil {
+		return err
+	}
+	job.Progress = progress
+	job.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+func (s *memoryStore) FinishJob(_ context.Context, id, owner, status string, result *JobResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.heldJob(id, owner)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	job.Status = status
+	job.Result = result
+	job.LeaseOwner = ""
+	job.LeaseExpiresAt = nil
+	job.FinishedAt = &now
+	job.UpdatedAt = now
+	return nil
+}
+
+func (s *memoryStore) ReleaseJob(_ context.Context, id, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.heldJob(id, owner)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	job.Status = JobStatusPending
+	if job.CancelRequested {
+		// Jobs flagged for cancellation are never claimed again.
+		if job.Result == nil {
+			job.Result = &JobResult{}
+		}
+		job.Result.Error = ErrJobCancelled.Error()
+		job.Status = JobStatusCancelled
+		job.FinishedAt = &now
+	}
+	job.LeaseOwner = ""
+	job.LeaseExpiresAt = nil
+	job.UpdatedAt = now
+	return nil
+}
+
+func (s *memoryStore) RequestJobCancel(_ context.Context, id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	if job.IsTerminal() {
+		return copyJob(job), nil
+	}
+	now := time.Now().UTC()
+	job.CancelRequested = true
+	if job.Status == JobStatusPending {
+		job.Status = JobStatusCancelled
+		job.FinishedAt = &now
+	}
+	job.UpdatedAt = now
+	return copyJob(job), nil
+}
+
+func (s *memoryStore) DeleteJobsFinishedBefore(_ context.Context, cutoff time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for id, job := range s.jobs {
+		if job.IsTerminal() && job.FinishedAt != nil && job.FinishedAt.Before(cutoff) {
+			delete(s.jobs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -2,56 +2,57 @@
 package jobs
 
 import (
-	"time"
+	"context"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 )
 
 // JobID uniquely identifies a job.
 type JobID string
 
 // JobStatus represents the current state of a job.
-type JobStatus string
+type JobStatus = string
 
 const (
-	JobStatusPending   JobStatus = "pending"
-	JobStatusRunning   JobStatus = "running"
-	JobStatusCompleted JobStatus = "completed"
-	JobStatusFailed    JobStatus = "failed"
+	JobStatusPending   JobStatus = models.JobStatusPending
+	JobStatusRunning   JobStatus = models.JobStatusRunning
+	JobStatusCompleted JobStatus = models.JobStatusCompleted
+	JobStatusFailed    JobStatus = models.JobStatusFailed
+	JobStatusCancelled JobStatus = models.JobStatusCancelled
 )
 
 // JobProgress tracks the progress of a job.
-type JobProgress struct {
-	Total     int `json:"total"`
-	Processed int `json:"processed"`
-	Updated   int `json:"updated"`
-	Skipped   int `json:"skipped"`
-	Failures  int `json:"failures"`
-}
+type JobProgress = models.JobProgress
 
 // JobResult contains the final outcome of a job.
-type JobResult struct {
-	ServersProcessed int    `json:"serversProcessed,omitempty"`
-	ServersUpdated   int    `json:"serversUpdated,omitempty"`
-	ServersSkipped   int    `json:"serversSkipped,omitempty"`
-	ServerFailures   int    `json:"serverFailures,omitempty"`
-	AgentsProcessed  int    `json:"agentsProcessed,omitempty"`
-	AgentsUpdated    int    `json:"agentsUpdated,omitempty"`
-	AgentsSkipped    int    `json:"agentsSkipped,omitempty"`
-	AgentFailures    int    `json:"agentFailures,omitempty"`
-	Error            string `json:"error,omitempty"`
-}
+type JobResult = models.JobResult
 
 // Job represents an async job with progress tracking.
-type Job struct {
-	ID        JobID       `json:"id"`
-	Type      string      `json:"type"`
-	Status    JobStatus   `json:"status"`
-	Progress  JobProgress `json:"progress"`
-	Result    *JobResult  `json:"result,omitempty"`
-	CreatedAt time.Time   `json:"createdAt"`
-	UpdatedAt time.Time   `json:"updatedAt"`
+type Job = models.Job
+
+// ProgressFunc records progress for the job being executed.
+type ProgressFunc func(progress JobProgress)
+
+// Runner executes a job of a registered type. Params are available on
+// job.Params. The context is cancelled when the job is cancelled or when this
+// replica loses the job lease.
+type Runner func(ctx context.Context, job *Job, report ProgressFunc) (*JobResult, error)
+
+// AuthorizeFunc decides whether the caller in ctx may submit or cancel a job
+// with the given params.
+type AuthorizeFunc func(ctx context.Context, authz *auth.Authorizer, params models.JSONObject) error
+
+// RequireRegistryAdmin is an AuthorizeFunc that allows only registry admins.
+func RequireRegistryAdmin(ctx context.Context, authz *auth.Authorizer, _ models.JSONObject) error {
+	return authz.CheckRegistryAdmin(ctx)
 }
 
-// IsTerminal returns true if the job is in a terminal state.
-func (j *Job) IsTerminal() bool {
-	return j.Status == JobStatusCompleted || j.Status == JobStatusFailed
+// RunnerOptions controls how jobs of a registered type are scheduled.
+type RunnerOptions struct {
+	// Exclusive allows at most one pending or running job of the type.
+	Exclusive bool
+	// Authorize, when set, is checked before a job of the type is submitted or
+	// cancelled. The job then runs with the permissions of its submitter.
+	Authorize AuthorizeFunc
 }
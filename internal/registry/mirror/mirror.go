@@ -0,0 +1,338 @@
+// Package mirror keeps servers from upstream registries (the official MCP
+// registry or another agentregistry) in sync with this registry.
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// MetadataKey is the publisher-provided `_meta` key that records the provenance
+// of a mirrored server version.
+const MetadataKey = "aregistry.ai/mirror"
+
+// pageSize is the number of servers requested per upstream page.
+const pageSize = 100
+
+// Syncer copies servers from a mirror's upstream registry into this registry.
+type Syncer struct {
+	registry   service.RegistryService
+	httpClient *http.Client
+	logger     *slog.Logger
+	now        func() time.Time
+}
+
+// NewSyncer creates a syncer that writes into registry.
+func NewSyncer(registry service.RegistryService) *Syncer {
+	return &Syncer{
+		registry:   registry,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		logger:     slog.Default().With("component", "mirror"),
+		now:        time.Now,
+	}
+}
+
+// SetHTTPClient overrides the HTTP client used to reach upstream registries.
+func (s *Syncer) SetHTTPClient(client *http.Client) {
+	if client != nil {
+		s.httpClient = client
+	}
+}
+
+// Sync pulls every server updated upstream since the mirror's watermark, applies
+// the ones matching its include/exclude patterns and records the run in the
+// mirror's sync history. Server versions that exist locally but were not mirrored
+// from this upstream are never overwritten. The watermark only advances when
+// every entry was applied, so failed entries are retried on the next sync.
+// Entries are written with the permissions of the mirror's creator.
+// report, when set, is called after each upstream entry is handled.
+func (s *Syncer) Sync(ctx context.Context, mirrorID string, report func(processed, total int)) (*models.MirrorSync, error) {
+	mirror, err := s.registry.GetMirrorByID(ctx, mirrorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mirror %s: %w", mirrorID, err)
+	}
+	// Write as the mirror's creator so a sync can only publish what its creator
+	// could, whoever triggered it.
+	ctx, err = auth.WithStoredPrincipal(ctx, mirror.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load mirror %s creator: %w", mirrorID, err)
+	}
+
+	sync := &models.MirrorSync{
+		MirrorID:  mirror.ID,
+		Since:     mirror.Watermark,
+		StartedAt: s.now().UTC(),
+	}
+
+	servers, err := s.fetch(ctx, mirror)
+	if err != nil {
+		sync.Status = models.MirrorSyncStatusFailed
+		sync.Error = err.Error()
+		sync.FinishedAt = s.now().UTC()
+		if recordErr := s.registry.RecordMirrorSync(ctx, sync); recordErr != nil {
+			s.logger.Error("failed to record mirror sync", "mirror", mirror.ID, "error", recordErr)
+		}
+		return sync, err
+	}
+	sync.Fetched = len(servers)
+
+	var newest *time.Time
+	var failures []string
+	for i, upstream := range servers {
+		if err := ctx.Err(); err != nil {
+			return sync, err
+		}
+		if official := upstream.Meta.Official; official != nil && !official.UpdatedAt.IsZero() {
+			if newest == nil || official.UpdatedAt.After(*newest) {
+				updatedAt := official.UpdatedAt.UTC()
+				newest = &updatedAt
+			}
+		}
+
+		// Entries filtered out by the mirror's patterns are not counted as skipped.
+		if Matches(mirror, upstream.Server.Name) {
+			result, err := s.apply(ctx, mirror, upstream)
+			switch {
+			case err != nil:
+				sync.Failed++
+				failures = append(failures, fmt.Sprintf("%s@%s: %v", upstream.Server.Name, upstream.Server.Version, err))
+				s.logger.Error("failed to mirror server", "mirror", mirror.ID, "name", upstream.Server.Name, "version", upstream.Server.Version, "error", err)
+			case result == outcomeCreated:
+				sync.Created++
+			case result == outcomeUpdated:
+				sync.Updated++
+			default:
+				sync.Skipped++
+			}
+		}
+		if report != nil {
+			report(i+1, len(servers))
+		}
+	}
+
+	sync.Status = models.MirrorSyncStatusCompleted
+	if sync.Failed > 0 {
+		sync.Status = models.MirrorSyncStatusPartial
+		sync.Error = strings.Join(failures, "; ")
+	} else {
+		sync.Watermark = newest
+	}
+	sync.FinishedAt = s.now().UTC()
+
+	if err := s.registry.RecordMirrorSync(ctx, sync); err != nil {
+		return sync, fmt.Errorf("failed to record mirror sync: %w", err)
+	}
+	s.logger.Info("mirror sync finished", "mirror", mirror.ID, "status", sync.Status,
+		"fetched", sync.Fetched, "created", sync.Created, "updated", sync.Updated, "skipped", sync.Skipped, "failed", sync.Failed)
+	return sync, nil
+}
+
+type outcome int
+
+const (
+	outcomeSkipped outcome = iota
+	outcomeCreated
+	outcomeUpdated
+)
+
+// apply writes one upstream server version, stamped with its provenance.
+func (s *Syncer) apply(ctx context.Context, mirror *models.Mirror, upstream apiv0.ServerResponse) (outcome, error) {
+	server := upstream.Server
+	status := string(model.StatusActive)
+	provenance := models.ServerMirrorMeta{
+		MirrorID: mirror.ID,
+		Source:   mirror.URL,
+		SyncedAt: s.now().UTC(),
+	}
+	if official := upstream.Meta.Official; official != nil {
+		if official.Status != "" {
+			status = string(official.Status)
+		}
+		provenance.UpstreamStatus = string(official.Status)
+		if !official.UpdatedAt.IsZero() {
+			updatedAt := official.UpdatedAt.UTC()
+			provenance.UpstreamUpdatedAt = &updatedAt
+		}
+	}
+	setProvenance(&server, provenance)
+
+	if err := validators.ValidateServerJSON(&server); err != nil {
+		s.logger.Warn("skipping invalid upstream server", "mirror", mirror.ID, "name", server.Name, "version", server.Version, "error", err)
+		return outcomeSkipped, nil
+	}
+
+	existing, err := s.registry.GetServerByNameAndVersion(ctx, server.Name, server.Version)
+	switch {
+	case errors.Is(err, database.ErrNotFound):
+		if _, err := s.registry.CreateServer(ctx, &server); err != nil {
+			return outcomeSkipped, err
+		}
+		if status != string(model.StatusActive) {
+			if _, err := s.registry.UpdateServer(ctx, server.Name, server.Version, &server, &status); err != nil {
+				return outcomeCreated, fmt.Errorf("failed to set status: %w", err)
+			}
+		}
+		return outcomeCreated, nil
+	case err != nil:
+		return outcomeSkipped, err
+	}
+
+	if current := Provenance(&existing.Server); current == nil || current.MirrorID != mirror.ID {
+		s.logger.Debug("keeping server not owned by this mirror", "mirror", mirror.ID, "name", server.Name, "version", server.Version)
+		return outcomeSkipped, nil
+	}
+	if _, err := s.registry.UpdateServer(ctx, server.Name, server.Version, &server, &status); err != nil {
+		return outcomeSkipped, err
+	}
+	return outcomeUpdated, nil
+}
+
+// fetch pages through the upstream server list, requesting only servers updated
+// after the mirror's watermark.
+func (s *Syncer) fetch(ctx context.Context, mirror *models.Mirror) ([]apiv0.ServerResponse, error) {
+	endpoint, err := url.Parse(serversEndpoint(mirror.URL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid mirror url: %w", err)
+	}
+
+	var servers []apiv0.ServerResponse
+	cursor := ""
+	for {
+		query := endpoint.Query()
+		query.Set("limit", strconv.Itoa(pageSize))
+		if mirror.Watermark != nil {
+			query.Set("updated_since", mirror.Watermark.UTC().Format(time.RFC3339Nano))
+		}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		pageURL := *endpoint
+		pageURL.RawQuery = query.Encode()
+
+		var page struct {
+			Servers  []apiv0.ServerResponse `json:"servers"`
+			Metadata struct {
+				NextCursor string `json:"nextCursor,omitempty"`
+			} `json:"metadata"`
+		}
+		if err := s.getJSON(ctx, pageURL.String(), &page); err != nil {
+			return nil, err
+		}
+		servers = append(servers, page.Servers...)
+
+		next := page.Metadata.NextCursor
+		if next == "" || next == cursor || len(page.Servers) == 0 {
+			return servers, nil
+		}
+		cursor = next
+	}
+}
+
+func (s *Syncer) getJSON(ctx context.Context, rawURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create upstream request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach upstream registry: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("upstream registry returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to parse upstream response: %w", err)
+	}
+	return nil
+}
+
+// serversEndpoint resolves the /v0/servers endpoint for a registry base URL.
+// URLs that already point at a servers endpoint are used as-is.
+func serversEndpoint(base string) string {
+	base = strings.TrimRight(base, "/")
+	switch {
+	case strings.HasSuffix(base, "/servers"):
+		return base
+	case strings.HasSuffix(base, "/v0"):
+		return base + "/servers"
+	default:
+		return base + "/v0/servers"
+	}
+}
+
+// Matches reports whether a server name passes the mirror's include and exclude
+// patterns. An empty include list matches every name; exclusions win.
+func Matches(mirror *models.Mirror, name string) bool {
+	included := len(mirror.Include) == 0
+	for _, pattern := range mirror.Include {
+		if ok, _ := path.Match(pattern, name); ok {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for _, pattern := range mirror.Exclude {
+		if ok, _ := path.Match(pattern, name); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Provenance returns the mirror provenance recorded on a server, or nil for
+// servers that were not mirrored.
+func Provenance(server *apiv0.ServerJSON) *models.ServerMirrorMeta {
+	if server == nil || server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return nil
+	}
+	raw, ok := server.Meta.PublisherProvided[MetadataKey]
+	if !ok {
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var meta models.ServerMirrorMeta
+	if err := json.Unmarshal(data, &meta); err != nil || meta.MirrorID == "" {
+		return nil
+	}
+	return &meta
+}
+
+func setProvenance(server *apiv0.ServerJSON, meta models.ServerMirrorMeta) {
+	if server.Meta == nil {
+		server.Meta = &apiv0.ServerMeta{}
+	}
+	provided := make(map[string]any, len(server.Meta.PublisherProvided)+1)
+	for k, v := range server.Meta.PublisherProvided {
+		provided[k] = v
+	}
+	provided[MetadataKey] = meta
+	server.Meta.PublisherProvided = provided
+}
@@ -0,0 +1,204 @@
+package mirror_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/mirror"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func upstreamServer(name, version string, status model.Status, updatedAt time.Time) apiv0.ServerResponse {
+	return apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{
+			Schema:      model.CurrentSchemaURL,
+			Name:        name,
+			Description: "Mirrored server",
+			Version:     version,
+		},
+		Meta: apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{Status: status, UpdatedAt: updatedAt}},
+	}
+}
+
+// creatorSession is the session of the admin that created a mirror.
+type creatorSession struct{}
+
+func (s *creatorSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{
+		Subject:     "mirror-admin",
+		Permissions: []auth.Permission{{Action: auth.PermissionActionPublish, ResourcePattern: "*"}},
+	}}
+}
+
+// sessionSubject returns the subject acting in ctx, or "system" or "anonymous".
+func sessionSubject(ctx context.Context) string {
+	s, ok := auth.AuthSessionFrom(ctx)
+	switch {
+	case !ok || s == nil:
+		return "anonymous"
+	case auth.IsSystemSession(s):
+		return "system"
+	}
+	return s.Principal().User.Subject
+}
+
+// newLocalRegistry returns a fake registry that stores servers in memory.
+func newLocalRegistry(m *models.Mirror) (*servicetesting.FakeRegistry, map[string]*apiv0.ServerResponse, *[]*models.MirrorSync) {
+	servers := map[string]*apiv0.ServerResponse{}
+	var syncs []*models.MirrorSync
+
+	registry := servicetesting.NewFakeRegistry()
+	registry.GetMirrorByIDFn = func(_ context.Context, id string) (*models.Mirror, error) {
+		if id != m.ID {
+			return nil, database.ErrNotFound
+		}
+		return m, nil
+	}
+	registry.RecordMirrorSyncFn = func(_ context.Context, sync *models.MirrorSync) error {
+		syncs = append(syncs, sync)
+		if sync.Watermark != nil {
+			m.Watermark = sync.Watermark
+		}
+		return nil
+	}
+	registry.GetServerByNameAndVersionFn = func(_ context.Context, name, version string) (*apiv0.ServerResponse, error) {
+		if srv, ok := servers[name+"@"+version]; ok {
+			return srv, nil
+		}
+		return nil, database.ErrNotFound
+	}
+	registry.CreateServerFn = func(ctx context.Context, req *apiv0.ServerJSON) (*apiv0.ServerResponse, error) {
+		if subject := sessionSubject(ctx); subject != "mirror-admin" {
+			return nil, fmt.Errorf("server written as %s, want the mirror creator", subject)
+		}
+		resp := &apiv0.ServerResponse{Server: *req, Meta: apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{Status: model.StatusActive}}}
+		servers[req.Name+"@"+req.Version] = resp
+		return resp, nil
+	}
+	registry.UpdateServerFn = func(_ context.Context, name, version string, req *apiv0.ServerJSON, status *string) (*apiv0.ServerResponse, error) {
+		resp := servers[name+"@"+version]
+		resp.Server = *req
+		if status != nil {
+			resp.Meta.Official.Status = model.Status(*status)
+		}
+		return resp, nil
+	}
+	return registry, servers, &syncs
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	t1 := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+
+	var queries []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v0/servers", r.URL.Path)
+		queries = append(queries, r.URL.RawQuery)
+
+		var body struct {
+			Servers  []apiv0.ServerResponse `json:"servers"`
+			Metadata map[string]string      `json:"metadata"`
+		}
+		switch {
+		case r.URL.Query().Get("updated_since") != "":
+			body.Servers = []apiv0.ServerResponse{}
+		case r.URL.Query().Get("cursor") == "":
+			body.Servers = []apiv0.ServerResponse{
+				upstreamServer("io.github.acme/weather", "1.0.0", model.StatusActive, t1),
+				upstreamServer("io.github.acme/legacy", "0.1.0", model.StatusDeprecated, t1),
+			}
+			body.Metadata = map[string]string{"nextCursor": "page2"}
+		default:
+			body.Servers = []apiv0.ServerResponse{
+				upstreamServer("io.github.acme/local", "1.0.0", model.StatusActive, t2),
+				upstreamServer("com.other/tool", "1.0.0", model.StatusActive, t2),
+			}
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer upstream.Close()
+
+	createdBy, err := auth.MarshalPrincipal(auth.AuthSessionTo(context.Background(), &creatorSession{}))
+	require.NoError(t, err)
+	m := &models.Mirror{ID: "acme", URL: upstream.URL, Include: []string{"io.github.acme/*"}, Enabled: true, CreatedBy: createdBy}
+	registry, servers, syncs := newLocalRegistry(m)
+
+	// A locally published version must not be overwritten by the mirror.
+	servers["io.github.acme/local@1.0.0"] = &apiv0.ServerResponse{
+		Server: apiv0.ServerJSON{Name: "io.github.acme/local", Version: "1.0.0", Description: "Local"},
+		Meta:   apiv0.ResponseMeta{Official: &apiv0.RegistryExtensions{Status: model.StatusActive}},
+	}
+
+	// Scheduled syncs are submitted by the system but write as the creator.
+	syncer := mirror.NewSyncer(registry)
+	sync, err := syncer.Sync(auth.WithSystemContext(context.Background()), "acme", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.MirrorSyncStatusCompleted, sync.Status)
+	assert.Equal(t, 4, sync.Fetched)
+	assert.Equal(t, 2, sync.Created)
+	assert.Equal(t, 1, sync.Skipped)
+	require.NotNil(t, sync.Watermark)
+	assert.True(t, t2.Equal(*sync.Watermark))
+	assert.NotContains(t, servers, "com.other/tool@1.0.0")
+	assert.Equal(t, "Local", servers["io.github.acme/local@1.0.0"].Server.Description)
+	assert.Equal(t, model.StatusDeprecated, servers["io.github.acme/legacy@0.1.0"].Meta.Official.Status)
+
+	provenance := mirror.Provenance(&servers["io.github.acme/weather@1.0.0"].Server)
+	require.NotNil(t, provenance)
+	assert.Equal(t, "acme", provenance.MirrorID)
+	assert.Equal(t, upstream.URL, provenance.Source)
+
+	// The second sync polls with the watermark and finds nothing new.
+	sync, err = syncer.Sync(context.Background(), "acme", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sync.Fetched)
+	assert.Contains(t, queries[len(queries)-1], "updated_since=2025-08-01T11%3A00%3A00Z")
+	assert.Len(t, *syncs, 2)
+}
+
+func TestSyncer_SyncRecordsUpstreamFailure(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer upstream.Close()
+
+	m := &models.Mirror{ID: "down", URL: upstream.URL + "/v0", Enabled: true}
+	registry, _, syncs := newLocalRegistry(m)
+
+	sync, err := mirror.NewSyncer(registry).Sync(context.Background(), "down", nil)
+	require.Error(t, err)
+	assert.Equal(t, models.MirrorSyncStatusFailed, sync.Status)
+	assert.Contains(t, sync.Error, "503")
+	require.Len(t, *syncs, 1)
+	assert.Nil(t, m.Watermark)
+}
+
+func TestMatches(t *testing.T) {
+	m := &models.Mirror{Include: []string{"io.github.*/*"}, Exclude: []string{"io.github.acme/internal-*"}}
+	assert.True(t, mirror.Matches(m, "io.github.acme/weather"))
+	assert.False(t, mirror.Matches(m, "io.github.acme/internal-tools"))
+	assert.False(t, mirror.Matches(m, "com.example/weather"))
+	assert.True(t, mirror.Matches(&models.Mirror{}, "com.example/weather"))
+}
+
+func TestDue(t *testing.T) {
+	now := time.Now()
+	last := now.Add(-30 * time.Minute)
+	assert.True(t, mirror.Due(&models.Mirror{Enabled: true}, now))
+	assert.False(t, mirror.Due(&models.Mirror{Enabled: false}, now))
+	assert.False(t, mirror.Due(&models.Mirror{Enabled: true, IntervalSeconds: 3600, LastSyncedAt: &last}, now))
+	assert.True(t, mirror.Due(&models.Mirror{Enabled: true, IntervalSeconds: 600, LastSyncedAt: &last}, now))
+}
@@ -0,0 +1,91 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+// DefaultSchedulerInterval is how often the scheduler looks for mirrors that are due.
+const DefaultSchedulerInterval = time.Minute
+
+// Scheduler submits a sync job for every enabled mirror whose interval has
+// elapsed since its last sync. Sync jobs are exclusive, so when several
+// replicas run a scheduler only one sync executes at a time; mirrors that could
+// not be submitted are picked up on a later tick.
+type Scheduler struct {
+	registry   service.RegistryService
+	jobManager *jobs.Manager
+	interval   time.Duration
+	now        func() time.Time
+	logger     *slog.Logger
+}
+
+// NewScheduler creates a scheduler that submits jobs through jobManager.
+func NewScheduler(registry service.RegistryService, jobManager *jobs.Manager) *Scheduler {
+	return &Scheduler{
+		registry:   registry,
+		jobManager: jobManager,
+		interval:   DefaultSchedulerInterval,
+		now:        time.Now,
+		logger:     slog.Default().With("component", "mirror-scheduler"),
+	}
+}
+
+// Run checks for due mirrors until ctx is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.Tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick submits a sync job for the first due mirror that can be scheduled.
+func (s *Scheduler) Tick(ctx context.Context) {
+	mirrors, err := s.registry.ListMirrors(ctx)
+	if err != nil {
+		s.logger.Error("failed to list mirrors", "error", err)
+		return
+	}
+	for _, mirror := range mirrors {
+		if !Due(mirror, s.now()) {
+			continue
+		}
+		job, err := s.jobManager.Submit(ctx, jobs.MirrorSyncJobType, models.JSONObject{"mirrorId": mirror.ID})
+		if errors.Is(err, jobs.ErrJobAlreadyRunning) {
+			return
+		}
+		if err != nil {
+			s.logger.Error("failed to submit mirror sync", "mirror", mirror.ID, "error", err)
+			continue
+		}
+		s.logger.Info("mirror sync submitted", "mirror", mirror.ID, "job_id", job.ID)
+		return
+	}
+}
+
+// Due reports whether an enabled mirror should be synced at now.
+func Due(mirror *models.Mirror, now time.Time) bool {
+	if !mirror.Enabled {
+		return false
+	}
+	if mirror.LastSyncedAt == nil {
+		return true
+	}
+	interval := time.Duration(mirror.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = models.DefaultMirrorIntervalSeconds * time.Second
+	}
+	return !now.Before(mirror.LastSyncedAt.Add(interval))
+}
@@ -11,10 +11,12 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type kubernetesDeploymentAdapter struct {
 	registry service.RegistryService
+	inFlight utils.InFlightDeployments
 }
 
 func NewKubernetesDeploymentAdapter(registry service.RegistryService) *kubernetesDeploymentAdapter {
@@ -32,21 +34,100 @@ func (a *kubernetesDeploymentAdapter) Deploy(ctx context.Context, req *models.De
 		return nil, err
 	}
 
+	ctx, release := a.inFlight.Track(ctx, req.ID)
+	defer release()
+
 	provider, err := a.registry.GetProviderByID(ctx, req.ProviderID)
 	if err != nil {
-		return nil, err
+		return a.handleKubernetesDeployError(ctx, req, nil, err)
 	}
 
 	cfg, err := a.translateKubernetesDeployment(ctx, req, provider)
+	if err != nil {
+		return a.handleKubernetesDeployError(ctx, req, provider, err)
+	}
+	if err := kubernetesApplyPlatformConfig(ctx, provider, cfg, false); err != nil {
+		return a.handleKubernetesDeployError(ctx, req, provider, fmt.Errorf("apply kubernetes platform config: %w", err))
+	}
+	if utils.IsDeploymentCancelled(ctx) {
+		return a.rollbackCancelledKubernetesDeployment(ctx, req, provider)
+	}
+	return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+}
+
+// Update rolls a deployment to a new configuration in place. Objects are
+// applied over the existing ones so the kagent and kmcp controllers roll the
+// workload; objects the new configuration no longer has are deleted afterwards.
+func (a *kubernetesDeploymentAdapter) Update(ctx context.Context, previous, desired *models.Deployment) (*models.DeploymentActionResult, error) {
+	if err := utils.ValidateDeploymentRequest(desired, false); err != nil {
+		return nil, err
+	}
+
+	ctx, release := a.inFlight.Track(ctx, desired.ID)
+	defer release()
+
+	provider, err := a.registry.GetProviderByID(ctx, desired.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := a.translateKubernetesDeployment(ctx, desired, provider)
 	if err != nil {
 		return nil, err
 	}
 	if err := kubernetesApplyPlatformConfig(ctx, provider, cfg, false); err != nil {
 		return nil, fmt.Errorf("apply kubernetes platform config: %w", err)
 	}
+
+	c, err := kubernetesGetClient(provider)
+	if err != nil {
+		return nil, err
+	}
+	namespace := deploymentNamespace(desired, provider)
+	if err := kubernetesPruneDeploymentResources(ctx, c, cfg, desired.ID, namespace); err != nil {
+		return nil, fmt.Errorf("prune kubernetes resources: %w", err)
+	}
+	if previous != nil {
+		if previousNamespace := deploymentNamespace(previous, provider); previousNamespace != namespace {
+			resourceType := strings.ToLower(strings.TrimSpace(previous.ResourceType))
+			if err := kubernetesDeleteResourcesByDeploymentID(ctx, provider, previous.ID, resourceType, previousNamespace); err != nil {
+				return nil, fmt.Errorf("remove resources from previous namespace %s: %w", previousNamespace, err)
+			}
+		}
+	}
 	return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
 }
 
+func (a *kubernetesDeploymentAdapter) handleKubernetesDeployError(
+	ctx context.Context,
+	deployment *models.Deployment,
+	provider *models.Provider,
+	deployErr error,
+) (*models.DeploymentActionResult, error) {
+	if utils.IsDeploymentCancelled(ctx) {
+		return a.rollbackCancelledKubernetesDeployment(ctx, deployment, provider)
+	}
+	return nil, deployErr
+}
+
+// rollbackCancelledKubernetesDeployment deletes any objects a cancelled Deploy
+// already applied. Nothing reached the cluster if the provider was never resolved.
+func (a *kubernetesDeploymentAdapter) rollbackCancelledKubernetesDeployment(
+	ctx context.Context,
+	deployment *models.Deployment,
+	provider *models.Provider,
+) (*models.DeploymentActionResult, error) {
+	if provider != nil {
+		if err := kubernetesRollbackDeployment(context.WithoutCancel(ctx), deployment, provider); err != nil {
+			return utils.CancelledDeploymentResult(), fmt.Errorf("%w: rollback failed: %v", utils.ErrDeploymentCancelled, err)
+		}
+	}
+	return utils.CancelledDeploymentResult(), utils.ErrDeploymentCancelled
+}
+
+func kubernetesRollbackDeployment(ctx context.Context, deployment *models.Deployment, provider *models.Provider) error {
+	return kubernetesDeleteResourcesByDeploymentID(ctx, provider, deployment.ID, strings.ToLower(strings.TrimSpace(deployment.ResourceType)), deploymentNamespace(deployment, provider))
+}
+
 func (a *kubernetesDeploymentAdapter) Undeploy(ctx context.Context, deployment *models.Deployment) error {
 	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
 		return err
@@ -73,12 +154,46 @@ func (a *kubernetesDeploymentAdapter) CleanupStale(ctx context.Context, deployme
 	return nil
 }
 
-func (a *kubernetesDeploymentAdapter) GetLogs(_ context.Context, _ *models.Deployment) ([]string, error) {
-	return nil, utils.ErrDeploymentNotSupported
+func (a *kubernetesDeploymentAdapter) GetLogs(ctx context.Context, deployment *models.Deployment) ([]string, error) {
+	logs := make([]string, 0)
+	if err := a.StreamLogs(ctx, deployment, models.DeploymentLogOptions{}, func(line string) error {
+		logs = append(logs, line)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// StreamLogs streams pod logs for the kagent Agent or kmcp MCPServer backing the deployment.
+func (a *kubernetesDeploymentAdapter) StreamLogs(
+	ctx context.Context,
+	deployment *models.Deployment,
+	opts models.DeploymentLogOptions,
+	emit func(line string) error,
+) error {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return err
+	}
+	provider, err := a.registry.GetProviderByID(ctx, deployment.ProviderID)
+	if err != nil {
+		return err
+	}
+	namespace := deploymentNamespace(deployment, provider)
+	return kubernetesStreamDeploymentLogs(ctx, provider, deployment.ID, strings.ToLower(strings.TrimSpace(deployment.ResourceType)), namespace, opts, emit)
 }
 
-func (a *kubernetesDeploymentAdapter) Cancel(_ context.Context, _ *models.Deployment) error {
-	return utils.ErrDeploymentNotSupported
+// Cancel stops an in-flight Deploy, which then deletes the objects it applied.
+// When no Deploy is running in this process it may be running on another
+// replica, so nothing is rolled back and ErrDeploymentNotInFlight is returned.
+func (a *kubernetesDeploymentAdapter) Cancel(ctx context.Context, deployment *models.Deployment) error {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return err
+	}
+	if a.inFlight.Cancel(deployment.ID) {
+		return nil
+	}
+	return utils.ErrDeploymentNotInFlight
 }
 
 func (a *kubernetesDeploymentAdapter) Discover(ctx context.Context, providerID string) ([]*models.Deployment, error) {
@@ -89,12 +204,91 @@ func (a *kubernetesDeploymentAdapter) Discover(ctx context.Context, providerID s
 	return kubernetesDiscoverDeployments(ctx, provider)
 }
 
+// Observe compares the deployment's desired kagent and kmcp resources with the
+// objects labelled with its ID.
+func (a *kubernetesDeploymentAdapter) Observe(ctx context.Context, deployment *models.Deployment) (*models.DeploymentObservation, error) {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return nil, err
+	}
+	provider, err := a.registry.GetProviderByID(ctx, deployment.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := a.translateKubernetesDeployment(ctx, deployment, provider)
+	if err != nil {
+		return nil, err
+	}
+	c, err := kubernetesGetClient(provider)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetesObserveDeployment(ctx, c, cfg, deployment.ID, deploymentNamespace(deployment, provider))
+}
+
+// ProbeTarget resolves the in-cluster endpoint health probes should reach.
+func (a *kubernetesDeploymentAdapter) ProbeTarget(ctx context.Context, deployment *models.Deployment) (*models.DeploymentProbeTarget, error) {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return nil, err
+	}
+	provider, err := a.registry.GetProviderByID(ctx, deployment.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := a.translateKubernetesDeployment(ctx, deployment, provider)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetesProbeTarget(cfg, strings.ToLower(strings.TrimSpace(deployment.ResourceType)), deploymentNamespace(deployment, provider))
+}
+
+// Render returns the kagent and kmcp objects Deploy would apply, without
+// secret values.
+func (a *kubernetesDeploymentAdapter) Render(ctx context.Context, deployment *models.Deployment, format string) (*models.DeploymentRender, error) {
+	if err := utils.ValidateDeploymentRequest(deployment, false); err != nil {
+		return nil, err
+	}
+	provider, err := a.registry.GetProviderByID(ctx, deployment.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := kubernetesBuildDesiredState(ctx, utils.WithoutSecretValues(a.registry), deployment, provider)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := kubernetesTranslatePlatformConfig(ctx, desired)
+	if err != nil {
+		return nil, fmt.Errorf("translate kubernetes platform config: %w", err)
+	}
+
+	// Same order as kubernetesApplyPlatformConfig.
+	var objects []client.Object
+	for _, secret := range cfg.Secrets {
+		objects = append(objects, secret)
+	}
+	for _, configMap := range cfg.ConfigMaps {
+		objects = append(objects, configMap)
+	}
+	for _, agent := range cfg.Agents {
+		objects = append(objects, agent)
+	}
+	for _, remoteMCP := range cfg.RemoteMCPServers {
+		objects = append(objects, remoteMCP)
+	}
+	for _, mcpServer := range cfg.MCPServers {
+		objects = append(objects, mcpServer)
+	}
+	for _, obj := range objects {
+		kubernetesEnsureNamespace(obj)
+	}
+	return kubernetesRenderObjects(a.Platform(), format, deployment.ServerName, deployment.Version, objects)
+}
+
 func (a *kubernetesDeploymentAdapter) translateKubernetesDeployment(
 	ctx context.Context,
 	deployment *models.Deployment,
 	provider *models.Provider,
 ) (*platformtypes.KubernetesPlatformConfig, error) {
-	desired, err := a.buildKubernetesDesiredState(ctx, deployment, provider)
+	desired, err := kubernetesBuildDesiredState(ctx, a.registry, deployment, provider)
 	if err != nil {
 		return nil, err
 	}
@@ -108,8 +302,11 @@ func (a *kubernetesDeploymentAdapter) translateKubernetesDeployment(
 	return cfg, nil
 }
 
-func (a *kubernetesDeploymentAdapter) buildKubernetesDesiredState(
+// kubernetesBuildDesiredState resolves the MCP servers and agents a deployment
+// runs, placed in the deployment's namespace.
+func kubernetesBuildDesiredState(
 	ctx context.Context,
+	registry service.RegistryService,
 	deployment *models.Deployment,
 	provider *models.Provider,
 ) (*platformtypes.DesiredState, error) {
@@ -117,13 +314,13 @@ func (a *kubernetesDeploymentAdapter) buildKubernetesDesiredState(
 	resourceType := strings.ToLower(strings.TrimSpace(deployment.ResourceType))
 	switch resourceType {
 	case "mcp":
-		server, err := utils.BuildPlatformMCPServer(ctx, a.registry, deployment, namespace)
+		server, err := utils.BuildPlatformMCPServer(ctx, registry, deployment, namespace)
 		if err != nil {
 			return nil, err
 		}
 		return &platformtypes.DesiredState{MCPServers: []*platformtypes.MCPServer{server}}, nil
 	case "agent":
-		resolved, err := utils.ResolveAgent(ctx, a.registry, deployment, namespace)
+		resolved, err := utils.ResolveAgent(ctx, registry, deployment, namespace)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,281 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	platformtypes "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/platforms/utils"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const kubernetesNativePlatform = "kubernetes-native"
+
+// kubernetesNativeDeploymentAdapter deploys to clusters without the kagent and
+// kmcp controllers by rendering core Deployments, Services, ConfigMaps and
+// Secrets. Providers use the same config as the kubernetes platform.
+type kubernetesNativeDeploymentAdapter struct {
+	registry service.RegistryService
+	inFlight utils.InFlightDeployments
+}
+
+func NewKubernetesNativeDeploymentAdapter(registry service.RegistryService) *kubernetesNativeDeploymentAdapter {
+	return &kubernetesNativeDeploymentAdapter{registry: registry}
+}
+
+func (a *kubernetesNativeDeploymentAdapter) Platform() string { return kubernetesNativePlatform }
+
+func (a *kubernetesNativeDeploymentAdapter) SupportedResourceTypes() []string {
+	return []string{"mcp", "agent"}
+}
+
+func (a *kubernetesNativeDeploymentAdapter) Deploy(ctx context.Context, req *models.Deployment) (*models.DeploymentActionResult, error) {
+	if err := utils.ValidateDeploymentRequest(req, false); err != nil {
+		return nil, err
+	}
+
+	ctx, release := a.inFlight.Track(ctx, req.ID)
+	defer release()
+
+	provider, err := a.registry.GetProviderByID(ctx, req.ProviderID)
+	if err != nil {
+		return a.handleDeployError(ctx, req, nil, err)
+	}
+	cfg, err := a.translate(ctx, a.registry, req, provider)
+	if err != nil {
+		return a.handleDeployError(ctx, req, provider, err)
+	}
+	if err := kubernetesNativeApplyPlatformConfig(ctx, provider, cfg); err != nil {
+		return a.handleDeployError(ctx, req, provider, fmt.Errorf("apply kubernetes-native platform config: %w", err))
+	}
+	if utils.IsDeploymentCancelled(ctx) {
+		return a.rollbackCancelledDeployment(ctx, req, provider)
+	}
+	return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+}
+
+// Update rolls a deployment to a new configuration in place. Objects are
+// applied over the existing ones so the Deployments roll their pods; objects
+// the new configuration no longer has, such as an agent's workload named after
+// the previous version, are deleted afterwards.
+func (a *kubernetesNativeDeploymentAdapter) Update(ctx context.Context, previous, desired *models.Deployment) (*models.DeploymentActionResult, error) {
+	if err := utils.ValidateDeploymentRequest(desired, false); err != nil {
+		return nil, err
+	}
+
+	ctx, release := a.inFlight.Track(ctx, desired.ID)
+	defer release()
+
+	provider, err := a.registry.GetProviderByID(ctx, desired.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := a.translate(ctx, a.registry, desired, provider)
+	if err != nil {
+		return nil, err
+	}
+	if err := kubernetesNativeApplyPlatformConfig(ctx, provider, cfg); err != nil {
+		return nil, fmt.Errorf("apply kubernetes-native platform config: %w", err)
+	}
+
+	c, err := kubernetesGetClient(provider)
+	if err != nil {
+		return nil, err
+	}
+	namespace := deploymentNamespace(desired, provider)
+	if err := kubernetesNativePruneDeploymentResources(ctx, c, cfg, desired.ID, namespace); err != nil {
+		return nil, fmt.Errorf("prune kubernetes-native resources: %w", err)
+	}
+	if previous != nil {
+		if previousNamespace := deploymentNamespace(previous, provider); previousNamespace != namespace {
+			if err := kubernetesNativeDeleteResourcesByDeploymentID(ctx, provider, previous.ID, previousNamespace); err != nil {
+				return nil, fmt.Errorf("remove resources from previous namespace %s: %w", previousNamespace, err)
+			}
+		}
+	}
+	return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+}
+
+func (a *kubernetesNativeDeploymentAdapter) handleDeployError(
+	ctx context.Context,
+	deployment *models.Deployment,
+	provider *models.Provider,
+	deployErr error,
+) (*models.DeploymentActionResult, error) {
+	if utils.IsDeploymentCancelled(ctx) {
+		return a.rollbackCancelledDeployment(ctx, deployment, provider)
+	}
+	return nil, deployErr
+}
+
+// rollbackCancelledDeployment deletes any objects a cancelled Deploy already
+// applied. Nothing reached the cluster if the provider was never resolved.
+func (a *kubernetesNativeDeploymentAdapter) rollbackCancelledDeployment(
+	ctx context.Context,
+	deployment *models.Deployment,
+	provider *models.Provider,
+) (*models.DeploymentActionResult, error) {
+	if provider != nil {
+		if err := kubernetesNativeDeleteResourcesByDeploymentID(context.WithoutCancel(ctx), provider, deployment.ID, deploymentNamespace(deployment, provider)); err != nil {
+			return utils.CancelledDeploymentResult(), fmt.Errorf("%w: rollback failed: %v", utils.ErrDeploymentCancelled, err)
+		}
+	}
+	return utils.CancelledDeploymentResult(), utils.ErrDeploymentCancelled
+}
+
+func (a *kubernetesNativeDeploymentAdapter) Undeploy(ctx context.Context, deployment *models.Deployment) error {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return err
+	}
+	provider, err := a.registry.GetProviderByID(ctx, deployment.ProviderID)
+	if err != nil {
+		return err
+	}
+	return kubernetesNativeDeleteResourcesByDeploymentID(ctx, provider, deployment.ID, deploymentNamespace(deployment, provider))
+}
+
+func (a *kubernetesNativeDeploymentAdapter) CleanupStale(ctx context.Context, deployment *models.Deployment) error {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return err
+	}
+	provider, err := a.registry.GetProviderByID(ctx, deployment.ProviderID)
+	if err != nil {
+		return err
+	}
+	if err := kubernetesNativeDeleteResourcesByDeploymentID(ctx, provider, deployment.ID, deploymentNamespace(deployment, provider)); err != nil {
+		log.Printf("Warning: failed to clean up stale kubernetes-native deployment %s: %v", deployment.ID, err)
+	}
+	return nil
+}
+
+func (a *kubernetesNativeDeploymentAdapter) GetLogs(ctx context.Context, deployment *models.Deployment) ([]string, error) {
+	logs := make([]string, 0)
+	if err := a.StreamLogs(ctx, deployment, models.DeploymentLogOptions{}, func(line string) error {
+		logs = append(logs, line)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// StreamLogs streams pod logs for the Deployment running the agent or MCP server.
+func (a *kubernetesNativeDeploymentAdapter) StreamLogs(
+	ctx context.Context,
+	deployment *models.Deployment,
+	opts models.DeploymentLogOptions,
+	emit func(line string) error,
+) error {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return err
+	}
+	provider, err := a.registry.GetProviderByID(ctx, deployment.ProviderID)
+	if err != nil {
+		return err
+	}
+	namespace := deploymentNamespace(deployment, provider)
+	return kubernetesNativeStreamDeploymentLogs(ctx, provider, deployment.ID, strings.ToLower(strings.TrimSpace(deployment.ResourceType)), namespace, opts, emit)
+}
+
+// Cancel stops an in-flight Deploy, which then deletes the objects it applied.
+// When no Deploy is running in this process it may be running on another
+// replica, so nothing is rolled back and ErrDeploymentNotInFlight is returned.
+func (a *kubernetesNativeDeploymentAdapter) Cancel(ctx context.Context, deployment *models.Deployment) error {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return err
+	}
+	if a.inFlight.Cancel(deployment.ID) {
+		return nil
+	}
+	return utils.ErrDeploymentNotInFlight
+}
+
+func (a *kubernetesNativeDeploymentAdapter) Discover(ctx context.Context, providerID string) ([]*models.Deployment, error) {
+	provider, err := a.registry.GetProviderByID(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetesNativeDiscoverDeployments(ctx, provider)
+}
+
+// Observe compares the deployment's desired Deployments with the ones
+// labelled with its ID.
+func (a *kubernetesNativeDeploymentAdapter) Observe(ctx context.Context, deployment *models.Deployment) (*models.DeploymentObservation, error) {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return nil, err
+	}
+	provider, err := a.registry.GetProviderByID(ctx, deployment.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := a.translate(ctx, a.registry, deployment, provider)
+	if err != nil {
+		return nil, err
+	}
+	c, err := kubernetesGetClient(provider)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetesNativeObserveDeployment(ctx, c, cfg, deployment.ID, deploymentNamespace(deployment, provider))
+}
+
+// Render returns the objects Deploy would apply, without secret values.
+func (a *kubernetesNativeDeploymentAdapter) Render(ctx context.Context, deployment *models.Deployment, format string) (*models.DeploymentRender, error) {
+	if err := utils.ValidateDeploymentRequest(deployment, false); err != nil {
+		return nil, err
+	}
+	provider, err := a.registry.GetProviderByID(ctx, deployment.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := a.translate(ctx, utils.WithoutSecretValues(a.registry), deployment, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same order as kubernetesNativeApplyPlatformConfig.
+	var objects []client.Object
+	for _, secret := range cfg.Secrets {
+		objects = append(objects, secret)
+	}
+	for _, configMap := range cfg.ConfigMaps {
+		objects = append(objects, configMap)
+	}
+	for _, service := range cfg.Services {
+		objects = append(objects, service)
+	}
+	for _, workload := range cfg.Deployments {
+		objects = append(objects, workload)
+	}
+	for _, obj := range objects {
+		kubernetesEnsureNamespace(obj)
+	}
+	return kubernetesRenderObjects(kubernetesNativePlatform, format, deployment.ServerName, deployment.Version, objects)
+}
+
+func (a *kubernetesNativeDeploymentAdapter) translate(
+	ctx context.Context,
+	registry service.RegistryService,
+	deployment *models.Deployment,
+	provider *models.Provider,
+) (*platformtypes.KubernetesNativePlatformConfig, error) {
+	desired, err := kubernetesBuildDesiredState(ctx, registry, deployment, provider)
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range desired.MCPServers {
+		if server.MCPServerType == platformtypes.MCPServerTypeRemote && strings.EqualFold(strings.TrimSpace(deployment.ResourceType), "mcp") {
+			return nil, fmt.Errorf("%s is a remote MCP server and has no workload to run on %s: %w", deployment.ServerName, kubernetesNativePlatform, database.ErrInvalidInput)
+		}
+	}
+	cfg, err := kubernetesNativeTranslatePlatformConfig(desired, deploymentNamespace(deployment, provider))
+	if err != nil {
+		return nil, fmt.Errorf("translate kubernetes-native platform config: %w", err)
+	}
+	return cfg, nil
+}
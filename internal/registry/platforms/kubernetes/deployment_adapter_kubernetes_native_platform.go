@@ -0,0 +1,667 @@
+package kubernetes
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strings"
+
+	platformtypes "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
+	platformutils "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/utils"
+	"github.com/agentregistry-dev/agentregistry/internal/version"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"go.yaml.in/yaml/v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	kubernetesNativeNameLabelKey      = "app.kubernetes.io/name"
+	kubernetesNativeComponentLabelKey = "app.kubernetes.io/component"
+	kubernetesNativeMCPComponent      = "mcp-server"
+	kubernetesNativeAgentComponent    = "agent"
+	// kubernetesNativeGatewayPort is where the agentgateway sidecar serves a
+	// stdio server over streamable HTTP.
+	kubernetesNativeGatewayPort = 3000
+	// kubernetesNativeStdioServerPort is where a stdio server image that the
+	// gateway cannot spawn itself runs in HTTP mode, behind the sidecar.
+	kubernetesNativeStdioServerPort  = 3001
+	kubernetesNativeGatewayConfigKey = "agent-gateway.yaml"
+	kubernetesNativeMCPPath          = "/mcp"
+)
+
+// kubernetesNativeTranslatePlatformConfig renders the desired MCP servers and
+// agents as core objects in namespace. Remote MCP servers need no objects;
+// agents reach them at their published URL.
+func kubernetesNativeTranslatePlatformConfig(desired *platformtypes.DesiredState, namespace string) (*platformtypes.KubernetesNativePlatformConfig, error) {
+	cfg := &platformtypes.KubernetesNativePlatformConfig{}
+
+	// Agents refer to their registry MCP servers by internal name; point them
+	// at the Services rendered for those servers instead.
+	endpoints := map[string]string{}
+	for _, server := range desired.MCPServers {
+		if server.MCPServerType != platformtypes.MCPServerTypeLocal || server.Local == nil {
+			continue
+		}
+		endpoint, err := kubernetesNativeTranslateMCPServer(cfg, server, namespace)
+		if err != nil {
+			return nil, err
+		}
+		endpoints[platformutils.GenerateInternalNameForDeployment(server.Name, server.DeploymentID)] = endpoint
+	}
+	for _, agent := range desired.Agents {
+		if err := kubernetesNativeTranslateAgent(cfg, agent, namespace, endpoints); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// kubernetesNativeTranslateMCPServer adds the objects running a local MCP
+// server to cfg and returns the in-cluster URL it is served at. HTTP servers
+// are exposed directly. Stdio servers are fronted by an agentgateway sidecar:
+// npx and uvx servers are spawned by the gateway itself, whose image ships
+// both runtimes, and other images run in HTTP mode next to it, as they do on
+// the local platform.
+func kubernetesNativeTranslateMCPServer(cfg *platformtypes.KubernetesNativePlatformConfig, server *platformtypes.MCPServer, namespace string) (string, error) {
+	local := server.Local
+	if local.Deployment.Image == "" {
+		return "", fmt.Errorf("image must be specified for MCPServer %s", server.Name)
+	}
+
+	name := kubernetesMCPServerResourceName(server.Name, server.DeploymentID)
+	labels := kubernetesNativeLabels(name, kubernetesNativeMCPComponent, server.DeploymentID)
+
+	env := kubernetesNativeEnvVars(local.Deployment.Env)
+	var envFrom []corev1.EnvFromSource
+	if len(local.Deployment.SecretEnv) > 0 {
+		secretName := kubernetesMCPServerSecretName(server.Name, server.DeploymentID)
+		cfg.Secrets = append(cfg.Secrets, kubernetesTranslateSecretEnv(secretName, namespace, server.DeploymentID, local.Deployment.SecretEnv))
+		envFrom = []corev1.EnvFromSource{{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secretName}},
+		}}
+	}
+	serverContainer := corev1.Container{
+		Name:    "mcp-server",
+		Image:   local.Deployment.Image,
+		Args:    local.Deployment.Args,
+		Env:     env,
+		EnvFrom: envFrom,
+	}
+	if local.Deployment.Cmd != "" {
+		serverContainer.Command = []string{local.Deployment.Cmd}
+	}
+
+	var (
+		port       int32
+		path       string
+		containers []corev1.Container
+		volumes    []corev1.Volume
+	)
+	switch local.TransportType {
+	case platformtypes.TransportTypeHTTP:
+		if local.HTTP == nil || local.HTTP.Port == 0 {
+			return "", fmt.Errorf("HTTP transport requires a target port for %s", server.Name)
+		}
+		port = int32(local.HTTP.Port)
+		path = cmp.Or(local.HTTP.Path, kubernetesNativeMCPPath)
+		serverContainer.Ports = []corev1.ContainerPort{{Name: "mcp", ContainerPort: port}}
+		containers = []corev1.Container{serverContainer}
+	case platformtypes.TransportTypeStdio:
+		port = kubernetesNativeGatewayPort
+		path = kubernetesNativeMCPPath
+
+		runsInGateway := kubernetesNativeRunsInGateway(local.Deployment.Cmd)
+		target := platformtypes.MCPTarget{Name: server.Name}
+		if runsInGateway {
+			target.Stdio = &platformtypes.StdioTargetSpec{Cmd: local.Deployment.Cmd, Args: local.Deployment.Args}
+		} else {
+			target.MCP = &platformtypes.MCPTargetSpec{Host: fmt.Sprintf("http://localhost:%d%s", kubernetesNativeStdioServerPort, kubernetesNativeMCPPath)}
+		}
+		configMap, err := kubernetesNativeGatewayConfigMap(server, namespace, labels, target)
+		if err != nil {
+			return "", err
+		}
+		cfg.ConfigMaps = append(cfg.ConfigMaps, configMap)
+
+		volumeName := "gateway-config"
+		volumes = []corev1.Volume{{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMap.Name},
+			}},
+		}}
+		gateway := corev1.Container{
+			Name:         "agentgateway",
+			Image:        kubernetesNativeAgentGatewayImage(),
+			Args:         []string{"-f", "/config/" + kubernetesNativeGatewayConfigKey},
+			Ports:        []corev1.ContainerPort{{Name: "mcp", ContainerPort: port}},
+			VolumeMounts: []corev1.VolumeMount{{Name: volumeName, MountPath: "/config", ReadOnly: true}},
+		}
+		if runsInGateway {
+			// The spawned server inherits the gateway's environment, which keeps
+			// secret values out of the gateway ConfigMap.
+			gateway.Env = env
+			gateway.EnvFrom = envFrom
+			containers = []corev1.Container{gateway}
+		} else {
+			serverContainer.Env = append(serverContainer.Env,
+				corev1.EnvVar{Name: "HOST", Value: "0.0.0.0"},
+				corev1.EnvVar{Name: "MCP_TRANSPORT_MODE", Value: "http"},
+				corev1.EnvVar{Name: "PORT", Value: fmt.Sprintf("%d", kubernetesNativeStdioServerPort)},
+			)
+			containers = []corev1.Container{serverContainer, gateway}
+		}
+	default:
+		return "", fmt.Errorf("unsupported MCP transport type %q for %s", local.TransportType, server.Name)
+	}
+
+	cfg.Deployments = append(cfg.Deployments, kubernetesNativeWorkload(name, namespace, server.DeploymentID, labels, corev1.PodSpec{
+		Containers: containers,
+		Volumes:    volumes,
+	}))
+	cfg.Services = append(cfg.Services, kubernetesNativeService(name, namespace, server.DeploymentID, labels, "mcp", port))
+	return fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s", name, namespace, port, path), nil
+}
+
+// kubernetesNativeGatewayConfigMap holds the agentgateway config serving a
+// single MCP target at /mcp.
+func kubernetesNativeGatewayConfigMap(server *platformtypes.MCPServer, namespace string, labels map[string]string, target platformtypes.MCPTarget) (*corev1.ConfigMap, error) {
+	gatewayConfig := &platformtypes.AgentGatewayConfig{
+		Config: struct{}{},
+		Binds: []platformtypes.LocalBind{{
+			Port: kubernetesNativeGatewayPort,
+			Listeners: []platformtypes.LocalListener{{
+				Name:     "default",
+				Protocol: platformtypes.LocalListenerProtocolHTTP,
+				Routes: []platformtypes.LocalRoute{{
+					RouteName: "mcp_route",
+					Matches: []platformtypes.RouteMatch{{
+						Path: platformtypes.PathMatch{PathPrefix: kubernetesNativeMCPPath},
+					}},
+					Backends: []platformtypes.RouteBackend{{
+						Weight: 100,
+						MCP:    &platformtypes.MCPBackend{Targets: []platformtypes.MCPTarget{target}},
+					}},
+				}},
+			}},
+		}},
+	}
+	content, err := yaml.Marshal(gatewayConfig)
+	if err != nil {
+		return nil, fmt.Errorf("marshal agent gateway config for %s: %w", server.Name, err)
+	}
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        kubernetesDeploymentScopedName(server.Name+"-gateway", server.DeploymentID),
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: kubernetesDeploymentManagedAnnotations(server.DeploymentID),
+		},
+		Data: map[string]string{kubernetesNativeGatewayConfigKey: string(content)},
+	}, nil
+}
+
+// kubernetesNativeTranslateAgent adds the objects running an agent to cfg. The
+// agent runs the same way as on the local platform, reading its MCP servers
+// and prompts from /config.
+func kubernetesNativeTranslateAgent(cfg *platformtypes.KubernetesNativePlatformConfig, agent *platformtypes.Agent, namespace string, endpoints map[string]string) error {
+	if agent.Deployment.Image == "" {
+		return fmt.Errorf("image must be specified for Agent %s", agent.Name)
+	}
+	if len(agent.Skills) > 0 {
+		log.Printf("Warning: agent %s declares skills, which are not installed on %s", agent.Name, kubernetesNativePlatform)
+	}
+
+	resolved := *agent
+	resolved.ResolvedMCPServers = slices.Clone(agent.ResolvedMCPServers)
+	for i, server := range resolved.ResolvedMCPServers {
+		if endpoint, ok := endpoints[server.Name]; ok && server.Type == "command" {
+			resolved.ResolvedMCPServers[i].Type = "remote"
+			resolved.ResolvedMCPServers[i].URL = endpoint
+		}
+	}
+	agent = &resolved
+
+	name := kubernetesAgentResourceName(agent.Name, agent.Version, agent.DeploymentID)
+	labels := kubernetesNativeLabels(name, kubernetesNativeAgentComponent, agent.DeploymentID)
+
+	if len(agent.Deployment.SecretEnv) > 0 {
+		cfg.Secrets = append(cfg.Secrets, kubernetesTranslateSecretEnv(
+			kubernetesAgentSecretName(agent.Name, agent.Version, agent.DeploymentID),
+			namespace, agent.DeploymentID, agent.Deployment.SecretEnv,
+		))
+	}
+	if len(agent.ResolvedMCPServers) > 0 || len(agent.ResolvedPrompts) > 0 {
+		configMap, err := kubernetesTranslateAgentConfigMap(agent)
+		if err != nil {
+			return fmt.Errorf("failed to create ConfigMap for agent %s: %w", agent.Name, err)
+		}
+		configMap.Namespace = namespace
+		cfg.ConfigMaps = append(cfg.ConfigMaps, configMap)
+	}
+
+	port := int32(cmp.Or(agent.Deployment.Port, platformutils.DefaultLocalAgentPort))
+	volumes, mounts := kubernetesAgentConfigVolumes(agent)
+	cfg.Deployments = append(cfg.Deployments, kubernetesNativeWorkload(name, namespace, agent.DeploymentID, labels, corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:         "agent",
+			Image:        agent.Deployment.Image,
+			Args:         []string{agent.Name, "--local", "--port", fmt.Sprintf("%d", port)},
+			Env:          kubernetesAgentEnvVars(agent),
+			Ports:        []corev1.ContainerPort{{Name: "http", ContainerPort: port}},
+			VolumeMounts: mounts,
+		}},
+		Volumes: volumes,
+	}))
+	cfg.Services = append(cfg.Services, kubernetesNativeService(name, namespace, agent.DeploymentID, labels, "http", port))
+	return nil
+}
+
+func kubernetesNativeRunsInGateway(cmd string) bool {
+	return cmd == "npx" || cmd == "uvx"
+}
+
+func kubernetesNativeAgentGatewayImage() string {
+	return fmt.Sprintf("%s/agentregistry-dev/agentregistry/arctl-agentgateway:%s", version.DockerRegistry, version.Version)
+}
+
+func kubernetesNativeLabels(name, component, deploymentID string) map[string]string {
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by":    "agentregistry",
+		kubernetesNativeNameLabelKey:      name,
+		kubernetesNativeComponentLabelKey: component,
+	}
+	maps.Copy(labels, kubernetesDeploymentManagedLabels(deploymentID))
+	return labels
+}
+
+// kubernetesNativeSelector matches the pods of one workload. An agent and the
+// MCP servers deployed with it share a deployment ID, so the name is included.
+// Rendered manifests have no deployment ID and select by name alone.
+func kubernetesNativeSelector(name, deploymentID string) map[string]string {
+	selector := map[string]string{kubernetesNativeNameLabelKey: name}
+	if deploymentID != "" {
+		selector[kubernetesDeploymentIDLabelKey] = deploymentID
+	}
+	return selector
+}
+
+func kubernetesNativeEnvVars(env map[string]string) []corev1.EnvVar {
+	envVars := make([]corev1.EnvVar, 0, len(env))
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		envVars = append(envVars, corev1.EnvVar{Name: key, Value: env[key]})
+	}
+	return envVars
+}
+
+func kubernetesNativeWorkload(name, namespace, deploymentID string, labels map[string]string, podSpec corev1.PodSpec) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: kubernetesDeploymentManagedAnnotations(deploymentID),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: kubernetesNativeSelector(name, deploymentID)},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
+
+func kubernetesNativeService(name, namespace, deploymentID string, labels map[string]string, portName string, port int32) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: kubernetesDeploymentManagedAnnotations(deploymentID),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: kubernetesNativeSelector(name, deploymentID),
+			Ports: []corev1.ServicePort{{
+				Name:       portName,
+				Port:       port,
+				TargetPort: intstr.FromString(portName),
+			}},
+		},
+	}
+}
+
+func kubernetesNativeApplyPlatformConfig(ctx context.Context, provider *models.Provider, cfg *platformtypes.KubernetesNativePlatformConfig) error {
+	if cfg == nil || len(cfg.Deployments) == 0 {
+		return nil
+	}
+	c, err := kubernetesGetClient(provider)
+	if err != nil {
+		return err
+	}
+
+	// Secrets and ConfigMaps go first so the pods mounting them can start.
+	for _, secret := range cfg.Secrets {
+		kubernetesEnsureNamespace(secret)
+		if err := kubernetesApplyResource(ctx, c, secret, false); err != nil {
+			return fmt.Errorf("secret %s: %w", secret.Name, err)
+		}
+	}
+	for _, configMap := range cfg.ConfigMaps {
+		kubernetesEnsureNamespace(configMap)
+		if err := kubernetesApplyResource(ctx, c, configMap, false); err != nil {
+			return fmt.Errorf("ConfigMap %s: %w", configMap.Name, err)
+		}
+	}
+	for _, service := range cfg.Services {
+		kubernetesEnsureNamespace(service)
+		if err := kubernetesApplyResource(ctx, c, service, false); err != nil {
+			return fmt.Errorf("service %s: %w", service.Name, err)
+		}
+	}
+	for _, workload := range cfg.Deployments {
+		kubernetesEnsureNamespace(workload)
+		if err := kubernetesApplyResource(ctx, c, workload, false); err != nil {
+			return fmt.Errorf("deployment %s: %w", workload.Name, err)
+		}
+	}
+	return nil
+}
+
+// kubernetesNativeDeleteResourcesByDeploymentID deletes the Deployments,
+// Services, ConfigMaps and Secrets labelled with the deployment ID.
+func kubernetesNativeDeleteResourcesByDeploymentID(ctx context.Context, provider *models.Provider, deploymentID, namespace string) error {
+	if deploymentID == "" {
+		return fmt.Errorf("deployment id is required")
+	}
+	c, err := kubernetesGetClient(provider)
+	if err != nil {
+		return err
+	}
+	opts := kubernetesDeploymentSelectorOpts(deploymentID, namespace)
+
+	workloads := &appsv1.DeploymentList{}
+	if err := c.List(ctx, workloads, opts...); err != nil {
+		return fmt.Errorf("failed to list deployments by deployment id %s: %w", deploymentID, err)
+	}
+	for i := range workloads.Items {
+		if err := kubernetesDeleteResource(ctx, c, &workloads.Items[i]); err != nil {
+			return fmt.Errorf("failed to delete deployment %s: %w", workloads.Items[i].Name, err)
+		}
+	}
+
+	services := &corev1.ServiceList{}
+	if err := c.List(ctx, services, opts...); err != nil {
+		return fmt.Errorf("failed to list services by deployment id %s: %w", deploymentID, err)
+	}
+	for i := range services.Items {
+		if err := kubernetesDeleteResource(ctx, c, &services.Items[i]); err != nil {
+			return fmt.Errorf("failed to delete service %s: %w", services.Items[i].Name, err)
+		}
+	}
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := c.List(ctx, configMaps, opts...); err != nil {
+		return fmt.Errorf("failed to list configmaps by deployment id %s: %w", deploymentID, err)
+	}
+	for i := range configMaps.Items {
+		if err := kubernetesDeleteResource(ctx, c, &configMaps.Items[i]); err != nil {
+			return fmt.Errorf("failed to delete configmap %s: %w", configMaps.Items[i].Name, err)
+		}
+	}
+	return kubernetesDeleteSecretsByDeploymentID(ctx, c, deploymentID, opts)
+}
+
+// kubernetesNativePruneDeploymentResources deletes the objects labelled with
+// the deployment ID that cfg no longer has, such as those named after the
+// version an update replaced.
+func kubernetesNativePruneDeploymentResources(ctx context.Context, c client.Client, cfg *platformtypes.KubernetesNativePlatformConfig, deploymentID, namespace string) error {
+	keep := map[string]struct{}{}
+	mark := func(kind string, obj client.Object) {
+		keep[kind+"/"+obj.GetName()] = struct{}{}
+	}
+	for _, obj := range cfg.Deployments {
+		mark("Deployment", obj)
+	}
+	for _, obj := range cfg.Services {
+		mark("Service", obj)
+	}
+	for _, obj := range cfg.ConfigMaps {
+		mark("ConfigMap", obj)
+	}
+	for _, obj := range cfg.Secrets {
+		mark("Secret", obj)
+	}
+
+	opts := kubernetesDeploymentSelectorOpts(deploymentID, namespace)
+	var stale []client.Object
+	collect := func(kind string, list client.ObjectList) error {
+		if err := c.List(ctx, list, opts...); err != nil {
+			return fmt.Errorf("failed to list %s resources by deployment id %s: %w", kind, deploymentID, err)
+		}
+		return meta.EachListItem(list, func(item k8sruntime.Object) error {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return nil
+			}
+			if _, ok := keep[kind+"/"+obj.GetName()]; !ok {
+				stale = append(stale, obj)
+			}
+			return nil
+		})
+	}
+	// Workloads go first so no pod is left mounting a deleted object.
+	if err := collect("Deployment", &appsv1.DeploymentList{}); err != nil {
+		return err
+	}
+	if err := collect("Service", &corev1.ServiceList{}); err != nil {
+		return err
+	}
+	if err := collect("ConfigMap", &corev1.ConfigMapList{}); err != nil {
+		return err
+	}
+	if err := collect("Secret", &corev1.SecretList{}); err != nil {
+		return err
+	}
+
+	for _, obj := range stale {
+		if err := kubernetesDeleteResource(ctx, c, obj); err != nil {
+			return fmt.Errorf("failed to delete stale %T %s: %w", obj, obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// kubernetesNativeObserveDeployment compares the Deployments in cfg with the
+// ones labelled with the deployment ID and reports missing, unexpected,
+// changed, or unavailable workloads.
+func kubernetesNativeObserveDeployment(ctx context.Context, c client.Client, cfg *platformtypes.KubernetesNativePlatformConfig, deploymentID, namespace string) (*models.DeploymentObservation, error) {
+	workloads := &appsv1.DeploymentList{}
+	if err := c.List(ctx, workloads, kubernetesDeploymentSelectorOpts(deploymentID, namespace)...); err != nil {
+		return nil, fmt.Errorf("failed to list deployments for deployment %s: %w", deploymentID, err)
+	}
+	live := map[string]*appsv1.Deployment{}
+	for i := range workloads.Items {
+		live[workloads.Items[i].Name] = &workloads.Items[i]
+	}
+
+	var want, missing, drift, unhealthy []string
+	for _, desired := range cfg.Deployments {
+		want = append(want, desired.Name)
+		workload, ok := live[desired.Name]
+		if !ok {
+			missing = append(missing, "Deployment/"+desired.Name)
+			continue
+		}
+		images := map[string]string{}
+		for _, container := range workload.Spec.Template.Spec.Containers {
+			images[container.Name] = container.Image
+		}
+		for _, container := range desired.Spec.Template.Spec.Containers {
+			if got, ok := images[container.Name]; !ok {
+				drift = append(drift, fmt.Sprintf("Deployment/%s has no %s container", desired.Name, container.Name))
+			} else if got != container.Image {
+				drift = append(drift, fmt.Sprintf("Deployment/%s runs image %s in %s, expected %s", desired.Name, got, container.Name, container.Image))
+			}
+		}
+		if reason := kubernetesNativeNotAvailableReason(workload); reason != "" {
+			unhealthy = append(unhealthy, fmt.Sprintf("Deployment/%s is not available: %s", desired.Name, reason))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(live)) {
+		if !slices.Contains(want, name) {
+			drift = append(drift, "unexpected Deployment/"+name)
+		}
+	}
+
+	switch {
+	case len(missing) > 0 && len(missing) == len(want):
+		return &models.DeploymentObservation{
+			Status: models.DeploymentStatusMissing,
+			Reason: "workload not found on platform: " + strings.Join(missing, ", "),
+		}, nil
+	case len(missing) > 0 || len(drift) > 0:
+		for _, key := range missing {
+			drift = append(drift, "missing "+key)
+		}
+		return &models.DeploymentObservation{Status: models.DeploymentStatusDrifted, Reason: strings.Join(drift, "; ")}, nil
+	case len(unhealthy) > 0:
+		return &models.DeploymentObservation{Status: models.DeploymentStatusUnhealthy, Reason: strings.Join(unhealthy, "; ")}, nil
+	}
+	return &models.DeploymentObservation{Status: models.DeploymentStatusDeployed}, nil
+}
+
+// kubernetesNativeNotAvailableReason returns the message of an Available
+// condition that is not true. Workloads that have not reported availability
+// yet are not flagged.
+func kubernetesNativeNotAvailableReason(workload *appsv1.Deployment) string {
+	for _, condition := range workload.Status.Conditions {
+		if condition.Type != appsv1.DeploymentAvailable || condition.Status == corev1.ConditionTrue {
+			continue
+		}
+		return cmp.Or(condition.Message, condition.Reason, string(condition.Status))
+	}
+	return ""
+}
+
+// kubernetesNativeDiscoverDeployments lists the Deployments labelled as MCP
+// servers or agents that the registry did not create.
+func kubernetesNativeDiscoverDeployments(ctx context.Context, provider *models.Provider) ([]*models.Deployment, error) {
+	providerID := defaultKubernetesProviderID
+	if provider != nil && strings.TrimSpace(provider.ID) != "" {
+		providerID = strings.TrimSpace(provider.ID)
+	}
+	c, err := kubernetesGetClient(provider)
+	if err != nil {
+		return nil, err
+	}
+	var opts []client.ListOption
+	if namespace := kubernetesProviderNamespace(provider); namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	workloads := &appsv1.DeploymentList{}
+	if err := c.List(ctx, workloads, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list deployments for discovery: %w", err)
+	}
+
+	discovered := make([]*models.Deployment, 0)
+	for _, workload := range workloads.Items {
+		if workload.Labels[kubernetesManagedLabelKey] == "true" {
+			continue
+		}
+		var resourceType string
+		switch workload.Labels[kubernetesNativeComponentLabelKey] {
+		case kubernetesNativeMCPComponent:
+			resourceType = "mcp"
+		case kubernetesNativeAgentComponent:
+			resourceType = "agent"
+		default:
+			continue
+		}
+		meta, _ := models.UnmarshalFrom(models.KubernetesProviderMetadata{
+			IsExternal: true,
+			Namespace:  workload.Namespace,
+		})
+		creation := workload.CreationTimestamp.Time
+		discovered = append(discovered, &models.Deployment{
+			ServerName:       cmp.Or(workload.Labels[kubernetesNativeNameLabelKey], workload.Name),
+			Version:          "unknown",
+			DeployedAt:       creation,
+			UpdatedAt:        creation,
+			Status:           models.DeploymentStatusDeployed,
+			Origin:           "discovered",
+			ProviderID:       providerID,
+			ResourceType:     resourceType,
+			Env:              workload.Labels,
+			ProviderMetadata: meta,
+		})
+	}
+	return discovered, nil
+}
+
+// kubernetesNativeStreamDeploymentLogs streams the logs of the pods behind the
+// deployment's agent or MCP server workloads. An agent's own MCP servers are
+// left out.
+func kubernetesNativeStreamDeploymentLogs(
+	ctx context.Context,
+	provider *models.Provider,
+	deploymentID, resourceType, namespace string,
+	opts models.DeploymentLogOptions,
+	emit func(line string) error,
+) error {
+	if deploymentID == "" {
+		return fmt.Errorf("deployment id is required")
+	}
+	var component string
+	switch resourceType {
+	case "mcp":
+		component = kubernetesNativeMCPComponent
+	case "agent":
+		component = kubernetesNativeAgentComponent
+	default:
+		return fmt.Errorf("invalid resource type %q: %w", resourceType, database.ErrInvalidInput)
+	}
+	c, err := kubernetesGetClient(provider)
+	if err != nil {
+		return err
+	}
+
+	listOpts := []client.ListOption{client.MatchingLabels{
+		kubernetesDeploymentIDLabelKey:    deploymentID,
+		kubernetesNativeComponentLabelKey: component,
+	}}
+	if namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+	workloads := &appsv1.DeploymentList{}
+	if err := c.List(ctx, workloads, listOpts...); err != nil {
+		return fmt.Errorf("failed to list deployments by deployment id %s: %w", deploymentID, err)
+	}
+	pods := make([]corev1.Pod, 0)
+	for i := range workloads.Items {
+		workloadPods, err := kubernetesListPodsForWorkload(ctx, c, &workloads.Items[i])
+		if err != nil {
+			return err
+		}
+		pods = append(pods, workloadPods...)
+	}
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	return kubernetesStreamPodsLogs(ctx, provider, deploymentID, pods, opts, emit)
+}
@@ -0,0 +1,420 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	platformtypes "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclientset "k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// useFakeKubernetesClients points the package's client constructors at fakes
+// for the duration of the test.
+func useFakeKubernetesClients(t *testing.T, c client.Client) {
+	t.Helper()
+	originalAmbientRESTConfig := kubernetesGetAmbientRESTConfig
+	originalNewClientForConfig := kubernetesNewClientForConfig
+	originalNewClientsetForConfig := kubernetesNewClientsetForConfig
+	t.Cleanup(func() {
+		kubernetesGetAmbientRESTConfig = originalAmbientRESTConfig
+		kubernetesNewClientForConfig = originalNewClientForConfig
+		kubernetesNewClientsetForConfig = originalNewClientsetForConfig
+	})
+
+	kubernetesGetAmbientRESTConfig = func() (*rest.Config, error) {
+		return &rest.Config{Host: "https://example.test"}, nil
+	}
+	kubernetesNewClientForConfig = func(*rest.Config) (client.Client, error) {
+		return c, nil
+	}
+	kubernetesNewClientsetForConfig = func(*rest.Config) (k8sclientset.Interface, error) {
+		return k8sfake.NewClientset(), nil
+	}
+}
+
+func testKubernetesNativeStdioServer(cmd, image string) *platformtypes.MCPServer {
+	return &platformtypes.MCPServer{
+		Name:          "weather",
+		DeploymentID:  "dep-native-123",
+		MCPServerType: platformtypes.MCPServerTypeLocal,
+		Local: &platformtypes.LocalMCPServer{
+			Deployment: platformtypes.MCPServerDeployment{
+				Image:     image,
+				Cmd:       cmd,
+				Args:      []string{"weather-mcp"},
+				Env:       map[string]string{"LOG_LEVEL": "info"},
+				SecretEnv: map[string]string{"WEATHER_API_KEY": "super-secret"},
+			},
+			TransportType: platformtypes.TransportTypeStdio,
+		},
+	}
+}
+
+func TestKubernetesNativeTranslatePlatformConfig_StdioServerRunsInGateway(t *testing.T) {
+	cfg, err := kubernetesNativeTranslatePlatformConfig(&platformtypes.DesiredState{
+		MCPServers: []*platformtypes.MCPServer{testKubernetesNativeStdioServer("npx", "node:24-alpine3.21")},
+	}, "tools")
+	if err != nil {
+		t.Fatalf("kubernetesNativeTranslatePlatformConfig() error = %v", err)
+	}
+	if len(cfg.Deployments) != 1 || len(cfg.Services) != 1 || len(cfg.ConfigMaps) != 1 || len(cfg.Secrets) != 1 {
+		t.Fatalf("expected one deployment, service, configmap and secret, got %d/%d/%d/%d",
+			len(cfg.Deployments), len(cfg.Services), len(cfg.ConfigMaps), len(cfg.Secrets))
+	}
+
+	workload := cfg.Deployments[0]
+	if workload.Namespace != "tools" {
+		t.Fatalf("deployment namespace = %q, want tools", workload.Namespace)
+	}
+	containers := workload.Spec.Template.Spec.Containers
+	if len(containers) != 1 || containers[0].Name != "agentgateway" {
+		t.Fatalf("expected the gateway to be the only container, got %#v", containers)
+	}
+	gateway := containers[0]
+	if len(gateway.EnvFrom) != 1 || gateway.EnvFrom[0].SecretRef == nil || gateway.EnvFrom[0].SecretRef.Name != cfg.Secrets[0].Name {
+		t.Fatalf("expected gateway env from secret %s, got %#v", cfg.Secrets[0].Name, gateway.EnvFrom)
+	}
+	if got := workload.Spec.Selector.MatchLabels; got[kubernetesDeploymentIDLabelKey] != "dep-native-123" || got[kubernetesNativeNameLabelKey] != workload.Name {
+		t.Fatalf("unexpected selector %#v", got)
+	}
+
+	gatewayConfig := cfg.ConfigMaps[0].Data[kubernetesNativeGatewayConfigKey]
+	if !strings.Contains(gatewayConfig, "stdio:") || !strings.Contains(gatewayConfig, "cmd: npx") {
+		t.Fatalf("expected gateway config to spawn npx over stdio, got:\n%s", gatewayConfig)
+	}
+	if strings.Contains(gatewayConfig, "super-secret") {
+		t.Fatalf("gateway config must not contain secret values:\n%s", gatewayConfig)
+	}
+
+	service := cfg.Services[0]
+	if len(service.Spec.Ports) != 1 || service.Spec.Ports[0].Port != kubernetesNativeGatewayPort {
+		t.Fatalf("expected service on port %d, got %#v", kubernetesNativeGatewayPort, service.Spec.Ports)
+	}
+}
+
+func TestKubernetesNativeTranslatePlatformConfig_StdioImageRunsBehindGatewaySidecar(t *testing.T) {
+	cfg, err := kubernetesNativeTranslatePlatformConfig(&platformtypes.DesiredState{
+		MCPServers: []*platformtypes.MCPServer{testKubernetesNativeStdioServer("", "ghcr.io/acme/weather:1.0.0")},
+	}, "tools")
+	if err != nil {
+		t.Fatalf("kubernetesNativeTranslatePlatformConfig() error = %v", err)
+	}
+
+	containers := cfg.Deployments[0].Spec.Template.Spec.Containers
+	if len(containers) != 2 || containers[0].Name != "mcp-server" || containers[1].Name != "agentgateway" {
+		t.Fatalf("expected server and gateway sidecar containers, got %#v", containers)
+	}
+	server := containers[0]
+	if server.Image != "ghcr.io/acme/weather:1.0.0" || len(server.Command) != 0 {
+		t.Fatalf("expected server image entrypoint to be kept, got image %q command %#v", server.Image, server.Command)
+	}
+	env := map[string]string{}
+	for _, envVar := range server.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	if env["MCP_TRANSPORT_MODE"] != "http" || env["PORT"] != "3001" || env["LOG_LEVEL"] != "info" {
+		t.Fatalf("unexpected server env %#v", env)
+	}
+	if gatewayConfig := cfg.ConfigMaps[0].Data[kubernetesNativeGatewayConfigKey]; !strings.Contains(gatewayConfig, "http://localhost:3001/mcp") {
+		t.Fatalf("expected gateway to proxy the server container, got:\n%s", gatewayConfig)
+	}
+}
+
+func TestKubernetesNativeTranslatePlatformConfig_AgentReachesMCPServersInCluster(t *testing.T) {
+	const deploymentID = "dep-agent-123"
+	server := testKubernetesNativeStdioServer("uvx", "ghcr.io/astral-sh/uv:debian")
+	server.DeploymentID = deploymentID
+
+	cfg, err := kubernetesNativeTranslatePlatformConfig(&platformtypes.DesiredState{
+		MCPServers: []*platformtypes.MCPServer{server},
+		Agents: []*platformtypes.Agent{{
+			Name:         "planner",
+			Version:      "1.0.0",
+			DeploymentID: deploymentID,
+			Deployment: platformtypes.AgentDeployment{
+				Image:     "ghcr.io/acme/planner:1.0.0",
+				Env:       map[string]string{"KAGENT_NAMESPACE": "agents"},
+				SecretEnv: map[string]string{"OPENAI_API_KEY": "sk-test"},
+			},
+			ResolvedMCPServers: []platformtypes.ResolvedMCPServerConfig{
+				{Name: "weather-dep-agent-123", Type: "command"},
+				{Name: "search", Type: "remote", URL: "https://search.example.com/mcp"},
+			},
+		}},
+	}, "agents")
+	if err != nil {
+		t.Fatalf("kubernetesNativeTranslatePlatformConfig() error = %v", err)
+	}
+	if len(cfg.Deployments) != 2 || len(cfg.Services) != 2 {
+		t.Fatalf("expected agent and MCP server workloads, got %d deployments and %d services", len(cfg.Deployments), len(cfg.Services))
+	}
+
+	var agentConfig *corev1.ConfigMap
+	for _, configMap := range cfg.ConfigMaps {
+		if _, ok := configMap.Data["mcp-servers.json"]; ok {
+			agentConfig = configMap
+		}
+	}
+	if agentConfig == nil {
+		t.Fatalf("expected an agent ConfigMap with mcp-servers.json")
+	}
+	var servers []platformtypes.ResolvedMCPServerConfig
+	if err := json.Unmarshal([]byte(agentConfig.Data["mcp-servers.json"]), &servers); err != nil {
+		t.Fatalf("unmarshal mcp-servers.json: %v", err)
+	}
+	wantURL := "http://" + kubernetesMCPServerResourceName("weather", deploymentID) + ".agents.svc.cluster.local:3000/mcp"
+	if servers[0].Type != "remote" || servers[0].URL != wantURL {
+		t.Fatalf("expected registry server at %s, got %#v", wantURL, servers[0])
+	}
+	if servers[1].URL != "https://search.example.com/mcp" {
+		t.Fatalf("expected remote server to be left alone, got %#v", servers[1])
+	}
+
+	var agentWorkload *appsv1.Deployment
+	for _, workload := range cfg.Deployments {
+		if workload.Labels[kubernetesNativeComponentLabelKey] == kubernetesNativeAgentComponent {
+			agentWorkload = workload
+		}
+	}
+	if agentWorkload == nil {
+		t.Fatalf("expected an agent deployment")
+	}
+	container := agentWorkload.Spec.Template.Spec.Containers[0]
+	if strings.Join(container.Args, " ") != "planner --local --port 8080" {
+		t.Fatalf("unexpected agent args %#v", container.Args)
+	}
+	for _, envVar := range container.Env {
+		if envVar.Name == "OPENAI_API_KEY" && (envVar.Value != "" || envVar.ValueFrom == nil) {
+			t.Fatalf("expected secret env to be referenced, got %#v", envVar)
+		}
+	}
+}
+
+func TestKubernetesNativeApplyAndDeleteResourcesByDeploymentID(t *testing.T) {
+	const deploymentID = "dep-native-123"
+	fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: "tools",
+			Labels:    kubernetesDeploymentManagedLabels("dep-other"),
+		}},
+	).Build()
+	useFakeKubernetesClients(t, fakeClient)
+	provider := &models.Provider{ID: "cluster", Platform: kubernetesNativePlatform}
+	ctx := context.Background()
+
+	cfg, err := kubernetesNativeTranslatePlatformConfig(&platformtypes.DesiredState{
+		MCPServers: []*platformtypes.MCPServer{testKubernetesNativeStdioServer("npx", "node:24-alpine3.21")},
+	}, "tools")
+	if err != nil {
+		t.Fatalf("kubernetesNativeTranslatePlatformConfig() error = %v", err)
+	}
+	if err := kubernetesNativeApplyPlatformConfig(ctx, provider, cfg); err != nil {
+		t.Fatalf("kubernetesNativeApplyPlatformConfig() error = %v", err)
+	}
+
+	workload := &appsv1.Deployment{}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Namespace: "tools", Name: cfg.Deployments[0].Name}, workload); err != nil {
+		t.Fatalf("expected applied deployment: %v", err)
+	}
+	secret := &corev1.Secret{}
+	if err := fakeClient.Get(ctx, client.ObjectKey{Namespace: "tools", Name: cfg.Secrets[0].Name}, secret); err != nil {
+		t.Fatalf("expected applied secret: %v", err)
+	}
+
+	if err := kubernetesNativeDeleteResourcesByDeploymentID(ctx, provider, deploymentID, "tools"); err != nil {
+		t.Fatalf("kubernetesNativeDeleteResourcesByDeploymentID() error = %v", err)
+	}
+	workloads := &appsv1.DeploymentList{}
+	services := &corev1.ServiceList{}
+	configMaps := &corev1.ConfigMapList{}
+	secrets := &corev1.SecretList{}
+	for _, list := range []client.ObjectList{workloads, services, configMaps, secrets} {
+		if err := fakeClient.List(ctx, list, client.InNamespace("tools")); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	}
+	if len(workloads.Items) != 0 || len(services.Items) != 0 || len(secrets.Items) != 0 {
+		t.Fatalf("expected deployment objects to be deleted, got %d deployments, %d services, %d secrets",
+			len(workloads.Items), len(services.Items), len(secrets.Items))
+	}
+	if len(configMaps.Items) != 1 || configMaps.Items[0].Name != "other" {
+		t.Fatalf("expected only the other deployment's ConfigMap to remain, got %#v", configMaps.Items)
+	}
+}
+
+func TestKubernetesNativeDiscoverDeployments_SkipsManagedAndUnlabelledWorkloads(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "external-weather", Namespace: "tools", Labels: map[string]string{
+			kubernetesNativeComponentLabelKey: kubernetesNativeMCPComponent,
+			kubernetesNativeNameLabelKey:      "weather",
+		}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "external-planner", Namespace: "agents", Labels: map[string]string{
+			kubernetesNativeComponentLabelKey: kubernetesNativeAgentComponent,
+		}}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: "tools",
+			Labels: kubernetesNativeLabels("managed", kubernetesNativeMCPComponent, "dep-1")}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "tools"}},
+	).Build()
+	useFakeKubernetesClients(t, fakeClient)
+
+	discovered, err := kubernetesNativeDiscoverDeployments(context.Background(), &models.Provider{ID: "cluster", Platform: kubernetesNativePlatform})
+	if err != nil {
+		t.Fatalf("kubernetesNativeDiscoverDeployments() error = %v", err)
+	}
+	got := map[string]string{}
+	for _, dep := range discovered {
+		if dep.Origin != "discovered" || dep.ProviderID != "cluster" {
+			t.Fatalf("unexpected discovered deployment %#v", dep)
+		}
+		got[dep.ServerName] = dep.ResourceType
+	}
+	if len(got) != 2 || got["weather"] != "mcp" || got["external-planner"] != "agent" {
+		t.Fatalf("expected the two unmanaged labelled workloads, got %#v", got)
+	}
+}
+
+func TestKubernetesNativeStreamDeploymentLogs_ReadsPodsOfRequestedComponent(t *testing.T) {
+	const deploymentID = "dep-logs-123"
+	agentName := kubernetesAgentResourceName("planner", "v1", deploymentID)
+	serverName := kubernetesMCPServerResourceName("weather", deploymentID)
+
+	workloadFor := func(name, component string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "agents", Labels: kubernetesNativeLabels(name, component, deploymentID)},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: kubernetesNativeSelector(name, deploymentID)}},
+		}
+	}
+	podFor := func(name, container string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name + "-abc", Namespace: "agents", Labels: kubernetesNativeSelector(name, deploymentID)},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: container}}},
+		}
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+		workloadFor(agentName, kubernetesNativeAgentComponent),
+		workloadFor(serverName, kubernetesNativeMCPComponent),
+		podFor(agentName, "agent"),
+		podFor(serverName, "agentgateway"),
+	).Build()
+	useFakeKubernetesClients(t, fakeClient)
+
+	var lines []string
+	err := kubernetesNativeStreamDeploymentLogs(context.Background(), &models.Provider{ID: "cluster", Platform: kubernetesNativePlatform},
+		deploymentID, "agent", "agents", models.DeploymentLogOptions{}, func(line string) error {
+			lines = append(lines, line)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("kubernetesNativeStreamDeploymentLogs() error = %v", err)
+	}
+	// The fake clientset returns a fixed body for every pod log request, so a
+	// single unprefixed line means only the agent pod was read.
+	if len(lines) != 1 || lines[0] != "fake logs" {
+		t.Fatalf("expected logs from the agent pod only, got %#v", lines)
+	}
+}
+
+func TestKubernetesNativePruneDeploymentResources_RemovesPreviousVersion(t *testing.T) {
+	const (
+		namespace    = "agents"
+		deploymentID = "dep-agent-123"
+	)
+	labels := map[string]string{kubernetesDeploymentIDLabelKey: deploymentID}
+	fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "planner-1-0-0", Namespace: namespace, Labels: labels}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "planner-1-0-0", Namespace: namespace, Labels: labels}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "planner-1-0-0-env", Namespace: namespace, Labels: labels}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "planner-2-0-0", Namespace: namespace, Labels: labels}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: namespace,
+			Labels:    map[string]string{kubernetesDeploymentIDLabelKey: "dep-other"},
+		}},
+	).Build()
+
+	cfg := &platformtypes.KubernetesNativePlatformConfig{
+		Deployments: []*appsv1.Deployment{{ObjectMeta: metav1.ObjectMeta{Name: "planner-2-0-0", Namespace: namespace}}},
+	}
+	if err := kubernetesNativePruneDeploymentResources(context.Background(), fakeClient, cfg, deploymentID, namespace); err != nil {
+		t.Fatalf("kubernetesNativePruneDeploymentResources() error = %v", err)
+	}
+
+	exists := func(obj client.Object, name string) bool {
+		t.Helper()
+		return fakeClient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, obj) == nil
+	}
+	if exists(&appsv1.Deployment{}, "planner-1-0-0") || exists(&corev1.Service{}, "planner-1-0-0") || exists(&corev1.Secret{}, "planner-1-0-0-env") {
+		t.Fatal("expected the previous version's objects to be pruned")
+	}
+	if !exists(&appsv1.Deployment{}, "planner-2-0-0") {
+		t.Fatal("expected the current workload to be kept")
+	}
+	if !exists(&appsv1.Deployment{}, "other") {
+		t.Fatal("expected the other deployment's workload to be kept")
+	}
+}
+
+func TestKubernetesNativeObserveDeployment_ClassifiesLiveState(t *testing.T) {
+	const (
+		namespace    = "tools"
+		deploymentID = "dep-native-123"
+	)
+	labels := map[string]string{kubernetesDeploymentIDLabelKey: deploymentID}
+	workload := func(name, image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "mcp-server", Image: image}},
+			}}},
+		}
+	}
+	desired := &platformtypes.KubernetesNativePlatformConfig{Deployments: []*appsv1.Deployment{workload("weather", "weather:2.0.0")}}
+	unavailable := workload("weather", "weather:2.0.0")
+	unavailable.Status.Conditions = []appsv1.DeploymentCondition{{
+		Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, Message: "Deployment does not have minimum availability.",
+	}}
+
+	tests := []struct {
+		name       string
+		objects    []client.Object
+		wantStatus string
+		wantReason string
+	}{
+		{name: "matching", objects: []client.Object{workload("weather", "weather:2.0.0")}, wantStatus: models.DeploymentStatusDeployed},
+		{name: "missing", wantStatus: models.DeploymentStatusMissing, wantReason: "Deployment/weather"},
+		{name: "image changed", objects: []client.Object{workload("weather", "weather:1.0.0")}, wantStatus: models.DeploymentStatusDrifted, wantReason: "weather:1.0.0"},
+		{
+			name:       "unexpected object",
+			objects:    []client.Object{workload("weather", "weather:2.0.0"), workload("leftover", "weather:1.0.0")},
+			wantStatus: models.DeploymentStatusDrifted,
+			wantReason: "unexpected Deployment/leftover",
+		},
+		{name: "not available", objects: []client.Object{unavailable}, wantStatus: models.DeploymentStatusUnhealthy, wantReason: "minimum availability"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(tt.objects...).Build()
+			got, err := kubernetesNativeObserveDeployment(context.Background(), fakeClient, desired, deploymentID, namespace)
+			if err != nil {
+				t.Fatalf("kubernetesNativeObserveDeployment() error = %v", err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", got.Status, got.Reason, tt.wantStatus)
+			}
+			if !strings.Contains(got.Reason, tt.wantReason) {
+				t.Fatalf("reason = %q, want it to contain %q", got.Reason, tt.wantReason)
+			}
+		})
+	}
+}
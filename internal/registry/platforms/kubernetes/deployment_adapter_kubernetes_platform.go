@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"bufio"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -8,19 +10,24 @@ import (
 	"maps"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/common/gitutil"
 	platformtypes "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
 	platformutils "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/utils"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	k8sclientset "k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -36,6 +43,10 @@ const (
 	kubernetesFieldManager              = "agentregistry"
 	maxKubernetesNameLength             = 63
 	maxDeploymentSuffixLength           = 16
+	// kubernetesKagentA2AURL is where the kagent controller serves agent A2A endpoints.
+	kubernetesKagentA2AURL = "http://kagent-controller.kagent.svc.cluster.local:8083/api/a2a"
+	// kubernetesDefaultMCPServerPort is kmcp's default MCPServer port.
+	kubernetesDefaultMCPServerPort = 3000
 )
 
 var (
@@ -44,6 +55,9 @@ var (
 	kubernetesNewClientForConfig   = func(restConfig *rest.Config) (client.Client, error) {
 		return client.New(restConfig, client.Options{Scheme: kubernetesScheme})
 	}
+	kubernetesNewClientsetForConfig = func(restConfig *rest.Config) (k8sclientset.Interface, error) {
+		return k8sclientset.NewForConfig(restConfig)
+	}
 )
 
 func init() {
@@ -152,7 +166,7 @@ func kubernetesProviderNamespace(provider *models.Provider) string {
 }
 
 func kubernetesApplyPlatformConfig(ctx context.Context, provider *models.Provider, cfg *platformtypes.KubernetesPlatformConfig, verbose bool) error {
-	if cfg == nil || (len(cfg.Agents) == 0 && len(cfg.RemoteMCPServers) == 0 && len(cfg.MCPServers) == 0 && len(cfg.ConfigMaps) == 0) {
+	if cfg == nil || (len(cfg.Agents) == 0 && len(cfg.RemoteMCPServers) == 0 && len(cfg.MCPServers) == 0 && len(cfg.ConfigMaps) == 0 && len(cfg.Secrets) == 0) {
 		return nil
 	}
 	c, err := kubernetesGetClient(provider)
@@ -160,6 +174,14 @@ func kubernetesApplyPlatformConfig(ctx context.Context, provider *models.Provide
 		return err
 	}
 
+	// Secrets go first so workloads referencing them can start.
+	for _, secret := range cfg.Secrets {
+		kubernetesEnsureNamespace(secret)
+		if err := kubernetesApplyResource(ctx, c, secret, verbose); err != nil {
+			return fmt.Errorf("secret %s: %w", secret.Name, err)
+		}
+	}
+
 	for _, configMap := range cfg.ConfigMaps {
 		kubernetesEnsureNamespace(configMap)
 		if err := kubernetesApplyResource(ctx, c, configMap, verbose); err != nil {
@@ -270,12 +292,19 @@ func kubernetesTranslatePlatformConfig(ctx context.Context, desired *platformtyp
 
 	agents := make([]*v1alpha2.Agent, 0, len(desired.Agents))
 	configMaps := make([]*corev1.ConfigMap, 0)
+	var secrets []*corev1.Secret
 	for _, agent := range desired.Agents {
 		resource, err := kubernetesTranslateAgent(agent)
 		if err != nil {
 			return nil, err
 		}
 		agents = append(agents, resource)
+		if len(agent.Deployment.SecretEnv) > 0 {
+			secrets = append(secrets, kubernetesTranslateSecretEnv(
+				kubernetesAgentSecretName(agent.Name, agent.Version, agent.DeploymentID),
+				resource.Namespace, agent.DeploymentID, agent.Deployment.SecretEnv,
+			))
+		}
 
 		if len(agent.ResolvedMCPServers) > 0 || len(agent.ResolvedPrompts) > 0 {
 			configMap, err := kubernetesTranslateAgentConfigMap(agent)
@@ -308,6 +337,12 @@ func kubernetesTranslatePlatformConfig(ctx context.Context, desired *platformtyp
 				return nil, err
 			}
 			mcpServers = append(mcpServers, resource)
+			if len(server.Local.Deployment.SecretEnv) > 0 {
+				secrets = append(secrets, kubernetesTranslateSecretEnv(
+					kubernetesMCPServerSecretName(server.Name, server.DeploymentID),
+					resource.Namespace, server.DeploymentID, server.Local.Deployment.SecretEnv,
+				))
+			}
 		}
 	}
 
@@ -316,6 +351,7 @@ func kubernetesTranslatePlatformConfig(ctx context.Context, desired *platformtyp
 		RemoteMCPServers: remoteMCPs,
 		MCPServers:       mcpServers,
 		ConfigMaps:       configMaps,
+		Secrets:          secrets,
 	}, nil
 }
 
@@ -326,44 +362,8 @@ func kubernetesTranslateAgent(agent *platformtypes.Agent) (*v1alpha2.Agent, erro
 
 	namespace := agent.Deployment.Env["KAGENT_NAMESPACE"]
 
-	envVars := make([]corev1.EnvVar, 0, len(agent.Deployment.Env))
-	if len(agent.Deployment.Env) > 0 {
-		keys := make([]string, 0, len(agent.Deployment.Env))
-		for key := range agent.Deployment.Env {
-			keys = append(keys, key)
-		}
-		slices.Sort(keys)
-		for _, key := range keys {
-			envVars = append(envVars, corev1.EnvVar{Name: key, Value: agent.Deployment.Env[key]})
-		}
-	}
-
-	sharedSpec := v1alpha2.SharedDeploymentSpec{Env: envVars}
-	if len(agent.ResolvedMCPServers) > 0 || len(agent.ResolvedPrompts) > 0 {
-		configMapName := kubernetesAgentConfigMapName(agent.Name, agent.Version, agent.DeploymentID)
-		volumeName := "agent-config"
-		var items []corev1.KeyToPath
-		if len(agent.ResolvedMCPServers) > 0 {
-			items = append(items, corev1.KeyToPath{Key: "mcp-servers.json", Path: "mcp-servers.json"})
-		}
-		if len(agent.ResolvedPrompts) > 0 {
-			items = append(items, corev1.KeyToPath{Key: "prompts.json", Path: "prompts.json"})
-		}
-		sharedSpec.Volumes = []corev1.Volume{{
-			Name: volumeName,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
-					Items:                items,
-				},
-			},
-		}}
-		sharedSpec.VolumeMounts = []corev1.VolumeMount{{
-			Name:      volumeName,
-			MountPath: "/config",
-			ReadOnly:  true,
-		}}
-	}
+	sharedSpec := v1alpha2.SharedDeploymentSpec{Env: kubernetesAgentEnvVars(agent)}
+	sharedSpec.Volumes, sharedSpec.VolumeMounts = kubernetesAgentConfigVolumes(agent)
 
 	agentSpec := v1alpha2.AgentSpec{
 		Description: agent.Name,
@@ -395,6 +395,60 @@ func kubernetesTranslateAgent(agent *platformtypes.Agent) (*v1alpha2.Agent, erro
 	}, nil
 }
 
+// kubernetesAgentEnvVars returns the agent's plain environment followed by
+// references to its secret environment, both sorted by name.
+func kubernetesAgentEnvVars(agent *platformtypes.Agent) []corev1.EnvVar {
+	envVars := make([]corev1.EnvVar, 0, len(agent.Deployment.Env)+len(agent.Deployment.SecretEnv))
+	for _, key := range slices.Sorted(maps.Keys(agent.Deployment.Env)) {
+		envVars = append(envVars, corev1.EnvVar{Name: key, Value: agent.Deployment.Env[key]})
+	}
+	if len(agent.Deployment.SecretEnv) > 0 {
+		secretName := kubernetesAgentSecretName(agent.Name, agent.Version, agent.DeploymentID)
+		for _, key := range slices.Sorted(maps.Keys(agent.Deployment.SecretEnv)) {
+			envVars = append(envVars, corev1.EnvVar{
+				Name: key,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  key,
+				}},
+			})
+		}
+	}
+	return envVars
+}
+
+// kubernetesAgentConfigVolumes mounts the agent's ConfigMap at /config when it
+// has MCP servers or prompts to read.
+func kubernetesAgentConfigVolumes(agent *platformtypes.Agent) ([]corev1.Volume, []corev1.VolumeMount) {
+	if len(agent.ResolvedMCPServers) == 0 && len(agent.ResolvedPrompts) == 0 {
+		return nil, nil
+	}
+	configMapName := kubernetesAgentConfigMapName(agent.Name, agent.Version, agent.DeploymentID)
+	volumeName := "agent-config"
+	var items []corev1.KeyToPath
+	if len(agent.ResolvedMCPServers) > 0 {
+		items = append(items, corev1.KeyToPath{Key: "mcp-servers.json", Path: "mcp-servers.json"})
+	}
+	if len(agent.ResolvedPrompts) > 0 {
+		items = append(items, corev1.KeyToPath{Key: "prompts.json", Path: "prompts.json"})
+	}
+	volumes := []corev1.Volume{{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+				Items:                items,
+			},
+		},
+	}}
+	mounts := []corev1.VolumeMount{{
+		Name:      volumeName,
+		MountPath: "/config",
+		ReadOnly:  true,
+	}}
+	return volumes, mounts
+}
+
 func kubernetesTranslateSkillsForAgent(skills []platformtypes.AgentSkillRef) (*v1alpha2.SkillForAgent, error) {
 	if len(skills) == 0 {
 		return nil, nil
@@ -507,6 +561,11 @@ func kubernetesTranslateLocalMCPServer(server *platformtypes.MCPServer) (*kmcpv1
 		Args:  server.Local.Deployment.Args,
 		Env:   server.Local.Deployment.Env,
 	}
+	if len(server.Local.Deployment.SecretEnv) > 0 {
+		deployment.SecretRefs = []corev1.LocalObjectReference{{
+			Name: kubernetesMCPServerSecretName(server.Name, server.DeploymentID),
+		}}
+	}
 
 	spec := kmcpv1alpha1.MCPServerSpec{Deployment: deployment}
 	switch server.Local.TransportType {
@@ -577,6 +636,44 @@ func kubernetesTranslateAgentConfigMap(agent *platformtypes.Agent) (*corev1.Conf
 	}, nil
 }
 
+// kubernetesTranslateSecretEnv builds the Secret holding a workload's
+// environment resolved from registry secrets.
+func kubernetesTranslateSecretEnv(name, namespace, deploymentID string, secretEnv map[string]string) *corev1.Secret {
+	data := make(map[string][]byte, len(secretEnv))
+	for key, value := range secretEnv {
+		data[key] = []byte(value)
+	}
+	labels := map[string]string{
+		"app.kubernetes.io/managed-by": "agentregistry",
+		"app.kubernetes.io/component":  "deployment-secrets",
+	}
+	maps.Copy(labels, kubernetesDeploymentManagedLabels(deploymentID))
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: kubernetesDeploymentManagedAnnotations(deploymentID),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+}
+
+func kubernetesAgentSecretName(name, version, deploymentID string) string {
+	base := fmt.Sprintf("%s-env", name)
+	if version != "" {
+		base = fmt.Sprintf("%s-%s-env", name, version)
+	}
+	return kubernetesDeploymentScopedName(base, deploymentID)
+}
+
+func kubernetesMCPServerSecretName(name, deploymentID string) string {
+	return kubernetesDeploymentScopedName(name+"-env", deploymentID)
+}
+
 func kubernetesAgentConfigMapName(name, version, deploymentID string) string {
 	base := fmt.Sprintf("%s-agent-config", name)
 	if version != "" {
@@ -749,6 +846,10 @@ func kubernetesDeleteAgentResourcesByDeploymentID(ctx context.Context, c client.
 		}
 	}
 
+	if err := kubernetesDeleteSecretsByDeploymentID(ctx, c, deploymentID, opts); err != nil {
+		return err
+	}
+
 	configMapList := &corev1.ConfigMapList{}
 	if err := c.List(ctx, configMapList, opts...); err != nil {
 		return fmt.Errorf("failed to list configmaps by deployment id %s: %w", deploymentID, err)
@@ -803,9 +904,226 @@ func kubernetesDeleteMCPResourcesByDeploymentID(ctx context.Context, c client.Cl
 			return fmt.Errorf("failed to delete remote mcp server %s: %w", remoteMCPList.Items[i].Name, err)
 		}
 	}
+	return kubernetesDeleteSecretsByDeploymentID(ctx, c, deploymentID, opts)
+}
+
+func kubernetesDeleteSecretsByDeploymentID(ctx context.Context, c client.Client, deploymentID string, opts []client.ListOption) error {
+	secretList := &corev1.SecretList{}
+	if err := c.List(ctx, secretList, opts...); err != nil {
+		return fmt.Errorf("failed to list secrets by deployment id %s: %w", deploymentID, err)
+	}
+	for i := range secretList.Items {
+		if err := kubernetesDeleteResource(ctx, c, &secretList.Items[i]); err != nil {
+			return fmt.Errorf("failed to delete secret %s: %w", secretList.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// kubernetesPruneDeploymentResources deletes the objects labelled with the
+// deployment ID that cfg no longer contains, such as the resources an agent
+// update left behind under its previous version's names.
+func kubernetesPruneDeploymentResources(ctx context.Context, c client.Client, cfg *platformtypes.KubernetesPlatformConfig, deploymentID, namespace string) error {
+	keep := map[string]struct{}{}
+	mark := func(kind string, obj client.Object) {
+		keep[kind+"/"+obj.GetName()] = struct{}{}
+	}
+	for _, obj := range cfg.Agents {
+		mark("Agent", obj)
+	}
+	for _, obj := range cfg.RemoteMCPServers {
+		mark("RemoteMCPServer", obj)
+	}
+	for _, obj := range cfg.MCPServers {
+		mark("MCPServer", obj)
+	}
+	for _, obj := range cfg.ConfigMaps {
+		mark("ConfigMap", obj)
+	}
+	for _, obj := range cfg.Secrets {
+		mark("Secret", obj)
+	}
+
+	opts := kubernetesDeploymentSelectorOpts(deploymentID, namespace)
+	var stale []client.Object
+	collect := func(kind string, list client.ObjectList) error {
+		if err := c.List(ctx, list, opts...); err != nil {
+			return fmt.Errorf("failed to list %s resources by deployment id %s: %w", kind, deploymentID, err)
+		}
+		return meta.EachListItem(list, func(item k8sruntime.Object) error {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return nil
+			}
+			if _, ok := keep[kind+"/"+obj.GetName()]; !ok {
+				stale = append(stale, obj)
+			}
+			return nil
+		})
+	}
+	if err := collect("Agent", &v1alpha2.AgentList{}); err != nil {
+		return err
+	}
+	if err := collect("RemoteMCPServer", &v1alpha2.RemoteMCPServerList{}); err != nil {
+		return err
+	}
+	if err := collect("MCPServer", &kmcpv1alpha1.MCPServerList{}); err != nil {
+		return err
+	}
+	if err := collect("ConfigMap", &corev1.ConfigMapList{}); err != nil {
+		return err
+	}
+	if err := collect("Secret", &corev1.SecretList{}); err != nil {
+		return err
+	}
+
+	for _, obj := range stale {
+		if err := kubernetesDeleteResource(ctx, c, obj); err != nil {
+			return fmt.Errorf("failed to delete stale %T %s: %w", obj, obj.GetName(), err)
+		}
+	}
 	return nil
 }
 
+// kubernetesObserveDeployment compares the workloads in cfg with the objects
+// labelled with the deployment ID and reports missing, unexpected, changed, or
+// not-ready workloads.
+func kubernetesObserveDeployment(ctx context.Context, c client.Client, cfg *platformtypes.KubernetesPlatformConfig, deploymentID, namespace string) (*models.DeploymentObservation, error) {
+	opts := kubernetesDeploymentSelectorOpts(deploymentID, namespace)
+	agents := &v1alpha2.AgentList{}
+	if err := c.List(ctx, agents, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list agents for deployment %s: %w", deploymentID, err)
+	}
+	remotes := &v1alpha2.RemoteMCPServerList{}
+	if err := c.List(ctx, remotes, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list remote MCP servers for deployment %s: %w", deploymentID, err)
+	}
+	servers := &kmcpv1alpha1.MCPServerList{}
+	if err := c.List(ctx, servers, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list MCP servers for deployment %s: %w", deploymentID, err)
+	}
+
+	live := map[string]client.Object{}
+	for i := range agents.Items {
+		live["Agent/"+agents.Items[i].Name] = &agents.Items[i]
+	}
+	for i := range remotes.Items {
+		live["RemoteMCPServer/"+remotes.Items[i].Name] = &remotes.Items[i]
+	}
+	for i := range servers.Items {
+		live["MCPServer/"+servers.Items[i].Name] = &servers.Items[i]
+	}
+
+	var want []string
+	var missing, drift, unhealthy []string
+	check := func(kind string, desired client.Object, compare func(live client.Object)) {
+		key := kind + "/" + desired.GetName()
+		want = append(want, key)
+		obj, ok := live[key]
+		if !ok {
+			missing = append(missing, key)
+			return
+		}
+		compare(obj)
+	}
+	for _, agent := range cfg.Agents {
+		check("Agent", agent, func(obj client.Object) {
+			if reason := kubernetesNotReadyReason(obj.(*v1alpha2.Agent).Status.Conditions); reason != "" {
+				unhealthy = append(unhealthy, fmt.Sprintf("Agent/%s is not ready: %s", agent.Name, reason))
+			}
+		})
+	}
+	for _, remote := range cfg.RemoteMCPServers {
+		check("RemoteMCPServer", remote, func(obj client.Object) {
+			if got, want := obj.(*v1alpha2.RemoteMCPServer).Spec.URL, remote.Spec.URL; got != want {
+				drift = append(drift, fmt.Sprintf("RemoteMCPServer/%s points at %s, expected %s", remote.Name, got, want))
+			}
+		})
+	}
+	for _, server := range cfg.MCPServers {
+		check("MCPServer", server, func(obj client.Object) {
+			liveServer := obj.(*kmcpv1alpha1.MCPServer)
+			if got, want := liveServer.Spec.Deployment.Image, server.Spec.Deployment.Image; want != "" && got != want {
+				drift = append(drift, fmt.Sprintf("MCPServer/%s runs image %s, expected %s", server.Name, got, want))
+			}
+			if reason := kubernetesNotReadyReason(liveServer.Status.Conditions); reason != "" {
+				unhealthy = append(unhealthy, fmt.Sprintf("MCPServer/%s is not ready: %s", server.Name, reason))
+			}
+		})
+	}
+	for _, key := range slices.Sorted(maps.Keys(live)) {
+		if !slices.Contains(want, key) {
+			drift = append(drift, "unexpected "+key)
+		}
+	}
+
+	switch {
+	case len(missing) > 0 && len(missing) == len(want):
+		return &models.DeploymentObservation{
+			Status: models.DeploymentStatusMissing,
+			Reason: "workload not found on platform: " + strings.Join(missing, ", "),
+		}, nil
+	case len(missing) > 0 || len(drift) > 0:
+		for _, key := range missing {
+			drift = append(drift, "missing "+key)
+		}
+		return &models.DeploymentObservation{Status: models.DeploymentStatusDrifted, Reason: strings.Join(drift, "; ")}, nil
+	case len(unhealthy) > 0:
+		return &models.DeploymentObservation{Status: models.DeploymentStatusUnhealthy, Reason: strings.Join(unhealthy, "; ")}, nil
+	}
+	return &models.DeploymentObservation{Status: models.DeploymentStatusDeployed}, nil
+}
+
+// kubernetesNotReadyReason returns the message of a Ready condition that is
+// not true. Workloads that have not reported readiness yet are not flagged.
+func kubernetesNotReadyReason(conditions []metav1.Condition) string {
+	ready := meta.FindStatusCondition(conditions, "Ready")
+	if ready == nil || ready.Status == metav1.ConditionTrue {
+		return ""
+	}
+	if ready.Message != "" {
+		return ready.Message
+	}
+	if ready.Reason != "" {
+		return ready.Reason
+	}
+	return string(ready.Status)
+}
+
+// kubernetesProbeTarget resolves the in-cluster endpoint of the deployment's
+// primary resource. kmcp fronts every MCPServer with a gateway serving /mcp on
+// the server port, and kagent serves agent cards through its controller.
+func kubernetesProbeTarget(cfg *platformtypes.KubernetesPlatformConfig, resourceType, namespace string) (*models.DeploymentProbeTarget, error) {
+	switch resourceType {
+	case "mcp":
+		if len(cfg.RemoteMCPServers) > 0 {
+			return &models.DeploymentProbeTarget{Protocol: models.DeploymentProbeMCP, URL: cfg.RemoteMCPServers[0].Spec.URL}, nil
+		}
+		if len(cfg.MCPServers) > 0 {
+			server := cfg.MCPServers[0]
+			port := server.Spec.Deployment.Port
+			if port == 0 {
+				port = kubernetesDefaultMCPServerPort
+			}
+			return &models.DeploymentProbeTarget{
+				Protocol: models.DeploymentProbeMCP,
+				URL:      fmt.Sprintf("http://%s.%s.svc.cluster.local:%d/mcp", server.Name, cmp.Or(server.Namespace, namespace), port),
+			}, nil
+		}
+	case "agent":
+		if len(cfg.Agents) > 0 {
+			agent := cfg.Agents[0]
+			return &models.DeploymentProbeTarget{
+				Protocol: models.DeploymentProbeA2A,
+				URL:      fmt.Sprintf("%s/%s/%s/.well-known/agent-card.json", kubernetesKagentA2AURL, cmp.Or(agent.Namespace, namespace), agent.Name),
+			}, nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid resource type %q: %w", resourceType, database.ErrInvalidInput)
+	}
+	return nil, fmt.Errorf("no %s resource to probe: %w", resourceType, database.ErrNotFound)
+}
+
 func kubernetesDiscoverDeployments(ctx context.Context, provider *models.Provider) ([]*models.Deployment, error) {
 	providerID := defaultKubernetesProviderID
 	if provider != nil && strings.TrimSpace(provider.ID) != "" {
@@ -875,3 +1193,238 @@ func kubernetesDiscoverDeployments(ctx context.Context, provider *models.Provide
 
 	return discovered, nil
 }
+
+// kubernetesPodLogTarget identifies a single container log stream.
+type kubernetesPodLogTarget struct {
+	namespace string
+	pod       string
+	container string
+}
+
+func kubernetesGetClientset(provider *models.Provider) (k8sclientset.Interface, error) {
+	restConfig, err := kubernetesRESTConfig(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	cs, err := kubernetesNewClientsetForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+	return cs, nil
+}
+
+// kubernetesStreamDeploymentLogs streams container logs for the pods backing the
+// kagent Agent or kmcp MCPServer workloads that belong to a deployment.
+func kubernetesStreamDeploymentLogs(
+	ctx context.Context,
+	provider *models.Provider,
+	deploymentID, resourceType, namespace string,
+	opts models.DeploymentLogOptions,
+	emit func(line string) error,
+) error {
+	if deploymentID == "" {
+		return fmt.Errorf("deployment id is required")
+	}
+	c, err := kubernetesGetClient(provider)
+	if err != nil {
+		return err
+	}
+	pods, err := kubernetesListWorkloadPods(ctx, c, deploymentID, resourceType, namespace)
+	if err != nil {
+		return err
+	}
+	return kubernetesStreamPodsLogs(ctx, provider, deploymentID, pods, opts, emit)
+}
+
+// kubernetesStreamPodsLogs streams the logs of every container in pods,
+// prefixing lines with their source when there is more than one container.
+func kubernetesStreamPodsLogs(
+	ctx context.Context,
+	provider *models.Provider,
+	deploymentID string,
+	pods []corev1.Pod,
+	opts models.DeploymentLogOptions,
+	emit func(line string) error,
+) error {
+	if len(pods) == 0 {
+		return fmt.Errorf("no pods found for deployment %s: %w", deploymentID, database.ErrNotFound)
+	}
+	cs, err := kubernetesGetClientset(provider)
+	if err != nil {
+		return err
+	}
+
+	targets := make([]kubernetesPodLogTarget, 0, len(pods))
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			targets = append(targets, kubernetesPodLogTarget{namespace: pod.Namespace, pod: pod.Name, container: container.Name})
+		}
+	}
+	prefixLines := len(targets) > 1
+
+	if !opts.Follow {
+		for _, target := range targets {
+			if err := kubernetesStreamPodLogs(ctx, cs, target, opts, prefixLines, emit); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Followed streams never end on their own, so read them concurrently and
+	// serialize emission.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	for _, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := kubernetesStreamPodLogs(ctx, cs, target, opts, prefixLines, func(line string) error {
+				mu.Lock()
+				defer mu.Unlock()
+				return emit(line)
+			})
+			if err != nil && ctx.Err() == nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func kubernetesStreamPodLogs(
+	ctx context.Context,
+	cs k8sclientset.Interface,
+	target kubernetesPodLogTarget,
+	opts models.DeploymentLogOptions,
+	prefixLines bool,
+	emit func(line string) error,
+) error {
+	podLogOpts := &corev1.PodLogOptions{
+		Container: target.container,
+		Follow:    opts.Follow,
+	}
+	if opts.TailLines > 0 {
+		tailLines := opts.TailLines
+		podLogOpts.TailLines = &tailLines
+	}
+	if opts.Since != nil && !opts.Since.IsZero() {
+		sinceTime := metav1.NewTime(*opts.Since)
+		podLogOpts.SinceTime = &sinceTime
+	}
+
+	stream, err := cs.CoreV1().Pods(target.namespace).GetLogs(target.pod, podLogOpts).Stream(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to stream logs for pod %s/%s: %w", target.pod, target.container, err)
+	}
+	defer stream.Close()
+
+	prefix := ""
+	if prefixLines {
+		prefix = fmt.Sprintf("[%s/%s] ", target.pod, target.container)
+	}
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if err := emit(prefix + scanner.Text()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to read logs for pod %s/%s: %w", target.pod, target.container, err)
+	}
+	return nil
+}
+
+// kubernetesListWorkloadPods returns the pods created for a deployment's Agent or
+// MCPServer resources. The kagent and kmcp controllers each own an apps/v1
+// Deployment per resource; its selector identifies the pods.
+func kubernetesListWorkloadPods(ctx context.Context, c client.Client, deploymentID, resourceType, namespace string) ([]corev1.Pod, error) {
+	opts := kubernetesDeploymentSelectorOpts(deploymentID, namespace)
+
+	var owners []client.Object
+	var ownerKind string
+	switch resourceType {
+	case "agent":
+		agentList := &v1alpha2.AgentList{}
+		if err := c.List(ctx, agentList, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list agents by deployment id %s: %w", deploymentID, err)
+		}
+		for i := range agentList.Items {
+			owners = append(owners, &agentList.Items[i])
+		}
+		ownerKind = "Agent"
+	case "mcp":
+		mcpList := &kmcpv1alpha1.MCPServerList{}
+		if err := c.List(ctx, mcpList, opts...); err != nil {
+			return nil, fmt.Errorf("failed to list mcp servers by deployment id %s: %w", deploymentID, err)
+		}
+		for i := range mcpList.Items {
+			owners = append(owners, &mcpList.Items[i])
+		}
+		ownerKind = "MCPServer"
+	default:
+		return nil, fmt.Errorf("invalid resource type %q: %w", resourceType, database.ErrInvalidInput)
+	}
+
+	pods := make([]corev1.Pod, 0)
+	for _, owner := range owners {
+		workloads := &appsv1.DeploymentList{}
+		if err := c.List(ctx, workloads, client.InNamespace(owner.GetNamespace())); err != nil {
+			return nil, fmt.Errorf("failed to list workloads for %s %s: %w", ownerKind, owner.GetName(), err)
+		}
+		for i := range workloads.Items {
+			workload := &workloads.Items[i]
+			if !kubernetesIsOwnedBy(workload, ownerKind, owner.GetName()) {
+				continue
+			}
+			workloadPods, err := kubernetesListPodsForWorkload(ctx, c, workload)
+			if err != nil {
+				return nil, err
+			}
+			pods = append(pods, workloadPods...)
+		}
+	}
+	slices.SortFunc(pods, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+	})
+	return pods, nil
+}
+
+// kubernetesListPodsForWorkload returns the pods matched by an apps/v1
+// Deployment's selector.
+func kubernetesListPodsForWorkload(ctx context.Context, c client.Client, workload *appsv1.Deployment) ([]corev1.Pod, error) {
+	if workload.Spec.Selector == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(workload.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on workload %s: %w", workload.Name, err)
+	}
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.InNamespace(workload.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list pods for workload %s: %w", workload.Name, err)
+	}
+	return podList.Items, nil
+}
+
+func kubernetesIsOwnedBy(obj client.Object, kind, name string) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == kind && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
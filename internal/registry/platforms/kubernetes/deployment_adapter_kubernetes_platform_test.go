@@ -3,16 +3,21 @@ package kubernetes
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
 
 	platformtypes "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclientset "k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -123,6 +128,70 @@ func TestKubernetesTranslatePlatformConfig_LocalMCP(t *testing.T) {
 	}
 }
 
+func TestKubernetesTranslatePlatformConfig_SecretEnv(t *testing.T) {
+	ctx := context.Background()
+
+	desired := &platformtypes.DesiredState{
+		Agents: []*platformtypes.Agent{{
+			Name:    "test-agent",
+			Version: "v1",
+			Deployment: platformtypes.AgentDeployment{
+				Image:     "agent-image:latest",
+				Env:       map[string]string{"KAGENT_NAMESPACE": "agents"},
+				SecretEnv: map[string]string{"OPENAI_API_KEY": "sk-agent"},
+			},
+		}},
+		MCPServers: []*platformtypes.MCPServer{{
+			Name:          "local-server",
+			MCPServerType: platformtypes.MCPServerTypeLocal,
+			Namespace:     "tools",
+			Local: &platformtypes.LocalMCPServer{
+				TransportType: platformtypes.TransportTypeStdio,
+				Deployment: platformtypes.MCPServerDeployment{
+					Image:     "mcp-image:latest",
+					SecretEnv: map[string]string{"GITHUB_TOKEN": "ghp-server"},
+				},
+			},
+		}},
+	}
+
+	config, err := kubernetesTranslatePlatformConfig(ctx, desired)
+	if err != nil {
+		t.Fatalf("kubernetesTranslatePlatformConfig failed: %v", err)
+	}
+	if len(config.Secrets) != 2 {
+		t.Fatalf("expected 2 Secrets, got %d", len(config.Secrets))
+	}
+	agentSecret, serverSecret := config.Secrets[0], config.Secrets[1]
+	if agentSecret.Namespace != "agents" || string(agentSecret.Data["OPENAI_API_KEY"]) != "sk-agent" {
+		t.Errorf("unexpected agent secret %s/%s: %v", agentSecret.Namespace, agentSecret.Name, agentSecret.Data)
+	}
+	if serverSecret.Namespace != "tools" || string(serverSecret.Data["GITHUB_TOKEN"]) != "ghp-server" {
+		t.Errorf("unexpected server secret %s/%s: %v", serverSecret.Namespace, serverSecret.Name, serverSecret.Data)
+	}
+
+	var secretEnvVar *corev1.EnvVar
+	for i, envVar := range config.Agents[0].Spec.BYO.Deployment.Env {
+		if envVar.Name == "OPENAI_API_KEY" {
+			secretEnvVar = &config.Agents[0].Spec.BYO.Deployment.Env[i]
+		}
+	}
+	if secretEnvVar == nil || secretEnvVar.Value != "" || secretEnvVar.ValueFrom == nil || secretEnvVar.ValueFrom.SecretKeyRef == nil {
+		t.Fatalf("expected OPENAI_API_KEY to reference a secret, got %+v", secretEnvVar)
+	}
+	if got := secretEnvVar.ValueFrom.SecretKeyRef.Name; got != agentSecret.Name {
+		t.Errorf("agent env references secret %s, want %s", got, agentSecret.Name)
+	}
+
+	server := config.MCPServers[0]
+	if _, ok := server.Spec.Deployment.Env["GITHUB_TOKEN"]; ok {
+		t.Error("secret value inlined in MCPServer env")
+	}
+	if refs := server.Spec.Deployment.SecretRefs; len(refs) != 1 || refs[0].Name != serverSecret.Name {
+		t.Errorf("MCPServer secretRefs = %+v, want %s", refs, serverSecret.Name)
+	}
+}
+
 func TestKubernetesTranslatePlatformConfig_AgentWithMCPServers(t *testing.T) {
 	ctx := context.Background()
 
@@ -568,6 +637,13 @@ func TestKubernetesDeleteAgentResourcesByDeploymentID_RemovesResolvedMCPResource
 				Labels:    map[string]string{kubernetesDeploymentIDLabelKey: deploymentID},
 			},
 		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "demo-agent-env",
+				Namespace: namespace,
+				Labels:    map[string]string{kubernetesDeploymentIDLabelKey: deploymentID},
+			},
+		},
 	).Build()
 
 	err := kubernetesDeleteAgentResourcesByDeploymentID(context.Background(), fakeClient, deploymentID, namespace)
@@ -587,6 +663,115 @@ func TestKubernetesDeleteAgentResourcesByDeploymentID_RemovesResolvedMCPResource
 	assertResourceDeleted(&corev1.ConfigMap{}, "demo-config")
 	assertResourceDeleted(&v1alpha2.RemoteMCPServer{}, "demo-remote-mcp")
 	assertResourceDeleted(&kmcpv1alpha1.MCPServer{}, "demo-local-mcp")
+	assertResourceDeleted(&corev1.Secret{}, "demo-agent-env")
+}
+
+func TestKubernetesPruneDeploymentResources_RemovesObjectsMissingFromConfig(t *testing.T) {
+	const (
+		namespace    = "demo-ns"
+		deploymentID = "dep-agent-123"
+	)
+	labels := map[string]string{kubernetesDeploymentIDLabelKey: deploymentID}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+		&v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "demo-agent-1-0-0", Namespace: namespace, Labels: labels}},
+		&v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "demo-agent-2-0-0", Namespace: namespace, Labels: labels}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "demo-agent-1-0-0-config", Namespace: namespace, Labels: labels}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "demo-agent-1-0-0-env", Namespace: namespace, Labels: labels}},
+		&v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-agent",
+			Namespace: namespace,
+			Labels:    map[string]string{kubernetesDeploymentIDLabelKey: "dep-other"},
+		}},
+	).Build()
+
+	cfg := &platformtypes.KubernetesPlatformConfig{
+		Agents: []*v1alpha2.Agent{{ObjectMeta: metav1.ObjectMeta{Name: "demo-agent-2-0-0", Namespace: namespace}}},
+	}
+	if err := kubernetesPruneDeploymentResources(context.Background(), fakeClient, cfg, deploymentID, namespace); err != nil {
+		t.Fatalf("kubernetesPruneDeploymentResources() error = %v", err)
+	}
+
+	exists := func(obj client.Object, name string) bool {
+		t.Helper()
+		return fakeClient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, obj) == nil
+	}
+	if exists(&v1alpha2.Agent{}, "demo-agent-1-0-0") {
+		t.Fatal("expected previous agent to be pruned")
+	}
+	if exists(&corev1.ConfigMap{}, "demo-agent-1-0-0-config") {
+		t.Fatal("expected previous configmap to be pruned")
+	}
+	if exists(&corev1.Secret{}, "demo-agent-1-0-0-env") {
+		t.Fatal("expected previous secret to be pruned")
+	}
+	if !exists(&v1alpha2.Agent{}, "demo-agent-2-0-0") {
+		t.Fatal("expected current agent to be kept")
+	}
+	if !exists(&v1alpha2.Agent{}, "other-agent") {
+		t.Fatal("expected other deployment's agent to be kept")
+	}
+}
+
+func TestKubernetesObserveDeployment_ClassifiesLiveState(t *testing.T) {
+	const (
+		namespace    = "demo-ns"
+		deploymentID = "dep-mcp-123"
+	)
+	labels := map[string]string{kubernetesDeploymentIDLabelKey: deploymentID}
+	desired := &platformtypes.KubernetesPlatformConfig{
+		MCPServers: []*kmcpv1alpha1.MCPServer{{
+			ObjectMeta: metav1.ObjectMeta{Name: "demo-server", Namespace: namespace},
+			Spec:       kmcpv1alpha1.MCPServerSpec{Deployment: kmcpv1alpha1.MCPServerDeployment{Image: "demo:2.0.0"}},
+		}},
+	}
+	liveServer := func(image string, ready metav1.ConditionStatus) *kmcpv1alpha1.MCPServer {
+		server := &kmcpv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "demo-server", Namespace: namespace, Labels: labels},
+			Spec:       kmcpv1alpha1.MCPServerSpec{Deployment: kmcpv1alpha1.MCPServerDeployment{Image: image}},
+		}
+		if ready != "" {
+			server.Status.Conditions = []metav1.Condition{{Type: "Ready", Status: ready, Message: "pod crash looping"}}
+		}
+		return server
+	}
+
+	tests := []struct {
+		name       string
+		objects    []client.Object
+		wantStatus string
+		wantReason string
+	}{
+		{name: "matching", objects: []client.Object{liveServer("demo:2.0.0", metav1.ConditionTrue)}, wantStatus: models.DeploymentStatusDeployed},
+		{name: "not reported yet", objects: []client.Object{liveServer("demo:2.0.0", "")}, wantStatus: models.DeploymentStatusDeployed},
+		{name: "missing", wantStatus: models.DeploymentStatusMissing, wantReason: "MCPServer/demo-server"},
+		{name: "image changed", objects: []client.Object{liveServer("demo:1.0.0", metav1.ConditionTrue)}, wantStatus: models.DeploymentStatusDrifted, wantReason: "demo:1.0.0"},
+		{
+			name: "unexpected object",
+			objects: []client.Object{
+				liveServer("demo:2.0.0", metav1.ConditionTrue),
+				&v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{Name: "leftover", Namespace: namespace, Labels: labels}},
+			},
+			wantStatus: models.DeploymentStatusDrifted,
+			wantReason: "unexpected Agent/leftover",
+		},
+		{name: "not ready", objects: []client.Object{liveServer("demo:2.0.0", metav1.ConditionFalse)}, wantStatus: models.DeploymentStatusUnhealthy, wantReason: "pod crash looping"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(tt.objects...).Build()
+			got, err := kubernetesObserveDeployment(context.Background(), fakeClient, desired, deploymentID, namespace)
+			if err != nil {
+				t.Fatalf("kubernetesObserveDeployment() error = %v", err)
+			}
+			if got.Status != tt.wantStatus {
+				t.Fatalf("status = %s (%s), want %s", got.Status, got.Reason, tt.wantStatus)
+			}
+			if !strings.Contains(got.Reason, tt.wantReason) {
+				t.Fatalf("reason = %q, want it to contain %q", got.Reason, tt.wantReason)
+			}
+		})
+	}
 }
 
 func TestKubernetesDiscoverDeployments_RecordsNamespaceInProviderMetadata(t *testing.T) {
@@ -636,6 +821,75 @@ func TestKubernetesDiscoverDeployments_RecordsNamespaceInProviderMetadata(t *tes
 	}
 }
 
+func TestKubernetesStreamDeploymentLogs_ReadsPodsOwnedByAgentWorkload(t *testing.T) {
+	const deploymentID = "dep-logs-123"
+	agentName := kubernetesAgentResourceName("logs-agent", "v1", deploymentID)
+	podLabels := map[string]string{"app.kubernetes.io/name": agentName}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+		&v1alpha2.Agent{ObjectMeta: metav1.ObjectMeta{
+			Name:      agentName,
+			Namespace: "team-a",
+			Labels:    kubernetesDeploymentManagedLabels(deploymentID),
+		}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            agentName,
+				Namespace:       "team-a",
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: v1alpha2.GroupVersion.String(), Kind: "Agent", Name: agentName}},
+			},
+			Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: podLabels}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: agentName + "-abc", Namespace: "team-a", Labels: podLabels},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "agent"}}},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "team-a", Labels: map[string]string{"app": "other"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "other"}}},
+		},
+	).Build()
+
+	originalAmbientRESTConfig := kubernetesGetAmbientRESTConfig
+	originalNewClientForConfig := kubernetesNewClientForConfig
+	originalNewClientsetForConfig := kubernetesNewClientsetForConfig
+	t.Cleanup(func() {
+		kubernetesGetAmbientRESTConfig = originalAmbientRESTConfig
+		kubernetesNewClientForConfig = originalNewClientForConfig
+		kubernetesNewClientsetForConfig = originalNewClientsetForConfig
+	})
+
+	kubernetesGetAmbientRESTConfig = func() (*rest.Config, error) {
+		return &rest.Config{Host: "https://example.test"}, nil
+	}
+	kubernetesNewClientForConfig = func(*rest.Config) (client.Client, error) {
+		return fakeClient, nil
+	}
+	kubernetesNewClientsetForConfig = func(*rest.Config) (k8sclientset.Interface, error) {
+		return k8sfake.NewClientset(), nil
+	}
+
+	var lines []string
+	err := kubernetesStreamDeploymentLogs(context.Background(), &models.Provider{ID: "kubernetes-default", Platform: "kubernetes"},
+		deploymentID, "agent", "team-a", models.DeploymentLogOptions{TailLines: 10}, func(line string) error {
+			lines = append(lines, line)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("kubernetesStreamDeploymentLogs() error = %v", err)
+	}
+	// The fake clientset returns a fixed body for every pod log request.
+	if len(lines) != 1 || lines[0] != "fake logs" {
+		t.Fatalf("expected logs from the single owned pod, got %#v", lines)
+	}
+
+	err = kubernetesStreamDeploymentLogs(context.Background(), &models.Provider{ID: "kubernetes-default", Platform: "kubernetes"},
+		"dep-missing", "agent", "team-a", models.DeploymentLogOptions{}, func(string) error { return nil })
+	if !errors.Is(err, database.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for deployment without pods, got %v", err)
+	}
+}
+
 func testKubernetesProviderKubeconfig(contextHosts map[string]string, currentContext string) string {
 	clusters := make([]string, 0, len(contextHosts))
 	contexts := make([]string, 0, len(contextHosts))
@@ -704,3 +958,68 @@ func TestKubernetesDeploymentScopedName_TruncatesLongBaseButPreservesSuffix(t *t
 		t.Fatalf("expected uuid short suffix to be preserved, got %s", got)
 	}
 }
+
+func TestKubernetesProbeTarget_ResolvesInClusterEndpoints(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          *platformtypes.KubernetesPlatformConfig
+		resourceType string
+		wantProtocol string
+		wantURL      string
+	}{
+		{
+			name: "remote MCP server",
+			cfg: &platformtypes.KubernetesPlatformConfig{
+				RemoteMCPServers: []*v1alpha2.RemoteMCPServer{{Spec: v1alpha2.RemoteMCPServerSpec{URL: "https://mcp.example.com/mcp"}}},
+			},
+			resourceType: "mcp",
+			wantProtocol: models.DeploymentProbeMCP,
+			wantURL:      "https://mcp.example.com/mcp",
+		},
+		{
+			name: "kmcp server on default port",
+			cfg: &platformtypes.KubernetesPlatformConfig{
+				MCPServers: []*kmcpv1alpha1.MCPServer{{ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "tools"}}},
+			},
+			resourceType: "mcp",
+			wantProtocol: models.DeploymentProbeMCP,
+			wantURL:      "http://weather.tools.svc.cluster.local:3000/mcp",
+		},
+		{
+			name: "kmcp server on custom port in deployment namespace",
+			cfg: &platformtypes.KubernetesPlatformConfig{
+				MCPServers: []*kmcpv1alpha1.MCPServer{{
+					ObjectMeta: metav1.ObjectMeta{Name: "weather"},
+					Spec:       kmcpv1alpha1.MCPServerSpec{Deployment: kmcpv1alpha1.MCPServerDeployment{Port: 8080}},
+				}},
+			},
+			resourceType: "mcp",
+			wantProtocol: models.DeploymentProbeMCP,
+			wantURL:      "http://weather.demo-ns.svc.cluster.local:8080/mcp",
+		},
+		{
+			name: "agent",
+			cfg: &platformtypes.KubernetesPlatformConfig{
+				Agents: []*v1alpha2.Agent{{ObjectMeta: metav1.ObjectMeta{Name: "planner", Namespace: "agents"}}},
+			},
+			resourceType: "agent",
+			wantProtocol: models.DeploymentProbeA2A,
+			wantURL:      kubernetesKagentA2AURL + "/agents/planner/.well-known/agent-card.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, err := kubernetesProbeTarget(tt.cfg, tt.resourceType, "demo-ns")
+			if err != nil {
+				t.Fatalf("kubernetesProbeTarget() error = %v", err)
+			}
+			if target.Protocol != tt.wantProtocol || target.URL != tt.wantURL {
+				t.Fatalf("kubernetesProbeTarget() = %+v, want %s %s", target, tt.wantProtocol, tt.wantURL)
+			}
+		})
+	}
+
+	if _, err := kubernetesProbeTarget(&platformtypes.KubernetesPlatformConfig{}, "agent", "demo-ns"); !errors.Is(err, database.ErrNotFound) {
+		t.Fatalf("kubernetesProbeTarget() without agent error = %v, want ErrNotFound", err)
+	}
+}
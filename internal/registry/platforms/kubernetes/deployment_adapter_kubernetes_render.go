@@ -0,0 +1,118 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"golang.org/x/mod/semver"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// kubernetesRenderedObject is one object serialized for a render.
+type kubernetesRenderedObject struct {
+	fileName string
+	content  string
+}
+
+// kubernetesRenderObjects packages objects in format: a single multi-document
+// manifests.yaml, a kustomization, or a Helm chart named after the deployed
+// resource. Objects keep the namespace they were translated for.
+func kubernetesRenderObjects(platform, format, name, version string, objects []client.Object) (*models.DeploymentRender, error) {
+	rendered := make([]kubernetesRenderedObject, 0, len(objects))
+	for _, obj := range objects {
+		content, err := kubernetesRenderObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+		rendered = append(rendered, kubernetesRenderedObject{
+			fileName: fmt.Sprintf("%s-%s.yaml", kind, obj.GetName()),
+			content:  content,
+		})
+	}
+
+	result := &models.DeploymentRender{Platform: platform, Format: format}
+	switch format {
+	case models.DeploymentRenderFormatYAML:
+		docs := make([]string, 0, len(rendered))
+		for _, obj := range rendered {
+			docs = append(docs, obj.content)
+		}
+		result.Files = []models.RenderedFile{{Path: "manifests.yaml", Content: strings.Join(docs, "---\n")}}
+	case models.DeploymentRenderFormatKustomize:
+		resources := make([]string, 0, len(rendered))
+		for _, obj := range rendered {
+			resources = append(resources, obj.fileName)
+			result.Files = append(result.Files, models.RenderedFile{Path: obj.fileName, Content: obj.content})
+		}
+		kustomization, err := yaml.Marshal(map[string]any{
+			"apiVersion": "kustomize.config.k8s.io/v1beta1",
+			"kind":       "Kustomization",
+			"resources":  resources,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("marshal kustomization: %w", err)
+		}
+		result.Files = append([]models.RenderedFile{{Path: "kustomization.yaml", Content: string(kustomization)}}, result.Files...)
+	case models.DeploymentRenderFormatHelm:
+		chart, err := yaml.Marshal(map[string]any{
+			"apiVersion":  "v2",
+			"name":        sanitizeKubernetesName(name),
+			"description": fmt.Sprintf("%s rendered by agentregistry", name),
+			"type":        "application",
+			"version":     kubernetesRenderChartVersion(version),
+			"appVersion":  version,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("marshal Chart.yaml: %w", err)
+		}
+		result.Files = append(result.Files, models.RenderedFile{Path: "Chart.yaml", Content: string(chart)})
+		for _, obj := range rendered {
+			result.Files = append(result.Files, models.RenderedFile{
+				Path:    "templates/" + obj.fileName,
+				Content: kubernetesEscapeHelmTemplate(obj.content),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("render format %q is not supported on %s: %w", format, platform, database.ErrInvalidInput)
+	}
+	return result, nil
+}
+
+// kubernetesRenderObject serializes obj as it would be applied, without the
+// empty status and creation timestamps of an unsubmitted object.
+func kubernetesRenderObject(obj client.Object) (string, error) {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	raw, err := k8sruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert %s %s to unstructured: %w", kind, obj.GetName(), err)
+	}
+	delete(raw, "status")
+	unstructured.RemoveNestedField(raw, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(raw, "spec", "template", "metadata", "creationTimestamp")
+	out, err := yaml.Marshal(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal %s %s: %w", kind, obj.GetName(), err)
+	}
+	return string(out), nil
+}
+
+// kubernetesRenderChartVersion returns version when it is full SemVer, as Helm
+// requires of chart versions, and 0.1.0 otherwise.
+func kubernetesRenderChartVersion(version string) string {
+	if v := "v" + version; semver.IsValid(v) && semver.Canonical(v) == v {
+		return version
+	}
+	return "0.1.0"
+}
+
+// kubernetesEscapeHelmTemplate keeps Helm from evaluating template delimiters
+// that are part of the rendered content, such as prompt placeholders.
+func kubernetesEscapeHelmTemplate(content string) string {
+	return strings.ReplaceAll(content, "{{", `{{ "{{" }}`)
+}
@@ -0,0 +1,194 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"sigs.k8s.io/yaml"
+)
+
+func newRenderTestRegistry(t *testing.T) *servicetesting.FakeRegistry {
+	t.Helper()
+	registry := servicetesting.NewFakeRegistry()
+	registry.GetProviderByIDFn = func(_ context.Context, providerID string) (*models.Provider, error) {
+		return &models.Provider{ID: providerID, Platform: "kubernetes"}, nil
+	}
+	registry.GetAgentByNameAndVersionFn = func(_ context.Context, name, version string) (*models.AgentResponse, error) {
+		return &models.AgentResponse{Agent: models.AgentJSON{
+			AgentManifest: models.AgentManifest{Name: name, Image: "agent-image:latest", ModelProvider: "openai"},
+			Version:       version,
+		}}, nil
+	}
+	registry.ResolveSecretRefsFn = func(context.Context, map[string]string) (map[string]string, error) {
+		t.Fatal("render must not resolve secret values")
+		return nil, nil
+	}
+	return registry
+}
+
+func renderTestDeployment() *models.Deployment {
+	return &models.Deployment{
+		ServerName:   "planner",
+		Version:      "1.2.0",
+		ResourceType: "agent",
+		ProviderID:   "kubernetes-default",
+		Env:          map[string]string{"KAGENT_NAMESPACE": "agents", "LOG_LEVEL": "debug"},
+		SecretRefs:   map[string]string{"OPENAI_API_KEY": "openai-key"},
+	}
+}
+
+func TestKubernetesRender_YAMLListsSecretKeysWithoutValues(t *testing.T) {
+	adapter := NewKubernetesDeploymentAdapter(newRenderTestRegistry(t))
+
+	render, err := adapter.Render(context.Background(), renderTestDeployment(), models.DeploymentRenderFormatYAML)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if render.Platform != "kubernetes" || len(render.Files) != 1 || render.Files[0].Path != "manifests.yaml" {
+		t.Fatalf("unexpected render %+v", render)
+	}
+
+	docs := strings.Split(render.Files[0].Content, "---\n")
+	if len(docs) != 2 {
+		t.Fatalf("expected a Secret and an Agent, got %d documents:\n%s", len(docs), render.Files[0].Content)
+	}
+	var secret, agent map[string]any
+	if err := yaml.Unmarshal([]byte(docs[0]), &secret); err != nil {
+		t.Fatalf("unmarshal Secret: %v", err)
+	}
+	if err := yaml.Unmarshal([]byte(docs[1]), &agent); err != nil {
+		t.Fatalf("unmarshal Agent: %v", err)
+	}
+	if secret["kind"] != "Secret" || agent["kind"] != "Agent" {
+		t.Fatalf("unexpected kinds %v, %v", secret["kind"], agent["kind"])
+	}
+	data, _ := secret["data"].(map[string]any)
+	if value, ok := data["OPENAI_API_KEY"]; !ok || value != "" {
+		t.Errorf("Secret data = %v, want OPENAI_API_KEY with an empty value", data)
+	}
+
+	metadata, _ := agent["metadata"].(map[string]any)
+	if metadata["name"] != "planner-1-2-0" || metadata["namespace"] != "agents" {
+		t.Errorf("Agent metadata = %v, want unsuffixed name in namespace agents", metadata)
+	}
+	if labels, _ := metadata["labels"].(map[string]any); labels[kubernetesDeploymentIDLabelKey] != nil {
+		t.Errorf("rendered Agent has deployment ID label: %v", labels)
+	}
+	if _, ok := metadata["creationTimestamp"]; ok {
+		t.Error("rendered Agent has creationTimestamp")
+	}
+	if _, ok := agent["status"]; ok {
+		t.Error("rendered Agent has status")
+	}
+}
+
+func TestKubernetesRender_PackagesHelmChartAndKustomization(t *testing.T) {
+	adapter := NewKubernetesDeploymentAdapter(newRenderTestRegistry(t))
+
+	helm, err := adapter.Render(context.Background(), renderTestDeployment(), models.DeploymentRenderFormatHelm)
+	if err != nil {
+		t.Fatalf("Render(helm) error = %v", err)
+	}
+	paths := renderedPaths(helm)
+	want := []string{"Chart.yaml", "templates/secret-planner-1-2-0-env.yaml", "templates/agent-planner-1-2-0.yaml"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Fatalf("helm files = %v, want %v", paths, want)
+	}
+	var chart map[string]any
+	if err := yaml.Unmarshal([]byte(helm.Files[0].Content), &chart); err != nil {
+		t.Fatalf("unmarshal Chart.yaml: %v", err)
+	}
+	if chart["apiVersion"] != "v2" || chart["name"] != "planner" || chart["version"] != "1.2.0" {
+		t.Errorf("unexpected Chart.yaml %v", chart)
+	}
+
+	kustomize, err := adapter.Render(context.Background(), renderTestDeployment(), models.DeploymentRenderFormatKustomize)
+	if err != nil {
+		t.Fatalf("Render(kustomize) error = %v", err)
+	}
+	var kustomization struct {
+		Kind      string   `json:"kind"`
+		Resources []string `json:"resources"`
+	}
+	if err := yaml.Unmarshal([]byte(kustomize.Files[0].Content), &kustomization); err != nil {
+		t.Fatalf("unmarshal kustomization.yaml: %v", err)
+	}
+	if kustomize.Files[0].Path != "kustomization.yaml" || kustomization.Kind != "Kustomization" {
+		t.Fatalf("unexpected kustomization %+v", kustomize.Files[0])
+	}
+	if got := strings.Join(kustomization.Resources, ","); got != "secret-planner-1-2-0-env.yaml,agent-planner-1-2-0.yaml" {
+		t.Errorf("kustomization resources = %s", got)
+	}
+}
+
+func TestKubernetesEscapeHelmTemplate(t *testing.T) {
+	got := kubernetesEscapeHelmTemplate("prompt: Hello {{name}}\n")
+	if want := "prompt: Hello {{ \"{{\" }}name}}\n"; got != want {
+		t.Errorf("kubernetesEscapeHelmTemplate() = %q, want %q", got, want)
+	}
+}
+
+func TestKubernetesRenderChartVersion(t *testing.T) {
+	for version, want := range map[string]string{
+		"1.2.0":        "1.2.0",
+		"1.2.0-beta.1": "1.2.0-beta.1",
+		"1.2":          "0.1.0",
+		"latest":       "0.1.0",
+		"v1.2.0":       "0.1.0",
+	} {
+		if got := kubernetesRenderChartVersion(version); got != want {
+			t.Errorf("kubernetesRenderChartVersion(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
+
+func TestKubernetesNativeRender_SelectsPodsWithoutDeploymentID(t *testing.T) {
+	registry := newRenderTestRegistry(t)
+	adapter := NewKubernetesNativeDeploymentAdapter(registry)
+
+	render, err := adapter.Render(context.Background(), renderTestDeployment(), models.DeploymentRenderFormatYAML)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	var workload map[string]any
+	for _, doc := range strings.Split(render.Files[0].Content, "---\n") {
+		var obj map[string]any
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			t.Fatalf("unmarshal document: %v", err)
+		}
+		if obj["kind"] == "Deployment" {
+			workload = obj
+		}
+	}
+	if workload == nil {
+		t.Fatalf("no Deployment rendered:\n%s", render.Files[0].Content)
+	}
+	spec, _ := workload["spec"].(map[string]any)
+	selector, _ := spec["selector"].(map[string]any)
+	matchLabels, _ := selector["matchLabels"].(map[string]any)
+	template, _ := spec["template"].(map[string]any)
+	templateMetadata, _ := template["metadata"].(map[string]any)
+	podLabels, _ := templateMetadata["labels"].(map[string]any)
+	for key, value := range matchLabels {
+		if podLabels[key] != value {
+			t.Errorf("selector %s=%v does not match pod labels %v", key, value, podLabels)
+		}
+	}
+
+	if _, err := adapter.Render(context.Background(), renderTestDeployment(), "jsonnet"); !errors.Is(err, database.ErrInvalidInput) {
+		t.Fatalf("Render(jsonnet) error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func renderedPaths(render *models.DeploymentRender) []string {
+	paths := make([]string, 0, len(render.Files))
+	for _, file := range render.Files {
+		paths = append(paths, file.Path)
+	}
+	return paths
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"maps"
+	"net"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
@@ -18,7 +22,9 @@ import (
 type localDeploymentAdapter struct {
 	registry         service.RegistryService
 	platformDir      string
+	agentGatewayHost string
 	agentGatewayPort uint16
+	inFlight         utils.InFlightDeployments
 }
 
 // localAgentConfig groups the agent-specific configuration produced during
@@ -33,6 +39,9 @@ type localAgentConfig struct {
 var (
 	runLocalComposeUp              = ComposeUpLocalPlatform
 	runLocalComposeDown            = ComposeDownLocalPlatform
+	runLocalComposeLogs            = ComposeLogsLocalPlatform
+	listLocalContainers            = listLabelledLocalContainers
+	listLocalServiceContainers     = listLocalProjectContainers
 	refreshLocalAgentMCPConfig     = common.RefreshMCPConfig
 	refreshLocalAgentPromptsConfig = common.RefreshPromptsConfig
 )
@@ -70,11 +79,13 @@ func (c *localAgentConfig) cleanup() error {
 func NewLocalDeploymentAdapter(
 	registry service.RegistryService,
 	platformDir string,
+	agentGatewayHost string,
 	agentGatewayPort uint16,
 ) *localDeploymentAdapter {
 	return &localDeploymentAdapter{
 		registry:         registry,
 		platformDir:      platformDir,
+		agentGatewayHost: agentGatewayHost,
 		agentGatewayPort: agentGatewayPort,
 	}
 }
@@ -90,22 +101,89 @@ func (a *localDeploymentAdapter) Deploy(ctx context.Context, req *models.Deploym
 		return nil, err
 	}
 
+	ctx, release := a.inFlight.Track(ctx, req.ID)
+	defer release()
+
 	translated, agentCfg, err := a.translateLocalDeployment(ctx, req)
 	if err != nil {
-		return nil, err
+		return a.handleLocalDeployError(ctx, req, err)
 	}
 
-	if err := a.mergeAndApplyLocalPlatform(ctx, translated, false); err != nil {
-		return nil, err
+	if err := a.mergeAndApplyLocalPlatform(ctx, translated, false, ""); err != nil {
+		return a.handleLocalDeployError(ctx, req, err)
 	}
 
 	if err := agentCfg.apply(); err != nil {
+		return a.handleLocalDeployError(ctx, req, err)
+	}
+
+	if utils.IsDeploymentCancelled(ctx) {
+		return a.rollbackCancelledLocalDeployment(ctx, req)
+	}
+	return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+}
+
+// Update rolls a deployment to a new configuration in place. The deployment's
+// compose services and gateway routes are replaced in a single write, so
+// `docker compose up` only recreates the containers whose configuration changed.
+func (a *localDeploymentAdapter) Update(ctx context.Context, previous, desired *models.Deployment) (*models.DeploymentActionResult, error) {
+	if err := utils.ValidateDeploymentRequest(desired, false); err != nil {
 		return nil, err
 	}
 
+	ctx, release := a.inFlight.Track(ctx, desired.ID)
+	defer release()
+
+	translated, agentCfg, err := a.translateLocalDeployment(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	// Agent config files are written first so a recreated agent container
+	// starts with the new version's MCP servers and prompts.
+	if err := agentCfg.apply(); err != nil {
+		return nil, err
+	}
+	if err := a.mergeAndApplyLocalPlatform(ctx, translated, false, desired.ID); err != nil {
+		return nil, err
+	}
+	if previous != nil && previous.Version != desired.Version {
+		if err := localFallbackAgentConfig(a.platformDir, previous).cleanup(); err != nil {
+			log.Printf("Warning: failed to remove agent config of previous version %s for deployment %s: %v", previous.Version, desired.ID, err)
+		}
+	}
 	return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
 }
 
+func (a *localDeploymentAdapter) handleLocalDeployError(
+	ctx context.Context,
+	deployment *models.Deployment,
+	deployErr error,
+) (*models.DeploymentActionResult, error) {
+	if utils.IsDeploymentCancelled(ctx) {
+		return a.rollbackCancelledLocalDeployment(ctx, deployment)
+	}
+	return nil, deployErr
+}
+
+// rollbackCancelledLocalDeployment removes the compose services, gateway routes, and
+// agent config files that a cancelled Deploy may already have written.
+func (a *localDeploymentAdapter) rollbackCancelledLocalDeployment(
+	ctx context.Context,
+	deployment *models.Deployment,
+) (*models.DeploymentActionResult, error) {
+	if err := a.rollbackLocalDeployment(context.WithoutCancel(ctx), deployment); err != nil {
+		return utils.CancelledDeploymentResult(), fmt.Errorf("%w: rollback failed: %v", utils.ErrDeploymentCancelled, err)
+	}
+	return utils.CancelledDeploymentResult(), utils.ErrDeploymentCancelled
+}
+
+func (a *localDeploymentAdapter) rollbackLocalDeployment(ctx context.Context, deployment *models.Deployment) error {
+	if err := a.removeLocalDeploymentArtifactsByID(ctx, deployment.ID); err != nil {
+		return err
+	}
+	return localFallbackAgentConfig(a.platformDir, deployment).cleanup()
+}
+
 func (a *localDeploymentAdapter) Undeploy(ctx context.Context, deployment *models.Deployment) error {
 	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
 		return err
@@ -115,7 +193,7 @@ func (a *localDeploymentAdapter) Undeploy(ctx context.Context, deployment *model
 	if err != nil {
 		return a.handleLocalUndeployTranslationError(ctx, deployment, err)
 	}
-	if err := a.mergeAndApplyLocalPlatform(ctx, translated, true); err != nil {
+	if err := a.mergeAndApplyLocalPlatform(ctx, translated, true, ""); err != nil {
 		return err
 	}
 
@@ -153,16 +231,175 @@ func (a *localDeploymentAdapter) CleanupStale(_ context.Context, _ *models.Deplo
 	return nil
 }
 
-func (a *localDeploymentAdapter) GetLogs(_ context.Context, _ *models.Deployment) ([]string, error) {
-	return nil, utils.ErrDeploymentNotSupported
+func (a *localDeploymentAdapter) GetLogs(ctx context.Context, deployment *models.Deployment) ([]string, error) {
+	logs := make([]string, 0)
+	if err := a.StreamLogs(ctx, deployment, models.DeploymentLogOptions{}, func(line string) error {
+		logs = append(logs, line)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return logs, nil
 }
 
-func (a *localDeploymentAdapter) Cancel(_ context.Context, _ *models.Deployment) error {
-	return utils.ErrDeploymentNotSupported
+// StreamLogs streams `docker compose logs` output for the compose service backing the deployment.
+func (a *localDeploymentAdapter) StreamLogs(
+	ctx context.Context,
+	deployment *models.Deployment,
+	opts models.DeploymentLogOptions,
+	emit func(line string) error,
+) error {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return err
+	}
+	serviceName, err := a.localDeploymentServiceName(deployment)
+	if err != nil {
+		return err
+	}
+	return runLocalComposeLogs(ctx, a.platformDir, []string{serviceName}, opts, emit)
 }
 
-func (a *localDeploymentAdapter) Discover(_ context.Context, _ string) ([]*models.Deployment, error) {
-	return []*models.Deployment{}, nil
+// localDeploymentServiceName resolves the compose service that runs the deployment.
+// MCP servers without a dedicated service (npx/uvx stdio servers and remotes) are
+// served by the agent gateway, so its logs are returned instead.
+func (a *localDeploymentAdapter) localDeploymentServiceName(deployment *models.Deployment) (string, error) {
+	composeCfg, err := LoadLocalDockerComposeConfig(a.platformDir)
+	if err != nil {
+		return "", err
+	}
+
+	var serviceName string
+	switch strings.ToLower(strings.TrimSpace(deployment.ResourceType)) {
+	case "mcp":
+		serviceName = localMCPServiceName(&platformtypes.MCPServer{Name: deployment.ServerName, DeploymentID: deployment.ID})
+		if _, ok := composeCfg.Services[serviceName]; !ok {
+			serviceName = localAgentGatewayServiceName
+		}
+	case "agent":
+		serviceName = localAgentServiceName(&platformtypes.Agent{Name: deployment.ServerName, DeploymentID: deployment.ID})
+	default:
+		return "", fmt.Errorf("invalid resource type %q: %w", deployment.ResourceType, database.ErrInvalidInput)
+	}
+
+	if _, ok := composeCfg.Services[serviceName]; !ok {
+		return "", fmt.Errorf("compose service %s for deployment %s: %w", serviceName, deployment.ID, database.ErrNotFound)
+	}
+	return serviceName, nil
+}
+
+// Cancel stops an in-flight Deploy, which then rolls back its partial state.
+// When no Deploy is running in this process it may be running on another
+// replica, so nothing is rolled back and ErrDeploymentNotInFlight is returned.
+func (a *localDeploymentAdapter) Cancel(ctx context.Context, deployment *models.Deployment) error {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return err
+	}
+	if a.inFlight.Cancel(deployment.ID) {
+		return nil
+	}
+	return utils.ErrDeploymentNotInFlight
+}
+
+// Discover reports compose services, agentgateway MCP targets, and labelled
+// containers that are not backed by a managed deployment of this provider.
+func (a *localDeploymentAdapter) Discover(ctx context.Context, providerID string) ([]*models.Deployment, error) {
+	origin := "managed"
+	managed, err := a.registry.GetDeployments(ctx, &models.DeploymentFilter{
+		ProviderID: &providerID,
+		Origin:     &origin,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list managed deployments: %w", err)
+	}
+	managedIDs := make([]string, 0, len(managed))
+	for _, deployment := range managed {
+		if deployment != nil && strings.TrimSpace(deployment.ID) != "" {
+			managedIDs = append(managedIDs, deployment.ID)
+		}
+	}
+
+	containers, err := listLocalContainers(ctx)
+	if err != nil {
+		log.Printf("Warning: failed to list labelled docker containers for discovery: %v", err)
+		containers = nil
+	}
+	return localDiscoverDeployments(a.platformDir, a.agentGatewayPort, providerID, managedIDs, containers)
+}
+
+// Observe compares the deployment's desired compose services and gateway
+// targets with the platform directory and checks that its containers run.
+func (a *localDeploymentAdapter) Observe(ctx context.Context, deployment *models.Deployment) (*models.DeploymentObservation, error) {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return nil, err
+	}
+	translated, _, err := a.translateLocalDeployment(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+	composeCfg, err := LoadLocalDockerComposeConfig(a.platformDir)
+	if err != nil {
+		return nil, err
+	}
+	gatewayCfg, err := LoadLocalAgentGatewayConfig(a.platformDir, a.agentGatewayPort)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := listLocalServiceContainers(ctx, composeCfg.Name)
+	if err != nil {
+		log.Printf("Warning: failed to list docker containers for deployment %s: %v", deployment.ID, err)
+		containers = nil
+	} else if containers == nil {
+		containers = []localContainer{}
+	}
+	return localObserveDeployment(translated, composeCfg, gatewayCfg, containers, deployment.ID), nil
+}
+
+// ProbeTarget points health probes at the deployment's agent gateway route,
+// the same endpoint MCP clients and A2A callers use.
+func (a *localDeploymentAdapter) ProbeTarget(ctx context.Context, deployment *models.Deployment) (*models.DeploymentProbeTarget, error) {
+	if err := utils.ValidateDeploymentRequest(deployment, true); err != nil {
+		return nil, err
+	}
+	gatewayCfg, err := LoadLocalAgentGatewayConfig(a.platformDir, a.agentGatewayPort)
+	if err != nil {
+		return nil, err
+	}
+	host := a.agentGatewayHost
+	if host == "" {
+		host = "localhost"
+	}
+	gatewayURL := "http://" + net.JoinHostPort(host, strconv.Itoa(int(a.agentGatewayPort)))
+	return localProbeTarget(gatewayURL, gatewayCfg, deployment)
+}
+
+// localProbeTarget resolves the gateway endpoint of a deployment. MCP servers
+// share the multiplexed /mcp route, where the gateway prefixes each target's
+// tools with its name once there is more than one target.
+func localProbeTarget(gatewayURL string, gatewayCfg *platformtypes.AgentGatewayConfig, deployment *models.Deployment) (*models.DeploymentProbeTarget, error) {
+	switch strings.ToLower(strings.TrimSpace(deployment.ResourceType)) {
+	case "mcp":
+		targetName := localMCPServiceName(&platformtypes.MCPServer{Name: deployment.ServerName, DeploymentID: deployment.ID})
+		targets := extractMCPRouteTargets(gatewayCfg)
+		if !slices.ContainsFunc(targets, func(t platformtypes.MCPTarget) bool { return t.Name == targetName }) {
+			return nil, fmt.Errorf("agent gateway target %s for deployment %s: %w", targetName, deployment.ID, database.ErrNotFound)
+		}
+		target := &models.DeploymentProbeTarget{
+			Protocol: models.DeploymentProbeMCP,
+			URL:      gatewayURL + "/mcp",
+		}
+		if len(targets) > 1 {
+			target.ToolPrefix = targetName + "_"
+		}
+		return target, nil
+	case "agent":
+		serviceName := localAgentServiceName(&platformtypes.Agent{Name: deployment.ServerName, DeploymentID: deployment.ID})
+		return &models.DeploymentProbeTarget{
+			Protocol: models.DeploymentProbeA2A,
+			URL:      fmt.Sprintf("%s/agents/%s/.well-known/agent-card.json", gatewayURL, serviceName),
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid resource type %q: %w", deployment.ResourceType, database.ErrInvalidInput)
+	}
 }
 
 func (a *localDeploymentAdapter) translateLocalDeployment(
@@ -172,7 +409,7 @@ func (a *localDeploymentAdapter) translateLocalDeployment(
 	if deployment == nil {
 		return nil, nil, nil
 	}
-	desired, agentCfg, err := a.buildLocalDesiredState(ctx, deployment)
+	desired, agentCfg, err := a.buildLocalDesiredState(ctx, a.registry, deployment)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -192,20 +429,47 @@ func (a *localDeploymentAdapter) translateLocalDeployment(
 	return cfg, agentCfg, nil
 }
 
+// Render returns the compose file, gateway config and secret env files that
+// Deploy would merge into the platform directory, holding only this
+// deployment. Env files list their keys with empty values. Agent MCP and
+// prompt config files are not included.
+func (a *localDeploymentAdapter) Render(ctx context.Context, deployment *models.Deployment, format string) (*models.DeploymentRender, error) {
+	if format != models.DeploymentRenderFormatYAML {
+		return nil, fmt.Errorf("render format %q is not supported on %s: %w", format, a.Platform(), database.ErrInvalidInput)
+	}
+	if err := utils.ValidateDeploymentRequest(deployment, false); err != nil {
+		return nil, err
+	}
+	desired, _, err := a.buildLocalDesiredState(ctx, utils.WithoutSecretValues(a.registry), deployment)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := BuildLocalPlatformConfig(ctx, a.platformDir, a.agentGatewayPort, "", desired)
+	if err != nil {
+		return nil, fmt.Errorf("translate local platform config: %w", err)
+	}
+	files, err := renderLocalPlatformFiles(cfg, a.agentGatewayPort)
+	if err != nil {
+		return nil, err
+	}
+	return &models.DeploymentRender{Platform: a.Platform(), Format: format, Files: files}, nil
+}
+
 func (a *localDeploymentAdapter) buildLocalDesiredState(
 	ctx context.Context,
+	registry service.RegistryService,
 	deployment *models.Deployment,
 ) (*platformtypes.DesiredState, *localAgentConfig, error) {
 	resourceType := strings.ToLower(strings.TrimSpace(deployment.ResourceType))
 	switch resourceType {
 	case "mcp":
-		server, err := utils.BuildPlatformMCPServer(ctx, a.registry, deployment, "")
+		server, err := utils.BuildPlatformMCPServer(ctx, registry, deployment, "")
 		if err != nil {
 			return nil, nil, err
 		}
 		return &platformtypes.DesiredState{MCPServers: []*platformtypes.MCPServer{server}}, nil, nil
 	case "agent":
-		resolved, err := utils.ResolveAgent(ctx, a.registry, deployment, "")
+		resolved, err := utils.ResolveAgent(ctx, registry, deployment, "")
 		if err != nil {
 			return nil, nil, err
 		}
@@ -215,7 +479,7 @@ func (a *localDeploymentAdapter) buildLocalDesiredState(
 				AgentName: resolved.Agent.Name,
 				Version:   resolved.Agent.Version,
 			},
-			pythonServers: append(common.PythonServersFromManifest(mustAgentManifest(ctx, a.registry, deployment)), resolved.PythonConfigServers...),
+			pythonServers: append(common.PythonServersFromManifest(mustAgentManifest(ctx, registry, deployment)), resolved.PythonConfigServers...),
 			pythonPrompts: pythonPromptsFromResolved(resolved.ResolvedPrompts),
 		}
 		return &platformtypes.DesiredState{
@@ -227,10 +491,15 @@ func (a *localDeploymentAdapter) buildLocalDesiredState(
 	}
 }
 
+// mergeAndApplyLocalPlatform merges config into the platform files and applies
+// them. When replaceDeploymentID is set, every service and gateway route of that
+// deployment is dropped first so an update leaves nothing of the old
+// configuration behind.
 func (a *localDeploymentAdapter) mergeAndApplyLocalPlatform(
 	ctx context.Context,
 	config *platformtypes.LocalPlatformConfig,
 	remove bool,
+	replaceDeploymentID string,
 ) error {
 	if config == nil {
 		return runLocalComposeUp(ctx, a.platformDir, false)
@@ -249,11 +518,20 @@ func (a *localDeploymentAdapter) mergeAndApplyLocalPlatform(
 	targetNames := extractTargetNames(config.AgentGateway)
 	routeNames := extractNonMCPRouteNames(config.AgentGateway)
 
+	if replaceDeploymentID != "" {
+		serviceNames = append(serviceNames, pruneLocalServicesByDeploymentID(composeCfg, replaceDeploymentID)...)
+		filterGatewayRoutesByDeploymentID(gatewayCfg, replaceDeploymentID)
+	}
 	for _, name := range serviceNames {
 		delete(composeCfg.Services, name)
 	}
+	if err := removeLocalServiceEnvFiles(a.platformDir, serviceNames); err != nil {
+		return err
+	}
+	var envFiles map[string]map[string]string
 	if !remove {
 		maps.Copy(composeCfg.Services, config.DockerCompose.Services)
+		envFiles = config.EnvFiles
 	}
 
 	mergeAgentGatewayConfig(gatewayCfg, config.AgentGateway, targetNames, routeNames, remove, a.agentGatewayPort)
@@ -261,6 +539,7 @@ func (a *localDeploymentAdapter) mergeAndApplyLocalPlatform(
 	if err := WriteLocalPlatformFiles(a.platformDir, &platformtypes.LocalPlatformConfig{
 		DockerCompose: composeCfg,
 		AgentGateway:  gatewayCfg,
+		EnvFiles:      envFiles,
 	}, a.agentGatewayPort); err != nil {
 		return err
 	}
@@ -285,10 +564,9 @@ func (a *localDeploymentAdapter) removeLocalDeploymentArtifactsByID(ctx context.
 		return err
 	}
 
-	for serviceName := range composeCfg.Services {
-		if strings.Contains(serviceName, deploymentID) {
-			delete(composeCfg.Services, serviceName)
-		}
+	removed := pruneLocalServicesByDeploymentID(composeCfg, deploymentID)
+	if err := removeLocalServiceEnvFiles(a.platformDir, removed); err != nil {
+		return err
 	}
 
 	filterGatewayRoutesByDeploymentID(gatewayCfg, deploymentID)
@@ -305,6 +583,19 @@ func (a *localDeploymentAdapter) removeLocalDeploymentArtifactsByID(ctx context.
 	return runLocalComposeUp(ctx, a.platformDir, false)
 }
 
+// pruneLocalServicesByDeploymentID deletes the compose services of a deployment
+// and returns their names.
+func pruneLocalServicesByDeploymentID(composeCfg *platformtypes.DockerComposeConfig, deploymentID string) []string {
+	var removed []string
+	for serviceName := range composeCfg.Services {
+		if strings.Contains(serviceName, deploymentID) {
+			delete(composeCfg.Services, serviceName)
+			removed = append(removed, serviceName)
+		}
+	}
+	return removed
+}
+
 func filterGatewayRoutesByDeploymentID(gatewayCfg *platformtypes.AgentGatewayConfig, deploymentID string) {
 	listener := localAgentGatewayListener(gatewayCfg)
 	if listener == nil {
@@ -1,16 +1,21 @@
 package local
 
 import (
+	"bufio"
 	"bytes"
 	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	platformtypes "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
 	platformutils "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/utils"
@@ -23,13 +28,19 @@ import (
 )
 
 const (
-	localMCPRouteName         = "mcp_route"
-	localComposeFileName      = "docker-compose.yaml"
-	localAgentGatewayFileName = "agent-gateway.yaml"
-	defaultLocalProjectName   = "agentregistry_runtime"
-	localOCIServerPort        = 3000
+	localMCPRouteName            = "mcp_route"
+	localComposeFileName         = "docker-compose.yaml"
+	localAgentGatewayFileName    = "agent-gateway.yaml"
+	localAgentGatewayServiceName = "agent_gateway"
+	defaultLocalProjectName      = "agentregistry_runtime"
+	localOCIServerPort           = 3000
+	localSecretsDirName          = ".secrets"
 )
 
+// localEnvFileValueEscaper escapes values for double-quoted env file entries,
+// which expand escape sequences and ${VAR} references.
+var localEnvFileValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`, "\n", `\n`, "\r", `\r`)
+
 func BuildLocalPlatformConfig(
 	ctx context.Context,
 	platformDir string,
@@ -48,8 +59,9 @@ func BuildLocalPlatformConfig(
 	}
 
 	dockerComposeServices := map[string]composetypes.ServiceConfig{
-		"agent_gateway": *agentGatewayService,
+		localAgentGatewayServiceName: *agentGatewayService,
 	}
+	envFiles := map[string]map[string]string{}
 
 	for _, mcpServer := range desired.MCPServers {
 		if mcpServer.MCPServerType != platformtypes.MCPServerTypeLocal {
@@ -68,6 +80,9 @@ func BuildLocalPlatformConfig(
 			return nil, fmt.Errorf("failed to translate MCPServer %s to service config: %w", mcpServer.Name, err)
 		}
 		dockerComposeServices[serviceName] = *serviceConfig
+		if len(mcpServer.Local.Deployment.SecretEnv) > 0 {
+			envFiles[serviceName] = mcpServer.Local.Deployment.SecretEnv
+		}
 	}
 
 	for _, agent := range desired.Agents {
@@ -81,6 +96,9 @@ func BuildLocalPlatformConfig(
 			return nil, fmt.Errorf("failed to translate Agent %s to service config: %w", agent.Name, err)
 		}
 		dockerComposeServices[serviceName] = *serviceConfig
+		if len(agent.Deployment.SecretEnv) > 0 {
+			envFiles[serviceName] = agent.Deployment.SecretEnv
+		}
 	}
 
 	dockerCompose := &platformtypes.DockerComposeConfig{
@@ -97,6 +115,7 @@ func BuildLocalPlatformConfig(
 	return &platformtypes.LocalPlatformConfig{
 		DockerCompose: dockerCompose,
 		AgentGateway:  gatewayConfig,
+		EnvFiles:      envFiles,
 	}, nil
 }
 
@@ -110,9 +129,102 @@ func WriteLocalPlatformFiles(platformDir string, cfg *platformtypes.LocalPlatfor
 	if err := writeLocalAgentGatewayConfig(platformDir, cfg.AgentGateway, port); err != nil {
 		return err
 	}
+	for serviceName, values := range cfg.EnvFiles {
+		if err := writeLocalServiceEnvFile(platformDir, serviceName, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderLocalPlatformFiles serializes cfg as the files WriteLocalPlatformFiles
+// would write, with paths relative to the platform directory.
+func renderLocalPlatformFiles(cfg *platformtypes.LocalPlatformConfig, port uint16) ([]models.RenderedFile, error) {
+	compose, err := cfg.DockerCompose.MarshalYAML()
+	if err != nil {
+		return nil, fmt.Errorf("marshal docker compose config: %w", err)
+	}
+	ensureLocalAgentGatewayDefaults(cfg.AgentGateway, port)
+	gateway, err := yaml.Marshal(cfg.AgentGateway)
+	if err != nil {
+		return nil, fmt.Errorf("marshal agent gateway config: %w", err)
+	}
+	files := []models.RenderedFile{
+		{Path: localComposeFileName, Content: string(compose)},
+		{Path: localAgentGatewayFileName, Content: string(gateway)},
+	}
+	for _, serviceName := range slices.Sorted(maps.Keys(cfg.EnvFiles)) {
+		files = append(files, models.RenderedFile{
+			Path:    localServiceEnvFile(serviceName),
+			Content: string(formatLocalEnvFile(cfg.EnvFiles[serviceName])),
+		})
+	}
+	return files, nil
+}
+
+// localServiceEnvFile returns the path, relative to the platform directory, of
+// the env file holding a compose service's secret environment.
+func localServiceEnvFile(serviceName string) string {
+	return filepath.Join(localSecretsDirName, serviceName+".env")
+}
+
+// localServiceEnvFiles references a service's secret env file from its compose
+// definition. The entry is not marked required because compose-go writes
+// required env files in a short form it cannot read back; the file is always
+// written together with the compose file that references it.
+func localServiceEnvFiles(serviceName string, secretEnv map[string]string) []composetypes.EnvFile {
+	if len(secretEnv) == 0 {
+		return nil
+	}
+	return []composetypes.EnvFile{{Path: localServiceEnvFile(serviceName)}}
+}
+
+// writeLocalServiceEnvFile writes a service's secret environment readable only
+// by the registry user.
+func writeLocalServiceEnvFile(platformDir, serviceName string, values map[string]string) error {
+	dir := filepath.Join(platformDir, localSecretsDirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("create secrets directory: %w", err)
+	}
+	// CreateTemp creates the file with mode 0600, so values are never
+	// readable by others, even briefly.
+	tmp, err := os.CreateTemp(dir, serviceName+".env.*")
+	if err != nil {
+		return fmt.Errorf("create env file for %s: %w", serviceName, err)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+	if _, err := tmp.Write(formatLocalEnvFile(values)); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write env file for %s: %w", serviceName, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write env file for %s: %w", serviceName, err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(platformDir, localServiceEnvFile(serviceName))); err != nil {
+		return fmt.Errorf("write env file for %s: %w", serviceName, err)
+	}
+	return nil
+}
+
+// removeLocalServiceEnvFiles deletes the secret env files of the given services.
+func removeLocalServiceEnvFiles(platformDir string, serviceNames []string) error {
+	for _, serviceName := range serviceNames {
+		err := os.Remove(filepath.Join(platformDir, localServiceEnvFile(serviceName)))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove env file for %s: %w", serviceName, err)
+		}
+	}
 	return nil
 }
 
+func formatLocalEnvFile(values map[string]string) []byte {
+	var buf bytes.Buffer
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		fmt.Fprintf(&buf, "%s=\"%s\"\n", key, localEnvFileValueEscaper.Replace(values[key]))
+	}
+	return buf.Bytes()
+}
+
 func ComposeUpLocalPlatform(ctx context.Context, platformDir string, verbose bool) error {
 	if err := os.MkdirAll(platformDir, 0755); err != nil {
 		return fmt.Errorf("create runtime directory: %w", err)
@@ -151,6 +263,75 @@ func ComposeDownLocalPlatform(ctx context.Context, platformDir string, verbose b
 	return nil
 }
 
+// ComposeLogsLocalPlatform runs `docker compose logs` for the given services and
+// calls emit for every output line until the command exits or emit fails.
+func ComposeLogsLocalPlatform(
+	ctx context.Context,
+	platformDir string,
+	services []string,
+	opts models.DeploymentLogOptions,
+	emit func(line string) error,
+) error {
+	if _, err := os.Stat(platformDir); os.IsNotExist(err) {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "docker", localComposeLogsArgs(services, opts)...)
+	cmd.Dir = platformDir
+	var stderrBuf bytes.Buffer
+	cmd.Stderr = &stderrBuf
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to read docker compose logs: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to read docker compose logs: %w", err)
+	}
+
+	var emitErr error
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if emitErr = emit(scanner.Text()); emitErr != nil {
+			cancel()
+			break
+		}
+	}
+	if emitErr == nil {
+		emitErr = scanner.Err()
+	}
+	// Drain any remaining output so Wait does not block on a full pipe.
+	_, _ = io.Copy(io.Discard, stdout)
+	waitErr := cmd.Wait()
+
+	switch {
+	case emitErr != nil:
+		return emitErr
+	case ctx.Err() != nil:
+		// Caller went away (e.g. follow mode client disconnected).
+		return nil
+	case waitErr != nil:
+		return fmt.Errorf("failed to read docker compose logs: %w: %s", waitErr, strings.TrimSpace(stderrBuf.String()))
+	}
+	return nil
+}
+
+func localComposeLogsArgs(services []string, opts models.DeploymentLogOptions) []string {
+	args := []string{"compose", "logs", "--no-color", "--no-log-prefix"}
+	if opts.TailLines > 0 {
+		args = append(args, "--tail", strconv.FormatInt(opts.TailLines, 10))
+	}
+	if opts.Since != nil && !opts.Since.IsZero() {
+		args = append(args, "--since", opts.Since.UTC().Format(time.RFC3339))
+	}
+	if opts.Follow {
+		args = append(args, "--follow")
+	}
+	return append(args, services...)
+}
+
 func LoadLocalDockerComposeConfig(platformDir string) (*platformtypes.DockerComposeConfig, error) {
 	path := filepath.Join(platformDir, localComposeFileName)
 	project := &platformtypes.DockerComposeConfig{
@@ -239,9 +420,16 @@ func writeLocalAgentGatewayConfig(platformDir string, cfg *platformtypes.AgentGa
 	if err != nil {
 		return fmt.Errorf("marshal agent gateway config: %w", err)
 	}
-	if err := os.WriteFile(filepath.Join(platformDir, localAgentGatewayFileName), content, 0644); err != nil {
+	// The config carries the secret env of stdio servers run by the gateway, so
+	// it is only readable by its owner (the gateway container runs as root).
+	// WriteFile keeps the mode of an existing file, so tighten it explicitly.
+	path := filepath.Join(platformDir, localAgentGatewayFileName)
+	if err := os.WriteFile(path, content, 0600); err != nil {
 		return fmt.Errorf("write agent gateway config: %w", err)
 	}
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("restrict agent gateway config permissions: %w", err)
+	}
 	return nil
 }
 
@@ -302,7 +490,7 @@ func translateLocalAgentGatewayService(platformDir string, port uint16) (*compos
 
 	image := fmt.Sprintf("%s/agentregistry-dev/agentregistry/arctl-agentgateway:%s", version.DockerRegistry, version.Version)
 	return &composetypes.ServiceConfig{
-		Name:    "agent_gateway",
+		Name:    localAgentGatewayServiceName,
 		Image:   image,
 		Command: []string{"-f", "/config/agent-gateway.yaml"},
 		Ports: []composetypes.ServicePortConfig{{
@@ -343,6 +531,7 @@ func translateLocalMCPServerToServiceConfig(server *platformtypes.MCPServer) (*c
 		Image:       image,
 		Command:     cmd,
 		Environment: composetypes.NewMappingWithEquals(envValues),
+		EnvFiles:    localServiceEnvFiles(localMCPServiceName(server), server.Local.Deployment.SecretEnv),
 	}, nil
 }
 
@@ -376,6 +565,7 @@ func translateLocalAgentToServiceConfig(platformDir string, agent *platformtypes
 		Image:       image,
 		Command:     []string{agent.Name, "--local", "--port", fmt.Sprintf("%d", port)},
 		Environment: composetypes.NewMappingWithEquals(envValues),
+		EnvFiles:    localServiceEnvFiles(localAgentServiceName(agent), agent.Deployment.SecretEnv),
 		Ports: []composetypes.ServicePortConfig{{
 			Target:    uint32(port),
 			Published: fmt.Sprintf("%d", port),
@@ -404,10 +594,20 @@ func translateLocalAgentGatewayConfig(agentGatewayPort uint16, servers []*platfo
 			switch server.Local.TransportType {
 			case platformtypes.TransportTypeStdio:
 				if canRunInsideLocalAgentGateway(server.Local.Deployment.Cmd) {
+					// Servers run by the gateway have no compose service of their
+					// own, so secrets are passed in the gateway's stdio target env.
+					env := server.Local.Deployment.Env
+					if len(server.Local.Deployment.SecretEnv) > 0 {
+						env = maps.Clone(env)
+						if env == nil {
+							env = map[string]string{}
+						}
+						maps.Copy(env, server.Local.Deployment.SecretEnv)
+					}
 					mcpTarget.Stdio = &platformtypes.StdioTargetSpec{
 						Cmd:  server.Local.Deployment.Cmd,
 						Args: server.Local.Deployment.Args,
-						Env:  server.Local.Deployment.Env,
+						Env:  env,
 					}
 				} else {
 					mcpTarget.MCP = &platformtypes.MCPTargetSpec{
@@ -521,7 +721,7 @@ func extractServiceNames(config *platformtypes.LocalPlatformConfig) []string {
 	}
 	names := make([]string, 0, len(config.DockerCompose.Services))
 	for name := range config.DockerCompose.Services {
-		if name == "agent_gateway" {
+		if name == localAgentGatewayServiceName {
 			continue
 		}
 		names = append(names, name)
@@ -663,3 +863,284 @@ func filterRoutes(routes []platformtypes.LocalRoute, names []string) []platformt
 	}
 	return filtered
 }
+
+// Labels recognised on compose services and docker containers that were not
+// deployed by the registry. They mirror the kagent/kmcp labels used for
+// Kubernetes discovery.
+const (
+	localManagedLabelKey      = "aregistry.ai/managed"
+	localResourceTypeLabelKey = "aregistry.ai/resource-type"
+	localNameLabelKey         = "aregistry.ai/name"
+	localVersionLabelKey      = "aregistry.ai/version"
+	localComposeProjectLabel  = "com.docker.compose.project"
+	localComposeServiceLabel  = "com.docker.compose.service"
+)
+
+// localContainer is the subset of `docker ps` output used for discovery and
+// reconciliation.
+type localContainer struct {
+	Name      string
+	Labels    map[string]string
+	State     string
+	CreatedAt time.Time
+}
+
+// listLabelledLocalContainers returns running containers that carry the
+// aregistry.ai/resource-type label.
+func listLabelledLocalContainers(ctx context.Context) ([]localContainer, error) {
+	return runLocalDockerPS(ctx, "--filter", "label="+localResourceTypeLabelKey)
+}
+
+// listLocalProjectContainers returns the containers of a compose project in
+// any state, so stopped and restarting services can be reported as unhealthy.
+func listLocalProjectContainers(ctx context.Context, project string) ([]localContainer, error) {
+	return runLocalDockerPS(ctx, "--all", "--filter", "label="+localComposeProjectLabel+"="+project)
+}
+
+func runLocalDockerPS(ctx context.Context, args ...string) ([]localContainer, error) {
+	cmd := exec.CommandContext(ctx, "docker", append(append([]string{"ps"}, args...), "--format", "{{json .}}")...)
+	var stderrBuf bytes.Buffer
+	cmd.Stderr = &stderrBuf
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list docker containers: %w: %s", err, strings.TrimSpace(stderrBuf.String()))
+	}
+	return parseLocalContainers(out)
+}
+
+func parseLocalContainers(out []byte) ([]localContainer, error) {
+	var containers []localContainer
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var raw struct {
+			Names     string `json:"Names"`
+			Labels    string `json:"Labels"`
+			State     string `json:"State"`
+			CreatedAt string `json:"CreatedAt"`
+		}
+		if err := json.Unmarshal(line, &raw); err != nil {
+			return nil, fmt.Errorf("parse docker ps output: %w", err)
+		}
+		container := localContainer{
+			Name:   strings.Split(raw.Names, ",")[0],
+			Labels: map[string]string{},
+			State:  raw.State,
+		}
+		for pair := range strings.SplitSeq(raw.Labels, ",") {
+			key, value, ok := strings.Cut(pair, "=")
+			if ok && key != "" {
+				container.Labels[key] = value
+			}
+		}
+		if created, err := time.Parse("2006-01-02 15:04:05 -0700 MST", raw.CreatedAt); err == nil {
+			container.CreatedAt = created
+		}
+		containers = append(containers, container)
+	}
+	return containers, scanner.Err()
+}
+
+// localDiscoverDeployments reports workloads in the platform directory's compose
+// project and agentgateway config, plus labelled containers, that do not belong
+// to any of the managed deployment IDs.
+func localDiscoverDeployments(
+	platformDir string,
+	port uint16,
+	providerID string,
+	managedDeploymentIDs []string,
+	containers []localContainer,
+) ([]*models.Deployment, error) {
+	composeCfg, err := LoadLocalDockerComposeConfig(platformDir)
+	if err != nil {
+		return nil, err
+	}
+	gatewayCfg, err := LoadLocalAgentGatewayConfig(platformDir, port)
+	if err != nil {
+		return nil, err
+	}
+
+	isManaged := func(name string, labels map[string]string) bool {
+		if labels[localManagedLabelKey] == "true" {
+			return true
+		}
+		for _, id := range managedDeploymentIDs {
+			if strings.Contains(name, id) {
+				return true
+			}
+		}
+		return false
+	}
+
+	discovered := make([]*models.Deployment, 0)
+	appendWorkload := func(name, resourceType string, labels map[string]string, preferRemote bool, creation time.Time) {
+		if resourceType != "mcp" && resourceType != "agent" {
+			return
+		}
+		if labelName := strings.TrimSpace(labels[localNameLabelKey]); labelName != "" {
+			name = labelName
+		}
+		version := strings.TrimSpace(labels[localVersionLabelKey])
+		if version == "" {
+			version = "unknown"
+		}
+		discovered = append(discovered, &models.Deployment{
+			ServerName:   name,
+			Version:      version,
+			DeployedAt:   creation,
+			UpdatedAt:    creation,
+			Status:       models.DeploymentStatusDeployed,
+			Origin:       "discovered",
+			ProviderID:   providerID,
+			ResourceType: resourceType,
+			PreferRemote: preferRemote,
+			Env:          labels,
+		})
+	}
+
+	agentServices := map[string]struct{}{}
+	for _, route := range extractNonMCPRoutes(gatewayCfg) {
+		for _, backend := range route.Backends {
+			if host, _, ok := strings.Cut(backend.Host, ":"); ok {
+				agentServices[host] = struct{}{}
+			}
+		}
+	}
+
+	composeModTime := localPlatformFileModTime(platformDir, localComposeFileName)
+	for _, serviceName := range slices.Sorted(maps.Keys(composeCfg.Services)) {
+		labels := map[string]string(composeCfg.Services[serviceName].Labels)
+		if serviceName == localAgentGatewayServiceName || isManaged(serviceName, labels) {
+			continue
+		}
+		resourceType := labels[localResourceTypeLabelKey]
+		if resourceType == "" {
+			resourceType = "mcp"
+			if _, ok := agentServices[serviceName]; ok {
+				resourceType = "agent"
+			}
+		}
+		appendWorkload(serviceName, resourceType, labels, false, composeModTime)
+	}
+
+	gatewayModTime := localPlatformFileModTime(platformDir, localAgentGatewayFileName)
+	for _, target := range extractMCPRouteTargets(gatewayCfg) {
+		if _, ok := composeCfg.Services[target.Name]; ok || isManaged(target.Name, nil) {
+			continue
+		}
+		// Stdio targets run inside the gateway; anything else points at a
+		// server outside this compose project.
+		appendWorkload(target.Name, "mcp", nil, target.Stdio == nil, gatewayModTime)
+	}
+
+	for _, container := range containers {
+		if container.Labels[localComposeProjectLabel] == composeCfg.Name {
+			if _, ok := composeCfg.Services[container.Labels[localComposeServiceLabel]]; ok {
+				continue
+			}
+		}
+		if isManaged(container.Name, container.Labels) {
+			continue
+		}
+		name := container.Labels[localComposeServiceLabel]
+		if name == "" {
+			name = container.Name
+		}
+		appendWorkload(name, container.Labels[localResourceTypeLabelKey], container.Labels, false, container.CreatedAt)
+	}
+
+	return discovered, nil
+}
+
+// localObserveDeployment compares the services and gateway targets translated
+// for a deployment with the live compose project and agentgateway config.
+// Containers are matched by compose service name to check that they run; a nil
+// slice skips the health check.
+func localObserveDeployment(
+	desired *platformtypes.LocalPlatformConfig,
+	composeCfg *platformtypes.DockerComposeConfig,
+	gatewayCfg *platformtypes.AgentGatewayConfig,
+	containers []localContainer,
+	deploymentID string,
+) *models.DeploymentObservation {
+	wantServices := extractServiceNames(desired)
+	wantTargets := extractTargetNames(desired.AgentGateway)
+	liveTargets := make(map[string]struct{})
+	for _, name := range extractTargetNames(gatewayCfg) {
+		liveTargets[name] = struct{}{}
+	}
+
+	var missing, drift []string
+	for _, name := range wantServices {
+		live, ok := composeCfg.Services[name]
+		if !ok {
+			missing = append(missing, "service "+name)
+			continue
+		}
+		if want := desired.DockerCompose.Services[name].Image; want != "" && live.Image != want {
+			drift = append(drift, fmt.Sprintf("service %s runs image %s, expected %s", name, live.Image, want))
+		}
+	}
+	for _, name := range wantTargets {
+		if _, ok := liveTargets[name]; !ok {
+			missing = append(missing, "gateway target "+name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(composeCfg.Services)) {
+		if strings.Contains(name, deploymentID) && !slices.Contains(wantServices, name) {
+			drift = append(drift, "unexpected service "+name)
+		}
+	}
+
+	switch {
+	case len(missing) > 0 && len(missing) == len(wantServices)+len(wantTargets):
+		return &models.DeploymentObservation{
+			Status: models.DeploymentStatusMissing,
+			Reason: "workload not found on platform: " + strings.Join(missing, ", "),
+		}
+	case len(missing) > 0 || len(drift) > 0:
+		for _, name := range missing {
+			drift = append(drift, "missing "+name)
+		}
+		return &models.DeploymentObservation{
+			Status: models.DeploymentStatusDrifted,
+			Reason: strings.Join(drift, "; "),
+		}
+	}
+
+	if containers != nil {
+		states := make(map[string]string, len(containers))
+		for _, container := range containers {
+			states[container.Labels[localComposeServiceLabel]] = container.State
+		}
+		var unhealthy []string
+		for _, name := range wantServices {
+			state, ok := states[name]
+			switch {
+			case !ok:
+				unhealthy = append(unhealthy, fmt.Sprintf("service %s has no container", name))
+			case state != "running":
+				unhealthy = append(unhealthy, fmt.Sprintf("service %s is %s", name, state))
+			}
+		}
+		if len(unhealthy) > 0 {
+			return &models.DeploymentObservation{
+				Status: models.DeploymentStatusUnhealthy,
+				Reason: strings.Join(unhealthy, "; "),
+			}
+		}
+	}
+	return &models.DeploymentObservation{Status: models.DeploymentStatusDeployed}
+}
+
+func localPlatformFileModTime(platformDir, fileName string) time.Time {
+	info, err := os.Stat(filepath.Join(platformDir, fileName))
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -2,10 +2,14 @@ package local
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	platformtypes "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
 	platformutils "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/utils"
+	"github.com/compose-spec/compose-go/v2/dotenv"
 )
 
 func TestBuildLocalPlatformConfig_UsesDefaultAgentPortInGatewayRoute(t *testing.T) {
@@ -49,3 +53,88 @@ func TestDefaultAgentPort(t *testing.T) {
 		t.Fatalf("defaultAgentPort(custom) = %d, want 9090", got)
 	}
 }
+
+func TestBuildLocalPlatformConfig_WritesSecretEnvFiles(t *testing.T) {
+	platformDir := t.TempDir()
+	agent := &platformtypes.Agent{
+		Name:         "demo-agent",
+		Version:      "1.0.0",
+		DeploymentID: "dep-1",
+		Deployment: platformtypes.AgentDeployment{
+			Image:     "demo-agent:latest",
+			Env:       map[string]string{"LOG_LEVEL": "debug"},
+			SecretEnv: map[string]string{"OPENAI_API_KEY": `sk-"quoted" $HOME \ path` + "\nline"},
+		},
+	}
+	cfg, err := BuildLocalPlatformConfig(context.Background(), platformDir, 8081, "test-project", &platformtypes.DesiredState{
+		Agents: []*platformtypes.Agent{agent},
+	})
+	if err != nil {
+		t.Fatalf("BuildLocalPlatformConfig() unexpected error: %v", err)
+	}
+
+	serviceName := localAgentServiceName(agent)
+	service := cfg.DockerCompose.Services[serviceName]
+	for _, value := range service.Environment {
+		if value != nil && strings.Contains(*value, "sk-") {
+			t.Fatalf("secret value inlined in compose environment: %q", *value)
+		}
+	}
+	if len(service.EnvFiles) != 1 || service.EnvFiles[0].Path != localServiceEnvFile(serviceName) {
+		t.Fatalf("service env files = %+v, want %s", service.EnvFiles, localServiceEnvFile(serviceName))
+	}
+
+	if err := WriteLocalPlatformFiles(platformDir, cfg, 8081); err != nil {
+		t.Fatalf("WriteLocalPlatformFiles() unexpected error: %v", err)
+	}
+	envFilePath := filepath.Join(platformDir, localServiceEnvFile(serviceName))
+	info, err := os.Stat(envFilePath)
+	if err != nil {
+		t.Fatalf("stat env file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("env file mode = %v, want 0600", info.Mode().Perm())
+	}
+	values, err := dotenv.ReadFile(envFilePath, nil)
+	if err != nil {
+		t.Fatalf("parse env file: %v", err)
+	}
+	if got := values["OPENAI_API_KEY"]; got != agent.Deployment.SecretEnv["OPENAI_API_KEY"] {
+		t.Fatalf("env file value = %q, want %q", got, agent.Deployment.SecretEnv["OPENAI_API_KEY"])
+	}
+
+	// The compose file keeps its env file reference across a reload.
+	loaded, err := LoadLocalDockerComposeConfig(platformDir)
+	if err != nil {
+		t.Fatalf("LoadLocalDockerComposeConfig() unexpected error: %v", err)
+	}
+	if got := loaded.Services[serviceName].EnvFiles; len(got) != 1 || got[0].Path != localServiceEnvFile(serviceName) {
+		t.Fatalf("reloaded env files = %+v", got)
+	}
+
+	if err := removeLocalServiceEnvFiles(platformDir, []string{serviceName}); err != nil {
+		t.Fatalf("removeLocalServiceEnvFiles() unexpected error: %v", err)
+	}
+	if _, err := os.Stat(envFilePath); !os.IsNotExist(err) {
+		t.Fatalf("env file still present after removal: %v", err)
+	}
+}
+
+func TestWriteLocalAgentGatewayConfig_OwnerOnly(t *testing.T) {
+	platformDir := t.TempDir()
+	path := filepath.Join(platformDir, localAgentGatewayFileName)
+	// A config written before secrets were supported is tightened on rewrite.
+	if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeLocalAgentGatewayConfig(platformDir, nil, 8081); err != nil {
+		t.Fatalf("writeLocalAgentGatewayConfig() unexpected error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat gateway config: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("gateway config mode = %v, want 0600", info.Mode().Perm())
+	}
+}
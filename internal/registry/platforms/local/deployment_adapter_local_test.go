@@ -2,10 +2,18 @@ package local
 
 import (
 	"context"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
 	platformtypes "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/platforms/utils"
 	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
@@ -94,7 +102,7 @@ func TestUndeploy_RemovesLocalArtifactsWhenRegistryArtifactIsMissing(t *testing.
 		return nil, database.ErrNotFound
 	}
 
-	adapter := NewLocalDeploymentAdapter(registry, tempDir, 8080)
+	adapter := NewLocalDeploymentAdapter(registry, tempDir, "localhost", 8080)
 
 	originalComposeUp := runLocalComposeUp
 	originalRefresh := refreshLocalAgentMCPConfig
@@ -213,7 +221,7 @@ func TestUndeploy_CallsComposeDownWhenNoServicesRemain(t *testing.T) {
 		return nil, database.ErrNotFound
 	}
 
-	adapter := NewLocalDeploymentAdapter(registry, tempDir, 8080)
+	adapter := NewLocalDeploymentAdapter(registry, tempDir, "localhost", 8080)
 
 	originalComposeUp := runLocalComposeUp
 	originalComposeDown := runLocalComposeDown
@@ -278,7 +286,7 @@ func TestDeploy_WritesPromptsConfig(t *testing.T) {
 		}, nil
 	}
 
-	adapter := NewLocalDeploymentAdapter(registry, tempDir, 8080)
+	adapter := NewLocalDeploymentAdapter(registry, tempDir, "localhost", 8080)
 
 	originalComposeUp := runLocalComposeUp
 	originalRefresh := refreshLocalAgentMCPConfig
@@ -321,3 +329,619 @@ func TestDeploy_WritesPromptsConfig(t *testing.T) {
 		t.Fatalf("unexpected prompt %+v", capturedPrompts[0])
 	}
 }
+
+func TestRender_ReturnsPlatformFilesWithoutSecretValues(t *testing.T) {
+	tempDir := t.TempDir()
+	deployment := &models.Deployment{
+		ServerName:   "planner",
+		Version:      "1.0.0",
+		ResourceType: "agent",
+		ProviderID:   "local",
+		Env:          map[string]string{},
+		SecretRefs:   map[string]string{"OPENAI_API_KEY": "openai-key"},
+	}
+
+	registry := servicetesting.NewFakeRegistry()
+	registry.GetAgentByNameAndVersionFn = func(_ context.Context, name, version string) (*models.AgentResponse, error) {
+		return &models.AgentResponse{
+			Agent: models.AgentJSON{
+				AgentManifest: models.AgentManifest{Name: name, Image: "agent-image:latest"},
+				Version:       version,
+			},
+		}, nil
+	}
+	registry.ResolveSecretRefsFn = func(context.Context, map[string]string) (map[string]string, error) {
+		t.Fatal("render must not resolve secret values")
+		return nil, nil
+	}
+	adapter := NewLocalDeploymentAdapter(registry, tempDir, "localhost", 8080)
+
+	render, err := adapter.Render(context.Background(), deployment, models.DeploymentRenderFormatYAML)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	paths := make([]string, 0, len(render.Files))
+	for _, file := range render.Files {
+		paths = append(paths, file.Path)
+	}
+	want := []string{localComposeFileName, localAgentGatewayFileName, localServiceEnvFile("planner")}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("rendered files = %v, want %v", paths, want)
+	}
+	if !strings.Contains(render.Files[0].Content, "agent-image:latest") {
+		t.Errorf("compose file does not run the agent image:\n%s", render.Files[0].Content)
+	}
+	if got := render.Files[2].Content; got != "OPENAI_API_KEY=\"\"\n" {
+		t.Errorf("env file = %q, want the key with an empty value", got)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, localComposeFileName)); !os.IsNotExist(err) {
+		t.Errorf("Render() wrote the compose file: %v", err)
+	}
+
+	if _, err := adapter.Render(context.Background(), deployment, models.DeploymentRenderFormatHelm); !errors.Is(err, database.ErrInvalidInput) {
+		t.Fatalf("Render(helm) error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestDeploy_CancelRollsBackPartialDeployment(t *testing.T) {
+	tempDir := t.TempDir()
+	deployment := &models.Deployment{
+		ID:           "dep-cancel-001",
+		ServerName:   "cancel-agent",
+		Version:      "1.0.0",
+		ResourceType: "agent",
+		ProviderID:   "local",
+		Env:          map[string]string{},
+	}
+
+	registry := servicetesting.NewFakeRegistry()
+	registry.GetAgentByNameAndVersionFn = func(_ context.Context, name, version string) (*models.AgentResponse, error) {
+		return &models.AgentResponse{
+			Agent: models.AgentJSON{
+				AgentManifest: models.AgentManifest{
+					Name:  name,
+					Image: "agent-image:latest",
+				},
+				Version: version,
+			},
+		}, nil
+	}
+
+	adapter := NewLocalDeploymentAdapter(registry, tempDir, "localhost", 8080)
+
+	originalComposeUp := runLocalComposeUp
+	originalComposeDown := runLocalComposeDown
+	originalRefresh := refreshLocalAgentMCPConfig
+	originalPromptsRefresh := refreshLocalAgentPromptsConfig
+	t.Cleanup(func() {
+		runLocalComposeUp = originalComposeUp
+		runLocalComposeDown = originalComposeDown
+		refreshLocalAgentMCPConfig = originalRefresh
+		refreshLocalAgentPromptsConfig = originalPromptsRefresh
+	})
+
+	composeUpStarted := make(chan struct{})
+	composeUpCalls := 0
+	runLocalComposeUp = func(ctx context.Context, _ string, _ bool) error {
+		composeUpCalls++
+		if composeUpCalls > 1 {
+			return nil
+		}
+		close(composeUpStarted)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	composeDownCalled := false
+	runLocalComposeDown = func(context.Context, string, bool) error {
+		composeDownCalled = true
+		return nil
+	}
+	refreshLocalAgentMCPConfig = func(*common.MCPConfigTarget, []common.PythonMCPServer, bool) error { return nil }
+	refreshLocalAgentPromptsConfig = func(*common.MCPConfigTarget, []common.PythonPrompt, bool) error { return nil }
+
+	go func() {
+		<-composeUpStarted
+		if err := adapter.Cancel(context.Background(), deployment); err != nil {
+			t.Errorf("Cancel() error = %v", err)
+		}
+	}()
+
+	result, err := adapter.Deploy(context.Background(), deployment)
+	if !errors.Is(err, utils.ErrDeploymentCancelled) {
+		t.Fatalf("Deploy() error = %v, want ErrDeploymentCancelled", err)
+	}
+	if result == nil || result.Status != models.DeploymentStatusCancelled {
+		t.Fatalf("expected cancelled result, got %+v", result)
+	}
+
+	composeCfg, err := LoadLocalDockerComposeConfig(tempDir)
+	if err != nil {
+		t.Fatalf("LoadLocalDockerComposeConfig() error = %v", err)
+	}
+	for serviceName := range composeCfg.Services {
+		if strings.Contains(serviceName, deployment.ID) {
+			t.Fatalf("expected service %q to be rolled back", serviceName)
+		}
+	}
+	if composeUpCalls < 2 && !composeDownCalled {
+		t.Fatal("expected rollback to re-apply the compose project")
+	}
+}
+
+func TestCancel_WithoutInFlightDeployLeavesPlatformAlone(t *testing.T) {
+	adapter := NewLocalDeploymentAdapter(servicetesting.NewFakeRegistry(), t.TempDir(), "localhost", 8080)
+
+	originalComposeUp := runLocalComposeUp
+	originalComposeDown := runLocalComposeDown
+	t.Cleanup(func() {
+		runLocalComposeUp = originalComposeUp
+		runLocalComposeDown = originalComposeDown
+	})
+	runLocalComposeUp = func(context.Context, string, bool) error {
+		t.Fatal("compose up should not run")
+		return nil
+	}
+	runLocalComposeDown = func(context.Context, string, bool) error {
+		t.Fatal("compose down should not run")
+		return nil
+	}
+
+	// The deploy may run on another replica, so nothing is rolled back here.
+	err := adapter.Cancel(context.Background(), &models.Deployment{
+		ID:           "dep-cancel-002",
+		ServerName:   "cancel-agent",
+		Version:      "1.0.0",
+		ResourceType: "agent",
+		ProviderID:   "local",
+	})
+	if !errors.Is(err, database.ErrConflict) {
+		t.Fatalf("Cancel() error = %v, want ErrConflict", err)
+	}
+}
+
+func TestDiscover_ReportsUnmanagedLocalWorkloads(t *testing.T) {
+	tempDir := t.TempDir()
+	managedService := "io-test-managed-dep-managed-1"
+
+	err := WriteLocalPlatformFiles(tempDir, &platformtypes.LocalPlatformConfig{
+		DockerCompose: &platformtypes.DockerComposeConfig{
+			Name:       defaultLocalProjectName,
+			WorkingDir: tempDir,
+			Services: map[string]composetypes.ServiceConfig{
+				localAgentGatewayServiceName: {Name: localAgentGatewayServiceName},
+				managedService:               {Name: managedService},
+				"hand-rolled-mcp": {
+					Name:   "hand-rolled-mcp",
+					Labels: composetypes.Labels{localVersionLabelKey: "0.3.0"},
+				},
+			},
+		},
+		AgentGateway: &platformtypes.AgentGatewayConfig{
+			Config: struct{}{},
+			Binds: []platformtypes.LocalBind{{
+				Port: 8080,
+				Listeners: []platformtypes.LocalListener{{
+					Name:     "default",
+					Protocol: platformtypes.LocalListenerProtocolHTTP,
+					Routes: []platformtypes.LocalRoute{{
+						RouteName: localMCPRouteName,
+						Backends: []platformtypes.RouteBackend{{
+							MCP: &platformtypes.MCPBackend{Targets: []platformtypes.MCPTarget{
+								{Name: managedService, MCP: &platformtypes.MCPTargetSpec{Host: "http://" + managedService + ":3000/mcp"}},
+								{Name: "hand-rolled-mcp", MCP: &platformtypes.MCPTargetSpec{Host: "http://hand-rolled-mcp:3000/mcp"}},
+								{Name: "external-search", MCP: &platformtypes.MCPTargetSpec{Host: "https://search.example.com/mcp"}},
+							}},
+						}},
+					}},
+				}},
+			}},
+		},
+	}, 8080)
+	if err != nil {
+		t.Fatalf("WriteLocalPlatformFiles() error = %v", err)
+	}
+
+	registry := servicetesting.NewFakeRegistry()
+	registry.GetDeploymentsFn = func(_ context.Context, filter *models.DeploymentFilter) ([]*models.Deployment, error) {
+		if filter == nil || filter.Origin == nil || *filter.Origin != "managed" {
+			t.Fatalf("expected managed origin filter, got %+v", filter)
+		}
+		return []*models.Deployment{{ID: "dep-managed-1"}}, nil
+	}
+	adapter := NewLocalDeploymentAdapter(registry, tempDir, "localhost", 8080)
+
+	originalListContainers := listLocalContainers
+	t.Cleanup(func() { listLocalContainers = originalListContainers })
+	listLocalContainers = func(context.Context) ([]localContainer, error) {
+		return []localContainer{
+			{
+				Name: "agentregistry_runtime-hand-rolled-mcp-1",
+				Labels: map[string]string{
+					localResourceTypeLabelKey: "mcp",
+					localComposeProjectLabel:  defaultLocalProjectName,
+					localComposeServiceLabel:  "hand-rolled-mcp",
+				},
+			},
+			{
+				Name: "weather",
+				Labels: map[string]string{
+					localResourceTypeLabelKey: "agent",
+					localNameLabelKey:         "weather-agent",
+				},
+			},
+			{
+				Name:   "unrelated",
+				Labels: map[string]string{localResourceTypeLabelKey: "database"},
+			},
+		}, nil
+	}
+
+	discovered, err := adapter.Discover(context.Background(), "local")
+	if err != nil {
+		t.Fatalf("Discover() error = %v", err)
+	}
+
+	got := map[string]*models.Deployment{}
+	for _, dep := range discovered {
+		if dep.Origin != "discovered" || dep.ProviderID != "local" {
+			t.Fatalf("unexpected discovered deployment %+v", dep)
+		}
+		got[dep.ServerName] = dep
+	}
+	if len(got) != 3 {
+		t.Fatalf("expected 3 discovered workloads, got %v", slices.Collect(maps.Keys(got)))
+	}
+	if dep := got["hand-rolled-mcp"]; dep == nil || dep.ResourceType != "mcp" || dep.Version != "0.3.0" {
+		t.Fatalf("unexpected compose workload %+v", dep)
+	}
+	if dep := got["external-search"]; dep == nil || dep.ResourceType != "mcp" || !dep.PreferRemote {
+		t.Fatalf("unexpected gateway target %+v", dep)
+	}
+	if dep := got["weather-agent"]; dep == nil || dep.ResourceType != "agent" || dep.Version != "unknown" {
+		t.Fatalf("unexpected container workload %+v", dep)
+	}
+}
+
+func TestStreamLogs_UsesDeploymentComposeService(t *testing.T) {
+	tempDir := t.TempDir()
+	agentDeployment := &models.Deployment{
+		ID:           "dep-logs-001",
+		ServerName:   "io.test/logs-agent",
+		Version:      "1.0.0",
+		ResourceType: "agent",
+		ProviderID:   "local",
+	}
+	gatewayMCPDeployment := &models.Deployment{
+		ID:           "dep-logs-002",
+		ServerName:   "io.test/npx-server",
+		Version:      "1.0.0",
+		ResourceType: "mcp",
+		ProviderID:   "local",
+	}
+	agentServiceName := localAgentServiceName(&platformtypes.Agent{
+		Name:         agentDeployment.ServerName,
+		DeploymentID: agentDeployment.ID,
+	})
+
+	err := WriteLocalPlatformFiles(tempDir, &platformtypes.LocalPlatformConfig{
+		DockerCompose: &platformtypes.DockerComposeConfig{
+			Name:       "test",
+			WorkingDir: tempDir,
+			Services: map[string]composetypes.ServiceConfig{
+				localAgentGatewayServiceName: {Name: localAgentGatewayServiceName},
+				agentServiceName:             {Name: agentServiceName},
+			},
+		},
+		AgentGateway: defaultLocalAgentGatewayConfig(8080),
+	}, 8080)
+	if err != nil {
+		t.Fatalf("WriteLocalPlatformFiles() error = %v", err)
+	}
+
+	originalComposeLogs := runLocalComposeLogs
+	t.Cleanup(func() {
+		runLocalComposeLogs = originalComposeLogs
+	})
+
+	var gotServices []string
+	var gotOpts models.DeploymentLogOptions
+	runLocalComposeLogs = func(_ context.Context, platformDir string, services []string, opts models.DeploymentLogOptions, emit func(string) error) error {
+		if platformDir != tempDir {
+			t.Fatalf("unexpected platform dir %q", platformDir)
+		}
+		gotServices = services
+		gotOpts = opts
+		return emit("hello from " + services[0])
+	}
+
+	adapter := NewLocalDeploymentAdapter(servicetesting.NewFakeRegistry(), tempDir, "localhost", 8080)
+
+	var lines []string
+	err = adapter.StreamLogs(context.Background(), agentDeployment, models.DeploymentLogOptions{TailLines: 20, Follow: true}, func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamLogs() error = %v", err)
+	}
+	if len(gotServices) != 1 || gotServices[0] != agentServiceName {
+		t.Fatalf("expected logs for service %q, got %#v", agentServiceName, gotServices)
+	}
+	if gotOpts.TailLines != 20 || !gotOpts.Follow {
+		t.Fatalf("expected options to be passed through, got %#v", gotOpts)
+	}
+	if len(lines) != 1 || lines[0] != "hello from "+agentServiceName {
+		t.Fatalf("unexpected log lines: %#v", lines)
+	}
+
+	// MCP servers without their own compose service run inside the agent gateway.
+	logs, err := adapter.GetLogs(context.Background(), gatewayMCPDeployment)
+	if err != nil {
+		t.Fatalf("GetLogs() error = %v", err)
+	}
+	if len(gotServices) != 1 || gotServices[0] != localAgentGatewayServiceName {
+		t.Fatalf("expected agent gateway logs, got %#v", gotServices)
+	}
+	if gotOpts.Follow {
+		t.Fatal("expected GetLogs not to follow")
+	}
+	if len(logs) != 1 {
+		t.Fatalf("unexpected logs: %#v", logs)
+	}
+
+	// Agents must have a compose service.
+	missing := *agentDeployment
+	missing.ID = "dep-logs-missing"
+	if _, err := adapter.GetLogs(context.Background(), &missing); !errors.Is(err, database.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for missing agent service, got %v", err)
+	}
+}
+
+func TestLocalComposeLogsArgs(t *testing.T) {
+	since := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	got := localComposeLogsArgs([]string{"svc"}, models.DeploymentLogOptions{TailLines: 5, Since: &since, Follow: true})
+	want := []string{"compose", "logs", "--no-color", "--no-log-prefix", "--tail", "5", "--since", "2026-01-02T03:04:05Z", "--follow", "svc"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("localComposeLogsArgs() = %#v, want %#v", got, want)
+	}
+}
+
+func TestUpdate_ReplacesDeploymentServicesInPlace(t *testing.T) {
+	tempDir := t.TempDir()
+	previous := &models.Deployment{
+		ID:           "dep-update-001",
+		ServerName:   "update-agent",
+		Version:      "1.0.0",
+		ResourceType: "agent",
+		ProviderID:   "local",
+		Env:          map[string]string{"LOG_LEVEL": "info"},
+	}
+
+	registry := servicetesting.NewFakeRegistry()
+	registry.GetAgentByNameAndVersionFn = func(_ context.Context, name, version string) (*models.AgentResponse, error) {
+		return &models.AgentResponse{
+			Agent: models.AgentJSON{
+				AgentManifest: models.AgentManifest{
+					Name:  name,
+					Image: "agent-image:" + version,
+				},
+				Version: version,
+			},
+		}, nil
+	}
+
+	adapter := NewLocalDeploymentAdapter(registry, tempDir, "localhost", 8080)
+
+	originalComposeUp := runLocalComposeUp
+	originalRefresh := refreshLocalAgentMCPConfig
+	originalPromptsRefresh := refreshLocalAgentPromptsConfig
+	t.Cleanup(func() {
+		runLocalComposeUp = originalComposeUp
+		refreshLocalAgentMCPConfig = originalRefresh
+		refreshLocalAgentPromptsConfig = originalPromptsRefresh
+	})
+
+	runLocalComposeUp = func(context.Context, string, bool) error { return nil }
+	var cleanedVersions []string
+	refreshLocalAgentMCPConfig = func(target *common.MCPConfigTarget, servers []common.PythonMCPServer, _ bool) error {
+		if servers == nil {
+			cleanedVersions = append(cleanedVersions, target.Version)
+		}
+		return nil
+	}
+	refreshLocalAgentPromptsConfig = func(*common.MCPConfigTarget, []common.PythonPrompt, bool) error { return nil }
+
+	if _, err := adapter.Deploy(context.Background(), previous); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+
+	// A service left over from the previous configuration must not survive the update.
+	composeCfg, err := LoadLocalDockerComposeConfig(tempDir)
+	if err != nil {
+		t.Fatalf("LoadLocalDockerComposeConfig() error = %v", err)
+	}
+	staleService := "stale-dependency-" + previous.ID
+	composeCfg.Services[staleService] = composetypes.ServiceConfig{Name: staleService, Image: "stale:latest"}
+	composeCfg.Services["unrelated-service"] = composetypes.ServiceConfig{Name: "unrelated-service", Image: "unrelated:latest"}
+	gatewayCfg, err := LoadLocalAgentGatewayConfig(tempDir, 8080)
+	if err != nil {
+		t.Fatalf("LoadLocalAgentGatewayConfig() error = %v", err)
+	}
+	if err := WriteLocalPlatformFiles(tempDir, &platformtypes.LocalPlatformConfig{DockerCompose: composeCfg, AgentGateway: gatewayCfg}, 8080); err != nil {
+		t.Fatalf("WriteLocalPlatformFiles() error = %v", err)
+	}
+
+	desired := *previous
+	desired.Version = "2.0.0"
+	desired.Env = map[string]string{"LOG_LEVEL": "debug"}
+	result, err := adapter.Update(context.Background(), previous, &desired)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if result.Status != models.DeploymentStatusDeployed {
+		t.Fatalf("expected status deployed, got %s", result.Status)
+	}
+
+	composeCfg, err = LoadLocalDockerComposeConfig(tempDir)
+	if err != nil {
+		t.Fatalf("LoadLocalDockerComposeConfig() error = %v", err)
+	}
+	if _, ok := composeCfg.Services[staleService]; ok {
+		t.Fatalf("expected stale service %q to be removed", staleService)
+	}
+	if _, ok := composeCfg.Services["unrelated-service"]; !ok {
+		t.Fatal("expected unrelated service to be kept")
+	}
+	serviceName := localAgentServiceName(&platformtypes.Agent{Name: desired.ServerName, DeploymentID: desired.ID})
+	service, ok := composeCfg.Services[serviceName]
+	if !ok {
+		t.Fatalf("expected agent service %q, got %v", serviceName, slices.Collect(maps.Keys(composeCfg.Services)))
+	}
+	if service.Image != "agent-image:2.0.0" {
+		t.Fatalf("expected image agent-image:2.0.0, got %s", service.Image)
+	}
+	if logLevel := service.Environment["LOG_LEVEL"]; logLevel == nil || *logLevel != "debug" {
+		t.Fatalf("expected LOG_LEVEL=debug, got %v", logLevel)
+	}
+	if !slices.Contains(cleanedVersions, "1.0.0") {
+		t.Fatalf("expected agent config of version 1.0.0 to be cleaned up, got %v", cleanedVersions)
+	}
+}
+
+func TestObserve_ReportsDriftMissingAndUnhealthyWorkloads(t *testing.T) {
+	tempDir := t.TempDir()
+	deployment := &models.Deployment{
+		ID:           "dep-observe-001",
+		ServerName:   "observe-agent",
+		Version:      "1.0.0",
+		ResourceType: "agent",
+		ProviderID:   "local",
+	}
+
+	registry := servicetesting.NewFakeRegistry()
+	registry.GetAgentByNameAndVersionFn = func(_ context.Context, name, version string) (*models.AgentResponse, error) {
+		return &models.AgentResponse{
+			Agent: models.AgentJSON{
+				AgentManifest: models.AgentManifest{Name: name, Image: "agent-image:" + version},
+				Version:       version,
+			},
+		}, nil
+	}
+	adapter := NewLocalDeploymentAdapter(registry, tempDir, "localhost", 8080)
+
+	originalComposeUp := runLocalComposeUp
+	originalRefresh := refreshLocalAgentMCPConfig
+	originalPromptsRefresh := refreshLocalAgentPromptsConfig
+	originalListContainers := listLocalServiceContainers
+	t.Cleanup(func() {
+		runLocalComposeUp = originalComposeUp
+		refreshLocalAgentMCPConfig = originalRefresh
+		refreshLocalAgentPromptsConfig = originalPromptsRefresh
+		listLocalServiceContainers = originalListContainers
+	})
+	runLocalComposeUp = func(context.Context, string, bool) error { return nil }
+	refreshLocalAgentMCPConfig = func(*common.MCPConfigTarget, []common.PythonMCPServer, bool) error { return nil }
+	refreshLocalAgentPromptsConfig = func(*common.MCPConfigTarget, []common.PythonPrompt, bool) error { return nil }
+
+	if _, err := adapter.Deploy(context.Background(), deployment); err != nil {
+		t.Fatalf("Deploy() error = %v", err)
+	}
+	serviceName := localAgentServiceName(&platformtypes.Agent{Name: deployment.ServerName, DeploymentID: deployment.ID})
+	containerState := "running"
+	listLocalServiceContainers = func(context.Context, string) ([]localContainer, error) {
+		return []localContainer{{Name: serviceName + "-1", State: containerState, Labels: map[string]string{localComposeServiceLabel: serviceName}}}, nil
+	}
+
+	observe := func(d *models.Deployment) *models.DeploymentObservation {
+		t.Helper()
+		observation, err := adapter.Observe(context.Background(), d)
+		if err != nil {
+			t.Fatalf("Observe() error = %v", err)
+		}
+		return observation
+	}
+
+	if got := observe(deployment); got.Status != models.DeploymentStatusDeployed {
+		t.Fatalf("expected deployed, got %s (%s)", got.Status, got.Reason)
+	}
+
+	containerState = "exited"
+	if got := observe(deployment); got.Status != models.DeploymentStatusUnhealthy || !strings.Contains(got.Reason, "exited") {
+		t.Fatalf("expected unhealthy with exited reason, got %s (%s)", got.Status, got.Reason)
+	}
+	containerState = "running"
+
+	upgraded := *deployment
+	upgraded.Version = "2.0.0"
+	if got := observe(&upgraded); got.Status != models.DeploymentStatusDrifted || !strings.Contains(got.Reason, "agent-image:1.0.0") {
+		t.Fatalf("expected drifted with image reason, got %s (%s)", got.Status, got.Reason)
+	}
+
+	composeCfg, err := LoadLocalDockerComposeConfig(tempDir)
+	if err != nil {
+		t.Fatalf("LoadLocalDockerComposeConfig() error = %v", err)
+	}
+	delete(composeCfg.Services, serviceName)
+	gatewayCfg, err := LoadLocalAgentGatewayConfig(tempDir, 8080)
+	if err != nil {
+		t.Fatalf("LoadLocalAgentGatewayConfig() error = %v", err)
+	}
+	if err := WriteLocalPlatformFiles(tempDir, &platformtypes.LocalPlatformConfig{DockerCompose: composeCfg, AgentGateway: gatewayCfg}, 8080); err != nil {
+		t.Fatalf("WriteLocalPlatformFiles() error = %v", err)
+	}
+	if got := observe(deployment); got.Status != models.DeploymentStatusMissing {
+		t.Fatalf("expected missing, got %s (%s)", got.Status, got.Reason)
+	}
+}
+
+func TestLocalProbeTarget_UsesGatewayRoutes(t *testing.T) {
+	mcpDeployment := &models.Deployment{ID: "dep-mcp-1", ServerName: "io.test/weather", ResourceType: "mcp"}
+	targetName := localMCPServiceName(&platformtypes.MCPServer{Name: mcpDeployment.ServerName, DeploymentID: mcpDeployment.ID})
+	gatewayCfg := func(targets ...string) *platformtypes.AgentGatewayConfig {
+		mcpTargets := make([]platformtypes.MCPTarget, 0, len(targets))
+		for _, name := range targets {
+			mcpTargets = append(mcpTargets, platformtypes.MCPTarget{Name: name})
+		}
+		return &platformtypes.AgentGatewayConfig{
+			Binds: []platformtypes.LocalBind{{
+				Listeners: []platformtypes.LocalListener{{
+					Routes: []platformtypes.LocalRoute{{
+						RouteName: localMCPRouteName,
+						Backends:  []platformtypes.RouteBackend{{MCP: &platformtypes.MCPBackend{Targets: mcpTargets}}},
+					}},
+				}},
+			}},
+		}
+	}
+
+	target, err := localProbeTarget("http://localhost:8081", gatewayCfg(targetName), mcpDeployment)
+	if err != nil {
+		t.Fatalf("localProbeTarget() error = %v", err)
+	}
+	if target.Protocol != models.DeploymentProbeMCP || target.URL != "http://localhost:8081/mcp" || target.ToolPrefix != "" {
+		t.Fatalf("single target probe = %+v", target)
+	}
+
+	target, err = localProbeTarget("http://localhost:8081", gatewayCfg("other", targetName), mcpDeployment)
+	if err != nil {
+		t.Fatalf("localProbeTarget() error = %v", err)
+	}
+	if target.ToolPrefix != targetName+"_" {
+		t.Fatalf("multiplexed ToolPrefix = %q, want %q", target.ToolPrefix, targetName+"_")
+	}
+
+	if _, err := localProbeTarget("http://localhost:8081", gatewayCfg("other"), mcpDeployment); !errors.Is(err, database.ErrNotFound) {
+		t.Fatalf("missing target error = %v, want ErrNotFound", err)
+	}
+
+	agentDeployment := &models.Deployment{ID: "dep-agent-1", ServerName: "planner", ResourceType: "agent"}
+	target, err = localProbeTarget("http://host.docker.internal:21212", gatewayCfg(), agentDeployment)
+	if err != nil {
+		t.Fatalf("localProbeTarget() error = %v", err)
+	}
+	serviceName := localAgentServiceName(&platformtypes.Agent{Name: agentDeployment.ServerName, DeploymentID: agentDeployment.ID})
+	wantURL := "http://host.docker.internal:21212/agents/" + serviceName + "/.well-known/agent-card.json"
+	if target.Protocol != models.DeploymentProbeA2A || target.URL != wantURL {
+		t.Fatalf("agent probe = %+v, want URL %s", target, wantURL)
+	}
+}
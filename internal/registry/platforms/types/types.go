@@ -4,6 +4,7 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent/frameworks/common"
 	v1alpha2 "github.com/kagent-dev/kagent/go/api/v1alpha2"
 	kmcpv1alpha1 "github.com/kagent-dev/kmcp/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 
 	composetypes "github.com/compose-spec/compose-go/v2/types"
@@ -104,12 +105,18 @@ type MCPServerDeployment struct {
 	Cmd   string            `json:"cmd,omitempty"`
 	Args  []string          `json:"args,omitempty"`
 	Env   map[string]string `json:"env,omitempty"`
+	// SecretEnv holds environment variables resolved from registry secrets.
+	// Platforms inject them out of band instead of inlining them with Env.
+	SecretEnv map[string]string `json:"-"`
 }
 
 type AgentDeployment struct {
 	Image string            `json:"image,omitempty"`
 	Env   map[string]string `json:"env,omitempty"`
 	Port  uint16            `json:"port,omitempty"`
+	// SecretEnv holds environment variables resolved from registry secrets.
+	// Platforms inject them out of band instead of inlining them with Env.
+	SecretEnv map[string]string `json:"-"`
 }
 
 type KubernetesPlatformConfig struct {
@@ -117,6 +124,16 @@ type KubernetesPlatformConfig struct {
 	RemoteMCPServers []*v1alpha2.RemoteMCPServer `json:"remoteMCPServers"`
 	MCPServers       []*kmcpv1alpha1.MCPServer   `json:"mcpServers"`
 	ConfigMaps       []*corev1.ConfigMap         `json:"configMaps,omitempty"`
+	Secrets          []*corev1.Secret            `json:"-"`
+}
+
+// KubernetesNativePlatformConfig holds the core objects that run a deployment
+// on a cluster without the kagent and kmcp controllers.
+type KubernetesNativePlatformConfig struct {
+	Deployments []*appsv1.Deployment `json:"deployments"`
+	Services    []*corev1.Service    `json:"services"`
+	ConfigMaps  []*corev1.ConfigMap  `json:"configMaps,omitempty"`
+	Secrets     []*corev1.Secret     `json:"-"`
 }
 
 type DockerComposeConfig = composetypes.Project
@@ -124,4 +141,7 @@ type DockerComposeConfig = composetypes.Project
 type LocalPlatformConfig struct {
 	DockerCompose *DockerComposeConfig
 	AgentGateway  *AgentGatewayConfig
+	// EnvFiles maps compose service names to the secret environment written
+	// to the service's env file.
+	EnvFiles map[string]map[string]string
 }
@@ -59,7 +59,13 @@ func BuildPlatformMCPServer(
 	if err != nil {
 		return nil, fmt.Errorf("load mcp server %s@%s: %w", deployment.ServerName, deployment.Version, err)
 	}
-	envValues, argValues, headerValues := splitDeploymentRuntimeInputs(deployment.Env)
+	secretValues, err := registryService.ResolveSecretRefs(ctx, deployment.SecretRefs)
+	if err != nil {
+		return nil, fmt.Errorf("resolve secrets for deployment %s: %w", deployment.ID, err)
+	}
+	inputs := copyStringMap(deployment.Env)
+	maps.Copy(inputs, secretValues)
+	envValues, argValues, headerValues := splitDeploymentRuntimeInputs(inputs)
 	server, err := TranslateMCPServer(ctx, &MCPServerRunRequest{
 		RegistryServer: &serverResp.Server,
 		DeploymentID:   deployment.ID,
@@ -71,6 +77,9 @@ func BuildPlatformMCPServer(
 	if err != nil {
 		return nil, err
 	}
+	if server.Local != nil {
+		server.Local.Deployment.SecretEnv = splitSecretEnv(server.Local.Deployment.Env, secretValues)
+	}
 	if namespace != "" && server.Namespace == "" {
 		server.Namespace = namespace
 	}
@@ -87,7 +96,12 @@ func ResolveAgent(
 	if err != nil {
 		return nil, fmt.Errorf("load agent %s@%s: %w", deployment.ServerName, deployment.Version, err)
 	}
+	secretValues, err := registryService.ResolveSecretRefs(ctx, deployment.SecretRefs)
+	if err != nil {
+		return nil, fmt.Errorf("resolve secrets for deployment %s: %w", deployment.ID, err)
+	}
 	envValues := copyStringMap(deployment.Env)
+	maps.Copy(envValues, secretValues)
 	if envValues["KAGENT_NAMESPACE"] == "" {
 		if namespace != "" {
 			envValues["KAGENT_NAMESPACE"] = namespace
@@ -102,17 +116,22 @@ func ResolveAgent(
 	envValues["AGENT_NAME"] = agentResp.Agent.Name
 	envValues["MODEL_PROVIDER"] = agentResp.Agent.ModelProvider
 	envValues["MODEL_NAME"] = agentResp.Agent.ModelName
+	secretEnv := splitSecretEnv(envValues, secretValues)
 
-	resolvedServers, resolvedConfigs, _, err := resolveAgentManifestPlatformMCPServers(ctx, registryService, deployment.ID, &agentResp.Agent.AgentManifest, namespace)
+	manifest, err := service.LockAgentManifest(ctx, registryService, &agentResp.Agent)
 	if err != nil {
 		return nil, err
 	}
-	skills, err := registryService.ResolveAgentManifestSkills(ctx, &agentResp.Agent.AgentManifest)
+	resolvedServers, resolvedConfigs, _, err := resolveAgentManifestPlatformMCPServers(ctx, registryService, deployment.ID, manifest, namespace)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := registryService.ResolveAgentManifestSkills(ctx, manifest)
 	if err != nil {
 		return nil, err
 	}
 
-	prompts, err := registryService.ResolveAgentManifestPrompts(ctx, &agentResp.Agent.AgentManifest)
+	prompts, err := registryService.ResolveAgentManifestPrompts(ctx, manifest)
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +141,7 @@ func ResolveAgent(
 			Name:               agentResp.Agent.Name,
 			Version:            agentResp.Agent.Version,
 			DeploymentID:       deployment.ID,
-			Deployment:         platformtypes.AgentDeployment{Image: agentResp.Agent.Image, Env: envValues, Port: DefaultLocalAgentPort},
+			Deployment:         platformtypes.AgentDeployment{Image: agentResp.Agent.Image, Env: envValues, SecretEnv: secretEnv, Port: DefaultLocalAgentPort},
 			ResolvedMCPServers: resolvedConfigs,
 			ResolvedPrompts:    prompts,
 			Skills:             skills,
@@ -668,6 +687,43 @@ func copyStringMap(input map[string]string) map[string]string {
 	return out
 }
 
+// splitSecretEnv moves the environment variables that still hold their
+// resolved secret value out of env and returns them. Secrets consumed as
+// ARG_ or HEADER_ inputs are rendered into arguments and headers instead.
+func splitSecretEnv(env, secretValues map[string]string) map[string]string {
+	if len(secretValues) == 0 {
+		return nil
+	}
+	secretEnv := make(map[string]string, len(secretValues))
+	for key, value := range secretValues {
+		if current, ok := env[key]; ok && current == value {
+			secretEnv[key] = value
+			delete(env, key)
+		}
+	}
+	return secretEnv
+}
+
+// WithoutSecretValues wraps a registry service so that secret references
+// resolve to empty values. Renderers use it so that manifests list every
+// secret key without its value. Secrets consumed as ARG_ or HEADER_ inputs
+// render as empty arguments and headers.
+func WithoutSecretValues(registryService service.RegistryService) service.RegistryService {
+	return redactedSecretsRegistry{RegistryService: registryService}
+}
+
+type redactedSecretsRegistry struct {
+	service.RegistryService
+}
+
+func (redactedSecretsRegistry) ResolveSecretRefs(_ context.Context, refs map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(refs))
+	for envName := range refs {
+		values[envName] = ""
+	}
+	return values, nil
+}
+
 func splitDeploymentRuntimeInputs(input map[string]string) (map[string]string, map[string]string, map[string]string) {
 	if len(input) == 0 {
 		return map[string]string{}, map[string]string{}, map[string]string{}
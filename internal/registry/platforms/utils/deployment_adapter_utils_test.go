@@ -2,11 +2,14 @@ package utils
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	platformtypes "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
 	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
 	"github.com/modelcontextprotocol/registry/pkg/model"
 )
@@ -197,6 +200,135 @@ func TestResolveAgentDefaultsLocalPort(t *testing.T) {
 	}
 }
 
+func TestResolveAgentSeparatesSecretEnv(t *testing.T) {
+	registry := servicetesting.NewFakeRegistry()
+	registry.Agents = []*models.AgentResponse{{
+		Agent: models.AgentJSON{
+			AgentManifest: models.AgentManifest{Name: "planner", ModelProvider: "openai", ModelName: "gpt-4o"},
+			Version:       "1.0.0",
+		},
+	}}
+	registry.ResolveSecretRefsFn = func(_ context.Context, refs map[string]string) (map[string]string, error) {
+		if refs["OPENAI_API_KEY"] != "openai-key" {
+			t.Fatalf("unexpected secret refs %v", refs)
+		}
+		return map[string]string{"OPENAI_API_KEY": "sk-test"}, nil
+	}
+
+	resolved, err := ResolveAgent(context.Background(), registry, &models.Deployment{
+		ID:         "dep-123",
+		ServerName: "planner",
+		Version:    "1.0.0",
+		Env:        map[string]string{"LOG_LEVEL": "debug"},
+		SecretRefs: map[string]string{"OPENAI_API_KEY": "openai-key"},
+	}, "")
+	if err != nil {
+		t.Fatalf("ResolveAgent() unexpected error: %v", err)
+	}
+	if _, ok := resolved.Agent.Deployment.Env["OPENAI_API_KEY"]; ok {
+		t.Fatal("secret value left in plain env")
+	}
+	if got := resolved.Agent.Deployment.SecretEnv["OPENAI_API_KEY"]; got != "sk-test" {
+		t.Fatalf("SecretEnv[OPENAI_API_KEY] = %q, want sk-test", got)
+	}
+	if got := resolved.Agent.Deployment.Env["LOG_LEVEL"]; got != "debug" {
+		t.Fatalf("LOG_LEVEL = %q, want debug", got)
+	}
+}
+
+func TestResolveAgentPinsRegistryRefsToLockAndRanges(t *testing.T) {
+	registry := servicetesting.NewFakeRegistry()
+	registry.Agents = []*models.AgentResponse{{
+		Agent: models.AgentJSON{
+			AgentManifest: models.AgentManifest{
+				Name:    "planner",
+				Skills:  []models.SkillRef{{Name: "pdf", RegistrySkillName: "acme/pdf", RegistrySkillVersion: "^1.2"}},
+				Prompts: []models.PromptRef{{Name: "system", RegistryPromptName: "acme/system", RegistryPromptVersion: "~0.4"}},
+			},
+			Version: "1.0.0",
+			Lock: &models.AgentLock{
+				Skills: []models.LockedVersion{{Name: "acme/pdf", Constraints: []string{"^1.2"}, Version: "1.2.3"}},
+			},
+		},
+	}}
+	registry.GetAllVersionsBySkillNameFn = func(context.Context, string) ([]*models.SkillResponse, error) {
+		t.Fatal("locked skill was resolved again")
+		return nil, nil
+	}
+	registry.GetAllVersionsByPromptNameFn = func(_ context.Context, name string) ([]*models.PromptResponse, error) {
+		var prompts []*models.PromptResponse
+		for _, version := range []string{"0.3.9", "0.4.1", "0.4.5", "0.5.0"} {
+			prompts = append(prompts, &models.PromptResponse{Prompt: models.PromptJSON{Name: name, Version: version}})
+		}
+		return prompts, nil
+	}
+	var skillVersion, promptVersion string
+	registry.ResolveAgentManifestSkillsFn = func(_ context.Context, manifest *models.AgentManifest) ([]platformtypes.AgentSkillRef, error) {
+		skillVersion = manifest.Skills[0].RegistrySkillVersion
+		return nil, nil
+	}
+	registry.ResolveAgentManifestPromptsFn = func(_ context.Context, manifest *models.AgentManifest) ([]platformtypes.ResolvedPrompt, error) {
+		promptVersion = manifest.Prompts[0].RegistryPromptVersion
+		return nil, nil
+	}
+
+	if _, err := ResolveAgent(context.Background(), registry, &models.Deployment{
+		ID:         "dep-123",
+		ServerName: "planner",
+		Version:    "1.0.0",
+	}, ""); err != nil {
+		t.Fatalf("ResolveAgent() unexpected error: %v", err)
+	}
+	if skillVersion != "1.2.3" {
+		t.Errorf("skill version = %q, want locked 1.2.3", skillVersion)
+	}
+	if promptVersion != "0.4.5" {
+		t.Errorf("prompt version = %q, want 0.4.5 resolved from ~0.4", promptVersion)
+	}
+}
+
+func TestResolveAgentRangesSkipDeletedAndForeignRegistries(t *testing.T) {
+	newRegistry := func(skill models.SkillRef) *servicetesting.FakeRegistry {
+		registry := servicetesting.NewFakeRegistry()
+		registry.Agents = []*models.AgentResponse{{
+			Agent: models.AgentJSON{
+				AgentManifest: models.AgentManifest{Name: "planner", Skills: []models.SkillRef{skill}},
+				Version:       "1.0.0",
+			},
+		}}
+		registry.GetAllVersionsBySkillNameFn = func(_ context.Context, name string) ([]*models.SkillResponse, error) {
+			skill := func(version, status string) *models.SkillResponse {
+				return &models.SkillResponse{
+					Skill: models.SkillJSON{Name: name, Version: version},
+					Meta:  models.SkillResponseMeta{Official: &models.SkillRegistryExtensions{Status: status}},
+				}
+			}
+			return []*models.SkillResponse{skill("1.2.0", "active"), skill("1.3.0", "deleted")}, nil
+		}
+		return registry
+	}
+	deployment := &models.Deployment{ID: "dep-123", ServerName: "planner", Version: "1.0.0"}
+
+	registry := newRegistry(models.SkillRef{Name: "pdf", RegistrySkillName: "acme/pdf", RegistrySkillVersion: "^1.2"})
+	var skillVersion string
+	registry.ResolveAgentManifestSkillsFn = func(_ context.Context, manifest *models.AgentManifest) ([]platformtypes.AgentSkillRef, error) {
+		skillVersion = manifest.Skills[0].RegistrySkillVersion
+		return nil, nil
+	}
+	if _, err := ResolveAgent(context.Background(), registry, deployment, ""); err != nil {
+		t.Fatalf("ResolveAgent() unexpected error: %v", err)
+	}
+	if skillVersion != "1.2.0" {
+		t.Errorf("skill version = %q, want 1.2.0 since 1.3.0 is deleted", skillVersion)
+	}
+
+	foreign := newRegistry(models.SkillRef{Name: "pdf", RegistrySkillName: "acme/pdf", RegistrySkillVersion: "^1.2", RegistryURL: "https://other.example.com"})
+	_, err := ResolveAgent(context.Background(), foreign, deployment, "")
+	if !errors.Is(err, database.ErrInvalidInput) || !strings.Contains(err.Error(), "https://other.example.com") {
+		t.Fatalf("ResolveAgent() error = %v, want invalid input naming the registry", err)
+	}
+}
+
 func TestResolveAgentNamespaceDefaulting(t *testing.T) {
 	newRegistry := func() *servicetesting.FakeRegistry {
 		r := servicetesting.NewFakeRegistry()
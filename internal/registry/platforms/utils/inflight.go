@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+)
+
+// ErrDeploymentCancelled is the cancellation cause for in-flight deployments
+// stopped through DeploymentPlatformAdapter.Cancel.
+var ErrDeploymentCancelled = errors.New("deployment was cancelled")
+
+// ErrDeploymentNotInFlight is returned by DeploymentPlatformAdapter.Cancel when
+// no Deploy for the deployment runs in this process. The Deploy may run on
+// another registry replica, so its platform state must not be touched here.
+var ErrDeploymentNotInFlight = fmt.Errorf("%w: deployment is not being applied by this registry instance", database.ErrConflict)
+
+// InFlightDeployments tracks cancel functions for deployments whose Deploy call
+// is still running, so that Cancel can stop them. The zero value is ready to use.
+type InFlightDeployments struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelCauseFunc
+}
+
+// Track derives a cancellable context for deploymentID. The returned release
+// function must be called once Deploy returns.
+func (t *InFlightDeployments) Track(ctx context.Context, deploymentID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	t.mu.Lock()
+	if t.cancels == nil {
+		t.cancels = map[string]context.CancelCauseFunc{}
+	}
+	t.cancels[deploymentID] = cancel
+	t.mu.Unlock()
+
+	return ctx, func() {
+		t.mu.Lock()
+		delete(t.cancels, deploymentID)
+		t.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// Cancel stops the in-flight Deploy for deploymentID. It reports whether a
+// running Deploy was found.
+func (t *InFlightDeployments) Cancel(deploymentID string) bool {
+	t.mu.Lock()
+	cancel, ok := t.cancels[deploymentID]
+	t.mu.Unlock()
+	if ok {
+		cancel(ErrDeploymentCancelled)
+	}
+	return ok
+}
+
+// IsDeploymentCancelled reports whether ctx was cancelled through Cancel.
+func IsDeploymentCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrDeploymentCancelled)
+}
+
+// CancelledDeploymentResult is the action result adapters return from Deploy
+// after an in-flight deployment was cancelled and rolled back.
+func CancelledDeploymentResult() *models.DeploymentActionResult {
+	return &models.DeploymentActionResult{
+		Status: models.DeploymentStatusCancelled,
+		Error:  ErrDeploymentCancelled.Error(),
+	}
+}
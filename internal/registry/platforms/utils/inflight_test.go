@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInFlightDeployments_CancelStopsTrackedContext(t *testing.T) {
+	var inFlight InFlightDeployments
+
+	ctx, release := inFlight.Track(context.Background(), "dep-1")
+	if IsDeploymentCancelled(ctx) {
+		t.Fatal("expected fresh context not to be cancelled")
+	}
+	if inFlight.Cancel("dep-other") {
+		t.Fatal("expected cancel of untracked deployment to report false")
+	}
+	if !inFlight.Cancel("dep-1") {
+		t.Fatal("expected cancel of tracked deployment to report true")
+	}
+	<-ctx.Done()
+	if !IsDeploymentCancelled(ctx) {
+		t.Fatalf("expected cancellation cause to be ErrDeploymentCancelled, got %v", context.Cause(ctx))
+	}
+
+	release()
+	if inFlight.Cancel("dep-1") {
+		t.Fatal("expected released deployment to no longer be tracked")
+	}
+}
+
+func TestInFlightDeployments_ReleaseIsNotCancellation(t *testing.T) {
+	var inFlight InFlightDeployments
+
+	ctx, release := inFlight.Track(context.Background(), "dep-1")
+	release()
+	<-ctx.Done()
+	if IsDeploymentCancelled(ctx) {
+		t.Fatal("expected release not to be reported as a cancellation")
+	}
+}
@@ -0,0 +1,139 @@
+// Package reconciler periodically compares managed deployments with the
+// workloads running on their platforms and probes their health.
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
+)
+
+// Reconciler records drift for managed deployments on every tick and, when
+// repair is enabled, re-applies desired state to missing and drifted
+// workloads. It then health probes the running deployments. Each pass runs as
+// an exclusive job, so when several replicas run a reconciler only one of them
+// reconciles at a time.
+type Reconciler struct {
+	registry   service.RegistryService
+	jobManager *jobs.Manager
+	interval   time.Duration
+	repair     bool
+	logger     *slog.Logger
+}
+
+// New creates a reconciler that runs every interval and registers its job
+// runner with jobManager. The runner is registered even when the interval
+// disables the reconciler, so this replica can run passes other replicas submit.
+func New(registry service.RegistryService, jobManager *jobs.Manager, interval time.Duration, repair bool) *Reconciler {
+	r := &Reconciler{
+		registry:   registry,
+		jobManager: jobManager,
+		interval:   interval,
+		repair:     repair,
+		logger:     slog.Default().With("component", "deployment-reconciler"),
+	}
+	jobManager.Register(jobs.DeploymentReconcileJobType, r.runJob, jobs.RunnerOptions{
+		Exclusive: true,
+		Authorize: jobs.RequireRegistryAdmin,
+	})
+	return r
+}
+
+// Run reconciles deployments until ctx is cancelled. A non-positive interval
+// disables the reconciler.
+func (r *Reconciler) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+	ctx = auth.WithSystemContext(ctx)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		r.runPass(ctx)
+	}
+}
+
+// runPass runs one reconcile pass as an exclusive job on this replica. The
+// pass is skipped while another replica's pass is pending or running.
+func (r *Reconciler) runPass(ctx context.Context) {
+	job, err := r.jobManager.Begin(ctx, jobs.DeploymentReconcileJobType, models.JSONObject{"repair": r.repair})
+	if errors.Is(err, jobs.ErrJobAlreadyRunning) {
+		r.logger.Debug("deployment reconcile already running on another replica")
+		return
+	}
+	if err != nil {
+		r.logger.Error("failed to start deployment reconcile", "error", err)
+		return
+	}
+	if _, err := r.jobManager.Execute(ctx, job, r.runJob); err != nil {
+		r.logger.Error("deployment reconcile failed", "job_id", job.ID, "error", err)
+	}
+}
+
+// runJob is the job runner for reconcile passes. The repair setting comes from
+// the replica that submitted the pass.
+func (r *Reconciler) runJob(ctx context.Context, job *jobs.Job, _ jobs.ProgressFunc) (*jobs.JobResult, error) {
+	repair, _ := job.Params["repair"].(bool)
+	r.tick(ctx, repair)
+	return &jobs.JobResult{}, nil
+}
+
+// Tick runs a single reconcile and probe pass and logs every status and
+// health change.
+func (r *Reconciler) Tick(ctx context.Context) {
+	r.tick(ctx, r.repair)
+}
+
+func (r *Reconciler) tick(ctx context.Context, repair bool) {
+	r.reconcile(ctx, repair)
+	r.probe(ctx)
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, repair bool) {
+	results, err := r.registry.ReconcileDeployments(ctx, repair)
+	if err != nil {
+		r.logger.Error("failed to reconcile deployments", "error", err)
+		return
+	}
+	for _, result := range results {
+		switch {
+		case result.Repaired:
+			r.logger.Info("deployment repaired", "deployment", result.DeploymentID, "reason", result.Reason)
+		case result.Status != result.PreviousStatus:
+			r.logger.Warn("deployment status changed",
+				"deployment", result.DeploymentID,
+				"from", result.PreviousStatus,
+				"to", result.Status,
+				"reason", result.Reason,
+			)
+		}
+	}
+}
+
+func (r *Reconciler) probe(ctx context.Context) {
+	results, err := r.registry.ProbeDeployments(ctx)
+	if err != nil {
+		r.logger.Error("failed to probe deployments", "error", err)
+		return
+	}
+	for _, result := range results {
+		wasHealthy := result.Previous == nil || result.Previous.Healthy
+		switch {
+		case wasHealthy && !result.Health.Healthy:
+			r.logger.Warn("deployment health probe failed", "deployment", result.DeploymentID, "error", result.Health.Error)
+		case !wasHealthy && result.Health.Healthy:
+			r.logger.Info("deployment health probe recovered", "deployment", result.DeploymentID)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	servicetesting "github.com/agentregistry-dev/agentregistry/internal/registry/service/testing"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+)
+
+func TestTick_PassesRepairSetting(t *testing.T) {
+	var gotRepair []bool
+	registry := servicetesting.NewFakeRegistry()
+	registry.ReconcileDeploymentsFn = func(_ context.Context, repair bool) ([]*models.DeploymentReconcileResult, error) {
+		gotRepair = append(gotRepair, repair)
+		return []*models.DeploymentReconcileResult{{
+			DeploymentID:   "dep-1",
+			PreviousStatus: models.DeploymentStatusDeployed,
+			Status:         models.DeploymentStatusMissing,
+		}}, nil
+	}
+
+	New(registry, jobs.NewManager(), time.Minute, false).Tick(context.Background())
+	New(registry, jobs.NewManager(), time.Minute, true).Tick(context.Background())
+
+	if len(gotRepair) != 2 || gotRepair[0] || !gotRepair[1] {
+		t.Fatalf("repair settings = %v, want [false true]", gotRepair)
+	}
+}
+
+func TestTick_ProbesAfterReconciling(t *testing.T) {
+	var calls []string
+	registry := servicetesting.NewFakeRegistry()
+	registry.ReconcileDeploymentsFn = func(context.Context, bool) ([]*models.DeploymentReconcileResult, error) {
+		calls = append(calls, "reconcile")
+		return nil, nil
+	}
+	registry.ProbeDeploymentsFn = func(context.Context) ([]*models.DeploymentHealthResult, error) {
+		calls = append(calls, "probe")
+		return []*models.DeploymentHealthResult{{
+			DeploymentID: "dep-1",
+			Previous:     &models.DeploymentHealth{Healthy: true},
+			Health:       &models.DeploymentHealth{Healthy: false, Error: "mcp initialize: connection refused"},
+		}}, nil
+	}
+
+	New(registry, jobs.NewManager(), time.Minute, false).Tick(context.Background())
+
+	if len(calls) != 2 || calls[0] != "reconcile" || calls[1] != "probe" {
+		t.Fatalf("calls = %v, want [reconcile probe]", calls)
+	}
+}
+
+func TestRun_DisabledWithoutInterval(t *testing.T) {
+	registry := servicetesting.NewFakeRegistry()
+	registry.ReconcileDeploymentsFn = func(context.Context, bool) ([]*models.DeploymentReconcileResult, error) {
+		t.Fatal("reconcile should not run when disabled")
+		return nil, nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		New(registry, jobs.NewManager(), 0, false).Run(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return for a zero interval")
+	}
+}
+
+func TestRunPass_RunsAsExclusiveJob(t *testing.T) {
+	var gotRepair []bool
+	registry := servicetesting.NewFakeRegistry()
+	registry.ReconcileDeploymentsFn = func(_ context.Context, repair bool) ([]*models.DeploymentReconcileResult, error) {
+		gotRepair = append(gotRepair, repair)
+		return nil, nil
+	}
+	manager := jobs.NewManager()
+	r := New(registry, manager, time.Minute, true)
+
+	r.runPass(context.Background())
+	if len(gotRepair) != 1 || !gotRepair[0] {
+		t.Fatalf("repair settings = %v, want [true]", gotRepair)
+	}
+
+	// Another replica's pass is still running, so this one is skipped.
+	if _, err := manager.Begin(context.Background(), jobs.DeploymentReconcileJobType, nil); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	r.runPass(context.Background())
+	if len(gotRepair) != 1 {
+		t.Fatalf("reconcile ran %d times while another pass was running, want 1", len(gotRepair))
+	}
+}
@@ -23,10 +23,11 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/embeddings"
-	"github.com/agentregistry-dev/agentregistry/internal/registry/importer"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/jobs"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/mirror"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/platforms/kubernetes"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/platforms/local"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/reconciler"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/seed"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/service"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/telemetry"
@@ -134,8 +135,9 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 	providerPlatforms := v0.DefaultProviderPlatformAdapters(registryService)
 	maps.Copy(providerPlatforms, options.ProviderPlatforms)
 	deploymentPlatforms := map[string]types.DeploymentPlatformAdapter{
-		"local":      local.NewLocalDeploymentAdapter(registryService, cfg.RuntimeDir, cfg.AgentGatewayPort),
-		"kubernetes": kubernetes.NewKubernetesDeploymentAdapter(registryService),
+		"local":             local.NewLocalDeploymentAdapter(registryService, cfg.RuntimeDir, cfg.AgentGatewayHost, cfg.AgentGatewayPort),
+		"kubernetes":        kubernetes.NewKubernetesDeploymentAdapter(registryService),
+		"kubernetes-native": kubernetes.NewKubernetesNativeDeploymentAdapter(registryService),
 	}
 	maps.Copy(deploymentPlatforms, options.DeploymentPlatforms)
 
@@ -163,25 +165,28 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 		}()
 	}
 
+	// Jobs are persisted in the registry database so that every replica sees
+	// them and exactly one replica executes each job.
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	jobManager := jobs.NewManager(jobs.WithStore(jobs.NewDatabaseStore(db)), jobs.WithAuthorizer(&authz))
+	registerDataJobRunners(jobManager, registryService, cfg, embeddingProvider)
+
 	// Import seed data if seed source is provided
 	if cfg.SeedFrom != "" {
 		slog.Info("importing data in the background", "seed_from", cfg.SeedFrom)
-		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-			defer cancel()
-
-			ctx = auth.WithSystemContext(ctx)
-
-			importerService := importer.NewService(registryService)
-			if embeddingProvider != nil {
-				importerService.SetEmbeddingProvider(embeddingProvider)
-				importerService.SetEmbeddingDimensions(cfg.Embeddings.Dimensions)
-				importerService.SetGenerateEmbeddings(cfg.Embeddings.Enabled)
-			}
-			if err := importerService.ImportFromPath(ctx, cfg.SeedFrom, cfg.EnrichServerData); err != nil {
-				slog.Error("failed to import seed data", "error", err)
-			}
-		}()
+		job, err := jobManager.Submit(auth.WithSystemContext(ctx), jobs.ImportJobType, map[string]any{
+			"source":           cfg.SeedFrom,
+			"enrichServerData": cfg.EnrichServerData,
+		})
+		switch {
+		case errors.Is(err, jobs.ErrJobAlreadyRunning):
+			slog.Info("seed import already running on another replica")
+		case err != nil:
+			slog.Error("failed to submit seed import job", "error", err)
+		default:
+			slog.Info("seed import job submitted", "job_id", job.ID)
+		}
 	}
 
 	slog.Info("starting agentregistry", "version", version.Version, "commit", version.GitCommit)
@@ -208,14 +213,13 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 		ProviderPlatforms:   providerPlatforms,
 		DeploymentPlatforms: deploymentPlatforms,
 		ExtraRoutes:         options.ExtraRoutes,
+		JobManager:          jobManager,
 	}
 
-	// Initialize job manager and indexer for embeddings.
+	// Initialize the indexer for embeddings.
 	if cfg.Embeddings.Enabled && embeddingProvider != nil {
-		jobManager := jobs.NewManager()
 		indexer := service.NewIndexer(registryService, embeddingProvider, cfg.Embeddings.Dimensions)
 		routeOpts.Indexer = indexer
-		routeOpts.JobManager = jobManager
 		slog.Info("embeddings indexing API enabled")
 	}
 
@@ -236,6 +240,7 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 	var mcpHTTPServer *http.Server
 	if cfg.MCPPort > 0 {
 		mcpServer := mcpregistry.NewServer(registryService)
+		go mcpregistry.NewPromptSync(mcpServer, registryService, cfg.MCPPromptSyncInterval).Run(jobsCtx)
 
 		var handler http.Handler = mcp.NewStreamableHTTPHandler(func(_ *http.Request) *mcp.Server {
 			return mcpServer
@@ -262,6 +267,17 @@ func App(_ context.Context, opts ...types.AppOptions) error {
 		}()
 	}
 
+	deploymentReconciler := reconciler.New(registryService, jobManager, cfg.DeploymentReconcileInterval, cfg.DeploymentReconcileRepair)
+
+	// Routes have registered their job runners; start claiming jobs.
+	jobManager.Start(jobsCtx)
+
+	// Periodically submit sync jobs for configured upstream mirrors.
+	go mirror.NewScheduler(registryService, jobManager).Run(auth.WithSystemContext(jobsCtx))
+
+	// Periodically compare managed deployments with live platform state.
+	go deploymentReconciler.Run(jobsCtx)
+
 	// Start server in a goroutine so it doesn't block signal handling
 	go func() {
 		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -0,0 +1,136 @@
+// Package secrets seals secret values with envelope encryption. Each value is
+// encrypted with its own random data key, and the data key is wrapped with the
+// registry master key, so only wrapped keys and ciphertext are ever stored.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// KeySize is the size in bytes of the master key and of each data key (AES-256).
+const KeySize = 32
+
+var (
+	// ErrNotConfigured is returned when no master key is configured.
+	ErrNotConfigured = errors.New("secrets master key is not configured")
+	// ErrKeyMismatch is returned when a secret was sealed under a different master key.
+	ErrKeyMismatch = errors.New("secret was encrypted with a different master key")
+)
+
+// Cipher seals and opens secret values under a master key.
+type Cipher struct {
+	master cipher.AEAD
+	keyID  string
+}
+
+// NewCipher creates a cipher from a hex- or base64-encoded 32-byte master key.
+// An empty key returns ErrNotConfigured.
+func NewCipher(masterKey string) (*Cipher, error) {
+	masterKey = strings.TrimSpace(masterKey)
+	if masterKey == "" {
+		return nil, ErrNotConfigured
+	}
+	key, err := decodeKey(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return &Cipher{master: aead, keyID: hex.EncodeToString(sum[:8])}, nil
+}
+
+func decodeKey(s string) ([]byte, error) {
+	if key, err := hex.DecodeString(s); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if key, err := enc.DecodeString(s); err == nil && len(key) == KeySize {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("secrets master key must be %d bytes, hex or base64 encoded", KeySize)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// KeyID identifies the master key without revealing it.
+func (c *Cipher) KeyID() string {
+	return c.keyID
+}
+
+// Seal encrypts value with a fresh data key and wraps the data key with the
+// master key. The secret name is bound to the ciphertext so a stored value
+// cannot be moved to another secret.
+func (c *Cipher) Seal(name string, value []byte) (ciphertext, wrappedKey []byte, err error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	ciphertext, err = seal(aead, value, []byte(name))
+	if err != nil {
+		return nil, nil, err
+	}
+	wrappedKey, err = seal(c.master, dataKey, []byte(c.keyID))
+	if err != nil {
+		return nil, nil, err
+	}
+	return ciphertext, wrappedKey, nil
+}
+
+// Open unwraps the data key and decrypts a value sealed by Seal.
+func (c *Cipher) Open(name string, ciphertext, wrappedKey []byte, keyID string) ([]byte, error) {
+	if keyID != c.keyID {
+		return nil, ErrKeyMismatch
+	}
+	dataKey, err := open(c.master, wrappedKey, []byte(c.keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	value, err := open(aead, ciphertext, []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret: %w", err)
+	}
+	return value, nil
+}
+
+// seal returns nonce || ciphertext.
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
@@ -0,0 +1,68 @@
+package secrets_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/agentregistry-dev/agentregistry/internal/registry/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func TestCipher_SealOpen(t *testing.T) {
+	c, err := secrets.NewCipher(testKey)
+	require.NoError(t, err)
+
+	ciphertext, wrappedKey, err := c.Seal("openai-key", []byte("sk-test"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "sk-test")
+
+	value, err := c.Open("openai-key", ciphertext, wrappedKey, c.KeyID())
+	require.NoError(t, err)
+	assert.Equal(t, "sk-test", string(value))
+
+	// The ciphertext is bound to the secret name.
+	_, err = c.Open("other", ciphertext, wrappedKey, c.KeyID())
+	require.Error(t, err)
+
+	// Sealing the same value twice uses fresh data keys.
+	again, _, err := c.Seal("openai-key", []byte("sk-test"))
+	require.NoError(t, err)
+	assert.NotEqual(t, ciphertext, again)
+}
+
+func TestCipher_OtherMasterKey(t *testing.T) {
+	c, err := secrets.NewCipher(testKey)
+	require.NoError(t, err)
+	ciphertext, wrappedKey, err := c.Seal("token", []byte("value"))
+	require.NoError(t, err)
+
+	other, err := secrets.NewCipher(strings.Repeat("ab", secrets.KeySize))
+	require.NoError(t, err)
+	assert.NotEqual(t, c.KeyID(), other.KeyID())
+
+	_, err = other.Open("token", ciphertext, wrappedKey, c.KeyID())
+	require.ErrorIs(t, err, secrets.ErrKeyMismatch)
+}
+
+func TestNewCipher_KeyFormats(t *testing.T) {
+	hexCipher, err := secrets.NewCipher(testKey)
+	require.NoError(t, err)
+
+	raw := make([]byte, secrets.KeySize)
+	for i := range raw {
+		raw[i] = byte(i)
+	}
+	b64Cipher, err := secrets.NewCipher(base64.StdEncoding.EncodeToString(raw))
+	require.NoError(t, err)
+	assert.Equal(t, hexCipher.KeyID(), b64Cipher.KeyID())
+
+	_, err = secrets.NewCipher("")
+	require.ErrorIs(t, err, secrets.ErrNotConfigured)
+
+	_, err = secrets.NewCipher("too-short")
+	require.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package seed
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// BundleAPIVersion is the version of the registry bundle format written by the exporter.
+	BundleAPIVersion = "agentregistry.dev/v1"
+	// BundleKind identifies a registry bundle document.
+	BundleKind = "RegistryBundle"
+)
+
+// Bundle is a versioned, multi-kind snapshot of registry content. It carries every
+// version of every server, agent, skill and prompt with its status (and the
+// successor of deprecated agent, skill and prompt versions), server READMEs,
+// the skill bundles that registry-hosted skills point at, and deployment providers,
+// so a registry can be backed up or migrated in one file.
+type Bundle struct {
+	APIVersion string           `json:"apiVersion"`
+	Kind       string           `json:"kind"`
+	ExportedAt time.Time        `json:"exportedAt"`
+	Servers    []BundleServer   `json:"servers,omitempty"`
+	Agents     []BundleAgent    `json:"agents,omitempty"`
+	Skills     []BundleSkill    `json:"skills,omitempty"`
+	Prompts    []BundlePrompt   `json:"prompts,omitempty"`
+	Providers  []BundleProvider `json:"providers,omitempty"`
+}
+
+// BundleServer is a server version stored in a bundle.
+type BundleServer struct {
+	Server apiv0.ServerJSON `json:"server"`
+	Status string           `json:"status,omitempty"`
+	Readme *ReadmeEntry     `json:"readme,omitempty"`
+}
+
+// BundleAgent is an agent version stored in a bundle.
+type BundleAgent struct {
+	Agent     models.AgentJSON `json:"agent"`
+	Status    string           `json:"status,omitempty"`
+	Successor string           `json:"successor,omitempty"`
+}
+
+// BundleSkill is a skill version stored in a bundle. Bundles holds the content
+// of every registry-hosted package of the version.
+type BundleSkill struct {
+	Skill     models.SkillJSON   `json:"skill"`
+	Status    string             `json:"status,omitempty"`
+	Successor string             `json:"successor,omitempty"`
+	Bundles   []SkillBundleEntry `json:"bundles,omitempty"`
+}
+
+// SkillBundleEntry is a skill bundle archive stored in a bundle.
+type SkillBundleEntry struct {
+	Digest  string `json:"digest"`
+	Content string `json:"content"`
+}
+
+// EncodeSkillBundle produces a SkillBundleEntry from a skill bundle archive.
+func EncodeSkillBundle(content []byte) SkillBundleEntry {
+	return SkillBundleEntry{
+		Digest:  skillbundle.Digest(content),
+		Content: base64.StdEncoding.EncodeToString(content),
+	}
+}
+
+// Decode returns the archive of a skill bundle entry after checking it against
+// the entry digest.
+func (e SkillBundleEntry) Decode() ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(e.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode skill bundle %s: %w", e.Digest, err)
+	}
+	if err := skillbundle.Verify(data, e.Digest); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// BundlePrompt is a prompt version stored in a bundle.
+type BundlePrompt struct {
+	Prompt    models.PromptJSON `json:"prompt"`
+	Status    string            `json:"status,omitempty"`
+	Successor string            `json:"successor,omitempty"`
+}
+
+// BundleProvider is a deployment provider stored in a bundle.
+type BundleProvider struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Platform string         `json:"platform"`
+	Config   map[string]any `json:"config,omitempty"`
+}
+
+// NewBundle returns an empty bundle stamped with the current format version.
+func NewBundle() *Bundle {
+	return &Bundle{
+		APIVersion: BundleAPIVersion,
+		Kind:       BundleKind,
+		ExportedAt: time.Now().UTC(),
+	}
+}
+
+// Count returns the number of entries in the bundle across all kinds.
+func (b *Bundle) Count() int {
+	return len(b.Servers) + len(b.Agents) + len(b.Skills) + len(b.Prompts) + len(b.Providers)
+}
+
+// MarshalBundle encodes a bundle as indented JSON, or as YAML when asYAML is set.
+func MarshalBundle(b *Bundle, asYAML bool) ([]byte, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+	if !asYAML {
+		return data, nil
+	}
+	out, err := yaml.JSONToYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert bundle to YAML: %w", err)
+	}
+	return out, nil
+}
+
+// IsBundle reports whether data is a registry bundle document (JSON or YAML)
+// rather than a legacy ServerJSON array seed.
+func IsBundle(data []byte) bool {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] == '[' {
+		return false
+	}
+	var header struct {
+		Kind string `json:"kind"`
+	}
+	if err := yaml.Unmarshal(trimmed, &header); err != nil {
+		return false
+	}
+	return header.Kind == BundleKind
+}
+
+// ParseBundle decodes a JSON or YAML bundle and checks its kind and format version.
+func ParseBundle(data []byte) (*Bundle, error) {
+	var b Bundle
+	if err := yaml.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle: %w", err)
+	}
+	if b.Kind != BundleKind {
+		return nil, fmt.Errorf("unexpected bundle kind %q (expected %q)", b.Kind, BundleKind)
+	}
+	if b.APIVersion != BundleAPIVersion {
+		return nil, fmt.Errorf("unsupported bundle apiVersion %q (expected %q)", b.APIVersion, BundleAPIVersion)
+	}
+	return &b, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	versionpkg "github.com/agentregistry-dev/agentregistry/internal/version"
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// LockAgentManifest returns the agent's manifest with registry references
+// pinned to the versions in the agent's lock. References the lock does not
+// cover, such as ranges in an agent published without a lock, are resolved
+// against the versions published in this registry. A range on a reference
+// that names a registry URL is only resolved from the lock: the versions of
+// that registry are not known here, and this registry's may differ.
+func LockAgentManifest(ctx context.Context, registryService RegistryService, agent *models.AgentJSON) (*models.AgentManifest, error) {
+	lock, err := agent.Lock.Complete(&agent.AgentManifest, func(kind models.RegistryRefKind, ref models.RegistryRef) (string, error) {
+		return versionpkg.Select(ref.Constraints, func() ([]string, error) {
+			if ref.RegistryURL != "" {
+				return nil, fmt.Errorf("%w: the range refers to registry %s and is not in the agent's lock; publish the agent with a lock", database.ErrInvalidInput, ref.RegistryURL)
+			}
+			return listRegistryVersions(ctx, registryService, kind, ref.Name)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lock agent %s@%s: %w", agent.Name, agent.Version, err)
+	}
+	return agent.AgentManifest.Pinned(lock), nil
+}
+
+// listRegistryVersions lists the versions of a registry artifact a range may
+// select. Deleted versions are left out.
+func listRegistryVersions(ctx context.Context, registryService RegistryService, kind models.RegistryRefKind, name string) ([]string, error) {
+	var versions []string
+	switch kind {
+	case models.RegistryRefMCPServer:
+		servers, err := registryService.GetAllVersionsByServerName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range servers {
+			if server.Meta.Official != nil && server.Meta.Official.Status == model.StatusDeleted {
+				continue
+			}
+			versions = append(versions, server.Server.Version)
+		}
+	case models.RegistryRefSkill:
+		skills, err := registryService.GetAllVersionsBySkillName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, skill := range skills {
+			if skill.Meta.Official != nil && skill.Meta.Official.Status == string(model.StatusDeleted) {
+				continue
+			}
+			versions = append(versions, skill.Skill.Version)
+		}
+	case models.RegistryRefPrompt:
+		prompts, err := registryService.GetAllVersionsByPromptName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, prompt := range prompts {
+			if prompt.Meta.Official != nil && prompt.Meta.Official.Status == string(model.StatusDeleted) {
+				continue
+			}
+			versions = append(versions, prompt.Prompt.Version)
+		}
+	}
+	return versions, nil
+}
@@ -17,6 +17,8 @@ type IndexOptions struct {
 	DryRun         bool `json:"dryRun"`
 	IncludeServers bool `json:"includeServers"`
 	IncludeAgents  bool `json:"includeAgents"`
+	IncludeSkills  bool `json:"includeSkills"`
+	IncludePrompts bool `json:"includePrompts"`
 }
 
 // IndexStats tracks progress for a resource type.
@@ -31,10 +33,12 @@ type IndexStats struct {
 type IndexResult struct {
 	Servers IndexStats `json:"servers"`
 	Agents  IndexStats `json:"agents"`
+	Skills  IndexStats `json:"skills"`
+	Prompts IndexStats `json:"prompts"`
 }
 
 // IndexProgressCallback is called with progress updates during indexing.
-// resource is "servers" or "agents".
+// resource is "servers", "agents", "skills" or "prompts".
 type IndexProgressCallback func(resource string, stats IndexStats)
 
 // Indexer defines the interface for embedding indexing operations.
@@ -66,8 +70,8 @@ func (s *indexerImpl) Run(ctx context.Context, opts IndexOptions, onProgress Ind
 		return nil, errors.New("embedding provider is not configured")
 	}
 
-	if !opts.IncludeServers && !opts.IncludeAgents {
-		return nil, errors.New("no targets selected; enable includeServers or includeAgents")
+	if !opts.IncludeServers && !opts.IncludeAgents && !opts.IncludeSkills && !opts.IncludePrompts {
+		return nil, errors.New("no targets selected; enable includeServers, includeAgents, includeSkills or includePrompts")
 	}
 
 	if opts.BatchSize <= 0 {
@@ -92,108 +96,139 @@ func (s *indexerImpl) Run(ctx context.Context, opts IndexOptions, onProgress Ind
 		result.Agents = stats
 	}
 
-	return result, nil
-}
-
-func (s *indexerImpl) indexServers(ctx context.Context, opts IndexOptions, onProgress IndexProgressCallback) (IndexStats, error) {
-	var (
-		stats  IndexStats
-		cursor string
-	)
-
-	const progressInterval = 100
-
-	for {
-		select {
-		case <-ctx.Done():
-			return stats, ctx.Err()
-		default:
+	if opts.IncludeSkills {
+		stats, err := s.indexSkills(ctx, opts, onProgress)
+		if err != nil {
+			return nil, err
 		}
+		result.Skills = stats
+	}
 
-		servers, nextCursor, err := s.registry.ListServers(ctx, nil, cursor, opts.BatchSize)
+	if opts.IncludePrompts {
+		stats, err := s.indexPrompts(ctx, opts, onProgress)
 		if err != nil {
-			return stats, err
-		}
-		if len(servers) == 0 {
-			break
+			return nil, err
 		}
+		result.Prompts = stats
+	}
 
-		for _, server := range servers {
-			select {
-			case <-ctx.Done():
-				return stats, ctx.Err()
-			default:
-			}
-
-			stats.Processed++
-			name := server.Server.Name
-			version := server.Server.Version
-			payload := embeddings.BuildServerEmbeddingPayload(&server.Server)
+	return result, nil
+}
 
-			if strings.TrimSpace(payload) == "" {
-				s.logger.Info("skipping server: empty embedding payload", "name", name, "version", version)
-				stats.Skipped++
-				continue
+func (s *indexerImpl) indexServers(ctx context.Context, opts IndexOptions, onProgress IndexProgressCallback) (IndexStats, error) {
+	return s.indexResources(ctx, opts, onProgress, indexTarget{
+		resource: "servers",
+		kind:     "server",
+		list: func(ctx context.Context, cursor string, limit int) ([]indexItem, string, error) {
+			servers, next, err := s.registry.ListServers(ctx, nil, cursor, limit)
+			if err != nil {
+				return nil, "", err
+			}
+			items := make([]indexItem, len(servers))
+			for i, server := range servers {
+				items[i] = indexItem{
+					name:    server.Server.Name,
+					version: server.Server.Version,
+					payload: embeddings.BuildServerEmbeddingPayload(&server.Server),
+				}
 			}
+			return items, next, nil
+		},
+		metadata: s.registry.GetServerEmbeddingMetadata,
+		upsert:   s.registry.UpsertServerEmbedding,
+	})
+}
 
-			payloadChecksum := embeddings.PayloadChecksum(payload)
-			meta, err := s.registry.GetServerEmbeddingMetadata(ctx, name, version)
-			if err != nil && !errors.Is(err, database.ErrNotFound) {
-				s.logger.Error("failed to read server embedding metadata", "name", name, "version", version, "error", err)
-				stats.Failures++
-				continue
+func (s *indexerImpl) indexAgents(ctx context.Context, opts IndexOptions, onProgress IndexProgressCallback) (IndexStats, error) {
+	return s.indexResources(ctx, opts, onProgress, indexTarget{
+		resource: "agents",
+		kind:     "agent",
+		list: func(ctx context.Context, cursor string, limit int) ([]indexItem, string, error) {
+			agents, next, err := s.registry.ListAgents(ctx, nil, cursor, limit)
+			if err != nil {
+				return nil, "", err
 			}
-			if errors.Is(err, database.ErrNotFound) {
-				meta = &database.SemanticEmbeddingMetadata{}
+			items := make([]indexItem, len(agents))
+			for i, agent := range agents {
+				items[i] = indexItem{
+					name:    agent.Agent.Name,
+					version: agent.Agent.Version,
+					payload: embeddings.BuildAgentEmbeddingPayload(&agent.Agent),
+				}
 			}
+			return items, next, nil
+		},
+		metadata: s.registry.GetAgentEmbeddingMetadata,
+		upsert:   s.registry.UpsertAgentEmbedding,
+	})
+}
 
-			hasEmbedding := meta != nil && meta.HasEmbedding
-			needsUpdate := opts.Force || !hasEmbedding || meta.Checksum != payloadChecksum
-			if !needsUpdate {
-				stats.Skipped++
-				continue
+func (s *indexerImpl) indexSkills(ctx context.Context, opts IndexOptions, onProgress IndexProgressCallback) (IndexStats, error) {
+	return s.indexResources(ctx, opts, onProgress, indexTarget{
+		resource: "skills",
+		kind:     "skill",
+		list: func(ctx context.Context, cursor string, limit int) ([]indexItem, string, error) {
+			skills, next, err := s.registry.ListSkills(ctx, nil, cursor, limit)
+			if err != nil {
+				return nil, "", err
 			}
-
-			if opts.DryRun {
-				s.logger.Info("dry run: would upsert server embedding", "name", name, "version", version, "existing", hasEmbedding, "checksum", meta.Checksum)
-				stats.Updated++
-				continue
+			items := make([]indexItem, len(skills))
+			for i, skill := range skills {
+				items[i] = indexItem{
+					name:    skill.Skill.Name,
+					version: skill.Skill.Version,
+					payload: embeddings.BuildSkillEmbeddingPayload(&skill.Skill),
+				}
 			}
+			return items, next, nil
+		},
+		metadata: s.registry.GetSkillEmbeddingMetadata,
+		upsert:   s.registry.UpsertSkillEmbedding,
+	})
+}
 
-			record, err := embeddings.GenerateSemanticEmbedding(ctx, s.provider, payload, s.dimensions)
+func (s *indexerImpl) indexPrompts(ctx context.Context, opts IndexOptions, onProgress IndexProgressCallback) (IndexStats, error) {
+	return s.indexResources(ctx, opts, onProgress, indexTarget{
+		resource: "prompts",
+		kind:     "prompt",
+		list: func(ctx context.Context, cursor string, limit int) ([]indexItem, string, error) {
+			prompts, next, err := s.registry.ListPrompts(ctx, nil, cursor, limit)
 			if err != nil {
-				s.logger.Error("failed to generate server embedding", "name", name, "version", version, "error", err)
-				stats.Failures++
-				continue
+				return nil, "", err
 			}
-
-			if err := s.registry.UpsertServerEmbedding(ctx, name, version, record); err != nil {
-				s.logger.Error("failed to persist server embedding", "name", name, "version", version, "error", err)
-				stats.Failures++
-				continue
+			items := make([]indexItem, len(prompts))
+			for i, prompt := range prompts {
+				items[i] = indexItem{
+					name:    prompt.Prompt.Name,
+					version: prompt.Prompt.Version,
+					payload: embeddings.BuildPromptEmbeddingPayload(&prompt.Prompt),
+				}
 			}
-			stats.Updated++
-		}
-
-		if stats.Processed%progressInterval == 0 && onProgress != nil {
-			onProgress("servers", stats)
-		}
-
-		if nextCursor == "" {
-			break
-		}
-		cursor = nextCursor
-	}
+			return items, next, nil
+		},
+		metadata: s.registry.GetPromptEmbeddingMetadata,
+		upsert:   s.registry.UpsertPromptEmbedding,
+	})
+}
 
-	// Final progress callback
-	if onProgress != nil {
-		onProgress("servers", stats)
-	}
+// indexTarget describes how to page through one resource type and read and
+// write its embeddings.
+type indexTarget struct {
+	resource string // progress key, e.g. "servers"
+	kind     string // singular name used in log messages, e.g. "server"
+	list     func(ctx context.Context, cursor string, limit int) ([]indexItem, string, error)
+	metadata func(ctx context.Context, name, version string) (*database.SemanticEmbeddingMetadata, error)
+	upsert   func(ctx context.Context, name, version string, embedding *database.SemanticEmbedding) error
+}
 
-	return stats, nil
+// indexItem is a resource version together with its embedding payload.
+type indexItem struct {
+	name    string
+	version string
+	payload string
 }
 
-func (s *indexerImpl) indexAgents(ctx context.Context, opts IndexOptions, onProgress IndexProgressCallback) (IndexStats, error) {
+func (s *indexerImpl) indexResources(ctx context.Context, opts IndexOptions, onProgress IndexProgressCallback, target indexTarget) (IndexStats, error) {
 	var (
 		stats  IndexStats
 		cursor string
@@ -208,15 +243,16 @@ func (s *indexerImpl) indexAgents(ctx context.Context, opts IndexOptions, onProg
 		default:
 		}
 
-		agents, nextCursor, err := s.registry.ListAgents(ctx, nil, cursor, opts.BatchSize)
+		items, nextCursor, err := target.list(ctx, cursor, opts.BatchSize)
 		if err != nil {
 			return stats, err
 		}
-		if len(agents) == 0 {
+		if len(items) == 0 {
 			break
 		}
 
-		for _, agent := range agents {
+		var pending []indexItem
+		for _, item := range items {
 			select {
 			case <-ctx.Done():
 				return stats, ctx.Err()
@@ -224,20 +260,18 @@ func (s *indexerImpl) indexAgents(ctx context.Context, opts IndexOptions, onProg
 			}
 
 			stats.Processed++
-			name := agent.Agent.Name
-			version := agent.Agent.Version
-			payload := embeddings.BuildAgentEmbeddingPayload(&agent.Agent)
+			name, version, payload := item.name, item.version, item.payload
 
 			if strings.TrimSpace(payload) == "" {
-				s.logger.Info("skipping agent: empty embedding payload", "name", name, "version", version)
+				s.logger.Info("skipping "+target.kind+": empty embedding payload", "name", name, "version", version)
 				stats.Skipped++
 				continue
 			}
 
 			payloadChecksum := embeddings.PayloadChecksum(payload)
-			meta, err := s.registry.GetAgentEmbeddingMetadata(ctx, name, version)
+			meta, err := target.metadata(ctx, name, version)
 			if err != nil && !errors.Is(err, database.ErrNotFound) {
-				s.logger.Error("failed to read agent embedding metadata", "name", name, "version", version, "error", err)
+				s.logger.Error("failed to read "+target.kind+" embedding metadata", "name", name, "version", version, "error", err)
 				stats.Failures++
 				continue
 			}
@@ -253,20 +287,23 @@ func (s *indexerImpl) indexAgents(ctx context.Context, opts IndexOptions, onProg
 			}
 
 			if opts.DryRun {
-				s.logger.Info("dry run: would upsert agent embedding", "name", name, "version", version, "existing", hasEmbedding, "checksum", meta.Checksum)
+				s.logger.Info("dry run: would upsert "+target.kind+" embedding", "name", name, "version", version, "existing", hasEmbedding, "checksum", meta.Checksum)
 				stats.Updated++
 				continue
 			}
 
-			record, err := embeddings.GenerateSemanticEmbedding(ctx, s.provider, payload, s.dimensions)
-			if err != nil {
-				s.logger.Error("failed to generate agent embedding", "name", name, "version", version, "error", err)
+			pending = append(pending, item)
+		}
+
+		records, errs := s.generateEmbeddings(ctx, pending)
+		for i, item := range pending {
+			if errs[i] != nil {
+				s.logger.Error("failed to generate "+target.kind+" embedding", "name", item.name, "version", item.version, "error", errs[i])
 				stats.Failures++
 				continue
 			}
-
-			if err := s.registry.UpsertAgentEmbedding(ctx, name, version, record); err != nil {
-				s.logger.Error("failed to persist agent embedding", "name", name, "version", version, "error", err)
+			if err := target.upsert(ctx, item.name, item.version, records[i]); err != nil {
+				s.logger.Error("failed to persist "+target.kind+" embedding", "name", item.name, "version", item.version, "error", err)
 				stats.Failures++
 				continue
 			}
@@ -274,7 +311,7 @@ func (s *indexerImpl) indexAgents(ctx context.Context, opts IndexOptions, onProg
 		}
 
 		if stats.Processed%progressInterval == 0 && onProgress != nil {
-			onProgress("agents", stats)
+			onProgress(target.resource, stats)
 		}
 
 		if nextCursor == "" {
@@ -285,8 +322,39 @@ func (s *indexerImpl) indexAgents(ctx context.Context, opts IndexOptions, onProg
 
 	// Final progress callback
 	if onProgress != nil {
-		onProgress("agents", stats)
+		onProgress(target.resource, stats)
 	}
 
 	return stats, nil
 }
+
+// generateEmbeddings embeds a page of payloads with one batched provider call.
+// If the batch fails, it falls back to one call per payload so that a single
+// bad payload only fails its own resource. The returned slices are indexed like
+// pending.
+func (s *indexerImpl) generateEmbeddings(ctx context.Context, pending []indexItem) ([]*database.SemanticEmbedding, []error) {
+	records := make([]*database.SemanticEmbedding, len(pending))
+	errs := make([]error, len(pending))
+	if len(pending) == 0 {
+		return records, errs
+	}
+
+	payloads := make([]string, len(pending))
+	for i, item := range pending {
+		payloads[i] = item.payload
+	}
+	batch, err := embeddings.GenerateSemanticEmbeddings(ctx, s.provider, payloads, s.dimensions)
+	if err == nil {
+		return batch, errs
+	}
+	if len(pending) == 1 {
+		errs[0] = err
+		return records, errs
+	}
+	s.logger.Warn("batch embedding failed; retrying payloads individually", "count", len(pending), "error", err)
+
+	for i, payload := range payloads {
+		records[i], errs[i] = embeddings.GenerateSemanticEmbedding(ctx, s.provider, payload, s.dimensions)
+	}
+	return records, errs
+}
@@ -3,6 +3,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"sync"
 	"testing"
 
@@ -20,6 +22,7 @@ import (
 type mockProvider struct {
 	generateFunc func(ctx context.Context, payload embeddings.Payload) (*embeddings.Result, error)
 	callCount    int
+	batchSizes   []int
 	mu           sync.Mutex
 }
 
@@ -38,6 +41,21 @@ func (m *mockProvider) Generate(ctx context.Context, payload embeddings.Payload)
 	}, nil
 }
 
+func (m *mockProvider) GenerateBatch(ctx context.Context, payloads []embeddings.Payload) ([]*embeddings.Result, error) {
+	m.mu.Lock()
+	m.batchSizes = append(m.batchSizes, len(payloads))
+	m.mu.Unlock()
+	results := make([]*embeddings.Result, 0, len(payloads))
+	for _, payload := range payloads {
+		result, err := m.Generate(ctx, payload)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 func (m *mockProvider) getCallCount() int {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -462,3 +480,78 @@ func TestIndexer_Run_DefaultBatchSize(t *testing.T) {
 	require.NotNil(t, result)
 	assert.Equal(t, 1, result.Servers.Processed)
 }
+
+func TestIndexer_Run_EmbedsPageInOneBatch(t *testing.T) {
+	mockRegistry := servicetesting.NewFakeRegistry()
+	for _, name := range []string{"com.example/a", "com.example/b", "com.example/c"} {
+		mockRegistry.Servers = append(mockRegistry.Servers, &apiv0.ServerResponse{
+			Server: apiv0.ServerJSON{Name: name, Version: "1.0.0", Description: "server " + name},
+		})
+	}
+
+	mockProv := &mockProvider{}
+	indexer := NewIndexer(mockRegistry, mockProv, 1536)
+
+	result, err := indexer.Run(context.Background(), IndexOptions{IncludeServers: true}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Servers.Updated)
+	assert.Equal(t, []int{3}, mockProv.batchSizes)
+	assert.Equal(t, 3, mockRegistry.UpsertServerEmbeddingCalls)
+}
+
+func TestIndexer_Run_BatchFailureFallsBackPerPayload(t *testing.T) {
+	mockRegistry := servicetesting.NewFakeRegistry()
+	for _, name := range []string{"com.example/good", "com.example/bad", "com.example/fine"} {
+		mockRegistry.Servers = append(mockRegistry.Servers, &apiv0.ServerResponse{
+			Server: apiv0.ServerJSON{Name: name, Version: "1.0.0", Description: "server " + name},
+		})
+	}
+
+	mockProv := &mockProvider{
+		generateFunc: func(ctx context.Context, payload embeddings.Payload) (*embeddings.Result, error) {
+			if strings.Contains(payload.Text, "com.example/bad") {
+				return nil, errors.New("payload rejected")
+			}
+			return &embeddings.Result{Vector: make([]float32, 1536), Dimensions: 1536}, nil
+		},
+	}
+	indexer := NewIndexer(mockRegistry, mockProv, 1536)
+
+	result, err := indexer.Run(context.Background(), IndexOptions{IncludeServers: true}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Servers.Updated)
+	assert.Equal(t, 1, result.Servers.Failures)
+	assert.Equal(t, 2, mockRegistry.UpsertServerEmbeddingCalls)
+}
+
+func TestIndexer_Run_SkillsAndPrompts(t *testing.T) {
+	mockRegistry := servicetesting.NewFakeRegistry()
+	mockRegistry.Skills = []*models.SkillResponse{
+		{Skill: models.SkillJSON{Name: "pdf-tools", Description: "Extract text from PDFs", Version: "1.0.0"}},
+		{Skill: models.SkillJSON{Name: "git-helper", Description: "Summarize git history", Version: "0.2.0"}},
+	}
+	mockRegistry.Prompts = []*models.PromptResponse{
+		{Prompt: models.PromptJSON{Name: "reviewer", Version: "1.0.0", Content: "You review pull requests."}},
+	}
+	mockRegistry.PromptEmbeddingMeta["reviewer@1.0.0"] = &database.SemanticEmbeddingMetadata{
+		HasEmbedding: true,
+		Checksum:     embeddings.PayloadChecksum(embeddings.BuildPromptEmbeddingPayload(&mockRegistry.Prompts[0].Prompt)),
+	}
+
+	var progressed []string
+	indexer := NewIndexer(mockRegistry, &mockProvider{}, 1536)
+	result, err := indexer.Run(context.Background(), IndexOptions{IncludeSkills: true, IncludePrompts: true}, func(resource string, _ IndexStats) {
+		progressed = append(progressed, resource)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Skills.Updated)
+	assert.Equal(t, 1, result.Prompts.Processed)
+	assert.Equal(t, 1, result.Prompts.Skipped)
+	assert.Equal(t, 2, mockRegistry.UpsertSkillEmbeddingCalls)
+	assert.Equal(t, 0, mockRegistry.UpsertPromptEmbeddingCalls)
+	assert.Equal(t, 0, mockRegistry.UpsertServerEmbeddingCalls)
+	assert.Equal(t, []string{"skills", "prompts"}, progressed)
+}
@@ -8,16 +8,26 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"maps"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/audit"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/embeddings"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/health"
 	api "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/secrets"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/validators"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
 	registrytypes "github.com/agentregistry-dev/agentregistry/pkg/types"
 	"github.com/jackc/pgx/v5"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -27,9 +37,16 @@ import (
 const (
 	maxServerVersionsPerServer = 10000
 
+	// Skill bundles that no version references are deleted once they are older
+	// than skillBundleGracePeriod, which leaves time to publish the version
+	// that uses them. A skill holds at most maxUnreferencedSkillBundles of them.
+	skillBundleGracePeriod      = 24 * time.Hour
+	maxUnreferencedSkillBundles = 20
+
 	resourceTypeMCP   = "mcp"
 	resourceTypeAgent = "agent"
 	originDiscovered  = "discovered"
+	originManaged     = "managed"
 )
 
 // UnsupportedDeploymentPlatformError is returned when no deployment adapter is
@@ -64,6 +81,13 @@ type registryServiceImpl struct {
 	embeddingsProvider embeddings.Provider
 	deploymentAdapters map[string]registrytypes.DeploymentPlatformAdapter
 	logger             *slog.Logger
+	// secretCipher seals secret values; secretCipherErr explains why it is nil.
+	secretCipher    *secrets.Cipher
+	secretCipherErr error
+	// trustPolicy verifies artifact signatures; trustPolicyErr explains why it is nil.
+	trustPolicy    *signing.TrustPolicy
+	trustPolicyErr error
+	healthProber   *health.Prober
 }
 
 // DeploymentPlatformStaleCleaner is an optional adapter hook for stale deployment replacement.
@@ -71,18 +95,73 @@ type DeploymentPlatformStaleCleaner interface {
 	CleanupStale(ctx context.Context, deployment *models.Deployment) error
 }
 
+// DeploymentPlatformLogStreamer is an optional adapter hook for filtered and followed log retrieval.
+// emit is called once per log line; returning an error from emit stops the stream.
+type DeploymentPlatformLogStreamer interface {
+	StreamLogs(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions, emit func(line string) error) error
+}
+
+// DeploymentPlatformUpdater is an optional adapter hook for rolling a deployment
+// to a new configuration in place. Adapters without it are re-applied with Deploy.
+type DeploymentPlatformUpdater interface {
+	Update(ctx context.Context, previous, desired *models.Deployment) (*models.DeploymentActionResult, error)
+}
+
+// DeploymentPlatformObserver is an optional adapter hook for comparing a managed
+// deployment with the workload running on the platform. Adapters without it
+// are skipped by the reconciler.
+type DeploymentPlatformObserver interface {
+	Observe(ctx context.Context, deployment *models.Deployment) (*models.DeploymentObservation, error)
+}
+
+// DeploymentPlatformProbeResolver is an optional adapter hook that tells the
+// health prober where a deployment answers. Adapters without it are not probed.
+type DeploymentPlatformProbeResolver interface {
+	ProbeTarget(ctx context.Context, deployment *models.Deployment) (*models.DeploymentProbeTarget, error)
+}
+
+// DeploymentPlatformRenderer is an optional adapter hook that returns the
+// manifests Deploy would apply instead of applying them. Adapters without it
+// cannot render deployments.
+type DeploymentPlatformRenderer interface {
+	Render(ctx context.Context, deployment *models.Deployment, format string) (*models.DeploymentRender, error)
+}
+
+// defaultDeploymentHealthTimeout bounds a probe when no config is provided.
+const defaultDeploymentHealthTimeout = 10 * time.Second
+
 // NewRegistryService creates a new registry service with the provided database and configuration
 func NewRegistryService(
 	db database.Database,
 	cfg *config.Config,
 	embeddingProvider embeddings.Provider,
 ) RegistryService {
-	return &registryServiceImpl{
+	svc := &registryServiceImpl{
 		db:                 db,
 		cfg:                cfg,
 		embeddingsProvider: embeddingProvider,
 		logger:             slog.Default().With("component", "registry"),
+		secretCipherErr:    secrets.ErrNotConfigured,
+		trustPolicyErr:     signing.ErrNotConfigured,
+		healthProber:       health.NewProber(defaultDeploymentHealthTimeout),
+	}
+	if cfg != nil {
+		if cfg.DeploymentHealthTimeout > 0 {
+			svc.healthProber = health.NewProber(cfg.DeploymentHealthTimeout)
+		}
+		svc.secretCipher, svc.secretCipherErr = secrets.NewCipher(cfg.SecretsMasterKey)
+		if svc.secretCipherErr != nil && !errors.Is(svc.secretCipherErr, secrets.ErrNotConfigured) {
+			svc.logger.Error("secrets are disabled", "error", svc.secretCipherErr)
+		}
+		svc.trustPolicy, svc.trustPolicyErr = signing.LoadTrustPolicy(cfg.SigningTrustRoots, cfg.SigningTrustedKeysDir, cfg.SigningIdentities)
+		if svc.trustPolicyErr != nil && !errors.Is(svc.trustPolicyErr, signing.ErrNotConfigured) {
+			svc.logger.Error("artifact signatures cannot be verified", "error", svc.trustPolicyErr)
+		}
+		if svc.trustPolicy != nil && cfg.SigningAcceptExpiredCertificates {
+			svc.trustPolicy.AcceptExpiredCertificates()
+		}
 	}
+	return svc
 }
 
 // SetPlatformAdapters wires platform extension adapters into the service.
@@ -175,6 +254,9 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	if err := validators.ValidatePublishRequest(ctx, *req, s.cfg); err != nil {
 		return nil, err
 	}
+	if err := s.verifyServerSignature(req, s.signaturesRequiredOnPublish()); err != nil {
+		return nil, err
+	}
 
 	publishTime := time.Now()
 	serverJSON := *req
@@ -248,6 +330,9 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	if err != nil {
 		return nil, err
 	}
+	if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionPublish, models.AuditResourceServer, serverJSON.Name, serverJSON.Version, nil, result)); err != nil {
+		return nil, err
+	}
 
 	// Generate embedding asynchronously (non-blocking, best-effort)
 	if s.shouldGenerateEmbeddingsOnPublish() { //nolint:nestif
@@ -271,6 +356,50 @@ func (s *registryServiceImpl) createServerInTransaction(ctx context.Context, tx
 	return result, nil
 }
 
+func (s *registryServiceImpl) signaturesRequiredOnPublish() bool {
+	return s.cfg != nil && s.cfg.SigningRequireOnPublish
+}
+
+// verifyArtifactSignature checks the signature of a server, agent or skill
+// version against the trust policy. Unsigned versions pass unless required is
+// set. Signed versions are verified whenever a trust policy is configured;
+// without one they pass unverified unless required is set. When required is
+// set, every artifact must also be pinned, since a signature over an image tag
+// does not fix the image that is pulled.
+func (s *registryServiceImpl) verifyArtifactSignature(payload signing.Payload, sig *models.ArtifactSignature, required bool) error {
+	if sig == nil && !required {
+		return nil
+	}
+	if unpinned := payload.Unpinned(); required && len(unpinned) > 0 {
+		refs := make([]string, 0, len(unpinned))
+		for _, a := range unpinned {
+			refs = append(refs, a.String())
+		}
+		return fmt.Errorf("%w: %s %s@%s: signed artifacts must be referenced by digest or exact version: %s",
+			database.ErrInvalidInput, payload.Kind, payload.Name, payload.Version, strings.Join(refs, ", "))
+	}
+	if s.trustPolicy == nil {
+		if !required {
+			return nil
+		}
+		return fmt.Errorf("%w: signature of %s %s@%s cannot be verified: %v", database.ErrInvalidInput, payload.Kind, payload.Name, payload.Version, s.trustPolicyErr)
+	}
+	if _, err := s.trustPolicy.Verify(payload.Bytes(), sig); err != nil {
+		return fmt.Errorf("%w: %s %s@%s: %v", database.ErrInvalidInput, payload.Kind, payload.Name, payload.Version, err)
+	}
+	return nil
+}
+
+// verifyServerSignature verifies the signature carried in the publisher-provided
+// metadata of a server version.
+func (s *registryServiceImpl) verifyServerSignature(server *apiv0.ServerJSON, required bool) error {
+	sig, err := signing.ServerSignature(server)
+	if err != nil {
+		return fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	return s.verifyArtifactSignature(signing.ServerPayload(server), sig, required)
+}
+
 // validateNoDuplicateRemoteURLs checks that no other server is using the same remote URLs
 func (s *registryServiceImpl) validateNoDuplicateRemoteURLs(ctx context.Context, tx pgx.Tx, serverDetail apiv0.ServerJSON) error {
 	// Check each remote URL in the new server for conflicts
@@ -303,6 +432,11 @@ func (s *registryServiceImpl) ListSkills(ctx context.Context, filter *database.S
 	if limit <= 0 {
 		limit = 30
 	}
+	if filter != nil {
+		if err := s.ensureSemanticEmbedding(ctx, filter.Semantic); err != nil {
+			return nil, "", err
+		}
+	}
 	skills, next, err := s.db.ListSkills(ctx, nil, filter, cursor, limit)
 	if err != nil {
 		return nil, "", err
@@ -341,18 +475,16 @@ func (s *registryServiceImpl) createSkillInTransaction(ctx context.Context, tx p
 	publishTime := time.Now()
 	skillJSON := *req
 
+	if err := s.validateSkillBundleRefs(ctx, tx, skillJSON.Name, &skillJSON); err != nil {
+		return nil, err
+	}
+	if err := s.verifyArtifactSignature(signing.SkillPayload(&skillJSON), skillJSON.Signature, s.signaturesRequiredOnPublish()); err != nil {
+		return nil, err
+	}
+
 	// Check duplicate remote URLs among skills
-	for _, remote := range skillJSON.Remotes {
-		filter := &database.SkillFilter{RemoteURL: &remote.URL}
-		existing, _, err := s.db.ListSkills(ctx, tx, filter, "", 1000)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check remote URL conflict: %w", err)
-		}
-		for _, e := range existing {
-			if e.Skill.Name != skillJSON.Name {
-				return nil, fmt.Errorf("remote URL %s is already used by skill %s", remote.URL, e.Skill.Name)
-			}
-		}
+	if err := s.validateNoDuplicateSkillRemoteURLs(ctx, tx, &skillJSON); err != nil {
+		return nil, err
 	}
 
 	// Enforce maximum versions per skill similar to servers
@@ -404,16 +536,275 @@ func (s *registryServiceImpl) createSkillInTransaction(ctx context.Context, tx p
 		IsLatest:    isNewLatest,
 	}
 
-	return s.db.CreateSkill(ctx, tx, &skillJSON, officialMeta)
+	result, err := s.db.CreateSkill(ctx, tx, &skillJSON, officialMeta)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionPublish, models.AuditResourceSkill, skillJSON.Name, skillJSON.Version, nil, result)); err != nil {
+		return nil, err
+	}
+
+	// Generate embedding asynchronously (non-blocking, best-effort)
+	if s.shouldGenerateEmbeddingsOnPublish() { //nolint:nestif
+		go func() {
+			bgCtx := context.Background()
+			payload := embeddings.BuildSkillEmbeddingPayload(&skillJSON)
+			if strings.TrimSpace(payload) == "" {
+				return
+			}
+			embedding, err := embeddings.GenerateSemanticEmbedding(bgCtx, s.embeddingsProvider, payload, s.cfg.Embeddings.Dimensions)
+			if err != nil {
+				s.logger.Warn("failed to generate embedding for skill", "name", skillJSON.Name, "version", skillJSON.Version, "error", err)
+			} else if embedding != nil {
+				if err := s.UpsertSkillEmbedding(bgCtx, skillJSON.Name, skillJSON.Version, embedding); err != nil {
+					s.logger.Warn("failed to store embedding for skill", "name", skillJSON.Name, "version", skillJSON.Version, "error", err)
+				}
+			}
+		}()
+	}
+
+	return result, nil
+}
+
+// validateSkillBundleRefs checks that every registry-hosted package of a skill
+// names a stored bundle that was uploaded for that skill.
+func (s *registryServiceImpl) validateSkillBundleRefs(ctx context.Context, tx pgx.Tx, skillName string, req *models.SkillJSON) error {
+	if req == nil {
+		return nil
+	}
+	for _, pkg := range req.Packages {
+		if pkg.RegistryType != models.SkillPackageTypeRegistry {
+			continue
+		}
+		if !skillbundle.ValidDigest(pkg.Identifier) {
+			return fmt.Errorf("%w: skill bundle identifier %q is not a sha256 digest", database.ErrInvalidInput, pkg.Identifier)
+		}
+		bundle, err := s.db.GetSkillBundle(ctx, tx, pkg.Identifier)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return fmt.Errorf("%w: skill bundle %s has not been uploaded", database.ErrInvalidInput, pkg.Identifier)
+			}
+			return err
+		}
+		if bundle.SkillName != skillName {
+			return fmt.Errorf("%w: skill bundle %s is for skill %q, not %q", database.ErrInvalidInput, pkg.Identifier, bundle.SkillName, skillName)
+		}
+	}
+	return nil
+}
+
+// validateNoDuplicateSkillRemoteURLs checks that no other skill is using the
+// same remote URLs.
+func (s *registryServiceImpl) validateNoDuplicateSkillRemoteURLs(ctx context.Context, tx pgx.Tx, skill *models.SkillJSON) error {
+	for _, remote := range skill.Remotes {
+		filter := &database.SkillFilter{RemoteURL: &remote.URL}
+		existing, _, err := s.db.ListSkills(ctx, tx, filter, "", 1000)
+		if err != nil {
+			return fmt.Errorf("failed to check remote URL conflict: %w", err)
+		}
+		for _, e := range existing {
+			if e.Skill.Name != skill.Name {
+				return fmt.Errorf("remote URL %s is already used by skill %s", remote.URL, e.Skill.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// UploadSkillBundle validates a skill bundle and stores it under its digest.
+// The skill it belongs to is taken from the SKILL.md frontmatter. Earlier
+// uploads for the skill that no version published within the grace period
+// are deleted first, and a skill cannot hold more than
+// maxUnreferencedSkillBundles unpublished uploads.
+func (s *registryServiceImpl) UploadSkillBundle(ctx context.Context, data []byte) (*models.SkillBundle, error) {
+	fm, err := skillbundle.Inspect(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+
+	bundle := &database.SkillBundle{
+		Digest:    skillbundle.Digest(data),
+		SkillName: fm.Name,
+		Content:   data,
+		SizeBytes: len(data),
+	}
+	result := &models.SkillBundle{
+		Digest:      bundle.Digest,
+		Name:        fm.Name,
+		Description: fm.Description,
+		SizeBytes:   bundle.SizeBytes,
+	}
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.SkillBundle, error) {
+		if err := s.pruneSkillBundles(ctx, tx, fm.Name); err != nil {
+			return nil, err
+		}
+
+		// Uploading a stored bundle again changes nothing.
+		if _, err := s.db.GetSkillBundle(auth.WithSystemContext(ctx), tx, bundle.Digest); err == nil {
+			return result, nil
+		} else if !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+
+		unreferenced, err := s.db.CountUnreferencedSkillBundles(ctx, tx, fm.Name)
+		if err != nil {
+			return nil, err
+		}
+		if unreferenced >= maxUnreferencedSkillBundles {
+			return nil, fmt.Errorf("%w: skill %s already has %d bundles that no version references; publish a version using one of them or retry after %s",
+				database.ErrInvalidInput, fm.Name, unreferenced, skillBundleGracePeriod)
+		}
+
+		if err := s.db.CreateSkillBundle(ctx, tx, bundle); err != nil {
+			return nil, err
+		}
+		entry := audit.NewEntry(ctx, models.AuditActionUpload, models.AuditResourceSkillBundle, bundle.Digest, "", nil, result)
+		entry.Details = models.JSONObject{"skill": fm.Name, "sizeBytes": bundle.SizeBytes}
+		if err := s.db.AppendAuditEntry(ctx, tx, entry); err != nil {
+			return nil, err
+		}
+		return result, nil
+	})
+}
+
+// pruneSkillBundles deletes the bundles of a skill that no version referenced
+// within skillBundleGracePeriod of their upload.
+func (s *registryServiceImpl) pruneSkillBundles(ctx context.Context, tx pgx.Tx, skillName string) error {
+	deleted, err := s.db.DeleteUnreferencedSkillBundles(ctx, tx, skillName, time.Now().Add(-skillBundleGracePeriod))
+	if err != nil {
+		return err
+	}
+	for _, digest := range deleted {
+		entry := audit.NewEntry(ctx, models.AuditActionDelete, models.AuditResourceSkillBundle, digest, "", nil, nil)
+		entry.Details = models.JSONObject{"skill": skillName, "reason": "unreferenced"}
+		if err := s.db.AppendAuditEntry(ctx, tx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSkillBundle retrieves a stored skill bundle by digest
+func (s *registryServiceImpl) GetSkillBundle(ctx context.Context, digest string) (*database.SkillBundle, error) {
+	if !skillbundle.ValidDigest(digest) {
+		return nil, fmt.Errorf("%w: %q is not a sha256 digest", database.ErrInvalidInput, digest)
+	}
+	return s.db.GetSkillBundle(ctx, nil, digest)
+}
+
+// UpdateSkill updates an existing skill version and optionally its status
+func (s *registryServiceImpl) UpdateSkill(ctx context.Context, skillName, version string, req *models.SkillJSON, newStatus *string) (*models.SkillResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.SkillResponse, error) {
+		current, err := s.db.GetSkillByNameAndVersion(ctx, tx, skillName, version)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateEdit(skillStatus(current), req != nil); err != nil {
+			return nil, err
+		}
+		if err := s.validateSkillBundleRefs(ctx, tx, skillName, req); err != nil {
+			return nil, err
+		}
+		if req != nil {
+			payload := signing.SkillPayload(req)
+			payload.Name, payload.Version = skillName, version
+			if err := s.verifyArtifactSignature(payload, req.Signature, s.signaturesRequiredOnPublish()); err != nil {
+				return nil, err
+			}
+			edited := *req
+			edited.Name = skillName
+			if err := s.validateNoDuplicateSkillRemoteURLs(ctx, tx, &edited); err != nil {
+				return nil, err
+			}
+		}
+		updated, err := s.db.UpdateSkill(ctx, tx, skillName, version, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionEdit, models.AuditResourceSkill, skillName, version, current, updated)); err != nil {
+			return nil, err
+		}
+
+		// Keep the successor of a version that stays deprecated
+		if newStatus == nil || *newStatus == skillStatus(updated) {
+			return updated, nil
+		}
+		return s.setSkillStatusInTransaction(ctx, tx, skillName, version, *newStatus, "", updated)
+	})
+}
+
+// SetSkillStatus updates the status of a specific skill version. successor names
+// the version that replaces a deprecated one and may be empty.
+func (s *registryServiceImpl) SetSkillStatus(ctx context.Context, skillName, version, status, successor string) (*models.SkillResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.SkillResponse, error) {
+		before, err := s.db.GetSkillByNameAndVersion(auth.WithSystemContext(ctx), tx, skillName, version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		return s.setSkillStatusInTransaction(ctx, tx, skillName, version, status, successor, before)
+	})
+}
+
+func (s *registryServiceImpl) setSkillStatusInTransaction(ctx context.Context, tx pgx.Tx, skillName, version, status, successor string, before *models.SkillResponse) (*models.SkillResponse, error) {
+	if err := validateStatusChange(skillStatus(before), status, version, successor); err != nil {
+		return nil, err
+	}
+	if successor != "" {
+		exists, err := s.db.CheckSkillVersionExists(auth.WithSystemContext(ctx), tx, skillName, successor)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: successor version %s of skill %s does not exist", database.ErrInvalidInput, successor, skillName)
+		}
+	}
+
+	updated, err := s.db.SetSkillStatus(ctx, tx, skillName, version, status, successor)
+	if err != nil {
+		return nil, err
+	}
+	entry := audit.NewEntry(ctx, models.AuditActionSetStatus, models.AuditResourceSkill, skillName, version, before, updated)
+	entry.Details = models.JSONObject{"status": status}
+	if successor != "" {
+		entry.Details["successor"] = successor
+	}
+	if err := s.db.AppendAuditEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func skillStatus(skill *models.SkillResponse) string {
+	if skill == nil || skill.Meta.Official == nil {
+		return ""
+	}
+	return skill.Meta.Official.Status
 }
 
 // DeleteSkill permanently removes a skill version from the registry
 func (s *registryServiceImpl) DeleteSkill(ctx context.Context, skillName, version string) error {
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.DeleteSkill(txCtx, tx, skillName, version)
+		before, err := s.db.GetSkillByNameAndVersion(auth.WithSystemContext(txCtx), tx, skillName, version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		if err := s.db.DeleteSkill(txCtx, tx, skillName, version); err != nil {
+			return err
+		}
+		return s.db.AppendAuditEntry(txCtx, tx, audit.NewEntry(txCtx, models.AuditActionDelete, models.AuditResourceSkill, skillName, version, before, nil))
+	})
+}
+
+func (s *registryServiceImpl) UpsertSkillEmbedding(ctx context.Context, skillName, version string, embedding *database.SemanticEmbedding) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		return s.db.SetSkillEmbedding(txCtx, tx, skillName, version, embedding)
 	})
 }
 
+func (s *registryServiceImpl) GetSkillEmbeddingMetadata(ctx context.Context, skillName, version string) (*database.SemanticEmbeddingMetadata, error) {
+	return s.db.GetSkillEmbeddingMetadata(ctx, nil, skillName, version)
+}
+
 // UpdateServer updates an existing server with new details
 func (s *registryServiceImpl) UpdateServer(ctx context.Context, serverName, version string, req *apiv0.ServerJSON, newStatus *string) (*apiv0.ServerResponse, error) {
 	// Wrap the entire operation in a transaction
@@ -441,6 +832,11 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 	if err := s.validateUpdateRequest(ctx, *req, skipRegistryValidation); err != nil {
 		return nil, err
 	}
+	if !skipRegistryValidation {
+		if err := s.verifyServerSignature(req, s.signaturesRequiredOnPublish()); err != nil {
+			return nil, err
+		}
+	}
 
 	// Merge the request with the current server, preserving metadata
 	updatedServer := *req
@@ -455,6 +851,9 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 	if err != nil {
 		return nil, err
 	}
+	if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionEdit, models.AuditResourceServer, serverName, version, currentServer, updatedServerResponse)); err != nil {
+		return nil, err
+	}
 
 	// Handle status change if provided
 	if newStatus != nil {
@@ -462,12 +861,51 @@ func (s *registryServiceImpl) updateServerInTransaction(ctx context.Context, tx
 		if err != nil {
 			return nil, err
 		}
+		entry := audit.NewEntry(ctx, models.AuditActionSetStatus, models.AuditResourceServer, serverName, version, updatedServerResponse, updatedWithStatus)
+		entry.Details = models.JSONObject{"status": *newStatus}
+		if err := s.db.AppendAuditEntry(ctx, tx, entry); err != nil {
+			return nil, err
+		}
 		return updatedWithStatus, nil
 	}
 
 	return updatedServerResponse, nil
 }
 
+// validateEdit checks that an agent, skill or prompt version in currentStatus
+// may be edited. Deleted versions are frozen: only their status can be set,
+// and only to deleted again.
+func validateEdit(currentStatus string, editsContent bool) error {
+	if editsContent && currentStatus == string(model.StatusDeleted) {
+		return fmt.Errorf("%w: deleted versions cannot be edited", database.ErrInvalidInput)
+	}
+	return nil
+}
+
+// validateStatusChange checks a status change of an agent, skill or prompt
+// version. Like servers, deleted versions cannot be undeleted, and only a
+// deprecated version can name the version that succeeds it.
+func validateStatusChange(currentStatus, status, version, successor string) error {
+	switch model.Status(status) {
+	case model.StatusActive, model.StatusDeprecated, model.StatusDeleted:
+	default:
+		return fmt.Errorf("%w: invalid status %q", database.ErrInvalidInput, status)
+	}
+	if currentStatus == string(model.StatusDeleted) && status != string(model.StatusDeleted) {
+		return fmt.Errorf("%w: deleted versions cannot be undeleted", database.ErrInvalidInput)
+	}
+	if successor == "" {
+		return nil
+	}
+	if status != string(model.StatusDeprecated) {
+		return fmt.Errorf("%w: a successor can only be set when deprecating", database.ErrInvalidInput)
+	}
+	if successor == version {
+		return fmt.Errorf("%w: version %s cannot succeed itself", database.ErrInvalidInput, version)
+	}
+	return nil
+}
+
 func (s *registryServiceImpl) StoreServerReadme(ctx context.Context, serverName, version string, content []byte, contentType string) error {
 	if len(content) == 0 {
 		return nil
@@ -509,7 +947,14 @@ func (s *registryServiceImpl) GetServerReadmeByVersion(ctx context.Context, serv
 // DeleteServer permanently removes a server version from the registry
 func (s *registryServiceImpl) DeleteServer(ctx context.Context, serverName, version string) error {
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.DeleteServer(txCtx, tx, serverName, version)
+		before, err := s.db.GetServerByNameAndVersion(auth.WithSystemContext(txCtx), tx, serverName, version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		if err := s.db.DeleteServer(txCtx, tx, serverName, version); err != nil {
+			return err
+		}
+		return s.db.AppendAuditEntry(txCtx, tx, audit.NewEntry(txCtx, models.AuditActionDelete, models.AuditResourceServer, serverName, version, before, nil))
 	})
 }
 
@@ -587,18 +1032,13 @@ func (s *registryServiceImpl) createAgentInTransaction(ctx context.Context, tx p
 	publishTime := time.Now()
 	agentJSON := *req
 
+	if err := s.verifyArtifactSignature(signing.AgentPayload(&agentJSON), agentJSON.Signature, s.signaturesRequiredOnPublish()); err != nil {
+		return nil, err
+	}
+
 	// Check duplicate remote URLs among agents
-	for _, remote := range agentJSON.Remotes {
-		filter := &database.AgentFilter{RemoteURL: &remote.URL}
-		existing, _, err := s.db.ListAgents(ctx, tx, filter, "", 1000)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check remote URL conflict: %w", err)
-		}
-		for _, e := range existing {
-			if e.Agent.Name != agentJSON.Name {
-				return nil, fmt.Errorf("remote URL %s is already used by agent %s", remote.URL, e.Agent.Name)
-			}
-		}
+	if err := s.validateNoDuplicateAgentRemoteURLs(ctx, tx, &agentJSON); err != nil {
+		return nil, err
 	}
 
 	// Enforce maximum versions per agent similar to servers
@@ -654,6 +1094,9 @@ func (s *registryServiceImpl) createAgentInTransaction(ctx context.Context, tx p
 	if err != nil {
 		return nil, err
 	}
+	if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionPublish, models.AuditResourceAgent, agentJSON.Name, agentJSON.Version, nil, result)); err != nil {
+		return nil, err
+	}
 
 	// Generate embedding asynchronously (non-blocking, best-effort)
 	if s.shouldGenerateEmbeddingsOnPublish() { //nolint:nestif
@@ -677,18 +1120,129 @@ func (s *registryServiceImpl) createAgentInTransaction(ctx context.Context, tx p
 	return result, nil
 }
 
-// DeleteAgent permanently removes an agent version from the registry
-func (s *registryServiceImpl) DeleteAgent(ctx context.Context, agentName, version string) error {
-	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.DeleteAgent(txCtx, tx, agentName, version)
-	})
+// validateNoDuplicateAgentRemoteURLs checks that no other agent is using the
+// same remote URLs.
+func (s *registryServiceImpl) validateNoDuplicateAgentRemoteURLs(ctx context.Context, tx pgx.Tx, agent *models.AgentJSON) error {
+	for _, remote := range agent.Remotes {
+		filter := &database.AgentFilter{RemoteURL: &remote.URL}
+		existing, _, err := s.db.ListAgents(ctx, tx, filter, "", 1000)
+		if err != nil {
+			return fmt.Errorf("failed to check remote URL conflict: %w", err)
+		}
+		for _, e := range existing {
+			if e.Agent.Name != agent.Name {
+				return fmt.Errorf("remote URL %s is already used by agent %s", remote.URL, e.Agent.Name)
+			}
+		}
+	}
+	return nil
 }
 
-func (s *registryServiceImpl) UpsertServerEmbedding(ctx context.Context, serverName, version string, embedding *database.SemanticEmbedding) error {
-	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.SetServerEmbedding(txCtx, tx, serverName, version, embedding)
-	})
-}
+// UpdateAgent updates an existing agent version and optionally its status
+func (s *registryServiceImpl) UpdateAgent(ctx context.Context, agentName, version string, req *models.AgentJSON, newStatus *string) (*models.AgentResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.AgentResponse, error) {
+		current, err := s.db.GetAgentByNameAndVersion(ctx, tx, agentName, version)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateEdit(agentStatus(current), req != nil); err != nil {
+			return nil, err
+		}
+		if req != nil {
+			payload := signing.AgentPayload(req)
+			payload.Name, payload.Version = agentName, version
+			if err := s.verifyArtifactSignature(payload, req.Signature, s.signaturesRequiredOnPublish()); err != nil {
+				return nil, err
+			}
+			edited := *req
+			edited.Name = agentName
+			if err := s.validateNoDuplicateAgentRemoteURLs(ctx, tx, &edited); err != nil {
+				return nil, err
+			}
+		}
+		updated, err := s.db.UpdateAgent(ctx, tx, agentName, version, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionEdit, models.AuditResourceAgent, agentName, version, current, updated)); err != nil {
+			return nil, err
+		}
+
+		// Keep the successor of a version that stays deprecated
+		if newStatus == nil || *newStatus == agentStatus(updated) {
+			return updated, nil
+		}
+		return s.setAgentStatusInTransaction(ctx, tx, agentName, version, *newStatus, "", updated)
+	})
+}
+
+// SetAgentStatus updates the status of a specific agent version. successor names
+// the version that replaces a deprecated one and may be empty.
+func (s *registryServiceImpl) SetAgentStatus(ctx context.Context, agentName, version, status, successor string) (*models.AgentResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.AgentResponse, error) {
+		before, err := s.db.GetAgentByNameAndVersion(auth.WithSystemContext(ctx), tx, agentName, version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		return s.setAgentStatusInTransaction(ctx, tx, agentName, version, status, successor, before)
+	})
+}
+
+func (s *registryServiceImpl) setAgentStatusInTransaction(ctx context.Context, tx pgx.Tx, agentName, version, status, successor string, before *models.AgentResponse) (*models.AgentResponse, error) {
+	if err := validateStatusChange(agentStatus(before), status, version, successor); err != nil {
+		return nil, err
+	}
+	if successor != "" {
+		exists, err := s.db.CheckAgentVersionExists(auth.WithSystemContext(ctx), tx, agentName, successor)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: successor version %s of agent %s does not exist", database.ErrInvalidInput, successor, agentName)
+		}
+	}
+
+	updated, err := s.db.SetAgentStatus(ctx, tx, agentName, version, status, successor)
+	if err != nil {
+		return nil, err
+	}
+	entry := audit.NewEntry(ctx, models.AuditActionSetStatus, models.AuditResourceAgent, agentName, version, before, updated)
+	entry.Details = models.JSONObject{"status": status}
+	if successor != "" {
+		entry.Details["successor"] = successor
+	}
+	if err := s.db.AppendAuditEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func agentStatus(agent *models.AgentResponse) string {
+	if agent == nil || agent.Meta.Official == nil {
+		return ""
+	}
+	return agent.Meta.Official.Status
+}
+
+// DeleteAgent permanently removes an agent version from the registry
+func (s *registryServiceImpl) DeleteAgent(ctx context.Context, agentName, version string) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		before, err := s.db.GetAgentByNameAndVersion(auth.WithSystemContext(txCtx), tx, agentName, version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		if err := s.db.DeleteAgent(txCtx, tx, agentName, version); err != nil {
+			return err
+		}
+		return s.db.AppendAuditEntry(txCtx, tx, audit.NewEntry(txCtx, models.AuditActionDelete, models.AuditResourceAgent, agentName, version, before, nil))
+	})
+}
+
+func (s *registryServiceImpl) UpsertServerEmbedding(ctx context.Context, serverName, version string, embedding *database.SemanticEmbedding) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		return s.db.SetServerEmbedding(txCtx, tx, serverName, version, embedding)
+	})
+}
 
 func (s *registryServiceImpl) GetServerEmbeddingMetadata(ctx context.Context, serverName, version string) (*database.SemanticEmbeddingMetadata, error) {
 	return s.db.GetServerEmbeddingMetadata(ctx, nil, serverName, version)
@@ -716,17 +1270,277 @@ func (s *registryServiceImpl) GetProviderByID(ctx context.Context, providerID st
 
 // CreateProvider creates a provider.
 func (s *registryServiceImpl) CreateProvider(ctx context.Context, in *models.CreateProviderInput) (*models.Provider, error) {
-	return s.db.CreateProvider(ctx, nil, in)
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.Provider, error) {
+		created, err := s.db.CreateProvider(ctx, tx, in)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionCreate, models.AuditResourceProvider, created.ID, "", nil, created)); err != nil {
+			return nil, err
+		}
+		return created, nil
+	})
 }
 
 // UpdateProvider updates mutable provider fields.
 func (s *registryServiceImpl) UpdateProvider(ctx context.Context, providerID string, in *models.UpdateProviderInput) (*models.Provider, error) {
-	return s.db.UpdateProvider(ctx, nil, providerID, in)
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.Provider, error) {
+		before, err := s.db.GetProviderByID(ctx, tx, providerID)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := s.db.UpdateProvider(ctx, tx, providerID, in)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionEdit, models.AuditResourceProvider, providerID, "", before, updated)); err != nil {
+			return nil, err
+		}
+		return updated, nil
+	})
 }
 
 // DeleteProvider removes a provider by ID.
 func (s *registryServiceImpl) DeleteProvider(ctx context.Context, providerID string) error {
-	return s.db.DeleteProvider(ctx, nil, providerID)
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		before, err := s.db.GetProviderByID(txCtx, tx, providerID)
+		if err != nil {
+			return err
+		}
+		if err := s.db.DeleteProvider(txCtx, tx, providerID); err != nil {
+			return err
+		}
+		return s.db.AppendAuditEntry(txCtx, tx, audit.NewEntry(txCtx, models.AuditActionDelete, models.AuditResourceProvider, providerID, "", before, nil))
+	})
+}
+
+// CreateMirror validates and registers an upstream registry to mirror.
+func (s *registryServiceImpl) CreateMirror(ctx context.Context, in *models.CreateMirrorInput) (*models.Mirror, error) {
+	if in == nil {
+		return nil, database.ErrInvalidInput
+	}
+	in.ID = strings.TrimSpace(in.ID)
+	in.URL = strings.TrimRight(strings.TrimSpace(in.URL), "/")
+	if in.ID == "" {
+		return nil, fmt.Errorf("%w: mirror id is required", database.ErrInvalidInput)
+	}
+	parsed, err := url.Parse(in.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: mirror url must be an http(s) URL", database.ErrInvalidInput)
+	}
+	for _, pattern := range append(slices.Clone(in.Include), in.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: invalid name pattern %q", database.ErrInvalidInput, pattern)
+		}
+	}
+	if in.IntervalSeconds < 0 {
+		return nil, fmt.Errorf("%w: intervalSeconds must be positive", database.ErrInvalidInput)
+	}
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.Mirror, error) {
+		created, err := s.db.CreateMirror(ctx, tx, in)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionCreate, models.AuditResourceMirror, created.ID, "", nil, created)); err != nil {
+			return nil, err
+		}
+		return created, nil
+	})
+}
+
+// ListMirrors lists configured mirrors.
+func (s *registryServiceImpl) ListMirrors(ctx context.Context) ([]*models.Mirror, error) {
+	return s.db.ListMirrors(ctx, nil)
+}
+
+// GetMirrorByID retrieves a mirror by ID.
+func (s *registryServiceImpl) GetMirrorByID(ctx context.Context, mirrorID string) (*models.Mirror, error) {
+	return s.db.GetMirrorByID(ctx, nil, mirrorID)
+}
+
+// DeleteMirror removes a mirror and its sync history.
+func (s *registryServiceImpl) DeleteMirror(ctx context.Context, mirrorID string) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		before, err := s.db.GetMirrorByID(txCtx, tx, mirrorID)
+		if err != nil {
+			return err
+		}
+		if err := s.db.DeleteMirror(txCtx, tx, mirrorID); err != nil {
+			return err
+		}
+		return s.db.AppendAuditEntry(txCtx, tx, audit.NewEntry(txCtx, models.AuditActionDelete, models.AuditResourceMirror, mirrorID, "", before, nil))
+	})
+}
+
+// GetMirrorStatus returns a mirror with its most recent syncs.
+func (s *registryServiceImpl) GetMirrorStatus(ctx context.Context, mirrorID string, historyLimit int) (*models.MirrorStatus, error) {
+	mirror, err := s.db.GetMirrorByID(ctx, nil, mirrorID)
+	if err != nil {
+		return nil, err
+	}
+	syncs, err := s.db.ListMirrorSyncs(ctx, nil, mirrorID, historyLimit)
+	if err != nil {
+		return nil, err
+	}
+	status := &models.MirrorStatus{Mirror: *mirror, History: make([]models.MirrorSync, 0, len(syncs))}
+	for _, sync := range syncs {
+		status.History = append(status.History, *sync)
+	}
+	return status, nil
+}
+
+// RecordMirrorSync stores the outcome of a sync.
+func (s *registryServiceImpl) RecordMirrorSync(ctx context.Context, sync *models.MirrorSync) error {
+	return s.db.RecordMirrorSync(ctx, nil, sync)
+}
+
+var (
+	secretNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,252}$`)
+	envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// sealSecret validates a secret input and encrypts its value.
+func (s *registryServiceImpl) sealSecret(name string, in *models.SecretInput) (*database.EncryptedSecret, error) {
+	if s.secretCipher == nil {
+		return nil, s.secretCipherErr
+	}
+	if in == nil {
+		return nil, database.ErrInvalidInput
+	}
+	if !secretNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: secret name must start with a letter or digit and contain only letters, digits, '.', '_' or '-'", database.ErrInvalidInput)
+	}
+	if in.Value == "" {
+		return nil, fmt.Errorf("%w: secret value is required", database.ErrInvalidInput)
+	}
+	ciphertext, wrappedKey, err := s.secretCipher.Seal(name, []byte(in.Value))
+	if err != nil {
+		return nil, err
+	}
+	return &database.EncryptedSecret{
+		Secret:     models.Secret{Name: name, Description: strings.TrimSpace(in.Description), KeyID: s.secretCipher.KeyID()},
+		Ciphertext: ciphertext,
+		WrappedKey: wrappedKey,
+	}, nil
+}
+
+// CreateSecret encrypts and stores a new secret.
+func (s *registryServiceImpl) CreateSecret(ctx context.Context, in *models.SecretInput) (*models.Secret, error) {
+	if in == nil {
+		return nil, database.ErrInvalidInput
+	}
+	sealed, err := s.sealSecret(strings.TrimSpace(in.Name), in)
+	if err != nil {
+		return nil, err
+	}
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.Secret, error) {
+		created, err := s.db.CreateSecret(ctx, tx, sealed)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionCreate, models.AuditResourceSecret, created.Name, "", nil, created)); err != nil {
+			return nil, err
+		}
+		return created, nil
+	})
+}
+
+// UpdateSecret re-encrypts an existing secret with a new value and data key.
+// Running deployments keep the old value until they are redeployed.
+func (s *registryServiceImpl) UpdateSecret(ctx context.Context, name string, in *models.SecretInput) (*models.Secret, error) {
+	sealed, err := s.sealSecret(strings.TrimSpace(name), in)
+	if err != nil {
+		return nil, err
+	}
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.Secret, error) {
+		// Editing a secret does not require permission to read its value.
+		before, err := s.db.GetSecret(auth.WithSystemContext(ctx), tx, sealed.Name)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := s.db.UpdateSecret(ctx, tx, sealed)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionEdit, models.AuditResourceSecret, updated.Name, "", before.Secret, updated)); err != nil {
+			return nil, err
+		}
+		return updated, nil
+	})
+}
+
+// ListSecrets lists secret metadata.
+func (s *registryServiceImpl) ListSecrets(ctx context.Context) ([]*models.Secret, error) {
+	return s.db.ListSecrets(ctx, nil)
+}
+
+// DeleteSecret removes a secret that no deployment references.
+func (s *registryServiceImpl) DeleteSecret(ctx context.Context, name string) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		before, err := s.db.GetSecret(auth.WithSystemContext(txCtx), tx, name)
+		if err != nil {
+			return err
+		}
+		if err := s.db.DeleteSecret(txCtx, tx, name); err != nil {
+			return err
+		}
+		return s.db.AppendAuditEntry(txCtx, tx, audit.NewEntry(txCtx, models.AuditActionDelete, models.AuditResourceSecret, name, "", before.Secret, nil))
+	})
+}
+
+// ResolveSecretRefs decrypts the referenced secrets, keyed by environment variable.
+// Read permission on each secret is checked when a deployment references it, so
+// resolving runs as the system: redeploys and reconciles need not be able to read
+// the secrets themselves.
+func (s *registryServiceImpl) ResolveSecretRefs(ctx context.Context, refs map[string]string) (map[string]string, error) {
+	values := make(map[string]string, len(refs))
+	if len(refs) == 0 {
+		return values, nil
+	}
+	if s.secretCipher == nil {
+		return nil, s.secretCipherErr
+	}
+	for envName, secretName := range refs {
+		stored, err := s.db.GetSecret(auth.WithSystemContext(ctx), nil, secretName)
+		if err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return nil, fmt.Errorf("secret %s referenced by %s: %w", secretName, envName, database.ErrNotFound)
+			}
+			return nil, err
+		}
+		value, err := s.secretCipher.Open(stored.Name, stored.Ciphertext, stored.WrappedKey, stored.KeyID)
+		if err != nil {
+			return nil, fmt.Errorf("secret %s: %w", secretName, err)
+		}
+		values[envName] = string(value)
+	}
+	return values, nil
+}
+
+// validateDeploymentSecretRefs checks that secret references name valid
+// environment variables that are not also set in plain env, and that every
+// referenced secret exists and may be read by the caller.
+func (s *registryServiceImpl) validateDeploymentSecretRefs(ctx context.Context, tx pgx.Tx, deployment *models.Deployment) error {
+	for envName, secretName := range deployment.SecretRefs {
+		if !envVarNamePattern.MatchString(envName) {
+			return fmt.Errorf("%w: invalid secret environment variable name %q", database.ErrInvalidInput, envName)
+		}
+		if _, ok := deployment.Env[envName]; ok {
+			return fmt.Errorf("%w: %s is set both as a plain value and as a secret", database.ErrInvalidInput, envName)
+		}
+		if _, err := s.db.GetSecret(ctx, tx, secretName); err != nil {
+			if errors.Is(err, database.ErrNotFound) {
+				return fmt.Errorf("%w: secret %s referenced by %s does not exist", database.ErrInvalidInput, secretName, envName)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// ListAuditEntries lists audit log entries, newest first.
+func (s *registryServiceImpl) ListAuditEntries(ctx context.Context, filter *models.AuditFilter, cursor string, limit int) ([]*models.AuditEntry, string, error) {
+	return s.db.ListAuditEntries(ctx, nil, filter, cursor, limit)
 }
 
 func shouldIncludeDiscoveredDeployments(filter *models.DeploymentFilter) bool {
@@ -1067,11 +1881,22 @@ func (s *registryServiceImpl) removeDeploymentRecord(ctx context.Context, deploy
 		return database.ErrInvalidInput
 	}
 
-	if err := s.db.RemoveDeploymentByID(ctx, nil, deployment.ID); err != nil {
-		return err
-	}
-
-	return nil
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		// The undeploy revision is appended first: appending locks the deployment
+		// row. Revisions outlive the deployment record.
+		undeployed := *deployment
+		undeployed.Status = models.DeploymentRevisionStatusUndeployed
+		undeployed.Error = ""
+		if err := s.db.AppendDeploymentRevision(txCtx, tx, newDeploymentRevision(&undeployed, models.DeploymentRevisionActionUndeploy, 0)); err != nil {
+			return err
+		}
+		if err := s.db.RemoveDeploymentByID(txCtx, tx, deployment.ID); err != nil {
+			return err
+		}
+		entry := audit.NewEntry(txCtx, models.AuditActionUndeploy, models.AuditResourceDeployment, deployment.ID, "", deployment, nil)
+		entry.Details = deploymentAuditDetails(deployment)
+		return s.db.AppendAuditEntry(txCtx, tx, entry)
+	})
 }
 
 // RemoveDeploymentByID removes a deployment by UUID.
@@ -1132,16 +1957,69 @@ func (s *registryServiceImpl) CreateDeployment(ctx context.Context, req *models.
 		return nil, deployErr
 	}
 
-	if err := s.applyDeploymentActionResult(ctx, created.ID, actionResult); err != nil {
-		return nil, err
+	return s.applyDeploymentActionResult(ctx, created.ID, actionResult, models.DeploymentRevisionActionDeploy, 0)
+}
+
+// RenderDeployment returns the manifests the platform adapter would apply for
+// req. Nothing is deployed or recorded, so the result carries no deployment ID.
+func (s *registryServiceImpl) RenderDeployment(ctx context.Context, req *models.Deployment, format string) (*models.DeploymentRender, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: deployment request is required", database.ErrInvalidInput)
+	}
+	resourceType := strings.ToLower(strings.TrimSpace(req.ResourceType))
+	if resourceType == "" {
+		resourceType = resourceTypeMCP
+	}
+	if resourceType != resourceTypeMCP && resourceType != resourceTypeAgent {
+		return nil, fmt.Errorf("%w: invalid resource type %q", database.ErrInvalidInput, req.ResourceType)
+	}
+	providerID := strings.TrimSpace(req.ProviderID)
+	if providerID == "" {
+		return nil, fmt.Errorf("%w: provider id is required", database.ErrInvalidInput)
+	}
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = models.DeploymentRenderFormatYAML
+	}
+	switch format {
+	case models.DeploymentRenderFormatYAML, models.DeploymentRenderFormatHelm, models.DeploymentRenderFormatKustomize:
+	default:
+		return nil, fmt.Errorf("%w: invalid render format %q", database.ErrInvalidInput, format)
 	}
 
-	updated, err := s.db.GetDeploymentByID(ctx, nil, created.ID)
+	adapter, err := s.resolveDeploymentAdapterByProviderID(ctx, providerID)
 	if err != nil {
 		return nil, err
 	}
+	if !deploymentAdapterSupportsResourceType(adapter, resourceType) {
+		return nil, fmt.Errorf("%w: provider does not support resource type %q", database.ErrInvalidInput, resourceType)
+	}
+	renderer, ok := adapter.(DeploymentPlatformRenderer)
+	if !ok {
+		return nil, fmt.Errorf("%w: rendering is not supported on platform %s", database.ErrInvalidInput, adapter.Platform())
+	}
 
-	return updated, nil
+	deploymentReq := *req
+	deploymentReq.ID = ""
+	deploymentReq.ServerName = strings.TrimSpace(req.ServerName)
+	deploymentReq.ResourceType = resourceType
+	deploymentReq.ProviderID = providerID
+	deploymentReq.Origin = originManaged
+	if deploymentReq.Env == nil {
+		deploymentReq.Env = map[string]string{}
+	}
+	if deploymentReq.ServerName == "" || strings.TrimSpace(deploymentReq.Version) == "" {
+		return nil, fmt.Errorf("%w: serverName and version are required", database.ErrInvalidInput)
+	}
+	version, err := s.resolveDeploymentVersion(ctx, resourceType, deploymentReq.ServerName, strings.TrimSpace(deploymentReq.Version))
+	if err != nil {
+		return nil, err
+	}
+	deploymentReq.Version = version
+	if err := s.validateDeploymentSecretRefs(ctx, nil, &deploymentReq); err != nil {
+		return nil, err
+	}
+	return renderer.Render(ctx, &deploymentReq, format)
 }
 
 // UndeployDeployment dispatches undeploy to the platform adapter.
@@ -1159,20 +2037,275 @@ func (s *registryServiceImpl) UndeployDeployment(ctx context.Context, deployment
 	return s.removeDeploymentRecord(ctx, deployment)
 }
 
-func (s *registryServiceImpl) createManagedDeploymentRecord(ctx context.Context, req *models.Deployment) (*models.Deployment, error) {
-	now := time.Now()
-	deployment := &models.Deployment{
-		ID:               req.ID,
-		ServerName:       strings.TrimSpace(req.ServerName),
-		Version:          strings.TrimSpace(req.Version),
-		Status:           models.DeploymentStatusDeploying,
-		Env:              req.Env,
-		ProviderConfig:   req.ProviderConfig,
-		ProviderMetadata: req.ProviderMetadata,
-		PreferRemote:     req.PreferRemote,
-		ResourceType:     req.ResourceType,
-		ProviderID:       req.ProviderID,
-		Origin:           req.Origin,
+// UpdateDeployment rolls a managed deployment to a new version or configuration
+// in place. The new spec is recorded before the rollout so the change is
+// authorized and audited up front. If the rollout fails, the previous workload
+// and record are restored and the failure is kept in the deployment's error.
+func (s *registryServiceImpl) UpdateDeployment(ctx context.Context, id string, update *models.DeploymentUpdate) (*models.Deployment, error) {
+	if update == nil {
+		return nil, fmt.Errorf("%w: deployment update is required", database.ErrInvalidInput)
+	}
+	return s.updateDeployment(ctx, id, update, models.DeploymentRevisionActionUpdate, 0)
+}
+
+// RollbackDeployment re-applies a previous revision of a managed deployment
+// through the same update path, so a failed rollback is itself rolled back.
+// Revisions do not keep env values, so env is restored to the revision's keys
+// with the values the deployment sets now.
+func (s *registryServiceImpl) RollbackDeployment(ctx context.Context, id string, revision int) (*models.Deployment, error) {
+	target, err := s.db.GetDeploymentRevision(ctx, nil, id, revision)
+	if err != nil {
+		return nil, err
+	}
+	if target.Action == models.DeploymentRevisionActionUndeploy {
+		return nil, fmt.Errorf("%w: revision %d is an undeploy and cannot be re-applied", database.ErrInvalidInput, revision)
+	}
+	if target.Status == models.DeploymentStatusFailed {
+		return nil, fmt.Errorf("%w: revision %d failed and cannot be re-applied", database.ErrInvalidInput, revision)
+	}
+	current, err := s.db.GetDeploymentByID(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	// An empty map clears env added since the revision; nil would keep it.
+	env := make(map[string]string, len(target.EnvKeys))
+	for _, key := range target.EnvKeys {
+		value, ok := current.Env[key]
+		if !ok {
+			return nil, fmt.Errorf("%w: revision %d sets %s, which the deployment no longer sets; restore it with an update",
+				database.ErrInvalidInput, revision, key)
+		}
+		env[key] = value
+	}
+
+	version := target.Version
+	preferRemote := target.PreferRemote
+	update := &models.DeploymentUpdate{
+		Version:        &version,
+		Env:            env,
+		SecretRefs:     maps.Clone(target.SecretRefs),
+		ProviderConfig: maps.Clone(target.ProviderConfig),
+		PreferRemote:   &preferRemote,
+	}
+	if update.SecretRefs == nil {
+		update.SecretRefs = map[string]string{}
+	}
+	if update.ProviderConfig == nil {
+		update.ProviderConfig = models.JSONObject{}
+	}
+	return s.updateDeployment(ctx, id, update, models.DeploymentRevisionActionRollback, revision)
+}
+
+// ListDeploymentRevisions lists the revisions of a deployment, oldest first.
+func (s *registryServiceImpl) ListDeploymentRevisions(ctx context.Context, id string) ([]*models.DeploymentRevision, error) {
+	return s.db.ListDeploymentRevisions(ctx, nil, id)
+}
+
+// updateDeployment applies update to a deployment and records the outcome as
+// a revision produced by action. sourceRevision is the revision a rollback
+// re-applies.
+func (s *registryServiceImpl) updateDeployment(ctx context.Context, id string, update *models.DeploymentUpdate, action string, sourceRevision int) (*models.Deployment, error) {
+	current, err := s.db.GetDeploymentByID(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Origin == originDiscovered {
+		return nil, fmt.Errorf("%w: discovered deployments cannot be updated", database.ErrInvalidInput)
+	}
+	if current.Status == models.DeploymentStatusDeploying {
+		return nil, fmt.Errorf("%w: deployment is still being applied", database.ErrConflict)
+	}
+	adapter, err := s.resolveDeploymentAdapterByProviderID(ctx, current.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	desired, patch, err := s.buildDeploymentUpdate(ctx, current, update)
+	if err != nil {
+		return nil, err
+	}
+	status := models.DeploymentStatusDeploying
+	errorText := ""
+	patch.Status = &status
+	patch.Error = &errorText
+	// Claim the deployment only if no other update started, and nothing changed
+	// it, since it was read: concurrent updates must not both roll out.
+	patch.UnlessStatus = &status
+	patch.IfUpdatedAt = &current.UpdatedAt
+	if err := s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		if err := s.validateDeploymentSecretRefs(txCtx, tx, desired); err != nil {
+			return err
+		}
+		if err := s.db.UpdateDeploymentState(txCtx, tx, current.ID, patch); err != nil {
+			if errors.Is(err, database.ErrConflict) {
+				return fmt.Errorf("%w: deployment is being updated concurrently", database.ErrConflict)
+			}
+			return err
+		}
+		auditAction := models.AuditActionUpdate
+		if action == models.DeploymentRevisionActionRollback {
+			auditAction = models.AuditActionRollback
+		}
+		entry := audit.NewEntry(txCtx, auditAction, models.AuditResourceDeployment, current.ID, "", current, desired)
+		entry.Details = deploymentAuditDetails(desired)
+		entry.Details["previousVersion"] = current.Version
+		if sourceRevision > 0 {
+			entry.Details["sourceRevision"] = sourceRevision
+		}
+		return s.db.AppendAuditEntry(txCtx, tx, entry)
+	}); err != nil {
+		return nil, err
+	}
+
+	result, rolloutErr := s.rolloutDeployment(ctx, adapter, current, desired)
+	if rolloutErr != nil {
+		if err := s.rollbackDeploymentUpdate(ctx, adapter, current, desired, rolloutErr, action, sourceRevision); err != nil {
+			return nil, fmt.Errorf("update failed: %w (restoring previous configuration failed: %v)", rolloutErr, err)
+		}
+		return nil, fmt.Errorf("update failed and was rolled back: %w", rolloutErr)
+	}
+	return s.applyDeploymentActionResult(ctx, current.ID, result, action, sourceRevision)
+}
+
+// buildDeploymentUpdate applies an update to a copy of the current deployment
+// and returns it with the matching state patch.
+func (s *registryServiceImpl) buildDeploymentUpdate(ctx context.Context, current *models.Deployment, update *models.DeploymentUpdate) (*models.Deployment, *models.DeploymentStatePatch, error) {
+	desired := *current
+	patch := &models.DeploymentStatePatch{}
+	if update.Version != nil {
+		requested := strings.TrimSpace(*update.Version)
+		if requested == "" {
+			return nil, nil, fmt.Errorf("%w: version must not be empty", database.ErrInvalidInput)
+		}
+		version, err := s.resolveDeploymentVersion(ctx, current.ResourceType, current.ServerName, requested)
+		if err != nil {
+			return nil, nil, err
+		}
+		desired.Version = version
+		patch.Version = &version
+	}
+	if update.Env != nil {
+		env := maps.Clone(update.Env)
+		// Responses redact env values, so a redacted value stands for the
+		// current one.
+		for key, value := range env {
+			if value != models.RedactedEnvValue {
+				continue
+			}
+			currentValue, ok := current.Env[key]
+			if !ok {
+				return nil, nil, fmt.Errorf("%w: env %s has no current value to keep", database.ErrInvalidInput, key)
+			}
+			env[key] = currentValue
+		}
+		desired.Env = env
+		patch.Env = &env
+	}
+	if update.SecretRefs != nil {
+		refs := maps.Clone(update.SecretRefs)
+		desired.SecretRefs = refs
+		patch.SecretRefs = &refs
+	}
+	if update.ProviderConfig != nil {
+		providerConfig := maps.Clone(update.ProviderConfig)
+		desired.ProviderConfig = providerConfig
+		patch.ProviderConfig = &providerConfig
+	}
+	if update.PreferRemote != nil {
+		preferRemote := *update.PreferRemote
+		desired.PreferRemote = preferRemote
+		patch.PreferRemote = &preferRemote
+	}
+	if !patch.HasSpecChanges() && patch.ProviderConfig == nil {
+		return nil, nil, fmt.Errorf("%w: no deployment changes requested", database.ErrInvalidInput)
+	}
+	return &desired, patch, nil
+}
+
+func (s *registryServiceImpl) rolloutDeployment(
+	ctx context.Context,
+	adapter registrytypes.DeploymentPlatformAdapter,
+	previous, desired *models.Deployment,
+) (*models.DeploymentActionResult, error) {
+	if updater, ok := adapter.(DeploymentPlatformUpdater); ok {
+		return updater.Update(ctx, previous, desired)
+	}
+	return adapter.Deploy(ctx, desired)
+}
+
+// rollbackDeploymentUpdate rolls the workload back to previous and restores the
+// deployment record, recording the failed attempt as a revision produced by
+// action. The deployment keeps its previous status when the rollback succeeds
+// and is marked failed when it does not.
+func (s *registryServiceImpl) rollbackDeploymentUpdate(
+	ctx context.Context,
+	adapter registrytypes.DeploymentPlatformAdapter,
+	previous, desired *models.Deployment,
+	rolloutErr error,
+	action string,
+	sourceRevision int,
+) error {
+	ctx = context.WithoutCancel(ctx)
+	status := previous.Status
+	errorText := fmt.Sprintf("update to version %s failed and was rolled back: %v", desired.Version, rolloutErr)
+	if _, err := s.rolloutDeployment(ctx, adapter, desired, previous); err != nil {
+		status = models.DeploymentStatusFailed
+		errorText = fmt.Sprintf("update to version %s failed: %v; rollback failed: %v", desired.Version, rolloutErr, err)
+	}
+
+	version := previous.Version
+	env := previous.Env
+	secretRefs := previous.SecretRefs
+	providerConfig := previous.ProviderConfig
+	if providerConfig == nil {
+		providerConfig = models.JSONObject{}
+	}
+	preferRemote := previous.PreferRemote
+	patch := &models.DeploymentStatePatch{
+		Status:         &status,
+		Error:          &errorText,
+		ProviderConfig: &providerConfig,
+		Version:        &version,
+		Env:            &env,
+		SecretRefs:     &secretRefs,
+		PreferRemote:   &preferRemote,
+	}
+	failed := *desired
+	failed.Status = models.DeploymentStatusFailed
+	failed.Error = rolloutErr.Error()
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		systemCtx := auth.WithSystemContext(txCtx)
+		if err := s.db.UpdateDeploymentState(systemCtx, tx, previous.ID, patch); err != nil {
+			return err
+		}
+		if err := s.db.AppendDeploymentRevision(systemCtx, tx, newDeploymentRevision(&failed, action, sourceRevision)); err != nil {
+			return err
+		}
+		restored := *previous
+		restored.Status = status
+		restored.Error = errorText
+		entry := audit.NewEntry(systemCtx, models.AuditActionUpdate, models.AuditResourceDeployment, previous.ID, "", desired, &restored)
+		entry.Details = deploymentAuditDetails(&restored)
+		entry.Details["rolledBack"] = true
+		return s.db.AppendAuditEntry(txCtx, tx, entry)
+	})
+}
+
+func (s *registryServiceImpl) createManagedDeploymentRecord(ctx context.Context, req *models.Deployment) (*models.Deployment, error) {
+	now := time.Now()
+	deployment := &models.Deployment{
+		ID:               req.ID,
+		ServerName:       strings.TrimSpace(req.ServerName),
+		Version:          strings.TrimSpace(req.Version),
+		Status:           models.DeploymentStatusDeploying,
+		Env:              req.Env,
+		SecretRefs:       req.SecretRefs,
+		ProviderConfig:   req.ProviderConfig,
+		ProviderMetadata: req.ProviderMetadata,
+		PreferRemote:     req.PreferRemote,
+		ResourceType:     req.ResourceType,
+		ProviderID:       req.ProviderID,
+		Origin:           req.Origin,
 		DeployedAt:       now,
 		UpdatedAt:        now,
 	}
@@ -1183,41 +2316,183 @@ func (s *registryServiceImpl) createManagedDeploymentRecord(ctx context.Context,
 		deployment.Env = map[string]string{}
 	}
 
-	switch deployment.ResourceType {
+	version, err := s.resolveDeploymentVersion(ctx, deployment.ResourceType, deployment.ServerName, deployment.Version)
+	if err != nil {
+		return nil, err
+	}
+	deployment.Version = version
+
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.Deployment, error) {
+		if err := s.validateDeploymentSecretRefs(ctx, tx, deployment); err != nil {
+			return nil, err
+		}
+		if err := s.db.CreateDeployment(ctx, tx, deployment); err != nil {
+			return nil, err
+		}
+		created, err := s.db.GetDeploymentByID(ctx, tx, deployment.ID)
+		if err != nil {
+			return nil, err
+		}
+		entry := audit.NewEntry(ctx, models.AuditActionDeploy, models.AuditResourceDeployment, created.ID, "", nil, created)
+		entry.Details = deploymentAuditDetails(created)
+		if err := s.db.AppendAuditEntry(ctx, tx, entry); err != nil {
+			return nil, err
+		}
+		return created, nil
+	})
+}
+
+// resolveDeploymentVersion checks that the artifact to deploy exists, and is
+// signed by a trusted publisher when signatures are required on deploy, along
+// with the registry MCP servers and skills an agent is deployed with, and
+// returns its concrete version, resolving "latest".
+func (s *registryServiceImpl) resolveDeploymentVersion(ctx context.Context, resourceType, name, version string) (string, error) {
+	requireSignature := s.cfg != nil && s.cfg.SigningRequireOnDeploy
+	switch resourceType {
 	case resourceTypeMCP:
-		serverResp, err := s.db.GetServerByNameAndVersion(ctx, nil, deployment.ServerName, deployment.Version)
+		serverResp, err := s.db.GetServerByNameAndVersion(ctx, nil, name, version)
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
-				return nil, fmt.Errorf("server %s not found in registry: %w", deployment.ServerName, database.ErrNotFound)
+				return "", fmt.Errorf("server %s not found in registry: %w", name, database.ErrNotFound)
+			}
+			return "", fmt.Errorf("failed to verify server: %w", err)
+		}
+		if requireSignature {
+			if err := s.verifyServerSignature(&serverResp.Server, true); err != nil {
+				return "", fmt.Errorf("refusing to deploy: %w", err)
 			}
-			return nil, fmt.Errorf("failed to verify server: %w", err)
 		}
-		deployment.Version = serverResp.Server.Version
+		return serverResp.Server.Version, nil
 	case resourceTypeAgent:
-		agentResp, err := s.db.GetAgentByNameAndVersion(ctx, nil, deployment.ServerName, deployment.Version)
+		agentResp, err := s.db.GetAgentByNameAndVersion(ctx, nil, name, version)
 		if err != nil {
 			if errors.Is(err, database.ErrNotFound) {
-				return nil, fmt.Errorf("agent %s not found in registry: %w", deployment.ServerName, database.ErrNotFound)
+				return "", fmt.Errorf("agent %s not found in registry: %w", name, database.ErrNotFound)
+			}
+			return "", fmt.Errorf("failed to verify agent: %w", err)
+		}
+		if requireSignature {
+			if err := s.verifyArtifactSignature(signing.AgentPayload(&agentResp.Agent), agentResp.Agent.Signature, true); err != nil {
+				return "", fmt.Errorf("refusing to deploy: %w", err)
+			}
+			if err := s.verifyAgentDependencySignatures(ctx, &agentResp.Agent); err != nil {
+				return "", fmt.Errorf("refusing to deploy: %w", err)
 			}
-			return nil, fmt.Errorf("failed to verify agent: %w", err)
 		}
-		deployment.Version = agentResp.Agent.Version
+		return agentResp.Agent.Version, nil
 	default:
-		return nil, fmt.Errorf("%w: invalid resource type %q", database.ErrInvalidInput, deployment.ResourceType)
+		return "", fmt.Errorf("%w: invalid resource type %q", database.ErrInvalidInput, resourceType)
 	}
+}
 
-	if err := s.db.CreateDeployment(ctx, nil, deployment); err != nil {
-		return nil, err
+// verifyAgentDependencySignatures verifies the signatures of the registry MCP
+// servers and skills deployed with an agent, at the versions its lock pins
+// them to, as the deployment adapters resolve them.
+func (s *registryServiceImpl) verifyAgentDependencySignatures(ctx context.Context, agent *models.AgentJSON) error {
+	manifest, err := LockAgentManifest(ctx, s, agent)
+	if err != nil {
+		return err
+	}
+	for _, mcpServer := range manifest.McpServers {
+		if mcpServer.Type != "registry" {
+			continue
+		}
+		version := strings.TrimSpace(mcpServer.RegistryServerVersion)
+		if version == "" {
+			version = "latest"
+		}
+		serverResp, err := s.db.GetServerByNameAndVersion(ctx, nil, mcpServer.RegistryServerName, version)
+		if err != nil {
+			return fmt.Errorf("failed to verify MCP server %s of agent %s: %w", mcpServer.RegistryServerName, agent.Name, err)
+		}
+		if err := s.verifyServerSignature(&serverResp.Server, true); err != nil {
+			return fmt.Errorf("MCP server of agent %s: %w", agent.Name, err)
+		}
 	}
+	for _, skill := range manifest.Skills {
+		name := strings.TrimSpace(skill.RegistrySkillName)
+		if name == "" {
+			continue
+		}
+		version := strings.TrimSpace(skill.RegistrySkillVersion)
+		if version == "" {
+			version = "latest"
+		}
+		skillResp, err := s.db.GetSkillByNameAndVersion(ctx, nil, name, version)
+		if err != nil {
+			return fmt.Errorf("failed to verify skill %s of agent %s: %w", name, agent.Name, err)
+		}
+		if err := s.verifyArtifactSignature(signing.SkillPayload(&skillResp.Skill), skillResp.Skill.Signature, true); err != nil {
+			return fmt.Errorf("skill of agent %s: %w", agent.Name, err)
+		}
+	}
+	return nil
+}
 
-	created, err := s.db.GetDeploymentByID(ctx, nil, deployment.ID)
-	if err != nil {
-		return nil, err
+// newDeploymentRevision snapshots what deployment applied and how it ended.
+func newDeploymentRevision(deployment *models.Deployment, action string, sourceRevision int) *models.DeploymentRevision {
+	return &models.DeploymentRevision{
+		DeploymentID:   deployment.ID,
+		Action:         action,
+		ResourceType:   deployment.ResourceType,
+		ServerName:     deployment.ServerName,
+		Version:        deployment.Version,
+		ProviderID:     deployment.ProviderID,
+		EnvKeys:        slices.Sorted(maps.Keys(deployment.Env)),
+		SecretRefs:     maps.Clone(deployment.SecretRefs),
+		ProviderConfig: maps.Clone(deployment.ProviderConfig),
+		PreferRemote:   deployment.PreferRemote,
+		Status:         deployment.Status,
+		Error:          deployment.Error,
+		SourceRevision: sourceRevision,
+	}
+}
+
+// finishDeploymentRollout applies the state a rollout ended in and appends the
+// revision it produced in one transaction, so the revision number is allocated
+// with the state change and neither is recorded without the other. The rollout
+// has already changed the workload, so this is not cancelled with ctx.
+func (s *registryServiceImpl) finishDeploymentRollout(
+	ctx context.Context,
+	deploymentID string,
+	patch *models.DeploymentStatePatch,
+	action string,
+	sourceRevision int,
+) (*models.Deployment, error) {
+	ctx = auth.WithSystemContext(context.WithoutCancel(ctx))
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.Deployment, error) {
+		if err := s.db.UpdateDeploymentState(ctx, tx, deploymentID, patch); err != nil {
+			return nil, err
+		}
+		deployment, err := s.db.GetDeploymentByID(ctx, tx, deploymentID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AppendDeploymentRevision(ctx, tx, newDeploymentRevision(deployment, action, sourceRevision)); err != nil {
+			return nil, fmt.Errorf("failed to record %s revision: %w", action, err)
+		}
+		return deployment, nil
+	})
+}
+
+// deploymentAuditDetails identifies the deployed artifact in a deployment's audit entries.
+func deploymentAuditDetails(deployment *models.Deployment) models.JSONObject {
+	return models.JSONObject{
+		"resourceType": deployment.ResourceType,
+		"name":         deployment.ServerName,
+		"version":      deployment.Version,
+		"providerId":   deployment.ProviderID,
 	}
-	return created, nil
 }
 
-func (s *registryServiceImpl) applyDeploymentActionResult(ctx context.Context, deploymentID string, result *models.DeploymentActionResult) error {
+// applyDeploymentActionResult records a successful rollout and its revision.
+func (s *registryServiceImpl) applyDeploymentActionResult(
+	ctx context.Context,
+	deploymentID string,
+	result *models.DeploymentActionResult,
+	action string,
+	sourceRevision int,
+) (*models.Deployment, error) {
 	status := models.DeploymentStatusDeployed
 	if result != nil {
 		if trimmedStatus := strings.TrimSpace(result.Status); trimmedStatus != "" {
@@ -1243,26 +2518,17 @@ func (s *registryServiceImpl) applyDeploymentActionResult(ctx context.Context, d
 		}
 	}
 
-	return s.db.UpdateDeploymentState(auth.WithSystemContext(ctx), nil, deploymentID, patch)
+	return s.finishDeploymentRollout(ctx, deploymentID, patch, action, sourceRevision)
 }
 
+// applyFailedDeploymentAction records a failed first deploy and its revision.
 func (s *registryServiceImpl) applyFailedDeploymentAction(
 	ctx context.Context,
 	deploymentID string,
 	deployErr error,
 	result *models.DeploymentActionResult,
 ) error {
-	status := models.DeploymentStatusFailed
-	if result != nil {
-		if trimmedStatus := strings.TrimSpace(result.Status); trimmedStatus != "" {
-			status = trimmedStatus
-		}
-	}
-	errorText := strings.TrimSpace(deployErr.Error())
-	if result != nil && strings.TrimSpace(result.Error) != "" {
-		errorText = strings.TrimSpace(result.Error)
-	}
-
+	status, errorText := failedDeploymentState(deployErr, result)
 	patch := &models.DeploymentStatePatch{
 		Status: &status,
 		Error:  &errorText,
@@ -1277,11 +2543,29 @@ func (s *registryServiceImpl) applyFailedDeploymentAction(
 			patch.ProviderMetadata = &meta
 		}
 	}
-	return s.db.UpdateDeploymentState(auth.WithSystemContext(ctx), nil, deploymentID, patch)
+	_, err := s.finishDeploymentRollout(ctx, deploymentID, patch, models.DeploymentRevisionActionDeploy, 0)
+	return err
+}
+
+// failedDeploymentState returns the status and error a failed deploy leaves the
+// deployment in, preferring what the adapter reported.
+func failedDeploymentState(deployErr error, result *models.DeploymentActionResult) (string, string) {
+	status := models.DeploymentStatusFailed
+	errorText := strings.TrimSpace(deployErr.Error())
+	if result != nil {
+		if trimmedStatus := strings.TrimSpace(result.Status); trimmedStatus != "" {
+			status = trimmedStatus
+		}
+		if trimmedError := strings.TrimSpace(result.Error); trimmedError != "" {
+			errorText = trimmedError
+		}
+	}
+	return status, errorText
 }
 
 // GetDeploymentLogs dispatches logs retrieval to the platform adapter.
-func (s *registryServiceImpl) GetDeploymentLogs(ctx context.Context, deployment *models.Deployment) ([]string, error) {
+// Follow is ignored; use StreamDeploymentLogs to follow logs.
+func (s *registryServiceImpl) GetDeploymentLogs(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions) ([]string, error) {
 	if deployment == nil {
 		return nil, database.ErrNotFound
 	}
@@ -1289,19 +2573,285 @@ func (s *registryServiceImpl) GetDeploymentLogs(ctx context.Context, deployment
 	if err != nil {
 		return nil, err
 	}
-	return adapter.GetLogs(ctx, deployment)
+	streamer, ok := adapter.(DeploymentPlatformLogStreamer)
+	if !ok {
+		return adapter.GetLogs(ctx, deployment)
+	}
+	opts.Follow = false
+	logs := make([]string, 0)
+	if err := streamer.StreamLogs(ctx, deployment, opts, func(line string) error {
+		logs = append(logs, line)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// StreamDeploymentLogs streams deployment logs from the platform adapter line by line.
+// Adapters that do not implement DeploymentPlatformLogStreamer fall back to GetLogs.
+func (s *registryServiceImpl) StreamDeploymentLogs(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions, emit func(line string) error) error {
+	if deployment == nil {
+		return database.ErrNotFound
+	}
+	adapter, err := s.resolveDeploymentAdapterByProviderID(ctx, deployment.ProviderID)
+	if err != nil {
+		return err
+	}
+	if streamer, ok := adapter.(DeploymentPlatformLogStreamer); ok {
+		return streamer.StreamLogs(ctx, deployment, opts, emit)
+	}
+	// Adapters without streaming support can only return a one-shot snapshot.
+	logs, err := adapter.GetLogs(ctx, deployment)
+	if err != nil {
+		return err
+	}
+	for _, line := range logs {
+		if err := emit(line); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// CancelDeployment dispatches cancellation to the platform adapter.
+// CancelDeployment dispatches cancellation to the platform adapter and marks
+// the deployment as cancelled once the adapter has stopped it. Cancelling
+// needs the permission to deploy the artifact, since it rolls back what the
+// deploy applied. Only the replica running the deploy can stop it; the others
+// fail with ErrConflict.
 func (s *registryServiceImpl) CancelDeployment(ctx context.Context, deployment *models.Deployment) error {
 	if deployment == nil {
 		return database.ErrNotFound
 	}
+	if deployment.Status != models.DeploymentStatusDeploying {
+		return fmt.Errorf("%w: only deployments in status %q can be cancelled (current status %q)",
+			database.ErrInvalidInput, models.DeploymentStatusDeploying, deployment.Status)
+	}
+	if err := s.db.CheckDeployPermission(ctx, nil, deployment.ID); err != nil {
+		return err
+	}
 	adapter, err := s.resolveDeploymentAdapterByProviderID(ctx, deployment.ProviderID)
 	if err != nil {
 		return err
 	}
-	return adapter.Cancel(ctx, deployment)
+	if err := adapter.Cancel(ctx, deployment); err != nil {
+		return err
+	}
+
+	status := models.DeploymentStatusCancelled
+	errorText := "deployment was cancelled"
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		systemCtx := auth.WithSystemContext(txCtx)
+		if err := s.db.UpdateDeploymentState(systemCtx, tx, deployment.ID, &models.DeploymentStatePatch{
+			Status: &status,
+			Error:  &errorText,
+		}); err != nil {
+			return err
+		}
+		cancelled := *deployment
+		cancelled.Status = status
+		cancelled.Error = errorText
+		entry := audit.NewEntry(txCtx, models.AuditActionCancel, models.AuditResourceDeployment, deployment.ID, "", deployment, &cancelled)
+		entry.Details = deploymentAuditDetails(deployment)
+		return s.db.AppendAuditEntry(txCtx, tx, entry)
+	})
+}
+
+// ReconcileDeployments compares every managed deployment with live platform
+// state and records drifted, missing and unhealthy workloads. Deployments that
+// match again return to deployed. When repair is set, missing and drifted
+// workloads are re-applied from the desired spec. Only settled deployments are
+// reconciled, and deployments changed by an update or undeploy while they were
+// being observed are left for the next pass.
+func (s *registryServiceImpl) ReconcileDeployments(ctx context.Context, repair bool) ([]*models.DeploymentReconcileResult, error) {
+	ctx = auth.WithSystemContext(ctx)
+	origin := originManaged
+	deployments, err := s.db.GetDeployments(ctx, nil, &models.DeploymentFilter{Origin: &origin})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list managed deployments: %w", err)
+	}
+
+	results := make([]*models.DeploymentReconcileResult, 0, len(deployments))
+	for _, deployment := range deployments {
+		if deployment == nil || !models.IsReconciledDeploymentStatus(deployment.Status) {
+			continue
+		}
+		adapter, err := s.resolveDeploymentAdapterByProviderID(ctx, deployment.ProviderID)
+		if err != nil {
+			log.Printf("Warning: Failed to resolve deployment adapter for reconciling %s: %v", deployment.ID, err)
+			continue
+		}
+		observer, ok := adapter.(DeploymentPlatformObserver)
+		if !ok {
+			continue
+		}
+		result, err := s.reconcileDeployment(ctx, adapter, observer, deployment, repair)
+		if errors.Is(err, database.ErrConflict) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Warning: Failed to reconcile deployment %s: %v", deployment.ID, err)
+			continue
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (s *registryServiceImpl) reconcileDeployment(
+	ctx context.Context,
+	adapter registrytypes.DeploymentPlatformAdapter,
+	observer DeploymentPlatformObserver,
+	deployment *models.Deployment,
+	repair bool,
+) (*models.DeploymentReconcileResult, error) {
+	observation, err := observer.Observe(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.DeploymentReconcileResult{
+		DeploymentID:   deployment.ID,
+		PreviousStatus: deployment.Status,
+		Status:         observation.Status,
+		Reason:         observation.Reason,
+	}
+
+	// State changes only apply while the deployment is as it was listed, so an
+	// update or undeploy that started meanwhile is never overwritten.
+	deploying := models.DeploymentStatusDeploying
+	unchanged := &deployment.UpdatedAt
+	needsRepair := observation.Status == models.DeploymentStatusMissing || observation.Status == models.DeploymentStatusDrifted
+	if repair && needsRepair {
+		// Claim the deployment so no update rolls out alongside the repair.
+		if err := s.db.UpdateDeploymentState(ctx, nil, deployment.ID, &models.DeploymentStatePatch{
+			Status:       &deploying,
+			UnlessStatus: &deploying,
+			IfUpdatedAt:  unchanged,
+		}); err != nil {
+			return nil, err
+		}
+		unchanged = nil
+		if _, err := s.rolloutDeployment(ctx, adapter, deployment, deployment); err != nil {
+			result.Reason = fmt.Sprintf("%s; repair failed: %v", observation.Reason, err)
+		} else {
+			result.Status = models.DeploymentStatusDeployed
+			result.Repaired = true
+		}
+	}
+
+	status := result.Status
+	errorText := result.Reason
+	if result.Repaired {
+		errorText = ""
+	}
+	if status == deployment.Status && errorText == deployment.Error && unchanged != nil {
+		return result, nil
+	}
+	patch := &models.DeploymentStatePatch{Status: &status, Error: &errorText}
+	if unchanged != nil {
+		patch.UnlessStatus = &deploying
+		patch.IfUpdatedAt = unchanged
+	}
+	return result, s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		if err := s.db.UpdateDeploymentState(txCtx, tx, deployment.ID, patch); err != nil {
+			return err
+		}
+		if !result.Repaired {
+			return nil
+		}
+		repaired := *deployment
+		repaired.Status = status
+		repaired.Error = errorText
+		entry := audit.NewEntry(txCtx, models.AuditActionRepair, models.AuditResourceDeployment, deployment.ID, "", deployment, &repaired)
+		entry.Details = deploymentAuditDetails(deployment)
+		entry.Details["observedStatus"] = observation.Status
+		entry.Details["reason"] = observation.Reason
+		return s.db.AppendAuditEntry(txCtx, tx, entry)
+	})
+}
+
+// GetDeploymentHealth returns the last probe result of a deployment, probing
+// it first when refresh is set or it has never been probed.
+func (s *registryServiceImpl) GetDeploymentHealth(ctx context.Context, id string, refresh bool) (*models.DeploymentHealth, error) {
+	deployment, err := s.db.GetDeploymentByID(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	if deployment.Health != nil && !refresh {
+		return deployment.Health, nil
+	}
+	adapter, err := s.resolveDeploymentAdapterByProviderID(ctx, deployment.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	resolver, ok := adapter.(DeploymentPlatformProbeResolver)
+	if !ok {
+		return nil, fmt.Errorf("%w: health probes are not supported on platform %s", database.ErrInvalidInput, adapter.Platform())
+	}
+	// Callers only need read access: the probe result is runtime state, not a
+	// change to the deployment.
+	return s.probeDeployment(auth.WithSystemContext(ctx), resolver, deployment)
+}
+
+// ProbeDeployments probes every managed deployment whose workload should be
+// running and stores the results.
+func (s *registryServiceImpl) ProbeDeployments(ctx context.Context) ([]*models.DeploymentHealthResult, error) {
+	ctx = auth.WithSystemContext(ctx)
+	origin := originManaged
+	deployments, err := s.db.GetDeployments(ctx, nil, &models.DeploymentFilter{Origin: &origin})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list managed deployments: %w", err)
+	}
+
+	results := make([]*models.DeploymentHealthResult, 0, len(deployments))
+	for _, deployment := range deployments {
+		if deployment == nil || !models.IsReconciledDeploymentStatus(deployment.Status) || deployment.Status == models.DeploymentStatusMissing {
+			continue
+		}
+		adapter, err := s.resolveDeploymentAdapterByProviderID(ctx, deployment.ProviderID)
+		if err != nil {
+			log.Printf("Warning: Failed to resolve deployment adapter for probing %s: %v", deployment.ID, err)
+			continue
+		}
+		resolver, ok := adapter.(DeploymentPlatformProbeResolver)
+		if !ok {
+			continue
+		}
+		result, err := s.probeDeployment(ctx, resolver, deployment)
+		if err != nil {
+			log.Printf("Warning: Failed to probe deployment %s: %v", deployment.ID, err)
+			continue
+		}
+		results = append(results, &models.DeploymentHealthResult{
+			DeploymentID: deployment.ID,
+			Previous:     deployment.Health,
+			Health:       result,
+		})
+	}
+	return results, nil
+}
+
+func (s *registryServiceImpl) probeDeployment(
+	ctx context.Context,
+	resolver DeploymentPlatformProbeResolver,
+	deployment *models.Deployment,
+) (*models.DeploymentHealth, error) {
+	var result *models.DeploymentHealth
+	target, err := resolver.ProbeTarget(ctx, deployment)
+	if err != nil {
+		// A deployment whose endpoint cannot be resolved is recorded as
+		// unhealthy rather than left with a stale result.
+		result = &models.DeploymentHealth{
+			CheckedAt: time.Now().UTC(),
+			Error:     fmt.Sprintf("resolve probe endpoint: %v", err),
+		}
+	} else {
+		result = s.healthProber.Probe(ctx, target)
+	}
+	if err := s.db.UpdateDeploymentState(ctx, nil, deployment.ID, &models.DeploymentStatePatch{Health: result}); err != nil {
+		return nil, fmt.Errorf("failed to store deployment health: %w", err)
+	}
+	return result, nil
 }
 
 // ResolveAgentManifestSkills resolves registry-type skill references from the
@@ -1398,13 +2948,18 @@ func (s *registryServiceImpl) ResolveAgentManifestPrompts(ctx context.Context, m
 			return nil, fmt.Errorf("resolve prompt %q version %q: %w", promptName, version, err)
 		}
 
+		content, err := promptResp.Prompt.Render(ref.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("render prompt %q version %q: %w", promptName, promptResp.Prompt.Version, err)
+		}
+
 		displayName := ref.Name
 		if displayName == "" {
 			displayName = promptName
 		}
 		resolved = append(resolved, api.ResolvedPrompt{
 			Name:    displayName,
-			Content: promptResp.Prompt.Content,
+			Content: content,
 		})
 	}
 	return resolved, nil
@@ -1444,6 +2999,11 @@ func (s *registryServiceImpl) ListPrompts(ctx context.Context, filter *database.
 	if limit <= 0 {
 		limit = 30
 	}
+	if filter != nil {
+		if err := s.ensureSemanticEmbedding(ctx, filter.Semantic); err != nil {
+			return nil, "", err
+		}
+	}
 	prompts, next, err := s.db.ListPrompts(ctx, nil, filter, cursor, limit)
 	if err != nil {
 		return nil, "", err
@@ -1473,10 +3033,38 @@ func (s *registryServiceImpl) CreatePrompt(ctx context.Context, req *models.Prom
 	})
 }
 
+// RenderPrompt renders a prompt version with argument values. version may be
+// "latest".
+func (s *registryServiceImpl) RenderPrompt(ctx context.Context, promptName, version string, arguments map[string]string) (*models.PromptRenderResponse, error) {
+	var promptResp *models.PromptResponse
+	var err error
+	if version == "" || version == "latest" {
+		promptResp, err = s.GetPromptByName(ctx, promptName)
+	} else {
+		promptResp, err = s.GetPromptByNameAndVersion(ctx, promptName, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := promptResp.Prompt.Render(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
+	return &models.PromptRenderResponse{
+		Name:    promptResp.Prompt.Name,
+		Version: promptResp.Prompt.Version,
+		Content: content,
+	}, nil
+}
+
 func (s *registryServiceImpl) createPromptInTransaction(ctx context.Context, tx pgx.Tx, req *models.PromptJSON) (*models.PromptResponse, error) {
 	if req == nil || req.Name == "" || req.Version == "" {
 		return nil, fmt.Errorf("invalid prompt payload: name and version are required")
 	}
+	if err := req.ValidateTemplate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+	}
 
 	publishTime := time.Now()
 	promptJSON := *req
@@ -1526,12 +3114,169 @@ func (s *registryServiceImpl) createPromptInTransaction(ctx context.Context, tx
 		IsLatest:    isNewLatest,
 	}
 
-	return s.db.CreatePrompt(ctx, tx, &promptJSON, officialMeta)
+	result, err := s.db.CreatePrompt(ctx, tx, &promptJSON, officialMeta)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionPublish, models.AuditResourcePrompt, promptJSON.Name, promptJSON.Version, nil, result)); err != nil {
+		return nil, err
+	}
+
+	// Generate embedding asynchronously (non-blocking, best-effort)
+	if s.shouldGenerateEmbeddingsOnPublish() { //nolint:nestif
+		go func() {
+			bgCtx := context.Background()
+			payload := embeddings.BuildPromptEmbeddingPayload(&promptJSON)
+			if strings.TrimSpace(payload) == "" {
+				return
+			}
+			embedding, err := embeddings.GenerateSemanticEmbedding(bgCtx, s.embeddingsProvider, payload, s.cfg.Embeddings.Dimensions)
+			if err != nil {
+				s.logger.Warn("failed to generate embedding for prompt", "name", promptJSON.Name, "version", promptJSON.Version, "error", err)
+			} else if embedding != nil {
+				if err := s.UpsertPromptEmbedding(bgCtx, promptJSON.Name, promptJSON.Version, embedding); err != nil {
+					s.logger.Warn("failed to store embedding for prompt", "name", promptJSON.Name, "version", promptJSON.Version, "error", err)
+				}
+			}
+		}()
+	}
+
+	return result, nil
+}
+
+// UpdatePrompt updates an existing prompt version and optionally its status
+func (s *registryServiceImpl) UpdatePrompt(ctx context.Context, promptName, version string, req *models.PromptJSON, newStatus *string) (*models.PromptResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.PromptResponse, error) {
+		current, err := s.db.GetPromptByNameAndVersion(ctx, tx, promptName, version)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateEdit(promptStatus(current), req != nil); err != nil {
+			return nil, err
+		}
+		if err := req.ValidateTemplate(); err != nil {
+			return nil, fmt.Errorf("%w: %v", database.ErrInvalidInput, err)
+		}
+		updated, err := s.db.UpdatePrompt(ctx, tx, promptName, version, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AppendAuditEntry(ctx, tx, audit.NewEntry(ctx, models.AuditActionEdit, models.AuditResourcePrompt, promptName, version, current, updated)); err != nil {
+			return nil, err
+		}
+
+		// Keep the successor of a version that stays deprecated
+		if newStatus == nil || *newStatus == promptStatus(updated) {
+			return updated, nil
+		}
+		return s.setPromptStatusInTransaction(ctx, tx, promptName, version, *newStatus, "", updated)
+	})
+}
+
+// SetPromptStatus updates the status of a specific prompt version. successor names
+// the version that replaces a deprecated one and may be empty.
+func (s *registryServiceImpl) SetPromptStatus(ctx context.Context, promptName, version, status, successor string) (*models.PromptResponse, error) {
+	return database.InTransactionT(ctx, s.db, func(ctx context.Context, tx pgx.Tx) (*models.PromptResponse, error) {
+		before, err := s.db.GetPromptByNameAndVersion(auth.WithSystemContext(ctx), tx, promptName, version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return nil, err
+		}
+		return s.setPromptStatusInTransaction(ctx, tx, promptName, version, status, successor, before)
+	})
+}
+
+func (s *registryServiceImpl) setPromptStatusInTransaction(ctx context.Context, tx pgx.Tx, promptName, version, status, successor string, before *models.PromptResponse) (*models.PromptResponse, error) {
+	if err := validateStatusChange(promptStatus(before), status, version, successor); err != nil {
+		return nil, err
+	}
+	if successor != "" {
+		exists, err := s.db.CheckPromptVersionExists(auth.WithSystemContext(ctx), tx, promptName, successor)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, fmt.Errorf("%w: successor version %s of prompt %s does not exist", database.ErrInvalidInput, successor, promptName)
+		}
+	}
+
+	updated, err := s.db.SetPromptStatus(ctx, tx, promptName, version, status, successor)
+	if err != nil {
+		return nil, err
+	}
+	entry := audit.NewEntry(ctx, models.AuditActionSetStatus, models.AuditResourcePrompt, promptName, version, before, updated)
+	entry.Details = models.JSONObject{"status": status}
+	if successor != "" {
+		entry.Details["successor"] = successor
+	}
+	if err := s.db.AppendAuditEntry(ctx, tx, entry); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func promptStatus(prompt *models.PromptResponse) string {
+	if prompt == nil || prompt.Meta.Official == nil {
+		return ""
+	}
+	return prompt.Meta.Official.Status
 }
 
 // DeletePrompt permanently removes a prompt version from the registry
 func (s *registryServiceImpl) DeletePrompt(ctx context.Context, promptName, version string) error {
 	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
-		return s.db.DeletePrompt(txCtx, tx, promptName, version)
+		before, err := s.db.GetPromptByNameAndVersion(auth.WithSystemContext(txCtx), tx, promptName, version)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return err
+		}
+		if err := s.db.DeletePrompt(txCtx, tx, promptName, version); err != nil {
+			return err
+		}
+		return s.db.AppendAuditEntry(txCtx, tx, audit.NewEntry(txCtx, models.AuditActionDelete, models.AuditResourcePrompt, promptName, version, before, nil))
 	})
 }
+
+func (s *registryServiceImpl) UpsertPromptEmbedding(ctx context.Context, promptName, version string, embedding *database.SemanticEmbedding) error {
+	return s.db.InTransaction(ctx, func(txCtx context.Context, tx pgx.Tx) error {
+		return s.db.SetPromptEmbedding(txCtx, tx, promptName, version, embedding)
+	})
+}
+
+func (s *registryServiceImpl) GetPromptEmbeddingMetadata(ctx context.Context, promptName, version string) (*database.SemanticEmbeddingMetadata, error) {
+	return s.db.GetPromptEmbeddingMetadata(ctx, nil, promptName, version)
+}
+
+// SearchRegistry searches servers, agents, skills and prompts at once and returns
+// a single ranked page of results together with per-type facet counts.
+func (s *registryServiceImpl) SearchRegistry(ctx context.Context, filter *database.SearchFilter, cursor string, limit int) (*models.SearchResponse, error) {
+	if limit <= 0 {
+		limit = 30
+	}
+	if filter == nil {
+		filter = &database.SearchFilter{}
+	}
+	if err := s.ensureSemanticEmbedding(ctx, filter.Semantic); err != nil {
+		return nil, err
+	}
+
+	results, next, err := s.db.SearchArtifacts(ctx, nil, filter, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	facets, err := s.db.CountSearchArtifacts(ctx, nil, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.SearchResponse{
+		Results: make([]models.SearchResult, len(results)),
+		Metadata: models.SearchMetadata{
+			NextCursor: next,
+			Count:      len(results),
+			Facets:     facets,
+		},
+	}
+	for i, r := range results {
+		resp.Results[i] = *r
+	}
+	return resp, nil
+}
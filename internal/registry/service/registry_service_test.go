@@ -3,18 +3,29 @@ package service
 
 import (
 	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
 	"fmt"
 	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/agentregistry-dev/agentregistry/internal/registry/audit"
 	"github.com/agentregistry-dev/agentregistry/internal/registry/config"
 	internaldb "github.com/agentregistry-dev/agentregistry/internal/registry/database"
+	"github.com/agentregistry-dev/agentregistry/internal/registry/health"
 	api "github.com/agentregistry-dev/agentregistry/internal/registry/platforms/types"
 	"github.com/agentregistry-dev/agentregistry/pkg/models"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/auth"
 	"github.com/agentregistry-dev/agentregistry/pkg/registry/database"
+	"github.com/agentregistry-dev/agentregistry/pkg/signing"
+	"github.com/agentregistry-dev/agentregistry/pkg/skillbundle"
 	registrytypes "github.com/agentregistry-dev/agentregistry/pkg/types"
 	"github.com/jackc/pgx/v5"
 	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
@@ -731,6 +742,141 @@ func TestUpdateServer_SkipValidationForDeletedServers(t *testing.T) {
 	assert.Equal(t, model.StatusDeleted, result2.Meta.Official.Status)
 }
 
+func TestSetPromptStatus_Successor(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{}, nil)
+
+	promptName := "com.example/lifecycle-prompt"
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		_, err := service.CreatePrompt(ctx, &models.PromptJSON{Name: promptName, Version: version, Content: "Hello"})
+		require.NoError(t, err)
+	}
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+	deprecated := string(model.StatusDeprecated)
+
+	_, err := service.SetPromptStatus(ctxWithAuth, promptName, "1.0.0", deprecated, "3.0.0")
+	require.ErrorIs(t, err, database.ErrInvalidInput, "successor must be a published version")
+	_, err = service.SetPromptStatus(ctxWithAuth, promptName, "1.0.0", string(model.StatusActive), "2.0.0")
+	require.ErrorIs(t, err, database.ErrInvalidInput, "only deprecated versions have a successor")
+
+	result, err := service.SetPromptStatus(ctxWithAuth, promptName, "1.0.0", deprecated, "2.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, deprecated, result.Meta.Official.Status)
+	assert.Equal(t, "2.0.0", result.Meta.Official.Successor)
+
+	// Editing a version that stays deprecated keeps its successor
+	result, err = service.UpdatePrompt(ctxWithAuth, promptName, "1.0.0", &models.PromptJSON{Name: promptName, Version: "1.0.0", Content: "Hi"}, &deprecated)
+	require.NoError(t, err)
+	assert.Equal(t, "Hi", result.Prompt.Content)
+	assert.Equal(t, "2.0.0", result.Meta.Official.Successor)
+
+	result, err = service.SetPromptStatus(ctxWithAuth, promptName, "1.0.0", string(model.StatusActive), "")
+	require.NoError(t, err)
+	assert.Equal(t, string(model.StatusActive), result.Meta.Official.Status)
+	assert.Empty(t, result.Meta.Official.Successor)
+
+	_, err = service.SetPromptStatus(ctxWithAuth, promptName, "1.0.0", string(model.StatusDeleted), "")
+	require.NoError(t, err)
+	_, err = service.SetPromptStatus(ctxWithAuth, promptName, "1.0.0", string(model.StatusActive), "")
+	require.ErrorIs(t, err, database.ErrInvalidInput, "deleted versions cannot be undeleted")
+}
+
+func TestPromptTemplates(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{}, nil)
+
+	_, err := service.CreatePrompt(ctx, &models.PromptJSON{Name: "com.example/undeclared", Version: "1.0.0", Content: "Hello {{name}}"})
+	require.ErrorIs(t, err, database.ErrInvalidInput, "placeholders must be declared arguments")
+
+	_, err = service.CreatePrompt(ctx, &models.PromptJSON{
+		Name:      "com.example/greeter",
+		Version:   "1.0.0",
+		Content:   "Hello {{name}}, reply in {{language}}.",
+		Arguments: []models.PromptArgument{{Name: "name", Required: true}, {Name: "language", Default: "English"}},
+	})
+	require.NoError(t, err)
+
+	rendered, err := service.RenderPrompt(ctx, "com.example/greeter", "latest", map[string]string{"name": "Ada", "language": "French"})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello Ada, reply in French.", rendered.Content)
+
+	resolved, err := service.ResolveAgentManifestPrompts(ctx, &models.AgentManifest{
+		Prompts: []models.PromptRef{{Name: "system", RegistryPromptName: "com.example/greeter", Arguments: map[string]string{"name": "Grace"}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, "Hello Grace, reply in English.", resolved[0].Content)
+
+	_, err = service.ResolveAgentManifestPrompts(ctx, &models.AgentManifest{
+		Prompts: []models.PromptRef{{Name: "system", RegistryPromptName: "com.example/greeter"}},
+	})
+	require.ErrorContains(t, err, "missing required prompt arguments: name")
+}
+
+func TestUpdateAgent_ValidatesEdits(t *testing.T) {
+	ctx := context.Background()
+	testDB := internaldb.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{}, nil)
+
+	agent := func(name, remote string) *models.AgentJSON {
+		return &models.AgentJSON{
+			AgentManifest: models.AgentManifest{Name: name, Image: "ghcr.io/example/" + name + ":1.0.0"},
+			Version:       "1.0.0",
+			Remotes:       []model.Transport{{Type: "streamable-http", URL: remote}},
+		}
+	}
+	_, err := service.CreateAgent(ctx, agent("com.example/planner", "https://planner.example.com/mcp"))
+	require.NoError(t, err)
+	_, err = service.CreateAgent(ctx, agent("com.example/writer", "https://writer.example.com/mcp"))
+	require.NoError(t, err)
+	ctxWithAuth := internaldb.WithTestSession(ctx)
+
+	_, err = service.UpdateAgent(ctxWithAuth, "com.example/writer", "1.0.0", agent("com.example/writer", "https://planner.example.com/mcp"), nil)
+	require.ErrorContains(t, err, "already used by agent com.example/planner")
+
+	_, err = service.SetAgentStatus(ctxWithAuth, "com.example/writer", "1.0.0", string(model.StatusDeleted), "")
+	require.NoError(t, err)
+	_, err = service.UpdateAgent(ctxWithAuth, "com.example/writer", "1.0.0", agent("com.example/writer", "https://writer.example.com/v2/mcp"), nil)
+	require.ErrorIs(t, err, database.ErrInvalidInput, "deleted versions cannot be edited")
+}
+
+func TestValidateEdit(t *testing.T) {
+	require.NoError(t, validateEdit(string(model.StatusDeprecated), true))
+	require.NoError(t, validateEdit(string(model.StatusDeleted), false), "deleted versions may be set to deleted again")
+	require.ErrorIs(t, validateEdit(string(model.StatusDeleted), true), database.ErrInvalidInput)
+}
+
+func TestValidateStatusChange(t *testing.T) {
+	tests := []struct {
+		name          string
+		currentStatus string
+		status        string
+		successor     string
+		wantErr       bool
+	}{
+		{name: "deprecate", currentStatus: "active", status: "deprecated"},
+		{name: "deprecate with successor", currentStatus: "active", status: "deprecated", successor: "2.0.0"},
+		{name: "undeprecate", currentStatus: "deprecated", status: "active"},
+		{name: "delete", currentStatus: "deprecated", status: "deleted"},
+		{name: "unknown status", currentStatus: "active", status: "yanked", wantErr: true},
+		{name: "undelete", currentStatus: "deleted", status: "active", wantErr: true},
+		{name: "successor while active", currentStatus: "deprecated", status: "active", successor: "2.0.0", wantErr: true},
+		{name: "own successor", currentStatus: "active", status: "deprecated", successor: "1.0.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStatusChange(tt.currentStatus, tt.status, "1.0.0", tt.successor)
+			if tt.wantErr {
+				require.ErrorIs(t, err, database.ErrInvalidInput)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestListServers(t *testing.T) {
 	ctx := context.Background()
 	testDB := internaldb.NewTestDB(t)
@@ -1052,11 +1198,22 @@ func TestApplyDeploymentActionResult_UsesSystemContext(t *testing.T) {
 			require.Empty(t, *patch.Error)
 			return nil
 		},
+		getDeploymentByIDFn: func(_ context.Context, _ pgx.Tx, id string) (*models.Deployment, error) {
+			return &models.Deployment{ID: id, ServerName: "io.test/server", Status: "deployed", Env: map[string]string{"TOKEN": "secret"}}, nil
+		},
 	}
 
 	svc := &registryServiceImpl{db: mockDB}
-	err := svc.applyDeploymentActionResult(ctx, "dep-1", &models.DeploymentActionResult{Status: "deployed"})
+	deployment, err := svc.applyDeploymentActionResult(ctx, "dep-1", &models.DeploymentActionResult{Status: "deployed"}, models.DeploymentRevisionActionDeploy, 0)
 	require.NoError(t, err)
+	assert.Equal(t, "dep-1", deployment.ID)
+	// The revision is recorded with the state change, keeping only env keys.
+	require.Len(t, mockDB.revisions, 1)
+	assert.Equal(t, []string{"TOKEN"}, mockDB.revisions[0].EnvKeys)
+
+	mockDB.appendDeploymentRevisionErr = fmt.Errorf("revision conflict")
+	_, err = svc.applyDeploymentActionResult(ctx, "dep-1", &models.DeploymentActionResult{Status: "deployed"}, models.DeploymentRevisionActionDeploy, 0)
+	require.ErrorContains(t, err, "revision conflict")
 }
 
 func TestApplyFailedDeploymentAction_UsesSystemContext(t *testing.T) {
@@ -1074,11 +1231,16 @@ func TestApplyFailedDeploymentAction_UsesSystemContext(t *testing.T) {
 			require.Equal(t, "boom", *patch.Error)
 			return nil
 		},
+		getDeploymentByIDFn: func(_ context.Context, _ pgx.Tx, id string) (*models.Deployment, error) {
+			return &models.Deployment{ID: id, ServerName: "io.test/server", Status: "failed", Error: "boom"}, nil
+		},
 	}
 
 	svc := &registryServiceImpl{db: mockDB}
 	err := svc.applyFailedDeploymentAction(ctx, "dep-2", fmt.Errorf("boom"), nil)
 	require.NoError(t, err)
+	require.Len(t, mockDB.revisions, 1)
+	assert.Equal(t, models.DeploymentStatusFailed, mockDB.revisions[0].Status)
 }
 
 type deployCreateMockDB struct {
@@ -1091,6 +1253,10 @@ type deployCreateMockDB struct {
 	updateDeploymentStateFn     func(ctx context.Context, tx pgx.Tx, id string, patch *models.DeploymentStatePatch) error
 	getDeploymentsFn            func(ctx context.Context, tx pgx.Tx, filter *models.DeploymentFilter) ([]*models.Deployment, error)
 	removeDeploymentByIDFn      func(ctx context.Context, tx pgx.Tx, id string) error
+	checkDeployPermissionFn     func(ctx context.Context, tx pgx.Tx, id string) error
+	// revisions collects appended deployment revisions.
+	revisions                   []*models.DeploymentRevision
+	appendDeploymentRevisionErr error
 }
 
 // deploymentMockDB is a minimal mock for database.Database that only implements
@@ -1102,6 +1268,8 @@ type deploymentMockDB struct {
 	listProvidersFn        func(ctx context.Context, tx pgx.Tx, platform *string) ([]*models.Provider, error)
 	getProviderByIDFn      func(ctx context.Context, tx pgx.Tx, providerID string) (*models.Provider, error)
 	removeDeploymentByIdFn func(ctx context.Context, tx pgx.Tx, id string) error
+	// revisions collects appended deployment revisions.
+	revisions []*models.DeploymentRevision
 }
 
 func (m *deployCreateMockDB) GetProviderByID(ctx context.Context, tx pgx.Tx, providerID string) (*models.Provider, error) {
@@ -1128,6 +1296,13 @@ func (m *deployCreateMockDB) UpdateDeploymentState(ctx context.Context, tx pgx.T
 	return m.updateDeploymentStateFn(ctx, tx, id, patch)
 }
 
+func (m *deployCreateMockDB) CheckDeployPermission(ctx context.Context, tx pgx.Tx, id string) error {
+	if m.checkDeployPermissionFn == nil {
+		return nil
+	}
+	return m.checkDeployPermissionFn(ctx, tx, id)
+}
+
 func (m *deployCreateMockDB) GetDeployments(ctx context.Context, tx pgx.Tx, filter *models.DeploymentFilter) ([]*models.Deployment, error) {
 	return m.getDeploymentsFn(ctx, tx, filter)
 }
@@ -1156,6 +1331,51 @@ func (m *deploymentMockDB) RemoveDeploymentByID(ctx context.Context, tx pgx.Tx,
 	return m.removeDeploymentByIdFn(ctx, tx, id)
 }
 
+// InTransaction runs fn without a transaction; the mocks ignore tx.
+func (m *deployCreateMockDB) InTransaction(ctx context.Context, fn func(context.Context, pgx.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+func (m *deployCreateMockDB) AppendAuditEntry(context.Context, pgx.Tx, *models.AuditEntry) error {
+	return nil
+}
+
+func (m *deployCreateMockDB) AppendDeploymentRevision(_ context.Context, _ pgx.Tx, rev *models.DeploymentRevision) error {
+	if m.appendDeploymentRevisionErr != nil {
+		return m.appendDeploymentRevisionErr
+	}
+	rev.Revision = 1
+	for _, existing := range m.revisions {
+		if existing.DeploymentID == rev.DeploymentID {
+			rev.Revision = existing.Revision + 1
+		}
+	}
+	m.revisions = append(m.revisions, rev)
+	return nil
+}
+
+func (m *deployCreateMockDB) GetDeploymentRevision(_ context.Context, _ pgx.Tx, deploymentID string, revision int) (*models.DeploymentRevision, error) {
+	for _, rev := range m.revisions {
+		if rev.DeploymentID == deploymentID && rev.Revision == revision {
+			return rev, nil
+		}
+	}
+	return nil, database.ErrNotFound
+}
+
+func (m *deploymentMockDB) InTransaction(ctx context.Context, fn func(context.Context, pgx.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+func (m *deploymentMockDB) AppendAuditEntry(context.Context, pgx.Tx, *models.AuditEntry) error {
+	return nil
+}
+
+func (m *deploymentMockDB) AppendDeploymentRevision(_ context.Context, _ pgx.Tx, rev *models.DeploymentRevision) error {
+	m.revisions = append(m.revisions, rev)
+	return nil
+}
+
 // Helper functions
 func stringPtr(s string) *string {
 	return &s
@@ -1316,10 +1536,20 @@ func TestUndeployDeployment_UsesAdapterForLocalPlatform(t *testing.T) {
 		},
 	}
 
-	err := svc.UndeployDeployment(context.Background(), &models.Deployment{ID: "dep-local-1", ProviderID: "local"})
+	err := svc.UndeployDeployment(context.Background(), &models.Deployment{
+		ID:         "dep-local-1",
+		ServerName: "io.test/server",
+		ProviderID: "local",
+		Env:        map[string]string{"TOKEN": "secret"},
+	})
 	require.NoError(t, err)
 	assert.True(t, undeployCalled)
 	assert.True(t, removeCalled)
+	// The undeploy is recorded as a revision that outlives the deployment.
+	require.Len(t, mockDB.revisions, 1)
+	assert.Equal(t, models.DeploymentRevisionActionUndeploy, mockDB.revisions[0].Action)
+	assert.Equal(t, models.DeploymentRevisionStatusUndeployed, mockDB.revisions[0].Status)
+	assert.Equal(t, []string{"TOKEN"}, mockDB.revisions[0].EnvKeys)
 }
 
 func TestUndeployDeployment_FailedOrCancelledRunsAdapterCleanup(t *testing.T) {
@@ -1370,6 +1600,131 @@ func TestUndeployDeployment_FailedOrCancelledRunsAdapterCleanup(t *testing.T) {
 	}
 }
 
+func TestCancelDeployment_MarksDeploymentCancelled(t *testing.T) {
+	cancelCalled := false
+	var patched *models.DeploymentStatePatch
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "local"}, nil
+		},
+		updateDeploymentStateFn: func(ctx context.Context, _ pgx.Tx, id string, patch *models.DeploymentStatePatch) error {
+			session, ok := auth.AuthSessionFrom(ctx)
+			require.True(t, ok)
+			require.True(t, auth.IsSystemSession(session))
+			require.Equal(t, "dep-cancel-1", id)
+			patched = patch
+			return nil
+		},
+	}
+	adapter := &testDeploymentAdapter{
+		cancelFn: func(_ context.Context, deployment *models.Deployment) error {
+			cancelCalled = deployment != nil && deployment.ID == "dep-cancel-1"
+			return nil
+		},
+	}
+
+	svc := &registryServiceImpl{
+		db: mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{
+			"local": adapter,
+		},
+	}
+
+	err := svc.CancelDeployment(context.Background(), &models.Deployment{
+		ID:         "dep-cancel-1",
+		ProviderID: "local",
+		Status:     models.DeploymentStatusDeploying,
+	})
+	require.NoError(t, err)
+	assert.True(t, cancelCalled)
+	require.NotNil(t, patched)
+	require.NotNil(t, patched.Status)
+	assert.Equal(t, models.DeploymentStatusCancelled, *patched.Status)
+	require.NotNil(t, patched.Error)
+	assert.NotEmpty(t, *patched.Error)
+}
+
+func TestCancelDeployment_ChecksDeployPermissionBeforeAdapter(t *testing.T) {
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "local"}, nil
+		},
+		checkDeployPermissionFn: func(ctx context.Context, _ pgx.Tx, id string) error {
+			_, ok := auth.AuthSessionFrom(ctx)
+			require.False(t, ok, "the caller's context must be checked, not the system context")
+			require.Equal(t, "dep-cancel-3", id)
+			return auth.ErrForbidden
+		},
+	}
+	adapter := &testDeploymentAdapter{
+		cancelFn: func(_ context.Context, _ *models.Deployment) error {
+			t.Fatal("adapter cancel should not be called")
+			return nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	err := svc.CancelDeployment(context.Background(), &models.Deployment{
+		ID:         "dep-cancel-3",
+		ProviderID: "local",
+		Status:     models.DeploymentStatusDeploying,
+	})
+	require.ErrorIs(t, err, auth.ErrForbidden)
+}
+
+func TestCancelDeployment_DeployOnAnotherReplicaIsLeftAlone(t *testing.T) {
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "local"}, nil
+		},
+		updateDeploymentStateFn: func(context.Context, pgx.Tx, string, *models.DeploymentStatePatch) error {
+			t.Fatal("deployment state should not change")
+			return nil
+		},
+	}
+	adapter := &testDeploymentAdapter{
+		cancelFn: func(_ context.Context, _ *models.Deployment) error {
+			return fmt.Errorf("%w: deployment is not being applied by this registry instance", database.ErrConflict)
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	err := svc.CancelDeployment(context.Background(), &models.Deployment{
+		ID:         "dep-cancel-4",
+		ProviderID: "local",
+		Status:     models.DeploymentStatusDeploying,
+	})
+	require.ErrorIs(t, err, database.ErrConflict)
+}
+
+func TestCancelDeployment_RejectsDeploymentNotInProgress(t *testing.T) {
+	adapter := &testDeploymentAdapter{
+		cancelFn: func(_ context.Context, _ *models.Deployment) error {
+			t.Fatal("adapter cancel should not be called")
+			return nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db: &deployCreateMockDB{},
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{
+			"local": adapter,
+		},
+	}
+
+	err := svc.CancelDeployment(context.Background(), &models.Deployment{
+		ID:         "dep-cancel-2",
+		ProviderID: "local",
+		Status:     models.DeploymentStatusDeployed,
+	})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+}
+
 func TestCreateDeployment_RejectsUnsupportedResourceTypeForProvider(t *testing.T) {
 	mockDB := &deployCreateMockDB{
 		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
@@ -1497,6 +1852,575 @@ func TestCreateDeployment_UsesAdapterResolvedFromProviderPlatform(t *testing.T)
 	}
 }
 
+func TestCreateDeployment_RequiresTrustedSignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	policy, err := signing.NewTrustPolicy(map[string]crypto.PublicKey{"release": pub}, nil, nil)
+	require.NoError(t, err)
+
+	signAgent := func(image string, servers ...models.McpServerType) models.AgentJSON {
+		agent := models.AgentJSON{
+			AgentManifest: models.AgentManifest{Name: "io.test/agent", Image: image, McpServers: servers},
+			Version:       "1.0.0",
+		}
+		sig, err := signing.Sign(signing.AgentPayload(&agent).Bytes(), priv)
+		require.NoError(t, err)
+		agent.Signature = &models.ArtifactSignature{Signature: sig, KeyID: "release"}
+		return agent
+	}
+	const pinnedImage = "ghcr.io/test/agent@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	signed := signAgent(pinnedImage)
+
+	tampered := signed
+	tampered.Image = "ghcr.io/attacker/agent@sha256:fcde2b2edba56bf408601fb721fe9b5c338d10ee429ea04fae5511b68fbf8fb9"
+
+	withServer := signAgent(pinnedImage, models.McpServerType{Type: "registry", Name: "weather", RegistryServerName: "io.test/weather", RegistryServerVersion: "1.0.0"})
+
+	tests := []struct {
+		name    string
+		agent   models.AgentJSON
+		wantErr string
+	}{
+		{name: "unsigned", agent: models.AgentJSON{AgentManifest: signed.AgentManifest, Version: signed.Version}, wantErr: "not signed"},
+		{name: "tampered", agent: tampered, wantErr: "does not match"},
+		{name: "tagged image", agent: signAgent("ghcr.io/test/agent:1.0.0"), wantErr: "referenced by digest"},
+		{name: "unsigned MCP server", agent: withServer, wantErr: "MCP server of agent io.test/agent"},
+		{name: "signed", agent: signed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adapterCalled := false
+			mockDB := &deployCreateMockDB{
+				getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+					return &models.Provider{ID: providerID, Platform: "local"}, nil
+				},
+				getAgentByNameAndVersionFn: func(_ context.Context, _ pgx.Tx, _, _ string) (*models.AgentResponse, error) {
+					return &models.AgentResponse{Agent: tt.agent}, nil
+				},
+				getServerByNameAndVersionFn: func(_ context.Context, _ pgx.Tx, serverName, version string) (*apiv0.ServerResponse, error) {
+					return &apiv0.ServerResponse{Server: apiv0.ServerJSON{Name: serverName, Version: version}}, nil
+				},
+				createDeploymentFn: func(_ context.Context, _ pgx.Tx, _ *models.Deployment) error {
+					return nil
+				},
+				updateDeploymentStateFn: func(_ context.Context, _ pgx.Tx, _ string, _ *models.DeploymentStatePatch) error {
+					return nil
+				},
+				getDeploymentByIDFn: func(_ context.Context, _ pgx.Tx, id string) (*models.Deployment, error) {
+					return &models.Deployment{ID: id, Status: "deployed"}, nil
+				},
+			}
+			svc := &registryServiceImpl{
+				db:          mockDB,
+				cfg:         &config.Config{SigningRequireOnDeploy: true},
+				trustPolicy: policy,
+				deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{
+					"local": &testDeploymentAdapter{deployFn: func(_ context.Context, _ *models.Deployment) (*models.DeploymentActionResult, error) {
+						adapterCalled = true
+						return &models.DeploymentActionResult{Status: "deployed"}, nil
+					}},
+				},
+			}
+
+			_, err := svc.CreateDeployment(context.Background(), &models.Deployment{
+				ServerName:   "io.test/agent",
+				Version:      "1.0.0",
+				ProviderID:   "local",
+				ResourceType: "agent",
+			})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.True(t, adapterCalled)
+				return
+			}
+			require.ErrorIs(t, err, database.ErrInvalidInput)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.False(t, adapterCalled, "unverified artifacts must not be deployed")
+		})
+	}
+}
+
+type testUpdatingDeploymentAdapter struct {
+	testDeploymentAdapter
+	updateFn func(ctx context.Context, previous, desired *models.Deployment) (*models.DeploymentActionResult, error)
+}
+
+func (a *testUpdatingDeploymentAdapter) Update(ctx context.Context, previous, desired *models.Deployment) (*models.DeploymentActionResult, error) {
+	return a.updateFn(ctx, previous, desired)
+}
+
+// newUpdateDeploymentTestDB returns a mock that applies state patches to record.
+func newUpdateDeploymentTestDB(t *testing.T, record *models.Deployment) *deployCreateMockDB {
+	t.Helper()
+	return &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "local"}, nil
+		},
+		getServerByNameAndVersionFn: func(_ context.Context, _ pgx.Tx, serverName, version string) (*apiv0.ServerResponse, error) {
+			return &apiv0.ServerResponse{Server: apiv0.ServerJSON{Name: serverName, Version: version}}, nil
+		},
+		getDeploymentByIDFn: func(_ context.Context, _ pgx.Tx, id string) (*models.Deployment, error) {
+			require.Equal(t, record.ID, id)
+			cloned := *record
+			return &cloned, nil
+		},
+		updateDeploymentStateFn: func(_ context.Context, _ pgx.Tx, id string, patch *models.DeploymentStatePatch) error {
+			require.Equal(t, record.ID, id)
+			if (patch.UnlessStatus != nil && record.Status == *patch.UnlessStatus) ||
+				(patch.IfUpdatedAt != nil && !record.UpdatedAt.Equal(*patch.IfUpdatedAt)) {
+				return database.ErrConflict
+			}
+			if patch.Status != nil {
+				record.Status = *patch.Status
+			}
+			if patch.Error != nil {
+				record.Error = *patch.Error
+			}
+			if patch.Version != nil {
+				record.Version = *patch.Version
+			}
+			if patch.Env != nil {
+				record.Env = *patch.Env
+			}
+			return nil
+		},
+	}
+}
+
+func TestUpdateDeployment_RollsOutInPlace(t *testing.T) {
+	record := &models.Deployment{
+		ID:           "dep-update-1",
+		ServerName:   "io.test/server",
+		Version:      "1.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		Env:          map[string]string{"LOG_LEVEL": "info"},
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		Origin:       "managed",
+	}
+	var calls [][2]string
+	adapter := &testUpdatingDeploymentAdapter{
+		updateFn: func(_ context.Context, previous, desired *models.Deployment) (*models.DeploymentActionResult, error) {
+			calls = append(calls, [2]string{previous.Version, desired.Version})
+			assert.Equal(t, "dep-update-1", desired.ID)
+			assert.Equal(t, "debug", desired.Env["LOG_LEVEL"])
+			return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 newUpdateDeploymentTestDB(t, record),
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	got, err := svc.UpdateDeployment(context.Background(), "dep-update-1", &models.DeploymentUpdate{
+		Version: stringPtr("2.0.0"),
+		Env:     map[string]string{"LOG_LEVEL": "debug"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]string{{"1.0.0", "2.0.0"}}, calls)
+	assert.Equal(t, "dep-update-1", got.ID)
+	assert.Equal(t, "2.0.0", got.Version)
+	assert.Equal(t, models.DeploymentStatusDeployed, got.Status)
+	assert.Empty(t, got.Error)
+}
+
+func TestUpdateDeployment_RollsBackOnFailure(t *testing.T) {
+	record := &models.Deployment{
+		ID:           "dep-update-2",
+		ServerName:   "io.test/server",
+		Version:      "1.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		Env:          map[string]string{},
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		Origin:       "managed",
+	}
+	var calls [][2]string
+	adapter := &testUpdatingDeploymentAdapter{
+		updateFn: func(_ context.Context, previous, desired *models.Deployment) (*models.DeploymentActionResult, error) {
+			calls = append(calls, [2]string{previous.Version, desired.Version})
+			if desired.Version == "2.0.0" {
+				return nil, fmt.Errorf("image pull failed")
+			}
+			return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 newUpdateDeploymentTestDB(t, record),
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	_, err := svc.UpdateDeployment(context.Background(), "dep-update-2", &models.DeploymentUpdate{
+		Version: stringPtr("2.0.0"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rolled back")
+	assert.Equal(t, [][2]string{{"1.0.0", "2.0.0"}, {"2.0.0", "1.0.0"}}, calls)
+	assert.Equal(t, "1.0.0", record.Version)
+	assert.Equal(t, models.DeploymentStatusDeployed, record.Status)
+	assert.Contains(t, record.Error, "image pull failed")
+}
+
+func TestUpdateDeployment_RedactedEnvValueKeepsCurrentValue(t *testing.T) {
+	record := &models.Deployment{
+		ID:           "dep-update-redacted",
+		ServerName:   "io.test/server",
+		Version:      "1.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		Env:          map[string]string{"API_TOKEN": "secret", "LOG_LEVEL": "info"},
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		Origin:       "managed",
+	}
+	var applied map[string]string
+	adapter := &testUpdatingDeploymentAdapter{
+		updateFn: func(_ context.Context, _, desired *models.Deployment) (*models.DeploymentActionResult, error) {
+			applied = desired.Env
+			return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 newUpdateDeploymentTestDB(t, record),
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	// A client that read the redacted deployment sends back what it got.
+	_, err := svc.UpdateDeployment(context.Background(), "dep-update-redacted", &models.DeploymentUpdate{
+		Env: map[string]string{"API_TOKEN": models.RedactedEnvValue, "LOG_LEVEL": "debug"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"API_TOKEN": "secret", "LOG_LEVEL": "debug"}, applied)
+
+	_, err = svc.UpdateDeployment(context.Background(), "dep-update-redacted", &models.DeploymentUpdate{
+		Env: map[string]string{"NEW_KEY": models.RedactedEnvValue},
+	})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+}
+
+func TestUpdateDeployment_RejectsEmptyUpdate(t *testing.T) {
+	record := &models.Deployment{
+		ID:           "dep-update-3",
+		ServerName:   "io.test/server",
+		Version:      "1.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		ResourceType: "mcp",
+		ProviderID:   "local",
+	}
+	svc := &registryServiceImpl{
+		db:                 newUpdateDeploymentTestDB(t, record),
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": &testDeploymentAdapter{}},
+	}
+
+	_, err := svc.UpdateDeployment(context.Background(), "dep-update-3", &models.DeploymentUpdate{})
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+}
+
+func TestUpdateDeployment_ConflictsWithConcurrentUpdate(t *testing.T) {
+	record := &models.Deployment{
+		ID:           "dep-update-4",
+		ServerName:   "io.test/server",
+		Version:      "1.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		Origin:       "managed",
+		UpdatedAt:    time.Now(),
+	}
+	db := newUpdateDeploymentTestDB(t, record)
+	getDeployment := db.getDeploymentByIDFn
+	db.getDeploymentByIDFn = func(ctx context.Context, tx pgx.Tx, id string) (*models.Deployment, error) {
+		read, err := getDeployment(ctx, tx, id)
+		// Another update claims the deployment right after this one read it.
+		record.Status = models.DeploymentStatusDeploying
+		record.UpdatedAt = record.UpdatedAt.Add(time.Second)
+		return read, err
+	}
+	adapter := &testUpdatingDeploymentAdapter{
+		updateFn: func(context.Context, *models.Deployment, *models.Deployment) (*models.DeploymentActionResult, error) {
+			t.Fatal("a deployment claimed by another update must not be rolled out")
+			return nil, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 db,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	_, err := svc.UpdateDeployment(context.Background(), "dep-update-4", &models.DeploymentUpdate{
+		Version: stringPtr("2.0.0"),
+	})
+	require.ErrorIs(t, err, database.ErrConflict)
+}
+
+func TestUpdateDeployment_RecordsRevisions(t *testing.T) {
+	record := &models.Deployment{
+		ID:           "dep-revisions",
+		ServerName:   "io.test/server",
+		Version:      "1.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		Env:          map[string]string{},
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		Origin:       "managed",
+	}
+	adapter := &testUpdatingDeploymentAdapter{
+		updateFn: func(_ context.Context, _, desired *models.Deployment) (*models.DeploymentActionResult, error) {
+			if desired.Version == "3.0.0" {
+				return nil, fmt.Errorf("image pull failed")
+			}
+			return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+		},
+	}
+	mockDB := newUpdateDeploymentTestDB(t, record)
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	_, err := svc.UpdateDeployment(context.Background(), "dep-revisions", &models.DeploymentUpdate{
+		Version: stringPtr("2.0.0"),
+		Env:     map[string]string{"LOG_LEVEL": "debug"},
+	})
+	require.NoError(t, err)
+	_, err = svc.UpdateDeployment(context.Background(), "dep-revisions", &models.DeploymentUpdate{
+		Version: stringPtr("3.0.0"),
+	})
+	require.Error(t, err)
+
+	require.Len(t, mockDB.revisions, 2)
+	assert.Equal(t, models.DeploymentRevisionActionUpdate, mockDB.revisions[0].Action)
+	assert.Equal(t, "2.0.0", mockDB.revisions[0].Version)
+	assert.Equal(t, []string{"LOG_LEVEL"}, mockDB.revisions[0].EnvKeys)
+	assert.Equal(t, models.DeploymentStatusDeployed, mockDB.revisions[0].Status)
+	assert.Equal(t, "3.0.0", mockDB.revisions[1].Version)
+	assert.Equal(t, models.DeploymentStatusFailed, mockDB.revisions[1].Status)
+	assert.Contains(t, mockDB.revisions[1].Error, "image pull failed")
+}
+
+func TestRollbackDeployment_ReappliesRevision(t *testing.T) {
+	record := &models.Deployment{
+		ID:           "dep-rollback",
+		ServerName:   "io.test/server",
+		Version:      "2.0.0",
+		Status:       models.DeploymentStatusDeployed,
+		Env:          map[string]string{"LOG_LEVEL": "debug", "EXTRA": "1"},
+		ResourceType: "mcp",
+		ProviderID:   "local",
+		Origin:       "managed",
+	}
+	var applied *models.Deployment
+	adapter := &testUpdatingDeploymentAdapter{
+		updateFn: func(_ context.Context, _, desired *models.Deployment) (*models.DeploymentActionResult, error) {
+			applied = desired
+			return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+		},
+	}
+	mockDB := newUpdateDeploymentTestDB(t, record)
+	mockDB.revisions = []*models.DeploymentRevision{
+		{DeploymentID: "dep-rollback", Revision: 1, Action: models.DeploymentRevisionActionDeploy, Version: "1.0.0",
+			EnvKeys: []string{"LOG_LEVEL"}, Status: models.DeploymentStatusDeployed},
+		{DeploymentID: "dep-rollback", Revision: 2, Action: models.DeploymentRevisionActionUpdate, Version: "1.5.0",
+			Status: models.DeploymentStatusFailed, Error: "crash loop"},
+		{DeploymentID: "dep-rollback", Revision: 3, Action: models.DeploymentRevisionActionUpdate, Version: "2.0.0",
+			EnvKeys: []string{"EXTRA", "LOG_LEVEL"}, Status: models.DeploymentStatusDeployed},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	got, err := svc.RollbackDeployment(context.Background(), "dep-rollback", 1)
+	require.NoError(t, err)
+	require.NotNil(t, applied)
+	assert.Equal(t, "1.0.0", applied.Version)
+	// Revisions keep env keys only: the revision's keys keep their current values.
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, applied.Env)
+	assert.Equal(t, "1.0.0", got.Version)
+
+	require.Len(t, mockDB.revisions, 4)
+	latest := mockDB.revisions[3]
+	assert.Equal(t, 4, latest.Revision)
+	assert.Equal(t, models.DeploymentRevisionActionRollback, latest.Action)
+	assert.Equal(t, 1, latest.SourceRevision)
+
+	_, err = svc.RollbackDeployment(context.Background(), "dep-rollback", 2)
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+	_, err = svc.RollbackDeployment(context.Background(), "dep-rollback", 9)
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	// An undeploy revision cannot be re-applied.
+	mockDB.revisions = append(mockDB.revisions, &models.DeploymentRevision{DeploymentID: "dep-rollback", Revision: 5,
+		Action: models.DeploymentRevisionActionUndeploy, Version: "1.0.0", Status: models.DeploymentRevisionStatusUndeployed})
+	_, err = svc.RollbackDeployment(context.Background(), "dep-rollback", 5)
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	// A key the deployment no longer sets has no value to restore.
+	mockDB.revisions = append(mockDB.revisions, &models.DeploymentRevision{DeploymentID: "dep-rollback", Revision: 6,
+		Action: models.DeploymentRevisionActionUpdate, Version: "2.0.0", EnvKeys: []string{"REMOVED"}, Status: models.DeploymentStatusDeployed})
+	_, err = svc.RollbackDeployment(context.Background(), "dep-rollback", 6)
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "REMOVED")
+}
+
+type testObservingDeploymentAdapter struct {
+	testDeploymentAdapter
+	observeFn func(ctx context.Context, deployment *models.Deployment) (*models.DeploymentObservation, error)
+}
+
+func (a *testObservingDeploymentAdapter) Observe(ctx context.Context, deployment *models.Deployment) (*models.DeploymentObservation, error) {
+	return a.observeFn(ctx, deployment)
+}
+
+func TestReconcileDeployments_RecordsObservedStatus(t *testing.T) {
+	deployments := []*models.Deployment{
+		{ID: "dep-gone", Status: models.DeploymentStatusDeployed, ProviderID: "local", Origin: "managed"},
+		{ID: "dep-recovered", Status: models.DeploymentStatusUnhealthy, Error: "service is exited", ProviderID: "local", Origin: "managed"},
+		{ID: "dep-steady", Status: models.DeploymentStatusDeployed, ProviderID: "local", Origin: "managed"},
+		{ID: "dep-in-progress", Status: models.DeploymentStatusDeploying, ProviderID: "local", Origin: "managed"},
+	}
+	patches := map[string]*models.DeploymentStatePatch{}
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "local"}, nil
+		},
+		getDeploymentsFn: func(ctx context.Context, _ pgx.Tx, filter *models.DeploymentFilter) ([]*models.Deployment, error) {
+			session, ok := auth.AuthSessionFrom(ctx)
+			require.True(t, ok)
+			require.True(t, auth.IsSystemSession(session))
+			require.NotNil(t, filter.Origin)
+			assert.Equal(t, "managed", *filter.Origin)
+			return deployments, nil
+		},
+		updateDeploymentStateFn: func(_ context.Context, _ pgx.Tx, id string, patch *models.DeploymentStatePatch) error {
+			patches[id] = patch
+			return nil
+		},
+	}
+	adapter := &testObservingDeploymentAdapter{
+		testDeploymentAdapter: testDeploymentAdapter{
+			deployFn: func(context.Context, *models.Deployment) (*models.DeploymentActionResult, error) {
+				t.Fatal("deploy should not be called without repair")
+				return nil, nil
+			},
+		},
+		observeFn: func(_ context.Context, deployment *models.Deployment) (*models.DeploymentObservation, error) {
+			switch deployment.ID {
+			case "dep-gone":
+				return &models.DeploymentObservation{Status: models.DeploymentStatusMissing, Reason: "workload not found on platform"}, nil
+			case "dep-in-progress":
+				t.Fatal("deployments being applied must not be observed")
+			}
+			return &models.DeploymentObservation{Status: models.DeploymentStatusDeployed}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	results, err := svc.ReconcileDeployments(context.Background(), false)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	require.Contains(t, patches, "dep-gone")
+	assert.Equal(t, models.DeploymentStatusMissing, *patches["dep-gone"].Status)
+	assert.Equal(t, "workload not found on platform", *patches["dep-gone"].Error)
+	require.Contains(t, patches, "dep-recovered")
+	assert.Equal(t, models.DeploymentStatusDeployed, *patches["dep-recovered"].Status)
+	assert.Empty(t, *patches["dep-recovered"].Error)
+	assert.NotContains(t, patches, "dep-steady")
+	assert.False(t, patches["dep-gone"].HasSpecChanges())
+}
+
+func TestReconcileDeployments_RepairsMissingWorkloads(t *testing.T) {
+	var patches []*models.DeploymentStatePatch
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "local"}, nil
+		},
+		getDeploymentsFn: func(context.Context, pgx.Tx, *models.DeploymentFilter) ([]*models.Deployment, error) {
+			return []*models.Deployment{{ID: "dep-gone", Version: "1.0.0", Status: models.DeploymentStatusDeployed, ProviderID: "local", Origin: "managed"}}, nil
+		},
+		updateDeploymentStateFn: func(_ context.Context, _ pgx.Tx, _ string, patch *models.DeploymentStatePatch) error {
+			patches = append(patches, patch)
+			return nil
+		},
+	}
+	deployed := false
+	adapter := &testObservingDeploymentAdapter{
+		testDeploymentAdapter: testDeploymentAdapter{
+			deployFn: func(_ context.Context, deployment *models.Deployment) (*models.DeploymentActionResult, error) {
+				deployed = deployment.ID == "dep-gone" && deployment.Version == "1.0.0"
+				return &models.DeploymentActionResult{Status: models.DeploymentStatusDeployed}, nil
+			},
+		},
+		observeFn: func(context.Context, *models.Deployment) (*models.DeploymentObservation, error) {
+			return &models.DeploymentObservation{Status: models.DeploymentStatusMissing, Reason: "workload not found on platform"}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	results, err := svc.ReconcileDeployments(context.Background(), true)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, deployed)
+	assert.True(t, results[0].Repaired)
+	assert.Equal(t, models.DeploymentStatusDeployed, results[0].Status)
+	assert.Equal(t, "workload not found on platform", results[0].Reason)
+	// The deployment is claimed while it is repaired, then marked deployed.
+	require.Len(t, patches, 2)
+	assert.Equal(t, models.DeploymentStatusDeploying, *patches[0].Status)
+	require.NotNil(t, patches[0].UnlessStatus)
+	require.NotNil(t, patches[0].IfUpdatedAt)
+	assert.Equal(t, models.DeploymentStatusDeployed, *patches[1].Status)
+	assert.Empty(t, *patches[1].Error)
+}
+
+func TestReconcileDeployments_SkipsConcurrentlyChangedDeployments(t *testing.T) {
+	listedAt := time.Now()
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "local"}, nil
+		},
+		getDeploymentsFn: func(context.Context, pgx.Tx, *models.DeploymentFilter) ([]*models.Deployment, error) {
+			return []*models.Deployment{
+				{ID: "dep-updated", Status: models.DeploymentStatusDeployed, ProviderID: "local", Origin: "managed", UpdatedAt: listedAt},
+			}, nil
+		},
+		updateDeploymentStateFn: func(_ context.Context, _ pgx.Tx, _ string, patch *models.DeploymentStatePatch) error {
+			// An update claimed the deployment after it was listed.
+			require.NotNil(t, patch.IfUpdatedAt)
+			assert.True(t, listedAt.Equal(*patch.IfUpdatedAt))
+			return database.ErrConflict
+		},
+	}
+	adapter := &testObservingDeploymentAdapter{
+		testDeploymentAdapter: testDeploymentAdapter{
+			deployFn: func(context.Context, *models.Deployment) (*models.DeploymentActionResult, error) {
+				t.Fatal("a deployment claimed by an update must not be repaired")
+				return nil, nil
+			},
+		},
+		observeFn: func(context.Context, *models.Deployment) (*models.DeploymentObservation, error) {
+			return &models.DeploymentObservation{Status: models.DeploymentStatusMissing, Reason: "workload not found on platform"}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+	}
+
+	for _, repair := range []bool{false, true} {
+		results, err := svc.ReconcileDeployments(context.Background(), repair)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	}
+}
+
 func TestGetDeployments_AppendsDiscoveredDeploymentsFromAdapters(t *testing.T) {
 	discoverCalled := false
 	mockDB := &deploymentMockDB{
@@ -1938,3 +2862,297 @@ func TestResolveAgentManifestPrompts(t *testing.T) {
 		})
 	}
 }
+
+func TestAuditTrail(t *testing.T) {
+	ctx := audit.WithRequestID(internaldb.WithTestSession(context.Background()), "req-audit")
+	testDB := internaldb.NewTestDB(t)
+	svc := NewRegistryService(testDB, &config.Config{EnableRegistryValidation: false}, nil)
+
+	serverName := "com.example/audited-server"
+	created, err := svc.CreateServer(ctx, &apiv0.ServerJSON{
+		Schema:      model.CurrentSchemaURL,
+		Name:        serverName,
+		Description: "Audited server",
+		Version:     "1.0.0",
+	})
+	require.NoError(t, err)
+
+	deprecated := string(model.StatusDeprecated)
+	_, err = svc.UpdateServer(ctx, serverName, "1.0.0", &created.Server, &deprecated)
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteServer(ctx, serverName, "1.0.0"))
+
+	// A failed change leaves no trace.
+	require.ErrorIs(t, svc.DeleteServer(ctx, serverName, "1.0.0"), database.ErrNotFound)
+
+	resourceName := serverName
+	entries, _, err := svc.ListAuditEntries(ctx, &models.AuditFilter{ResourceName: &resourceName}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	actions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+		assert.Equal(t, "test-user", entry.Principal)
+		assert.Equal(t, "req-audit", entry.RequestID)
+		assert.Equal(t, models.AuditResourceServer, entry.ResourceType)
+	}
+	assert.Equal(t, []string{models.AuditActionDelete, models.AuditActionSetStatus, models.AuditActionEdit, models.AuditActionPublish}, actions)
+
+	deleted, statusChange, published := entries[0], entries[1], entries[3]
+	assert.Empty(t, published.BeforeDigest)
+	assert.NotEmpty(t, published.AfterDigest)
+	assert.Equal(t, deprecated, statusChange.Details["status"])
+	assert.NotEqual(t, statusChange.BeforeDigest, statusChange.AfterDigest)
+	assert.NotEmpty(t, deleted.BeforeDigest)
+	assert.Empty(t, deleted.AfterDigest)
+}
+
+type testProbingDeploymentAdapter struct {
+	testDeploymentAdapter
+	probeTargetFn func(ctx context.Context, deployment *models.Deployment) (*models.DeploymentProbeTarget, error)
+}
+
+func (a *testProbingDeploymentAdapter) ProbeTarget(ctx context.Context, deployment *models.Deployment) (*models.DeploymentProbeTarget, error) {
+	return a.probeTargetFn(ctx, deployment)
+}
+
+func TestGetDeploymentHealth_ProbesAndStoresResult(t *testing.T) {
+	cardServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"name":"planner"}`))
+	}))
+	defer cardServer.Close()
+
+	stored := &models.DeploymentHealth{Healthy: false, Error: "stale"}
+	var patched *models.DeploymentStatePatch
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "local"}, nil
+		},
+		getDeploymentByIDFn: func(_ context.Context, _ pgx.Tx, id string) (*models.Deployment, error) {
+			return &models.Deployment{ID: id, ServerName: "planner", ResourceType: "agent", ProviderID: "local", Health: stored}, nil
+		},
+		updateDeploymentStateFn: func(ctx context.Context, _ pgx.Tx, _ string, patch *models.DeploymentStatePatch) error {
+			session, ok := auth.AuthSessionFrom(ctx)
+			require.True(t, ok)
+			require.True(t, auth.IsSystemSession(session))
+			patched = patch
+			return nil
+		},
+	}
+	adapter := &testProbingDeploymentAdapter{
+		probeTargetFn: func(context.Context, *models.Deployment) (*models.DeploymentProbeTarget, error) {
+			return &models.DeploymentProbeTarget{Protocol: models.DeploymentProbeA2A, URL: cardServer.URL}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+		healthProber:       health.NewProber(5 * time.Second),
+	}
+
+	cached, err := svc.GetDeploymentHealth(context.Background(), "dep-agent", false)
+	require.NoError(t, err)
+	assert.Same(t, stored, cached)
+	assert.Nil(t, patched)
+
+	refreshed, err := svc.GetDeploymentHealth(context.Background(), "dep-agent", true)
+	require.NoError(t, err)
+	assert.True(t, refreshed.Healthy)
+	assert.Equal(t, cardServer.URL, refreshed.Endpoint)
+	require.NotNil(t, patched)
+	assert.Same(t, refreshed, patched.Health)
+	assert.Nil(t, patched.Status)
+	assert.False(t, patched.HasSpecChanges())
+}
+
+func TestGetDeploymentHealth_RejectsPlatformsWithoutProbes(t *testing.T) {
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "test"}, nil
+		},
+		getDeploymentByIDFn: func(_ context.Context, _ pgx.Tx, id string) (*models.Deployment, error) {
+			return &models.Deployment{ID: id, ResourceType: "mcp", ProviderID: "test"}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"test": &testDeploymentAdapter{}},
+		healthProber:       health.NewProber(time.Second),
+	}
+
+	_, err := svc.GetDeploymentHealth(context.Background(), "dep-mcp", false)
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+}
+
+type testRenderingDeploymentAdapter struct {
+	testDeploymentAdapter
+	renderFn func(ctx context.Context, deployment *models.Deployment, format string) (*models.DeploymentRender, error)
+}
+
+func (a *testRenderingDeploymentAdapter) Render(ctx context.Context, deployment *models.Deployment, format string) (*models.DeploymentRender, error) {
+	return a.renderFn(ctx, deployment, format)
+}
+
+func TestRenderDeployment_ResolvesVersionWithoutRecordingDeployment(t *testing.T) {
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "test"}, nil
+		},
+		getAgentByNameAndVersionFn: func(_ context.Context, _ pgx.Tx, name, _ string) (*models.AgentResponse, error) {
+			return &models.AgentResponse{Agent: models.AgentJSON{AgentManifest: models.AgentManifest{Name: name}, Version: "1.4.0"}}, nil
+		},
+		createDeploymentFn: func(context.Context, pgx.Tx, *models.Deployment) error {
+			t.Fatal("render must not record a deployment")
+			return nil
+		},
+	}
+	var rendered *models.Deployment
+	adapter := &testRenderingDeploymentAdapter{
+		testDeploymentAdapter: testDeploymentAdapter{
+			deployFn: func(context.Context, *models.Deployment) (*models.DeploymentActionResult, error) {
+				t.Fatal("render must not deploy")
+				return nil, nil
+			},
+		},
+		renderFn: func(_ context.Context, deployment *models.Deployment, format string) (*models.DeploymentRender, error) {
+			rendered = deployment
+			return &models.DeploymentRender{Platform: "test", Format: format}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"test": adapter},
+	}
+
+	render, err := svc.RenderDeployment(context.Background(), &models.Deployment{
+		ID:           "ignored",
+		ServerName:   "planner",
+		Version:      "latest",
+		ResourceType: "Agent",
+		ProviderID:   " test ",
+	}, "")
+	require.NoError(t, err)
+	assert.Equal(t, models.DeploymentRenderFormatYAML, render.Format)
+	require.NotNil(t, rendered)
+	assert.Empty(t, rendered.ID)
+	assert.Equal(t, "1.4.0", rendered.Version)
+	assert.Equal(t, "agent", rendered.ResourceType)
+	assert.Equal(t, "test", rendered.ProviderID)
+	assert.NotNil(t, rendered.Env)
+
+	_, err = svc.RenderDeployment(context.Background(), &models.Deployment{
+		ServerName: "planner", Version: "latest", ResourceType: "agent", ProviderID: "test",
+	}, "jsonnet")
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+}
+
+func TestRenderDeployment_RejectsPlatformsWithoutRenderer(t *testing.T) {
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "test"}, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"test": &testDeploymentAdapter{}},
+	}
+
+	_, err := svc.RenderDeployment(context.Background(), &models.Deployment{
+		ServerName: "weather", Version: "1.0.0", ResourceType: "mcp", ProviderID: "test",
+	}, models.DeploymentRenderFormatYAML)
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "rendering is not supported on platform test")
+}
+
+func TestProbeDeployments_ProbesRunningManagedDeployments(t *testing.T) {
+	deployments := []*models.Deployment{
+		{ID: "dep-running", Status: models.DeploymentStatusDeployed, ProviderID: "local", Origin: "managed"},
+		{ID: "dep-unresolvable", Status: models.DeploymentStatusUnhealthy, ProviderID: "local", Origin: "managed",
+			Health: &models.DeploymentHealth{Healthy: true}},
+		{ID: "dep-missing", Status: models.DeploymentStatusMissing, ProviderID: "local", Origin: "managed"},
+		{ID: "dep-failed", Status: models.DeploymentStatusFailed, ProviderID: "local", Origin: "managed"},
+	}
+	patches := map[string]*models.DeploymentStatePatch{}
+	mockDB := &deployCreateMockDB{
+		getProviderByIDFn: func(_ context.Context, _ pgx.Tx, providerID string) (*models.Provider, error) {
+			return &models.Provider{ID: providerID, Platform: "local"}, nil
+		},
+		getDeploymentsFn: func(context.Context, pgx.Tx, *models.DeploymentFilter) ([]*models.Deployment, error) {
+			return deployments, nil
+		},
+		updateDeploymentStateFn: func(_ context.Context, _ pgx.Tx, id string, patch *models.DeploymentStatePatch) error {
+			patches[id] = patch
+			return nil
+		},
+	}
+	adapter := &testProbingDeploymentAdapter{
+		probeTargetFn: func(_ context.Context, deployment *models.Deployment) (*models.DeploymentProbeTarget, error) {
+			switch deployment.ID {
+			case "dep-running":
+				return &models.DeploymentProbeTarget{Protocol: "unknown", URL: "http://127.0.0.1:0"}, nil
+			case "dep-unresolvable":
+				return nil, fmt.Errorf("agent gateway target not found")
+			}
+			t.Fatalf("deployment %s should not be probed", deployment.ID)
+			return nil, nil
+		},
+	}
+	svc := &registryServiceImpl{
+		db:                 mockDB,
+		deploymentAdapters: map[string]registrytypes.DeploymentPlatformAdapter{"local": adapter},
+		healthProber:       health.NewProber(time.Second),
+	}
+
+	results, err := svc.ProbeDeployments(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Len(t, patches, 2)
+
+	assert.Equal(t, "dep-running", results[0].DeploymentID)
+	assert.Nil(t, results[0].Previous)
+	assert.Contains(t, results[0].Health.Error, "unsupported probe protocol")
+
+	assert.Equal(t, "dep-unresolvable", results[1].DeploymentID)
+	require.NotNil(t, results[1].Previous)
+	assert.True(t, results[1].Previous.Healthy)
+	assert.False(t, results[1].Health.Healthy)
+	assert.Contains(t, patches["dep-unresolvable"].Health.Error, "resolve probe endpoint")
+}
+
+func TestUploadSkillBundle_CapsUnreferencedBundles(t *testing.T) {
+	ctx := internaldb.WithTestSession(context.Background())
+	testDB := internaldb.NewTestDB(t)
+	service := NewRegistryService(testDB, &config.Config{}, nil)
+
+	pack := func(i int) []byte {
+		dir := t.TempDir()
+		content := fmt.Sprintf("---\nname: pdf-tools\ndescription: PDF helpers\n---\nRevision %d\n", i)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte(content), 0o600))
+		data, err := skillbundle.Pack(dir)
+		require.NoError(t, err)
+		return data
+	}
+
+	first := pack(0)
+	for i := range maxUnreferencedSkillBundles {
+		data := first
+		if i > 0 {
+			data = pack(i)
+		}
+		_, err := service.UploadSkillBundle(ctx, data)
+		require.NoError(t, err)
+	}
+
+	_, err := service.UploadSkillBundle(ctx, pack(maxUnreferencedSkillBundles))
+	require.ErrorIs(t, err, database.ErrInvalidInput)
+
+	uploaded, err := service.UploadSkillBundle(ctx, first)
+	require.NoError(t, err, "uploading a stored bundle again is not capped")
+	assert.Equal(t, skillbundle.Digest(first), uploaded.Digest)
+
+	resourceType := models.AuditResourceSkillBundle
+	entries, _, err := testDB.ListAuditEntries(ctx, nil, &models.AuditFilter{ResourceType: &resourceType}, "", 100)
+	require.NoError(t, err)
+	assert.Len(t, entries, maxUnreferencedSkillBundles, "only new bundles are audited")
+}
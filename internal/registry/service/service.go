@@ -51,6 +51,10 @@ type RegistryService interface {
 	ResolveAgentManifestSkills(ctx context.Context, manifest *models.AgentManifest) ([]platformtypes.AgentSkillRef, error)
 	// ResolveAgentManifestPrompts resolves manifest prompt refs to concrete prompt content.
 	ResolveAgentManifestPrompts(ctx context.Context, manifest *models.AgentManifest) ([]platformtypes.ResolvedPrompt, error)
+	// UpdateAgent updates an existing agent version and optionally its status
+	UpdateAgent(ctx context.Context, agentName, version string, req *models.AgentJSON, newStatus *string) (*models.AgentResponse, error)
+	// SetAgentStatus updates the status of a specific agent version and the version that succeeds it
+	SetAgentStatus(ctx context.Context, agentName, version, status, successor string) (*models.AgentResponse, error)
 	// DeleteAgent permanently removes an agent version from the registry
 	DeleteAgent(ctx context.Context, agentName, version string) error
 	// UpsertAgentEmbedding stores semantic embedding metadata for an agent version
@@ -68,8 +72,20 @@ type RegistryService interface {
 	GetAllVersionsBySkillName(ctx context.Context, skillName string) ([]*models.SkillResponse, error)
 	// CreateSkill creates a new skill version
 	CreateSkill(ctx context.Context, req *models.SkillJSON) (*models.SkillResponse, error)
+	// UpdateSkill updates an existing skill version and optionally its status
+	UpdateSkill(ctx context.Context, skillName, version string, req *models.SkillJSON, newStatus *string) (*models.SkillResponse, error)
+	// SetSkillStatus updates the status of a specific skill version and the version that succeeds it
+	SetSkillStatus(ctx context.Context, skillName, version, status, successor string) (*models.SkillResponse, error)
 	// DeleteSkill permanently removes a skill version from the registry
 	DeleteSkill(ctx context.Context, skillName, version string) error
+	// UploadSkillBundle validates and stores a skill bundle under its digest
+	UploadSkillBundle(ctx context.Context, data []byte) (*models.SkillBundle, error)
+	// GetSkillBundle retrieves a stored skill bundle by digest
+	GetSkillBundle(ctx context.Context, digest string) (*database.SkillBundle, error)
+	// UpsertSkillEmbedding stores semantic embedding metadata for a skill version
+	UpsertSkillEmbedding(ctx context.Context, skillName, version string, embedding *database.SemanticEmbedding) error
+	// GetSkillEmbeddingMetadata retrieves the embedding metadata for a skill version
+	GetSkillEmbeddingMetadata(ctx context.Context, skillName, version string) (*database.SemanticEmbeddingMetadata, error)
 
 	// Prompts APIs
 	// ListPrompts retrieve all prompts with optional filtering
@@ -82,8 +98,22 @@ type RegistryService interface {
 	GetAllVersionsByPromptName(ctx context.Context, promptName string) ([]*models.PromptResponse, error)
 	// CreatePrompt creates a new prompt version
 	CreatePrompt(ctx context.Context, req *models.PromptJSON) (*models.PromptResponse, error)
+	// RenderPrompt renders a prompt version with argument values
+	RenderPrompt(ctx context.Context, promptName, version string, arguments map[string]string) (*models.PromptRenderResponse, error)
+	// UpdatePrompt updates an existing prompt version and optionally its status
+	UpdatePrompt(ctx context.Context, promptName, version string, req *models.PromptJSON, newStatus *string) (*models.PromptResponse, error)
+	// SetPromptStatus updates the status of a specific prompt version and the version that succeeds it
+	SetPromptStatus(ctx context.Context, promptName, version, status, successor string) (*models.PromptResponse, error)
 	// DeletePrompt permanently removes a prompt version from the registry
 	DeletePrompt(ctx context.Context, promptName, version string) error
+	// UpsertPromptEmbedding stores semantic embedding metadata for a prompt version
+	UpsertPromptEmbedding(ctx context.Context, promptName, version string, embedding *database.SemanticEmbedding) error
+	// GetPromptEmbeddingMetadata retrieves the embedding metadata for a prompt version
+	GetPromptEmbeddingMetadata(ctx context.Context, promptName, version string) (*database.SemanticEmbeddingMetadata, error)
+
+	// Search APIs
+	// SearchRegistry ranks servers, agents, skills and prompts matching the filter in one result set
+	SearchRegistry(ctx context.Context, filter *database.SearchFilter, cursor string, limit int) (*models.SearchResponse, error)
 
 	// Deployments APIs
 	// ListProviders retrieves deployment target providers, optionally filtered by provider platform type.
@@ -109,10 +139,61 @@ type RegistryService interface {
 	RemoveDeploymentByID(ctx context.Context, id string) error
 	// CreateDeployment dispatches deployment creation via provider-resolved platform adapter.
 	CreateDeployment(ctx context.Context, req *models.Deployment) (*models.Deployment, error)
+	// RenderDeployment returns the manifests the provider's platform would apply
+	// for req, in format, without applying them or recording a deployment.
+	RenderDeployment(ctx context.Context, req *models.Deployment, format string) (*models.DeploymentRender, error)
+	// UpdateDeployment rolls an existing deployment to a new version or configuration
+	// in place, keeping its ID. The previous configuration is restored if the rollout fails.
+	UpdateDeployment(ctx context.Context, id string, update *models.DeploymentUpdate) (*models.Deployment, error)
+	// RollbackDeployment re-applies a previous revision of a deployment in place.
+	RollbackDeployment(ctx context.Context, id string, revision int) (*models.Deployment, error)
+	// ListDeploymentRevisions lists the recorded revisions of a deployment, oldest first.
+	ListDeploymentRevisions(ctx context.Context, id string) ([]*models.DeploymentRevision, error)
 	// UndeployDeployment dispatches undeploy via provider-resolved platform adapter.
 	UndeployDeployment(ctx context.Context, deployment *models.Deployment) error
 	// GetDeploymentLogs dispatches deployment log retrieval via provider-resolved platform adapter.
-	GetDeploymentLogs(ctx context.Context, deployment *models.Deployment) ([]string, error)
+	GetDeploymentLogs(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions) ([]string, error)
+	// StreamDeploymentLogs streams deployment logs line by line via provider-resolved platform adapter.
+	StreamDeploymentLogs(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions, emit func(line string) error) error
 	// CancelDeployment dispatches deployment cancellation via provider-resolved platform adapter.
 	CancelDeployment(ctx context.Context, deployment *models.Deployment) error
+	// ReconcileDeployments compares managed deployments with live platform state,
+	// records drift, and re-applies desired state when repair is set.
+	ReconcileDeployments(ctx context.Context, repair bool) ([]*models.DeploymentReconcileResult, error)
+	// GetDeploymentHealth returns the last health probe result of a deployment,
+	// probing it first when refresh is set or it has never been probed.
+	GetDeploymentHealth(ctx context.Context, id string, refresh bool) (*models.DeploymentHealth, error)
+	// ProbeDeployments runs health probes against running managed deployments
+	// and stores the results.
+	ProbeDeployments(ctx context.Context) ([]*models.DeploymentHealthResult, error)
+
+	// Mirror APIs
+	// CreateMirror validates and registers an upstream registry to mirror.
+	CreateMirror(ctx context.Context, in *models.CreateMirrorInput) (*models.Mirror, error)
+	// ListMirrors lists configured mirrors.
+	ListMirrors(ctx context.Context) ([]*models.Mirror, error)
+	// GetMirrorByID retrieves a mirror by ID.
+	GetMirrorByID(ctx context.Context, mirrorID string) (*models.Mirror, error)
+	// DeleteMirror removes a mirror and its sync history; mirrored servers are kept.
+	DeleteMirror(ctx context.Context, mirrorID string) error
+	// GetMirrorStatus returns a mirror with its most recent syncs, newest first.
+	GetMirrorStatus(ctx context.Context, mirrorID string, historyLimit int) (*models.MirrorStatus, error)
+	// RecordMirrorSync stores the outcome of a sync and advances the mirror's watermark.
+	RecordMirrorSync(ctx context.Context, sync *models.MirrorSync) error
+
+	// Secret APIs
+	// CreateSecret encrypts and stores a new secret.
+	CreateSecret(ctx context.Context, in *models.SecretInput) (*models.Secret, error)
+	// UpdateSecret replaces the value and description of an existing secret.
+	UpdateSecret(ctx context.Context, name string, in *models.SecretInput) (*models.Secret, error)
+	// ListSecrets lists secret metadata; values are never returned.
+	ListSecrets(ctx context.Context) ([]*models.Secret, error)
+	// DeleteSecret removes a secret that no deployment references.
+	DeleteSecret(ctx context.Context, name string) error
+	// ResolveSecretRefs decrypts the secrets referenced by a deployment, keyed by environment variable.
+	ResolveSecretRefs(ctx context.Context, refs map[string]string) (map[string]string, error)
+
+	// Audit APIs
+	// ListAuditEntries lists audit log entries matching filter, newest first, with cursor-based pagination.
+	ListAuditEntries(ctx context.Context, filter *models.AuditFilter, cursor string, limit int) ([]*models.AuditEntry, string, error)
 }
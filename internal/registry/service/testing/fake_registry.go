@@ -27,10 +27,14 @@ type FakeRegistry struct {
 	// Embedding metadata maps (keyed by "name@version")
 	ServerEmbeddingMeta map[string]*database.SemanticEmbeddingMetadata
 	AgentEmbeddingMeta  map[string]*database.SemanticEmbeddingMetadata
+	SkillEmbeddingMeta  map[string]*database.SemanticEmbeddingMetadata
+	PromptEmbeddingMeta map[string]*database.SemanticEmbeddingMetadata
 
 	// Call counters for verification
 	UpsertServerEmbeddingCalls int
 	UpsertAgentEmbeddingCalls  int
+	UpsertSkillEmbeddingCalls  int
+	UpsertPromptEmbeddingCalls int
 
 	// Function hooks for custom behavior (take precedence over data fields when set)
 	ListServersFn                 func(ctx context.Context, filter *database.ServerFilter, cursor string, limit int) ([]*apiv0.ServerResponse, string, error)
@@ -53,6 +57,8 @@ type FakeRegistry struct {
 	ResolveAgentManifestSkillsFn  func(ctx context.Context, manifest *models.AgentManifest) ([]platformtypes.AgentSkillRef, error)
 	ResolveAgentManifestPromptsFn func(ctx context.Context, manifest *models.AgentManifest) ([]platformtypes.ResolvedPrompt, error)
 	DeleteAgentFn                 func(ctx context.Context, agentName, version string) error
+	UpdateAgentFn                 func(ctx context.Context, agentName, version string, req *models.AgentJSON, newStatus *string) (*models.AgentResponse, error)
+	SetAgentStatusFn              func(ctx context.Context, agentName, version, status, successor string) (*models.AgentResponse, error)
 	UpsertAgentEmbeddingFn        func(ctx context.Context, agentName, version string, embedding *database.SemanticEmbedding) error
 	GetAgentEmbeddingMetadataFn   func(ctx context.Context, agentName, version string) (*database.SemanticEmbeddingMetadata, error)
 	ListSkillsFn                  func(ctx context.Context, filter *database.SkillFilter, cursor string, limit int) ([]*models.SkillResponse, string, error)
@@ -61,6 +67,12 @@ type FakeRegistry struct {
 	GetAllVersionsBySkillNameFn   func(ctx context.Context, skillName string) ([]*models.SkillResponse, error)
 	CreateSkillFn                 func(ctx context.Context, req *models.SkillJSON) (*models.SkillResponse, error)
 	DeleteSkillFn                 func(ctx context.Context, skillName, version string) error
+	UpdateSkillFn                 func(ctx context.Context, skillName, version string, req *models.SkillJSON, newStatus *string) (*models.SkillResponse, error)
+	SetSkillStatusFn              func(ctx context.Context, skillName, version, status, successor string) (*models.SkillResponse, error)
+	UploadSkillBundleFn           func(ctx context.Context, data []byte) (*models.SkillBundle, error)
+	GetSkillBundleFn              func(ctx context.Context, digest string) (*database.SkillBundle, error)
+	UpsertSkillEmbeddingFn        func(ctx context.Context, skillName, version string, embedding *database.SemanticEmbedding) error
+	GetSkillEmbeddingMetadataFn   func(ctx context.Context, skillName, version string) (*database.SemanticEmbeddingMetadata, error)
 	GetDeploymentsFn              func(ctx context.Context, filter *models.DeploymentFilter) ([]*models.Deployment, error)
 	ListProvidersFn               func(ctx context.Context, platform *string) ([]*models.Provider, error)
 	GetProviderByIDFn             func(ctx context.Context, providerID string) (*models.Provider, error)
@@ -72,10 +84,18 @@ type FakeRegistry struct {
 	DeployAgentFn                 func(ctx context.Context, agentName, version string, config map[string]string, preferRemote bool, providerID string) (*models.Deployment, error)
 	RemoveDeploymentByIDFn        func(ctx context.Context, id string) error
 	CreateDeploymentFn            func(ctx context.Context, req *models.Deployment) (*models.Deployment, error)
+	RenderDeploymentFn            func(ctx context.Context, req *models.Deployment, format string) (*models.DeploymentRender, error)
+	UpdateDeploymentFn            func(ctx context.Context, id string, update *models.DeploymentUpdate) (*models.Deployment, error)
+	RollbackDeploymentFn          func(ctx context.Context, id string, revision int) (*models.Deployment, error)
+	ListDeploymentRevisionsFn     func(ctx context.Context, id string) ([]*models.DeploymentRevision, error)
 	UndeployDeploymentFn          func(ctx context.Context, deployment *models.Deployment) error
-	GetDeploymentLogsFn           func(ctx context.Context, deployment *models.Deployment) ([]string, error)
+	GetDeploymentLogsFn           func(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions) ([]string, error)
+	StreamDeploymentLogsFn        func(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions, emit func(line string) error) error
 	CancelDeploymentFn            func(ctx context.Context, deployment *models.Deployment) error
 	ReconcileAllFn                func(ctx context.Context) error
+	ReconcileDeploymentsFn        func(ctx context.Context, repair bool) ([]*models.DeploymentReconcileResult, error)
+	GetDeploymentHealthFn         func(ctx context.Context, id string, refresh bool) (*models.DeploymentHealth, error)
+	ProbeDeploymentsFn            func(ctx context.Context) ([]*models.DeploymentHealthResult, error)
 
 	// Prompt fields and hooks
 	Prompts                      []*models.PromptResponse
@@ -84,7 +104,33 @@ type FakeRegistry struct {
 	GetPromptByNameAndVersionFn  func(ctx context.Context, promptName, version string) (*models.PromptResponse, error)
 	GetAllVersionsByPromptNameFn func(ctx context.Context, promptName string) ([]*models.PromptResponse, error)
 	CreatePromptFn               func(ctx context.Context, req *models.PromptJSON) (*models.PromptResponse, error)
+	RenderPromptFn               func(ctx context.Context, promptName, version string, arguments map[string]string) (*models.PromptRenderResponse, error)
 	DeletePromptFn               func(ctx context.Context, promptName, version string) error
+	UpdatePromptFn               func(ctx context.Context, promptName, version string, req *models.PromptJSON, newStatus *string) (*models.PromptResponse, error)
+	SetPromptStatusFn            func(ctx context.Context, promptName, version, status, successor string) (*models.PromptResponse, error)
+	UpsertPromptEmbeddingFn      func(ctx context.Context, promptName, version string, embedding *database.SemanticEmbedding) error
+	GetPromptEmbeddingMetadataFn func(ctx context.Context, promptName, version string) (*database.SemanticEmbeddingMetadata, error)
+
+	// Search hooks
+	SearchRegistryFn func(ctx context.Context, filter *database.SearchFilter, cursor string, limit int) (*models.SearchResponse, error)
+
+	// Mirror hooks
+	CreateMirrorFn     func(ctx context.Context, in *models.CreateMirrorInput) (*models.Mirror, error)
+	ListMirrorsFn      func(ctx context.Context) ([]*models.Mirror, error)
+	GetMirrorByIDFn    func(ctx context.Context, mirrorID string) (*models.Mirror, error)
+	DeleteMirrorFn     func(ctx context.Context, mirrorID string) error
+	GetMirrorStatusFn  func(ctx context.Context, mirrorID string, historyLimit int) (*models.MirrorStatus, error)
+	RecordMirrorSyncFn func(ctx context.Context, sync *models.MirrorSync) error
+
+	// Secret hooks
+	CreateSecretFn      func(ctx context.Context, in *models.SecretInput) (*models.Secret, error)
+	UpdateSecretFn      func(ctx context.Context, name string, in *models.SecretInput) (*models.Secret, error)
+	ListSecretsFn       func(ctx context.Context) ([]*models.Secret, error)
+	DeleteSecretFn      func(ctx context.Context, name string) error
+	ResolveSecretRefsFn func(ctx context.Context, refs map[string]string) (map[string]string, error)
+
+	// Audit hooks
+	ListAuditEntriesFn func(ctx context.Context, filter *models.AuditFilter, cursor string, limit int) ([]*models.AuditEntry, string, error)
 }
 
 // NewFakeRegistry creates a new FakeRegistry with initialized maps.
@@ -92,6 +138,8 @@ func NewFakeRegistry() *FakeRegistry {
 	return &FakeRegistry{
 		ServerEmbeddingMeta: make(map[string]*database.SemanticEmbeddingMetadata),
 		AgentEmbeddingMeta:  make(map[string]*database.SemanticEmbeddingMetadata),
+		SkillEmbeddingMeta:  make(map[string]*database.SemanticEmbeddingMetadata),
+		PromptEmbeddingMeta: make(map[string]*database.SemanticEmbeddingMetadata),
 	}
 }
 
@@ -270,6 +318,20 @@ func (f *FakeRegistry) ResolveAgentManifestPrompts(ctx context.Context, manifest
 	return nil, nil
 }
 
+func (f *FakeRegistry) UpdateAgent(ctx context.Context, agentName, version string, req *models.AgentJSON, newStatus *string) (*models.AgentResponse, error) {
+	if f.UpdateAgentFn != nil {
+		return f.UpdateAgentFn(ctx, agentName, version, req, newStatus)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) SetAgentStatus(ctx context.Context, agentName, version, status, successor string) (*models.AgentResponse, error) {
+	if f.SetAgentStatusFn != nil {
+		return f.SetAgentStatusFn(ctx, agentName, version, status, successor)
+	}
+	return nil, database.ErrNotFound
+}
+
 func (f *FakeRegistry) DeleteAgent(ctx context.Context, agentName, version string) error {
 	if f.DeleteAgentFn != nil {
 		return f.DeleteAgentFn(ctx, agentName, version)
@@ -346,6 +408,34 @@ func (f *FakeRegistry) CreateSkill(ctx context.Context, req *models.SkillJSON) (
 	return nil, database.ErrNotFound
 }
 
+func (f *FakeRegistry) UpdateSkill(ctx context.Context, skillName, version string, req *models.SkillJSON, newStatus *string) (*models.SkillResponse, error) {
+	if f.UpdateSkillFn != nil {
+		return f.UpdateSkillFn(ctx, skillName, version, req, newStatus)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) SetSkillStatus(ctx context.Context, skillName, version, status, successor string) (*models.SkillResponse, error) {
+	if f.SetSkillStatusFn != nil {
+		return f.SetSkillStatusFn(ctx, skillName, version, status, successor)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) UploadSkillBundle(ctx context.Context, data []byte) (*models.SkillBundle, error) {
+	if f.UploadSkillBundleFn != nil {
+		return f.UploadSkillBundleFn(ctx, data)
+	}
+	return nil, database.ErrInvalidInput
+}
+
+func (f *FakeRegistry) GetSkillBundle(ctx context.Context, digest string) (*database.SkillBundle, error) {
+	if f.GetSkillBundleFn != nil {
+		return f.GetSkillBundleFn(ctx, digest)
+	}
+	return nil, database.ErrNotFound
+}
+
 func (f *FakeRegistry) DeleteSkill(ctx context.Context, skillName, version string) error {
 	if f.DeleteSkillFn != nil {
 		return f.DeleteSkillFn(ctx, skillName, version)
@@ -353,6 +443,29 @@ func (f *FakeRegistry) DeleteSkill(ctx context.Context, skillName, version strin
 	return database.ErrNotFound
 }
 
+func (f *FakeRegistry) UpsertSkillEmbedding(ctx context.Context, skillName, version string, embedding *database.SemanticEmbedding) error {
+	if f.UpsertSkillEmbeddingFn != nil {
+		return f.UpsertSkillEmbeddingFn(ctx, skillName, version, embedding)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.UpsertSkillEmbeddingCalls++
+	return nil
+}
+
+func (f *FakeRegistry) GetSkillEmbeddingMetadata(ctx context.Context, skillName, version string) (*database.SemanticEmbeddingMetadata, error) {
+	if f.GetSkillEmbeddingMetadataFn != nil {
+		return f.GetSkillEmbeddingMetadataFn(ctx, skillName, version)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := skillName + "@" + version
+	if meta, ok := f.SkillEmbeddingMeta[key]; ok {
+		return meta, nil
+	}
+	return nil, database.ErrNotFound
+}
+
 // Deployment methods
 
 func (f *FakeRegistry) ListProviders(ctx context.Context, platform *string) ([]*models.Provider, error) {
@@ -453,6 +566,13 @@ func (f *FakeRegistry) CreateDeployment(ctx context.Context, req *models.Deploym
 	return nil, database.ErrNotFound
 }
 
+func (f *FakeRegistry) RenderDeployment(ctx context.Context, req *models.Deployment, format string) (*models.DeploymentRender, error) {
+	if f.RenderDeploymentFn != nil {
+		return f.RenderDeploymentFn(ctx, req, format)
+	}
+	return nil, database.ErrNotFound
+}
+
 // Prompt methods
 
 func (f *FakeRegistry) ListPrompts(ctx context.Context, filter *database.PromptFilter, cursor string, limit int) ([]*models.PromptResponse, string, error) {
@@ -476,6 +596,27 @@ func (f *FakeRegistry) GetPromptByName(ctx context.Context, promptName string) (
 	return nil, database.ErrNotFound
 }
 
+func (f *FakeRegistry) UpdateDeployment(ctx context.Context, id string, update *models.DeploymentUpdate) (*models.Deployment, error) {
+	if f.UpdateDeploymentFn != nil {
+		return f.UpdateDeploymentFn(ctx, id, update)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) RollbackDeployment(ctx context.Context, id string, revision int) (*models.Deployment, error) {
+	if f.RollbackDeploymentFn != nil {
+		return f.RollbackDeploymentFn(ctx, id, revision)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) ListDeploymentRevisions(ctx context.Context, id string) ([]*models.DeploymentRevision, error) {
+	if f.ListDeploymentRevisionsFn != nil {
+		return f.ListDeploymentRevisionsFn(ctx, id)
+	}
+	return nil, database.ErrNotFound
+}
+
 func (f *FakeRegistry) UndeployDeployment(ctx context.Context, deployment *models.Deployment) error {
 	if f.UndeployDeploymentFn != nil {
 		return f.UndeployDeploymentFn(ctx, deployment)
@@ -483,13 +624,20 @@ func (f *FakeRegistry) UndeployDeployment(ctx context.Context, deployment *model
 	return database.ErrNotFound
 }
 
-func (f *FakeRegistry) GetDeploymentLogs(ctx context.Context, deployment *models.Deployment) ([]string, error) {
+func (f *FakeRegistry) GetDeploymentLogs(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions) ([]string, error) {
 	if f.GetDeploymentLogsFn != nil {
-		return f.GetDeploymentLogsFn(ctx, deployment)
+		return f.GetDeploymentLogsFn(ctx, deployment, opts)
 	}
 	return nil, database.ErrNotFound
 }
 
+func (f *FakeRegistry) StreamDeploymentLogs(ctx context.Context, deployment *models.Deployment, opts models.DeploymentLogOptions, emit func(line string) error) error {
+	if f.StreamDeploymentLogsFn != nil {
+		return f.StreamDeploymentLogsFn(ctx, deployment, opts, emit)
+	}
+	return database.ErrNotFound
+}
+
 func (f *FakeRegistry) CancelDeployment(ctx context.Context, deployment *models.Deployment) error {
 	if f.CancelDeploymentFn != nil {
 		return f.CancelDeploymentFn(ctx, deployment)
@@ -497,6 +645,27 @@ func (f *FakeRegistry) CancelDeployment(ctx context.Context, deployment *models.
 	return database.ErrNotFound
 }
 
+func (f *FakeRegistry) ReconcileDeployments(ctx context.Context, repair bool) ([]*models.DeploymentReconcileResult, error) {
+	if f.ReconcileDeploymentsFn != nil {
+		return f.ReconcileDeploymentsFn(ctx, repair)
+	}
+	return nil, nil
+}
+
+func (f *FakeRegistry) GetDeploymentHealth(ctx context.Context, id string, refresh bool) (*models.DeploymentHealth, error) {
+	if f.GetDeploymentHealthFn != nil {
+		return f.GetDeploymentHealthFn(ctx, id, refresh)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) ProbeDeployments(ctx context.Context) ([]*models.DeploymentHealthResult, error) {
+	if f.ProbeDeploymentsFn != nil {
+		return f.ProbeDeploymentsFn(ctx)
+	}
+	return nil, nil
+}
+
 func (f *FakeRegistry) GetPromptByNameAndVersion(ctx context.Context, promptName, version string) (*models.PromptResponse, error) {
 	if f.GetPromptByNameAndVersionFn != nil {
 		return f.GetPromptByNameAndVersionFn(ctx, promptName, version)
@@ -520,6 +689,27 @@ func (f *FakeRegistry) CreatePrompt(ctx context.Context, req *models.PromptJSON)
 	return nil, database.ErrNotFound
 }
 
+func (f *FakeRegistry) RenderPrompt(ctx context.Context, promptName, version string, arguments map[string]string) (*models.PromptRenderResponse, error) {
+	if f.RenderPromptFn != nil {
+		return f.RenderPromptFn(ctx, promptName, version, arguments)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) UpdatePrompt(ctx context.Context, promptName, version string, req *models.PromptJSON, newStatus *string) (*models.PromptResponse, error) {
+	if f.UpdatePromptFn != nil {
+		return f.UpdatePromptFn(ctx, promptName, version, req, newStatus)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) SetPromptStatus(ctx context.Context, promptName, version, status, successor string) (*models.PromptResponse, error) {
+	if f.SetPromptStatusFn != nil {
+		return f.SetPromptStatusFn(ctx, promptName, version, status, successor)
+	}
+	return nil, database.ErrNotFound
+}
+
 func (f *FakeRegistry) DeletePrompt(ctx context.Context, promptName, version string) error {
 	if f.DeletePromptFn != nil {
 		return f.DeletePromptFn(ctx, promptName, version)
@@ -527,6 +717,123 @@ func (f *FakeRegistry) DeletePrompt(ctx context.Context, promptName, version str
 	return database.ErrNotFound
 }
 
+func (f *FakeRegistry) UpsertPromptEmbedding(ctx context.Context, promptName, version string, embedding *database.SemanticEmbedding) error {
+	if f.UpsertPromptEmbeddingFn != nil {
+		return f.UpsertPromptEmbeddingFn(ctx, promptName, version, embedding)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.UpsertPromptEmbeddingCalls++
+	return nil
+}
+
+func (f *FakeRegistry) GetPromptEmbeddingMetadata(ctx context.Context, promptName, version string) (*database.SemanticEmbeddingMetadata, error) {
+	if f.GetPromptEmbeddingMetadataFn != nil {
+		return f.GetPromptEmbeddingMetadataFn(ctx, promptName, version)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := promptName + "@" + version
+	if meta, ok := f.PromptEmbeddingMeta[key]; ok {
+		return meta, nil
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) SearchRegistry(ctx context.Context, filter *database.SearchFilter, cursor string, limit int) (*models.SearchResponse, error) {
+	if f.SearchRegistryFn != nil {
+		return f.SearchRegistryFn(ctx, filter, cursor, limit)
+	}
+	return &models.SearchResponse{Results: []models.SearchResult{}}, nil
+}
+
+func (f *FakeRegistry) CreateMirror(ctx context.Context, in *models.CreateMirrorInput) (*models.Mirror, error) {
+	if f.CreateMirrorFn != nil {
+		return f.CreateMirrorFn(ctx, in)
+	}
+	return nil, database.ErrInvalidInput
+}
+
+func (f *FakeRegistry) ListMirrors(ctx context.Context) ([]*models.Mirror, error) {
+	if f.ListMirrorsFn != nil {
+		return f.ListMirrorsFn(ctx)
+	}
+	return []*models.Mirror{}, nil
+}
+
+func (f *FakeRegistry) GetMirrorByID(ctx context.Context, mirrorID string) (*models.Mirror, error) {
+	if f.GetMirrorByIDFn != nil {
+		return f.GetMirrorByIDFn(ctx, mirrorID)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) DeleteMirror(ctx context.Context, mirrorID string) error {
+	if f.DeleteMirrorFn != nil {
+		return f.DeleteMirrorFn(ctx, mirrorID)
+	}
+	return database.ErrNotFound
+}
+
+func (f *FakeRegistry) GetMirrorStatus(ctx context.Context, mirrorID string, historyLimit int) (*models.MirrorStatus, error) {
+	if f.GetMirrorStatusFn != nil {
+		return f.GetMirrorStatusFn(ctx, mirrorID, historyLimit)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) RecordMirrorSync(ctx context.Context, sync *models.MirrorSync) error {
+	if f.RecordMirrorSyncFn != nil {
+		return f.RecordMirrorSyncFn(ctx, sync)
+	}
+	return nil
+}
+
+func (f *FakeRegistry) CreateSecret(ctx context.Context, in *models.SecretInput) (*models.Secret, error) {
+	if f.CreateSecretFn != nil {
+		return f.CreateSecretFn(ctx, in)
+	}
+	return nil, database.ErrInvalidInput
+}
+
+func (f *FakeRegistry) UpdateSecret(ctx context.Context, name string, in *models.SecretInput) (*models.Secret, error) {
+	if f.UpdateSecretFn != nil {
+		return f.UpdateSecretFn(ctx, name, in)
+	}
+	return nil, database.ErrNotFound
+}
+
+func (f *FakeRegistry) ListSecrets(ctx context.Context) ([]*models.Secret, error) {
+	if f.ListSecretsFn != nil {
+		return f.ListSecretsFn(ctx)
+	}
+	return []*models.Secret{}, nil
+}
+
+func (f *FakeRegistry) DeleteSecret(ctx context.Context, name string) error {
+	if f.DeleteSecretFn != nil {
+		return f.DeleteSecretFn(ctx, name)
+	}
+	return database.ErrNotFound
+}
+
+func (f *FakeRegistry) ResolveSecretRefs(ctx context.Context, refs map[string]string) (map[string]string, error) {
+	if f.ResolveSecretRefsFn != nil {
+		return f.ResolveSecretRefsFn(ctx, refs)
+	}
+	if len(refs) > 0 {
+		return nil, database.ErrNotFound
+	}
+	return map[string]string{}, nil
+}
+
+func (f *FakeRegistry) ListAuditEntries(ctx context.Context, filter *models.AuditFilter, cursor string, limit int) ([]*models.AuditEntry, string, error) {
+	if f.ListAuditEntriesFn != nil {
+		return f.ListAuditEntriesFn(ctx, filter, cursor, limit)
+	}
+	return nil, "", nil
+}
+
 func (f *FakeRegistry) ReconcileAll(ctx context.Context) error {
 	if f.ReconcileAllFn != nil {
 		return f.ReconcileAllFn(ctx)
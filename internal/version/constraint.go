@@ -0,0 +1,117 @@
+package version
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Latest is the version ref that selects whatever version the registry marks
+// as latest. An empty ref means the same.
+const Latest = "latest"
+
+// IsLatest reports whether ref selects the latest version.
+func IsLatest(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	return ref == "" || strings.EqualFold(ref, Latest)
+}
+
+// IsConstraint reports whether ref is a semver range such as ^1.2, ~0.4 or
+// ">=1.0, <2" rather than an exact version or latest.
+func IsConstraint(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return false
+	}
+	return strings.ContainsAny(ref[:1], "^~<>=!") ||
+		strings.ContainsAny(ref, "*, ") ||
+		strings.Contains(ref, "||")
+}
+
+// Select picks the version that satisfies every ref in refs, where each ref
+// is an exact version, a semver range or latest. It returns "" when all refs
+// are latest so the caller can ask the registry for its latest version.
+// listVersions is only called when a range has to be matched.
+func Select(refs []string, listVersions func() ([]string, error)) (string, error) {
+	var exact string
+	var constraints []string
+	for _, ref := range refs {
+		ref = strings.TrimSpace(ref)
+		switch {
+		case IsLatest(ref):
+		case IsConstraint(ref):
+			constraints = append(constraints, ref)
+		case exact != "" && exact != ref:
+			return "", fmt.Errorf("conflicting versions %s and %s", exact, ref)
+		default:
+			exact = ref
+		}
+	}
+	if len(constraints) == 0 {
+		return exact, nil
+	}
+
+	parsed := make([]*semver.Constraints, 0, len(constraints))
+	for _, ref := range constraints {
+		c, err := semver.NewConstraint(ref)
+		if err != nil {
+			return "", fmt.Errorf("invalid version constraint %q: %w", ref, err)
+		}
+		parsed = append(parsed, c)
+	}
+
+	if exact != "" {
+		v, err := semver.NewVersion(exact)
+		if err != nil {
+			return "", fmt.Errorf("version %s is not semver and cannot satisfy %s", exact, strings.Join(constraints, ", "))
+		}
+		for i, c := range parsed {
+			if !c.Check(v) {
+				return "", fmt.Errorf("version %s does not satisfy %s", exact, constraints[i])
+			}
+		}
+		return exact, nil
+	}
+
+	available, err := listVersions()
+	if err != nil {
+		return "", err
+	}
+	var best *semver.Version
+	var bestRaw string
+	satisfied := make([]bool, len(parsed))
+	for _, raw := range available {
+		v, err := semver.NewVersion(raw)
+		if err != nil {
+			continue
+		}
+		all := true
+		for i, c := range parsed {
+			if c.Check(v) {
+				satisfied[i] = true
+			} else {
+				all = false
+			}
+		}
+		if all && (best == nil || v.GreaterThan(best)) {
+			best, bestRaw = v, raw
+		}
+	}
+	if best != nil {
+		return bestRaw, nil
+	}
+	if i := slices.Index(satisfied, false); i >= 0 {
+		return "", fmt.Errorf("no version satisfies %s (available: %s)", constraints[i], availableList(available))
+	}
+	return "", fmt.Errorf("constraints %s conflict: no single version satisfies them all (available: %s)",
+		strings.Join(constraints, ", "), availableList(available))
+}
+
+func availableList(versions []string) string {
+	if len(versions) == 0 {
+		return "none"
+	}
+	return strings.Join(versions, ", ")
+}
@@ -0,0 +1,72 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsConstraint(t *testing.T) {
+	for ref, want := range map[string]bool{
+		"^1.2":       true,
+		"~0.4":       true,
+		">=1.0, <2":  true,
+		"1.x || 2.*": true,
+		"1.2.3":      false,
+		"latest":     false,
+		"":           false,
+		"2024-06-01": false,
+	} {
+		if got := IsConstraint(ref); got != want {
+			t.Errorf("IsConstraint(%q) = %v, want %v", ref, got, want)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	available := []string{"0.4.1", "0.4.7", "0.5.0", "1.1.0", "1.2.0", "1.4.2", "2.0.0", "2.1.0-beta.1", "nightly"}
+	tests := []struct {
+		name    string
+		refs    []string
+		want    string
+		wantErr string
+	}{
+		{name: "caret picks the highest compatible version", refs: []string{"^1.2"}, want: "1.4.2"},
+		{name: "tilde stays on the minor version", refs: []string{"~0.4"}, want: "0.4.7"},
+		{name: "ranges are intersected", refs: []string{"^1.0", "<1.3"}, want: "1.2.0"},
+		{name: "exact version within a range", refs: []string{"1.2.0", "^1.1"}, want: "1.2.0"},
+		{name: "prereleases are skipped", refs: []string{">=2.0.0"}, want: "2.0.0"},
+		{name: "latest is left to the registry", refs: []string{"", "latest"}, want: ""},
+		{name: "latest defers to a range", refs: []string{"latest", "~0.4"}, want: "0.4.7"},
+		{name: "different exact versions conflict", refs: []string{"1.1.0", "1.2.0"}, wantErr: "conflicting versions 1.1.0 and 1.2.0"},
+		{name: "exact version outside a range", refs: []string{"1.1.0", "^1.2"}, wantErr: "does not satisfy ^1.2"},
+		{name: "unsatisfiable range", refs: []string{"^3"}, wantErr: "no version satisfies ^3"},
+		{name: "disjoint ranges conflict", refs: []string{"^1.2", "~0.4"}, wantErr: "conflict"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Select(tt.refs, func() ([]string, error) { return available, nil })
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Select(%v) error = %v, want %q", tt.refs, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Select(%v) unexpected error: %v", tt.refs, err)
+			}
+			if got != tt.want {
+				t.Errorf("Select(%v) = %q, want %q", tt.refs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelect_ListsVersionsOnlyForRanges(t *testing.T) {
+	list := func() ([]string, error) {
+		t.Fatal("listVersions called without a range")
+		return nil, nil
+	}
+	if got, err := Select([]string{"1.2.0", "latest"}, list); err != nil || got != "1.2.0" {
+		t.Fatalf("Select() = %q, %v, want 1.2.0", got, err)
+	}
+}
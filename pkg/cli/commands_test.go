@@ -14,14 +14,20 @@ func TestCommandTree(t *testing.T) {
 
 	expectedTopLevel := []string{
 		"agent",
+		"apply",
+		"audit",
 		"configure",
 		"daemon",
 		"deployments",
+		"diff",
 		"embeddings",
 		"export",
 		"import",
 		"mcp",
+		"mirror",
 		"prompt",
+		"search",
+		"secret",
 		"skill",
 		"version",
 	}
@@ -44,18 +50,22 @@ func TestCommandTree(t *testing.T) {
 
 	// Verify subcommand counts for parent commands
 	expectedSubcmdCounts := map[string]int{
-		// init, build, run, add-skill, add-prompt, add-mcp, publish, delete, list, show
-		"agent": 10,
+		// init, build, run, add-skill, add-prompt, add-mcp, publish, delete, deprecate, undeprecate, list, show
+		"agent": 12,
 		// init, build, add-tool, publish, delete, list, run, show
 		"mcp": 8,
-		// create, list, show, delete
-		"deployments": 4,
-		// init, build, list, publish, delete, pull, show
-		"skill": 7,
-		// list, publish, delete, show
-		"prompt": 4,
+		// create, list, show, update, rollback, render, delete, logs
+		"deployments": 8,
+		// init, build, list, publish, delete, deprecate, undeprecate, pull, show
+		"skill": 9,
+		// list, publish, delete, deprecate, undeprecate, show
+		"prompt": 6,
 		// generate
 		"embeddings": 1,
+		// add, list, remove, sync, status
+		"mirror": 5,
+		// create, update, list, delete
+		"secret": 4,
 	}
 
 	for _, cmd := range root.Commands() {
@@ -209,6 +219,9 @@ func TestRequiredFlags(t *testing.T) {
 	}{
 		{"skill", "delete", []string{"version"}},
 		{"agent", "delete", []string{"version"}},
+		{"agent", "deprecate", []string{"version"}},
+		{"skill", "undeprecate", []string{"version"}},
+		{"prompt", "deprecate", []string{"version"}},
 	}
 
 	for _, tt := range tests {
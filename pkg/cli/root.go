@@ -10,11 +10,14 @@ import (
 	"github.com/agentregistry-dev/agentregistry/internal/cli"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/agent"
 	agentutils "github.com/agentregistry-dev/agentregistry/internal/cli/agent/utils"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/apply"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/configure"
 	clidaemon "github.com/agentregistry-dev/agentregistry/internal/cli/daemon"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/deployment"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/mcp"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/mirror"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/prompt"
+	"github.com/agentregistry-dev/agentregistry/internal/cli/secret"
 	"github.com/agentregistry-dev/agentregistry/internal/cli/skill"
 	"github.com/agentregistry-dev/agentregistry/internal/client"
 	"github.com/agentregistry-dev/agentregistry/pkg/daemon/dockercompose"
@@ -77,6 +80,9 @@ var rootCmd = &cobra.Command{
 		skill.SetAPIClient(c)
 		prompt.SetAPIClient(c)
 		deployment.SetAPIClient(c)
+		mirror.SetAPIClient(c)
+		secret.SetAPIClient(c)
+		apply.SetAPIClient(c)
 		cli.SetAPIClient(c)
 		return nil
 	},
@@ -95,7 +101,13 @@ func init() {
 	rootCmd.AddCommand(cli.ImportCmd)
 	rootCmd.AddCommand(cli.ExportCmd)
 	rootCmd.AddCommand(cli.EmbeddingsCmd)
+	rootCmd.AddCommand(cli.SearchCmd)
+	rootCmd.AddCommand(cli.AuditCmd)
 	rootCmd.AddCommand(deployment.DeploymentCmd)
+	rootCmd.AddCommand(mirror.MirrorCmd)
+	rootCmd.AddCommand(secret.SecretCmd)
+	rootCmd.AddCommand(apply.ApplyCmd)
+	rootCmd.AddCommand(apply.DiffCmd)
 	rootCmd.AddCommand(clidaemon.New(dockercompose.NewManager(dockercompose.DefaultConfig())))
 }
 
@@ -16,6 +16,8 @@ type AgentJSON struct {
 	Repository    *model.Repository  `json:"repository,omitempty" doc:"Optional repository metadata for the agent source code."`
 	Packages      []AgentPackageInfo `json:"packages,omitempty"`
 	Remotes       []model.Transport  `json:"remotes,omitempty"`
+	Lock          *AgentLock         `json:"lock,omitempty" doc:"Versions the agent's registry MCP servers, skills and prompts were locked to when it was built."`
+	Signature     *ArtifactSignature `json:"signature,omitempty" doc:"Publisher signature over the agent image and packages."`
 }
 
 type AgentPackageInfo struct {
@@ -33,6 +35,7 @@ type AgentRegistryExtensions struct {
 	PublishedAt time.Time `json:"publishedAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 	IsLatest    bool      `json:"isLatest"`
+	Successor   string    `json:"successor,omitempty" doc:"Version that replaces this one, set when it is deprecated."`
 }
 
 type AgentSemanticMeta struct {
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AgentLock pins the registry references of an agent manifest to the
+// concrete versions they resolved to, so builds and deployments of the same
+// manifest use the same MCP servers, skills and prompts.
+type AgentLock struct {
+	McpServers []LockedVersion `yaml:"mcpServers,omitempty" json:"mcpServers,omitempty"`
+	Skills     []LockedVersion `yaml:"skills,omitempty" json:"skills,omitempty"`
+	Prompts    []LockedVersion `yaml:"prompts,omitempty" json:"prompts,omitempty"`
+}
+
+// LockedVersion is the version a registry resource resolved to, along with
+// the version refs it was resolved from. An entry is only honored while the
+// manifest still uses the same refs.
+type LockedVersion struct {
+	RegistryURL string   `yaml:"registryURL,omitempty" json:"registryURL,omitempty"`
+	Name        string   `yaml:"name" json:"name"`
+	Constraints []string `yaml:"constraints" json:"constraints"`
+	Version     string   `yaml:"version" json:"version"`
+}
+
+// RegistryRef is a registry resource referenced by an agent manifest, with
+// every version ref the manifest uses for it. Constraints are sorted and
+// distinct, and an empty ref is recorded as "latest".
+type RegistryRef struct {
+	RegistryURL string
+	Name        string
+	Constraints []string
+}
+
+// RegistryServerRefs returns the registry MCP servers the manifest references.
+func (m *AgentManifest) RegistryServerRefs() []RegistryRef {
+	var refs registryRefs
+	for _, srv := range m.McpServers {
+		if srv.Type == "registry" {
+			refs.add(srv.RegistryURL, srv.RegistryServerName, srv.RegistryServerVersion)
+		}
+	}
+	return refs
+}
+
+// RegistrySkillRefs returns the registry skills the manifest references.
+func (m *AgentManifest) RegistrySkillRefs() []RegistryRef {
+	var refs registryRefs
+	for _, skill := range m.Skills {
+		if strings.TrimSpace(skill.RegistrySkillName) != "" {
+			refs.add(skill.RegistryURL, skill.RegistrySkillName, skill.RegistrySkillVersion)
+		}
+	}
+	return refs
+}
+
+// RegistryPromptRefs returns the registry prompts the manifest references.
+func (m *AgentManifest) RegistryPromptRefs() []RegistryRef {
+	var refs registryRefs
+	for _, prompt := range m.Prompts {
+		if strings.TrimSpace(prompt.RegistryPromptName) != "" {
+			refs.add(prompt.RegistryURL, prompt.RegistryPromptName, prompt.RegistryPromptVersion)
+		}
+	}
+	return refs
+}
+
+// Pinned returns a copy of m whose registry references carry the versions
+// locked in lock. References without a current lock entry are left as is.
+func (m *AgentManifest) Pinned(lock *AgentLock) *AgentManifest {
+	pinned := *m
+	if lock == nil {
+		return &pinned
+	}
+
+	servers := m.RegistryServerRefs()
+	pinned.McpServers = slices.Clone(m.McpServers)
+	for i, srv := range pinned.McpServers {
+		if srv.Type != "registry" {
+			continue
+		}
+		if version, ok := lockedVersionFor(lock.McpServers, servers, srv.RegistryURL, srv.RegistryServerName); ok {
+			pinned.McpServers[i].RegistryServerVersion = version
+		}
+	}
+
+	skills := m.RegistrySkillRefs()
+	pinned.Skills = slices.Clone(m.Skills)
+	for i, skill := range pinned.Skills {
+		if version, ok := lockedVersionFor(lock.Skills, skills, skill.RegistryURL, skill.RegistrySkillName); ok {
+			pinned.Skills[i].RegistrySkillVersion = version
+		}
+	}
+
+	prompts := m.RegistryPromptRefs()
+	pinned.Prompts = slices.Clone(m.Prompts)
+	for i, prompt := range pinned.Prompts {
+		if version, ok := lockedVersionFor(lock.Prompts, prompts, prompt.RegistryURL, prompt.RegistryPromptName); ok {
+			pinned.Prompts[i].RegistryPromptVersion = version
+		}
+	}
+	return &pinned
+}
+
+// RegistryRefKind names the kind of resource a RegistryRef points to.
+type RegistryRefKind string
+
+const (
+	RegistryRefMCPServer RegistryRefKind = "MCP server"
+	RegistryRefSkill     RegistryRefKind = "skill"
+	RegistryRefPrompt    RegistryRefKind = "prompt"
+)
+
+// CheckCurrent returns an error naming the first registry reference of m that
+// l does not pin for the version refs m currently uses.
+func (l *AgentLock) CheckCurrent(m *AgentManifest) error {
+	for _, section := range l.sections(m) {
+		for _, ref := range section.refs {
+			if _, ok := LookupLockedVersion(section.locked, ref); !ok {
+				return fmt.Errorf("%s %s (%s) is not locked", section.kind, ref.Name, strings.Join(ref.Constraints, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// Complete returns a lock with an entry for every registry reference of m.
+// Entries of l that still match m are kept, entries m no longer uses are
+// dropped, and the remaining references are resolved with resolve. resolve
+// may return "" to leave a reference unpinned.
+func (l *AgentLock) Complete(m *AgentManifest, resolve func(kind RegistryRefKind, ref RegistryRef) (string, error)) (*AgentLock, error) {
+	completed := &AgentLock{}
+	for _, section := range l.sections(m) {
+		var entries []LockedVersion
+		for _, ref := range section.refs {
+			version, ok := LookupLockedVersion(section.locked, ref)
+			if !ok {
+				var err error
+				if version, err = resolve(section.kind, ref); err != nil {
+					return nil, fmt.Errorf("resolve %s %s (%s): %w", section.kind, ref.Name, strings.Join(ref.Constraints, ", "), err)
+				}
+			}
+			if version != "" {
+				entries = append(entries, LockedVersion{RegistryURL: ref.RegistryURL, Name: ref.Name, Constraints: ref.Constraints, Version: version})
+			}
+		}
+		switch section.kind {
+		case RegistryRefMCPServer:
+			completed.McpServers = entries
+		case RegistryRefSkill:
+			completed.Skills = entries
+		case RegistryRefPrompt:
+			completed.Prompts = entries
+		}
+	}
+	return completed, nil
+}
+
+type agentLockSection struct {
+	kind   RegistryRefKind
+	refs   []RegistryRef
+	locked []LockedVersion
+}
+
+func (l *AgentLock) sections(m *AgentManifest) []agentLockSection {
+	lock := &AgentLock{}
+	if l != nil {
+		lock = l
+	}
+	return []agentLockSection{
+		{RegistryRefMCPServer, m.RegistryServerRefs(), lock.McpServers},
+		{RegistryRefSkill, m.RegistrySkillRefs(), lock.Skills},
+		{RegistryRefPrompt, m.RegistryPromptRefs(), lock.Prompts},
+	}
+}
+
+// LookupLockedVersion returns the version locked for ref when the entry was
+// resolved from the same version refs.
+func LookupLockedVersion(locked []LockedVersion, ref RegistryRef) (string, bool) {
+	for _, entry := range locked {
+		if entry.RegistryURL == ref.RegistryURL && entry.Name == ref.Name && slices.Equal(entry.Constraints, ref.Constraints) {
+			return entry.Version, entry.Version != ""
+		}
+	}
+	return "", false
+}
+
+func lockedVersionFor(locked []LockedVersion, refs []RegistryRef, registryURL, name string) (string, bool) {
+	registryURL, name = strings.TrimSpace(registryURL), strings.TrimSpace(name)
+	for _, ref := range refs {
+		if ref.RegistryURL == registryURL && ref.Name == name {
+			return LookupLockedVersion(locked, ref)
+		}
+	}
+	return "", false
+}
+
+type registryRefs []RegistryRef
+
+func (r *registryRefs) add(registryURL, name, version string) {
+	registryURL, name, version = strings.TrimSpace(registryURL), strings.TrimSpace(name), strings.TrimSpace(version)
+	if version == "" || strings.EqualFold(version, "latest") {
+		version = "latest"
+	}
+	for i, ref := range *r {
+		if ref.RegistryURL == registryURL && ref.Name == name {
+			if !slices.Contains(ref.Constraints, version) {
+				(*r)[i].Constraints = append(ref.Constraints, version)
+				slices.Sort((*r)[i].Constraints)
+			}
+			return
+		}
+	}
+	*r = append(*r, RegistryRef{RegistryURL: registryURL, Name: name, Constraints: []string{version}})
+}
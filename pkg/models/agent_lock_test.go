@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func lockTestManifest() *AgentManifest {
+	return &AgentManifest{
+		Name: "planner",
+		McpServers: []McpServerType{
+			{Type: "registry", Name: "weather", RegistryServerName: "acme/weather", RegistryServerVersion: "^1.2"},
+			{Type: "registry", Name: "weather-alerts", RegistryServerName: "acme/weather", RegistryServerVersion: "~1.4"},
+			{Type: "remote", Name: "search", URL: "https://search.example.com/mcp"},
+		},
+		Skills:  []SkillRef{{Name: "pdf", RegistrySkillName: "acme/pdf"}, {Name: "local", Image: "skills/local:1"}},
+		Prompts: []PromptRef{{Name: "system", RegistryPromptName: "acme/system", RegistryPromptVersion: "1.0.0"}},
+	}
+}
+
+func TestAgentManifest_RegistryRefsGroupVersionsByResource(t *testing.T) {
+	manifest := lockTestManifest()
+
+	servers := manifest.RegistryServerRefs()
+	if len(servers) != 1 || servers[0].Name != "acme/weather" || strings.Join(servers[0].Constraints, ",") != "^1.2,~1.4" {
+		t.Fatalf("RegistryServerRefs() = %+v", servers)
+	}
+	skills := manifest.RegistrySkillRefs()
+	if len(skills) != 1 || strings.Join(skills[0].Constraints, ",") != "latest" {
+		t.Fatalf("RegistrySkillRefs() = %+v", skills)
+	}
+}
+
+func TestAgentLock_CompleteKeepsCurrentEntriesAndPinsManifest(t *testing.T) {
+	manifest := lockTestManifest()
+	existing := &AgentLock{
+		McpServers: []LockedVersion{{Name: "acme/weather", Constraints: []string{"^1.2", "~1.4"}, Version: "1.4.2"}},
+		// Resolved from a ref the manifest no longer uses.
+		Prompts: []LockedVersion{{Name: "acme/system", Constraints: []string{"0.9.0"}, Version: "0.9.0"}},
+	}
+
+	var resolved []string
+	lock, err := existing.Complete(manifest, func(kind RegistryRefKind, ref RegistryRef) (string, error) {
+		resolved = append(resolved, string(kind)+" "+ref.Name)
+		if kind == RegistryRefSkill {
+			return "2.0.0", nil
+		}
+		return ref.Constraints[0], nil
+	})
+	if err != nil {
+		t.Fatalf("Complete() unexpected error: %v", err)
+	}
+	if got := strings.Join(resolved, ","); got != "skill acme/pdf,prompt acme/system" {
+		t.Fatalf("resolved %s, want only the skill and the changed prompt", got)
+	}
+	if err := lock.CheckCurrent(manifest); err != nil {
+		t.Fatalf("CheckCurrent() = %v", err)
+	}
+
+	pinned := manifest.Pinned(lock)
+	if pinned.McpServers[0].RegistryServerVersion != "1.4.2" || pinned.McpServers[1].RegistryServerVersion != "1.4.2" {
+		t.Errorf("pinned servers = %+v", pinned.McpServers)
+	}
+	if pinned.Skills[0].RegistrySkillVersion != "2.0.0" || pinned.Prompts[0].RegistryPromptVersion != "1.0.0" {
+		t.Errorf("pinned skills = %+v, prompts = %+v", pinned.Skills, pinned.Prompts)
+	}
+	if manifest.McpServers[0].RegistryServerVersion != "^1.2" {
+		t.Error("Pinned() modified the original manifest")
+	}
+}
+
+func TestAgentLock_CheckCurrentReportsChangedRefs(t *testing.T) {
+	manifest := lockTestManifest()
+	lock := &AgentLock{
+		McpServers: []LockedVersion{{Name: "acme/weather", Constraints: []string{"^1.2"}, Version: "1.2.0"}},
+	}
+	err := lock.CheckCurrent(manifest)
+	if err == nil || !strings.Contains(err.Error(), "MCP server acme/weather (^1.2, ~1.4) is not locked") {
+		t.Fatalf("CheckCurrent() = %v", err)
+	}
+
+	if pinned := manifest.Pinned(lock); pinned.McpServers[0].RegistryServerVersion != "^1.2" {
+		t.Errorf("stale lock entry was applied: %+v", pinned.McpServers[0])
+	}
+
+	errResolve := errors.New("registry unavailable")
+	if _, err := (*AgentLock)(nil).Complete(manifest, func(RegistryRefKind, RegistryRef) (string, error) {
+		return "", errResolve
+	}); !errors.Is(err, errResolve) {
+		t.Fatalf("Complete() error = %v, want %v", err, errResolve)
+	}
+}
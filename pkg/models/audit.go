@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// Audit actions recorded for mutating registry operations.
+const (
+	AuditActionPublish   = "publish"
+	AuditActionEdit      = "edit"
+	AuditActionSetStatus = "set-status"
+	AuditActionDelete    = "delete"
+	AuditActionCreate    = "create"
+	AuditActionDeploy    = "deploy"
+	AuditActionUpdate    = "update"
+	AuditActionUndeploy  = "undeploy"
+	AuditActionCancel    = "cancel"
+	AuditActionRepair    = "repair"
+	AuditActionRollback  = "rollback"
+	AuditActionUpload    = "upload"
+)
+
+// Audit resource types. Registry artifacts use the same names as permissions.
+const (
+	AuditResourceServer     = "server"
+	AuditResourceAgent      = "agent"
+	AuditResourceSkill      = "skill"
+	AuditResourcePrompt     = "prompt"
+	AuditResourceProvider   = "provider"
+	AuditResourceDeployment = "deployment"
+	AuditResourceMirror     = "mirror"
+	AuditResourceSecret     = "secret"
+	// AuditResourceSkillBundle entries are named by the bundle digest.
+	AuditResourceSkillBundle = "skill-bundle"
+)
+
+// Principals recorded for requests that do not carry a user identity.
+const (
+	AuditPrincipalSystem    = "system"
+	AuditPrincipalAnonymous = "anonymous"
+)
+
+// AuditEntry records one mutating operation. Entries are append-only; the
+// digests are sha256 hashes of the resource's JSON before and after the change,
+// so an entry can be matched against a stored or exported copy of the resource.
+type AuditEntry struct {
+	ID              int64      `json:"id"`
+	Timestamp       time.Time  `json:"timestamp"`
+	Principal       string     `json:"principal"`
+	AuthMethod      string     `json:"authMethod"`
+	Action          string     `json:"action"`
+	ResourceType    string     `json:"resourceType"`
+	ResourceName    string     `json:"resourceName"`
+	ResourceVersion string     `json:"resourceVersion,omitempty"`
+	BeforeDigest    string     `json:"beforeDigest,omitempty"`
+	AfterDigest     string     `json:"afterDigest,omitempty"`
+	RequestID       string     `json:"requestId,omitempty"`
+	Details         JSONObject `json:"details,omitempty"`
+}
+
+// AuditFilter narrows audit log queries. Nil fields are not filtered on.
+type AuditFilter struct {
+	Principal    *string
+	Action       *string
+	ResourceType *string
+	ResourceName *string
+	RequestID    *string
+	Since        *time.Time
+	Until        *time.Time
+}
+
+// AuditMetadata holds pagination info for an audit log page.
+type AuditMetadata struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	Count      int    `json:"count"`
+}
+
+// AuditLogResponse is the paginated list response for audit entries.
+type AuditLogResponse struct {
+	Entries  []AuditEntry  `json:"entries"`
+	Metadata AuditMetadata `json:"metadata"`
+}
@@ -17,24 +17,104 @@ const (
 	DeploymentStatusCancelled = "cancelled"
 	// DeploymentStatusDiscovered indicates deployment was discovered from runtime state.
 	DeploymentStatusDiscovered = "discovered"
+	// DeploymentStatusDrifted indicates the running workload no longer matches the desired spec.
+	DeploymentStatusDrifted = "drifted"
+	// DeploymentStatusMissing indicates the workload was removed from the platform outside the registry.
+	DeploymentStatusMissing = "missing"
+	// DeploymentStatusUnhealthy indicates the workload exists but is not running or ready.
+	DeploymentStatusUnhealthy = "unhealthy"
 )
 
+// IsReconciledDeploymentStatus reports whether a managed deployment in this
+// status is checked against live platform state by the reconciler.
+func IsReconciledDeploymentStatus(status string) bool {
+	switch status {
+	case DeploymentStatusDeployed, DeploymentStatusDrifted, DeploymentStatusMissing, DeploymentStatusUnhealthy:
+		return true
+	default:
+		return false
+	}
+}
+
 // Deployment represents a deployed resource with unified deployment metadata.
 type Deployment struct {
-	ID               string            `json:"id"`
-	ServerName       string            `json:"serverName"` // deployed resource name
-	Version          string            `json:"version"`
-	ProviderID       string            `json:"providerId,omitempty"`
-	ResourceType     string            `json:"resourceType"`
-	Status           string            `json:"status"` // deploying, deployed, failed, cancelled, discovered
-	Origin           string            `json:"origin"` // managed, discovered
-	Env              map[string]string `json:"env"`
+	ID           string `json:"id"`
+	ServerName   string `json:"serverName"` // deployed resource name
+	Version      string `json:"version"`
+	ProviderID   string `json:"providerId,omitempty"`
+	ResourceType string `json:"resourceType"`
+	Status       string `json:"status"` // deploying, deployed, failed, cancelled, discovered, drifted, missing, unhealthy
+	Origin       string `json:"origin"` // managed, discovered
+	// Env holds plain environment variables. API responses replace the values
+	// with RedactedEnvValue, since they may hold credentials.
+	Env map[string]string `json:"env"`
+	// SecretRefs maps environment variable names to registry secrets. Values are
+	// resolved only when the deployment is applied and are never returned.
+	SecretRefs       map[string]string `json:"secretRefs,omitempty"`
 	ProviderConfig   JSONObject        `json:"providerConfig,omitempty"`
 	ProviderMetadata JSONObject        `json:"providerMetadata,omitempty"`
 	PreferRemote     bool              `json:"preferRemote"`
 	Error            string            `json:"error,omitempty"`
-	DeployedAt       time.Time         `json:"deployedAt"`
-	UpdatedAt        time.Time         `json:"updatedAt"`
+	// Health is the result of the last health probe, if the deployment has been probed.
+	Health     *DeploymentHealth `json:"health,omitempty"`
+	DeployedAt time.Time         `json:"deployedAt"`
+	UpdatedAt  time.Time         `json:"updatedAt"`
+}
+
+// RedactedEnvValue replaces env values in deployment API responses. An update
+// that sends it for a key keeps the key's current value.
+const RedactedEnvValue = "***"
+
+// Redacted returns a copy of the deployment with its env values replaced by
+// RedactedEnvValue.
+func (d Deployment) Redacted() Deployment {
+	if len(d.Env) == 0 {
+		return d
+	}
+	env := make(map[string]string, len(d.Env))
+	for key := range d.Env {
+		env[key] = RedactedEnvValue
+	}
+	d.Env = env
+	return d
+}
+
+// Deployment probe protocols.
+const (
+	// DeploymentProbeMCP probes an MCP server with an initialize handshake and a tool listing.
+	DeploymentProbeMCP = "mcp"
+	// DeploymentProbeA2A probes an agent by fetching its A2A agent card.
+	DeploymentProbeA2A = "a2a"
+)
+
+// DeploymentProbeTarget tells the health prober where a deployment answers.
+type DeploymentProbeTarget struct {
+	// Protocol is DeploymentProbeMCP or DeploymentProbeA2A.
+	Protocol string
+	// URL is the MCP endpoint or the agent card URL.
+	URL string
+	// ToolPrefix, when set, only counts tools with this prefix. Gateways that
+	// multiplex several MCP servers on one route prefix each server's tools.
+	ToolPrefix string
+}
+
+// DeploymentHealth is the result of probing a deployment.
+type DeploymentHealth struct {
+	Healthy   bool      `json:"healthy" doc:"Whether the deployment answered the probe"`
+	CheckedAt time.Time `json:"checkedAt" doc:"When the probe ran"`
+	LatencyMs int64     `json:"latencyMs" doc:"Probe round-trip time in milliseconds"`
+	Endpoint  string    `json:"endpoint,omitempty" doc:"URL that was probed"`
+	// ToolCount is only set for MCP servers.
+	ToolCount *int   `json:"toolCount,omitempty" doc:"Number of tools the MCP server listed"`
+	Error     string `json:"error,omitempty" doc:"Why the probe failed"`
+}
+
+// DeploymentHealthResult reports the outcome of probing one deployment.
+type DeploymentHealthResult struct {
+	DeploymentID string `json:"deploymentId"`
+	// Previous is the last stored probe result, nil if the deployment had not been probed.
+	Previous *DeploymentHealth `json:"previous,omitempty"`
+	Health   *DeploymentHealth `json:"health"`
 }
 
 // DeploymentActionResult captures provider-specific execution outcome from adapters.
@@ -51,6 +131,24 @@ type DeploymentActionResult struct {
 	ProviderMetadata JSONObject `json:"providerMetadata,omitempty"`
 }
 
+// DeploymentObservation is what an adapter found on the platform for a managed
+// deployment. Status is deployed when the workload matches the desired spec,
+// or one of drifted, missing or unhealthy with Reason explaining why.
+type DeploymentObservation struct {
+	Status string
+	Reason string
+}
+
+// DeploymentReconcileResult reports the outcome of reconciling one deployment.
+type DeploymentReconcileResult struct {
+	DeploymentID   string `json:"deploymentId"`
+	PreviousStatus string `json:"previousStatus"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason,omitempty"`
+	// Repaired is set when desired state was re-applied to the platform.
+	Repaired bool `json:"repaired,omitempty"`
+}
+
 // DeploymentStatePatch describes partial deployment state updates.
 // Nil fields are left unchanged.
 type DeploymentStatePatch struct {
@@ -58,6 +156,107 @@ type DeploymentStatePatch struct {
 	Error            *string
 	ProviderConfig   *JSONObject
 	ProviderMetadata *JSONObject
+	// Version, Env, SecretRefs and PreferRemote change what is deployed and are
+	// only set when a deployment is updated in place.
+	Version      *string
+	Env          *map[string]string
+	SecretRefs   *map[string]string
+	PreferRemote *bool
+	// Health records a probe result. It does not change UpdatedAt.
+	Health *DeploymentHealth
+
+	// UnlessStatus and IfUpdatedAt make the patch conditional: it only applies
+	// while the stored status differs from UnlessStatus and UpdatedAt still
+	// equals IfUpdatedAt. A patch whose conditions no longer hold fails with
+	// ErrConflict.
+	UnlessStatus *string
+	IfUpdatedAt  *time.Time
+}
+
+// HasSpecChanges reports whether the patch changes what is deployed rather
+// than only recording runtime state.
+func (p *DeploymentStatePatch) HasSpecChanges() bool {
+	return p.Version != nil || p.Env != nil || p.SecretRefs != nil || p.PreferRemote != nil
+}
+
+// DeploymentUpdate describes an in-place update of a deployment. Nil fields
+// are left unchanged; Env and SecretRefs replace the existing maps when set.
+type DeploymentUpdate struct {
+	Version        *string           `json:"version,omitempty" doc:"Version to roll the deployment to (use 'latest' for the latest version)"`
+	Env            map[string]string `json:"env,omitempty" doc:"Replacement deployment environment variables. A value of *** keeps the variable's current value."`
+	SecretRefs     map[string]string `json:"secretRefs,omitempty" doc:"Replacement environment variables read from stored secrets, mapped to the secret name."`
+	ProviderConfig JSONObject        `json:"providerConfig,omitempty" doc:"Replacement provider-specific deployment settings."`
+	PreferRemote   *bool             `json:"preferRemote,omitempty" doc:"Prefer remote deployment over local"`
+}
+
+// Deployment revision actions.
+const (
+	DeploymentRevisionActionDeploy   = "deploy"
+	DeploymentRevisionActionUpdate   = "update"
+	DeploymentRevisionActionRollback = "rollback"
+	DeploymentRevisionActionUndeploy = "undeploy"
+)
+
+// DeploymentRevisionStatusUndeployed is the status recorded by undeploy revisions.
+const DeploymentRevisionStatusUndeployed = "undeployed"
+
+// DeploymentRevision is an immutable record of one deploy, update, rollback or
+// undeploy of a deployment and the status it ended in.
+type DeploymentRevision struct {
+	DeploymentID string `json:"deploymentId" doc:"Deployment ID"`
+	Revision     int    `json:"revision" doc:"Revision number, starting at 1 for the first deploy"`
+	Action       string `json:"action" doc:"What produced the revision" enum:"deploy,update,rollback,undeploy"`
+	ResourceType string `json:"resourceType" doc:"Deployed resource type (mcp, agent)"`
+	ServerName   string `json:"serverName" doc:"Deployed resource name"`
+	Version      string `json:"version" doc:"Deployed resource version"`
+	ProviderID   string `json:"providerId,omitempty" doc:"Provider the revision was applied to"`
+	// EnvKeys names the plain environment variables that were set. Their values
+	// are not kept, since they may hold credentials.
+	EnvKeys        []string          `json:"envKeys" doc:"Names of the environment variables that were set"`
+	SecretRefs     map[string]string `json:"secretRefs,omitempty" doc:"Environment variables read from stored secrets, mapped to the secret name"`
+	ProviderConfig JSONObject        `json:"providerConfig,omitempty" doc:"Provider-specific deployment settings"`
+	PreferRemote   bool              `json:"preferRemote" doc:"Whether a remote deployment was preferred"`
+	Status         string            `json:"status" doc:"Status the deployment ended in"`
+	Error          string            `json:"error,omitempty" doc:"Failure details"`
+	// SourceRevision is the revision a rollback re-applied.
+	SourceRevision int       `json:"sourceRevision,omitempty" doc:"Revision re-applied by a rollback"`
+	CreatedAt      time.Time `json:"createdAt" doc:"When the revision was recorded"`
+}
+
+// DeploymentLogOptions controls which log lines are returned for a deployment.
+// Zero values mean "all lines, from the beginning, without following".
+type DeploymentLogOptions struct {
+	// TailLines limits output to the last N lines per workload. Zero returns all lines.
+	TailLines int64
+	// Since only returns lines emitted at or after this time.
+	Since *time.Time
+	// Follow keeps the stream open and emits new lines as they are written.
+	Follow bool
+}
+
+// Deployment render formats.
+const (
+	// DeploymentRenderFormatYAML renders the platform's manifests as plain YAML.
+	DeploymentRenderFormatYAML = "yaml"
+	// DeploymentRenderFormatHelm packages Kubernetes objects as a Helm chart.
+	DeploymentRenderFormatHelm = "helm"
+	// DeploymentRenderFormatKustomize packages Kubernetes objects as a kustomization.
+	DeploymentRenderFormatKustomize = "kustomize"
+)
+
+// RenderedFile is one file of a rendered deployment.
+type RenderedFile struct {
+	Path    string `json:"path" doc:"Path of the file, relative to the output directory" example:"manifests.yaml"`
+	Content string `json:"content" doc:"File content"`
+}
+
+// DeploymentRender holds the manifests a platform would apply for a
+// deployment. Secret values are never rendered; secret keys are listed with
+// empty values for the caller to fill in.
+type DeploymentRender struct {
+	Platform string         `json:"platform" doc:"Platform the manifests target" example:"kubernetes"`
+	Format   string         `json:"format" doc:"Render format" enum:"yaml,helm,kustomize"`
+	Files    []RenderedFile `json:"files" doc:"Rendered files"`
 }
 
 type KubernetesProviderMetadata struct {
@@ -109,6 +308,7 @@ type DeploymentSummary struct {
 	Status     string    `json:"status"`
 	Origin     string    `json:"origin"`
 	Version    string    `json:"version,omitempty"`
+	Error      string    `json:"error,omitempty"`
 	DeployedAt time.Time `json:"deployedAt"`
 	UpdatedAt  time.Time `json:"updatedAt"`
 }
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Job status values.
+const (
+	// JobStatusPending indicates the job is waiting for a replica to claim it.
+	JobStatusPending = "pending"
+	// JobStatusRunning indicates a replica holds the job lease and is executing it.
+	JobStatusRunning = "running"
+	// JobStatusCompleted indicates the job finished successfully.
+	JobStatusCompleted = "completed"
+	// JobStatusFailed indicates the job finished with an error.
+	JobStatusFailed = "failed"
+	// JobStatusCancelled indicates the job was cancelled before it finished.
+	JobStatusCancelled = "cancelled"
+)
+
+// JobProgress tracks the progress of a job.
+type JobProgress struct {
+	Total     int `json:"total"`
+	Processed int `json:"processed"`
+	Updated   int `json:"updated"`
+	Skipped   int `json:"skipped"`
+	Failures  int `json:"failures"`
+}
+
+// JobResult contains the final outcome of a job.
+type JobResult struct {
+	ServersProcessed int    `json:"serversProcessed,omitempty"`
+	ServersUpdated   int    `json:"serversUpdated,omitempty"`
+	ServersSkipped   int    `json:"serversSkipped,omitempty"`
+	ServerFailures   int    `json:"serverFailures,omitempty"`
+	AgentsProcessed  int    `json:"agentsProcessed,omitempty"`
+	AgentsUpdated    int    `json:"agentsUpdated,omitempty"`
+	AgentsSkipped    int    `json:"agentsSkipped,omitempty"`
+	AgentFailures    int    `json:"agentFailures,omitempty"`
+	SkillsProcessed  int    `json:"skillsProcessed,omitempty"`
+	SkillsUpdated    int    `json:"skillsUpdated,omitempty"`
+	SkillsSkipped    int    `json:"skillsSkipped,omitempty"`
+	SkillFailures    int    `json:"skillFailures,omitempty"`
+	PromptsProcessed int    `json:"promptsProcessed,omitempty"`
+	PromptsUpdated   int    `json:"promptsUpdated,omitempty"`
+	PromptsSkipped   int    `json:"promptsSkipped,omitempty"`
+	PromptFailures   int    `json:"promptFailures,omitempty"`
+	ItemsExported    int    `json:"itemsExported,omitempty"`
+	OutputPath       string `json:"outputPath,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+// Job is a persisted background job. Replicas coordinate execution through a
+// lease: only the LeaseOwner may update a running job, and a job whose lease
+// expired can be claimed again by another replica.
+type Job struct {
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	Status          string      `json:"status"`
+	Params          JSONObject  `json:"params,omitempty"`
+	Progress        JobProgress `json:"progress"`
+	Result          *JobResult  `json:"result,omitempty"`
+	LeaseOwner      string      `json:"leaseOwner,omitempty"`
+	LeaseExpiresAt  *time.Time  `json:"leaseExpiresAt,omitempty"`
+	CancelRequested bool        `json:"cancelRequested,omitempty"`
+	Attempts        int         `json:"attempts"`
+	CreatedAt       time.Time   `json:"createdAt"`
+	UpdatedAt       time.Time   `json:"updatedAt"`
+	StartedAt       *time.Time  `json:"startedAt,omitempty"`
+	FinishedAt      *time.Time  `json:"finishedAt,omitempty"`
+	// Submitter is the stored principal that submitted the job; the job runs
+	// with its permissions. It is not exposed through the API.
+	Submitter json.RawMessage `json:"-"`
+}
+
+// IsTerminal returns true if the job is in a terminal state.
+func (j *Job) IsTerminal() bool {
+	return j.Status == JobStatusCompleted || j.Status == JobStatusFailed || j.Status == JobStatusCancelled
+}
+
+// JobFilter defines filtering options for job queries.
+type JobFilter struct {
+	Type   *string
+	Status *string
+	Limit  int
+}
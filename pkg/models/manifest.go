@@ -43,6 +43,8 @@ type PromptRef struct {
 	RegistryPromptName string `yaml:"registryPromptName,omitempty" json:"registryPromptName,omitempty"`
 	// RegistryPromptVersion is the version of the prompt to pull.
 	RegistryPromptVersion string `yaml:"registryPromptVersion,omitempty" json:"registryPromptVersion,omitempty"`
+	// Arguments are the values the prompt template is rendered with.
+	Arguments map[string]string `yaml:"arguments,omitempty" json:"arguments,omitempty"`
 }
 
 // McpServerType represents a single MCP server configuration.
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Mirror sync status values.
+const (
+	// MirrorSyncStatusCompleted indicates every matching upstream entry was applied.
+	MirrorSyncStatusCompleted = "completed"
+	// MirrorSyncStatusPartial indicates the sync finished but some entries failed.
+	MirrorSyncStatusPartial = "partial"
+	// MirrorSyncStatusFailed indicates the sync could not read the upstream registry.
+	MirrorSyncStatusFailed = "failed"
+)
+
+// DefaultMirrorIntervalSeconds is the sync interval used when a mirror does not set one.
+const DefaultMirrorIntervalSeconds = 3600
+
+// Mirror is an upstream registry whose servers are continuously copied into this registry.
+// Upstreams are the official MCP registry or another agentregistry; both serve /v0/servers.
+type Mirror struct {
+	ID              string   `json:"id"`
+	URL             string   `json:"url"`
+	Include         []string `json:"include,omitempty"`
+	Exclude         []string `json:"exclude,omitempty"`
+	IntervalSeconds int      `json:"intervalSeconds"`
+	Enabled         bool     `json:"enabled"`
+	// Watermark is the newest upstream updatedAt applied so far. The next sync
+	// only requests servers updated after it.
+	Watermark    *time.Time `json:"watermark,omitempty"`
+	LastSyncedAt *time.Time `json:"lastSyncedAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	// CreatedBy is the stored principal that created the mirror. Syncs run with
+	// its permissions.
+	CreatedBy json.RawMessage `json:"-"`
+}
+
+// CreateMirrorInput defines inputs for mirror creation. Include and exclude are
+// glob patterns (path.Match syntax) matched against server names.
+type CreateMirrorInput struct {
+	ID              string   `json:"id"`
+	URL             string   `json:"url"`
+	Include         []string `json:"include,omitempty"`
+	Exclude         []string `json:"exclude,omitempty"`
+	IntervalSeconds int      `json:"intervalSeconds,omitempty"`
+	Enabled         *bool    `json:"enabled,omitempty"`
+}
+
+// MirrorSync records the outcome of one sync run.
+type MirrorSync struct {
+	ID         int64      `json:"id"`
+	MirrorID   string     `json:"mirrorId"`
+	Status     string     `json:"status"`
+	Since      *time.Time `json:"since,omitempty"`
+	Watermark  *time.Time `json:"watermark,omitempty"`
+	Fetched    int        `json:"fetched"`
+	Created    int        `json:"created"`
+	Updated    int        `json:"updated"`
+	Skipped    int        `json:"skipped"`
+	Failed     int        `json:"failed"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt time.Time  `json:"finishedAt"`
+}
+
+// MirrorStatus combines a mirror's configuration with its recent sync history, newest first.
+type MirrorStatus struct {
+	Mirror  Mirror       `json:"mirror"`
+	History []MirrorSync `json:"history"`
+}
+
+// ServerMirrorMeta is the `_meta["aregistry.ai/mirror"]` payload recording where a
+// mirrored server version came from.
+type ServerMirrorMeta struct {
+	MirrorID          string     `json:"mirrorId"`
+	Source            string     `json:"source"`
+	UpstreamStatus    string     `json:"upstreamStatus,omitempty"`
+	UpstreamUpdatedAt *time.Time `json:"upstreamUpdatedAt,omitempty"`
+	SyncedAt          time.Time  `json:"syncedAt"`
+}
@@ -4,11 +4,35 @@ import "time"
 
 // PromptJSON is the stored JSONB payload for a prompt registry entry.
 // A prompt is a named, versioned text string (e.g. a system prompt for an agent).
+// The content is a template whose {{name}} placeholders are filled from the
+// declared arguments when the prompt is rendered.
 type PromptJSON struct {
+	Name        string           `json:"name" yaml:"name"`
+	Description string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string           `json:"version" yaml:"version"`
+	Content     string           `json:"content" yaml:"content"`
+	Arguments   []PromptArgument `json:"arguments,omitempty" yaml:"arguments,omitempty"`
+}
+
+// PromptArgument declares a variable of a prompt template.
+type PromptArgument struct {
 	Name        string `json:"name" yaml:"name"`
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
-	Version     string `json:"version" yaml:"version"`
-	Content     string `json:"content" yaml:"content"`
+	Type        string `json:"type,omitempty" yaml:"type,omitempty" enum:"string,number,integer,boolean" doc:"Type of the value, string when omitted"`
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	Default     string `json:"default,omitempty" yaml:"default,omitempty"`
+}
+
+// PromptRenderRequest carries the argument values to render a prompt with.
+type PromptRenderRequest struct {
+	Arguments map[string]string `json:"arguments,omitempty" required:"false" doc:"Argument values by name. Omitted arguments take their default."`
+}
+
+// PromptRenderResponse is a prompt rendered with argument values.
+type PromptRenderResponse struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Content string `json:"content"`
 }
 
 // PromptRegistryExtensions mirrors official metadata stored separately.
@@ -17,11 +41,18 @@ type PromptRegistryExtensions struct {
 	PublishedAt time.Time `json:"publishedAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 	IsLatest    bool      `json:"isLatest"`
+	Successor   string    `json:"successor,omitempty" doc:"Version that replaces this one, set when it is deprecated."`
+}
+
+// PromptSemanticMeta carries semantic search metadata for prompts.
+type PromptSemanticMeta struct {
+	Score float64 `json:"score"`
 }
 
 // PromptResponseMeta contains metadata about a prompt response.
 type PromptResponseMeta struct {
 	Official *PromptRegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty"`
+	Semantic *PromptSemanticMeta       `json:"aregistry.ai/semantic,omitempty"`
 }
 
 // PromptResponse wraps a PromptJSON with its registry metadata.
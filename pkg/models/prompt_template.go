@@ -0,0 +1,137 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Prompt argument types. An argument without a type is a string.
+const (
+	PromptArgumentTypeString  = "string"
+	PromptArgumentTypeNumber  = "number"
+	PromptArgumentTypeInteger = "integer"
+	PromptArgumentTypeBoolean = "boolean"
+)
+
+var (
+	promptArgumentName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+	promptPlaceholder  = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+)
+
+// Placeholders returns the argument names the prompt content refers to, in
+// order of first use.
+func (p *PromptJSON) Placeholders() []string {
+	var names []string
+	for _, match := range promptPlaceholder.FindAllStringSubmatch(p.Content, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// ValidateTemplate checks that the arguments are well formed and that every
+// placeholder in the content is a declared argument. Content of a prompt that
+// declares no arguments is not a template, so it may contain literal {{...}}
+// text, as Render leaves it unchanged.
+func (p *PromptJSON) ValidateTemplate() error {
+	declared := make(map[string]bool, len(p.Arguments))
+	for _, arg := range p.Arguments {
+		if !promptArgumentName.MatchString(arg.Name) {
+			return fmt.Errorf("invalid prompt argument name %q", arg.Name)
+		}
+		if declared[arg.Name] {
+			return fmt.Errorf("prompt argument %q is declared more than once", arg.Name)
+		}
+		declared[arg.Name] = true
+
+		switch arg.Type {
+		case "", PromptArgumentTypeString, PromptArgumentTypeNumber, PromptArgumentTypeInteger, PromptArgumentTypeBoolean:
+		default:
+			return fmt.Errorf("prompt argument %q has unknown type %q", arg.Name, arg.Type)
+		}
+		if arg.Default == "" {
+			continue
+		}
+		if arg.Required {
+			return fmt.Errorf("prompt argument %q is required and cannot have a default", arg.Name)
+		}
+		if err := arg.checkValue(arg.Default); err != nil {
+			return fmt.Errorf("default of %w", err)
+		}
+	}
+
+	if len(p.Arguments) == 0 {
+		return nil
+	}
+	var undeclared []string
+	for _, name := range p.Placeholders() {
+		if !declared[name] {
+			undeclared = append(undeclared, name)
+		}
+	}
+	if len(undeclared) > 0 {
+		return fmt.Errorf("prompt content uses undeclared arguments: %s", strings.Join(undeclared, ", "))
+	}
+	return nil
+}
+
+// Render fills the placeholders of the prompt content with values. Arguments
+// without a value take their default, and a required argument without a value
+// is an error. Placeholders that are not declared arguments are left as is, so
+// prompts published before arguments existed render unchanged.
+func (p *PromptJSON) Render(values map[string]string) (string, error) {
+	for name := range values {
+		if !slices.ContainsFunc(p.Arguments, func(arg PromptArgument) bool { return arg.Name == name }) {
+			return "", fmt.Errorf("unknown prompt argument %q", name)
+		}
+	}
+
+	resolved := make(map[string]string, len(p.Arguments))
+	var missing []string
+	for _, arg := range p.Arguments {
+		value, ok := values[arg.Name]
+		if !ok {
+			if arg.Required {
+				missing = append(missing, arg.Name)
+				continue
+			}
+			value = arg.Default
+		}
+		if ok || value != "" {
+			if err := arg.checkValue(value); err != nil {
+				return "", err
+			}
+		}
+		resolved[arg.Name] = value
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing required prompt arguments: %s", strings.Join(missing, ", "))
+	}
+
+	return promptPlaceholder.ReplaceAllStringFunc(p.Content, func(placeholder string) string {
+		if value, ok := resolved[promptPlaceholder.FindStringSubmatch(placeholder)[1]]; ok {
+			return value
+		}
+		return placeholder
+	}), nil
+}
+
+func (a PromptArgument) checkValue(value string) error {
+	var err error
+	switch a.Type {
+	case PromptArgumentTypeNumber:
+		_, err = strconv.ParseFloat(value, 64)
+	case PromptArgumentTypeInteger:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case PromptArgumentTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	}
+	if err != nil {
+		return fmt.Errorf("prompt argument %q must be of type %s, got %q", a.Name, a.Type, value)
+	}
+	return nil
+}
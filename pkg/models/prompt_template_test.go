@@ -0,0 +1,98 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name    string
+		prompt  PromptJSON
+		wantErr string
+	}{
+		{
+			name: "declared placeholders",
+			prompt: PromptJSON{
+				Content:   "Review {{ language }} code in {{repo}}.",
+				Arguments: []PromptArgument{{Name: "language", Required: true}, {Name: "repo", Default: "main"}},
+			},
+		},
+		{
+			name:   "no placeholders",
+			prompt: PromptJSON{Content: "You are a helpful assistant. {{ not a placeholder }}"},
+		},
+		{
+			name:    "undeclared placeholder",
+			prompt:  PromptJSON{Content: "Hello {{name}}, welcome to {{place}}", Arguments: []PromptArgument{{Name: "name"}}},
+			wantErr: "undeclared arguments: place",
+		},
+		{
+			name:   "no arguments allows literal braces",
+			prompt: PromptJSON{Content: "Write a Handlebars template such as {{title}}"},
+		},
+		{
+			name:    "duplicate argument",
+			prompt:  PromptJSON{Arguments: []PromptArgument{{Name: "a"}, {Name: "a"}}},
+			wantErr: "declared more than once",
+		},
+		{
+			name:    "unknown type",
+			prompt:  PromptJSON{Arguments: []PromptArgument{{Name: "a", Type: "date"}}},
+			wantErr: `unknown type "date"`,
+		},
+		{
+			name:    "default of the wrong type",
+			prompt:  PromptJSON{Arguments: []PromptArgument{{Name: "count", Type: PromptArgumentTypeInteger, Default: "many"}}},
+			wantErr: "must be of type integer",
+		},
+		{
+			name:    "required argument with default",
+			prompt:  PromptJSON{Arguments: []PromptArgument{{Name: "a", Required: true, Default: "x"}}},
+			wantErr: "cannot have a default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.prompt.ValidateTemplate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateTemplate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateTemplate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPromptRender(t *testing.T) {
+	prompt := PromptJSON{
+		Content: "Review {{language}} code, at most {{ max_files }} files. Strict: {{strict}}. {{legacy}}",
+		Arguments: []PromptArgument{
+			{Name: "language", Required: true},
+			{Name: "max_files", Type: PromptArgumentTypeInteger, Default: "10"},
+			{Name: "strict", Type: PromptArgumentTypeBoolean},
+		},
+	}
+
+	got, err := prompt.Render(map[string]string{"language": "Go", "strict": "true"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := "Review Go code, at most 10 files. Strict: true. {{legacy}}"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	if _, err := prompt.Render(nil); err == nil || !strings.Contains(err.Error(), "missing required prompt arguments: language") {
+		t.Errorf("Render(nil) error = %v, want missing language", err)
+	}
+	if _, err := prompt.Render(map[string]string{"language": "Go", "max_files": "ten"}); err == nil || !strings.Contains(err.Error(), "must be of type integer") {
+		t.Errorf("Render() error = %v, want type error", err)
+	}
+	if _, err := prompt.Render(map[string]string{"language": "Go", "tone": "polite"}); err == nil || !strings.Contains(err.Error(), `unknown prompt argument "tone"`) {
+		t.Errorf("Render() error = %v, want unknown argument", err)
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Artifact types returned by cross-artifact registry search.
+const (
+	SearchTypeServer = "server"
+	SearchTypeAgent  = "agent"
+	SearchTypeSkill  = "skill"
+	SearchTypePrompt = "prompt"
+)
+
+// SearchTypes lists every artifact type covered by registry search, in facet order.
+var SearchTypes = []string{SearchTypeServer, SearchTypeAgent, SearchTypeSkill, SearchTypePrompt}
+
+// SearchResult is a single ranked hit from a cross-artifact registry search.
+type SearchResult struct {
+	Type        string    `json:"type" doc:"Artifact type (server, agent, skill or prompt)"`
+	Name        string    `json:"name"`
+	Version     string    `json:"version"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Status      string    `json:"status"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	// Score is the combined rank of the result; higher is better.
+	Score float64 `json:"score"`
+	// SubstringScore is the trigram/substring similarity between the query and the artifact text (0-1).
+	SubstringScore float64 `json:"substringScore"`
+	// SemanticScore is the cosine distance to the query embedding; only set for semantic searches.
+	SemanticScore *float64 `json:"semanticScore,omitempty"`
+}
+
+// SearchMetadata contains pagination and facet info for search responses.
+type SearchMetadata struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+	Count      int    `json:"count"`
+	// Facets counts matches per artifact type, regardless of the requested type filter.
+	Facets map[string]int `json:"facets"`
+}
+
+// SearchResponse is the paginated response for cross-artifact registry search.
+type SearchResponse struct {
+	Results  []SearchResult `json:"results"`
+	Metadata SearchMetadata `json:"metadata"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Secret is the metadata of a stored secret. The value is write-only: it is
+// stored encrypted and only decrypted when a deployment referencing it is applied.
+type Secret struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// KeyID identifies the master key the secret's data key is wrapped with.
+	KeyID     string    `json:"keyId"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SecretInput defines inputs for creating or replacing a secret.
+type SecretInput struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
@@ -14,6 +14,7 @@ type ServerResponseMeta struct {
 	Official    *apiv0.RegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty"`
 	Semantic    *ServerSemanticMeta       `json:"aregistry.ai/semantic,omitempty"`
 	Deployments *ResourceDeploymentsMeta  `json:"aregistry.ai/deployments,omitempty"`
+	Mirror      *ServerMirrorMeta         `json:"aregistry.ai/mirror,omitempty"`
 }
 
 // ServerResponse is the server API shape with registry-managed metadata.
@@ -0,0 +1,15 @@
+package models
+
+// SignatureMetadataKey is the publisher-provided `_meta` key that carries the
+// signature of a server version, since server.json has no field for it.
+const SignatureMetadataKey = "aregistry.ai/signature"
+
+// ArtifactSignature is a publisher's signature over the artifacts referenced
+// by a server, agent or skill version. A key-based signature names a key the
+// registry trusts; a keyless signature carries a certificate that must chain
+// to a trusted root.
+type ArtifactSignature struct {
+	Signature   string `json:"signature" doc:"Base64-encoded signature over the version's signing payload."`
+	Certificate string `json:"certificate,omitempty" doc:"PEM-encoded signing certificate, followed by any intermediates, for keyless signatures."`
+	KeyID       string `json:"keyId,omitempty" doc:"ID of the trusted public key that made a key-based signature."`
+}
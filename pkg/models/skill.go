@@ -14,6 +14,7 @@ type SkillJSON struct {
 	Repository  *SkillRepository   `json:"repository,omitempty"`
 	Packages    []SkillPackageInfo `json:"packages,omitempty"`
 	Remotes     []SkillRemoteInfo  `json:"remotes,omitempty"`
+	Signature   *ArtifactSignature `json:"signature,omitempty" doc:"Publisher signature over the skill packages."`
 }
 
 type SkillRepository struct {
@@ -21,6 +22,11 @@ type SkillRepository struct {
 	Source string `json:"source"`
 }
 
+// SkillPackageTypeRegistry is the registryType of a skill package whose
+// bundle is hosted by the registry itself. Its identifier is the bundle
+// digest.
+const SkillPackageTypeRegistry = "registry"
+
 type SkillPackageInfo struct {
 	RegistryType string `json:"registryType"`
 	Identifier   string `json:"identifier"`
@@ -30,6 +36,14 @@ type SkillPackageInfo struct {
 	} `json:"transport"`
 }
 
+// SkillBundle describes a skill bundle stored by the registry.
+type SkillBundle struct {
+	Digest      string `json:"digest" doc:"Content address of the bundle" example:"sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"`
+	Name        string `json:"name" doc:"Skill name from the SKILL.md frontmatter"`
+	Description string `json:"description" doc:"Skill description from the SKILL.md frontmatter"`
+	SizeBytes   int    `json:"sizeBytes"`
+}
+
 type SkillRemoteInfo struct {
 	URL string `json:"url"`
 }
@@ -40,10 +54,16 @@ type SkillRegistryExtensions struct {
 	PublishedAt time.Time `json:"publishedAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 	IsLatest    bool      `json:"isLatest"`
+	Successor   string    `json:"successor,omitempty" doc:"Version that replaces this one, set when it is deprecated."`
+}
+
+type SkillSemanticMeta struct {
+	Score float64 `json:"score"`
 }
 
 type SkillResponseMeta struct {
 	Official *SkillRegistryExtensions `json:"io.modelcontextprotocol.registry/official,omitempty"`
+	Semantic *SkillSemanticMeta       `json:"aregistry.ai/semantic,omitempty"`
 }
 
 type SkillResponse struct {
@@ -0,0 +1,8 @@
+package models
+
+// StatusUpdate changes the lifecycle status of an agent, skill or prompt
+// version.
+type StatusUpdate struct {
+	Status    string `json:"status" enum:"active,deprecated,deleted" doc:"New status for the version"`
+	Successor string `json:"successor,omitempty" required:"false" doc:"Version that replaces this one. Only allowed when deprecating."`
+}
@@ -16,12 +16,16 @@ type Resource struct {
 }
 
 type User struct {
+	// Subject identifies the user to the auth method, e.g. a GitHub username or OIDC subject.
+	Subject     string
 	Permissions []Permission
 }
 
 // Authn
 type Principal struct {
 	User User
+	// AuthMethod is the method the session was authenticated with.
+	AuthMethod Method
 }
 
 type Session interface {
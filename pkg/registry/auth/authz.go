@@ -25,7 +25,27 @@ type AuthzProvider interface {
 	IsRegistryAdmin(ctx context.Context, s Session) bool
 }
 
-var _ AuthzProvider = &PublicAuthzProvider{}
+// ReadFilter describes the artifacts of one type a session may read, so list
+// queries can return only those rows instead of checking each one.
+type ReadFilter struct {
+	// Unrestricted is set when every artifact of the type may be read.
+	Unrestricted bool
+	// Patterns are the resource patterns ("name" or "prefix*") that may be read
+	// when the filter is restricted. No patterns means nothing may be read.
+	Patterns []string
+}
+
+// ReadFilterProvider is implemented by authz providers that restrict which
+// artifacts appear in list results. Lists are unfiltered for providers that
+// don't implement it.
+type ReadFilterProvider interface {
+	ReadFilter(ctx context.Context, s Session, artifactType PermissionArtifactType) ReadFilter
+}
+
+var (
+	_ AuthzProvider      = &PublicAuthzProvider{}
+	_ ReadFilterProvider = &PublicAuthzProvider{}
+)
 
 type Authorizer struct {
 	Authz AuthzProvider
@@ -47,6 +67,32 @@ func (a *Authorizer) IsRegistryAdmin(ctx context.Context) bool {
 	return a.Authz.IsRegistryAdmin(ctx, s)
 }
 
+// CheckRegistryAdmin returns nil when the session in ctx is a registry admin. It
+// guards registry-wide operations that per-artifact permissions don't cover.
+func (a *Authorizer) CheckRegistryAdmin(ctx context.Context) error {
+	if a.Authz == nil {
+		return nil
+	}
+	s, ok := AuthSessionFrom(ctx)
+	if a.Authz.IsRegistryAdmin(ctx, s) {
+		return nil
+	}
+	if !ok {
+		return ErrUnauthenticated
+	}
+	return ErrForbidden
+}
+
+// ReadFilter returns the artifacts of the given type the session in ctx may read.
+func (a *Authorizer) ReadFilter(ctx context.Context, artifactType PermissionArtifactType) ReadFilter {
+	provider, ok := a.Authz.(ReadFilterProvider)
+	if !ok {
+		return ReadFilter{Unrestricted: true}
+	}
+	s, _ := AuthSessionFrom(ctx)
+	return provider.ReadFilter(ctx, s, artifactType)
+}
+
 // PublicActions defines which actions are allowed without authentication (non-destructive actions).
 // NOTE: In the meantime, we'll allow all actions to be performed locally without authentication.
 // Once we implement better authN/authZ handling, we'll want to remove these, and just have read-only (above) actions as "public".
@@ -76,7 +122,8 @@ func (o *PublicAuthzProvider) Check(ctx context.Context, s Session, verb Permiss
 		return nil
 	}
 
-	if PublicActions[verb] {
+	// Secrets are never public, whatever the public actions allow.
+	if PublicActions[verb] && resource.Type != PermissionArtifactTypeSecret {
 		return nil
 	}
 
@@ -91,6 +138,21 @@ func (o *PublicAuthzProvider) Check(ctx context.Context, s Session, verb Permiss
 	return o.jwtManager.Check(ctx, s, verb, resource)
 }
 
+// ReadFilter returns the artifacts of the given type the session may read. Reads
+// are unrestricted while read is a public action.
+func (o *PublicAuthzProvider) ReadFilter(ctx context.Context, s Session, artifactType PermissionArtifactType) ReadFilter {
+	if o.IsRegistryAdmin(ctx, s) || PublicActions[PermissionActionRead] {
+		return ReadFilter{Unrestricted: true}
+	}
+	if s == nil {
+		return ReadFilter{}
+	}
+	if o.jwtManager == nil {
+		return ReadFilter{Unrestricted: true}
+	}
+	return o.jwtManager.ReadFilter(ctx, s, artifactType)
+}
+
 func (o *PublicAuthzProvider) IsRegistryAdmin(ctx context.Context, s Session) bool {
 	if s == nil {
 		return false
@@ -102,7 +164,7 @@ func (o *PublicAuthzProvider) IsRegistryAdmin(ctx context.Context, s Session) bo
 	}
 
 	for _, permission := range s.Principal().User.Permissions {
-		if permission.ResourcePattern == "*" {
+		if permission.ResourcePattern == "*" && permission.ResourceType == "" {
 			return true
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"time"
 
@@ -22,8 +23,19 @@ const (
 	PermissionArtifactTypeSkill  PermissionArtifactType = "skill"
 	PermissionArtifactTypeServer PermissionArtifactType = "server"
 	PermissionArtifactTypePrompt PermissionArtifactType = "prompt"
+	// PermissionArtifactTypeSecret covers deployment secrets. Only permissions
+	// typed "secret:" grant access to them.
+	PermissionArtifactTypeSecret PermissionArtifactType = "secret"
 )
 
+var permissionArtifactTypes = []PermissionArtifactType{
+	PermissionArtifactTypeAgent,
+	PermissionArtifactTypeSkill,
+	PermissionArtifactTypeServer,
+	PermissionArtifactTypePrompt,
+	PermissionArtifactTypeSecret,
+}
+
 // PermissionAction represents the type of action that can be performed
 type PermissionAction string
 
@@ -36,8 +48,30 @@ const (
 )
 
 type Permission struct {
-	Action          PermissionAction `json:"action"`   // The action type (e.g. publish, edit, delete, etc.)
-	ResourcePattern string           `json:"resource"` // e.g., "io.github.username/*"
+	Action          PermissionAction       `json:"action"`         // The action type (e.g. publish, edit, delete, etc.)
+	ResourcePattern string                 `json:"resource"`       // e.g., "io.github.username/*"
+	ResourceType    PermissionArtifactType `json:"type,omitempty"` // Restricts the permission to one artifact type; empty covers all types
+}
+
+// ParsePermission builds a permission from a configured pattern. A pattern may
+// be prefixed with an artifact type to restrict it to that type, e.g.
+// "server:io.github.acme/*"; unprefixed patterns cover all artifact types.
+func ParsePermission(action PermissionAction, pattern string) Permission {
+	perm := Permission{Action: action, ResourcePattern: pattern}
+	if prefix, rest, found := strings.Cut(pattern, ":"); found && slices.Contains(permissionArtifactTypes, PermissionArtifactType(prefix)) {
+		perm.ResourceType = PermissionArtifactType(prefix)
+		perm.ResourcePattern = rest
+	}
+	return perm
+}
+
+// covers reports whether the permission applies to artifacts of type t.
+// Untyped permissions cover every artifact type except secrets.
+func (p Permission) covers(t PermissionArtifactType) bool {
+	if t == PermissionArtifactTypeSecret {
+		return p.ResourceType == t
+	}
+	return p.ResourceType == "" || p.ResourceType == t
 }
 
 // JWTClaims represents the claims for the Registry JWT token
@@ -84,11 +118,11 @@ func NewJWTManager(cfg *config.Config) *JWTManager {
 }
 
 // GenerateToken generates a new Registry JWT token
-func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims) (*TokenResponse, error) {
+func (j *JWTManager) GenerateTokenResponse(ctx context.Context, claims JWTClaims) (*TokenResponse, error) {
 	// Check whether they have global permissions (used by admins)
 	hasGlobalPermissions := false
 	for _, perm := range claims.Permissions {
-		if perm.ResourcePattern == "*" {
+		if perm.ResourcePattern == "*" && perm.ResourceType == "" {
 			hasGlobalPermissions = true
 			break
 		}
@@ -96,9 +130,14 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 
 	// Check permissions against denylist, provided they are not an admin
 	if !hasGlobalPermissions {
+		session := &jwtSession{claims: &claims}
 		for _, blockedNamespace := range BlockedNamespaces {
-			if j.HasPermission(blockedNamespace+"/test", PermissionActionPublish, claims.Permissions) {
-				return nil, fmt.Errorf("your namespace is blocked. raise an issue at https://github.com/modelcontextprotocol/registry/ if you think this is a mistake")
+			// Typed permissions must not slip past the denylist either.
+			for _, artifactType := range permissionArtifactTypes {
+				resource := Resource{Name: blockedNamespace + "/test", Type: artifactType}
+				if j.Check(ctx, session, PermissionActionPublish, resource) == nil {
+					return nil, fmt.Errorf("your namespace is blocked. raise an issue at https://github.com/modelcontextprotocol/registry/ if you think this is a mistake")
+				}
 			}
 		}
 	}
@@ -132,11 +171,27 @@ func (j *JWTManager) GenerateTokenResponse(_ context.Context, claims JWTClaims)
 }
 
 func (j *JWTManager) Check(ctx context.Context, s Session, verb PermissionAction, resource Resource) error {
-	// TODO: also check resource.Type
-	if !j.HasPermission(resource.Name, verb, s.Principal().User.Permissions) {
-		return ErrForbidden
+	for _, perm := range s.Principal().User.Permissions {
+		if perm.Action == verb && perm.covers(resource.Type) && isResourceMatch(resource.Name, perm.ResourcePattern) {
+			return nil
+		}
 	}
-	return nil
+	return ErrForbidden
+}
+
+// ReadFilter returns the artifacts of the given type the session may read.
+func (j *JWTManager) ReadFilter(_ context.Context, s Session, artifactType PermissionArtifactType) ReadFilter {
+	var patterns []string
+	for _, perm := range s.Principal().User.Permissions {
+		if perm.Action != PermissionActionRead || !perm.covers(artifactType) {
+			continue
+		}
+		if perm.ResourcePattern == "*" {
+			return ReadFilter{Unrestricted: true}
+		}
+		patterns = append(patterns, perm.ResourcePattern)
+	}
+	return ReadFilter{Patterns: patterns}
 }
 
 type jwtSession struct {
@@ -146,8 +201,10 @@ type jwtSession struct {
 func (s *jwtSession) Principal() Principal {
 	return Principal{
 		User: User{
+			Subject:     s.claims.AuthMethodSubject,
 			Permissions: s.claims.Permissions,
 		},
+		AuthMethod: s.claims.AuthMethod,
 	}
 }
 func (j *JWTManager) Authenticate(ctx context.Context, reqHeaders func(name string) string, query url.Values) (Session, error) {
@@ -194,15 +251,6 @@ func (j *JWTManager) ValidateToken(_ context.Context, tokenString string) (*JWTC
 	return claims, nil
 }
 
-func (j *JWTManager) HasPermission(resource string, action PermissionAction, permissions []Permission) bool {
-	for _, perm := range permissions {
-		if perm.Action == action && isResourceMatch(resource, perm.ResourcePattern) {
-			return true
-		}
-	}
-	return false
-}
-
 func isResourceMatch(resource, pattern string) bool {
 	if pattern == "*" {
 		return true
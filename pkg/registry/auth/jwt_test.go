@@ -180,7 +180,7 @@ func TestJWTManager_GenerateAndVerifyToken(t *testing.T) {
 	})
 }
 
-func TestJWTManager_HasPermission(t *testing.T) {
+func TestJWTManager_Check(t *testing.T) {
 	// Generate a proper Ed25519 seed for testing
 	testSeed := make([]byte, ed25519.SeedSize)
 	_, err := rand.Read(testSeed)
@@ -266,8 +266,8 @@ func TestJWTManager_HasPermission(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := jwtManager.HasPermission(tt.resource, tt.action, tt.permissions)
-			assert.Equal(t, tt.expected, result)
+			err := jwtManager.Check(context.Background(), &permissionSession{permissions: tt.permissions}, tt.action, auth.Resource{Name: tt.resource})
+			assert.Equal(t, tt.expected, err == nil)
 		})
 	}
 }
@@ -373,6 +373,26 @@ func TestJWTManager_BlockedNamespaces(t *testing.T) {
 		assert.Nil(t, tokenResponse)
 	})
 
+	t.Run("typed permission in blocked namespace should deny token", func(t *testing.T) {
+		originalBlocked := auth.BlockedNamespaces
+		auth.BlockedNamespaces = []string{"io.github.spammer"}
+		defer func() { auth.BlockedNamespaces = originalBlocked }()
+
+		jwtManager := auth.NewJWTManager(cfg)
+
+		claims := auth.JWTClaims{
+			AuthMethod:        auth.MethodGitHubAT,
+			AuthMethodSubject: "spammer",
+			Permissions: []auth.Permission{
+				auth.ParsePermission(auth.PermissionActionPublish, "agent:io.github.spammer/*"),
+			},
+		}
+
+		_, err := jwtManager.GenerateTokenResponse(ctx, claims)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "your namespace is blocked")
+	})
+
 	t.Run("global admin permissions should bypass denylist", func(t *testing.T) {
 		// Temporarily override blocked namespaces for testing
 		originalBlocked := auth.BlockedNamespaces
@@ -397,3 +417,159 @@ func TestJWTManager_BlockedNamespaces(t *testing.T) {
 		assert.NotEmpty(t, tokenResponse.RegistryToken)
 	})
 }
+
+type permissionSession struct {
+	permissions []auth.Permission
+}
+
+func (s *permissionSession) Principal() auth.Principal {
+	return auth.Principal{User: auth.User{Permissions: s.permissions}}
+}
+
+func newTestJWTManager(t *testing.T) *auth.JWTManager {
+	t.Helper()
+	testSeed := make([]byte, ed25519.SeedSize)
+	_, err := rand.Read(testSeed)
+	require.NoError(t, err)
+	return auth.NewJWTManager(&config.Config{JWTPrivateKey: hex.EncodeToString(testSeed)})
+}
+
+func TestJWTManager_CheckResourceType(t *testing.T) {
+	jwtManager := newTestJWTManager(t)
+	ctx := context.Background()
+	session := &permissionSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "acme/*", ResourceType: auth.PermissionArtifactTypeServer},
+		{Action: auth.PermissionActionEdit, ResourcePattern: "acme/*"},
+	}}
+
+	server := auth.Resource{Name: "acme/weather", Type: auth.PermissionArtifactTypeServer}
+	agent := auth.Resource{Name: "acme/weather", Type: auth.PermissionArtifactTypeAgent}
+
+	require.NoError(t, jwtManager.Check(ctx, session, auth.PermissionActionPublish, server))
+	assert.ErrorIs(t, jwtManager.Check(ctx, session, auth.PermissionActionPublish, agent), auth.ErrForbidden)
+	// Untyped permissions cover every artifact type.
+	require.NoError(t, jwtManager.Check(ctx, session, auth.PermissionActionEdit, server))
+	require.NoError(t, jwtManager.Check(ctx, session, auth.PermissionActionEdit, agent))
+}
+
+func TestJWTManager_ReadFilter(t *testing.T) {
+	jwtManager := newTestJWTManager(t)
+	ctx := context.Background()
+	session := &permissionSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "acme/*", ResourceType: auth.PermissionArtifactTypeServer},
+		{Action: auth.PermissionActionRead, ResourcePattern: "shared/tool"},
+		{Action: auth.PermissionActionRead, ResourcePattern: "*", ResourceType: auth.PermissionArtifactTypePrompt},
+		{Action: auth.PermissionActionPublish, ResourcePattern: "other/*"},
+	}}
+
+	assert.Equal(t, auth.ReadFilter{Patterns: []string{"acme/*", "shared/tool"}},
+		jwtManager.ReadFilter(ctx, session, auth.PermissionArtifactTypeServer))
+	assert.Equal(t, auth.ReadFilter{Patterns: []string{"shared/tool"}},
+		jwtManager.ReadFilter(ctx, session, auth.PermissionArtifactTypeAgent))
+	assert.Equal(t, auth.ReadFilter{Unrestricted: true},
+		jwtManager.ReadFilter(ctx, session, auth.PermissionArtifactTypePrompt))
+}
+
+func TestPublicAuthzProvider_ReadFilter(t *testing.T) {
+	provider := auth.NewPublicAuthzProvider(newTestJWTManager(t))
+	ctx := context.Background()
+	session := &permissionSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "acme/*"},
+	}}
+
+	// Reads are public by default, so lists are not filtered.
+	assert.True(t, provider.ReadFilter(ctx, session, auth.PermissionArtifactTypeServer).Unrestricted)
+
+	publicRead := auth.PublicActions[auth.PermissionActionRead]
+	auth.PublicActions[auth.PermissionActionRead] = false
+	t.Cleanup(func() { auth.PublicActions[auth.PermissionActionRead] = publicRead })
+
+	assert.Equal(t, auth.ReadFilter{Patterns: []string{"acme/*"}}, provider.ReadFilter(ctx, session, auth.PermissionArtifactTypeServer))
+	assert.Equal(t, auth.ReadFilter{}, provider.ReadFilter(ctx, nil, auth.PermissionArtifactTypeServer))
+	assert.True(t, provider.ReadFilter(ctx, &auth.SystemSession{}, auth.PermissionArtifactTypeServer).Unrestricted)
+
+	// A typed global permission is not registry admin.
+	typedGlobal := &permissionSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionEdit, ResourcePattern: "*", ResourceType: auth.PermissionArtifactTypeSkill},
+	}}
+	assert.False(t, provider.IsRegistryAdmin(ctx, typedGlobal))
+}
+
+func TestParsePermission(t *testing.T) {
+	assert.Equal(t,
+		auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "io.github.acme/*", ResourceType: auth.PermissionArtifactTypeServer},
+		auth.ParsePermission(auth.PermissionActionRead, "server:io.github.acme/*"))
+	assert.Equal(t,
+		auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "io.github.acme/*"},
+		auth.ParsePermission(auth.PermissionActionRead, "io.github.acme/*"))
+	// Unknown prefixes are part of the pattern.
+	assert.Equal(t,
+		auth.Permission{Action: auth.PermissionActionRead, ResourcePattern: "widget:acme"},
+		auth.ParsePermission(auth.PermissionActionRead, "widget:acme"))
+}
+
+func TestStoredPrincipal(t *testing.T) {
+	ctx := context.Background()
+	session := &permissionSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "acme/*", ResourceType: auth.PermissionArtifactTypeServer},
+	}}
+
+	data, err := auth.MarshalPrincipal(auth.AuthSessionTo(ctx, session))
+	require.NoError(t, err)
+	restored, err := auth.WithStoredPrincipal(ctx, data)
+	require.NoError(t, err)
+	s, ok := auth.AuthSessionFrom(restored)
+	require.True(t, ok)
+	assert.Equal(t, session.Principal().User.Permissions, s.Principal().User.Permissions)
+
+	data, err = auth.MarshalPrincipal(auth.WithSystemContext(ctx))
+	require.NoError(t, err)
+	restored, err = auth.WithStoredPrincipal(ctx, data)
+	require.NoError(t, err)
+	s, _ = auth.AuthSessionFrom(restored)
+	assert.True(t, auth.IsSystemSession(s))
+
+	// Anonymous principals restore without a session, even over one in ctx.
+	data, err = auth.MarshalPrincipal(ctx)
+	require.NoError(t, err)
+	restored, err = auth.WithStoredPrincipal(auth.WithSystemContext(ctx), data)
+	require.NoError(t, err)
+	_, ok = auth.AuthSessionFrom(restored)
+	assert.False(t, ok)
+}
+
+func TestAuthorizer_CheckRegistryAdmin(t *testing.T) {
+	authz := &auth.Authorizer{Authz: auth.NewPublicAuthzProvider(newTestJWTManager(t))}
+	ctx := context.Background()
+
+	assert.ErrorIs(t, authz.CheckRegistryAdmin(ctx), auth.ErrUnauthenticated)
+	assert.ErrorIs(t, authz.CheckRegistryAdmin(auth.AuthSessionTo(ctx, &permissionSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "acme/*"},
+	}})), auth.ErrForbidden)
+	assert.NoError(t, authz.CheckRegistryAdmin(auth.AuthSessionTo(ctx, &permissionSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionPublish, ResourcePattern: "*"},
+	}})))
+	assert.NoError(t, authz.CheckRegistryAdmin(auth.WithSystemContext(ctx)))
+}
+
+func TestPublicAuthzProvider_CheckSecret(t *testing.T) {
+	provider := auth.NewPublicAuthzProvider(newTestJWTManager(t))
+	ctx := context.Background()
+	secret := auth.Resource{Name: "acme-db-password", Type: auth.PermissionArtifactTypeSecret}
+
+	// Public actions do not extend to secrets.
+	assert.ErrorIs(t, provider.Check(ctx, nil, auth.PermissionActionRead, secret), auth.ErrUnauthenticated)
+
+	// Untyped permissions do not cover secrets; typed ones do.
+	untyped := &permissionSession{permissions: []auth.Permission{
+		{Action: auth.PermissionActionRead, ResourcePattern: "acme-*"},
+	}}
+	assert.ErrorIs(t, provider.Check(ctx, untyped, auth.PermissionActionRead, secret), auth.ErrForbidden)
+	typed := &permissionSession{permissions: []auth.Permission{
+		auth.ParsePermission(auth.PermissionActionRead, "secret:acme-*"),
+	}}
+	require.NoError(t, provider.Check(ctx, typed, auth.PermissionActionRead, secret))
+	assert.ErrorIs(t, provider.Check(ctx, typed, auth.PermissionActionDelete, secret), auth.ErrForbidden)
+
+	require.NoError(t, provider.Check(ctx, &auth.SystemSession{}, auth.PermissionActionDelete, secret))
+}
@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// storedPrincipal is the persisted form of a session, kept with background work
+// (jobs, mirrors) so that the work runs later, on any replica, with the
+// permissions of the principal that requested it instead of as the system.
+type storedPrincipal struct {
+	System      bool         `json:"system,omitempty"`
+	Subject     string       `json:"subject,omitempty"`
+	AuthMethod  Method       `json:"authMethod,omitempty"`
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// storedSession is a session restored from a storedPrincipal.
+type storedSession struct {
+	principal Principal
+}
+
+func (s *storedSession) Principal() Principal {
+	return s.principal
+}
+
+// MarshalPrincipal returns the persisted form of the session in ctx. Contexts
+// without a session are stored as anonymous.
+func MarshalPrincipal(ctx context.Context) (json.RawMessage, error) {
+	var stored storedPrincipal
+	if s, ok := AuthSessionFrom(ctx); ok {
+		if IsSystemSession(s) {
+			stored.System = true
+		} else {
+			p := s.Principal()
+			stored.Subject = p.User.Subject
+			stored.AuthMethod = p.AuthMethod
+			stored.Permissions = p.User.Permissions
+		}
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal principal: %w", err)
+	}
+	return data, nil
+}
+
+// WithStoredPrincipal returns a context acting as the principal persisted by
+// MarshalPrincipal. Empty data acts as anonymous.
+func WithStoredPrincipal(ctx context.Context, data json.RawMessage) (context.Context, error) {
+	if len(data) == 0 {
+		return AuthSessionTo(ctx, nil), nil
+	}
+	var stored storedPrincipal
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal principal: %w", err)
+	}
+	switch {
+	case stored.System:
+		return WithSystemContext(ctx), nil
+	case stored.Subject == "" && stored.AuthMethod == "" && len(stored.Permissions) == 0:
+		return AuthSessionTo(ctx, nil), nil
+	}
+	return AuthSessionTo(ctx, &storedSession{principal: Principal{
+		User:       User{Subject: stored.Subject, Permissions: stored.Permissions},
+		AuthMethod: stored.AuthMethod,
+	}}), nil
+}
@@ -18,6 +18,8 @@ var (
 	ErrInvalidInput       = errors.New("invalid input")
 	ErrDatabase           = errors.New("database error")
 	ErrInvalidVersion     = errors.New("invalid version: cannot publish duplicate version")
+	ErrInUse              = errors.New("record is in use")
+	ErrConflict           = errors.New("record was changed concurrently")
 	ErrMaxVersionsReached = errors.New("maximum number of versions reached (10000): please reach out at https://github.com/modelcontextprotocol/registry to explain your use case")
 )
 
@@ -43,6 +45,16 @@ type ServerReadme struct {
 	FetchedAt   time.Time
 }
 
+// SkillBundle represents a stored skill bundle, addressed by the sha256 digest
+// of its content
+type SkillBundle struct {
+	Digest    string
+	SkillName string
+	Content   []byte
+	SizeBytes int
+	CreatedAt time.Time
+}
+
 // SkillFilter defines filtering options for skill queries (mirrors ServerFilter)
 type SkillFilter struct {
 	Name          *string    // for finding versions of same skill
@@ -72,6 +84,16 @@ type PromptFilter struct {
 	SubstringName *string    // for substring search on name
 	Version       *string    // for exact version matching
 	IsLatest      *bool      // for filtering latest versions only
+	Semantic      *SemanticSearchOptions
+}
+
+// SearchFilter defines options for cross-artifact registry search
+type SearchFilter struct {
+	Query          string   // matched against name, title and description by substring and trigram similarity
+	Types          []string // restrict results to these artifact types (models.SearchType*); empty means all
+	AllVersions    bool     // include non-latest versions
+	IncludeDeleted bool     // include versions with status deleted, which are hidden by default
+	Semantic       *SemanticSearchOptions
 }
 
 // SemanticEmbedding captures data stored alongside registry resources for semantic search.
@@ -106,6 +128,14 @@ type SemanticSearchOptions struct {
 	HybridSubstring *string
 }
 
+// EncryptedSecret is a secret as stored: the value sealed with a data key, and
+// the data key wrapped with the master key identified by KeyID.
+type EncryptedSecret struct {
+	models.Secret
+	Ciphertext []byte
+	WrappedKey []byte
+}
+
 // Database defines the interface for database operations
 type Database interface {
 	// DeleteServer permanently removes a server version from the database
@@ -155,8 +185,8 @@ type Database interface {
 	CreateAgent(ctx context.Context, tx pgx.Tx, agentJSON *models.AgentJSON, officialMeta *models.AgentRegistryExtensions) (*models.AgentResponse, error)
 	// UpdateAgent updates an existing agent record
 	UpdateAgent(ctx context.Context, tx pgx.Tx, agentName, version string, agentJSON *models.AgentJSON) (*models.AgentResponse, error)
-	// SetAgentStatus updates the status of a specific agent version
-	SetAgentStatus(ctx context.Context, tx pgx.Tx, agentName, version string, status string) (*models.AgentResponse, error)
+	// SetAgentStatus updates the status of a specific agent version and replaces its successor
+	SetAgentStatus(ctx context.Context, tx pgx.Tx, agentName, version, status, successor string) (*models.AgentResponse, error)
 	// ListAgents retrieve agent entries with optional filtering
 	ListAgents(ctx context.Context, tx pgx.Tx, filter *AgentFilter, cursor string, limit int) ([]*models.AgentResponse, string, error)
 	// GetAgentByName retrieve a single agent by its name (latest)
@@ -185,8 +215,8 @@ type Database interface {
 	CreateSkill(ctx context.Context, tx pgx.Tx, skillJSON *models.SkillJSON, officialMeta *models.SkillRegistryExtensions) (*models.SkillResponse, error)
 	// UpdateSkill updates an existing skill record
 	UpdateSkill(ctx context.Context, tx pgx.Tx, skillName, version string, skillJSON *models.SkillJSON) (*models.SkillResponse, error)
-	// SetSkillStatus updates the status of a specific skill version
-	SetSkillStatus(ctx context.Context, tx pgx.Tx, skillName, version string, status string) (*models.SkillResponse, error)
+	// SetSkillStatus updates the status of a specific skill version and replaces its successor
+	SetSkillStatus(ctx context.Context, tx pgx.Tx, skillName, version, status, successor string) (*models.SkillResponse, error)
 	// ListSkills retrieve skill entries with optional filtering
 	ListSkills(ctx context.Context, tx pgx.Tx, filter *SkillFilter, cursor string, limit int) ([]*models.SkillResponse, string, error)
 	// GetSkillByName retrieve a single skill by its name (latest)
@@ -205,10 +235,27 @@ type Database interface {
 	UnmarkSkillAsLatest(ctx context.Context, tx pgx.Tx, skillName string) error
 	// DeleteSkill permanently removes a skill version from the database
 	DeleteSkill(ctx context.Context, tx pgx.Tx, skillName, version string) error
+	// CreateSkillBundle stores a skill bundle unless one with the same digest exists
+	CreateSkillBundle(ctx context.Context, tx pgx.Tx, bundle *SkillBundle) error
+	// GetSkillBundle retrieves a skill bundle by digest
+	GetSkillBundle(ctx context.Context, tx pgx.Tx, digest string) (*SkillBundle, error)
+	// DeleteUnreferencedSkillBundles deletes the bundles of a skill created before the given
+	// time that no skill version references, and returns their digests
+	DeleteUnreferencedSkillBundles(ctx context.Context, tx pgx.Tx, skillName string, createdBefore time.Time) ([]string, error)
+	// CountUnreferencedSkillBundles counts the bundles of a skill that no skill version references
+	CountUnreferencedSkillBundles(ctx context.Context, tx pgx.Tx, skillName string) (int, error)
+	// SetSkillEmbedding upserts the semantic embedding metadata for a skill version
+	SetSkillEmbedding(ctx context.Context, tx pgx.Tx, skillName, version string, embedding *SemanticEmbedding) error
+	// GetSkillEmbeddingMetadata returns metadata about a skill's embedding without loading the vector
+	GetSkillEmbeddingMetadata(ctx context.Context, tx pgx.Tx, skillName, version string) (*SemanticEmbeddingMetadata, error)
 
 	// Prompts API
 	// CreatePrompt inserts a new prompt version with official metadata
 	CreatePrompt(ctx context.Context, tx pgx.Tx, promptJSON *models.PromptJSON, officialMeta *models.PromptRegistryExtensions) (*models.PromptResponse, error)
+	// UpdatePrompt updates an existing prompt record
+	UpdatePrompt(ctx context.Context, tx pgx.Tx, promptName, version string, promptJSON *models.PromptJSON) (*models.PromptResponse, error)
+	// SetPromptStatus updates the status of a specific prompt version and replaces its successor
+	SetPromptStatus(ctx context.Context, tx pgx.Tx, promptName, version, status, successor string) (*models.PromptResponse, error)
 	// ListPrompts retrieve prompt entries with optional filtering
 	ListPrompts(ctx context.Context, tx pgx.Tx, filter *PromptFilter, cursor string, limit int) ([]*models.PromptResponse, string, error)
 	// GetPromptByName retrieve a single prompt by its name (latest)
@@ -227,6 +274,16 @@ type Database interface {
 	UnmarkPromptAsLatest(ctx context.Context, tx pgx.Tx, promptName string) error
 	// DeletePrompt permanently removes a prompt version from the database
 	DeletePrompt(ctx context.Context, tx pgx.Tx, promptName, version string) error
+	// SetPromptEmbedding upserts the semantic embedding metadata for a prompt version
+	SetPromptEmbedding(ctx context.Context, tx pgx.Tx, promptName, version string, embedding *SemanticEmbedding) error
+	// GetPromptEmbeddingMetadata returns metadata about a prompt's embedding without loading the vector
+	GetPromptEmbeddingMetadata(ctx context.Context, tx pgx.Tx, promptName, version string) (*SemanticEmbeddingMetadata, error)
+
+	// Search API
+	// SearchArtifacts ranks servers, agents, skills and prompts matching the filter in a single result set
+	SearchArtifacts(ctx context.Context, tx pgx.Tx, filter *SearchFilter, cursor string, limit int) ([]*models.SearchResult, string, error)
+	// CountSearchArtifacts returns the number of matches per artifact type, ignoring filter.Types
+	CountSearchArtifacts(ctx context.Context, tx pgx.Tx, filter *SearchFilter) (map[string]int, error)
 
 	// Deployments API
 	// CreateProvider creates a new provider record.
@@ -250,6 +307,77 @@ type Database interface {
 	UpdateDeploymentState(ctx context.Context, tx pgx.Tx, id string, patch *models.DeploymentStatePatch) error
 	// RemoveDeploymentByID removes a deployment by ID.
 	RemoveDeploymentByID(ctx context.Context, tx pgx.Tx, id string) error
+	// CheckDeployPermission returns nil when the caller may deploy the artifact
+	// of the deployment with the given ID.
+	CheckDeployPermission(ctx context.Context, tx pgx.Tx, id string) error
+	// AppendDeploymentRevision records the next revision of a deployment and sets
+	// its number and timestamp. Revisions are never changed or removed.
+	AppendDeploymentRevision(ctx context.Context, tx pgx.Tx, revision *models.DeploymentRevision) error
+	// ListDeploymentRevisions lists a deployment's revisions, oldest first. They
+	// remain available after the deployment is removed.
+	ListDeploymentRevisions(ctx context.Context, tx pgx.Tx, deploymentID string) ([]*models.DeploymentRevision, error)
+	// GetDeploymentRevision retrieves one revision of a deployment.
+	GetDeploymentRevision(ctx context.Context, tx pgx.Tx, deploymentID string, revision int) (*models.DeploymentRevision, error)
+
+	// Jobs API
+	// CreateJob inserts a new job. When exclusive is true it returns ErrAlreadyExists
+	// if another job of the same type is pending or running.
+	CreateJob(ctx context.Context, tx pgx.Tx, job *models.Job, exclusive bool) error
+	// GetJob retrieves a job by ID.
+	GetJob(ctx context.Context, tx pgx.Tx, id string) (*models.Job, error)
+	// ListJobs lists jobs, newest first.
+	ListJobs(ctx context.Context, tx pgx.Tx, filter *models.JobFilter) ([]*models.Job, error)
+	// ClaimJob acquires the lease on a pending job or a job whose lease expired.
+	ClaimJob(ctx context.Context, tx pgx.Tx, id, owner string, leaseUntil time.Time) (*models.Job, error)
+	// ClaimNextJob acquires the lease on the oldest claimable job of the given types.
+	ClaimNextJob(ctx context.Context, tx pgx.Tx, jobTypes []string, owner string, leaseUntil time.Time) (*models.Job, error)
+	// RenewJobLease extends a held lease and reports whether cancellation was requested.
+	RenewJobLease(ctx context.Context, tx pgx.Tx, id, owner string, leaseUntil time.Time) (bool, error)
+	// UpdateJobProgress stores progress for a running job held by owner.
+	UpdateJobProgress(ctx context.Context, tx pgx.Tx, id, owner string, progress models.JobProgress) error
+	// FinishJob moves a running job held by owner to a terminal status.
+	FinishJob(ctx context.Context, tx pgx.Tx, id, owner, status string, result *models.JobResult) error
+	// ReleaseJob gives up the lease owner holds on a running job and returns it
+	// to pending, or cancels it if cancellation was requested meanwhile.
+	ReleaseJob(ctx context.Context, tx pgx.Tx, id, owner string) error
+	// RequestJobCancel cancels a pending job or flags a running job for cancellation.
+	RequestJobCancel(ctx context.Context, tx pgx.Tx, id string) (*models.Job, error)
+	// DeleteJobsFinishedBefore removes terminal jobs that finished before cutoff.
+	DeleteJobsFinishedBefore(ctx context.Context, tx pgx.Tx, cutoff time.Time) (int64, error)
+
+	// Mirrors API
+	// CreateMirror registers an upstream registry to mirror.
+	CreateMirror(ctx context.Context, tx pgx.Tx, in *models.CreateMirrorInput) (*models.Mirror, error)
+	// ListMirrors lists configured mirrors.
+	ListMirrors(ctx context.Context, tx pgx.Tx) ([]*models.Mirror, error)
+	// GetMirrorByID returns a mirror by ID.
+	GetMirrorByID(ctx context.Context, tx pgx.Tx, mirrorID string) (*models.Mirror, error)
+	// DeleteMirror removes a mirror and its sync history. Mirrored servers are kept.
+	DeleteMirror(ctx context.Context, tx pgx.Tx, mirrorID string) error
+	// RecordMirrorSync stores a finished sync and advances the mirror's watermark
+	// when sync.Watermark is set.
+	RecordMirrorSync(ctx context.Context, tx pgx.Tx, sync *models.MirrorSync) error
+	// ListMirrorSyncs lists the most recent syncs of a mirror, newest first.
+	ListMirrorSyncs(ctx context.Context, tx pgx.Tx, mirrorID string, limit int) ([]*models.MirrorSync, error)
+
+	// Secrets API
+	// CreateSecret stores a new encrypted secret.
+	CreateSecret(ctx context.Context, tx pgx.Tx, secret *EncryptedSecret) (*models.Secret, error)
+	// UpdateSecret replaces the value and description of an existing secret.
+	UpdateSecret(ctx context.Context, tx pgx.Tx, secret *EncryptedSecret) (*models.Secret, error)
+	// GetSecret returns a secret with its encrypted value.
+	GetSecret(ctx context.Context, tx pgx.Tx, name string) (*EncryptedSecret, error)
+	// ListSecrets lists secret metadata ordered by name.
+	ListSecrets(ctx context.Context, tx pgx.Tx) ([]*models.Secret, error)
+	// DeleteSecret removes a secret. It returns ErrInUse while a deployment references it.
+	DeleteSecret(ctx context.Context, tx pgx.Tx, name string) error
+
+	// Audit API
+	// AppendAuditEntry appends an entry to the audit log. Pass the transaction of
+	// the change being audited so the entry is only kept if the change commits.
+	AppendAuditEntry(ctx context.Context, tx pgx.Tx, entry *models.AuditEntry) error
+	// ListAuditEntries lists audit entries matching filter, newest first, with cursor-based pagination.
+	ListAuditEntries(ctx context.Context, tx pgx.Tx, filter *models.AuditFilter, cursor string, limit int) ([]*models.AuditEntry, string, error)
 }
 
 // InTransactionT is a generic helper that wraps InTransaction for functions returning a value
@@ -0,0 +1,451 @@
+// Package signing signs and verifies the artifacts referenced by registry
+// entries. A signature covers a canonical payload listing the name and version
+// of a server, agent or skill version and the artifacts (images, packages,
+// skill bundles) it points at, so a verified signature ties those artifacts to
+// the publisher that holds the signing key.
+//
+// Signatures are either key-based, naming one of the registry's trusted public
+// keys, or keyless in the Sigstore style, carrying a short-lived certificate
+// that must chain to a trusted root. Verification only uses local trust
+// material and works offline.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+)
+
+// Kinds of registry entries a payload can describe.
+const (
+	KindServer = "server"
+	KindAgent  = "agent"
+	KindSkill  = "skill"
+)
+
+var (
+	// ErrNotConfigured is returned when no trusted keys or roots are configured.
+	ErrNotConfigured = errors.New("signing trust policy is not configured")
+	// ErrUnsigned is returned when an entry carries no signature.
+	ErrUnsigned = errors.New("artifact is not signed")
+)
+
+// Artifact is one artifact covered by a signature.
+type Artifact struct {
+	RegistryType string `json:"registryType"`
+	Identifier   string `json:"identifier"`
+	Version      string `json:"version,omitempty"`
+	Digest       string `json:"digest,omitempty"`
+}
+
+// Payload is the statement a publisher signs.
+type Payload struct {
+	Kind      string     `json:"kind"`
+	Name      string     `json:"name"`
+	Version   string     `json:"version"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// Bytes returns the canonical encoding of the payload that signatures are made
+// over. Artifacts are sorted so their order in the entry does not matter.
+func (p Payload) Bytes() []byte {
+	artifacts := slices.Clone(p.Artifacts)
+	if artifacts == nil {
+		artifacts = []Artifact{}
+	}
+	slices.SortFunc(artifacts, func(a, b Artifact) int {
+		return strings.Compare(a.RegistryType+"\x00"+a.Identifier+"\x00"+a.Version+"\x00"+a.Digest,
+			b.RegistryType+"\x00"+b.Identifier+"\x00"+b.Version+"\x00"+b.Digest)
+	})
+	p.Artifacts = artifacts
+	// Marshalling a struct of strings cannot fail.
+	data, _ := json.Marshal(p)
+	return data
+}
+
+// ServerPayload returns the payload of a server version: its packages.
+func ServerPayload(server *apiv0.ServerJSON) Payload {
+	p := Payload{Kind: KindServer, Name: server.Name, Version: server.Version}
+	for _, pkg := range server.Packages {
+		artifact := Artifact{RegistryType: pkg.RegistryType, Identifier: pkg.Identifier, Version: pkg.Version}
+		if pkg.FileSHA256 != "" {
+			artifact.Digest = "sha256:" + pkg.FileSHA256
+		}
+		p.Artifacts = append(p.Artifacts, artifact)
+	}
+	return p
+}
+
+// AgentPayload returns the payload of an agent version: its image and packages.
+func AgentPayload(agent *models.AgentJSON) Payload {
+	p := Payload{Kind: KindAgent, Name: agent.Name, Version: agent.Version}
+	if agent.Image != "" {
+		p.Artifacts = append(p.Artifacts, Artifact{RegistryType: "oci", Identifier: agent.Image})
+	}
+	for _, pkg := range agent.Packages {
+		p.Artifacts = append(p.Artifacts, Artifact{RegistryType: pkg.RegistryType, Identifier: pkg.Identifier, Version: pkg.Version})
+	}
+	return p
+}
+
+// Unpinned returns the artifacts of the payload that are referenced by a name
+// that can later point at different content, such as an image tag, so that a
+// signature over the payload does not fix what is installed. Images must be
+// referenced by digest ("@sha256:"), files by their SHA-256 and registry
+// packages by an exact version.
+func (p Payload) Unpinned() []Artifact {
+	var unpinned []Artifact
+	for _, a := range p.Artifacts {
+		if !a.pinned() {
+			unpinned = append(unpinned, a)
+		}
+	}
+	return unpinned
+}
+
+func (a Artifact) pinned() bool {
+	if a.Digest != "" {
+		return true
+	}
+	switch strings.ToLower(a.RegistryType) {
+	case "oci", "docker":
+		return strings.Contains(a.Identifier, "@sha256:")
+	case models.SkillPackageTypeRegistry:
+		// Registry-hosted skill bundles are addressed by their digest.
+		return strings.HasPrefix(a.Identifier, "sha256:")
+	case "mcpb":
+		return false
+	default:
+		return a.Version != "" && a.Version != "latest"
+	}
+}
+
+// String returns the artifact as it is referenced, for messages.
+func (a Artifact) String() string {
+	if a.Version != "" {
+		return a.RegistryType + ":" + a.Identifier + "@" + a.Version
+	}
+	return a.RegistryType + ":" + a.Identifier
+}
+
+// SkillPayload returns the payload of a skill version: its packages, including
+// registry-hosted bundles whose identifier is the bundle digest.
+func SkillPayload(skill *models.SkillJSON) Payload {
+	p := Payload{Kind: KindSkill, Name: skill.Name, Version: skill.Version}
+	for _, pkg := range skill.Packages {
+		p.Artifacts = append(p.Artifacts, Artifact{RegistryType: pkg.RegistryType, Identifier: pkg.Identifier, Version: pkg.Version})
+	}
+	return p
+}
+
+// ServerSignature returns the signature attached to a server version in its
+// publisher-provided metadata, or nil when it has none.
+func ServerSignature(server *apiv0.ServerJSON) (*models.ArtifactSignature, error) {
+	if server == nil || server.Meta == nil || server.Meta.PublisherProvided == nil {
+		return nil, nil
+	}
+	raw, ok := server.Meta.PublisherProvided[models.SignatureMetadataKey]
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", models.SignatureMetadataKey, err)
+	}
+	var sig models.ArtifactSignature
+	if err := json.Unmarshal(data, &sig); err != nil {
+		return nil, fmt.Errorf("invalid %s metadata: %w", models.SignatureMetadataKey, err)
+	}
+	return &sig, nil
+}
+
+// SetServerSignature attaches a signature to the publisher-provided metadata of
+// a server version.
+func SetServerSignature(server *apiv0.ServerJSON, sig *models.ArtifactSignature) {
+	if server.Meta == nil {
+		server.Meta = &apiv0.ServerMeta{}
+	}
+	provided := make(map[string]any, len(server.Meta.PublisherProvided)+1)
+	for k, v := range server.Meta.PublisherProvided {
+		provided[k] = v
+	}
+	provided[models.SignatureMetadataKey] = sig
+	server.Meta.PublisherProvided = provided
+}
+
+// Sign signs a payload with an ECDSA, Ed25519 or RSA key and returns the
+// base64-encoded signature. ECDSA and RSA sign the SHA-256 of the payload.
+func Sign(payload []byte, key crypto.Signer) (string, error) {
+	var (
+		sig []byte
+		err error
+	)
+	switch key.Public().(type) {
+	case ed25519.PublicKey:
+		sig, err = key.Sign(rand.Reader, payload, crypto.Hash(0))
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		digest := sha256.Sum256(payload)
+		sig, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		return "", fmt.Errorf("unsupported signing key type %T", key.Public())
+	}
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// TrustPolicy decides which signatures are trusted.
+type TrustPolicy struct {
+	keys       map[string]crypto.PublicKey
+	roots      *x509.CertPool
+	identities []string
+	// atIssuance checks certificates as of their NotBefore time.
+	atIssuance bool
+}
+
+// NewTrustPolicy creates a policy from trusted public keys by key ID, PEM
+// encoded root certificates for keyless signatures, and the certificate
+// identities (email or URI subject alternative names) to accept. With no
+// identities, any certificate that chains to a root is accepted.
+func NewTrustPolicy(keys map[string]crypto.PublicKey, rootsPEM []byte, identities []string) (*TrustPolicy, error) {
+	p := &TrustPolicy{keys: keys, identities: identities}
+	if len(rootsPEM) > 0 {
+		p.roots = x509.NewCertPool()
+		if !p.roots.AppendCertsFromPEM(rootsPEM) {
+			return nil, fmt.Errorf("no certificates found in signing trust roots")
+		}
+	}
+	if len(p.keys) == 0 && p.roots == nil {
+		return nil, ErrNotConfigured
+	}
+	return p, nil
+}
+
+// AcceptExpiredCertificates makes the policy check keyless certificates as of
+// their NotBefore time instead of the current time. Keyless certificates
+// expire minutes after they are issued, so without it their signatures only
+// verify briefly. There is no transparency log or signed timestamp to prove
+// that a signature was made while its certificate was valid, though, so with
+// it anyone holding the key of an expired certificate can still sign. Only
+// enable it when signing keys are discarded right after signing.
+func (p *TrustPolicy) AcceptExpiredCertificates() {
+	p.atIssuance = true
+}
+
+// LoadTrustPolicy loads the root certificates in rootsFile and the public keys
+// in keysDir, one PEM file per key named <key-id>.pem. Either may be empty;
+// when both are, ErrNotConfigured is returned.
+func LoadTrustPolicy(rootsFile, keysDir string, identities []string) (*TrustPolicy, error) {
+	var rootsPEM []byte
+	if rootsFile = strings.TrimSpace(rootsFile); rootsFile != "" {
+		data, err := os.ReadFile(rootsFile)
+		if err != nil {
+			return nil, fmt.Errorf("read signing trust roots: %w", err)
+		}
+		rootsPEM = data
+	}
+	keys := map[string]crypto.PublicKey{}
+	if keysDir = strings.TrimSpace(keysDir); keysDir != "" {
+		paths, err := filepath.Glob(filepath.Join(keysDir, "*.pem"))
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			data, err := os.ReadFile(p)
+			if err != nil {
+				return nil, fmt.Errorf("read trusted key: %w", err)
+			}
+			key, err := ParsePublicKey(data)
+			if err != nil {
+				return nil, fmt.Errorf("trusted key %s: %w", p, err)
+			}
+			keys[strings.TrimSuffix(filepath.Base(p), ".pem")] = key
+		}
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("no trusted keys (*.pem) found in %s", keysDir)
+		}
+	}
+	return NewTrustPolicy(keys, rootsPEM, identities)
+}
+
+// ParsePublicKey parses a PEM-encoded PKIX public key.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("expected a PEM encoded PUBLIC KEY")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// ParsePrivateKey parses a PEM-encoded PKCS#8, SEC 1 (EC) or PKCS#1 (RSA)
+// private key.
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("expected a PEM encoded private key")
+	}
+	var (
+		key any
+		err error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// Verify checks that sig is a signature over payload by a trusted key or by a
+// certificate that chains to a trusted root, and returns who signed it: the
+// key ID or the certificate identity.
+//
+// Certificates must be valid now, unless AcceptExpiredCertificates was called.
+func (p *TrustPolicy) Verify(payload []byte, sig *models.ArtifactSignature) (string, error) {
+	if sig == nil || strings.TrimSpace(sig.Signature) == "" {
+		return "", ErrUnsigned
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(sig.Signature))
+	if err != nil {
+		return "", fmt.Errorf("signature is not base64 encoded: %w", err)
+	}
+
+	var (
+		key    crypto.PublicKey
+		signer string
+	)
+	switch {
+	case strings.TrimSpace(sig.Certificate) != "":
+		leaf, err := p.verifyCertificate(sig.Certificate)
+		if err != nil {
+			return "", err
+		}
+		key, signer = leaf.PublicKey, certificateIdentity(leaf)
+	case sig.KeyID != "":
+		var ok bool
+		if key, ok = p.keys[sig.KeyID]; !ok {
+			return "", fmt.Errorf("signing key %q is not trusted", sig.KeyID)
+		}
+		signer = sig.KeyID
+	default:
+		return "", fmt.Errorf("signature has neither a certificate nor a key ID")
+	}
+
+	if !verifySignature(key, payload, raw) {
+		return "", fmt.Errorf("signature by %s does not match the signed artifacts", signer)
+	}
+	return signer, nil
+}
+
+func (p *TrustPolicy) verifyCertificate(certPEM string) (*x509.Certificate, error) {
+	if p.roots == nil {
+		return nil, fmt.Errorf("no trust roots are configured for certificate signatures")
+	}
+	var certs []*x509.Certificate
+	rest := []byte(certPEM)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse signing certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("signing certificate is not PEM encoded")
+	}
+
+	leaf := certs[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	var at time.Time
+	if p.atIssuance {
+		at = leaf.NotBefore
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         p.roots,
+		Intermediates: intermediates,
+		CurrentTime:   at,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return nil, fmt.Errorf("signing certificate is not trusted: %w", err)
+	}
+
+	if len(p.identities) > 0 {
+		for _, id := range certificateIdentities(leaf) {
+			if slices.Contains(p.identities, id) {
+				return leaf, nil
+			}
+		}
+		return nil, fmt.Errorf("signing certificate identity %q is not trusted", certificateIdentity(leaf))
+	}
+	return leaf, nil
+}
+
+func certificateIdentities(cert *x509.Certificate) []string {
+	ids := slices.Clone(cert.EmailAddresses)
+	for _, u := range cert.URIs {
+		ids = append(ids, u.String())
+	}
+	return ids
+}
+
+func certificateIdentity(cert *x509.Certificate) string {
+	if ids := certificateIdentities(cert); len(ids) > 0 {
+		return ids[0]
+	}
+	return cert.Subject.String()
+}
+
+func verifySignature(key crypto.PublicKey, payload, sig []byte) bool {
+	digest := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, sig)
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, digest[:], sig)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	default:
+		return false
+	}
+}
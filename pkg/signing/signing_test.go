@@ -0,0 +1,261 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agentregistry-dev/agentregistry/pkg/models"
+	apiv0 "github.com/modelcontextprotocol/registry/pkg/api/v0"
+	"github.com/modelcontextprotocol/registry/pkg/model"
+)
+
+// testCA is a local root that issues short-lived code signing certificates,
+// standing in for a keyless signing CA.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test signing root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a signing key and a certificate for it, valid for ten minutes
+// from notBefore, naming identity as its URI.
+func (ca *testCA) issue(t *testing.T, identity string, notBefore time.Time) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uri, err := url.Parse(identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(10 * time.Minute),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:         []*url.URL{uri},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func testSkill() *models.SkillJSON {
+	return &models.SkillJSON{
+		Name:    "pdf-tools",
+		Version: "1.0.0",
+		Packages: []models.SkillPackageInfo{
+			{RegistryType: models.SkillPackageTypeRegistry, Identifier: "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"},
+			{RegistryType: "docker", Identifier: "ghcr.io/org/pdf-tools:1.0.0"},
+		},
+	}
+}
+
+func TestPayloadBytesIgnoresArtifactOrder(t *testing.T) {
+	skill := testSkill()
+	want := SkillPayload(skill).Bytes()
+
+	skill.Packages[0], skill.Packages[1] = skill.Packages[1], skill.Packages[0]
+	if got := SkillPayload(skill).Bytes(); string(got) != string(want) {
+		t.Errorf("Bytes() = %s, want %s", got, want)
+	}
+
+	skill.Packages[0].Identifier = "ghcr.io/org/pdf-tools:1.0.1"
+	if got := SkillPayload(skill).Bytes(); string(got) == string(want) {
+		t.Error("Bytes() did not change with the artifacts")
+	}
+}
+
+func TestPayloadUnpinned(t *testing.T) {
+	agent := &models.AgentJSON{
+		AgentManifest: models.AgentManifest{
+			Name:  "planner",
+			Image: "ghcr.io/org/planner@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+		},
+		Version: "2.0.0",
+		Packages: []models.AgentPackageInfo{
+			{RegistryType: "pypi", Identifier: "planner", Version: "2.0.0"},
+			{RegistryType: "npm", Identifier: "planner-ui"},
+		},
+	}
+	unpinned := AgentPayload(agent).Unpinned()
+	if len(unpinned) != 1 || unpinned[0].Identifier != "planner-ui" {
+		t.Errorf("Unpinned() = %v, want the npm package without a version", unpinned)
+	}
+
+	unpinned = SkillPayload(testSkill()).Unpinned()
+	if len(unpinned) != 1 || unpinned[0].Identifier != "ghcr.io/org/pdf-tools:1.0.0" {
+		t.Errorf("Unpinned() = %v, want the tagged image", unpinned)
+	}
+
+	server := &apiv0.ServerJSON{
+		Name:    "io.github.org/weather",
+		Version: "1.0.0",
+		Packages: []model.Package{
+			{RegistryType: "mcpb", Identifier: "https://example.com/weather.mcpb", FileSHA256: "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"},
+			{RegistryType: "mcpb", Identifier: "https://example.com/weather-unpinned.mcpb"},
+		},
+	}
+	unpinned = ServerPayload(server).Unpinned()
+	if len(unpinned) != 1 || unpinned[0].Identifier != "https://example.com/weather-unpinned.mcpb" {
+		t.Errorf("Unpinned() = %v, want the file without a SHA-256", unpinned)
+	}
+}
+
+func TestVerifyKeySignature(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keysDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(keysDir, "release.pem"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadTrustPolicy("", keysDir, nil)
+	if err != nil {
+		t.Fatalf("LoadTrustPolicy() error = %v", err)
+	}
+
+	agent := &models.AgentJSON{
+		AgentManifest: models.AgentManifest{Name: "planner", Image: "ghcr.io/org/planner@sha256:abc"},
+		Version:       "2.0.0",
+	}
+	payload := AgentPayload(agent).Bytes()
+	sig, err := Sign(payload, priv)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	signer, err := policy.Verify(payload, &models.ArtifactSignature{Signature: sig, KeyID: "release"})
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if signer != "release" {
+		t.Errorf("Verify() signer = %q, want release", signer)
+	}
+
+	agent.Image = "ghcr.io/attacker/planner:latest"
+	if _, err := policy.Verify(AgentPayload(agent).Bytes(), &models.ArtifactSignature{Signature: sig, KeyID: "release"}); err == nil {
+		t.Error("Verify() accepted a signature over different artifacts")
+	}
+	if _, err := policy.Verify(payload, &models.ArtifactSignature{Signature: sig, KeyID: "unknown"}); err == nil || !strings.Contains(err.Error(), "not trusted") {
+		t.Errorf("Verify() with unknown key error = %v", err)
+	}
+	if _, err := policy.Verify(payload, nil); !errors.Is(err, ErrUnsigned) {
+		t.Errorf("Verify() of unsigned payload error = %v, want ErrUnsigned", err)
+	}
+}
+
+func TestVerifyCertificateSignature(t *testing.T) {
+	ca := newTestCA(t)
+	identity := "https://github.com/org/repo/.github/workflows/release.yml@refs/heads/main"
+	policy, err := NewTrustPolicy(nil, ca.pem, []string{identity})
+	if err != nil {
+		t.Fatalf("NewTrustPolicy() error = %v", err)
+	}
+
+	server := &apiv0.ServerJSON{
+		Name:     "io.github.org/weather",
+		Version:  "1.0.0",
+		Packages: []model.Package{{RegistryType: "npm", Identifier: "@org/weather", Version: "1.0.0"}},
+	}
+	payload := ServerPayload(server).Bytes()
+
+	// Keyless certificates expire minutes after signing; an old one only
+	// verifies when the policy accepts expired certificates.
+	key, cert := ca.issue(t, identity, time.Now().Add(-30*time.Minute))
+	sig, err := Sign(payload, key)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	server.Meta = &apiv0.ServerMeta{PublisherProvided: map[string]any{
+		models.SignatureMetadataKey: map[string]any{"signature": sig, "certificate": cert},
+	}}
+	attached, err := ServerSignature(server)
+	if err != nil {
+		t.Fatalf("ServerSignature() error = %v", err)
+	}
+	if _, err := policy.Verify(payload, attached); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("Verify() with expired certificate error = %v", err)
+	}
+	policy.AcceptExpiredCertificates()
+	signer, err := policy.Verify(payload, attached)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if signer != identity {
+		t.Errorf("Verify() signer = %q, want %q", signer, identity)
+	}
+
+	otherKey, otherCert := ca.issue(t, "https://github.com/someone/else", time.Now())
+	otherSig, err := Sign(payload, otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := policy.Verify(payload, &models.ArtifactSignature{Signature: otherSig, Certificate: otherCert}); err == nil || !strings.Contains(err.Error(), "identity") {
+		t.Errorf("Verify() with untrusted identity error = %v", err)
+	}
+
+	untrusted := newTestCA(t)
+	selfKey, selfCert := untrusted.issue(t, identity, time.Now())
+	selfSig, err := Sign(payload, selfKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := policy.Verify(payload, &models.ArtifactSignature{Signature: selfSig, Certificate: selfCert}); err == nil || !strings.Contains(err.Error(), "not trusted") {
+		t.Errorf("Verify() with certificate from another root error = %v", err)
+	}
+}
+
+func TestLoadTrustPolicyNotConfigured(t *testing.T) {
+	if _, err := LoadTrustPolicy("", "", nil); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("LoadTrustPolicy() error = %v, want ErrNotConfigured", err)
+	}
+}
@@ -0,0 +1,288 @@
+// Package skillbundle packs, inspects and unpacks skill bundles: gzipped
+// tarballs of a skill folder with SKILL.md at the root. The registry stores
+// bundles under the sha256 digest of their bytes, so the CLI and the registry
+// share this package to agree on the format.
+package skillbundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+const (
+	// MaxSize is the largest bundle, in compressed bytes, the registry accepts.
+	MaxSize = 10 << 20
+	// maxUnpackedSize bounds the extracted size of a bundle.
+	maxUnpackedSize = 100 << 20
+
+	// ContentType is the media type bundles are uploaded and served with.
+	ContentType = "application/gzip"
+
+	skillFile = "SKILL.md"
+)
+
+var digestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// Frontmatter is the YAML frontmatter of SKILL.md.
+type Frontmatter struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// ParseFrontmatter parses the YAML frontmatter of SKILL.md content and checks
+// that the required fields are set.
+func ParseFrontmatter(content []byte) (*Frontmatter, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed reading SKILL.md: %w", err)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("SKILL.md is empty")
+	}
+
+	var yamlStart, yamlEnd = -1, -1
+	for i, l := range lines {
+		if strings.TrimSpace(l) == "---" {
+			if yamlStart == -1 {
+				yamlStart = i + 1
+			} else {
+				yamlEnd = i
+				break
+			}
+		}
+	}
+	if yamlStart == -1 || yamlEnd == -1 || yamlEnd <= yamlStart {
+		return nil, fmt.Errorf("SKILL.md missing YAML frontmatter delimited by ---")
+	}
+	yamlContent := strings.Join(lines[yamlStart:yamlEnd], "\n")
+
+	var fm Frontmatter
+	if err := yaml.Unmarshal([]byte(yamlContent), &fm); err != nil {
+		return nil, fmt.Errorf("failed to parse SKILL.md frontmatter: %w", err)
+	}
+
+	if fm.Name == "" {
+		return nil, fmt.Errorf("SKILL.md frontmatter missing required field: name")
+	}
+	if fm.Description == "" {
+		return nil, fmt.Errorf("SKILL.md frontmatter missing required field: description")
+	}
+
+	return &fm, nil
+}
+
+// Digest returns the content address of a bundle, "sha256:<hex>".
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ValidDigest reports whether digest is a well-formed bundle digest.
+func ValidDigest(digest string) bool {
+	return digestPattern.MatchString(digest)
+}
+
+// Verify checks that data hashes to digest.
+func Verify(data []byte, digest string) error {
+	if got := Digest(data); got != digest {
+		return fmt.Errorf("skill bundle digest mismatch: expected %s, got %s", digest, got)
+	}
+	return nil
+}
+
+// Pack builds a bundle from a skill folder. Entries are written in lexical
+// order with fixed timestamps and owners, so packing the same files twice gives
+// the same digest. VCS metadata and anything that is not a regular file or
+// directory is left out.
+func Pack(dir string) ([]byte, error) {
+	if _, err := os.Stat(filepath.Join(dir, skillFile)); err != nil {
+		return nil, fmt.Errorf("skill folder %s has no %s: %w", dir, skillFile, err)
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		hdr := &tar.Header{
+			Name:    filepath.ToSlash(rel),
+			ModTime: time.Unix(0, 0),
+			Format:  tar.FormatPAX,
+		}
+		if d.IsDir() {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Name += "/"
+			hdr.Mode = 0o755
+			return tw.WriteHeader(hdr)
+		}
+		hdr.Typeflag = tar.TypeReg
+		hdr.Mode = 0o644
+		if info.Mode()&0o111 != 0 {
+			hdr.Mode = 0o755
+		}
+		hdr.Size = info.Size()
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pack skill folder %s: %w", dir, err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() > MaxSize {
+		return nil, fmt.Errorf("skill bundle is %d bytes, larger than the %d byte limit", buf.Len(), MaxSize)
+	}
+	return buf.Bytes(), nil
+}
+
+// Inspect validates a bundle and returns the frontmatter of its SKILL.md.
+func Inspect(data []byte) (*Frontmatter, error) {
+	var fm *Frontmatter
+	err := walk(data, func(name string, hdr *tar.Header, r io.Reader) error {
+		if name != skillFile || hdr.Typeflag != tar.TypeReg {
+			return nil
+		}
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		fm, err = ParseFrontmatter(content)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fm == nil {
+		return nil, fmt.Errorf("skill bundle has no %s at its root", skillFile)
+	}
+	return fm, nil
+}
+
+// Unpack extracts a bundle into dir, creating it if needed.
+func Unpack(data []byte, dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create directory %s: %w", dir, err)
+	}
+	return walk(data, func(name string, hdr *tar.Header, r io.Reader) error {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if hdr.Typeflag == tar.TypeDir {
+			return os.MkdirAll(target, 0o755)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		mode := os.FileMode(0o644)
+		if hdr.Mode&0o111 != 0 {
+			mode = 0o755
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(f, r); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	})
+}
+
+// walk calls fn for every file and directory of a bundle with its cleaned,
+// slash-separated path. It rejects entries that would escape the bundle root,
+// links and other special files, and bundles that unpack past the size limit.
+func walk(data []byte, fn func(name string, hdr *tar.Header, r io.Reader) error) error {
+	if len(data) > MaxSize {
+		return fmt.Errorf("skill bundle is %d bytes, larger than the %d byte limit", len(data), MaxSize)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("skill bundle is not a gzipped tarball: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	var total int64
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read skill bundle: %w", err)
+		}
+
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if name == "." {
+			continue
+		}
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("skill bundle entry %q is outside the bundle root", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeReg, tar.TypeDir:
+		default:
+			return fmt.Errorf("skill bundle entry %q is not a regular file or directory", hdr.Name)
+		}
+
+		total += hdr.Size
+		if total > maxUnpackedSize {
+			return fmt.Errorf("skill bundle unpacks to more than %d bytes", maxUnpackedSize)
+		}
+		if err := fn(name, hdr, io.LimitReader(tr, hdr.Size)); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package skillbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSkill(t *testing.T, dir string) {
+	t.Helper()
+	files := map[string]string{
+		"SKILL.md":           "---\nname: my-skill\ndescription: Does things\n---\n# My skill\n",
+		"scripts/run.sh":     "#!/bin/sh\necho hi\n",
+		".git/HEAD":          "ref: refs/heads/main\n",
+		"reference/notes.md": "notes",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPackInspectUnpack(t *testing.T) {
+	src := t.TempDir()
+	writeSkill(t, src)
+
+	data, err := Pack(src)
+	if err != nil {
+		t.Fatalf("Pack() error = %v", err)
+	}
+	again, err := Pack(src)
+	if err != nil {
+		t.Fatalf("Pack() error = %v", err)
+	}
+	if Digest(data) != Digest(again) {
+		t.Errorf("Pack() is not reproducible: %s != %s", Digest(data), Digest(again))
+	}
+	if !ValidDigest(Digest(data)) {
+		t.Errorf("ValidDigest(%q) = false", Digest(data))
+	}
+	if err := Verify(data, Digest(data)); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+	if err := Verify(append(data, 0), Digest(data)); err == nil {
+		t.Error("Verify() of modified bundle succeeded")
+	}
+
+	fm, err := Inspect(data)
+	if err != nil {
+		t.Fatalf("Inspect() error = %v", err)
+	}
+	if fm.Name != "my-skill" || fm.Description != "Does things" {
+		t.Errorf("Inspect() = %+v", fm)
+	}
+
+	dst := t.TempDir()
+	if err := Unpack(data, dst); err != nil {
+		t.Fatalf("Unpack() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dst, "reference", "notes.md"))
+	if err != nil || string(got) != "notes" {
+		t.Errorf("unpacked notes.md = %q, %v", got, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, ".git")); !os.IsNotExist(err) {
+		t.Errorf(".git should not be packed, stat error = %v", err)
+	}
+}
+
+func tarball(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestInspectRejectsInvalidBundles(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{name: "not gzip", data: []byte("plain text"), wantErr: "not a gzipped tarball"},
+		{name: "no SKILL.md", data: tarball(t, map[string]string{"README.md": "hi"}), wantErr: "no SKILL.md"},
+		{name: "nested SKILL.md only", data: tarball(t, map[string]string{"sub/SKILL.md": "---\nname: a\ndescription: b\n---\n"}), wantErr: "no SKILL.md"},
+		{name: "missing description", data: tarball(t, map[string]string{"SKILL.md": "---\nname: a\n---\n"}), wantErr: "missing required field: description"},
+		{name: "path traversal", data: tarball(t, map[string]string{"../evil": "x"}), wantErr: "outside the bundle root"},
+		{name: "absolute path", data: tarball(t, map[string]string{"/etc/passwd": "x"}), wantErr: "outside the bundle root"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Inspect(tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Inspect() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}